@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"mrs/internal/api/dto/request"
+	"mrs/internal/infrastructure/config"
+	applog "mrs/pkg/log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// 片单导入命令行：
+//
+//	go run ./cmd/catalog -file movies.csv -dry-run
+//
+// 导入报告以 JSON 输出到标准输出，存在失败记录时以非零状态码退出
+var (
+	file       = flag.String("file", "", "catalog feed file (.json/.csv)")
+	format     = flag.String("format", "", "catalog format (json|csv), defaults to the file extension")
+	dryRun     = flag.Bool("dry-run", false, "report the changes without writing to the database")
+	configName = flag.String("config", "app.dev", "config file name under ./config")
+)
+
+func main() {
+	flag.Parse()
+	if *file == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		log.Fatalf("Failed to read catalog feed: %v", err)
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(*file)), ".")
+	}
+
+	// 确保日志目录存在
+	if err := os.MkdirAll("./var/log", 0755); err != nil {
+		log.Fatalf("Failed to ensure log directory: %v", err)
+	}
+
+	components, cleanup, err := InitializeCatalog(config.ConfigInput{
+		Path: "config",
+		Name: *configName,
+		Type: "yaml",
+	})
+	if err != nil {
+		log.Fatalf("Failed to initialize catalog import: %v", err)
+	}
+	defer cleanup()
+
+	logger := components.Logger
+	logger.Info("开始导入片单", applog.String("file", *file), applog.String("format", *format), applog.Bool("dry_run", *dryRun))
+
+	report, err := components.MovieService.ImportMovies(context.Background(), &request.ImportMoviesRequest{
+		Format: *format,
+		DryRun: *dryRun,
+		Data:   data,
+	})
+	if err != nil {
+		logger.Error("片单导入失败", applog.Error(err))
+		log.Fatalf("Failed to import catalog: %v", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		log.Fatalf("Failed to write import report: %v", err)
+	}
+
+	logger.Info("片单导入完成", applog.Any("summary", report.Summary))
+	if report.Summary.Failed > 0 {
+		cleanup()
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,41 @@
+//go:build wireinject
+// +build wireinject
+
+package main
+
+import (
+	"mrs/internal/app"
+	"mrs/internal/di"
+	"mrs/internal/infrastructure/config"
+	applog "mrs/pkg/log"
+
+	"github.com/google/wire"
+)
+
+type CatalogComponents struct {
+	MovieService app.MovieService
+	Logger       applog.Logger
+}
+
+func NewCatalogComponents(movieService app.MovieService, logger applog.Logger) *CatalogComponents {
+	return &CatalogComponents{
+		MovieService: movieService,
+		Logger:       logger,
+	}
+}
+
+func InitializeCatalog(input config.ConfigInput) (*CatalogComponents, func(), error) {
+	wire.Build(
+		di.ConfigSet,
+		di.LoggerSet,
+		di.DatabaseSet,
+		di.RedisSet,
+		di.RepositorySet,
+		di.CacheSet,
+		di.PolicySet,
+		app.NewMovieService,
+
+		NewCatalogComponents,
+	)
+	return nil, nil, nil
+}
@@ -74,11 +74,27 @@ func createAdminUser(userRepo user.UserRepository, roleRepo user.RoleRepository,
 func dropExistingTables(db *gorm.DB, logger applog.Logger) error {
 	// 定义需要删除的表名
 	tables := []interface{}{
+		&models.LoyaltyEntryGorm{},
+		&models.FunnelEventGorm{},
+		&models.ReportRunGorm{},
+		&models.ReportSubscriptionGorm{},
+		&models.DailySalesGorm{},
+		&models.GenreTranslationGorm{},
+		&models.MovieTranslationGorm{},
+		&models.WatchlistEntryGorm{},
+		&models.MediaAssetGorm{},
+		&models.MovieCreditGorm{},
+		&models.PersonGorm{},
+		&models.ReviewVoteGorm{},
+		&models.ReviewGorm{},
 		&models.BookedSeatGorm{},
 		&models.BookingGorm{},
 		&models.ShowtimeGorm{},
+		&models.SeatRestrictionGorm{},
+		&models.HallLayoutVersionGorm{},
 		&models.SeatGorm{},
 		&models.CinemaHallGorm{},
+		&models.CinemaGorm{},
 		&models.MovieGorm{},
 		&models.GenreGorm{},
 		&models.UserGorm{},
@@ -133,11 +149,27 @@ func main() {
 		&models.RoleGorm{},
 		&models.MovieGorm{},
 		&models.GenreGorm{},
+		&models.CinemaGorm{},
 		&models.CinemaHallGorm{},
 		&models.SeatGorm{},
+		&models.SeatRestrictionGorm{},
+		&models.HallLayoutVersionGorm{},
 		&models.ShowtimeGorm{},
 		&models.BookingGorm{},
 		&models.BookedSeatGorm{},
+		&models.ReviewGorm{},
+		&models.ReviewVoteGorm{},
+		&models.PersonGorm{},
+		&models.MovieCreditGorm{},
+		&models.MediaAssetGorm{},
+		&models.WatchlistEntryGorm{},
+		&models.MovieTranslationGorm{},
+		&models.GenreTranslationGorm{},
+		&models.DailySalesGorm{},
+		&models.ReportSubscriptionGorm{},
+		&models.ReportRunGorm{},
+		&models.FunnelEventGorm{},
+		&models.LoyaltyEntryGorm{},
 	)
 
 	if err != nil {
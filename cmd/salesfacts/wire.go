@@ -0,0 +1,38 @@
+//go:build wireinject
+// +build wireinject
+
+package main
+
+import (
+	"mrs/internal/app"
+	"mrs/internal/di"
+	"mrs/internal/infrastructure/config"
+	applog "mrs/pkg/log"
+
+	"github.com/google/wire"
+)
+
+type SalesFactsComponents struct {
+	ReportService app.ReportService
+	Logger        applog.Logger
+}
+
+func NewSalesFactsComponents(reportService app.ReportService, logger applog.Logger) *SalesFactsComponents {
+	return &SalesFactsComponents{
+		ReportService: reportService,
+		Logger:        logger,
+	}
+}
+
+func InitializeSalesFacts(input config.ConfigInput) (*SalesFactsComponents, func(), error) {
+	wire.Build(
+		di.ConfigSet,
+		di.LoggerSet,
+		di.DatabaseSet,
+		di.RepositorySet,
+		app.NewReportService,
+
+		NewSalesFactsComponents,
+	)
+	return nil, nil, nil
+}
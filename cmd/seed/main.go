@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
 	"log"
 	"math/rand"
@@ -13,6 +14,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/brianvoe/gofakeit/v6"
@@ -32,10 +34,22 @@ const (
 	// 基础数据量
 	numUsers       = 4000
 	numMovies      = 100
+	numCinemas     = 5
 	numCinemaHalls = 50
 )
 
+// 影厅布局文件目录（.txt 为 ascii 格式，.json/.yaml 为结构化格式），为空时随机生成布局
+var layoutDir = flag.String("layouts", "", "directory of cinema hall layout files (.txt/.json/.yaml)")
+
+// 影厅布局模板：布局及其座位
+type hallTemplate struct {
+	layout *cinema.HallLayout
+	seats  []*cinema.Seat
+}
+
 func main() {
+	flag.Parse()
+
 	// 确保日志目录存在
 	if err := os.MkdirAll("./var/log", 0755); err != nil {
 		log.Fatalf("Failed to ensure log directory: %v", err)
@@ -59,9 +73,16 @@ func main() {
 	// 设置随机种子
 	gofakeit.Seed(time.Now().UnixNano())
 
+	// 读取影厅布局文件（在写入任何数据之前完成校验）
+	templates, err := loadHallTemplates(*layoutDir)
+	if err != nil {
+		logger.Error("影厅布局文件不合法", applog.Error(err))
+		log.Fatalf("Failed to load hall layouts: %v", err)
+	}
+
 	// 开始填充数据
 	startTime := time.Now()
-	if err := seedData(db, logger); err != nil {
+	if err := seedData(db, logger, templates); err != nil {
 		logger.Error("数据填充失败", applog.Error(err))
 		log.Fatalf("Failed to seed data: %v", err)
 	}
@@ -77,7 +98,7 @@ func main() {
 	)
 }
 
-func seedData(db *gorm.DB, logger applog.Logger) error {
+func seedData(db *gorm.DB, logger applog.Logger, templates []*hallTemplate) error {
 	// 1. 获取预定义的角色
 	logger.Info("开始获取角色数据")
 	var roles []models.RoleGorm
@@ -111,23 +132,37 @@ func seedData(db *gorm.DB, logger applog.Logger) error {
 	}
 	logger.Info("电影数据创建完成", applog.Int("实际创建数量", len(movies)))
 
-	// 5. 创建影厅
+	// 5. 创建影院
+	logger.Info("开始创建影院数据", applog.Int("计划创建数量", numCinemas))
+	cinemas := createCinemas()
+	if err := db.Create(&cinemas).Error; err != nil {
+		return fmt.Errorf("failed to create cinemas: %v", err)
+	}
+	logger.Info("影院数据创建完成", applog.Int("实际创建数量", len(cinemas)))
+
+	// 6. 创建影厅
 	logger.Info("开始创建影厅数据", applog.Int("计划创建数量", numCinemaHalls))
-	halls := createCinemaHalls()
+	halls, hallTemplates := createCinemaHalls(cinemas, templates)
 	if err := db.Create(&halls).Error; err != nil {
 		return fmt.Errorf("failed to create cinema halls: %v", err)
 	}
 	logger.Info("影厅数据创建完成", applog.Int("实际创建数量", len(halls)))
 
-	// 6. 创建座位
+	// 7. 创建座位
 	logger.Info("开始创建座位数据")
-	seats := createSeats(halls)
+	seats := createSeats(halls, hallTemplates)
 	if err := db.Create(&seats).Error; err != nil {
 		return fmt.Errorf("failed to create seats: %v", err)
 	}
 	logger.Info("座位数据创建完成", applog.Int("数量", len(seats)))
 
-	// 7. 创建场次
+	// 记录影厅的初始布局版本
+	layoutVersions := createLayoutVersions(halls, hallTemplates)
+	if err := db.Create(&layoutVersions).Error; err != nil {
+		return fmt.Errorf("failed to create hall layout versions: %v", err)
+	}
+
+	// 8. 创建场次
 	logger.Info("开始创建场次数据")
 	showtimes := createShowtimes(movies, halls)
 	if err := db.Create(&showtimes).Error; err != nil {
@@ -259,41 +294,133 @@ func createMovies(genres []models.GenreGorm) []models.MovieGorm {
 	return movies
 }
 
-func createCinemaHalls() []models.CinemaHallGorm {
+func createCinemas() []models.CinemaGorm {
+	cinemas := make([]models.CinemaGorm, 0, numCinemas)
+	for i := 0; i < numCinemas; i++ {
+		// 每天 09:00 营业至次日 02:00
+		openingHours := make([]*cinema.OpeningHours, 0, 7)
+		for d := time.Sunday; d <= time.Saturday; d++ {
+			openingHours = append(openingHours, &cinema.OpeningHours{Weekday: d, OpenTime: "09:00", CloseTime: "02:00"})
+		}
+		cinemas = append(cinemas, models.CinemaGorm{
+			Name:         fmt.Sprintf("影城-%d", i+1),
+			Address:      gofakeit.Street(),
+			City:         gofakeit.City(),
+			Phone:        gofakeit.Phone(),
+			Timezone:     cinema.DefaultCinemaTimezone,
+			OpeningHours: openingHours,
+		})
+	}
+	return cinemas
+}
+
+// 读取布局目录下的全部布局文件，目录为空时返回nil
+func loadHallTemplates(dir string) ([]*hallTemplate, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read layout dir: %v", err)
+	}
+	templates := make([]*hallTemplate, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		format, err := cinema.ParseLayoutFormat(strings.TrimPrefix(filepath.Ext(entry.Name()), "."))
+		if err != nil {
+			continue // 忽略非布局文件
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read layout file %s: %v", entry.Name(), err)
+		}
+		layout, seats, err := cinema.DecodeLayout(format, data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+		templates = append(templates, &hallTemplate{layout: layout, seats: seats})
+	}
+	if len(templates) == 0 {
+		return nil, fmt.Errorf("no layout files found in %s", dir)
+	}
+	return templates, nil
+}
+
+// 创建影厅：提供了布局模板时按顺序循环使用模板，否则随机生成布局
+func createCinemaHalls(cinemas []models.CinemaGorm, templates []*hallTemplate) ([]models.CinemaHallGorm, []*hallTemplate) {
 	halls := make([]models.CinemaHallGorm, 0, numCinemaHalls)
+	hallTemplates := make([]*hallTemplate, 0, numCinemaHalls)
 	for i := 0; i < numCinemaHalls; i++ {
-		rowCount := gofakeit.Number(5, 15)
-		colCount := gofakeit.Number(8, 20)
+		var tpl *hallTemplate
+		if len(templates) > 0 {
+			tpl = templates[i%len(templates)]
+		} else {
+			layout := createHallLayout(gofakeit.Number(5, 15), gofakeit.Number(8, 20))
+			tpl = &hallTemplate{layout: layout, seats: layout.GenerateSeats(0)}
+		}
+		// 影厅按顺序均匀分配到各个影院
+		cinemaID := cinemas[i%len(cinemas)].ID
 		hall := models.CinemaHallGorm{
+			CinemaID:    &cinemaID,
 			Name:        fmt.Sprintf("放映厅-%d", i+1),
 			ScreenType:  []string{"2D", "3D", "IMAX"}[rand.Intn(3)],
 			SoundSystem: []string{"Dolby", "DTS", "SDDS"}[rand.Intn(3)],
-			RowCount:    rowCount,
-			ColCount:    colCount,
+			RowCount:    tpl.layout.Rows,
+			ColCount:    tpl.layout.Cols,
+			Layout:      tpl.layout,
 		}
 		halls = append(halls, hall)
+		hallTemplates = append(hallTemplates, tpl)
+	}
+	return halls, hallTemplates
+}
+
+// 生成带两条纵向过道的影厅布局：座位被分为左、中、右三个分区，部分影厅为弧形排
+func createHallLayout(rows, seatCols int) *cinema.HallLayout {
+	side := seatCols / 4
+	layout := cinema.NewGridLayout(rows, seatCols+2)
+	layout.Elements = []*cinema.LayoutElement{
+		{Type: cinema.LayoutElementAisle, Row: 0, Col: side, RowSpan: rows, ColSpan: 1},
+		{Type: cinema.LayoutElementAisle, Row: 0, Col: layout.Cols - side - 1, RowSpan: rows, ColSpan: 1},
+	}
+	layout.Blocks = []*cinema.SeatBlock{
+		{Name: "L", StartRow: 0, EndRow: rows - 1, StartCol: 0, EndCol: side - 1},
+		{Name: "C", StartRow: 0, EndRow: rows - 1, StartCol: side + 1, EndCol: layout.Cols - side - 2},
+		{Name: "R", StartRow: 0, EndRow: rows - 1, StartCol: layout.Cols - side, EndCol: layout.Cols - 1},
+	}
+	if rand.Intn(2) == 0 {
+		layout.Curvature = 1
 	}
-	return halls
+	return layout
 }
 
-func createSeats(halls []models.CinemaHallGorm) []models.SeatGorm {
+func createSeats(halls []models.CinemaHallGorm, hallTemplates []*hallTemplate) []models.SeatGorm {
 	var seats []models.SeatGorm
-	for _, hall := range halls {
-		for row := 1; row <= hall.RowCount; row++ {
-			for col := 1; col <= hall.ColCount; col++ {
-				seat := models.SeatGorm{
-					CinemaHallID:  hall.ID,
-					RowIdentifier: string(rune('A' - 1 + row)),
-					SeatNumber:    fmt.Sprintf("%d", col),
-					Type:          cinema.SeatTypeStandard,
-				}
-				seats = append(seats, seat)
-			}
+	for i, hall := range halls {
+		for _, seat := range hallTemplates[i].seats {
+			seatGorm := models.SeatGormFromDomain(seat)
+			seatGorm.CinemaHallID = hall.ID
+			seats = append(seats, *seatGorm)
 		}
 	}
 	return seats
 }
 
+func createLayoutVersions(halls []models.CinemaHallGorm, hallTemplates []*hallTemplate) []models.HallLayoutVersionGorm {
+	versions := make([]models.HallLayoutVersionGorm, len(halls))
+	for i, hall := range halls {
+		versions[i] = models.HallLayoutVersionGorm{
+			CinemaHallID: hall.ID,
+			Version:      cinema.InitialLayoutVersion,
+			Layout:       hall.Layout,
+			SeatCount:    len(hallTemplates[i].seats),
+		}
+	}
+	return versions
+}
+
 func createShowtimes(movies []models.MovieGorm, halls []models.CinemaHallGorm) []models.ShowtimeGorm {
 	var showtimes []models.ShowtimeGorm
 
@@ -341,6 +468,10 @@ func createShowtimes(movies []models.MovieGorm, halls []models.CinemaHallGorm) [
 					StartTime:    slotStartTime,
 					EndTime:      slotStartTime.Add(time.Duration(movieDuration) * time.Minute),
 					Price:        float64(gofakeit.Number(3000, 8000)) / 100, // 30-80元
+					// 随机放映格式与语言版本
+					Format:           gofakeit.RandomString([]string{"2D", "3D", "IMAX", "IMAX_3D"}),
+					AudioLanguage:    gofakeit.RandomString([]string{"zh", "en"}),
+					SubtitleLanguage: gofakeit.RandomString([]string{"", "zh", "en"}),
 				}
 
 				showtimes = append(showtimes, showtime)
@@ -3,6 +3,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	config "mrs/internal/infrastructure/config"
@@ -20,7 +21,7 @@ func main() {
 
 	// 调用 Wire 生成的 Injector 函数
 	// 所有依赖注入的细节全部被隐藏
-	engine, cleanup, err := InitializeServer(config.ConfigInput{
+	server, cleanup, err := InitializeServer(config.ConfigInput{
 		Path: "config",
 		Name: "app.dev",
 		Type: "yaml",
@@ -38,8 +39,12 @@ func main() {
 	repository_circuitbreaker.ConfigMovieRepositoryBreakers()
 	repository_circuitbreaker.ConfigShowtimeRepositoryBreakers()
 
+	// 启动后台定时任务（电影生命周期状态推进等）
+	server.Scheduler.Start(context.Background())
+	defer server.Scheduler.Stop()
+
 	fmt.Println("Starting server on port " + port)
-	if err := engine.Run(":" + port); err != nil {
+	if err := server.Engine.Run(":" + port); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
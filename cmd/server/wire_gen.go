@@ -7,24 +7,27 @@
 package main
 
 import (
-	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 	"mrs/internal/api/handlers"
 	"mrs/internal/api/middleware"
 	"mrs/internal/api/routers"
 	"mrs/internal/app"
+	"mrs/internal/di"
 	"mrs/internal/infrastructure/cache"
 	"mrs/internal/infrastructure/config"
+	"mrs/internal/infrastructure/delivery"
+	"mrs/internal/infrastructure/notification"
 	"mrs/internal/infrastructure/persistence/decorators"
 	"mrs/internal/infrastructure/persistence/mysql/repository"
+	"mrs/internal/infrastructure/storage"
 	"mrs/internal/utils"
 	"mrs/pkg/log"
 )
 
 // Injectors from wire.go:
 
-// 我们将 gin.Engine 定义为 Server，因为它是我们最终要运行的东西
-func InitializeServer(input config.ConfigInput) (*gin.Engine, func(), error) {
+// Server 包含我们最终要运行的 gin.Engine 与后台定时任务
+func InitializeServer(input config.ConfigInput) (*di.Server, func(), error) {
 	configConfig, err := config.LoadConfig(input)
 	if err != nil {
 		return nil, nil, err
@@ -69,29 +72,93 @@ func InitializeServer(input config.ConfigInput) (*gin.Engine, func(), error) {
 	movieRepository := decorators.NewMovieRepository(db, logger)
 	genreRepository := repository.NewGormGenreRepository(db, logger)
 	movieCache := cache.NewRedisMovieCache(client, logger)
-	movieService := app.NewMovieService(unitOfWork, movieRepository, genreRepository, movieCache, logger)
+	movieSearchIndex := repository.NewGormMovieSearchIndex(db, logger)
+	ageRatingConfig := configConfig.AgeRatingConfig
+	ageRatingPolicy, err := config.NewAgeRatingPolicy(ageRatingConfig)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	movieService := app.NewMovieService(unitOfWork, movieRepository, genreRepository, movieCache, movieSearchIndex, ageRatingPolicy, logger)
 	movieHandler := handlers.NewMovieHandler(movieService, logger)
+	cinemaRepository := repository.NewGormCinemaRepository(db, logger)
 	cinemaHallRepository := repository.NewGormCinemaHallRepository(db, logger)
 	seatRepository := repository.NewGormSeatRepository(db, logger)
+	seatRestrictionRepository := repository.NewGormSeatRestrictionRepository(db, logger)
+	hallLayoutVersionRepository := repository.NewGormHallLayoutVersionRepository(db, logger)
 	cinemaHallCache := cache.NewCinemaHallCache(client, logger)
-	cinemaService := app.NewCinemaService(unitOfWork, cinemaHallRepository, seatRepository, cinemaHallCache, logger)
+	seatCache := cache.NewRedisSeatCache(client, logger)
+	cinemaService := app.NewCinemaService(unitOfWork, cinemaRepository, cinemaHallRepository, seatRepository, seatRestrictionRepository, hallLayoutVersionRepository, cinemaHallCache, seatCache, logger)
 	cinemaHandler := handlers.NewCinemaHandler(cinemaService, logger)
 	showtimeRepository := decorators.NewShowtimeRepository(db, logger)
 	bookingRepository := repository.NewGormBookingRepository(db, logger)
 	showtimeCache := cache.NewRedisShowtimeCache(client, logger)
-	seatCache := cache.NewRedisSeatCache(client, logger)
 	lockProvider := cache.NewRedisLockProvider(client, logger)
-	showtimeService := app.NewShowtimeService(unitOfWork, showtimeRepository, seatRepository, bookingRepository, showtimeCache, seatCache, lockProvider, logger)
+	notifier := notification.NewLogNotifier(logger)
+	trendingCache := cache.NewRedisTrendingCache(client, logger)
+	funnelEventRepository := repository.NewGormFunnelEventRepository(db, logger)
+	showtimeService := app.NewShowtimeService(unitOfWork, showtimeRepository, seatRepository, bookingRepository, showtimeCache, seatCache, lockProvider, notifier, trendingCache, funnelEventRepository, logger)
 	showtimeHandler := handlers.NewShowtimeHandler(showtimeService, logger)
-	bookingService := app.NewBookingService(unitOfWork, bookingRepository, showtimeRepository, seatCache, showtimeCache, showtimeService, lockProvider, logger)
+	loyaltyConfig := configConfig.LoyaltyConfig
+	program, err := config.NewLoyaltyProgram(loyaltyConfig)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	ledgerRepository := repository.NewGormLoyaltyLedgerRepository(db, logger)
+	bookingService := app.NewBookingService(unitOfWork, bookingRepository, showtimeRepository, seatCache, showtimeCache, showtimeService, lockProvider, userRepository, ageRatingPolicy, trendingCache, funnelEventRepository, ledgerRepository, program, logger)
 	bookingHandler := handlers.NewBookingHandler(bookingService, logger)
-	reportService := app.NewReportService(logger, bookingRepository)
+	dailySalesRepository := repository.NewGormDailySalesRepository(db, logger)
+	reportService := app.NewReportService(logger, unitOfWork, bookingRepository, dailySalesRepository, funnelEventRepository)
 	reportHandler := handlers.NewReportHandler(reportService, logger)
+	reviewRepository := repository.NewGormReviewRepository(db, logger)
+	reviewService := app.NewReviewService(unitOfWork, reviewRepository, bookingRepository, movieRepository, movieCache, lockProvider, logger)
+	reviewHandler := handlers.NewReviewHandler(reviewService, logger)
+	personRepository := repository.NewGormPersonRepository(db, logger)
+	personService := app.NewPersonService(unitOfWork, personRepository, logger)
+	personHandler := handlers.NewPersonHandler(personService, logger)
+	storageConfig := configConfig.StorageConfig
+	blobStore, err := storage.NewBlobStore(storageConfig, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	mediaPolicy := storage.NewMediaPolicy(storageConfig)
+	mediaService := app.NewMediaService(unitOfWork, blobStore, mediaPolicy, movieCache, logger)
+	mediaHandler := handlers.NewMediaHandler(mediaService, mediaPolicy, logger)
+	watchlistRepository := repository.NewGormWatchlistRepository(db, logger)
+	watchlistService := app.NewWatchlistService(unitOfWork, watchlistRepository, showtimeRepository, notifier, logger)
+	watchlistHandler := handlers.NewWatchlistHandler(watchlistService, logger)
+	recommendationRepository := repository.NewGormRecommendationRepository(db, logger)
+	recommendationCache := cache.NewRedisRecommendationCache(client, logger)
+	recommendationService := app.NewRecommendationService(recommendationRepository, movieRepository, recommendationCache, logger)
+	recommendationHandler := handlers.NewRecommendationHandler(recommendationService, logger)
+	reportSubscriptionRepository := repository.NewGormReportSubscriptionRepository(db, logger)
+	runRepository := repository.NewGormReportRunRepository(db, logger)
+	reportDeliveryConfig := configConfig.ReportDeliveryConfig
+	deliverers := delivery.NewDeliverers(reportDeliveryConfig, logger)
+	reportSubscriptionService := app.NewReportSubscriptionService(reportSubscriptionRepository, runRepository, reportService, deliverers, logger)
+	reportSubscriptionHandler := handlers.NewReportSubscriptionHandler(reportSubscriptionService, logger)
+	trendingRepository := repository.NewGormTrendingRepository(db, logger)
+	trendingService := app.NewTrendingService(trendingRepository, trendingCache, movieRepository, showtimeRepository, logger)
+	trendingHandler := handlers.NewTrendingHandler(trendingService, logger)
 	auth := middleware.AuthMiddleware(jwtManager, logger)
 	admin := middleware.AdminMiddleware(jwtManager, logger)
 	middlewareLogger := middleware.LoggerMiddleware(logger)
-	engine := routers.SetupRouter(healthHandler, authHandler, userHandler, movieHandler, cinemaHandler, showtimeHandler, bookingHandler, reportHandler, auth, admin, middlewareLogger)
-	return engine, func() {
+	locale := middleware.LocaleMiddleware()
+	loyaltyService := app.NewLoyaltyService(ledgerRepository, program, logger)
+	loyaltyHandler := handlers.NewLoyaltyHandler(loyaltyService, logger)
+	engine := routers.SetupRouter(healthHandler, authHandler, userHandler, movieHandler, cinemaHandler, showtimeHandler, bookingHandler, reportHandler, reviewHandler, personHandler, mediaHandler, watchlistHandler, recommendationHandler, reportSubscriptionHandler, trendingHandler, loyaltyHandler, auth, admin, middlewareLogger, locale)
+	schedulerConfig := configConfig.SchedulerConfig
+	schedulerScheduler := di.NewScheduler(schedulerConfig, movieService, watchlistService, recommendationService, reportSubscriptionService, trendingService, showtimeService, logger)
+	server := di.NewServer(engine, schedulerScheduler)
+	return server, func() {
 		cleanup3()
 		cleanup2()
 		cleanup()
@@ -12,11 +12,12 @@ require (
 	github.com/stretchr/testify v1.10.0
 	go.uber.org/zap v1.27.0
 	golang.org/x/crypto v0.38.0
+	gopkg.in/yaml.v3 v3.0.1
 	gorm.io/driver/mysql v1.5.7
 	gorm.io/gorm v1.30.0
 )
 
-require github.com/afex/hystrix-go v0.0.0-20180502004556-fa1af6a1f4f5 // indirect
+require github.com/afex/hystrix-go v0.0.0-20180502004556-fa1af6a1f4f5
 
 require (
 	filippo.io/edwards25519 v1.1.0 // indirect
@@ -60,5 +61,4 @@ require (
 	golang.org/x/sys v0.33.0 // indirect
 	golang.org/x/text v0.25.0 // indirect
 	google.golang.org/protobuf v1.36.6 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -7,9 +7,10 @@ import (
 
 // 创建订单请求
 type CreateBookingRequest struct {
-	UserID     uint
-	ShowtimeID uint   `json:"showtime_id"`
-	SeatIDs    []uint `json:"seat_ids"`
+	UserID       uint
+	ShowtimeID   uint   `json:"showtime_id"`
+	SeatIDs      []uint `json:"seat_ids"`
+	RedeemPoints int    `json:"redeem_points" binding:"omitempty,min=0"` // 抵扣使用的积分，默认不抵扣
 }
 
 type GetBookingRequest struct {
@@ -41,3 +42,8 @@ type CancelBookingRequest struct {
 type ConfirmBookingRequest struct {
 	ID uint
 }
+
+// 检票信息请求
+type GetCheckInRequest struct {
+	ID uint
+}
@@ -3,14 +3,100 @@ package request
 import (
 	"mrs/internal/domain/cinema"
 	"mrs/internal/domain/shared/vo"
+	"time"
 )
 
+// 营业时间
+type OpeningHoursRequest struct {
+	Weekday   int    `json:"weekday" binding:"min=0,max=6"` // 0 表示星期日
+	OpenTime  string `json:"open_time" binding:"required,datetime=15:04"`
+	CloseTime string `json:"close_time" binding:"required,datetime=15:04"`
+}
+
+func (r *OpeningHoursRequest) ToDomain() *cinema.OpeningHours {
+	return &cinema.OpeningHours{
+		Weekday:   time.Weekday(r.Weekday),
+		OpenTime:  r.OpenTime,
+		CloseTime: r.CloseTime,
+	}
+}
+
+func openingHoursToDomain(reqs []*OpeningHoursRequest) []*cinema.OpeningHours {
+	if reqs == nil {
+		return nil
+	}
+	hours := make([]*cinema.OpeningHours, len(reqs))
+	for i, oh := range reqs {
+		hours[i] = oh.ToDomain()
+	}
+	return hours
+}
+
+// 创建影院
+type CreateCinemaRequest struct {
+	Name         string                 `json:"name" binding:"required,min=1,max=100"`
+	Address      string                 `json:"address" binding:"omitempty,max=255"`
+	City         string                 `json:"city" binding:"omitempty,max=50"`
+	Phone        string                 `json:"phone" binding:"omitempty,max=30"`
+	Timezone     string                 `json:"timezone" binding:"omitempty,max=64"` // IANA 时区，默认 Asia/Shanghai
+	OpeningHours []*OpeningHoursRequest `json:"opening_hours" binding:"omitempty,dive"`
+}
+
+func (r *CreateCinemaRequest) ToDomain() *cinema.Cinema {
+	timezone := r.Timezone
+	if timezone == "" {
+		timezone = cinema.DefaultCinemaTimezone
+	}
+	return &cinema.Cinema{
+		Name:         r.Name,
+		Address:      r.Address,
+		City:         r.City,
+		Phone:        r.Phone,
+		Timezone:     timezone,
+		OpeningHours: openingHoursToDomain(r.OpeningHours),
+	}
+}
+
+type GetCinemaRequest struct {
+	ID uint
+}
+
+// 更新影院（opening_hours 传入时整体替换）
+type UpdateCinemaRequest struct {
+	ID           uint
+	Name         string                 `json:"name" binding:"omitempty,min=1,max=100"`
+	Address      string                 `json:"address" binding:"omitempty,max=255"`
+	City         string                 `json:"city" binding:"omitempty,max=50"`
+	Phone        string                 `json:"phone" binding:"omitempty,max=30"`
+	Timezone     string                 `json:"timezone" binding:"omitempty,max=64"`
+	OpeningHours []*OpeningHoursRequest `json:"opening_hours" binding:"omitempty,dive"`
+}
+
+func (r *UpdateCinemaRequest) ToDomain() *cinema.Cinema {
+	return &cinema.Cinema{
+		ID:           vo.CinemaID(r.ID),
+		Name:         r.Name,
+		Address:      r.Address,
+		City:         r.City,
+		Phone:        r.Phone,
+		Timezone:     r.Timezone,
+		OpeningHours: openingHoursToDomain(r.OpeningHours),
+	}
+}
+
+type DeleteCinemaRequest struct {
+	ID uint
+}
+
 // 创建影厅
 type CreateCinemaHallRequest struct {
+	CinemaID    uint           `json:"cinema_id" binding:"omitempty,min=1"` // 所属影院ID
 	Name        string         `json:"name" binding:"required,min=1,max=255"`
 	ScreenType  string         `json:"screen_type" binding:"required,min=1,max=255"`
 	SoundSystem string         `json:"sound_system" binding:"required,min=1,max=255"`
-	Seats       []*SeatRequest `json:"seats" binding:"omitempty"` // 影厅座位，如果为空，则自动生成默认座位布局
+	Seats       []*SeatRequest `json:"seats" binding:"omitempty"` // 影厅座位，如果为空，则按布局（或默认布局）自动生成
+	// 影厅几何布局，如果为空，则根据座位推断（座位也为空时使用默认布局）
+	Layout *HallLayoutRequest `json:"layout" binding:"omitempty"`
 }
 
 func (r *CreateCinemaHallRequest) ToDomain() *cinema.CinemaHall {
@@ -22,21 +108,90 @@ func (r *CreateCinemaHallRequest) ToDomain() *cinema.CinemaHall {
 		}
 	}
 	return &cinema.CinemaHall{
+		CinemaID:    vo.CinemaID(r.CinemaID),
 		Name:        r.Name,
 		ScreenType:  r.ScreenType,
 		SoundSystem: r.SoundSystem,
 		Seats:       seats,
+		Layout:      r.Layout.ToDomain(),
+	}
+}
+
+// 影厅布局
+type HallLayoutRequest struct {
+	Rows      int                     `json:"rows" binding:"required,min=1,max=100"`
+	Cols      int                     `json:"cols" binding:"required,min=1,max=100"`
+	Screen    string                  `json:"screen" binding:"omitempty,oneof=top bottom"` // 默认 top
+	Curvature float64                 `json:"curvature" binding:"omitempty,min=0"`
+	Elements  []*LayoutElementRequest `json:"elements" binding:"omitempty,dive"`
+	Blocks    []*SeatBlockRequest     `json:"blocks" binding:"omitempty,dive"`
+}
+
+func (r *HallLayoutRequest) ToDomain() *cinema.HallLayout {
+	if r == nil {
+		return nil
+	}
+	layout := cinema.NewGridLayout(r.Rows, r.Cols)
+	if r.Screen != "" {
+		layout.Screen = cinema.ScreenPosition(r.Screen)
 	}
+	layout.Curvature = r.Curvature
+	for _, e := range r.Elements {
+		layout.Elements = append(layout.Elements, &cinema.LayoutElement{
+			Type:    cinema.LayoutElementType(e.Type),
+			Row:     e.Row,
+			Col:     e.Col,
+			RowSpan: e.RowSpan,
+			ColSpan: e.ColSpan,
+		})
+	}
+	for _, b := range r.Blocks {
+		layout.Blocks = append(layout.Blocks, &cinema.SeatBlock{
+			Name:     b.Name,
+			StartRow: b.StartRow,
+			EndRow:   b.EndRow,
+			StartCol: b.StartCol,
+			EndCol:   b.EndCol,
+		})
+	}
+	return layout
+}
+
+// 布局元素（过道、空位、台阶）
+type LayoutElementRequest struct {
+	Type    string `json:"type" binding:"required,oneof=aisle gap stair"`
+	Row     int    `json:"row" binding:"min=0"`
+	Col     int    `json:"col" binding:"min=0"`
+	RowSpan int    `json:"row_span" binding:"omitempty,min=1"` // 默认 1
+	ColSpan int    `json:"col_span" binding:"omitempty,min=1"` // 默认 1
+}
+
+// 座位分区
+type SeatBlockRequest struct {
+	Name     string `json:"name" binding:"required,min=1,max=50"`
+	StartRow int    `json:"start_row" binding:"min=0"`
+	EndRow   int    `json:"end_row" binding:"min=0"`
+	StartCol int    `json:"start_col" binding:"min=0"`
+	EndCol   int    `json:"end_col" binding:"min=0"`
 }
 
 type GetCinemaHallRequest struct {
 	ID uint
 }
 
+// 获取影厅列表
+type ListCinemaHallsRequest struct {
+	CinemaID uint `json:"cinema_id" form:"cinema_id" binding:"omitempty,min=1"` // 按影院筛选
+}
+
 type SeatRequest struct {
 	RowIdentifier string `json:"row_identifier" binding:"required,min=1,max=255"`
 	SeatNumber    string `json:"seat_number" binding:"required,min=1,max=255"`
 	Type          string `json:"type" binding:"omitempty,min=1,max=255"`
+	// 布局网格坐标，未提供布局时忽略并按排号/座位号推断
+	GridRow int    `json:"grid_row" binding:"min=0"`
+	GridCol int    `json:"grid_col" binding:"min=0"`
+	Block   string `json:"block" binding:"omitempty,max=50"`
 }
 
 func (r *SeatRequest) ToDomain() *cinema.Seat {
@@ -44,6 +199,9 @@ func (r *SeatRequest) ToDomain() *cinema.Seat {
 		RowIdentifier: r.RowIdentifier,
 		SeatNumber:    r.SeatNumber,
 		Type:          cinema.SeatType(r.Type),
+		GridRow:       r.GridRow,
+		GridCol:       r.GridCol,
+		Block:         r.Block,
 	}
 }
 
@@ -63,6 +221,7 @@ func (r *CreateHallSeatsRequest) ToDomain() []*cinema.Seat {
 
 type UpdateCinemaHallRequest struct {
 	ID          uint
+	CinemaID    uint   `json:"cinema_id" binding:"omitempty,min=1"` // 调整所属影院
 	Name        string `json:"name" binding:"omitempty,min=1,max=255"`
 	ScreenType  string `json:"screen_type" binding:"omitempty,min=1,max=255"`
 	SoundSystem string `json:"sound_system" binding:"omitempty,min=1,max=255"`
@@ -71,6 +230,7 @@ type UpdateCinemaHallRequest struct {
 func (r *UpdateCinemaHallRequest) ToDomain() *cinema.CinemaHall {
 	return &cinema.CinemaHall{
 		ID:          vo.CinemaHallID(r.ID),
+		CinemaID:    vo.CinemaID(r.CinemaID),
 		Name:        r.Name,
 		ScreenType:  r.ScreenType,
 		SoundSystem: r.SoundSystem,
@@ -80,3 +240,83 @@ func (r *UpdateCinemaHallRequest) ToDomain() *cinema.CinemaHall {
 type DeleteCinemaHallRequest struct {
 	ID uint
 }
+
+// 导入影厅布局（请求体为布局文件原文），替换影厅的布局与全部座位
+type ImportCinemaHallLayoutRequest struct {
+	ID     uint
+	Format string `form:"format" binding:"omitempty,oneof=ascii json yaml"` // 默认 ascii
+	DryRun bool   `form:"dry_run"`                                          // 只校验并返回解析结果，不落库
+	Data   []byte
+}
+
+// 导出影厅布局
+type ExportCinemaHallLayoutRequest struct {
+	ID      uint
+	Format  string `form:"format" binding:"omitempty,oneof=ascii json yaml"` // 默认 ascii
+	Version int    `form:"version" binding:"omitempty,min=1"`                // 布局版本，默认当前版本
+}
+
+// 查询影厅布局版本
+type ListLayoutVersionsRequest struct {
+	ID uint
+}
+
+// 创建座位限制（为多个座位创建相同的限制）
+type CreateSeatRestrictionsRequest struct {
+	CinemaHallID uint
+	SeatIDs      []uint     `json:"seat_ids" binding:"required,min=1,dive,min=1"`
+	Kind         string     `json:"kind" binding:"required,oneof=OUT_OF_SERVICE HOUSE_SEAT BLOCKED"`
+	Reason       string     `json:"reason" binding:"omitempty,max=255"`
+	StartsAt     *time.Time `json:"starts_at" binding:"omitempty"` // 为空表示立即生效
+	EndsAt       *time.Time `json:"ends_at" binding:"omitempty"`   // 为空表示长期有效
+}
+
+func (r *CreateSeatRestrictionsRequest) ToDomain() []*cinema.SeatRestriction {
+	restrictions := make([]*cinema.SeatRestriction, len(r.SeatIDs))
+	for i, seatID := range r.SeatIDs {
+		restrictions[i] = &cinema.SeatRestriction{
+			SeatID:       vo.SeatID(seatID),
+			CinemaHallID: vo.CinemaHallID(r.CinemaHallID),
+			Kind:         cinema.SeatRestrictionKind(r.Kind),
+			Reason:       r.Reason,
+			StartsAt:     r.StartsAt,
+			EndsAt:       r.EndsAt,
+		}
+	}
+	return restrictions
+}
+
+// 查询影厅的座位限制
+type ListSeatRestrictionsRequest struct {
+	CinemaHallID uint
+	ActiveOnly   bool `form:"active_only"` // 只返回当前及将来仍生效的限制
+}
+
+// 删除座位限制
+type DeleteSeatRestrictionRequest struct {
+	CinemaHallID uint
+	ID           uint
+}
+
+// 设置影厅的社交距离策略
+type UpdateDistancingPolicyRequest struct {
+	CinemaHallID       uint
+	SeatsBetweenGroups int        `json:"seats_between_groups" binding:"min=0,max=10"`
+	BlockFrontAndBack  bool       `json:"block_front_and_back"`
+	StartsAt           *time.Time `json:"starts_at" binding:"omitempty"`
+	EndsAt             *time.Time `json:"ends_at" binding:"omitempty"`
+}
+
+func (r *UpdateDistancingPolicyRequest) ToDomain() *cinema.DistancingPolicy {
+	return &cinema.DistancingPolicy{
+		SeatsBetweenGroups: r.SeatsBetweenGroups,
+		BlockFrontAndBack:  r.BlockFrontAndBack,
+		StartsAt:           r.StartsAt,
+		EndsAt:             r.EndsAt,
+	}
+}
+
+// 清除影厅的社交距离策略
+type DeleteDistancingPolicyRequest struct {
+	CinemaHallID uint
+}
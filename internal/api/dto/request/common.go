@@ -1,6 +1,47 @@
 package request
 
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
 type PaginationRequest struct {
 	Page     int `json:"page" form:"page" binding:"required,min=1"`
 	PageSize int `json:"page_size" form:"page_size" binding:"required,min=1,max=100"`
 }
+
+// 日期：接受 YYYY-MM-DD 或 RFC3339，只保留日期部分（服务器时区零点），与数据库 date 列一致
+type Date struct {
+	time.Time
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	t, err := time.ParseInLocation(time.DateOnly, s, time.Local)
+	if err != nil {
+		if t, err = time.Parse(time.RFC3339, s); err != nil {
+			return fmt.Errorf("invalid date %q, want YYYY-MM-DD or RFC3339", s)
+		}
+		// RFC3339 只取其时区下的日期，不做时区换算
+		t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+	}
+	d.Time = t
+	return nil
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Format(time.DateOnly))
+}
+
+// 转换为时间指针，未填写时返回nil
+func (d *Date) TimePtr() *time.Time {
+	if d == nil {
+		return nil
+	}
+	t := d.Time
+	return &t
+}
@@ -0,0 +1,12 @@
+package request
+
+// 查询自己的积分账户与会员等级
+type GetLoyaltyAccountRequest struct {
+	UserID uint
+}
+
+// 查询自己的积分流水
+type ListLoyaltyLedgerRequest struct {
+	PaginationRequest
+	UserID uint
+}
@@ -0,0 +1,20 @@
+package request
+
+import (
+	"io"
+	"mrs/internal/domain/movie"
+)
+
+// 上传电影媒体文件（multipart 表单字段 file）
+type UploadMovieMediaRequest struct {
+	MovieID  uint
+	Kind     movie.MediaKind
+	Filename string
+	Size     int64
+	Content  io.ReadSeeker
+}
+
+// 读取媒体文件 GET /media/*key
+type GetMediaRequest struct {
+	Key string
+}
@@ -17,6 +17,17 @@ type CreateMovieRequest struct {
 	PosterURL       string    `json:"poster_url" binding:"omitempty,url"`
 	AgeRating       string    `json:"age_rating" binding:"omitempty,min=1,max=50"`
 	Cast            string    `json:"cast" binding:"omitempty,min=1,max=1000"`
+
+	// 演职员，影人按姓名查找或创建；未提供 cast 时由演员姓名生成
+	Credits []*CreditRequest `json:"credits" binding:"omitempty,max=200,dive"`
+}
+
+// 电影的一条演职员信息
+type CreditRequest struct {
+	PersonName   string `json:"person_name" binding:"required,min=1,max=255"`
+	Role         string `json:"role" binding:"required,oneof=actor director writer composer"`
+	Character    string `json:"character" binding:"omitempty,max=255"`
+	BillingOrder int    `json:"billing_order" binding:"omitempty,min=0"`
 }
 
 // Genre 需要应用层自行处理
@@ -35,7 +46,8 @@ func (r *CreateMovieRequest) ToMovie() *movie.Movie {
 
 // GetMovieRequest 定义了获取电影请求的结构体。
 type GetMovieRequest struct {
-	ID uint
+	ID      uint
+	Locales []vo.Locale // 偏好语言，由 Accept-Language 解析而来
 }
 
 // UpdateMovieRequest 定义了更新电影请求的结构体。
@@ -50,6 +62,9 @@ type UpdateMovieRequest struct {
 	PosterURL       string    `json:"poster_url" binding:"omitempty,url"`
 	AgeRating       string    `json:"age_rating" binding:"omitempty,min=1,max=50"`
 	Cast            string    `json:"cast" binding:"omitempty,min=1,max=1000"`
+
+	// 未提供时保持不变，提供空数组时清空演职员
+	Credits []*CreditRequest `json:"credits" binding:"omitempty,max=200,dive"`
 }
 
 func (r *UpdateMovieRequest) ToDomain() *movie.Movie {
@@ -71,21 +86,76 @@ type DeleteMovieRequest struct {
 	ID uint
 }
 
+// 手动归档电影
+type ArchiveMovieRequest struct {
+	ID uint
+}
+
+// 恢复已归档的电影
+type RestoreMovieRequest struct {
+	ID uint
+}
+
 type ListMovieRequest struct {
 	PaginationRequest
 	Title       string `json:"title" form:"title" binding:"omitempty,min=1,max=255"`
 	GenreName   string `json:"genre_name" form:"genre_name" binding:"omitempty,min=1,max=255"`
 	ReleaseYear int    `json:"release_year" form:"release_year" binding:"omitempty,min=1900,max=2100"` // 按上映年份过滤
+
+	// 按影人过滤，例如"某演员参演的电影"
+	PersonID   uint   `json:"person_id" form:"person_id" binding:"omitempty,min=1"`
+	CreditRole string `json:"credit_role" form:"credit_role" binding:"omitempty,oneof=actor director writer composer"`
+
+	// 按生命周期状态过滤，默认只返回未归档的电影，all 返回全部
+	Status string `json:"status" form:"status" binding:"omitempty,oneof=coming_soon now_showing archived all"`
+
+	Locales []vo.Locale `json:"-" form:"-"` // 偏好语言，由 Accept-Language 解析而来
 }
 
+// 生命周期状态过滤：查询全部电影
+const MovieStatusAll = "all"
+
 func (r *ListMovieRequest) ToDomain() *movie.MovieQueryOptions {
-	return &movie.MovieQueryOptions{
+	options := &movie.MovieQueryOptions{
 		Title:       r.Title,
 		GenreName:   r.GenreName,
 		ReleaseYear: r.ReleaseYear,
+		PersonID:    r.PersonID,
+		CreditRole:  r.CreditRole,
 		Page:        r.Page,
 		PageSize:    r.PageSize,
 	}
+	switch r.Status {
+	case "":
+		options.Statuses = movie.ActiveStatuses
+	case MovieStatusAll:
+	default:
+		options.Statuses = []movie.Status{movie.Status(r.Status)}
+	}
+	return options
+}
+
+// 全文搜索电影
+type SearchMoviesRequest struct {
+	PaginationRequest
+	Query string `json:"q" form:"q" binding:"required,min=1,max=255"`
+
+	Locales []vo.Locale `json:"-" form:"-"` // 偏好语言，由 Accept-Language 解析而来
+}
+
+func (r *SearchMoviesRequest) ToDomain() *movie.MovieSearchQuery {
+	return &movie.MovieSearchQuery{
+		Query:    r.Query,
+		Page:     r.Page,
+		PageSize: r.PageSize,
+	}
+}
+
+// 批量导入片单（按标题匹配，已存在则更新）
+type ImportMoviesRequest struct {
+	Format string `form:"format" binding:"omitempty,oneof=json csv"` // 默认 json
+	DryRun bool   `form:"dry_run"`                                   // 只返回差异报告，不落库
+	Data   []byte
 }
 
 // 创建类型
@@ -110,3 +180,35 @@ func (r *UpdateGenreRequest) ToDomain() *movie.Genre {
 type DeleteGenreRequest struct {
 	ID uint
 }
+
+// 获取类型列表
+type ListGenresRequest struct {
+	Locales []vo.Locale // 偏好语言，由 Accept-Language 解析而来
+}
+
+// 新增或覆盖电影翻译 PUT /admin/movies/:id/translations/:locale
+type UpsertMovieTranslationRequest struct {
+	MovieID     uint
+	Locale      string
+	Title       string `json:"title" binding:"required,min=1,max=255"`
+	Description string `json:"description" binding:"omitempty,min=1,max=1000"`
+}
+
+// 删除电影翻译
+type DeleteMovieTranslationRequest struct {
+	MovieID uint
+	Locale  string
+}
+
+// 新增或覆盖类型翻译 PUT /admin/genres/:id/translations/:locale
+type UpsertGenreTranslationRequest struct {
+	GenreID uint
+	Locale  string
+	Name    string `json:"name" binding:"required,min=1,max=255"`
+}
+
+// 删除类型翻译
+type DeleteGenreTranslationRequest struct {
+	GenreID uint
+	Locale  string
+}
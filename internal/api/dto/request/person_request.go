@@ -0,0 +1,73 @@
+package request
+
+import (
+	"mrs/internal/domain/movie"
+	"mrs/internal/domain/shared/vo"
+	"time"
+)
+
+// 创建影人
+type CreatePersonRequest struct {
+	Name      string     `json:"name" binding:"required,min=1,max=255"`
+	Bio       string     `json:"bio" binding:"omitempty,max=5000"`
+	BirthDate *time.Time `json:"birth_date" binding:"omitempty"`
+	PhotoURL  string     `json:"photo_url" binding:"omitempty,url,max=500"`
+}
+
+func (r *CreatePersonRequest) ToDomain() *movie.Person {
+	return &movie.Person{
+		Name:      r.Name,
+		Bio:       r.Bio,
+		BirthDate: r.BirthDate,
+		PhotoURL:  r.PhotoURL,
+	}
+}
+
+// 获取影人详情
+type GetPersonRequest struct {
+	ID uint
+}
+
+// 更新影人（整体替换，未提供的可选字段将被清空）
+type UpdatePersonRequest struct {
+	ID        uint
+	Name      string     `json:"name" binding:"required,min=1,max=255"`
+	Bio       string     `json:"bio" binding:"omitempty,max=5000"`
+	BirthDate *time.Time `json:"birth_date" binding:"omitempty"`
+	PhotoURL  string     `json:"photo_url" binding:"omitempty,url,max=500"`
+}
+
+func (r *UpdatePersonRequest) ToDomain() *movie.Person {
+	return &movie.Person{
+		ID:        vo.PersonID(r.ID),
+		Name:      r.Name,
+		Bio:       r.Bio,
+		BirthDate: r.BirthDate,
+		PhotoURL:  r.PhotoURL,
+	}
+}
+
+// 删除影人
+type DeletePersonRequest struct {
+	ID uint
+}
+
+// 查询影人列表
+type ListPeopleRequest struct {
+	PaginationRequest
+	Name string `json:"name" form:"name" binding:"omitempty,min=1,max=255"`
+}
+
+func (r *ListPeopleRequest) ToDomain() *movie.PersonQueryOptions {
+	return &movie.PersonQueryOptions{
+		Name:     r.Name,
+		Page:     r.Page,
+		PageSize: r.PageSize,
+	}
+}
+
+// 查询影人作品年表
+type GetFilmographyRequest struct {
+	ID   uint
+	Role string `json:"role" form:"role" binding:"omitempty,oneof=actor director writer composer"`
+}
@@ -0,0 +1,7 @@
+package request
+
+// 查询个性化推荐
+type GetRecommendationsRequest struct {
+	UserID uint
+	Limit  int `json:"limit" form:"limit" binding:"omitempty,min=1,max=50"` // 返回数量，默认10
+}
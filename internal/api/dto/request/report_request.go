@@ -3,8 +3,73 @@ package request
 import "time"
 
 type GenerateSalesReportRequest struct {
-	MovieID   uint      `json:"movie_id" form:"movie_id" binding:"omitempty"`
-	CinemaID  uint      `json:"cinema_id" form:"cinema_id" binding:"omitempty"`
-	StartDate time.Time `json:"start_date" form:"start_date" binding:"omitempty"`
-	EndDate   time.Time `json:"end_date" form:"end_date" binding:"omitempty"`
+	MovieID      uint      `json:"movie_id" form:"movie_id" binding:"omitempty"`
+	CinemaID     uint      `json:"cinema_id" form:"cinema_id" binding:"omitempty"`
+	CinemaHallID uint      `json:"cinema_hall_id" form:"cinema_hall_id" binding:"omitempty"`
+	StartDate    time.Time `json:"start_date" form:"start_date" binding:"omitempty"`
+	EndDate      time.Time `json:"end_date" form:"end_date" binding:"omitempty"`
+
+	// 时间范围的日期口径：booking 按下单时间（默认），showtime 按场次开始时间
+	DateBasis string `json:"date_basis" form:"date_basis" binding:"omitempty,oneof=booking showtime"`
+
+	// 时间序列：为空时不返回序列；时区为 IANA 名称（如 Asia/Shanghai），默认服务器时区
+	Granularity string `json:"granularity" form:"granularity" binding:"omitempty,oneof=hour day week month"`
+	Timezone    string `json:"timezone" form:"timezone" binding:"omitempty,max=64"`
+	// 时间序列中每个周期按电影或影厅拆分
+	Breakdown string `json:"breakdown" form:"breakdown" binding:"omitempty,oneof=movie hall"`
+
+	// 与紧邻的上一周期（长度相同）对比，需要同时提供开始与结束日期
+	Compare bool `json:"compare" form:"compare"`
+
+	// 响应格式：json（默认）、csv 或 xlsx，后两者以附件下载
+	Format string `json:"format" form:"format" binding:"omitempty,oneof=json csv xlsx"`
+}
+
+// 上座率报告，过滤条件同销售报告，时间范围按场次开始时间过滤
+type GenerateOccupancyReportRequest struct {
+	MovieID      uint      `json:"movie_id" form:"movie_id" binding:"omitempty"`
+	CinemaID     uint      `json:"cinema_id" form:"cinema_id" binding:"omitempty"`
+	CinemaHallID uint      `json:"cinema_hall_id" form:"cinema_hall_id" binding:"omitempty"`
+	StartDate    time.Time `json:"start_date" form:"start_date" binding:"omitempty"`
+	EndDate      time.Time `json:"end_date" form:"end_date" binding:"omitempty"`
+
+	// 响应格式：json（默认）、csv 或 xlsx，后两者以附件下载
+	Format string `json:"format" form:"format" binding:"omitempty,oneof=json csv xlsx"`
+}
+
+// 转化漏斗报告，时间范围按事件发生时间过滤
+type GenerateFunnelReportRequest struct {
+	MovieID      uint      `json:"movie_id" form:"movie_id" binding:"omitempty"`
+	CinemaID     uint      `json:"cinema_id" form:"cinema_id" binding:"omitempty"`
+	CinemaHallID uint      `json:"cinema_hall_id" form:"cinema_hall_id" binding:"omitempty"`
+	StartDate    time.Time `json:"start_date" form:"start_date" binding:"omitempty"`
+	EndDate      time.Time `json:"end_date" form:"end_date" binding:"omitempty"`
+
+	// 响应格式：json（默认）、csv 或 xlsx，后两者以附件下载
+	Format string `json:"format" form:"format" binding:"omitempty,oneof=json csv xlsx"`
+}
+
+// 重建每日销售事实的业务日期范围（两端均包含，按服务器时区取日期）
+type RebuildDailySalesRequest struct {
+	StartDate time.Time `json:"start_date" binding:"required"`
+	EndDate   time.Time `json:"end_date" binding:"required"`
+}
+
+// 订单明细导出，逐行读取数据库，适合导出大范围的数据
+type ExportBookingsRequest struct {
+	MovieID      uint      `json:"movie_id" form:"movie_id" binding:"omitempty"`
+	CinemaID     uint      `json:"cinema_id" form:"cinema_id" binding:"omitempty"`
+	CinemaHallID uint      `json:"cinema_hall_id" form:"cinema_hall_id" binding:"omitempty"`
+	StartDate    time.Time `json:"start_date" form:"start_date" binding:"omitempty"`
+	EndDate      time.Time `json:"end_date" form:"end_date" binding:"omitempty"`
+
+	// 时间范围的日期口径：booking 按下单时间（默认），showtime 按场次开始时间
+	DateBasis string `json:"date_basis" form:"date_basis" binding:"omitempty,oneof=booking showtime"`
+	// 订单状态，默认 confirmed
+	Status string `json:"status" form:"status" binding:"omitempty,oneof=pending confirmed canceled refunded"`
+	// 导出时间使用的时区（IANA 名称），默认服务器时区
+	Timezone string `json:"timezone" form:"timezone" binding:"omitempty,max=64"`
+
+	// 导出格式：json（默认）、csv 或 xlsx
+	Format string `json:"format" form:"format" binding:"omitempty,oneof=json csv xlsx"`
 }
@@ -0,0 +1,84 @@
+package request
+
+import (
+	"mrs/internal/domain/report"
+	"mrs/internal/domain/shared/vo"
+)
+
+// 报表参数，含义与报表接口的同名查询参数相同；period 为相对于计划执行时间的报表周期，默认前一天
+type ReportSubscriptionParameters struct {
+	Period       string `json:"period" binding:"omitempty,oneof=previous_day previous_week previous_month"`
+	MovieID      uint   `json:"movie_id" binding:"omitempty"`
+	CinemaID     uint   `json:"cinema_id" binding:"omitempty"`
+	CinemaHallID uint   `json:"cinema_hall_id" binding:"omitempty"`
+	DateBasis    string `json:"date_basis" binding:"omitempty,oneof=booking showtime"`
+	Granularity  string `json:"granularity" binding:"omitempty,oneof=hour day week month"`
+	Breakdown    string `json:"breakdown" binding:"omitempty,oneof=movie hall"`
+	Compare      bool   `json:"compare"`
+	Status       string `json:"status" binding:"omitempty,oneof=pending confirmed canceled refunded"`
+}
+
+func (p *ReportSubscriptionParameters) ToDomain() report.Parameters {
+	return report.Parameters{
+		Period:       report.Period(p.Period),
+		MovieID:      p.MovieID,
+		CinemaID:     p.CinemaID,
+		CinemaHallID: p.CinemaHallID,
+		DateBasis:    p.DateBasis,
+		Granularity:  p.Granularity,
+		Breakdown:    p.Breakdown,
+		Compare:      p.Compare,
+		Status:       p.Status,
+	}
+}
+
+// 报表订阅的内容，创建与更新（整体替换）共用。
+// 收件人按渠道解释：email 为邮箱地址，webhook 为 http(s) 地址，file 为输出目录下的相对子目录
+type ReportSubscriptionBody struct {
+	Name       string                       `json:"name" binding:"required,min=1,max=100"`
+	ReportType string                       `json:"report_type" binding:"required,oneof=sales occupancy bookings"`
+	Parameters ReportSubscriptionParameters `json:"parameters"`
+	Cron       string                       `json:"cron" binding:"required,max=100"`
+	Timezone   string                       `json:"timezone" binding:"omitempty,max=64"`
+	Locale     string                       `json:"locale" binding:"omitempty,max=35"`
+	Format     string                       `json:"format" binding:"required,oneof=csv xlsx json"`
+	Channel    string                       `json:"channel" binding:"required,oneof=email webhook file"`
+	Recipients []string                     `json:"recipients" binding:"omitempty,max=50,dive,min=1,max=500"`
+	Enabled    *bool                        `json:"enabled"` // 默认启用
+}
+
+// 转换为领域对象，调用方需校验语言标签
+func (b *ReportSubscriptionBody) ToDomain(locale vo.Locale) *report.Subscription {
+	return &report.Subscription{
+		Name:       b.Name,
+		ReportType: report.Type(b.ReportType),
+		Parameters: b.Parameters.ToDomain(),
+		Cron:       b.Cron,
+		Timezone:   b.Timezone,
+		Locale:     locale,
+		Format:     report.Format(b.Format),
+		Channel:    report.Channel(b.Channel),
+		Recipients: b.Recipients,
+		Enabled:    b.Enabled == nil || *b.Enabled,
+	}
+}
+
+type CreateReportSubscriptionRequest struct {
+	ReportSubscriptionBody
+	CreatedBy uint
+}
+
+type UpdateReportSubscriptionRequest struct {
+	ReportSubscriptionBody
+	ID uint
+}
+
+type ListReportSubscriptionsRequest struct {
+	PaginationRequest
+}
+
+// 查询订阅的执行记录
+type ListReportRunsRequest struct {
+	PaginationRequest
+	SubscriptionID uint
+}
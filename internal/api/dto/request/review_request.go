@@ -0,0 +1,86 @@
+package request
+
+import (
+	"mrs/internal/domain/review"
+	"mrs/internal/domain/shared/vo"
+)
+
+// 审核操作
+const (
+	ReviewActionApprove = "approve"
+	ReviewActionReject  = "reject"
+	ReviewActionHide    = "hide"
+	ReviewActionRestore = "restore"
+)
+
+// 发表评价
+type CreateReviewRequest struct {
+	MovieID uint
+	UserID  uint
+	Score   int    `json:"score" binding:"required,min=1,max=10"`
+	Content string `json:"content" binding:"omitempty,max=2000"`
+}
+
+// 查询电影的已发布评价
+type ListMovieReviewsRequest struct {
+	PaginationRequest
+	MovieID uint
+	SortBy  string `json:"sort_by" form:"sort_by" binding:"omitempty,oneof=recent helpful"`
+}
+
+func (r *ListMovieReviewsRequest) ToDomain() *review.ReviewQueryOptions {
+	return &review.ReviewQueryOptions{
+		MovieID:  vo.MovieID(r.MovieID),
+		Status:   review.ReviewStatusPublished,
+		SortBy:   r.SortBy,
+		Page:     r.Page,
+		PageSize: r.PageSize,
+	}
+}
+
+// 修改自己的评价（修改后需重新审核）
+type UpdateReviewRequest struct {
+	ID      uint
+	UserID  uint
+	Score   int    `json:"score" binding:"required,min=1,max=10"`
+	Content string `json:"content" binding:"omitempty,max=2000"`
+}
+
+// 删除自己的评价
+type DeleteReviewRequest struct {
+	ID     uint
+	UserID uint
+}
+
+// 为评价投"有用"票或撤销投票
+type VoteReviewRequest struct {
+	ID     uint
+	UserID uint
+}
+
+// 管理员查询评价（如待审核队列）
+type ListReviewsRequest struct {
+	PaginationRequest
+	MovieID uint   `json:"movie_id" form:"movie_id" binding:"omitempty,min=1"`
+	UserID  uint   `json:"user_id" form:"user_id" binding:"omitempty,min=1"`
+	Status  string `json:"status" form:"status" binding:"omitempty,oneof=pending published rejected hidden"`
+	SortBy  string `json:"sort_by" form:"sort_by" binding:"omitempty,oneof=recent helpful"`
+}
+
+func (r *ListReviewsRequest) ToDomain() *review.ReviewQueryOptions {
+	return &review.ReviewQueryOptions{
+		MovieID:  vo.MovieID(r.MovieID),
+		UserID:   vo.UserID(r.UserID),
+		Status:   review.ReviewStatus(r.Status),
+		SortBy:   r.SortBy,
+		Page:     r.Page,
+		PageSize: r.PageSize,
+	}
+}
+
+// 审核评价，Action 由路由决定
+type ModerateReviewRequest struct {
+	ID     uint
+	Action string
+	Note   string `json:"note" binding:"omitempty,max=255"`
+}
@@ -13,15 +13,35 @@ type CreateShowtimeRequest struct {
 	StartTime    time.Time `json:"start_time" binding:"required"`
 	EndTime      time.Time `json:"end_time" binding:"required"`
 	Price        float64   `json:"price" binding:"required,min=0"`
+
+	Format           string `json:"format" binding:"omitempty,oneof=2D 3D IMAX IMAX_3D 4DX DOLBY_CINEMA"`
+	AudioLanguage    string `json:"audio_language" binding:"omitempty,min=2,max=10"`
+	SubtitleLanguage string `json:"subtitle_language" binding:"omitempty,min=2,max=10"`
+	AudioDescription bool   `json:"audio_description"`
+	ClosedCaptions   bool   `json:"closed_captions"`
+	SensoryFriendly  bool   `json:"sensory_friendly"`
 }
 
 func (r *CreateShowtimeRequest) ToDomain() *showtime.Showtime {
+	format := showtime.ProjectionFormat(r.Format)
+	if format == "" {
+		format = showtime.DefaultProjectionFormat
+	}
 	return &showtime.Showtime{
-		MovieID:      vo.MovieID(r.MovieID),
-		CinemaHallID: vo.CinemaHallID(r.CinemaHallID),
-		StartTime:    r.StartTime,
-		EndTime:      r.EndTime,
-		Price:        r.Price,
+		MovieID:          vo.MovieID(r.MovieID),
+		CinemaHallID:     vo.CinemaHallID(r.CinemaHallID),
+		StartTime:        r.StartTime,
+		EndTime:          r.EndTime,
+		Price:            r.Price,
+		Format:           format,
+		AudioLanguage:    r.AudioLanguage,
+		SubtitleLanguage: r.SubtitleLanguage,
+		Accessibility: showtime.Accessibility{
+			AudioDescription: r.AudioDescription,
+			ClosedCaptions:   r.ClosedCaptions,
+			SensoryFriendly:  r.SensoryFriendly,
+		},
+		Status: showtime.ShowtimeStatusScheduled,
 	}
 }
 
@@ -44,17 +64,47 @@ type UpdateShowtimeRequest struct {
 	StartTime    time.Time `json:"start_time" binding:"omitempty"`
 	EndTime      time.Time `json:"end_time" binding:"omitempty"`
 	Price        float64   `json:"price" binding:"omitempty,min=0"`
+
+	Format        string `json:"format" binding:"omitempty,oneof=2D 3D IMAX IMAX_3D 4DX DOLBY_CINEMA"`
+	AudioLanguage string `json:"audio_language" binding:"omitempty,min=2,max=10"`
+	// 字幕语言使用指针区分"未传"与"清除字幕"（传空字符串）
+	SubtitleLanguage *string `json:"subtitle_language" binding:"omitempty,max=0|min=2,max=10"`
+	// 无障碍标记使用指针区分"未传"与"false"
+	AudioDescription *bool `json:"audio_description"`
+	ClosedCaptions   *bool `json:"closed_captions"`
+	SensoryFriendly  *bool `json:"sensory_friendly"`
 }
 
 func (r *UpdateShowtimeRequest) ToDomain() *showtime.Showtime {
 	return &showtime.Showtime{
-		ID:           vo.ShowtimeID(r.ID),
-		MovieID:      vo.MovieID(r.MovieID),
-		CinemaHallID: vo.CinemaHallID(r.CinemaHallID),
-		StartTime:    r.StartTime,
-		EndTime:      r.EndTime,
-		Price:        r.Price,
+		ID:            vo.ShowtimeID(r.ID),
+		MovieID:       vo.MovieID(r.MovieID),
+		CinemaHallID:  vo.CinemaHallID(r.CinemaHallID),
+		StartTime:     r.StartTime,
+		EndTime:       r.EndTime,
+		Price:         r.Price,
+		Format:        showtime.ProjectionFormat(r.Format),
+		AudioLanguage: r.AudioLanguage,
+	}
+}
+
+// 请求中是否传入了无障碍标记
+func (r *UpdateShowtimeRequest) HasAccessibility() bool {
+	return r.AudioDescription != nil || r.ClosedCaptions != nil || r.SensoryFriendly != nil
+}
+
+// 在当前无障碍标记的基础上应用请求中传入的字段
+func (r *UpdateShowtimeRequest) MergeAccessibility(current showtime.Accessibility) showtime.Accessibility {
+	if r.AudioDescription != nil {
+		current.AudioDescription = *r.AudioDescription
+	}
+	if r.ClosedCaptions != nil {
+		current.ClosedCaptions = *r.ClosedCaptions
+	}
+	if r.SensoryFriendly != nil {
+		current.SensoryFriendly = *r.SensoryFriendly
 	}
+	return current
 }
 
 // 删除场次
@@ -68,25 +118,68 @@ func (r *DeleteShowtimeRequest) ToDomain() *showtime.Showtime {
 	}
 }
 
+// 取消场次
+type CancelShowtimeRequest struct {
+	ID        uint
+	Reason    string `json:"reason" binding:"omitempty,max=255"`
+	BatchSize int    `json:"batch_size" binding:"omitempty,min=1,max=1000"` // 每批处理的订单数量
+}
+
 // 获取放映列表
 type ListShowtimesRequest struct {
 	PaginationRequest
 	MovieID      uint      `json:"movie_id" form:"movie_id" binding:"omitempty,min=1"`
+	CinemaID     uint      `json:"cinema_id" form:"cinema_id" binding:"omitempty,min=1"`
 	CinemaHallID uint      `json:"cinema_hall_id" form:"cinema_hall_id" binding:"omitempty,min=1"`
 	Date         time.Time `json:"date" form:"date" binding:"omitempty"`
+
+	Format           string `json:"format" form:"format" binding:"omitempty,oneof=2D 3D IMAX IMAX_3D 4DX DOLBY_CINEMA"`
+	AudioLanguage    string `json:"audio_language" form:"audio_language" binding:"omitempty,min=2,max=10"`
+	SubtitleLanguage string `json:"subtitle_language" form:"subtitle_language" binding:"omitempty,min=2,max=10"`
+	AudioDescription bool   `json:"audio_description" form:"audio_description"`
+	ClosedCaptions   bool   `json:"closed_captions" form:"closed_captions"`
+	SensoryFriendly  bool   `json:"sensory_friendly" form:"sensory_friendly"`
 }
 
 func (r *ListShowtimesRequest) ToDomain() *showtime.ShowtimeQueryOptions {
 	return &showtime.ShowtimeQueryOptions{
-		MovieID:      vo.MovieID(r.MovieID),
-		CinemaHallID: vo.CinemaHallID(r.CinemaHallID),
-		Date:         r.Date,
-		Page:         r.Page,
-		PageSize:     r.PageSize,
+		MovieID:          vo.MovieID(r.MovieID),
+		CinemaID:         vo.CinemaID(r.CinemaID),
+		CinemaHallID:     vo.CinemaHallID(r.CinemaHallID),
+		Date:             r.Date,
+		Format:           showtime.ProjectionFormat(r.Format),
+		AudioLanguage:    r.AudioLanguage,
+		SubtitleLanguage: r.SubtitleLanguage,
+		AudioDescription: r.AudioDescription,
+		ClosedCaptions:   r.ClosedCaptions,
+		SensoryFriendly:  r.SensoryFriendly,
+		Page:             r.Page,
+		PageSize:         r.PageSize,
 	}
 }
 
+// 管理员获取放映列表，可按状态过滤；未指定状态时包含已取消的场次
+type ListAdminShowtimesRequest struct {
+	ListShowtimesRequest
+	Status string `json:"status" form:"status" binding:"omitempty,oneof=scheduled cancelled"`
+}
+
+func (r *ListAdminShowtimesRequest) ToDomain() *showtime.ShowtimeQueryOptions {
+	options := r.ListShowtimesRequest.ToDomain()
+	options.Status = showtime.ShowtimeStatus(r.Status)
+	options.IncludeCancelled = true
+	return options
+}
+
 // 获取指定放映场次的座位表（包含座位状态）
 type GetSeatMapRequest struct {
 	ShowtimeID uint
+	UserID     uint // 当前用户，用于记录漏斗事件
+}
+
+// 将影厅未开始的场次迁移到新的布局版本
+type MigrateShowtimeLayoutsRequest struct {
+	CinemaHallID  uint `json:"cinema_hall_id" binding:"required"`
+	TargetVersion int  `json:"target_version" binding:"omitempty,min=1"` // 默认影厅当前版本
+	DryRun        bool `json:"dry_run"`                                  // 只报告迁移结果与冲突，不落库
 }
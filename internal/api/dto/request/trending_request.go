@@ -0,0 +1,18 @@
+package request
+
+import "mrs/internal/domain/shared/vo"
+
+// 查询热门电影
+type GetTrendingMoviesRequest struct {
+	Window  string      `json:"window" form:"window" binding:"omitempty,oneof=1h today 7d"` // 统计窗口，默认 today
+	Limit   int         `json:"limit" form:"limit" binding:"omitempty,min=1,max=50"`        // 返回数量，默认10
+	Locales []vo.Locale `json:"-" form:"-"`                                                 // 偏好语言，由 Accept-Language 解析而来
+}
+
+// 查询售票排行榜（管理员）
+type GetLeaderboardRequest struct {
+	Window  string      `json:"window" form:"window" binding:"omitempty,oneof=1h today 7d"` // 统计窗口，默认 today
+	Limit   int         `json:"limit" form:"limit" binding:"omitempty,min=1,max=100"`       // 电影与场次各返回的数量，默认10
+	Format  string      `json:"format" form:"format" binding:"omitempty,oneof=json csv xlsx"`
+	Locales []vo.Locale `json:"-" form:"-"` // 偏好语言，由 Accept-Language 解析而来
+}
@@ -10,6 +10,9 @@ type RegisterUserRequest struct {
 	Username string `json:"username" binding:"required,alphanum,min=3,max=50"`
 	Password string `json:"password" binding:"required,min=8,max=100"`
 	Email    string `json:"email" binding:"required,email"`
+
+	// 出生日期（可选，YYYY-MM-DD 或 RFC3339），用于观看有年龄分级的电影时核验年龄
+	BirthDate *Date `json:"birth_date" binding:"omitempty"`
 }
 
 type GetUserRequest struct {
@@ -21,13 +24,16 @@ type UpdateUserRequest struct {
 	Username string `json:"username" binding:"omitempty,alphanum,min=3,max=50"`
 	Password string `json:"password" binding:"omitempty,min=8,max=100"`
 	Email    string `json:"email" binding:"omitempty,email"`
+
+	BirthDate *Date `json:"birth_date" binding:"omitempty"`
 }
 
 func (r *UpdateUserRequest) ToDomain() *user.User {
 	return &user.User{
-		ID:       vo.UserID(r.ID),
-		Username: r.Username,
-		Email:    r.Email,
+		ID:        vo.UserID(r.ID),
+		Username:  r.Username,
+		Email:     r.Email,
+		BirthDate: r.BirthDate.TimePtr(),
 	}
 }
 
@@ -0,0 +1,21 @@
+package request
+
+// 关注电影，可选指定偏好的影院或影厅（只在偏好影院/影厅排片时提醒）
+type AddWatchlistRequest struct {
+	UserID            uint
+	MovieID           uint `json:"movie_id" binding:"required,min=1"`
+	PreferredCinemaID uint `json:"preferred_cinema_id" binding:"omitempty,min=1"`
+	PreferredHallID   uint `json:"preferred_hall_id" binding:"omitempty,min=1"`
+}
+
+// 取消关注
+type RemoveWatchlistRequest struct {
+	UserID  uint
+	MovieID uint
+}
+
+// 查询自己的关注列表
+type ListWatchlistRequest struct {
+	PaginationRequest
+	UserID uint
+}
@@ -4,6 +4,7 @@ import (
 	"math"
 	"mrs/internal/api/dto/request"
 	"mrs/internal/domain/booking"
+	"mrs/internal/domain/movie"
 	"time"
 )
 
@@ -13,6 +14,11 @@ type BookingResponse struct {
 	TotalAmount float64   `json:"total_amount"`
 	BookingTime time.Time `json:"booking_time"`
 	Status      string    `json:"status"`
+
+	// 观影年龄要求（电影无年龄限制时省略）
+	AgeRequirement *AgeRequirementResponse `json:"age_requirement,omitempty"`
+	// 会员折扣与积分抵扣（没有优惠时省略）
+	Discount *BookingDiscountResponse `json:"discount,omitempty"`
 }
 
 func ToBookingResponse(booking *booking.Booking) *BookingResponse {
@@ -20,10 +26,75 @@ func ToBookingResponse(booking *booking.Booking) *BookingResponse {
 		return nil
 	}
 	return &BookingResponse{
-		ID:          uint(booking.ID),
-		TotalAmount: booking.TotalAmount,
-		BookingTime: booking.BookingTime,
-		Status:      string(booking.Status),
+		ID:             uint(booking.ID),
+		TotalAmount:    booking.TotalAmount,
+		BookingTime:    booking.BookingTime,
+		Status:         string(booking.Status),
+		AgeRequirement: ToAgeRequirementResponse(booking.AgeRequirement, booking.AgeCheck),
+		Discount:       ToBookingDiscountResponse(booking.Discount),
+	}
+}
+
+// 订单优惠，total_amount 已扣除 tier_discount 与 points_discount
+type BookingDiscountResponse struct {
+	Tier           string  `json:"tier"`
+	TierDiscount   float64 `json:"tier_discount"`
+	PointsRedeemed int     `json:"points_redeemed"`
+	PointsDiscount float64 `json:"points_discount"`
+}
+
+func ToBookingDiscountResponse(d *booking.Discount) *BookingDiscountResponse {
+	if d == nil {
+		return nil
+	}
+	return &BookingDiscountResponse{
+		Tier:           d.Tier,
+		TierDiscount:   d.TierDiscount,
+		PointsRedeemed: d.PointsRedeemed,
+		PointsDiscount: d.PointsDiscount,
+	}
+}
+
+// 订单的年龄要求与下单时的核验结果，verify_at_entry 为 true 时检票员需核验证件或陪同成人
+type AgeRequirementResponse struct {
+	Rating            string `json:"rating"`
+	MinAge            int    `json:"min_age"`
+	Accompanied       bool   `json:"accompanied"`
+	AccompaniedMinAge int    `json:"accompanied_min_age,omitempty"`
+	Check             string `json:"check"`
+	VerifyAtEntry     bool   `json:"verify_at_entry"`
+}
+
+func ToAgeRequirementResponse(requirement *movie.AgeRequirement, check movie.AgeCheck) *AgeRequirementResponse {
+	if requirement == nil {
+		return nil
+	}
+	return &AgeRequirementResponse{
+		Rating:            requirement.Rating,
+		MinAge:            requirement.MinAge,
+		Accompanied:       requirement.Accompanied,
+		AccompaniedMinAge: requirement.AccompaniedMinAge,
+		Check:             string(check),
+		VerifyAtEntry:     check.NeedsVerification(),
+	}
+}
+
+// 检票信息：订单、场次、座位与年龄要求
+type CheckInResponse struct {
+	Booking  *BookingResponse  `json:"booking"`
+	Showtime *ShowtimeResponse `json:"showtime"`
+	SeatIDs  []uint            `json:"seat_ids"`
+}
+
+func ToCheckInResponse(bk *booking.Booking, st *ShowtimeResponse) *CheckInResponse {
+	seatIDs := make([]uint, len(bk.BookedSeats))
+	for i, bookedSeat := range bk.BookedSeats {
+		seatIDs[i] = uint(bookedSeat.SeatID)
+	}
+	return &CheckInResponse{
+		Booking:  ToBookingResponse(bk),
+		Showtime: st,
+		SeatIDs:  seatIDs,
 	}
 }
 
@@ -1,29 +1,112 @@
 package response
 
-import "mrs/internal/domain/cinema"
+import (
+	"mrs/internal/domain/cinema"
+	"time"
+)
+
+// 营业时间
+type OpeningHoursResponse struct {
+	Weekday   int    `json:"weekday"`
+	OpenTime  string `json:"open_time"`
+	CloseTime string `json:"close_time"`
+}
+
+// 影院
+type CinemaResponse struct {
+	ID           uint                        `json:"id"`
+	Name         string                      `json:"name"`
+	Address      string                      `json:"address"`
+	City         string                      `json:"city"`
+	Phone        string                      `json:"phone"`
+	Timezone     string                      `json:"timezone"`
+	OpeningHours []*OpeningHoursResponse     `json:"opening_hours"`
+	CinemaHalls  []*CinemaHallSimpleResponse `json:"cinema_halls,omitempty"`
+}
+
+func ToCinemaResponse(c *cinema.Cinema, halls []*cinema.CinemaHall) *CinemaResponse {
+	if c == nil {
+		return nil
+	}
+	openingHours := make([]*OpeningHoursResponse, len(c.OpeningHours))
+	for i, oh := range c.OpeningHours {
+		openingHours[i] = &OpeningHoursResponse{
+			Weekday:   int(oh.Weekday),
+			OpenTime:  oh.OpenTime,
+			CloseTime: oh.CloseTime,
+		}
+	}
+	var hallResponses []*CinemaHallSimpleResponse
+	if halls != nil {
+		hallResponses = make([]*CinemaHallSimpleResponse, len(halls))
+		for i, hall := range halls {
+			hallResponses[i] = ToCinemaHallSimpleResponse(hall)
+		}
+	}
+	return &CinemaResponse{
+		ID:           uint(c.ID),
+		Name:         c.Name,
+		Address:      c.Address,
+		City:         c.City,
+		Phone:        c.Phone,
+		Timezone:     c.Timezone,
+		OpeningHours: openingHours,
+		CinemaHalls:  hallResponses,
+	}
+}
+
+type ListAllCinemasResponse struct {
+	Cinemas []*CinemaResponse `json:"cinemas"`
+}
+
+func ToListAllCinemasResponse(cinemas []*cinema.Cinema) *ListAllCinemasResponse {
+	cinemaResponses := make([]*CinemaResponse, len(cinemas))
+	for i, c := range cinemas {
+		cinemaResponses[i] = ToCinemaResponse(c, nil)
+	}
+	return &ListAllCinemasResponse{
+		Cinemas: cinemaResponses,
+	}
+}
 
 // 影厅
 type CinemaHallResponse struct {
 	ID          uint            `json:"id"`
+	CinemaID    uint            `json:"cinema_id"`
 	Name        string          `json:"name"`
 	ScreenType  string          `json:"screen_type"`
 	SoundSystem string          `json:"sound_system"`
 	Seats       []*SeatResponse `json:"seats"`
+
+	RowCount int                `json:"row_count"`
+	ColCount int                `json:"col_count"`
+	Layout   *cinema.HallLayout `json:"layout"`
+
+	DistancingPolicy *cinema.DistancingPolicy `json:"distancing_policy"`
+	LayoutVersion    int                      `json:"layout_version"`
 }
 
 func ToCinemaHallResponse(hall *cinema.CinemaHall) *CinemaHallResponse {
 	return &CinemaHallResponse{
 		ID:          uint(hall.ID),
+		CinemaID:    uint(hall.CinemaID),
 		Name:        hall.Name,
 		ScreenType:  hall.ScreenType,
 		SoundSystem: hall.SoundSystem,
 		Seats:       ToSeatResponses(hall.Seats),
+		RowCount:    hall.RowCount,
+		ColCount:    hall.ColCount,
+		Layout:      hall.Layout,
+
+		DistancingPolicy: hall.DistancingPolicy,
+		LayoutVersion:    hall.LayoutVersion,
 	}
 }
 
 // 影厅简易信息
 type CinemaHallSimpleResponse struct {
 	ID          uint   `json:"id"`
+	CinemaID    uint   `json:"cinema_id"`
 	Name        string `json:"name"`
 	ScreenType  string `json:"screen_type"`
 	SoundSystem string `json:"sound_system"`
@@ -35,6 +118,7 @@ func ToCinemaHallSimpleResponse(hall *cinema.CinemaHall) *CinemaHallSimpleRespon
 	}
 	return &CinemaHallSimpleResponse{
 		ID:          uint(hall.ID),
+		CinemaID:    uint(hall.CinemaID),
 		Name:        hall.Name,
 		ScreenType:  hall.ScreenType,
 		SoundSystem: hall.SoundSystem,
@@ -47,6 +131,9 @@ type SeatResponse struct {
 	RowIdentifier string `json:"row_identifier"`
 	SeatNumber    string `json:"seat_number"`
 	Type          string `json:"type"`
+	GridRow       int    `json:"grid_row"`
+	GridCol       int    `json:"grid_col"`
+	Block         string `json:"block,omitempty"`
 }
 
 func ToSeatResponses(seats []*cinema.Seat) []*SeatResponse {
@@ -66,6 +153,9 @@ func ToSeatResponse(seat *cinema.Seat) *SeatResponse {
 		RowIdentifier: seat.RowIdentifier,
 		SeatNumber:    seat.SeatNumber,
 		Type:          string(seat.Type),
+		GridRow:       seat.GridRow,
+		GridCol:       seat.GridCol,
+		Block:         seat.Block,
 	}
 }
 
@@ -85,3 +175,75 @@ func ToListAllCinemaHallsResponse(halls []*cinema.CinemaHall) *ListAllCinemaHall
 		CinemaHalls: cinemaHallResponses,
 	}
 }
+
+// 影厅布局文件
+type CinemaHallLayoutFileResponse struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+// 座位限制
+type SeatRestrictionResponse struct {
+	ID           uint       `json:"id"`
+	SeatID       uint       `json:"seat_id"`
+	CinemaHallID uint       `json:"cinema_hall_id"`
+	Kind         string     `json:"kind"`
+	Reason       string     `json:"reason"`
+	StartsAt     *time.Time `json:"starts_at"`
+	EndsAt       *time.Time `json:"ends_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+func ToSeatRestrictionResponse(r *cinema.SeatRestriction) *SeatRestrictionResponse {
+	return &SeatRestrictionResponse{
+		ID:           uint(r.ID),
+		SeatID:       uint(r.SeatID),
+		CinemaHallID: uint(r.CinemaHallID),
+		Kind:         string(r.Kind),
+		Reason:       r.Reason,
+		StartsAt:     r.StartsAt,
+		EndsAt:       r.EndsAt,
+		CreatedAt:    r.CreatedAt,
+	}
+}
+
+type ListSeatRestrictionsResponse struct {
+	Restrictions []*SeatRestrictionResponse `json:"restrictions"`
+}
+
+func ToListSeatRestrictionsResponse(restrictions []*cinema.SeatRestriction) *ListSeatRestrictionsResponse {
+	responses := make([]*SeatRestrictionResponse, len(restrictions))
+	for i, r := range restrictions {
+		responses[i] = ToSeatRestrictionResponse(r)
+	}
+	return &ListSeatRestrictionsResponse{Restrictions: responses}
+}
+
+// 影厅布局版本
+type LayoutVersionResponse struct {
+	Version   int                `json:"version"`
+	Current   bool               `json:"current"` // 是否为影厅当前版本
+	SeatCount int                `json:"seat_count"`
+	Layout    *cinema.HallLayout `json:"layout"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+type ListLayoutVersionsResponse struct {
+	CurrentVersion int                      `json:"current_version"`
+	Versions       []*LayoutVersionResponse `json:"versions"`
+}
+
+func ToListLayoutVersionsResponse(current int, versions []*cinema.HallLayoutVersion) *ListLayoutVersionsResponse {
+	responses := make([]*LayoutVersionResponse, len(versions))
+	for i, v := range versions {
+		responses[i] = &LayoutVersionResponse{
+			Version:   v.Version,
+			Current:   v.Version == current,
+			SeatCount: v.SeatCount,
+			Layout:    v.Layout,
+			CreatedAt: v.CreatedAt,
+		}
+	}
+	return &ListLayoutVersionsResponse{CurrentVersion: current, Versions: responses}
+}
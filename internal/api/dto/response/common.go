@@ -6,3 +6,11 @@ type PaginationResponse struct {
 	TotalCount int `json:"total_count"`
 	TotalPages int `json:"total_pages"`
 }
+
+// 错误响应：code 为稳定的错误码，error 为按 Accept-Language 本地化的消息，
+// detail 为原始错误信息（仅4xx错误返回，便于排查）
+type ErrorResponse struct {
+	Code   string `json:"code"`
+	Error  string `json:"error"`
+	Detail string `json:"detail,omitempty"`
+}
@@ -0,0 +1,78 @@
+package response
+
+import (
+	"mrs/internal/domain/loyalty"
+	"time"
+)
+
+// 积分账户：余额与等级均由积分流水汇总
+type LoyaltyAccountResponse struct {
+	Balance          int                    `json:"balance"`           // 可用积分
+	QualifyingPoints int                    `json:"qualifying_points"` // 等级积分（消费获得的积分，不含抵扣）
+	PointValue       float64                `json:"point_value"`       // 每积分抵扣的金额
+	Tier             *LoyaltyTierResponse   `json:"tier"`
+	NextTier         *LoyaltyTierResponse   `json:"next_tier,omitempty"`           // 已是最高等级时省略
+	PointsToNextTier int                    `json:"points_to_next_tier,omitempty"` // 升到下一等级还需的等级积分
+	Tiers            []*LoyaltyTierResponse `json:"tiers"`                         // 全部等级（按门槛升序）
+}
+
+type LoyaltyTierResponse struct {
+	Name              string  `json:"name"`
+	MinPoints         int     `json:"min_points"`
+	Discount          float64 `json:"discount"`            // 票面金额的折扣比例
+	EarlyBookingHours float64 `json:"early_booking_hours"` // 比普通购票窗口提前开放的小时数
+}
+
+func ToLoyaltyTierResponse(tier *loyalty.Tier) *LoyaltyTierResponse {
+	if tier == nil {
+		return nil
+	}
+	return &LoyaltyTierResponse{
+		Name:              tier.Name,
+		MinPoints:         tier.MinPoints,
+		Discount:          tier.Discount,
+		EarlyBookingHours: tier.EarlyBooking.Hours(),
+	}
+}
+
+func ToLoyaltyAccountResponse(account *loyalty.Account, program *loyalty.Program) *LoyaltyAccountResponse {
+	tier := program.TierFor(account.QualifyingPoints)
+	resp := &LoyaltyAccountResponse{
+		Balance:          account.Balance,
+		QualifyingPoints: account.QualifyingPoints,
+		PointValue:       program.PointValue,
+		Tier:             ToLoyaltyTierResponse(tier),
+		Tiers:            make([]*LoyaltyTierResponse, len(program.Tiers())),
+	}
+	if next := program.NextTier(tier); next != nil {
+		resp.NextTier = ToLoyaltyTierResponse(next)
+		resp.PointsToNextTier = next.MinPoints - account.QualifyingPoints
+	}
+	for i, t := range program.Tiers() {
+		resp.Tiers[i] = ToLoyaltyTierResponse(t)
+	}
+	return resp
+}
+
+type LoyaltyEntryResponse struct {
+	ID        uint      `json:"id"`
+	BookingID uint      `json:"booking_id"`
+	Type      string    `json:"type"`
+	Points    int       `json:"points"` // 正数增加余额，负数减少余额
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func ToLoyaltyEntryResponse(entry *loyalty.Entry) *LoyaltyEntryResponse {
+	return &LoyaltyEntryResponse{
+		ID:        uint(entry.ID),
+		BookingID: uint(entry.BookingID),
+		Type:      string(entry.Type),
+		Points:    entry.Points,
+		CreatedAt: entry.CreatedAt,
+	}
+}
+
+type PaginatedLoyaltyLedgerResponse struct {
+	Pagination PaginationResponse      `json:"pagination"`
+	Entries    []*LoyaltyEntryResponse `json:"entries"`
+}
@@ -0,0 +1,42 @@
+package response
+
+import (
+	"mrs/internal/domain/movie"
+	"time"
+)
+
+// 电影媒体资源，urls 的键为尺寸名称（original 或 w<宽度>）
+type MediaAssetResponse struct {
+	Kind        string            `json:"kind"`
+	ContentType string            `json:"content_type"`
+	Size        int64             `json:"size"`
+	Width       int               `json:"width,omitempty"`
+	Height      int               `json:"height,omitempty"`
+	URLs        map[string]string `json:"urls"`
+	CreatedAt   time.Time         `json:"created_at"`
+}
+
+func ToMediaAssetResponse(asset *movie.MediaAsset) *MediaAssetResponse {
+	urls := make(map[string]string, len(asset.Variants))
+	for _, variant := range asset.Variants {
+		urls[variant.Name] = variant.URL
+	}
+	return &MediaAssetResponse{
+		Kind:        string(asset.Kind),
+		ContentType: asset.ContentType,
+		Size:        asset.Size,
+		Width:       asset.Width,
+		Height:      asset.Height,
+		URLs:        urls,
+		CreatedAt:   asset.CreatedAt,
+	}
+}
+
+// 以媒体类型为键
+func ToMediaResponses(assets []*movie.MediaAsset) map[string]*MediaAssetResponse {
+	media := make(map[string]*MediaAssetResponse, len(assets))
+	for _, asset := range assets {
+		media[string(asset.Kind)] = ToMediaAssetResponse(asset)
+	}
+	return media
+}
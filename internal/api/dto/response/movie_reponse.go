@@ -1,7 +1,9 @@
 package response
 
 import (
+	"math"
 	"mrs/internal/domain/movie"
+	"mrs/internal/domain/shared/vo"
 	"time"
 )
 
@@ -17,6 +19,21 @@ type MovieResponse struct {
 	AgeRating       string           `json:"age_rating"`
 	Cast            string           `json:"cast"`
 	Genres          []*GenreResponse `json:"genres"`
+
+	Credits []*CreditResponse `json:"credits"`
+
+	// 生命周期状态：coming_soon | now_showing | archived
+	Status string `json:"status"`
+
+	// 海报、背景图与预告片，以类型(poster/backdrop/trailer)为键
+	Media map[string]*MediaAssetResponse `json:"media"`
+
+	// 用户评价聚合（仅统计已发布的评价）
+	ReviewAverage float64 `json:"review_average"`
+	ReviewCount   int     `json:"review_count"`
+
+	// 已有翻译的语言（不含默认语言）
+	AvailableLocales []string `json:"available_locales"`
 	// CreatedAt       time.Time        `json:"created_at"`
 	// UpdatedAt       time.Time        `json:"updated_at"`
 }
@@ -29,6 +46,14 @@ func ToMovieResponse(movie *movie.Movie) *MovieResponse {
 	for _, genre := range movie.Genres {
 		genres = append(genres, ToGenreResponse(genre))
 	}
+	credits := make([]*CreditResponse, 0, len(movie.Credits))
+	for _, credit := range movie.Credits {
+		credits = append(credits, ToCreditResponse(credit))
+	}
+	locales := make([]string, 0, len(movie.Translations))
+	for _, translation := range movie.Translations {
+		locales = append(locales, string(translation.Locale))
+	}
 	return &MovieResponse{
 		ID:              uint(movie.ID),
 		Title:           movie.Title,
@@ -40,6 +65,13 @@ func ToMovieResponse(movie *movie.Movie) *MovieResponse {
 		AgeRating:       movie.AgeRating,
 		Cast:            movie.Cast,
 		Genres:          genres,
+		Credits:         credits,
+		Status:          string(movie.Status),
+		Media:           ToMediaResponses(movie.Media),
+		ReviewAverage:   math.Round(movie.ReviewAverage()*10) / 10,
+		ReviewCount:     movie.ReviewCount,
+
+		AvailableLocales: locales,
 	}
 }
 
@@ -48,9 +80,10 @@ type MovieSimpleResponse struct {
 	Title     string  `json:"title"`
 	PosterURL string  `json:"poster_url"`
 	Rating    float32 `json:"rating"`
+	AgeRating string  `json:"age_rating"`
+	Status    string  `json:"status"`
 	// GenreNames []string `json:"genre_names"`
 	// ReleaseDate time.Time `json:"release_date"`
-	// AgeRating   string    `json:"age_rating"`
 	// CreatedAt   time.Time `json:"created_at"`
 	// UpdatedAt   time.Time `json:"updated_at"`
 }
@@ -64,6 +97,8 @@ func ToMovieSimpleResponse(movie *movie.Movie) *MovieSimpleResponse {
 		Title:     movie.Title,
 		PosterURL: movie.PosterURL,
 		Rating:    movie.Rating,
+		AgeRating: movie.AgeRating,
+		Status:    string(movie.Status),
 	}
 }
 
@@ -72,6 +107,73 @@ type PaginatedMovieResponse struct {
 	Movies     []*MovieSimpleResponse `json:"movies"`
 }
 
+// 搜索结果，highlights 的键为命中的字段(title/description/cast/genres)，命中词以<em>标记
+type MovieSearchResultResponse struct {
+	Movie      *MovieSimpleResponse `json:"movie"`
+	Score      float64              `json:"score"`
+	Highlights map[string]string    `json:"highlights"`
+}
+
+func ToMovieSearchResultResponse(mv *movie.Movie, score float64, highlights map[string]string) *MovieSearchResultResponse {
+	return &MovieSearchResultResponse{
+		Movie:      ToMovieSimpleResponse(mv),
+		Score:      score,
+		Highlights: highlights,
+	}
+}
+
+type SearchMoviesResponse struct {
+	Pagination PaginationResponse           `json:"pagination"`
+	Results    []*MovieSearchResultResponse `json:"results"`
+}
+
+// 片单导入中每条记录的处理结果
+const (
+	ImportActionCreate    = "create"
+	ImportActionUpdate    = "update"
+	ImportActionUnchanged = "unchanged"
+	ImportActionError     = "error"
+)
+
+type ImportMovieRowResponse struct {
+	Row     int                         `json:"row"`
+	Title   string                      `json:"title"`
+	Action  string                      `json:"action"`
+	MovieID uint                        `json:"movie_id,omitempty"`
+	Changes []*movie.CatalogFieldChange `json:"changes,omitempty"`
+	Error   string                      `json:"error,omitempty"`
+}
+
+type ImportMoviesSummary struct {
+	Total     int `json:"total"`
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+	Failed    int `json:"failed"`
+}
+
+type ImportMoviesResponse struct {
+	DryRun  bool                      `json:"dry_run"`
+	Summary ImportMoviesSummary       `json:"summary"`
+	Rows    []*ImportMovieRowResponse `json:"rows"`
+}
+
+// 按处理结果累加统计
+func (r *ImportMoviesResponse) Add(row *ImportMovieRowResponse) {
+	r.Rows = append(r.Rows, row)
+	r.Summary.Total++
+	switch row.Action {
+	case ImportActionCreate:
+		r.Summary.Created++
+	case ImportActionUpdate:
+		r.Summary.Updated++
+	case ImportActionUnchanged:
+		r.Summary.Unchanged++
+	default:
+		r.Summary.Failed++
+	}
+}
+
 type GenreResponse struct {
 	ID   uint   `json:"id"`
 	Name string `json:"name"`
@@ -97,3 +199,35 @@ func ToListAllGenresResponse(genres []*movie.Genre) *ListAllGenresResponse {
 	}
 	return &ListAllGenresResponse{Genres: genreResponses}
 }
+
+// 电影翻译
+type MovieTranslationResponse struct {
+	MovieID     uint   `json:"movie_id"`
+	Locale      string `json:"locale"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+func ToMovieTranslationResponse(movieID vo.MovieID, translation *movie.MovieTranslation) *MovieTranslationResponse {
+	return &MovieTranslationResponse{
+		MovieID:     uint(movieID),
+		Locale:      string(translation.Locale),
+		Title:       translation.Title,
+		Description: translation.Description,
+	}
+}
+
+// 类型翻译
+type GenreTranslationResponse struct {
+	GenreID uint   `json:"genre_id"`
+	Locale  string `json:"locale"`
+	Name    string `json:"name"`
+}
+
+func ToGenreTranslationResponse(genreID vo.GenreID, translation *movie.GenreTranslation) *GenreTranslationResponse {
+	return &GenreTranslationResponse{
+		GenreID: uint(genreID),
+		Locale:  string(translation.Locale),
+		Name:    translation.Name,
+	}
+}
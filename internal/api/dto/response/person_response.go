@@ -0,0 +1,85 @@
+package response
+
+import (
+	"mrs/internal/domain/movie"
+	"time"
+)
+
+type PersonResponse struct {
+	ID        uint       `json:"id"`
+	Name      string     `json:"name"`
+	Bio       string     `json:"bio"`
+	BirthDate *time.Time `json:"birth_date"`
+	PhotoURL  string     `json:"photo_url"`
+}
+
+func ToPersonResponse(person *movie.Person) *PersonResponse {
+	if person == nil {
+		return nil
+	}
+	return &PersonResponse{
+		ID:        uint(person.ID),
+		Name:      person.Name,
+		Bio:       person.Bio,
+		BirthDate: person.BirthDate,
+		PhotoURL:  person.PhotoURL,
+	}
+}
+
+type PaginatedPersonResponse struct {
+	Pagination PaginationResponse `json:"pagination"`
+	People     []*PersonResponse  `json:"people"`
+}
+
+// 电影详情中的演职员
+type CreditResponse struct {
+	PersonID     uint   `json:"person_id"`
+	PersonName   string `json:"person_name"`
+	Role         string `json:"role"`
+	Character    string `json:"character,omitempty"`
+	BillingOrder int    `json:"billing_order"`
+}
+
+func ToCreditResponse(credit *movie.Credit) *CreditResponse {
+	resp := &CreditResponse{
+		Role:         string(credit.Role),
+		Character:    credit.Character,
+		BillingOrder: credit.BillingOrder,
+	}
+	if credit.Person != nil {
+		resp.PersonID = uint(credit.Person.ID)
+		resp.PersonName = credit.Person.Name
+	}
+	return resp
+}
+
+// 作品年表中的一条记录
+type FilmographyEntryResponse struct {
+	Movie        *MovieSimpleResponse `json:"movie"`
+	ReleaseDate  time.Time            `json:"release_date"`
+	Role         string               `json:"role"`
+	Character    string               `json:"character,omitempty"`
+	BillingOrder int                  `json:"billing_order"`
+}
+
+type FilmographyResponse struct {
+	Person  *PersonResponse             `json:"person"`
+	Entries []*FilmographyEntryResponse `json:"entries"`
+}
+
+func ToFilmographyResponse(person *movie.Person, entries []*movie.FilmographyEntry) *FilmographyResponse {
+	entryResponses := make([]*FilmographyEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		entryResponses = append(entryResponses, &FilmographyEntryResponse{
+			Movie:        ToMovieSimpleResponse(entry.Movie),
+			ReleaseDate:  entry.Movie.ReleaseDate,
+			Role:         string(entry.Role),
+			Character:    entry.Character,
+			BillingOrder: entry.BillingOrder,
+		})
+	}
+	return &FilmographyResponse{
+		Person:  ToPersonResponse(person),
+		Entries: entryResponses,
+	}
+}
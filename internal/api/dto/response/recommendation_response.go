@@ -0,0 +1,15 @@
+package response
+
+import "time"
+
+type RecommendationResponse struct {
+	Movie   *MovieSimpleResponse `json:"movie"`
+	Score   float64              `json:"score"`
+	Reasons []string             `json:"reasons"`
+}
+
+type RecommendationListResponse struct {
+	Source          string                    `json:"source"` // personalized | popular
+	GeneratedAt     time.Time                 `json:"generated_at"`
+	Recommendations []*RecommendationResponse `json:"recommendations"`
+}
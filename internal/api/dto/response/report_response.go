@@ -1,5 +1,12 @@
 package response
 
+import (
+	"math"
+	"mrs/internal/domain/analytics"
+	"mrs/internal/domain/booking"
+	"time"
+)
+
 type GenerateSalesReportResponse struct {
 	// 报告基本信息
 	ReportDate string `json:"report_date"` // 报告生成日期
@@ -9,4 +16,260 @@ type GenerateSalesReportResponse struct {
 	// 总体销售数据
 	TotalRevenue  float64 `json:"total_revenue"`  // 总收入
 	TotalBookings int     `json:"total_bookings"` // 总订单数
+	TotalTickets  int     `json:"total_tickets"`  // 总票数
+
+	// 统计口径
+	DateBasis   string `json:"date_basis"`            // booking | showtime
+	Timezone    string `json:"timezone"`              // 日期与时间序列使用的时区
+	Granularity string `json:"granularity,omitempty"` // 时间序列粒度
+
+	Series  []*SalesSeriesPointResponse `json:"series,omitempty"` // 时间序列
+	ByMovie []*SalesBreakdownResponse   `json:"by_movie"`         // 按电影，收入降序
+	ByHall  []*SalesBreakdownResponse   `json:"by_hall"`          // 按影厅，收入降序
+
+	PreviousPeriod *SalesPeriodComparisonResponse `json:"previous_period,omitempty"` // 上一周期对比
+}
+
+// 时间序列中的一个周期
+type SalesSeriesPointResponse struct {
+	PeriodStart time.Time                 `json:"period_start"` // 周期起始时间（报表时区）
+	Revenue     float64                   `json:"revenue"`
+	Bookings    int                       `json:"bookings"`
+	Tickets     int                       `json:"tickets"`
+	Breakdown   []*SalesBreakdownResponse `json:"breakdown,omitempty"`
+}
+
+// 按电影或影厅拆分的销售数据
+type SalesBreakdownResponse struct {
+	ID       uint    `json:"id"`
+	Name     string  `json:"name"`
+	Revenue  float64 `json:"revenue"`
+	Bookings int     `json:"bookings"`
+	Tickets  int     `json:"tickets"`
+}
+
+// 上一周期的汇总与变化比例（上一周期为0时变化比例为 null）
+type SalesPeriodComparisonResponse struct {
+	StartDate      time.Time                   `json:"start_date"`
+	EndDate        time.Time                   `json:"end_date"`
+	TotalRevenue   float64                     `json:"total_revenue"`
+	TotalBookings  int                         `json:"total_bookings"`
+	TotalTickets   int                         `json:"total_tickets"`
+	RevenueChange  *float64                    `json:"revenue_change"`
+	BookingsChange *float64                    `json:"bookings_change"`
+	TicketsChange  *float64                    `json:"tickets_change"`
+	Series         []*SalesSeriesPointResponse `json:"series,omitempty"`
+}
+
+func ToSalesBreakdownResponses(buckets []*booking.SalesBucket) []*SalesBreakdownResponse {
+	responses := make([]*SalesBreakdownResponse, len(buckets))
+	for i, bucket := range buckets {
+		responses[i] = &SalesBreakdownResponse{
+			ID:       bucket.DimensionID,
+			Name:     bucket.DimensionName,
+			Revenue:  roundAmount(bucket.Revenue),
+			Bookings: bucket.Bookings,
+			Tickets:  bucket.Tickets,
+		}
+	}
+	return responses
+}
+
+func ToSalesSeriesResponses(points []*booking.SalesSeriesPoint) []*SalesSeriesPointResponse {
+	responses := make([]*SalesSeriesPointResponse, len(points))
+	for i, point := range points {
+		responses[i] = &SalesSeriesPointResponse{
+			PeriodStart: point.PeriodStart,
+			Revenue:     roundAmount(point.Revenue),
+			Bookings:    point.Bookings,
+			Tickets:     point.Tickets,
+			Breakdown:   ToSalesBreakdownResponses(point.Breakdown),
+		}
+	}
+	return responses
+}
+
+// 变化比例保留四位小数
+func roundChange(change *float64) *float64 {
+	if change == nil {
+		return nil
+	}
+	rounded := roundRatio(*change)
+	return &rounded
+}
+
+func ToSalesPeriodComparisonResponse(start, end time.Time, current, previous *booking.SalesStatistics,
+	series []*booking.SalesSeriesPoint) *SalesPeriodComparisonResponse {
+	return &SalesPeriodComparisonResponse{
+		StartDate:      start,
+		EndDate:        end,
+		TotalRevenue:   roundAmount(previous.TotalRevenue),
+		TotalBookings:  previous.TotalBookings,
+		TotalTickets:   previous.TotalTickets,
+		RevenueChange:  roundChange(booking.SalesChange(current.TotalRevenue, previous.TotalRevenue)),
+		BookingsChange: roundChange(booking.SalesChange(float64(current.TotalBookings), float64(previous.TotalBookings))),
+		TicketsChange:  roundChange(booking.SalesChange(float64(current.TotalTickets), float64(previous.TotalTickets))),
+		Series:         ToSalesSeriesResponses(series),
+	}
+}
+
+type GenerateOccupancyReportResponse struct {
+	// 报告基本信息
+	ReportDate string `json:"report_date"` // 报告生成日期
+	StartDate  string `json:"start_date"`  // 统计开始日期
+	EndDate    string `json:"end_date"`    // 统计结束日期
+
+	Summary    *OccupancyGroupResponse      `json:"summary"`      // 所有场次汇总
+	ByMovie    []*OccupancyGroupResponse    `json:"by_movie"`     // 按电影
+	ByHall     []*OccupancyGroupResponse    `json:"by_hall"`      // 按影厅
+	ByWeekday  []*OccupancyGroupResponse    `json:"by_weekday"`   // 按星期
+	ByTimeSlot []*OccupancyGroupResponse    `json:"by_time_slot"` // 按放映时段
+	Showtimes  []*ShowtimeOccupancyResponse `json:"showtimes"`    // 各场次明细
+}
+
+// 单个场次的上座情况
+type ShowtimeOccupancyResponse struct {
+	ShowtimeID       uint      `json:"showtime_id"`
+	MovieID          uint      `json:"movie_id"`
+	MovieTitle       string    `json:"movie_title"`
+	CinemaHallID     uint      `json:"cinema_hall_id"`
+	HallName         string    `json:"hall_name"`
+	StartTime        time.Time `json:"start_time"`
+	Capacity         int       `json:"capacity"`
+	SeatsSold        int       `json:"seats_sold"`
+	LoadFactor       float64   `json:"load_factor"`        // 上座率（0~1）
+	Revenue          float64   `json:"revenue"`            // 实收金额
+	EmptySeatRevenue float64   `json:"empty_seat_revenue"` // 空座按票价全部售出可增加的收入
+}
+
+func ToShowtimeOccupancyResponse(o *booking.ShowtimeOccupancy) *ShowtimeOccupancyResponse {
+	return &ShowtimeOccupancyResponse{
+		ShowtimeID:       uint(o.ShowtimeID),
+		MovieID:          uint(o.MovieID),
+		MovieTitle:       o.MovieTitle,
+		CinemaHallID:     uint(o.CinemaHallID),
+		HallName:         o.HallName,
+		StartTime:        o.StartTime,
+		Capacity:         o.Capacity,
+		SeatsSold:        o.SeatsSold,
+		LoadFactor:       roundRatio(o.LoadFactor()),
+		Revenue:          roundAmount(o.Revenue),
+		EmptySeatRevenue: roundAmount(o.EmptySeatRevenue()),
+	}
+}
+
+// 按维度聚合的上座情况
+type OccupancyGroupResponse struct {
+	Key               string  `json:"key"`
+	Label             string  `json:"label"`
+	Showtimes         int     `json:"showtimes"`
+	Capacity          int     `json:"capacity"`
+	SeatsSold         int     `json:"seats_sold"`
+	AverageLoadFactor float64 `json:"average_load_factor"` // 各场次上座率的平均值
+	OverallLoadFactor float64 `json:"overall_load_factor"` // 总售出座位数 / 总容量
+	Revenue           float64 `json:"revenue"`
+	EmptySeatRevenue  float64 `json:"empty_seat_revenue"`
+}
+
+func ToOccupancyGroupResponse(g *booking.OccupancyGroup) *OccupancyGroupResponse {
+	return &OccupancyGroupResponse{
+		Key:               g.Key,
+		Label:             g.Label,
+		Showtimes:         g.Showtimes,
+		Capacity:          g.Capacity,
+		SeatsSold:         g.SeatsSold,
+		AverageLoadFactor: roundRatio(g.AverageLoadFactor()),
+		OverallLoadFactor: roundRatio(g.OverallLoadFactor()),
+		Revenue:           roundAmount(g.Revenue),
+		EmptySeatRevenue:  roundAmount(g.EmptySeatRevenue),
+	}
+}
+
+func ToOccupancyGroupResponses(groups []*booking.OccupancyGroup) []*OccupancyGroupResponse {
+	responses := make([]*OccupancyGroupResponse, len(groups))
+	for i, group := range groups {
+		responses[i] = ToOccupancyGroupResponse(group)
+	}
+	return responses
+}
+
+type GenerateFunnelReportResponse struct {
+	// 报告基本信息
+	ReportDate string `json:"report_date"` // 报告生成日期
+	StartDate  string `json:"start_date"`  // 统计开始日期
+	EndDate    string `json:"end_date"`    // 统计结束日期
+
+	Summary    *FunnelGroupResponse   `json:"summary"`      // 所有会话汇总
+	ByMovie    []*FunnelGroupResponse `json:"by_movie"`     // 按电影
+	ByHour     []*FunnelGroupResponse `json:"by_hour"`      // 按会话开始的小时
+	ByTimeSlot []*FunnelGroupResponse `json:"by_time_slot"` // 按会话开始的时段
+
+	UntrackedStages []string `json:"untracked_stages"` // 未记录事件的阶段（如订单过期），相应的会话计入流失
+}
+
+// 按维度聚合的转化漏斗，会话为同一用户对同一场次的一组事件
+type FunnelGroupResponse struct {
+	Key                        string   `json:"key"`
+	Label                      string   `json:"label"`
+	Sessions                   int      `json:"sessions"`
+	SeatsLocked                int      `json:"seats_locked"`
+	BookingsCreated            int      `json:"bookings_created"`
+	BookingsConfirmed          int      `json:"bookings_confirmed"`
+	BookingsCancelled          int      `json:"bookings_cancelled"`
+	Abandoned                  int      `json:"abandoned"`                      // 创建了订单但没有确认
+	LockRate                   float64  `json:"lock_rate"`                      // 锁座会话 / 会话数
+	BookingRate                float64  `json:"booking_rate"`                   // 下单会话 / 会话数
+	ConfirmRate                float64  `json:"confirm_rate"`                   // 确认会话 / 下单会话
+	ConversionRate             float64  `json:"conversion_rate"`                // 确认会话 / 会话数
+	AbandonmentRate            float64  `json:"abandonment_rate"`               // 流失会话 / 下单会话
+	MedianTimeToConfirmSeconds *float64 `json:"median_time_to_confirm_seconds"` // 取整到秒，没有确认的订单时为 null
+}
+
+func ToFunnelGroupResponse(g *analytics.FunnelGroup) *FunnelGroupResponse {
+	resp := &FunnelGroupResponse{
+		Key:               g.Key,
+		Label:             g.Label,
+		Sessions:          g.Sessions,
+		SeatsLocked:       g.Locked,
+		BookingsCreated:   g.Booked,
+		BookingsConfirmed: g.Confirmed,
+		BookingsCancelled: g.Cancelled,
+		Abandoned:         g.Abandoned(),
+		LockRate:          roundRatio(g.LockRate()),
+		BookingRate:       roundRatio(g.BookingRate()),
+		ConfirmRate:       roundRatio(g.ConfirmRate()),
+		ConversionRate:    roundRatio(g.ConversionRate()),
+		AbandonmentRate:   roundRatio(g.AbandonmentRate()),
+	}
+	if median, ok := g.MedianTimeToConfirm(); ok {
+		seconds := math.Round(median)
+		resp.MedianTimeToConfirmSeconds = &seconds
+	}
+	return resp
+}
+
+func ToFunnelGroupResponses(groups []*analytics.FunnelGroup) []*FunnelGroupResponse {
+	responses := make([]*FunnelGroupResponse, len(groups))
+	for i, group := range groups {
+		responses[i] = ToFunnelGroupResponse(group)
+	}
+	return responses
+}
+
+// 比率保留四位小数
+func roundRatio(v float64) float64 {
+	return math.Round(v*10000) / 10000
+}
+
+// 金额保留两位小数
+func roundAmount(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// 每日销售事实重建结果
+type RebuildDailySalesResponse struct {
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+	Days      int    `json:"days"` // 重建的业务日期数
+	Rows      int64  `json:"rows"` // 写入的事实行数
 }
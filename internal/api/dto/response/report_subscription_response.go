@@ -0,0 +1,100 @@
+package response
+
+import (
+	"mrs/internal/domain/report"
+	"time"
+)
+
+type ReportSubscriptionResponse struct {
+	ID         uint              `json:"id"`
+	Name       string            `json:"name"`
+	ReportType string            `json:"report_type"`
+	Parameters report.Parameters `json:"parameters"`
+	Cron       string            `json:"cron"`
+	Timezone   string            `json:"timezone,omitempty"`
+	Locale     string            `json:"locale,omitempty"`
+	Format     string            `json:"format"`
+	Channel    string            `json:"channel"`
+	Recipients []string          `json:"recipients"`
+	Enabled    bool              `json:"enabled"`
+	NextRunAt  *time.Time        `json:"next_run_at"`
+	CreatedBy  uint              `json:"created_by,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+}
+
+func ToReportSubscriptionResponse(s *report.Subscription) *ReportSubscriptionResponse {
+	if s == nil {
+		return nil
+	}
+	recipients := s.Recipients
+	if recipients == nil {
+		recipients = []string{}
+	}
+	return &ReportSubscriptionResponse{
+		ID:         uint(s.ID),
+		Name:       s.Name,
+		ReportType: string(s.ReportType),
+		Parameters: s.Parameters,
+		Cron:       s.Cron,
+		Timezone:   s.Timezone,
+		Locale:     string(s.Locale),
+		Format:     string(s.Format),
+		Channel:    string(s.Channel),
+		Recipients: recipients,
+		Enabled:    s.Enabled,
+		NextRunAt:  s.NextRunAt,
+		CreatedBy:  uint(s.CreatedBy),
+		CreatedAt:  s.CreatedAt,
+		UpdatedAt:  s.UpdatedAt,
+	}
+}
+
+type PaginatedReportSubscriptionResponse struct {
+	Pagination    PaginationResponse            `json:"pagination"`
+	Subscriptions []*ReportSubscriptionResponse `json:"subscriptions"`
+}
+
+type ReportRunResponse struct {
+	ID             uint       `json:"id"`
+	SubscriptionID uint       `json:"subscription_id"`
+	Trigger        string     `json:"trigger"`
+	ScheduledAt    time.Time  `json:"scheduled_at"`
+	Attempt        int        `json:"attempt"`
+	Status         string     `json:"status"`
+	PeriodStart    time.Time  `json:"period_start"`
+	PeriodEnd      time.Time  `json:"period_end"`
+	FileName       string     `json:"file_name,omitempty"`
+	FileSize       int64      `json:"file_size,omitempty"`
+	Error          string     `json:"error,omitempty"`
+	RetryAt        *time.Time `json:"retry_at,omitempty"`
+	StartedAt      time.Time  `json:"started_at"`
+	FinishedAt     *time.Time `json:"finished_at"`
+}
+
+func ToReportRunResponse(r *report.Run) *ReportRunResponse {
+	if r == nil {
+		return nil
+	}
+	return &ReportRunResponse{
+		ID:             uint(r.ID),
+		SubscriptionID: uint(r.SubscriptionID),
+		Trigger:        string(r.Trigger),
+		ScheduledAt:    r.ScheduledAt,
+		Attempt:        r.Attempt,
+		Status:         string(r.Status),
+		PeriodStart:    r.PeriodStart,
+		PeriodEnd:      r.PeriodEnd,
+		FileName:       r.FileName,
+		FileSize:       r.FileSize,
+		Error:          r.Error,
+		RetryAt:        r.RetryAt,
+		StartedAt:      r.StartedAt,
+		FinishedAt:     r.FinishedAt,
+	}
+}
+
+type PaginatedReportRunResponse struct {
+	Pagination PaginationResponse   `json:"pagination"`
+	Runs       []*ReportRunResponse `json:"runs"`
+}
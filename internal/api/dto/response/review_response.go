@@ -0,0 +1,42 @@
+package response
+
+import (
+	"mrs/internal/domain/review"
+	"time"
+)
+
+type ReviewResponse struct {
+	ID             uint      `json:"id"`
+	MovieID        uint      `json:"movie_id"`
+	UserID         uint      `json:"user_id"`
+	Score          int       `json:"score"`
+	Content        string    `json:"content"`
+	Status         string    `json:"status"`
+	ModerationNote string    `json:"moderation_note,omitempty"`
+	HelpfulCount   int       `json:"helpful_count"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+func ToReviewResponse(rv *review.Review) *ReviewResponse {
+	if rv == nil {
+		return nil
+	}
+	return &ReviewResponse{
+		ID:             uint(rv.ID),
+		MovieID:        uint(rv.MovieID),
+		UserID:         uint(rv.UserID),
+		Score:          rv.Score,
+		Content:        rv.Content,
+		Status:         string(rv.Status),
+		ModerationNote: rv.ModerationNote,
+		HelpfulCount:   rv.HelpfulCount,
+		CreatedAt:      rv.CreatedAt,
+		UpdatedAt:      rv.UpdatedAt,
+	}
+}
+
+type PaginatedReviewResponse struct {
+	Pagination PaginationResponse `json:"pagination"`
+	Reviews    []*ReviewResponse  `json:"reviews"`
+}
@@ -6,6 +6,21 @@ import (
 	"time"
 )
 
+// 无障碍服务标记
+type AccessibilityResponse struct {
+	AudioDescription bool `json:"audio_description"`
+	ClosedCaptions   bool `json:"closed_captions"`
+	SensoryFriendly  bool `json:"sensory_friendly"`
+}
+
+func ToAccessibilityResponse(a showtime.Accessibility) *AccessibilityResponse {
+	return &AccessibilityResponse{
+		AudioDescription: a.AudioDescription,
+		ClosedCaptions:   a.ClosedCaptions,
+		SensoryFriendly:  a.SensoryFriendly,
+	}
+}
+
 // 返回完整的showtime信息
 type ShowtimeResponse struct {
 	ID         uint                      `json:"id"`
@@ -14,6 +29,14 @@ type ShowtimeResponse struct {
 	StartTime  time.Time                 `json:"start_time"`
 	EndTime    time.Time                 `json:"end_time"`
 	Price      float64                   `json:"price"`
+	Status     string                    `json:"status"`
+
+	Format           string                 `json:"format"`
+	AudioLanguage    string                 `json:"audio_language"`
+	SubtitleLanguage string                 `json:"subtitle_language"`
+	Accessibility    *AccessibilityResponse `json:"accessibility"`
+
+	LayoutVersion int `json:"layout_version"` // 场次固定的影厅布局版本
 }
 
 func ToShowtimeResponse(showtime *showtime.Showtime) *ShowtimeResponse {
@@ -27,6 +50,14 @@ func ToShowtimeResponse(showtime *showtime.Showtime) *ShowtimeResponse {
 		StartTime:  showtime.StartTime,
 		EndTime:    showtime.EndTime,
 		Price:      showtime.Price,
+		Status:     string(showtime.Status),
+
+		Format:           string(showtime.Format),
+		AudioLanguage:    showtime.AudioLanguage,
+		SubtitleLanguage: showtime.SubtitleLanguage,
+		Accessibility:    ToAccessibilityResponse(showtime.Accessibility),
+
+		LayoutVersion: showtime.LayoutVersion,
 	}
 }
 
@@ -36,6 +67,12 @@ type ShowtimeSimpleResponse struct {
 	CinemaHallID uint      `json:"cinema_hall_id"`
 	StartTime    time.Time `json:"start_time"`
 	EndTime      time.Time `json:"end_time"`
+	Status       string    `json:"status"`
+
+	Format           string                 `json:"format"`
+	AudioLanguage    string                 `json:"audio_language"`
+	SubtitleLanguage string                 `json:"subtitle_language"`
+	Accessibility    *AccessibilityResponse `json:"accessibility"`
 }
 
 func ToShowtimeSimpleResponse(showtime *showtime.Showtime) *ShowtimeSimpleResponse {
@@ -45,6 +82,12 @@ func ToShowtimeSimpleResponse(showtime *showtime.Showtime) *ShowtimeSimpleRespon
 		CinemaHallID: uint(showtime.CinemaHallID),
 		StartTime:    showtime.StartTime,
 		EndTime:      showtime.EndTime,
+		Status:       string(showtime.Status),
+
+		Format:           string(showtime.Format),
+		AudioLanguage:    showtime.AudioLanguage,
+		SubtitleLanguage: showtime.SubtitleLanguage,
+		Accessibility:    ToAccessibilityResponse(showtime.Accessibility),
 	}
 }
 
@@ -55,5 +98,65 @@ type PaginatedShowtimeResponse struct {
 
 // 座位表
 type SeatMapResponse struct {
-	Seats []*cinema.SeatInfo `json:"seats"`
+	Layout *cinema.HallLayout `json:"layout"` // 影厅几何布局，用于渲染过道、台阶、屏幕位置等
+	Seats  []*cinema.SeatInfo `json:"seats"`
+
+	Distancing bool `json:"distancing"` // 是否按社交距离策略封锁了相邻座位
+}
+
+func ToSeatMapResponse(seatMap *cinema.SeatMap) *SeatMapResponse {
+	return &SeatMapResponse{
+		Layout: seatMap.Layout,
+		Seats:  seatMap.Seats,
+
+		Distancing: seatMap.Distancing,
+	}
+}
+
+// 取消场次的汇总报告
+type CancelShowtimeResponse struct {
+	ShowtimeID        uint      `json:"showtime_id"`
+	Status            string    `json:"status"`
+	CancelledAt       time.Time `json:"cancelled_at"`
+	Reason            string    `json:"reason"`
+	TotalBookings     int       `json:"total_bookings"`     // 处理的有效订单总数
+	CancelledBookings int       `json:"cancelled_bookings"` // 取消的待支付订单数
+	RefundedBookings  int       `json:"refunded_bookings"`  // 退款的已确认订单数
+	RefundAmount      float64   `json:"refund_amount"`      // 退款总金额
+	ReleasedSeats     int       `json:"released_seats"`     // 释放的座位数
+	NotifiedUsers     int       `json:"notified_users"`     // 通知的用户数
+	Batches           int       `json:"batches"`            // 处理批次数
+}
+
+// 布局迁移冲突：已售座位在目标版本中不存在
+type LayoutMigrationConflictResponse struct {
+	BookingID     uint   `json:"booking_id"`
+	SeatID        uint   `json:"seat_id"`
+	RowIdentifier string `json:"row_identifier"`
+	SeatNumber    string `json:"seat_number"`
+	Reason        string `json:"reason"`
+}
+
+// 单个场次的布局迁移结果
+type ShowtimeLayoutMigrationResponse struct {
+	ShowtimeID      uint                               `json:"showtime_id"`
+	StartTime       time.Time                          `json:"start_time"`
+	FromVersion     int                                `json:"from_version"`
+	Migrated        bool                               `json:"migrated"`
+	WouldMigrate    bool                               `json:"would_migrate"`    // dry_run 时没有冲突、可以迁移
+	ReassignedSeats int                                `json:"reassigned_seats"` // 改写到新版本座位的已售座位数
+	Conflicts       []*LayoutMigrationConflictResponse `json:"conflicts"`
+	Error           string                             `json:"error,omitempty"`
+}
+
+// 布局迁移汇总报告
+type MigrateShowtimeLayoutsResponse struct {
+	CinemaHallID  uint                               `json:"cinema_hall_id"`
+	TargetVersion int                                `json:"target_version"`
+	DryRun        bool                               `json:"dry_run"`
+	Migrated      int                                `json:"migrated"`      // 已迁移的场次数（dry_run 时为0）
+	WouldMigrate  int                                `json:"would_migrate"` // dry_run 时可迁移的场次数
+	Conflicted    int                                `json:"conflicted"`    // 存在冲突而保持原版本的场次数
+	Failed        int                                `json:"failed"`        // 迁移失败的场次数
+	Showtimes     []*ShowtimeLayoutMigrationResponse `json:"showtimes"`
 }
@@ -0,0 +1,30 @@
+package response
+
+import "time"
+
+type TrendingMovieResponse struct {
+	Rank    int                  `json:"rank"`
+	Movie   *MovieSimpleResponse `json:"movie"`
+	Tickets int                  `json:"tickets"`
+}
+
+type TrendingMoviesResponse struct {
+	Window      string                   `json:"window"`
+	GeneratedAt time.Time                `json:"generated_at"`
+	Movies      []*TrendingMovieResponse `json:"movies"`
+}
+
+type TrendingShowtimeResponse struct {
+	Rank           int                     `json:"rank"`
+	Showtime       *ShowtimeSimpleResponse `json:"showtime"`
+	MovieTitle     string                  `json:"movie_title"`
+	CinemaHallName string                  `json:"cinema_hall_name"`
+	Tickets        int                     `json:"tickets"`
+}
+
+type LeaderboardResponse struct {
+	Window      string                      `json:"window"`
+	GeneratedAt time.Time                   `json:"generated_at"`
+	Movies      []*TrendingMovieResponse    `json:"movies"`
+	Showtimes   []*TrendingShowtimeResponse `json:"showtimes"`
+}
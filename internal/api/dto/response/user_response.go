@@ -1,12 +1,17 @@
 package response
 
-import "mrs/internal/domain/user"
+import (
+	"mrs/internal/domain/user"
+	"time"
+)
 
 type UserProfileResponse struct {
 	// ID       uint   `json:"id"`
 	Username string `json:"username"`
 	Email    string `json:"email"`
 	RoleName string `json:"role_name"` // 来自关联的 Role 实体的 Name 字段
+
+	BirthDate *time.Time `json:"birth_date,omitempty"`
 	// CreateAt time.Time `json:"create_at"`
 	// UpdateAt time.Time `json:"update_at"`
 	// IsActive bool      `json:"is_active"`
@@ -21,6 +26,8 @@ func ToUserProfileResponse(user *user.User) *UserProfileResponse {
 		Username: user.Username,
 		Email:    user.Email,
 		RoleName: user.Role.Name,
+
+		BirthDate: user.BirthDate,
 	}
 }
 
@@ -30,6 +37,8 @@ type UserResponse struct {
 	Email    string `json:"email"`
 	RoleName string `json:"role_name"`
 	RoleID   uint   `json:"role_id"`
+
+	BirthDate *time.Time `json:"birth_date,omitempty"`
 }
 
 func ToUserResponse(user *user.User) *UserResponse {
@@ -42,6 +51,8 @@ func ToUserResponse(user *user.User) *UserResponse {
 		Email:    user.Email,
 		RoleName: user.Role.Name,
 		RoleID:   uint(user.Role.ID),
+
+		BirthDate: user.BirthDate,
 	}
 }
 
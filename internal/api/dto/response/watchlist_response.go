@@ -0,0 +1,36 @@
+package response
+
+import (
+	"mrs/internal/domain/watchlist"
+	"time"
+)
+
+type WatchlistEntryResponse struct {
+	ID                uint                 `json:"id"`
+	MovieID           uint                 `json:"movie_id"`
+	Movie             *MovieSimpleResponse `json:"movie,omitempty"`
+	PreferredCinemaID uint                 `json:"preferred_cinema_id,omitempty"`
+	PreferredHallID   uint                 `json:"preferred_hall_id,omitempty"`
+	NotifiedAt        *time.Time           `json:"notified_at"`
+	CreatedAt         time.Time            `json:"created_at"`
+}
+
+func ToWatchlistEntryResponse(entry *watchlist.WatchlistEntry) *WatchlistEntryResponse {
+	if entry == nil {
+		return nil
+	}
+	return &WatchlistEntryResponse{
+		ID:                uint(entry.ID),
+		MovieID:           uint(entry.MovieID),
+		Movie:             ToMovieSimpleResponse(entry.Movie),
+		PreferredCinemaID: uint(entry.PreferredCinemaID),
+		PreferredHallID:   uint(entry.PreferredHallID),
+		NotifiedAt:        entry.NotifiedAt,
+		CreatedAt:         entry.CreatedAt,
+	}
+}
+
+type PaginatedWatchlistResponse struct {
+	Pagination PaginationResponse        `json:"pagination"`
+	Entries    []*WatchlistEntryResponse `json:"entries"`
+}
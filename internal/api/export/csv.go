@@ -0,0 +1,82 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// UTF-8 BOM，使电子表格软件正确识别中文等非 ASCII 字符
+const utf8BOM = "\ufeff"
+
+type csvWriter struct {
+	w        io.Writer
+	csv      *csv.Writer
+	format   NumberFormat
+	columns  []Column
+	sections bool
+	tables   int
+}
+
+func newCSVWriter(w io.Writer, format NumberFormat, sections bool) *csvWriter {
+	writer := csv.NewWriter(w)
+	writer.Comma = format.Separator
+	return &csvWriter{w: w, csv: writer, format: format, sections: sections}
+}
+
+func (c *csvWriter) BeginTable(name string, columns []Column) error {
+	if c.tables == 0 {
+		if _, err := io.WriteString(c.w, utf8BOM); err != nil {
+			return fmt.Errorf("write csv bom error: %w", err)
+		}
+	}
+	c.columns = columns
+	c.tables++
+
+	// 单表导出保持标准的"表头 + 数据行"格式，便于其他程序读取
+	if c.sections {
+		if c.tables > 1 {
+			// 空行，encoding/csv 会把空记录写成空行
+			if err := c.csv.Write([]string{""}); err != nil {
+				return fmt.Errorf("write csv separator error: %w", err)
+			}
+		}
+		if err := c.csv.Write([]string{name}); err != nil {
+			return fmt.Errorf("write csv title error: %w", err)
+		}
+	}
+
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = column.Header
+	}
+	if err := c.csv.Write(headers); err != nil {
+		return fmt.Errorf("write csv header error: %w", err)
+	}
+	return nil
+}
+
+func (c *csvWriter) WriteRow(values ...any) error {
+	record := make([]string, len(c.columns))
+	for i, column := range c.columns {
+		if i < len(values) {
+			value, err := c.format.format(column, values[i])
+			if err != nil {
+				return fmt.Errorf("write csv row error: column %s: %w", column.Key, err)
+			}
+			record[i] = value
+		}
+	}
+	if err := c.csv.Write(record); err != nil {
+		return fmt.Errorf("write csv row error: %w", err)
+	}
+	return nil
+}
+
+func (c *csvWriter) Close() error {
+	c.csv.Flush()
+	if err := c.csv.Error(); err != nil {
+		return fmt.Errorf("flush csv error: %w", err)
+	}
+	return nil
+}
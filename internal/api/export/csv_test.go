@@ -0,0 +1,193 @@
+package export
+
+import (
+	"bytes"
+	"errors"
+	"mrs/internal/domain/shared/vo"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testColumns = []Column{
+	{Key: "name", Header: "Name", Type: ColumnText},
+	{Key: "tickets", Header: "Tickets", Type: ColumnInteger},
+	{Key: "revenue", Header: "Revenue", Type: ColumnAmount},
+	{Key: "load_factor", Header: "Load Factor", Type: ColumnRatio},
+	{Key: "start_time", Header: "Start Time", Type: ColumnTime},
+}
+
+func writeCSV(t *testing.T, locale vo.Locale, sections bool, tables map[string][][]any, order ...string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewTableWriter(FormatCSV, &buf, Options{Locale: locale, Sections: sections})
+	if err != nil {
+		t.Fatalf("NewTableWriter failed: %v", err)
+	}
+	for _, name := range order {
+		if err := w.BeginTable(name, testColumns); err != nil {
+			t.Fatalf("BeginTable(%q) failed: %v", name, err)
+		}
+		for _, row := range tables[name] {
+			if err := w.WriteRow(row...); err != nil {
+				t.Fatalf("WriteRow(%v) failed: %v", row, err)
+			}
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	out := buf.String()
+	if !strings.HasPrefix(out, utf8BOM) {
+		t.Fatalf("csv output does not start with BOM: %q", out)
+	}
+	return strings.TrimPrefix(out, utf8BOM)
+}
+
+func TestCSVWriter_NumberFormatByLocale(t *testing.T) {
+	start := time.Date(2026, 10, 18, 19, 30, 0, 0, time.FixedZone("CST", 8*3600))
+	var missing *float64
+	rows := map[string][][]any{
+		"summary": {
+			{"Hall 1, IMAX", 1234, 1234.5, 0.123456, start},
+			{"Hall 2; VIP", int32(7), float32(0.25), missing, time.Time{}},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		locale vo.Locale
+		want   string
+	}{
+		{
+			name:   "decimal point and comma separator",
+			locale: "en-US",
+			want: "Name,Tickets,Revenue,Load Factor,Start Time\n" +
+				"\"Hall 1, IMAX\",1234,1234.50,0.1235,2026-10-18 19:30:00\n" +
+				"Hall 2; VIP,7,0.25,,\n",
+		},
+		{
+			name:   "decimal comma and semicolon separator",
+			locale: "de-DE",
+			want: "Name;Tickets;Revenue;Load Factor;Start Time\n" +
+				"Hall 1, IMAX;1234;1234,50;0,1235;2026-10-18 19:30:00\n" +
+				"\"Hall 2; VIP\";7;0,25;;\n",
+		},
+		{
+			name:   "language without region",
+			locale: "fr",
+			want: "Name;Tickets;Revenue;Load Factor;Start Time\n" +
+				"Hall 1, IMAX;1234;1234,50;0,1235;2026-10-18 19:30:00\n" +
+				"\"Hall 2; VIP\";7;0,25;;\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := writeCSV(t, tt.locale, false, rows, "summary")
+			if got != tt.want {
+				t.Errorf("csv output =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCSVWriter_Sections(t *testing.T) {
+	rows := map[string][][]any{
+		"Summary":  {{"all", 2, 10, 0.5, nil}},
+		"By Movie": {{"Dune", 2, 10, 0.5, nil}},
+	}
+	got := writeCSV(t, "en", true, rows, "Summary", "By Movie")
+	want := "Summary\n" +
+		"Name,Tickets,Revenue,Load Factor,Start Time\n" +
+		"all,2,10.00,0.5000,\n" +
+		"\n" +
+		"By Movie\n" +
+		"Name,Tickets,Revenue,Load Factor,Start Time\n" +
+		"Dune,2,10.00,0.5000,\n"
+	if got != want {
+		t.Errorf("csv output =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestCSVWriter_RejectsNonNumericValue(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewTableWriter(FormatCSV, &buf, Options{Locale: "en"})
+	if err != nil {
+		t.Fatalf("NewTableWriter failed: %v", err)
+	}
+	if err := w.BeginTable("summary", testColumns); err != nil {
+		t.Fatalf("BeginTable failed: %v", err)
+	}
+	if err := w.WriteRow("x", "12", 1.0, 0.5, nil); !errors.Is(err, ErrNonNumericValue) {
+		t.Errorf("WriteRow with string in integer column error = %v, want %v", err, ErrNonNumericValue)
+	}
+}
+
+func TestToFloat(t *testing.T) {
+	n, f := 42, 2.5
+	var nilInt *int
+
+	tests := []struct {
+		name    string
+		value   any
+		want    float64
+		wantErr bool
+	}{
+		{"int", 3, 3, false},
+		{"int8", int8(-8), -8, false},
+		{"int16", int16(16), 16, false},
+		{"int32", int32(-32), -32, false},
+		{"int64", int64(1 << 40), 1 << 40, false},
+		{"uint8", uint8(8), 8, false},
+		{"uint16", uint16(16), 16, false},
+		{"uint32", uint32(32), 32, false},
+		{"uint64", uint64(64), 64, false},
+		{"float32", float32(0.5), 0.5, false},
+		{"float64", 1.25, 1.25, false},
+		{"named uint", vo.MovieID(7), 7, false},
+		{"pointer to int", &n, 42, false},
+		{"pointer to float64", &f, 2.5, false},
+		{"nil pointer", nilInt, 0, true},
+		{"string", "12", 0, true},
+		{"bool", true, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toFloat(tt.value)
+			if tt.wantErr {
+				if !errors.Is(err, ErrNonNumericValue) {
+					t.Errorf("toFloat(%v) error = %v, want %v", tt.value, err, ErrNonNumericValue)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("toFloat(%v) failed: %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("toFloat(%v) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatInteger(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{int32(-5), "-5"},
+		{uint64(18446744073709551615), "18446744073709551615"},
+		{2.5, "3"},
+		{2.4, "2"},
+		{vo.ShowtimeID(12), "12"},
+	}
+	for _, tt := range tests {
+		got, err := formatInteger(tt.value)
+		if err != nil {
+			t.Fatalf("formatInteger(%v) failed: %v", tt.value, err)
+		}
+		if got != tt.want {
+			t.Errorf("formatInteger(%v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
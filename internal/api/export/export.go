@@ -0,0 +1,224 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"mrs/internal/domain/shared/vo"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 导出格式
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported export format")
+
+// 列的数据类型，决定单元格的格式
+type ColumnType int
+
+const (
+	ColumnText    ColumnType = iota
+	ColumnInteger            // 整数，千分位
+	ColumnAmount             // 金额，两位小数
+	ColumnRatio              // 比率（0~1），XLSX 中显示为百分比
+	ColumnTime               // 时间，按值自身的时区显示
+)
+
+// 表格列
+type Column struct {
+	Key    string // JSON 字段名
+	Header string // 表头（已本地化）
+	Type   ColumnType
+}
+
+// 表格写入器：逐行写入，不在内存中保留已写入的行
+// 一次导出可以包含多个表：CSV 中依次排列，XLSX 中为多个工作表，JSON 中为多个数组字段
+type TableWriter interface {
+	// 开始一个新表，写入表头
+	BeginTable(name string, columns []Column) error
+	// 写入一行，值的顺序与列一致，支持 string、整数、浮点数与 time.Time
+	WriteRow(values ...any) error
+	// 结束导出，写入文件尾部（不关闭底层 io.Writer）
+	Close() error
+}
+
+// 导出选项
+type Options struct {
+	// 决定 CSV 中的数字格式
+	Locale vo.Locale
+	// 导出包含多个表：CSV 中每个表前写入表名作为标题行，表之间以空行分隔
+	Sections bool
+}
+
+// 创建表格写入器
+func NewTableWriter(format Format, w io.Writer, opts Options) (TableWriter, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w, NumberFormatFor(opts.Locale), opts.Sections), nil
+	case FormatXLSX:
+		return newXLSXWriter(w), nil
+	case FormatJSON:
+		return newJSONWriter(w), nil
+	default:
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedFormat, format)
+	}
+}
+
+// 响应的 Content-Type
+func ContentType(format Format) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "application/json; charset=utf-8"
+	}
+}
+
+// 导出文件名，形如 sales-report-20261018-153000.csv
+func FileName(name string, format Format, now time.Time) string {
+	return fmt.Sprintf("%s-%s.%s", name, now.Format("20060102-150405"), format)
+}
+
+// 响应的 Content-Disposition
+func ContentDisposition(name string, format Format, now time.Time) string {
+	return fmt.Sprintf(`attachment; filename="%s"`, FileName(name, format, now))
+}
+
+// 数字格式：小数点与 CSV 分隔符
+type NumberFormat struct {
+	Decimal   string
+	Separator rune
+}
+
+// 以逗号作为小数点的语言，CSV 改用分号分隔字段，与这些地区的电子表格默认设置一致
+var commaDecimalLanguages = map[string]bool{
+	"de": true, "fr": true, "es": true, "it": true, "pt": true, "nl": true,
+	"ru": true, "pl": true, "tr": true, "sv": true, "da": true, "fi": true,
+	"nb": true, "cs": true, "id": true, "vi": true,
+}
+
+func NumberFormatFor(locale vo.Locale) NumberFormat {
+	if commaDecimalLanguages[locale.Language()] {
+		return NumberFormat{Decimal: ",", Separator: ';'}
+	}
+	return NumberFormat{Decimal: ".", Separator: ','}
+}
+
+// 按列类型把值格式化为文本（CSV 使用）
+func (f NumberFormat) format(column Column, value any) (string, error) {
+	if isNull(value) {
+		return "", nil
+	}
+	switch column.Type {
+	case ColumnAmount, ColumnRatio:
+		n, err := toFloat(value)
+		if err != nil {
+			return "", err
+		}
+		precision := 2
+		if column.Type == ColumnRatio {
+			precision = 4
+		}
+		return f.decimal(strconv.FormatFloat(n, 'f', precision, 64)), nil
+	case ColumnInteger:
+		return formatInteger(value)
+	case ColumnTime:
+		if t, ok := value.(time.Time); ok {
+			if t.IsZero() {
+				return "", nil
+			}
+			return t.Format("2006-01-02 15:04:05"), nil
+		}
+	}
+	return toString(value), nil
+}
+
+func (f NumberFormat) decimal(s string) string {
+	if f.Decimal == "." {
+		return s
+	}
+	return strings.Replace(s, ".", f.Decimal, 1)
+}
+
+// 数值列的值不是数字（或指向数字的指针）时返回错误，避免写出错误的0
+var ErrNonNumericValue = errors.New("non-numeric value in numeric column")
+
+// 数值（含自定义数值类型与指向数值的指针）的底层值
+func numericValue(value any) (reflect.Value, error) {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return v, nil
+	}
+	return reflect.Value{}, fmt.Errorf("%w: %T", ErrNonNumericValue, value)
+}
+
+func toFloat(value any) (float64, error) {
+	v, err := numericValue(value)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case v.CanInt():
+		return float64(v.Int()), nil
+	case v.CanUint():
+		return float64(v.Uint()), nil
+	default:
+		return v.Float(), nil
+	}
+}
+
+// 整数列：整数原样输出，浮点数四舍五入
+func formatInteger(value any) (string, error) {
+	v, err := numericValue(value)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case v.CanInt():
+		return strconv.FormatInt(v.Int(), 10), nil
+	case v.CanUint():
+		return strconv.FormatUint(v.Uint(), 10), nil
+	default:
+		return strconv.FormatFloat(math.Round(v.Float()), 'f', 0, 64), nil
+	}
+}
+
+// 空值（nil 或空指针）导出为空单元格
+func isNull(value any) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
+
+func toString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
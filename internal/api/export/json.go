@@ -0,0 +1,90 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// JSON 写入器：输出 {"表名": [{列: 值}, ...], ...}，逐行编码写出
+type jsonWriter struct {
+	w       *bufio.Writer
+	columns []Column
+	tables  int
+	rows    int
+}
+
+func newJSONWriter(w io.Writer) *jsonWriter {
+	return &jsonWriter{w: bufio.NewWriter(w)}
+}
+
+func (j *jsonWriter) BeginTable(name string, columns []Column) error {
+	prefix := "{"
+	if j.tables > 0 {
+		prefix = "],"
+	}
+	key, err := json.Marshal(name)
+	if err != nil {
+		return fmt.Errorf("encode json table name error: %w", err)
+	}
+	if _, err := fmt.Fprintf(j.w, "%s%s:[", prefix, key); err != nil {
+		return fmt.Errorf("write json table error: %w", err)
+	}
+	j.columns = columns
+	j.tables++
+	j.rows = 0
+	return nil
+}
+
+func (j *jsonWriter) WriteRow(values ...any) error {
+	if j.rows > 0 {
+		if err := j.w.WriteByte(','); err != nil {
+			return fmt.Errorf("write json row error: %w", err)
+		}
+	}
+	j.rows++
+
+	if err := j.w.WriteByte('{'); err != nil {
+		return fmt.Errorf("write json row error: %w", err)
+	}
+	for i, column := range j.columns {
+		var value any
+		if i < len(values) && !isNull(values[i]) {
+			value = values[i]
+			if t, ok := value.(time.Time); ok && t.IsZero() {
+				value = nil
+			}
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("encode json value error: %w", err)
+		}
+		key, _ := json.Marshal(column.Key)
+		if i > 0 {
+			j.w.WriteByte(',')
+		}
+		j.w.Write(key)
+		j.w.WriteByte(':')
+		j.w.Write(data)
+	}
+	if err := j.w.WriteByte('}'); err != nil {
+		return fmt.Errorf("write json row error: %w", err)
+	}
+	return nil
+}
+
+func (j *jsonWriter) Close() error {
+	suffix := "]}"
+	if j.tables == 0 {
+		suffix = "{}"
+	}
+	if _, err := j.w.WriteString(suffix); err != nil {
+		return fmt.Errorf("write json error: %w", err)
+	}
+	if err := j.w.Flush(); err != nil {
+		return fmt.Errorf("flush json error: %w", err)
+	}
+	return nil
+}
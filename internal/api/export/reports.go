@@ -0,0 +1,302 @@
+package export
+
+import (
+	"mrs/internal/api/dto/response"
+	"mrs/internal/domain/booking"
+)
+
+// 报表的表格生成器，列定义与数据在同一处维护，表名与表头通过 Label 本地化
+type Tables struct {
+	W     TableWriter
+	Label func(key string) string
+}
+
+// 开始一个新表，name 与列的 Key 为本地化的键
+func (t *Tables) Begin(name string, columns ...Column) error {
+	columns = append([]Column(nil), columns...)
+	for i := range columns {
+		columns[i].Header = t.Label(columns[i].Key)
+	}
+	return t.W.BeginTable(t.Label(name), columns)
+}
+
+func col(key string, columnType ColumnType) Column {
+	return Column{Key: key, Type: columnType}
+}
+
+// 销售报告：汇总、上一周期对比、时间序列以及按电影与影厅的拆分
+func WriteSalesReport(t *Tables, resp *response.GenerateSalesReportResponse) error {
+	if err := t.Begin("summary",
+		col("report_date", ColumnText),
+		col("start_date", ColumnText),
+		col("end_date", ColumnText),
+		col("date_basis", ColumnText),
+		col("timezone", ColumnText),
+		col("total_revenue", ColumnAmount),
+		col("total_bookings", ColumnInteger),
+		col("total_tickets", ColumnInteger),
+	); err != nil {
+		return err
+	}
+	if err := t.W.WriteRow(resp.ReportDate, resp.StartDate, resp.EndDate, resp.DateBasis, resp.Timezone,
+		resp.TotalRevenue, resp.TotalBookings, resp.TotalTickets); err != nil {
+		return err
+	}
+
+	if prev := resp.PreviousPeriod; prev != nil {
+		if err := t.Begin("previous_period",
+			col("start_date", ColumnTime),
+			col("end_date", ColumnTime),
+			col("total_revenue", ColumnAmount),
+			col("total_bookings", ColumnInteger),
+			col("total_tickets", ColumnInteger),
+			col("revenue_change", ColumnRatio),
+			col("bookings_change", ColumnRatio),
+			col("tickets_change", ColumnRatio),
+		); err != nil {
+			return err
+		}
+		if err := t.W.WriteRow(prev.StartDate, prev.EndDate, prev.TotalRevenue, prev.TotalBookings, prev.TotalTickets,
+			prev.RevenueChange, prev.BookingsChange, prev.TicketsChange); err != nil {
+			return err
+		}
+	}
+
+	if resp.Series != nil {
+		if err := writeSalesSeriesTables(t, "series", "series_breakdown", resp.Series); err != nil {
+			return err
+		}
+	}
+	if resp.PreviousPeriod != nil && resp.PreviousPeriod.Series != nil {
+		if err := writeSalesSeriesTables(t, "previous_series", "", resp.PreviousPeriod.Series); err != nil {
+			return err
+		}
+	}
+
+	for _, breakdown := range []struct {
+		name  string
+		items []*response.SalesBreakdownResponse
+	}{{"by_movie", resp.ByMovie}, {"by_hall", resp.ByHall}} {
+		if err := t.Begin(breakdown.name,
+			col("id", ColumnText),
+			col("name", ColumnText),
+			col("revenue", ColumnAmount),
+			col("bookings", ColumnInteger),
+			col("tickets", ColumnInteger),
+		); err != nil {
+			return err
+		}
+		for _, item := range breakdown.items {
+			if err := t.W.WriteRow(item.ID, item.Name, item.Revenue, item.Bookings, item.Tickets); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// 时间序列表；breakdownName 不为空且序列包含拆分时，另外输出一张拆分表
+func writeSalesSeriesTables(t *Tables, name, breakdownName string, series []*response.SalesSeriesPointResponse) error {
+	if err := t.Begin(name,
+		col("period_start", ColumnTime),
+		col("revenue", ColumnAmount),
+		col("bookings", ColumnInteger),
+		col("tickets", ColumnInteger),
+	); err != nil {
+		return err
+	}
+	hasBreakdown := false
+	for _, point := range series {
+		if err := t.W.WriteRow(point.PeriodStart, point.Revenue, point.Bookings, point.Tickets); err != nil {
+			return err
+		}
+		hasBreakdown = hasBreakdown || len(point.Breakdown) > 0
+	}
+	if breakdownName == "" || !hasBreakdown {
+		return nil
+	}
+
+	if err := t.Begin(breakdownName,
+		col("period_start", ColumnTime),
+		col("id", ColumnText),
+		col("name", ColumnText),
+		col("revenue", ColumnAmount),
+		col("bookings", ColumnInteger),
+		col("tickets", ColumnInteger),
+	); err != nil {
+		return err
+	}
+	for _, point := range series {
+		for _, item := range point.Breakdown {
+			if err := t.W.WriteRow(point.PeriodStart, item.ID, item.Name, item.Revenue, item.Bookings, item.Tickets); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// 上座率报告：各维度的汇总与场次明细
+func WriteOccupancyReport(t *Tables, resp *response.GenerateOccupancyReportResponse) error {
+	groups := []struct {
+		name  string
+		items []*response.OccupancyGroupResponse
+	}{
+		{"summary", []*response.OccupancyGroupResponse{resp.Summary}},
+		{"by_movie", resp.ByMovie},
+		{"by_hall", resp.ByHall},
+		{"by_weekday", resp.ByWeekday},
+		{"by_time_slot", resp.ByTimeSlot},
+	}
+	for _, group := range groups {
+		if err := t.Begin(group.name,
+			col("key", ColumnText),
+			col("label", ColumnText),
+			col("showtimes", ColumnInteger),
+			col("capacity", ColumnInteger),
+			col("seats_sold", ColumnInteger),
+			col("average_load_factor", ColumnRatio),
+			col("overall_load_factor", ColumnRatio),
+			col("revenue", ColumnAmount),
+			col("empty_seat_revenue", ColumnAmount),
+		); err != nil {
+			return err
+		}
+		for _, item := range group.items {
+			if err := t.W.WriteRow(item.Key, item.Label, item.Showtimes, item.Capacity, item.SeatsSold,
+				item.AverageLoadFactor, item.OverallLoadFactor, item.Revenue, item.EmptySeatRevenue); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := t.Begin("showtimes",
+		col("showtime_id", ColumnText),
+		col("movie_id", ColumnText),
+		col("movie_title", ColumnText),
+		col("cinema_hall_id", ColumnText),
+		col("hall_name", ColumnText),
+		col("start_time", ColumnTime),
+		col("capacity", ColumnInteger),
+		col("seats_sold", ColumnInteger),
+		col("load_factor", ColumnRatio),
+		col("revenue", ColumnAmount),
+		col("empty_seat_revenue", ColumnAmount),
+	); err != nil {
+		return err
+	}
+	for _, item := range resp.Showtimes {
+		if err := t.W.WriteRow(item.ShowtimeID, item.MovieID, item.MovieTitle, item.CinemaHallID, item.HallName,
+			item.StartTime, item.Capacity, item.SeatsSold, item.LoadFactor, item.Revenue, item.EmptySeatRevenue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 转化漏斗报告：各维度的阶段会话数、转化率与确认用时
+func WriteFunnelReport(t *Tables, resp *response.GenerateFunnelReportResponse) error {
+	groups := []struct {
+		name  string
+		items []*response.FunnelGroupResponse
+	}{
+		{"summary", []*response.FunnelGroupResponse{resp.Summary}},
+		{"by_movie", resp.ByMovie},
+		{"by_hour", resp.ByHour},
+		{"by_time_slot", resp.ByTimeSlot},
+	}
+	for _, group := range groups {
+		if err := t.Begin(group.name,
+			col("key", ColumnText),
+			col("label", ColumnText),
+			col("sessions", ColumnInteger),
+			col("seats_locked", ColumnInteger),
+			col("bookings_created", ColumnInteger),
+			col("bookings_confirmed", ColumnInteger),
+			col("bookings_cancelled", ColumnInteger),
+			col("abandoned", ColumnInteger),
+			col("lock_rate", ColumnRatio),
+			col("booking_rate", ColumnRatio),
+			col("confirm_rate", ColumnRatio),
+			col("conversion_rate", ColumnRatio),
+			col("abandonment_rate", ColumnRatio),
+			col("median_time_to_confirm_seconds", ColumnInteger),
+		); err != nil {
+			return err
+		}
+		for _, item := range group.items {
+			if err := t.W.WriteRow(item.Key, item.Label, item.Sessions, item.SeatsLocked, item.BookingsCreated,
+				item.BookingsConfirmed, item.BookingsCancelled, item.Abandoned, item.LockRate, item.BookingRate,
+				item.ConfirmRate, item.ConversionRate, item.AbandonmentRate, item.MedianTimeToConfirmSeconds); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// 售票排行榜：电影与场次排行
+func WriteLeaderboard(t *Tables, resp *response.LeaderboardResponse) error {
+	if err := t.Begin("summary",
+		col("window", ColumnText),
+		col("generated_at", ColumnTime),
+	); err != nil {
+		return err
+	}
+	if err := t.W.WriteRow(resp.Window, resp.GeneratedAt); err != nil {
+		return err
+	}
+
+	if err := t.Begin("movies",
+		col("rank", ColumnInteger),
+		col("movie_id", ColumnText),
+		col("movie_title", ColumnText),
+		col("tickets", ColumnInteger),
+	); err != nil {
+		return err
+	}
+	for _, item := range resp.Movies {
+		if err := t.W.WriteRow(item.Rank, item.Movie.ID, item.Movie.Title, item.Tickets); err != nil {
+			return err
+		}
+	}
+
+	if err := t.Begin("showtimes",
+		col("rank", ColumnInteger),
+		col("showtime_id", ColumnText),
+		col("movie_title", ColumnText),
+		col("cinema_hall_name", ColumnText),
+		col("start_time", ColumnTime),
+		col("tickets", ColumnInteger),
+	); err != nil {
+		return err
+	}
+	for _, item := range resp.Showtimes {
+		if err := t.W.WriteRow(item.Rank, item.Showtime.ID, item.MovieTitle, item.CinemaHallName,
+			item.Showtime.StartTime, item.Tickets); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 订单明细的列
+var BookingColumns = []Column{
+	col("booking_id", ColumnText),
+	col("user_id", ColumnText),
+	col("status", ColumnText),
+	col("booked_at", ColumnTime),
+	col("movie_id", ColumnText),
+	col("movie_title", ColumnText),
+	col("cinema_name", ColumnText),
+	col("hall_name", ColumnText),
+	col("showtime_start", ColumnTime),
+	col("tickets", ColumnInteger),
+	col("total_amount", ColumnAmount),
+}
+
+// 订单明细的一行，值的顺序与 BookingColumns 一致
+func BookingRow(r *booking.SalesRecord) []any {
+	return []any{uint(r.BookingID), uint(r.UserID), string(r.Status), r.BookedAt, uint(r.MovieID),
+		r.MovieTitle, r.CinemaName, r.HallName, r.ShowtimeStart, r.Tickets, r.TotalAmount}
+}
@@ -0,0 +1,304 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 最小化的 XLSX（Office Open XML）写入器，不依赖第三方库：
+// 工作表以 zip 条目逐行写出，字符串使用内联字符串，无需在内存中汇总共享字符串表；
+// 工作簿、样式等元数据在 Close 时写入（zip 条目的顺序不影响读取）
+
+// 单元格样式下标，对应 xlsxStyles 中 cellXfs 的顺序
+const (
+	xlsxStyleDefault = iota
+	xlsxStyleAmount
+	xlsxStyleInteger
+	xlsxStylePercent
+	xlsxStyleTime
+	xlsxStyleHeader
+)
+
+// 数值列的单元格样式
+var xlsxNumberStyles = map[ColumnType]int{
+	ColumnAmount:  xlsxStyleAmount,
+	ColumnInteger: xlsxStyleInteger,
+	ColumnRatio:   xlsxStylePercent,
+}
+
+const xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy-mm-dd hh:mm:ss"/></numFmts>
+<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>
+<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>
+<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>
+<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>
+<cellXfs count="6">
+<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>
+<xf numFmtId="4" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>
+<xf numFmtId="3" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>
+<xf numFmtId="10" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>
+<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>
+<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>
+</cellXfs>
+<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>
+</styleSheet>`
+
+const xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+
+const xlsxSheetHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+const xlsxSheetFooter = `</sheetData></worksheet>`
+
+// Excel 日期序列号的起点（1900 日期系统）
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+type xlsxWriter struct {
+	zip     *zip.Writer
+	sheet   *bufio.Writer
+	columns []Column
+	sheets  []string
+	row     int
+}
+
+func newXLSXWriter(w io.Writer) *xlsxWriter {
+	return &xlsxWriter{zip: zip.NewWriter(w)}
+}
+
+func (x *xlsxWriter) BeginTable(name string, columns []Column) error {
+	if err := x.endSheet(); err != nil {
+		return err
+	}
+
+	x.sheets = append(x.sheets, sheetName(name, x.sheets))
+	entry, err := x.zip.Create(fmt.Sprintf("xl/worksheets/sheet%d.xml", len(x.sheets)))
+	if err != nil {
+		return fmt.Errorf("create xlsx sheet error: %w", err)
+	}
+	x.sheet = bufio.NewWriter(entry)
+	x.columns = columns
+	x.row = 0
+	if _, err := x.sheet.WriteString(xlsxSheetHeader); err != nil {
+		return fmt.Errorf("write xlsx sheet error: %w", err)
+	}
+
+	x.row++
+	x.writeRowStart()
+	for i, column := range columns {
+		x.writeString(i, column.Header, xlsxStyleHeader)
+	}
+	return x.writeRowEnd()
+}
+
+func (x *xlsxWriter) WriteRow(values ...any) error {
+	if x.sheet == nil {
+		return fmt.Errorf("write xlsx row error: no table started")
+	}
+	x.row++
+	x.writeRowStart()
+	for i, column := range x.columns {
+		if i >= len(values) || isNull(values[i]) {
+			continue
+		}
+		value := values[i]
+		switch column.Type {
+		case ColumnAmount, ColumnInteger, ColumnRatio:
+			n, err := toFloat(value)
+			if err != nil {
+				return fmt.Errorf("write xlsx row error: column %s: %w", column.Key, err)
+			}
+			x.writeNumber(i, n, xlsxNumberStyles[column.Type])
+		case ColumnTime:
+			if t, ok := value.(time.Time); ok {
+				if !t.IsZero() {
+					x.writeNumber(i, excelTime(t), xlsxStyleTime)
+				}
+				continue
+			}
+			x.writeString(i, toString(value), xlsxStyleDefault)
+		default:
+			x.writeString(i, toString(value), xlsxStyleDefault)
+		}
+	}
+	return x.writeRowEnd()
+}
+
+func (x *xlsxWriter) Close() error {
+	if err := x.endSheet(); err != nil {
+		return err
+	}
+	// 没有任何表时也生成一个空工作表，保证文件可以打开
+	if len(x.sheets) == 0 {
+		if err := x.BeginTable("", nil); err != nil {
+			return err
+		}
+		if err := x.endSheet(); err != nil {
+			return err
+		}
+	}
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", x.contentTypes()},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", x.workbook()},
+		{"xl/_rels/workbook.xml.rels", x.workbookRels()},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for _, file := range files {
+		entry, err := x.zip.Create(file.name)
+		if err != nil {
+			return fmt.Errorf("create xlsx entry error: %w", err)
+		}
+		if _, err := io.WriteString(entry, file.content); err != nil {
+			return fmt.Errorf("write xlsx entry error: %w", err)
+		}
+	}
+	if err := x.zip.Close(); err != nil {
+		return fmt.Errorf("close xlsx error: %w", err)
+	}
+	return nil
+}
+
+func (x *xlsxWriter) endSheet() error {
+	if x.sheet == nil {
+		return nil
+	}
+	if _, err := x.sheet.WriteString(xlsxSheetFooter); err != nil {
+		return fmt.Errorf("write xlsx sheet error: %w", err)
+	}
+	if err := x.sheet.Flush(); err != nil {
+		return fmt.Errorf("flush xlsx sheet error: %w", err)
+	}
+	x.sheet = nil
+	return nil
+}
+
+// bufio.Writer 的写入错误会一直保留，因此只在行尾检查一次
+func (x *xlsxWriter) writeRowStart() {
+	fmt.Fprintf(x.sheet, `<row r="%d">`, x.row)
+}
+
+func (x *xlsxWriter) writeRowEnd() error {
+	if _, err := x.sheet.WriteString(`</row>`); err != nil {
+		return fmt.Errorf("write xlsx row error: %w", err)
+	}
+	return nil
+}
+
+func (x *xlsxWriter) writeString(col int, value string, style int) {
+	fmt.Fprintf(x.sheet, `<c r="%s%d" t="inlineStr"`, columnName(col), x.row)
+	if style != xlsxStyleDefault {
+		fmt.Fprintf(x.sheet, ` s="%d"`, style)
+	}
+	x.sheet.WriteString(`><is><t xml:space="preserve">`)
+	xml.EscapeText(x.sheet, []byte(value))
+	x.sheet.WriteString(`</t></is></c>`)
+}
+
+func (x *xlsxWriter) writeNumber(col int, value float64, style int) {
+	fmt.Fprintf(x.sheet, `<c r="%s%d" s="%d"><v>%s</v></c>`,
+		columnName(col), x.row, style, strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+func (x *xlsxWriter) contentTypes() string {
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>
+`)
+	for i := range x.sheets {
+		fmt.Fprintf(&sb, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`+"\n", i+1)
+	}
+	sb.WriteString(`</Types>`)
+	return sb.String()
+}
+
+func (x *xlsxWriter) workbook() string {
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	for i, name := range x.sheets {
+		sb.WriteString(`<sheet name="`)
+		xml.EscapeText(&sb, []byte(name))
+		fmt.Fprintf(&sb, `" sheetId="%d" r:id="rId%d"/>`, i+1, i+1)
+	}
+	sb.WriteString(`</sheets></workbook>`)
+	return sb.String()
+}
+
+func (x *xlsxWriter) workbookRels() string {
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+`)
+	for i := range x.sheets {
+		fmt.Fprintf(&sb, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`+"\n", i+1, i+1)
+	}
+	fmt.Fprintf(&sb, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`+"\n", len(x.sheets)+1)
+	sb.WriteString(`</Relationships>`)
+	return sb.String()
+}
+
+// 列号转换为列名：0 -> A，25 -> Z，26 -> AA
+func columnName(col int) string {
+	name := ""
+	for col >= 0 {
+		name = string(rune('A'+col%26)) + name
+		col = col/26 - 1
+	}
+	return name
+}
+
+// 工作表名称最长31个字符，不能包含 []:*?/\，不能以单引号开头或结尾，且在工作簿内不区分大小写唯一
+func sheetName(name string, existing []string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	name = strings.Trim(strings.TrimSpace(name), "'")
+	if name == "" {
+		name = "Sheet" + strconv.Itoa(len(existing)+1)
+	}
+
+	used := make(map[string]bool, len(existing))
+	for _, sheet := range existing {
+		used[strings.ToLower(sheet)] = true
+	}
+	candidate := truncateRunes(name, 31)
+	for n := 2; used[strings.ToLower(candidate)]; n++ {
+		suffix := fmt.Sprintf(" (%d)", n)
+		candidate = truncateRunes(name, 31-len(suffix)) + suffix
+	}
+	return candidate
+}
+
+func truncateRunes(s string, n int) string {
+	if runes := []rune(s); len(runes) > n {
+		return string(runes[:n])
+	}
+	return s
+}
+
+// 时间转换为 Excel 日期序列号（按时间自身时区的墙上时间）
+func excelTime(t time.Time) float64 {
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	return wall.Sub(excelEpoch).Hours() / 24
+}
@@ -0,0 +1,314 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testSheet struct {
+	Rows []struct {
+		R     int `xml:"r,attr"`
+		Cells []struct {
+			R      string `xml:"r,attr"`
+			T      string `xml:"t,attr"`
+			S      int    `xml:"s,attr"`
+			V      string `xml:"v"`
+			Inline string `xml:"is>t"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+type testWorkbook struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+// 写出 XLSX 并解压，返回条目名到内容的映射
+func writeXLSX(t *testing.T, write func(w TableWriter)) map[string][]byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewTableWriter(FormatXLSX, &buf, Options{})
+	if err != nil {
+		t.Fatalf("NewTableWriter failed: %v", err)
+	}
+	write(w)
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("generated xlsx is not a valid zip: %v", err)
+	}
+	files := make(map[string][]byte, len(reader.File))
+	for _, f := range reader.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s failed: %v", f.Name, err)
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("read %s failed: %v", f.Name, err)
+		}
+		files[f.Name] = data
+	}
+	return files
+}
+
+// 逐个 token 解析，检查 XML 格式正确
+func checkWellFormed(t *testing.T, name string, data []byte) {
+	t.Helper()
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		_, err := decoder.Token()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			t.Fatalf("%s is not well-formed XML: %v", name, err)
+		}
+	}
+}
+
+func parseSheet(t *testing.T, files map[string][]byte, index int) *testSheet {
+	t.Helper()
+	name := fmt.Sprintf("xl/worksheets/sheet%d.xml", index)
+	data, ok := files[name]
+	if !ok {
+		t.Fatalf("missing %s", name)
+	}
+	var sheet testSheet
+	if err := xml.Unmarshal(data, &sheet); err != nil {
+		t.Fatalf("parse %s failed: %v", name, err)
+	}
+	return &sheet
+}
+
+func TestXLSXWriter_PackageParts(t *testing.T) {
+	files := writeXLSX(t, func(w TableWriter) {
+		if err := w.BeginTable("Summary", testColumns); err != nil {
+			t.Fatalf("BeginTable failed: %v", err)
+		}
+		start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+		if err := w.WriteRow("Dune", 1200, 99.5, 0.75, start); err != nil {
+			t.Fatalf("WriteRow failed: %v", err)
+		}
+		if err := w.BeginTable("By Movie", testColumns[:2]); err != nil {
+			t.Fatalf("BeginTable failed: %v", err)
+		}
+	})
+
+	for _, name := range []string{
+		"[Content_Types].xml",
+		"_rels/.rels",
+		"xl/workbook.xml",
+		"xl/_rels/workbook.xml.rels",
+		"xl/styles.xml",
+		"xl/worksheets/sheet1.xml",
+		"xl/worksheets/sheet2.xml",
+	} {
+		data, ok := files[name]
+		if !ok {
+			t.Errorf("missing part %s", name)
+			continue
+		}
+		checkWellFormed(t, name, data)
+	}
+	if len(files) != 7 {
+		t.Errorf("xlsx has %d parts, want 7", len(files))
+	}
+	for i := 1; i <= 2; i++ {
+		part := fmt.Sprintf("/xl/worksheets/sheet%d.xml", i)
+		if !bytes.Contains(files["[Content_Types].xml"], []byte(part)) {
+			t.Errorf("content types missing override for %s", part)
+		}
+		target := fmt.Sprintf("worksheets/sheet%d.xml", i)
+		if !bytes.Contains(files["xl/_rels/workbook.xml.rels"], []byte(target)) {
+			t.Errorf("workbook rels missing target %s", target)
+		}
+	}
+
+	var workbook testWorkbook
+	if err := xml.Unmarshal(files["xl/workbook.xml"], &workbook); err != nil {
+		t.Fatalf("parse workbook failed: %v", err)
+	}
+	if len(workbook.Sheets) != 2 || workbook.Sheets[0].Name != "Summary" || workbook.Sheets[1].Name != "By Movie" {
+		t.Errorf("workbook sheets = %+v, want [Summary By Movie]", workbook.Sheets)
+	}
+
+	sheet := parseSheet(t, files, 1)
+	if len(sheet.Rows) != 2 {
+		t.Fatalf("sheet1 has %d rows, want 2", len(sheet.Rows))
+	}
+	header := sheet.Rows[0]
+	if header.R != 1 || header.Cells[0].R != "A1" || header.Cells[0].Inline != "Name" || header.Cells[0].S != xlsxStyleHeader {
+		t.Errorf("header row = %+v", header)
+	}
+
+	type cell struct {
+		ref, typ, value string
+		style           int
+	}
+	want := []cell{
+		{"A2", "inlineStr", "Dune", xlsxStyleDefault},
+		{"B2", "", "1200", xlsxStyleInteger},
+		{"C2", "", "99.5", xlsxStyleAmount},
+		{"D2", "", "0.75", xlsxStylePercent},
+		{"E2", "", "45292.5", xlsxStyleTime},
+	}
+	row := sheet.Rows[1]
+	if len(row.Cells) != len(want) {
+		t.Fatalf("data row has %d cells, want %d", len(row.Cells), len(want))
+	}
+	for i, c := range row.Cells {
+		value := c.V
+		if c.T == "inlineStr" {
+			value = c.Inline
+		}
+		got := cell{c.R, c.T, value, c.S}
+		if got != want[i] {
+			t.Errorf("cell %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+}
+
+func TestXLSXWriter_ColumnsPastZ(t *testing.T) {
+	columns := make([]Column, 28)
+	values := make([]any, 28)
+	for i := range columns {
+		columns[i] = Column{Key: fmt.Sprintf("c%d", i), Header: fmt.Sprintf("C%d", i), Type: ColumnInteger}
+		values[i] = i
+	}
+	files := writeXLSX(t, func(w TableWriter) {
+		if err := w.BeginTable("Wide", columns); err != nil {
+			t.Fatalf("BeginTable failed: %v", err)
+		}
+		if err := w.WriteRow(values...); err != nil {
+			t.Fatalf("WriteRow failed: %v", err)
+		}
+	})
+
+	row := parseSheet(t, files, 1).Rows[1]
+	for i, want := range map[int]string{0: "A2", 25: "Z2", 26: "AA2", 27: "AB2"} {
+		if got := row.Cells[i].R; got != want {
+			t.Errorf("cell %d ref = %s, want %s", i, got, want)
+		}
+	}
+}
+
+func TestXLSXWriter_EscapesText(t *testing.T) {
+	files := writeXLSX(t, func(w TableWriter) {
+		if err := w.BeginTable("Notes <&>", []Column{{Key: "note", Header: "Note", Type: ColumnText}}); err != nil {
+			t.Fatalf("BeginTable failed: %v", err)
+		}
+		if err := w.WriteRow("a\x01b\x1f<c & \"d\">\tline1\nline2"); err != nil {
+			t.Fatalf("WriteRow failed: %v", err)
+		}
+	})
+	checkWellFormed(t, "sheet1", files["xl/worksheets/sheet1.xml"])
+	checkWellFormed(t, "workbook", files["xl/workbook.xml"])
+
+	// XML 1.0 不允许的控制字符替换为 U+FFFD，制表符与换行保留
+	got := parseSheet(t, files, 1).Rows[1].Cells[0].Inline
+	want := "a�b�<c & \"d\">\tline1\nline2"
+	if got != want {
+		t.Errorf("cell text = %q, want %q", got, want)
+	}
+
+	var workbook testWorkbook
+	if err := xml.Unmarshal(files["xl/workbook.xml"], &workbook); err != nil {
+		t.Fatalf("parse workbook failed: %v", err)
+	}
+	if name := workbook.Sheets[0].Name; name != "Notes <&>" {
+		t.Errorf("sheet name = %q, want %q", name, "Notes <&>")
+	}
+}
+
+func TestXLSXWriter_EmptyWorkbook(t *testing.T) {
+	files := writeXLSX(t, func(w TableWriter) {})
+	for name, data := range files {
+		checkWellFormed(t, name, data)
+	}
+	if _, ok := files["xl/worksheets/sheet1.xml"]; !ok {
+		t.Error("empty workbook should contain one sheet")
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	tests := []struct {
+		col  int
+		want string
+	}{
+		{0, "A"},
+		{25, "Z"},
+		{26, "AA"},
+		{27, "AB"},
+		{51, "AZ"},
+		{52, "BA"},
+		{701, "ZZ"},
+		{702, "AAA"},
+		{16383, "XFD"},
+	}
+	for _, tt := range tests {
+		if got := columnName(tt.col); got != tt.want {
+			t.Errorf("columnName(%d) = %s, want %s", tt.col, got, tt.want)
+		}
+	}
+}
+
+func TestSheetName(t *testing.T) {
+	long := strings.Repeat("x", 40)
+	tests := []struct {
+		name     string
+		input    string
+		existing []string
+		want     string
+	}{
+		{"plain", "Summary", nil, "Summary"},
+		{"invalid characters", "a[b]c:d*e?f/g\\h", nil, "a_b_c_d_e_f_g_h"},
+		{"surrounding spaces and quotes", " 'Sales' ", nil, "Sales"},
+		{"truncated to 31 characters", long, nil, strings.Repeat("x", 31)},
+		{"truncated by rune", strings.Repeat("按电影", 11), nil, strings.Repeat("按电影", 10) + "按"},
+		{"empty name", "", []string{"A", "B"}, "Sheet3"},
+		{"duplicate name", "Summary", []string{"summary"}, "Summary (2)"},
+		{"second duplicate", "Summary", []string{"Summary", "Summary (2)"}, "Summary (3)"},
+		{"duplicate of truncated name", long, []string{strings.Repeat("x", 31)}, strings.Repeat("x", 27) + " (2)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sheetName(tt.input, tt.existing)
+			if got != tt.want {
+				t.Errorf("sheetName(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+			if n := len([]rune(got)); n > 31 {
+				t.Errorf("sheetName(%q) has %d characters, want at most 31", tt.input, n)
+			}
+		})
+	}
+}
+
+func TestExcelTime(t *testing.T) {
+	tests := []struct {
+		t    time.Time
+		want float64
+	}{
+		{time.Date(1900, 3, 1, 0, 0, 0, 0, time.UTC), 61},
+		{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 45292},
+		{time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC), 45292.75},
+		// 按时间自身时区的墙上时间
+		{time.Date(2024, 1, 1, 18, 0, 0, 0, time.FixedZone("CST", 8*3600)), 45292.75},
+	}
+	for _, tt := range tests {
+		if got := excelTime(tt.t); got != tt.want {
+			t.Errorf("excelTime(%v) = %v, want %v", tt.t, got, tt.want)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"errors"
 	"mrs/internal/api/dto/request"
+	"mrs/internal/api/i18n"
 	"mrs/internal/app"
 	"mrs/internal/domain/user"
 	applog "mrs/pkg/log"
@@ -30,7 +31,7 @@ func (h *AuthHandler) Login(ctx *gin.Context) {
 	var req request.LoginRequest
 	if err := ctx.BindJSON(&req); err != nil {
 		logger.Warn("Failed to bind login request", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -40,17 +41,17 @@ func (h *AuthHandler) Login(ctx *gin.Context) {
 		// 用户不存在
 		if errors.Is(err, user.ErrUserAlreadyExists) {
 			logger.Warn("user cannot found", applog.String("username", req.Username))
-			ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			i18n.WriteError(ctx, http.StatusUnauthorized, err)
 			return
 		}
 		// 密码验证错误
 		if errors.Is(err, user.ErrInvalidPassword) {
 			logger.Warn("invalid password", applog.Error(err))
-			ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			i18n.WriteError(ctx, http.StatusUnauthorized, err)
 			return
 		}
 		logger.Error("Login service failed", applog.Error(err), applog.String("username", req.Username))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Login failed due to an internal error"})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
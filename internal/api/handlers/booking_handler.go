@@ -3,9 +3,11 @@ package handlers
 import (
 	"errors"
 	"mrs/internal/api/dto/request"
+	"mrs/internal/api/i18n"
 	"mrs/internal/api/middleware"
 	"mrs/internal/app"
 	"mrs/internal/domain/booking"
+	"mrs/internal/domain/loyalty"
 	"mrs/internal/domain/showtime"
 	applog "mrs/pkg/log"
 	"net/http"
@@ -29,7 +31,7 @@ func (h *BookingHandler) CreateBooking(ctx *gin.Context) {
 	var req request.CreateBookingRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		logger.Error("failed to bind request", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 	req.UserID = ctx.GetUint(middleware.UserIDKey)
@@ -39,17 +41,41 @@ func (h *BookingHandler) CreateBooking(ctx *gin.Context) {
 		// 场次已结束
 		if errors.Is(err, showtime.ErrShowtimeEnded) {
 			logger.Warn("showtime has ended", applog.Error(err))
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			i18n.WriteError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		// 场次已取消
+		if errors.Is(err, showtime.ErrShowtimeCancelled) {
+			logger.Warn("showtime has been cancelled", applog.Error(err))
+			i18n.WriteError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		// 未达电影分级的年龄要求
+		if errors.Is(err, booking.ErrAgeRestricted) {
+			logger.Warn("user does not meet the age requirement", applog.Error(err))
+			i18n.WriteError(ctx, http.StatusForbidden, err)
 			return
 		}
 		// 座位已锁定
 		if errors.Is(err, booking.ErrBookedSeatAlreadyLocked) {
 			logger.Warn("booked seat already locked", applog.Error(err))
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			i18n.WriteError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		// 积分不足或超过抵扣上限
+		if errors.Is(err, loyalty.ErrInsufficientPoints) || errors.Is(err, loyalty.ErrRedemptionLimitExceeded) {
+			logger.Warn("loyalty points cannot be redeemed", applog.Error(err))
+			i18n.WriteError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		// 会员等级尚未开放购票
+		if errors.Is(err, loyalty.ErrBookingNotOpen) {
+			logger.Warn("booking is not open yet", applog.Error(err))
+			i18n.WriteError(ctx, http.StatusForbidden, err)
 			return
 		}
 		logger.Error("failed to create booking", applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -64,7 +90,7 @@ func (h *BookingHandler) ListBookings(ctx *gin.Context) {
 	var req request.ListBookingsRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
 		logger.Error("failed to bind request", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 	req.UserID = ctx.GetUint(middleware.UserIDKey)
@@ -72,7 +98,7 @@ func (h *BookingHandler) ListBookings(ctx *gin.Context) {
 	bookingsResp, err := h.bookingService.ListBookings(ctx, &req)
 	if err != nil {
 		logger.Error("failed to list bookings", applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -88,7 +114,7 @@ func (h *BookingHandler) GetBooking(ctx *gin.Context) {
 	bookingID, err := getIDFromPath(ctx)
 	if err != nil {
 		logger.Error("failed to get booking id", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 	req.ID = bookingID
@@ -96,7 +122,7 @@ func (h *BookingHandler) GetBooking(ctx *gin.Context) {
 	bookingResp, err := h.bookingService.GetBooking(ctx, &req)
 	if err != nil {
 		logger.Error("failed to get booking", applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -111,7 +137,7 @@ func (h *BookingHandler) CancelBooking(ctx *gin.Context) {
 	bookingID, err := getIDFromPath(ctx)
 	if err != nil {
 		logger.Error("failed to get booking id", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 	req := request.CancelBookingRequest{ID: bookingID}
@@ -120,16 +146,16 @@ func (h *BookingHandler) CancelBooking(ctx *gin.Context) {
 	if err != nil {
 		if errors.Is(err, booking.ErrBookingNotFound) {
 			logger.Error("booking not found", applog.Error(err))
-			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			i18n.WriteError(ctx, http.StatusNotFound, err)
 			return
 		}
 		if errors.Is(err, booking.ErrBookingNotPending) {
 			logger.Error("booking status is not pending", applog.Error(err))
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			i18n.WriteError(ctx, http.StatusBadRequest, err)
 			return
 		}
 		logger.Error("failed to cancel booking", applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -144,7 +170,7 @@ func (h *BookingHandler) ConfirmBooking(ctx *gin.Context) {
 	bookingID, err := getIDFromPath(ctx)
 	if err != nil {
 		logger.Error("failed to get booking id", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 	req := request.ConfirmBookingRequest{ID: bookingID}
@@ -152,10 +178,38 @@ func (h *BookingHandler) ConfirmBooking(ctx *gin.Context) {
 	bookingResp, err := h.bookingService.ConfirmBooking(ctx, &req)
 	if err != nil {
 		logger.Error("failed to confirm booking", applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
 	logger.Info("confirm booking successfully", applog.Uint("booking_id", uint(bookingResp.ID)))
 	ctx.JSON(http.StatusOK, bookingResp)
 }
+
+// 检票信息 GET /api/v1/admin/bookings/:id/check-in
+func (h *BookingHandler) GetCheckIn(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "GetCheckIn"))
+
+	bookingID, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Warn("failed to get booking id", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req := request.GetCheckInRequest{ID: bookingID}
+
+	checkInResp, err := h.bookingService.GetCheckIn(ctx, &req)
+	if err != nil {
+		if errors.Is(err, booking.ErrBookingNotFound) {
+			logger.Warn("booking not found", applog.Error(err))
+			i18n.WriteError(ctx, http.StatusNotFound, err)
+			return
+		}
+		logger.Error("failed to get check-in info", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	logger.Info("get check-in info successfully", applog.Uint("booking_id", bookingID))
+	ctx.JSON(http.StatusOK, checkInResp)
+}
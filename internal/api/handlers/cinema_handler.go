@@ -2,7 +2,10 @@ package handlers
 
 import (
 	"errors"
+	"fmt"
 	"mrs/internal/api/dto/request"
+	"mrs/internal/api/dto/response"
+	"mrs/internal/api/i18n"
 	"mrs/internal/app"
 	"mrs/internal/domain/cinema"
 	applog "mrs/pkg/log"
@@ -20,20 +23,176 @@ func NewCinemaHandler(cinemaService app.CinemaService, logger applog.Logger) *Ci
 	return &CinemaHandler{cinemaService: cinemaService, logger: logger.With(applog.String("Handler", "CinemaHandler"))}
 }
 
+// 创建影院 POST /api/v1/admin/cinemas
+func (h *CinemaHandler) CreateCinema(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "CreateCinema"))
+	var req request.CreateCinemaRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Error("failed to bind request", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	cinemaResp, err := h.cinemaService.CreateCinema(ctx, &req)
+	if err != nil {
+		if errors.Is(err, cinema.ErrInvalidTimezone) || errors.Is(err, cinema.ErrInvalidOpeningHours) {
+			logger.Warn("invalid cinema", applog.Error(err))
+			i18n.WriteError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		if errors.Is(err, cinema.ErrCinemaAlreadyExists) {
+			logger.Warn("cinema already exists")
+			i18n.WriteError(ctx, http.StatusConflict, err)
+			return
+		}
+		logger.Error("failed to create cinema", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	logger.Info("cinema created successfully", applog.Uint("cinema_id", cinemaResp.ID))
+	ctx.JSON(http.StatusCreated, cinemaResp)
+}
+
+// 获取影院 GET /api/v1/cinemas/:id
+func (h *CinemaHandler) GetCinema(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "GetCinema"))
+	id, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Error("failed to get id from path", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	cinemaResp, err := h.cinemaService.GetCinema(ctx, &request.GetCinemaRequest{ID: id})
+	if err != nil {
+		if errors.Is(err, cinema.ErrCinemaNotFound) {
+			logger.Warn("cinema not found")
+			i18n.WriteError(ctx, http.StatusNotFound, err)
+			return
+		}
+		logger.Error("failed to get cinema", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	logger.Info("cinema retrieved successfully", applog.Uint("cinema_id", cinemaResp.ID))
+	ctx.JSON(http.StatusOK, cinemaResp)
+}
+
+// 获取所有影院 GET /api/v1/cinemas
+func (h *CinemaHandler) ListAllCinemas(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "ListAllCinemas"))
+
+	cinemasResp, err := h.cinemaService.ListAllCinemas(ctx)
+	if err != nil {
+		logger.Error("failed to list all cinemas", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	logger.Info("list all cinemas successfully")
+	ctx.JSON(http.StatusOK, cinemasResp)
+}
+
+// 更新影院 PUT /api/v1/admin/cinemas/:id
+func (h *CinemaHandler) UpdateCinema(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "UpdateCinema"))
+	var req request.UpdateCinemaRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Error("failed to bind request", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	id, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Error("failed to get id from path", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req.ID = id
+
+	cinemaResp, err := h.cinemaService.UpdateCinema(ctx, &req)
+	if err != nil {
+		if errors.Is(err, cinema.ErrCinemaNotFound) {
+			logger.Warn("cinema not found")
+			i18n.WriteError(ctx, http.StatusNotFound, err)
+			return
+		}
+		if errors.Is(err, cinema.ErrInvalidTimezone) || errors.Is(err, cinema.ErrInvalidOpeningHours) {
+			logger.Warn("invalid cinema", applog.Error(err))
+			i18n.WriteError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		if errors.Is(err, cinema.ErrCinemaAlreadyExists) {
+			logger.Warn("cinema name already exists")
+			i18n.WriteError(ctx, http.StatusConflict, err)
+			return
+		}
+		logger.Error("failed to update cinema", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	logger.Info("cinema updated successfully", applog.Uint("cinema_id", cinemaResp.ID))
+	ctx.JSON(http.StatusOK, cinemaResp)
+}
+
+// 删除影院 DELETE /api/v1/admin/cinemas/:id
+func (h *CinemaHandler) DeleteCinema(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "DeleteCinema"))
+	id, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Error("failed to get id from path", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.cinemaService.DeleteCinema(ctx, &request.DeleteCinemaRequest{ID: id}); err != nil {
+		if errors.Is(err, cinema.ErrCinemaNotFound) {
+			logger.Warn("cinema not found")
+			i18n.WriteError(ctx, http.StatusNotFound, err)
+			return
+		}
+		if errors.Is(err, cinema.ErrCinemaReferenced) {
+			logger.Warn("cinema still has cinema halls")
+			i18n.WriteError(ctx, http.StatusConflict, err)
+			return
+		}
+		logger.Error("failed to delete cinema", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	logger.Info("cinema deleted successfully", applog.Uint("cinema_id", id))
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
 // 创建影厅 POST /api/v1/admin/cinema-halls
 func (h *CinemaHandler) CreateCinemaHall(ctx *gin.Context) {
 	logger := h.logger.With(applog.String("Method", "CreateCinemaHall"))
 	var req request.CreateCinemaHallRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		logger.Error("failed to bind request", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	cinemaHallResp, err := h.cinemaService.CreateCinemaHall(ctx, &req)
 	if err != nil {
+		if errors.Is(err, cinema.ErrCinemaNotFound) {
+			logger.Warn("cinema not found")
+			i18n.WriteError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		if errors.Is(err, cinema.ErrInvalidHallLayout) {
+			logger.Warn("invalid cinema hall layout")
+			i18n.WriteError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		if errors.Is(err, cinema.ErrCinemaHallAlreadyExists) {
+			logger.Warn("cinema hall already exists")
+			i18n.WriteError(ctx, http.StatusConflict, err)
+			return
+		}
 		logger.Error("failed to create cinema hall", applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 	logger.Info("cinema hall created successfully", applog.Uint("cinema_hall_id", uint(cinemaHallResp.ID)))
@@ -47,7 +206,7 @@ func (h *CinemaHandler) GetCinemaHall(ctx *gin.Context) {
 	id, err := getIDFromPath(ctx)
 	if err != nil {
 		logger.Error("failed to get id from path", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 	req.ID = id
@@ -56,25 +215,31 @@ func (h *CinemaHandler) GetCinemaHall(ctx *gin.Context) {
 	if err != nil {
 		if errors.Is(err, cinema.ErrCinemaHallNotFound) {
 			logger.Warn("cinema hall not found")
-			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			i18n.WriteError(ctx, http.StatusNotFound, err)
 			return
 		}
 		logger.Error("failed to get cinema hall", applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 	logger.Info("cinema hall retrieved successfully", applog.Uint("cinema_hall_id", uint(cinemaHallResp.ID)))
 	ctx.JSON(http.StatusOK, cinemaHallResp)
 }
 
-// 获取所有影厅 GET /api/v1/cinema-halls
+// 获取所有影厅 GET /api/v1/cinema-halls?cinema_id=
 func (h *CinemaHandler) ListAllCinemaHalls(ctx *gin.Context) {
 	logger := h.logger.With(applog.String("Method", "ListAllCinemaHalls"))
+	var req request.ListCinemaHallsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		logger.Error("failed to bind request", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
 
-	cinemaHallResp, err := h.cinemaService.ListAllCinemaHalls(ctx)
+	cinemaHallResp, err := h.cinemaService.ListAllCinemaHalls(ctx, &req)
 	if err != nil {
 		logger.Error("failed to list all cinema halls", applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 	logger.Info("list all cinema halls successfully")
@@ -87,13 +252,13 @@ func (h *CinemaHandler) UpdateCinemaHall(ctx *gin.Context) {
 	var req request.UpdateCinemaHallRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		logger.Error("failed to bind request", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 	id, err := getIDFromPath(ctx)
 	if err != nil {
 		logger.Error("failed to get id from path", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 	req.ID = id
@@ -102,11 +267,21 @@ func (h *CinemaHandler) UpdateCinemaHall(ctx *gin.Context) {
 	if err != nil {
 		if errors.Is(err, cinema.ErrCinemaHallNotFound) {
 			logger.Warn("cinema hall not found")
-			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			i18n.WriteError(ctx, http.StatusNotFound, err)
+			return
+		}
+		if errors.Is(err, cinema.ErrCinemaNotFound) {
+			logger.Warn("cinema not found")
+			i18n.WriteError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		if errors.Is(err, cinema.ErrCinemaHallAlreadyExists) {
+			logger.Warn("cinema hall already exists")
+			i18n.WriteError(ctx, http.StatusConflict, err)
 			return
 		}
 		logger.Error("failed to update cinema hall", applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 	logger.Info("cinema hall updated successfully", applog.Uint("cinema_hall_id", uint(cinemaHallResp.ID)))
@@ -120,7 +295,7 @@ func (h *CinemaHandler) DeleteCinemaHall(ctx *gin.Context) {
 	id, err := getIDFromPath(ctx)
 	if err != nil {
 		logger.Error("failed to get id from path", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 	req.ID = id
@@ -128,14 +303,295 @@ func (h *CinemaHandler) DeleteCinemaHall(ctx *gin.Context) {
 	if err := h.cinemaService.DeleteCinemaHall(ctx, &req); err != nil {
 		if errors.Is(err, cinema.ErrCinemaHallNotFound) {
 			logger.Warn("cinema hall not found")
-			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			i18n.WriteError(ctx, http.StatusNotFound, err)
 			return
 		}
 		logger.Error("failed to delete cinema hall", applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
 	logger.Info("cinema hall deleted successfully", applog.Uint("cinema_hall_id", uint(req.ID)))
 	ctx.JSON(http.StatusNoContent, nil)
 }
+
+// 导入影厅布局 PUT /api/v1/admin/cinema-halls/:id/layout?format=ascii|json|yaml&dry_run=true
+func (h *CinemaHandler) ImportCinemaHallLayout(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "ImportCinemaHallLayout"))
+	var req request.ImportCinemaHallLayoutRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		logger.Error("failed to bind request", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	id, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Error("failed to get id from path", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req.ID = id
+	if req.Data, err = ctx.GetRawData(); err != nil {
+		logger.Error("failed to read request body", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	cinemaHallResp, err := h.cinemaService.ImportCinemaHallLayout(ctx, &req)
+	if err != nil {
+		var importErr *cinema.LayoutImportError
+		if errors.As(err, &importErr) {
+			logger.Warn("invalid layout file")
+			ctx.JSON(http.StatusBadRequest, struct {
+				*response.ErrorResponse
+				Issues []*cinema.LayoutIssue `json:"issues"`
+			}{i18n.NewErrorResponse(ctx, http.StatusBadRequest, err), importErr.Issues})
+			return
+		}
+		if errors.Is(err, cinema.ErrInvalidHallLayout) || errors.Is(err, cinema.ErrInvalidLayoutFormat) {
+			logger.Warn("invalid layout")
+			i18n.WriteError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		if errors.Is(err, cinema.ErrCinemaHallNotFound) {
+			logger.Warn("cinema hall not found")
+			i18n.WriteError(ctx, http.StatusNotFound, err)
+			return
+		}
+		logger.Error("failed to import cinema hall layout", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	logger.Info("cinema hall layout imported successfully", applog.Uint("cinema_hall_id", cinemaHallResp.ID))
+	ctx.JSON(http.StatusOK, cinemaHallResp)
+}
+
+// 导出影厅布局 GET /api/v1/admin/cinema-halls/:id/layout?format=ascii|json|yaml&version=1
+func (h *CinemaHandler) ExportCinemaHallLayout(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "ExportCinemaHallLayout"))
+	var req request.ExportCinemaHallLayoutRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		logger.Error("failed to bind request", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	id, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Error("failed to get id from path", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req.ID = id
+
+	fileResp, err := h.cinemaService.ExportCinemaHallLayout(ctx, &req)
+	if err != nil {
+		if errors.Is(err, cinema.ErrInvalidLayoutFormat) {
+			logger.Warn("invalid layout format")
+			i18n.WriteError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		if errors.Is(err, cinema.ErrCinemaHallNotFound) || errors.Is(err, cinema.ErrLayoutVersionNotFound) {
+			logger.Warn("cinema hall or layout version not found")
+			i18n.WriteError(ctx, http.StatusNotFound, err)
+			return
+		}
+		logger.Error("failed to export cinema hall layout", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	logger.Info("cinema hall layout exported successfully", applog.Uint("cinema_hall_id", req.ID))
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileResp.Filename))
+	ctx.Data(http.StatusOK, fileResp.ContentType, fileResp.Content)
+}
+
+// 创建座位限制 POST /api/v1/admin/cinema-halls/:id/seat-restrictions
+func (h *CinemaHandler) CreateSeatRestrictions(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "CreateSeatRestrictions"))
+	var req request.CreateSeatRestrictionsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Error("failed to bind request", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	id, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Error("failed to get id from path", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req.CinemaHallID = id
+
+	restrictionsResp, err := h.cinemaService.CreateSeatRestrictions(ctx, &req)
+	if err != nil {
+		if errors.Is(err, cinema.ErrInvalidSeatRestriction) {
+			logger.Warn("invalid seat restriction")
+			i18n.WriteError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		if errors.Is(err, cinema.ErrCinemaHallNotFound) {
+			logger.Warn("cinema hall not found")
+			i18n.WriteError(ctx, http.StatusNotFound, err)
+			return
+		}
+		logger.Error("failed to create seat restrictions", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	logger.Info("seat restrictions created successfully", applog.Int("count", len(restrictionsResp.Restrictions)))
+	ctx.JSON(http.StatusCreated, restrictionsResp)
+}
+
+// 查询座位限制 GET /api/v1/admin/cinema-halls/:id/seat-restrictions?active_only=true
+func (h *CinemaHandler) ListSeatRestrictions(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "ListSeatRestrictions"))
+	var req request.ListSeatRestrictionsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		logger.Error("failed to bind request", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	id, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Error("failed to get id from path", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req.CinemaHallID = id
+
+	restrictionsResp, err := h.cinemaService.ListSeatRestrictions(ctx, &req)
+	if err != nil {
+		if errors.Is(err, cinema.ErrCinemaHallNotFound) {
+			logger.Warn("cinema hall not found")
+			i18n.WriteError(ctx, http.StatusNotFound, err)
+			return
+		}
+		logger.Error("failed to list seat restrictions", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	logger.Info("list seat restrictions successfully")
+	ctx.JSON(http.StatusOK, restrictionsResp)
+}
+
+// 删除座位限制 DELETE /api/v1/admin/cinema-halls/:id/seat-restrictions/:restriction_id
+func (h *CinemaHandler) DeleteSeatRestriction(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "DeleteSeatRestriction"))
+	var req request.DeleteSeatRestrictionRequest
+	id, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Error("failed to get id from path", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	restrictionID, err := getUintParam(ctx, "restriction_id")
+	if err != nil {
+		logger.Error("failed to get restriction id from path", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req.CinemaHallID = id
+	req.ID = restrictionID
+
+	if err := h.cinemaService.DeleteSeatRestriction(ctx, &req); err != nil {
+		if errors.Is(err, cinema.ErrSeatRestrictionNotFound) {
+			logger.Warn("seat restriction not found")
+			i18n.WriteError(ctx, http.StatusNotFound, err)
+			return
+		}
+		logger.Error("failed to delete seat restriction", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	logger.Info("seat restriction deleted successfully", applog.Uint("restriction_id", req.ID))
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+// 设置社交距离策略 PUT /api/v1/admin/cinema-halls/:id/distancing-policy
+func (h *CinemaHandler) UpdateDistancingPolicy(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "UpdateDistancingPolicy"))
+	var req request.UpdateDistancingPolicyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Error("failed to bind request", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	id, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Error("failed to get id from path", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req.CinemaHallID = id
+
+	cinemaHallResp, err := h.cinemaService.UpdateDistancingPolicy(ctx, &req)
+	if err != nil {
+		if errors.Is(err, cinema.ErrInvalidDistancingPolicy) {
+			logger.Warn("invalid distancing policy")
+			i18n.WriteError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		if errors.Is(err, cinema.ErrCinemaHallNotFound) {
+			logger.Warn("cinema hall not found")
+			i18n.WriteError(ctx, http.StatusNotFound, err)
+			return
+		}
+		logger.Error("failed to update distancing policy", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	logger.Info("distancing policy updated successfully", applog.Uint("cinema_hall_id", cinemaHallResp.ID))
+	ctx.JSON(http.StatusOK, cinemaHallResp)
+}
+
+// 清除社交距离策略 DELETE /api/v1/admin/cinema-halls/:id/distancing-policy
+func (h *CinemaHandler) DeleteDistancingPolicy(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "DeleteDistancingPolicy"))
+	var req request.DeleteDistancingPolicyRequest
+	id, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Error("failed to get id from path", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req.CinemaHallID = id
+
+	if err := h.cinemaService.DeleteDistancingPolicy(ctx, &req); err != nil {
+		if errors.Is(err, cinema.ErrCinemaHallNotFound) {
+			logger.Warn("cinema hall not found")
+			i18n.WriteError(ctx, http.StatusNotFound, err)
+			return
+		}
+		logger.Error("failed to delete distancing policy", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	logger.Info("distancing policy deleted successfully", applog.Uint("cinema_hall_id", req.CinemaHallID))
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+// 查询影厅布局版本 GET /api/v1/admin/cinema-halls/:id/layout-versions
+func (h *CinemaHandler) ListLayoutVersions(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "ListLayoutVersions"))
+	var req request.ListLayoutVersionsRequest
+	id, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Error("failed to get id from path", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req.ID = id
+
+	versionsResp, err := h.cinemaService.ListLayoutVersions(ctx, &req)
+	if err != nil {
+		if errors.Is(err, cinema.ErrCinemaHallNotFound) {
+			logger.Warn("cinema hall not found")
+			i18n.WriteError(ctx, http.StatusNotFound, err)
+			return
+		}
+		logger.Error("failed to list layout versions", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	logger.Info("list layout versions successfully", applog.Int("count", len(versionsResp.Versions)))
+	ctx.JSON(http.StatusOK, versionsResp)
+}
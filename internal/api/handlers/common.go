@@ -8,9 +8,14 @@ import (
 )
 
 func getIDFromPath(ctx *gin.Context) (uint, error) {
-	idStr, exists := ctx.Params.Get("id")
+	return getUintParam(ctx, "id")
+}
+
+// 获取路径中的数字参数，如 /cinema-halls/:id/seat-restrictions/:restriction_id
+func getUintParam(ctx *gin.Context, name string) (uint, error) {
+	idStr, exists := ctx.Params.Get(name)
 	if !exists {
-		return 0, fmt.Errorf("id not found in params")
+		return 0, fmt.Errorf("%s not found in params", name)
 	}
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
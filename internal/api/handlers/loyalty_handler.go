@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"errors"
+	"mrs/internal/api/dto/request"
+	"mrs/internal/api/i18n"
+	"mrs/internal/api/middleware"
+	"mrs/internal/app"
+	"mrs/internal/domain/shared"
+	applog "mrs/pkg/log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LoyaltyHandler struct {
+	loyaltyService app.LoyaltyService
+	logger         applog.Logger
+}
+
+func NewLoyaltyHandler(loyaltyService app.LoyaltyService, logger applog.Logger) *LoyaltyHandler {
+	return &LoyaltyHandler{
+		loyaltyService: loyaltyService,
+		logger:         logger.With(applog.String("Handler", "LoyaltyHandler")),
+	}
+}
+
+// 积分账户与会员等级 GET /api/v1/users/me/loyalty
+func (h *LoyaltyHandler) GetLoyaltyAccount(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "GetLoyaltyAccount"))
+
+	req := request.GetLoyaltyAccountRequest{UserID: ctx.GetUint(middleware.UserIDKey)}
+	accountResp, err := h.loyaltyService.GetLoyaltyAccount(ctx, &req)
+	if err != nil {
+		h.writeError(ctx, logger, err, "failed to get loyalty account")
+		return
+	}
+
+	logger.Info("get loyalty account successfully", applog.Uint("user_id", req.UserID))
+	ctx.JSON(http.StatusOK, accountResp)
+}
+
+// 积分流水 GET /api/v1/users/me/loyalty/ledger
+func (h *LoyaltyHandler) ListLoyaltyLedger(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "ListLoyaltyLedger"))
+
+	var req request.ListLoyaltyLedgerRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		logger.Warn("failed to bind list loyalty ledger request", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req.UserID = ctx.GetUint(middleware.UserIDKey)
+
+	ledgerResp, err := h.loyaltyService.ListLoyaltyLedger(ctx, &req)
+	if err != nil {
+		h.writeError(ctx, logger, err, "failed to list loyalty ledger")
+		return
+	}
+
+	logger.Info("list loyalty ledger successfully", applog.Int("total", ledgerResp.Pagination.TotalCount))
+	ctx.JSON(http.StatusOK, ledgerResp)
+}
+
+func (h *LoyaltyHandler) writeError(ctx *gin.Context, logger applog.Logger, err error, msg string) {
+	// 熔断器打开
+	if errors.Is(err, shared.ErrCircuitReadOperationBusy) {
+		logger.Warn(msg, applog.Error(err))
+		i18n.WriteError(ctx, http.StatusServiceUnavailable, err)
+		return
+	}
+	logger.Error(msg, applog.Error(err))
+	i18n.WriteError(ctx, http.StatusInternalServerError, err)
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"mrs/internal/api/dto/request"
+	"mrs/internal/api/i18n"
+	"mrs/internal/app"
+	"mrs/internal/domain/movie"
+	"mrs/internal/domain/shared"
+	"mrs/internal/domain/shared/blob"
+	applog "mrs/pkg/log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	mediaFormField = "file"
+	// multipart 表单除文件外的额外开销
+	multipartOverhead = 1 << 20
+	// 媒体文件以内容哈希命名，内容不会变化，可长期缓存
+	mediaCacheControl = "public, max-age=31536000, immutable"
+)
+
+type MediaHandler struct {
+	mediaService app.MediaService
+	policy       *movie.MediaPolicy
+	logger       applog.Logger
+}
+
+func NewMediaHandler(mediaService app.MediaService, policy *movie.MediaPolicy, logger applog.Logger) *MediaHandler {
+	return &MediaHandler{
+		mediaService: mediaService,
+		policy:       policy,
+		logger:       logger.With(applog.String("Handler", "MediaHandler")),
+	}
+}
+
+// 上传电影媒体文件 POST /api/v1/admin/movies/:id/media/:kind
+// kind: poster | backdrop | trailer，multipart 表单字段 file
+func (h *MediaHandler) UploadMovieMedia(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "UploadMovieMedia"))
+
+	movieID, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Warn("failed to get movie id", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	kind := movie.MediaKind(ctx.Param("kind"))
+	if !kind.IsValid() {
+		err := fmt.Errorf("%w: unknown media kind %q", movie.ErrUnsupportedMediaType, kind)
+		logger.Warn("invalid media kind", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	// 在解析表单前限制请求体大小，超出部分不会被读取
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, h.policy.MaxSize(kind)+multipartOverhead)
+	fileHeader, err := ctx.FormFile(mediaFormField)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			err = fmt.Errorf("%w: request body exceeds %d bytes", movie.ErrMediaTooLarge, maxBytesErr.Limit)
+			logger.Warn("media file too large", applog.Error(err))
+			i18n.WriteError(ctx, http.StatusRequestEntityTooLarge, err)
+			return
+		}
+		logger.Warn("failed to get media file", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		logger.Error("failed to open media file", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer file.Close()
+
+	req := request.UploadMovieMediaRequest{
+		MovieID:  movieID,
+		Kind:     kind,
+		Filename: fileHeader.Filename,
+		Size:     fileHeader.Size,
+		Content:  file,
+	}
+	mediaResp, err := h.mediaService.UploadMovieMedia(ctx, &req)
+	if err != nil {
+		h.writeError(ctx, logger, err, "failed to upload movie media")
+		return
+	}
+
+	logger.Info("upload movie media successfully", applog.Uint("movie_id", movieID), applog.String("kind", string(kind)))
+	ctx.JSON(http.StatusCreated, mediaResp)
+}
+
+// 读取媒体文件 GET /media/*key（本地存储驱动时作为公开访问地址）
+func (h *MediaHandler) GetMedia(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "GetMedia"))
+
+	req := request.GetMediaRequest{Key: strings.TrimPrefix(ctx.Param("key"), "/")}
+	object, err := h.mediaService.OpenMedia(ctx, &req)
+	if err != nil {
+		h.writeError(ctx, logger, err, "failed to get media")
+		return
+	}
+	defer object.Body.Close()
+
+	ctx.DataFromReader(http.StatusOK, object.Size, object.ContentType, object.Body, map[string]string{
+		"Cache-Control":          mediaCacheControl,
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// 将媒体相关错误映射为HTTP状态码
+func (h *MediaHandler) writeError(ctx *gin.Context, logger applog.Logger, err error, msg string) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, movie.ErrMovieNotFound), errors.Is(err, blob.ErrBlobNotFound),
+		errors.Is(err, blob.ErrInvalidBlobKey):
+		status = http.StatusNotFound
+	case errors.Is(err, movie.ErrMediaTooLarge):
+		status = http.StatusRequestEntityTooLarge
+	case errors.Is(err, movie.ErrUnsupportedMediaType):
+		status = http.StatusUnsupportedMediaType
+	case errors.Is(err, movie.ErrInvalidMediaImage):
+		status = http.StatusBadRequest
+	case errors.Is(err, shared.ErrCircuitReadOperationBusy), errors.Is(err, shared.ErrCircuitWriteOperationBusy):
+		status = http.StatusServiceUnavailable
+	}
+
+	if status == http.StatusInternalServerError {
+		logger.Error(msg, applog.Error(err))
+	} else {
+		logger.Warn(msg, applog.Error(err))
+	}
+	i18n.WriteError(ctx, status, err)
+}
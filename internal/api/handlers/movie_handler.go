@@ -3,9 +3,11 @@ package handlers
 import (
 	"errors"
 	"mrs/internal/api/dto/request"
+	"mrs/internal/api/i18n"
 	"mrs/internal/app"
 	"mrs/internal/domain/movie"
 	"mrs/internal/domain/shared"
+	"mrs/internal/domain/shared/vo"
 	applog "mrs/pkg/log"
 	"net/http"
 	"strconv"
@@ -31,7 +33,7 @@ func (h *MovieHandler) CreateMovie(ctx *gin.Context) {
 	var req request.CreateMovieRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		logger.Warn("failed to bind create movie request", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -40,17 +42,23 @@ func (h *MovieHandler) CreateMovie(ctx *gin.Context) {
 		// 电影可能已存在
 		if errors.Is(err, movie.ErrMovieAlreadyExists) {
 			logger.Warn("movie already exists", applog.Error(err))
-			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			i18n.WriteError(ctx, http.StatusConflict, err)
+			return
+		}
+		// 演职员重复（同一影人同一职务）或年龄分级不在对照表中
+		if errors.Is(err, movie.ErrInvalidCredit) || errors.Is(err, movie.ErrInvalidAgeRating) {
+			logger.Warn("invalid movie credits", applog.Error(err))
+			i18n.WriteError(ctx, http.StatusBadRequest, err)
 			return
 		}
 		// 熔断器打开
 		if errors.Is(err, shared.ErrCircuitWriteOperationBusy) {
 			logger.Warn("circuit breaker is open", applog.Error(err))
-			ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+			i18n.WriteError(ctx, http.StatusServiceUnavailable, err)
 			return
 		}
 		logger.Error("failed to create movie", applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 	logger.Info("movie created successfully", applog.Uint("movie_id", uint(movieResp.ID)))
@@ -64,25 +72,25 @@ func (h *MovieHandler) GetMovie(ctx *gin.Context) {
 	movieID, err := strconv.ParseUint(movieId, 10, 32)
 	if err != nil {
 		logger.Warn("failed to parse movie id", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
-	movieResp, err := h.movieService.GetMovie(ctx, &request.GetMovieRequest{ID: uint(movieID)})
+	movieResp, err := h.movieService.GetMovie(ctx, &request.GetMovieRequest{ID: uint(movieID), Locales: i18n.Locales(ctx)})
 	if err != nil {
 		if errors.Is(err, movie.ErrMovieNotFound) {
 			logger.Warn("movie not found", applog.Error(err))
-			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			i18n.WriteError(ctx, http.StatusNotFound, err)
 			return
 		}
 		// 熔断器打开
 		if errors.Is(err, shared.ErrCircuitReadOperationBusy) {
 			logger.Warn("circuit breaker is open", applog.Error(err))
-			ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+			i18n.WriteError(ctx, http.StatusServiceUnavailable, err)
 			return
 		}
 		logger.Error("failed to get movie", applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -97,28 +105,38 @@ func (h *MovieHandler) UpdateMovie(ctx *gin.Context) {
 	movieID, err := strconv.ParseUint(movieId, 10, 32)
 	if err != nil {
 		logger.Warn("failed to parse movie id", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	var req request.UpdateMovieRequest
 	if err := ctx.ShouldBindBodyWithJSON(&req); err != nil {
 		logger.Warn("failed to bind update movie request", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 	req.ID = uint(movieID)
 
 	movieResp, err := h.movieService.UpdateMovie(ctx, &req)
 	if err != nil {
+		if errors.Is(err, movie.ErrMovieNotFound) {
+			logger.Warn("movie not found", applog.Error(err))
+			i18n.WriteError(ctx, http.StatusNotFound, err)
+			return
+		}
+		if errors.Is(err, movie.ErrInvalidCredit) || errors.Is(err, movie.ErrInvalidAgeRating) {
+			logger.Warn("invalid movie credits", applog.Error(err))
+			i18n.WriteError(ctx, http.StatusBadRequest, err)
+			return
+		}
 		// 熔断器打开
 		if errors.Is(err, shared.ErrCircuitWriteOperationBusy) {
 			logger.Warn("circuit breaker is open", applog.Error(err))
-			ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+			i18n.WriteError(ctx, http.StatusServiceUnavailable, err)
 			return
 		}
 		logger.Error("failed to update movie", applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -133,7 +151,7 @@ func (h *MovieHandler) DeleteMovie(ctx *gin.Context) {
 	movieID, err := strconv.ParseUint(movieId, 10, 32)
 	if err != nil {
 		logger.Warn("failed to parse movie id", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -142,11 +160,11 @@ func (h *MovieHandler) DeleteMovie(ctx *gin.Context) {
 		// 熔断器打开
 		if errors.Is(err, shared.ErrCircuitWriteOperationBusy) {
 			logger.Warn("circuit breaker is open", applog.Error(err))
-			ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+			i18n.WriteError(ctx, http.StatusServiceUnavailable, err)
 			return
 		}
 		logger.Error("failed to delete movie", applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -154,26 +172,87 @@ func (h *MovieHandler) DeleteMovie(ctx *gin.Context) {
 	ctx.JSON(http.StatusNoContent, nil)
 }
 
+// 归档电影 POST /api/v1/admin/movies/{movieId}/archive
+func (h *MovieHandler) ArchiveMovie(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "ArchiveMovie"))
+	movieID, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Warn("failed to parse movie id", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	movieResp, err := h.movieService.ArchiveMovie(ctx, &request.ArchiveMovieRequest{ID: movieID})
+	if err != nil {
+		h.writeLifecycleError(ctx, logger, err, "failed to archive movie")
+		return
+	}
+
+	logger.Info("movie archived successfully", applog.Uint("movie_id", movieID))
+	ctx.JSON(http.StatusOK, movieResp)
+}
+
+// 恢复已归档的电影 POST /api/v1/admin/movies/{movieId}/restore
+func (h *MovieHandler) RestoreMovie(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "RestoreMovie"))
+	movieID, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Warn("failed to parse movie id", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	movieResp, err := h.movieService.RestoreMovie(ctx, &request.RestoreMovieRequest{ID: movieID})
+	if err != nil {
+		h.writeLifecycleError(ctx, logger, err, "failed to restore movie")
+		return
+	}
+
+	logger.Info("movie restored successfully", applog.Uint("movie_id", movieID))
+	ctx.JSON(http.StatusOK, movieResp)
+}
+
+// 将生命周期操作的错误映射为HTTP状态码
+func (h *MovieHandler) writeLifecycleError(ctx *gin.Context, logger applog.Logger, err error, msg string) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, movie.ErrMovieNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, movie.ErrMovieHasUpcomingShowtimes), errors.Is(err, movie.ErrMovieNotArchived):
+		status = http.StatusConflict
+	case errors.Is(err, shared.ErrCircuitReadOperationBusy), errors.Is(err, shared.ErrCircuitWriteOperationBusy):
+		status = http.StatusServiceUnavailable
+	}
+
+	if status == http.StatusInternalServerError {
+		logger.Error(msg, applog.Error(err))
+	} else {
+		logger.Warn(msg, applog.Error(err))
+	}
+	i18n.WriteError(ctx, status, err)
+}
+
 // 获取电影列表 GET /api/v1/movies
 func (h *MovieHandler) ListMovies(ctx *gin.Context) {
 	logger := h.logger.With(applog.String("Method", "ListMovies"))
 	var req request.ListMovieRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
 		logger.Warn("failed to bind list movie request", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
+	req.Locales = i18n.Locales(ctx)
 
 	movieResp, err := h.movieService.ListMovies(ctx, &req)
 	if err != nil {
 		// 熔断器打开
 		if errors.Is(err, shared.ErrCircuitReadOperationBusy) {
 			logger.Warn("circuit breaker is open", applog.Error(err))
-			ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+			i18n.WriteError(ctx, http.StatusServiceUnavailable, err)
 			return
 		}
 		logger.Error("failed to list movies", applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -181,13 +260,75 @@ func (h *MovieHandler) ListMovies(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, movieResp)
 }
 
+// 全文搜索电影 GET /api/v1/movies/search
+func (h *MovieHandler) SearchMovies(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "SearchMovies"))
+	var req request.SearchMoviesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		logger.Warn("failed to bind search movies request", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req.Locales = i18n.Locales(ctx)
+
+	searchResp, err := h.movieService.SearchMovies(ctx, &req)
+	if err != nil {
+		// 熔断器打开
+		if errors.Is(err, shared.ErrCircuitReadOperationBusy) {
+			logger.Warn("circuit breaker is open", applog.Error(err))
+			i18n.WriteError(ctx, http.StatusServiceUnavailable, err)
+			return
+		}
+		logger.Error("failed to search movies", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	logger.Info("movies searched successfully", applog.Int("total", searchResp.Pagination.TotalCount))
+	ctx.JSON(http.StatusOK, searchResp)
+}
+
+// 批量导入片单 POST /api/v1/admin/movies/import?format=json|csv&dry_run=true
+func (h *MovieHandler) ImportMovies(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "ImportMovies"))
+	var req request.ImportMoviesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		logger.Warn("failed to bind import movies request", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	data, err := ctx.GetRawData()
+	if err != nil {
+		logger.Warn("failed to read request body", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req.Data = data
+
+	// 单条记录的错误记录在报告中，只有片单整体无法解析时才返回错误
+	importResp, err := h.movieService.ImportMovies(ctx, &req)
+	if err != nil {
+		if errors.Is(err, movie.ErrInvalidCatalogFeed) {
+			logger.Warn("invalid catalog feed", applog.Error(err))
+			i18n.WriteError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		logger.Error("failed to import movies", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	logger.Info("movies imported successfully", applog.Any("summary", importResp.Summary))
+	ctx.JSON(http.StatusOK, importResp)
+}
+
 // 创建类型 POST /api/v1/admin/genres
 func (h *MovieHandler) CreateGenre(ctx *gin.Context) {
 	logger := h.logger.With(applog.String("Method", "CreateGenre"))
 	var req request.CreateGenreRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		logger.Warn("failed to bind create genre request", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -196,11 +337,11 @@ func (h *MovieHandler) CreateGenre(ctx *gin.Context) {
 		// 熔断器打开
 		if errors.Is(err, shared.ErrCircuitWriteOperationBusy) {
 			logger.Warn("circuit breaker is open", applog.Error(err))
-			ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+			i18n.WriteError(ctx, http.StatusServiceUnavailable, err)
 			return
 		}
 		logger.Error("failed to create genre", applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -215,14 +356,14 @@ func (h *MovieHandler) UpdateGenre(ctx *gin.Context) {
 	genreID, err := strconv.ParseUint(genreId, 10, 32)
 	if err != nil {
 		logger.Warn("failed to parse genre id", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	var req request.UpdateGenreRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		logger.Warn("failed to bind update genre request", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 	req.ID = uint(genreID)
@@ -232,11 +373,11 @@ func (h *MovieHandler) UpdateGenre(ctx *gin.Context) {
 		// 熔断器打开
 		if errors.Is(err, shared.ErrCircuitWriteOperationBusy) {
 			logger.Warn("circuit breaker is open", applog.Error(err))
-			ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+			i18n.WriteError(ctx, http.StatusServiceUnavailable, err)
 			return
 		}
 		logger.Error("failed to update genre", applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -251,24 +392,24 @@ func (h *MovieHandler) DeleteGenre(ctx *gin.Context) {
 	genreID, err := strconv.ParseUint(genreId, 10, 32)
 	if err != nil {
 		logger.Warn("failed to parse genre id", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	if err := h.movieService.DeleteGenre(ctx, &request.DeleteGenreRequest{ID: uint(genreID)}); err != nil {
 		if errors.Is(err, movie.ErrGenreReferenced) {
 			logger.Warn("genre has movies", applog.Error(err))
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			i18n.WriteError(ctx, http.StatusBadRequest, err)
 			return
 		}
 		// 熔断器打开
 		if errors.Is(err, shared.ErrCircuitWriteOperationBusy) {
 			logger.Warn("circuit breaker is open", applog.Error(err))
-			ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+			i18n.WriteError(ctx, http.StatusServiceUnavailable, err)
 			return
 		}
 		logger.Error("failed to delete genre", applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -279,18 +420,137 @@ func (h *MovieHandler) DeleteGenre(ctx *gin.Context) {
 // 获取所有类型 GET /api/v1/genres
 func (h *MovieHandler) ListAllGenres(ctx *gin.Context) {
 	logger := h.logger.With(applog.String("Method", "ListGenres"))
-	genreResp, err := h.movieService.ListAllGenres(ctx)
+	genreResp, err := h.movieService.ListAllGenres(ctx, &request.ListGenresRequest{Locales: i18n.Locales(ctx)})
 	if err != nil {
 		// 熔断器打开
 		if errors.Is(err, shared.ErrCircuitReadOperationBusy) {
 			logger.Warn("circuit breaker is open", applog.Error(err))
-			ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+			i18n.WriteError(ctx, http.StatusServiceUnavailable, err)
 			return
 		}
 		logger.Error("failed to list genres", applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 	logger.Info("genres listed successfully", applog.Int("total", len(genreResp.Genres)))
 	ctx.JSON(http.StatusOK, genreResp)
 }
+
+// 新增或覆盖电影翻译 PUT /api/v1/admin/movies/{movieId}/translations/{locale}
+func (h *MovieHandler) UpsertMovieTranslation(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "UpsertMovieTranslation"))
+	movieID, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Warn("failed to get movie id", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	var req request.UpsertMovieTranslationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Warn("failed to bind upsert movie translation request", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req.MovieID = movieID
+	req.Locale = ctx.Param("locale")
+
+	translationResp, err := h.movieService.UpsertMovieTranslation(ctx, &req)
+	if err != nil {
+		h.writeTranslationError(ctx, logger, err, "failed to upsert movie translation")
+		return
+	}
+
+	logger.Info("movie translation upserted successfully", applog.Uint("movie_id", movieID), applog.String("locale", translationResp.Locale))
+	ctx.JSON(http.StatusOK, translationResp)
+}
+
+// 删除电影翻译 DELETE /api/v1/admin/movies/{movieId}/translations/{locale}
+func (h *MovieHandler) DeleteMovieTranslation(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "DeleteMovieTranslation"))
+	movieID, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Warn("failed to get movie id", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	req := request.DeleteMovieTranslationRequest{MovieID: movieID, Locale: ctx.Param("locale")}
+	if err := h.movieService.DeleteMovieTranslation(ctx, &req); err != nil {
+		h.writeTranslationError(ctx, logger, err, "failed to delete movie translation")
+		return
+	}
+
+	logger.Info("movie translation deleted successfully", applog.Uint("movie_id", movieID), applog.String("locale", req.Locale))
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+// 新增或覆盖类型翻译 PUT /api/v1/admin/genres/{genreId}/translations/{locale}
+func (h *MovieHandler) UpsertGenreTranslation(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "UpsertGenreTranslation"))
+	genreID, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Warn("failed to get genre id", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	var req request.UpsertGenreTranslationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Warn("failed to bind upsert genre translation request", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req.GenreID = genreID
+	req.Locale = ctx.Param("locale")
+
+	translationResp, err := h.movieService.UpsertGenreTranslation(ctx, &req)
+	if err != nil {
+		h.writeTranslationError(ctx, logger, err, "failed to upsert genre translation")
+		return
+	}
+
+	logger.Info("genre translation upserted successfully", applog.Uint("genre_id", genreID), applog.String("locale", translationResp.Locale))
+	ctx.JSON(http.StatusOK, translationResp)
+}
+
+// 删除类型翻译 DELETE /api/v1/admin/genres/{genreId}/translations/{locale}
+func (h *MovieHandler) DeleteGenreTranslation(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "DeleteGenreTranslation"))
+	genreID, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Warn("failed to get genre id", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	req := request.DeleteGenreTranslationRequest{GenreID: genreID, Locale: ctx.Param("locale")}
+	if err := h.movieService.DeleteGenreTranslation(ctx, &req); err != nil {
+		h.writeTranslationError(ctx, logger, err, "failed to delete genre translation")
+		return
+	}
+
+	logger.Info("genre translation deleted successfully", applog.Uint("genre_id", genreID), applog.String("locale", req.Locale))
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+// 将翻译管理的错误映射为HTTP状态码
+func (h *MovieHandler) writeTranslationError(ctx *gin.Context, logger applog.Logger, err error, msg string) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, movie.ErrMovieNotFound), errors.Is(err, movie.ErrGenreNotFound),
+		errors.Is(err, movie.ErrTranslationNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, vo.ErrInvalidLocale):
+		status = http.StatusBadRequest
+	case errors.Is(err, shared.ErrCircuitReadOperationBusy), errors.Is(err, shared.ErrCircuitWriteOperationBusy):
+		status = http.StatusServiceUnavailable
+	}
+
+	if status == http.StatusInternalServerError {
+		logger.Error(msg, applog.Error(err))
+	} else {
+		logger.Warn(msg, applog.Error(err))
+	}
+	i18n.WriteError(ctx, status, err)
+}
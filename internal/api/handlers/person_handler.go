@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"errors"
+	"mrs/internal/api/dto/request"
+	"mrs/internal/api/i18n"
+	"mrs/internal/app"
+	"mrs/internal/domain/movie"
+	applog "mrs/pkg/log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PersonHandler struct {
+	personService app.PersonService
+	logger        applog.Logger
+}
+
+func NewPersonHandler(personService app.PersonService, logger applog.Logger) *PersonHandler {
+	return &PersonHandler{
+		personService: personService,
+		logger:        logger.With(applog.String("Handler", "PersonHandler")),
+	}
+}
+
+// 创建影人 POST /api/v1/admin/people
+func (h *PersonHandler) CreatePerson(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "CreatePerson"))
+
+	var req request.CreatePersonRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Warn("failed to bind create person request", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	personResp, err := h.personService.CreatePerson(ctx, &req)
+	if err != nil {
+		h.writeError(ctx, logger, err, "failed to create person")
+		return
+	}
+
+	logger.Info("create person successfully", applog.Uint("person_id", personResp.ID))
+	ctx.JSON(http.StatusCreated, personResp)
+}
+
+// 查询影人列表 GET /api/v1/people
+func (h *PersonHandler) ListPeople(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "ListPeople"))
+
+	var req request.ListPeopleRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		logger.Warn("failed to bind list people request", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	peopleResp, err := h.personService.ListPeople(ctx, &req)
+	if err != nil {
+		h.writeError(ctx, logger, err, "failed to list people")
+		return
+	}
+
+	logger.Info("list people successfully", applog.Int("total", peopleResp.Pagination.TotalCount))
+	ctx.JSON(http.StatusOK, peopleResp)
+}
+
+// 获取影人详情 GET /api/v1/people/:id
+func (h *PersonHandler) GetPerson(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "GetPerson"))
+
+	personID, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Warn("failed to get person id", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	personResp, err := h.personService.GetPerson(ctx, &request.GetPersonRequest{ID: personID})
+	if err != nil {
+		h.writeError(ctx, logger, err, "failed to get person")
+		return
+	}
+
+	logger.Info("get person successfully", applog.Uint("person_id", personID))
+	ctx.JSON(http.StatusOK, personResp)
+}
+
+// 获取影人作品年表 GET /api/v1/people/:id/filmography
+func (h *PersonHandler) GetFilmography(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "GetFilmography"))
+
+	personID, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Warn("failed to get person id", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	var req request.GetFilmographyRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		logger.Warn("failed to bind filmography request", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req.ID = personID
+
+	filmographyResp, err := h.personService.GetFilmography(ctx, &req)
+	if err != nil {
+		h.writeError(ctx, logger, err, "failed to get filmography")
+		return
+	}
+
+	logger.Info("get filmography successfully", applog.Uint("person_id", personID))
+	ctx.JSON(http.StatusOK, filmographyResp)
+}
+
+// 更新影人 PUT /api/v1/admin/people/:id
+func (h *PersonHandler) UpdatePerson(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "UpdatePerson"))
+
+	personID, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Warn("failed to get person id", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	var req request.UpdatePersonRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Warn("failed to bind update person request", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req.ID = personID
+
+	personResp, err := h.personService.UpdatePerson(ctx, &req)
+	if err != nil {
+		h.writeError(ctx, logger, err, "failed to update person")
+		return
+	}
+
+	logger.Info("update person successfully", applog.Uint("person_id", personID))
+	ctx.JSON(http.StatusOK, personResp)
+}
+
+// 删除影人 DELETE /api/v1/admin/people/:id
+func (h *PersonHandler) DeletePerson(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "DeletePerson"))
+
+	personID, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Warn("failed to get person id", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.personService.DeletePerson(ctx, &request.DeletePersonRequest{ID: personID}); err != nil {
+		h.writeError(ctx, logger, err, "failed to delete person")
+		return
+	}
+
+	logger.Info("delete person successfully", applog.Uint("person_id", personID))
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+// 将影人相关错误映射为HTTP状态码
+func (h *PersonHandler) writeError(ctx *gin.Context, logger applog.Logger, err error, msg string) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, movie.ErrPersonNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, movie.ErrPersonAlreadyExists), errors.Is(err, movie.ErrPersonReferenced):
+		status = http.StatusConflict
+	}
+
+	if status == http.StatusInternalServerError {
+		logger.Error(msg, applog.Error(err))
+	} else {
+		logger.Warn(msg, applog.Error(err))
+	}
+	i18n.WriteError(ctx, status, err)
+}
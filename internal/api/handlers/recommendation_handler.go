@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"errors"
+	"mrs/internal/api/dto/request"
+	"mrs/internal/api/i18n"
+	"mrs/internal/api/middleware"
+	"mrs/internal/app"
+	"mrs/internal/domain/shared"
+	applog "mrs/pkg/log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RecommendationHandler struct {
+	recommendationService app.RecommendationService
+	logger                applog.Logger
+}
+
+func NewRecommendationHandler(recommendationService app.RecommendationService, logger applog.Logger) *RecommendationHandler {
+	return &RecommendationHandler{
+		recommendationService: recommendationService,
+		logger:                logger.With(applog.String("Handler", "RecommendationHandler")),
+	}
+}
+
+// 查询个性化推荐 GET /api/v1/users/me/recommendations
+func (h *RecommendationHandler) GetRecommendations(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "GetRecommendations"))
+
+	var req request.GetRecommendationsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		logger.Warn("failed to bind get recommendations request", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req.UserID = ctx.GetUint(middleware.UserIDKey)
+
+	recommendationsResp, err := h.recommendationService.GetRecommendations(ctx, &req)
+	if err != nil {
+		if errors.Is(err, shared.ErrCircuitReadOperationBusy) {
+			logger.Warn("failed to get recommendations", applog.Error(err))
+			i18n.WriteError(ctx, http.StatusServiceUnavailable, err)
+			return
+		}
+		logger.Error("failed to get recommendations", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	logger.Info("get recommendations successfully", applog.String("source", recommendationsResp.Source))
+	ctx.JSON(http.StatusOK, recommendationsResp)
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"mrs/internal/api/export"
+	"mrs/internal/api/i18n"
+	applog "mrs/pkg/log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 报表导出：CSV 与 XLSX 以附件形式下载，表头按 Accept-Language 本地化，
+// CSV 中的数字格式（小数点与分隔符）按首选语言确定
+
+// 是否以文件形式导出
+func isFileExport(format string) bool {
+	return format == string(export.FormatCSV) || format == string(export.FormatXLSX)
+}
+
+// 设置下载响应头并创建表格写入器
+func newReportWriter(c *gin.Context, format export.Format, name string, sections bool) (*export.Tables, error) {
+	locales := i18n.Locales(c)
+	opts := export.Options{Locale: "en", Sections: sections}
+	if len(locales) > 0 {
+		opts.Locale = locales[0]
+	}
+	w, err := export.NewTableWriter(format, c.Writer, opts)
+	if err != nil {
+		return nil, err
+	}
+	c.Header("Content-Type", export.ContentType(format))
+	if format != export.FormatJSON {
+		c.Header("Content-Disposition", export.ContentDisposition(name, format, time.Now()))
+	}
+	c.Status(http.StatusOK)
+	return &export.Tables{W: w, Label: func(key string) string { return i18n.Label(locales, key) }}, nil
+}
+
+// 导出已生成的报表，写出过程中的错误只能记录日志（响应头已经发送）
+func (h *ReportHandler) writeReportFile(c *gin.Context, format, name string, write func(*export.Tables) error) {
+	if err := exportReportFile(c, h.logger, format, name, write); err != nil {
+		h.writeError(c, err, "create report writer error")
+	}
+}
+
+// 写出报表文件，只返回创建写入器的错误（此时尚未写出响应，调用方负责返回错误响应）
+func exportReportFile(c *gin.Context, logger applog.Logger, format, name string, write func(*export.Tables) error) error {
+	tables, err := newReportWriter(c, export.Format(format), name, true)
+	if err != nil {
+		return err
+	}
+	if err := write(tables); err != nil {
+		logger.Error("write report file error", applog.String("report", name), applog.Error(err))
+		return nil
+	}
+	if err := tables.W.Close(); err != nil {
+		logger.Error("close report file error", applog.String("report", name), applog.Error(err))
+		return nil
+	}
+	logger.Info("export report successfully", applog.String("report", name), applog.String("format", format))
+	return nil
+}
@@ -1,8 +1,12 @@
 package handlers
 
 import (
+	"errors"
 	"mrs/internal/api/dto/request"
+	"mrs/internal/api/export"
+	"mrs/internal/api/i18n"
 	"mrs/internal/app"
+	"mrs/internal/domain/booking"
 	applog "mrs/pkg/log"
 	"net/http"
 
@@ -23,15 +27,137 @@ func (h *ReportHandler) GenerateSalesReport(c *gin.Context) {
 	var req request.GenerateSalesReportRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
 		h.logger.Error("invalid request", applog.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		i18n.WriteError(c, http.StatusBadRequest, err)
 		return
 	}
 	resp, err := h.reportService.GenerateSalesReport(c, &req)
 	if err != nil {
-		h.logger.Error("generate sales report error", applog.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		h.writeError(c, err, "generate sales report error")
+		return
+	}
+	if isFileExport(req.Format) {
+		h.writeReportFile(c, req.Format, "sales-report", func(t *export.Tables) error {
+			return export.WriteSalesReport(t, resp)
+		})
 		return
 	}
 	c.JSON(http.StatusOK, resp)
 	h.logger.Info("generate sales report successfully")
 }
+
+// GET /api/v1/admin/reports/occupancy 生成上座率报告
+func (h *ReportHandler) GenerateOccupancyReport(c *gin.Context) {
+	var req request.GenerateOccupancyReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("invalid request", applog.Error(err))
+		i18n.WriteError(c, http.StatusBadRequest, err)
+		return
+	}
+	resp, err := h.reportService.GenerateOccupancyReport(c, &req)
+	if err != nil {
+		h.logger.Error("generate occupancy report error", applog.Error(err))
+		i18n.WriteError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if isFileExport(req.Format) {
+		h.writeReportFile(c, req.Format, "occupancy-report", func(t *export.Tables) error {
+			return export.WriteOccupancyReport(t, resp)
+		})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+	h.logger.Info("generate occupancy report successfully")
+}
+
+// GET /api/v1/admin/reports/funnel 生成转化漏斗报告
+func (h *ReportHandler) GenerateFunnelReport(c *gin.Context) {
+	var req request.GenerateFunnelReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("invalid request", applog.Error(err))
+		i18n.WriteError(c, http.StatusBadRequest, err)
+		return
+	}
+	resp, err := h.reportService.GenerateFunnelReport(c, &req)
+	if err != nil {
+		h.logger.Error("generate funnel report error", applog.Error(err))
+		i18n.WriteError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if isFileExport(req.Format) {
+		h.writeReportFile(c, req.Format, "funnel-report", func(t *export.Tables) error {
+			return export.WriteFunnelReport(t, resp)
+		})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+	h.logger.Info("generate funnel report successfully")
+}
+
+// GET /api/v1/admin/reports/bookings 导出订单明细（json、csv 或 xlsx），逐行写出响应
+func (h *ReportHandler) ExportBookings(c *gin.Context) {
+	var req request.ExportBookingsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("invalid request", applog.Error(err))
+		i18n.WriteError(c, http.StatusBadRequest, err)
+		return
+	}
+	format := export.Format(req.Format)
+	if format == "" {
+		format = export.FormatJSON
+	}
+
+	// 读到第一条记录（或读取完成）时才写出响应头，参数错误与查询失败仍可返回错误响应
+	var tables *export.Tables
+	begin := func() error {
+		t, err := newReportWriter(c, format, "bookings", false)
+		if err != nil {
+			return err
+		}
+		tables = t
+		return tables.Begin("bookings", export.BookingColumns...)
+	}
+	err := h.reportService.ExportBookings(c, &req, func(r *booking.SalesRecord) error {
+		if tables == nil {
+			if err := begin(); err != nil {
+				return err
+			}
+		}
+		return tables.W.WriteRow(export.BookingRow(r)...)
+	})
+	if err != nil {
+		if tables == nil {
+			h.writeError(c, err, "export bookings error")
+			return
+		}
+		h.logger.Error("export bookings interrupted", applog.Error(err))
+		return
+	}
+	if tables == nil {
+		if err := begin(); err != nil {
+			h.writeError(c, err, "export bookings error")
+			return
+		}
+	}
+	if err := tables.W.Close(); err != nil {
+		h.logger.Error("close bookings export error", applog.Error(err))
+		return
+	}
+	h.logger.Info("export bookings successfully", applog.String("format", string(format)))
+}
+
+// 将报表参数错误映射为400，其余为500
+func (h *ReportHandler) writeError(c *gin.Context, err error, msg string) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, booking.ErrInvalidReportRange), errors.Is(err, booking.ErrInvalidTimezone),
+		errors.Is(err, booking.ErrTooManyReportPeriods), errors.Is(err, export.ErrUnsupportedFormat):
+		status = http.StatusBadRequest
+	}
+
+	if status == http.StatusInternalServerError {
+		h.logger.Error(msg, applog.Error(err))
+	} else {
+		h.logger.Warn(msg, applog.Error(err))
+	}
+	i18n.WriteError(c, status, err)
+}
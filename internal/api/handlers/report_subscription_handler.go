@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"errors"
+	"mrs/internal/api/dto/request"
+	"mrs/internal/api/i18n"
+	"mrs/internal/api/middleware"
+	"mrs/internal/app"
+	"mrs/internal/domain/report"
+	applog "mrs/pkg/log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReportSubscriptionHandler struct {
+	subscriptionService app.ReportSubscriptionService
+	logger              applog.Logger
+}
+
+func NewReportSubscriptionHandler(subscriptionService app.ReportSubscriptionService, logger applog.Logger) *ReportSubscriptionHandler {
+	return &ReportSubscriptionHandler{
+		subscriptionService: subscriptionService,
+		logger:              logger.With(applog.String("Handler", "ReportSubscriptionHandler")),
+	}
+}
+
+// 创建报表订阅 POST /api/v1/admin/reports/subscriptions
+func (h *ReportSubscriptionHandler) CreateSubscription(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "CreateSubscription"))
+
+	var req request.CreateReportSubscriptionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Warn("failed to bind create report subscription request", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req.CreatedBy = ctx.GetUint(middleware.UserIDKey)
+
+	resp, err := h.subscriptionService.CreateSubscription(ctx, &req)
+	if err != nil {
+		h.writeError(ctx, logger, err, "failed to create report subscription")
+		return
+	}
+
+	logger.Info("create report subscription successfully", applog.Uint("subscription_id", resp.ID))
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// 查询报表订阅列表 GET /api/v1/admin/reports/subscriptions
+func (h *ReportSubscriptionHandler) ListSubscriptions(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "ListSubscriptions"))
+
+	var req request.ListReportSubscriptionsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		logger.Warn("failed to bind list report subscriptions request", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	resp, err := h.subscriptionService.ListSubscriptions(ctx, &req)
+	if err != nil {
+		h.writeError(ctx, logger, err, "failed to list report subscriptions")
+		return
+	}
+
+	logger.Info("list report subscriptions successfully", applog.Int("total", resp.Pagination.TotalCount))
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// 查询报表订阅 GET /api/v1/admin/reports/subscriptions/:id
+func (h *ReportSubscriptionHandler) GetSubscription(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "GetSubscription"))
+
+	id, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Warn("failed to get subscription id", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	resp, err := h.subscriptionService.GetSubscription(ctx, id)
+	if err != nil {
+		h.writeError(ctx, logger, err, "failed to get report subscription")
+		return
+	}
+
+	logger.Info("get report subscription successfully", applog.Uint("subscription_id", id))
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// 更新报表订阅（整体替换） PUT /api/v1/admin/reports/subscriptions/:id
+func (h *ReportSubscriptionHandler) UpdateSubscription(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "UpdateSubscription"))
+
+	id, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Warn("failed to get subscription id", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	var req request.UpdateReportSubscriptionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Warn("failed to bind update report subscription request", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req.ID = id
+
+	resp, err := h.subscriptionService.UpdateSubscription(ctx, &req)
+	if err != nil {
+		h.writeError(ctx, logger, err, "failed to update report subscription")
+		return
+	}
+
+	logger.Info("update report subscription successfully", applog.Uint("subscription_id", id))
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// 删除报表订阅及其执行记录 DELETE /api/v1/admin/reports/subscriptions/:id
+func (h *ReportSubscriptionHandler) DeleteSubscription(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "DeleteSubscription"))
+
+	id, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Warn("failed to get subscription id", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.subscriptionService.DeleteSubscription(ctx, id); err != nil {
+		h.writeError(ctx, logger, err, "failed to delete report subscription")
+		return
+	}
+
+	logger.Info("delete report subscription successfully", applog.Uint("subscription_id", id))
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+// 立即执行一次 POST /api/v1/admin/reports/subscriptions/:id/run
+func (h *ReportSubscriptionHandler) RunSubscription(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "RunSubscription"))
+
+	id, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Warn("failed to get subscription id", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	resp, err := h.subscriptionService.RunSubscription(ctx, id)
+	if err != nil {
+		h.writeError(ctx, logger, err, "failed to run report subscription")
+		return
+	}
+
+	logger.Info("run report subscription", applog.Uint("subscription_id", id), applog.String("status", resp.Status))
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// 查询执行记录 GET /api/v1/admin/reports/subscriptions/:id/runs
+func (h *ReportSubscriptionHandler) ListRuns(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "ListRuns"))
+
+	id, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Warn("failed to get subscription id", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	var req request.ListReportRunsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		logger.Warn("failed to bind list report runs request", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req.SubscriptionID = id
+
+	resp, err := h.subscriptionService.ListRuns(ctx, &req)
+	if err != nil {
+		h.writeError(ctx, logger, err, "failed to list report runs")
+		return
+	}
+
+	logger.Info("list report runs successfully", applog.Int("total", resp.Pagination.TotalCount))
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// 将报表订阅相关错误映射为HTTP状态码
+func (h *ReportSubscriptionHandler) writeError(ctx *gin.Context, logger applog.Logger, err error, msg string) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, report.ErrSubscriptionNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, report.ErrInvalidSubscription), errors.Is(err, report.ErrInvalidCron),
+		errors.Is(err, report.ErrDelivererUnavailable):
+		status = http.StatusBadRequest
+	}
+
+	if status == http.StatusInternalServerError {
+		logger.Error(msg, applog.Error(err))
+	} else {
+		logger.Warn(msg, applog.Error(err))
+	}
+	i18n.WriteError(ctx, status, err)
+}
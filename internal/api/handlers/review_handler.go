@@ -0,0 +1,284 @@
+package handlers
+
+import (
+	"errors"
+	"mrs/internal/api/dto/request"
+	"mrs/internal/api/i18n"
+	"mrs/internal/api/middleware"
+	"mrs/internal/app"
+	"mrs/internal/domain/movie"
+	"mrs/internal/domain/review"
+	"mrs/internal/domain/shared"
+	"mrs/internal/domain/shared/lock"
+	applog "mrs/pkg/log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReviewHandler struct {
+	reviewService app.ReviewService
+	logger        applog.Logger
+}
+
+func NewReviewHandler(reviewService app.ReviewService, logger applog.Logger) *ReviewHandler {
+	return &ReviewHandler{
+		reviewService: reviewService,
+		logger:        logger.With(applog.String("Handler", "ReviewHandler")),
+	}
+}
+
+// 发表评价 POST /api/v1/movies/:id/reviews
+func (h *ReviewHandler) CreateReview(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "CreateReview"))
+
+	movieID, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Warn("failed to get movie id", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	var req request.CreateReviewRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Warn("failed to bind create review request", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req.MovieID = movieID
+	req.UserID = ctx.GetUint(middleware.UserIDKey)
+
+	reviewResp, err := h.reviewService.CreateReview(ctx, &req)
+	if err != nil {
+		h.writeError(ctx, logger, err, "failed to create review")
+		return
+	}
+
+	logger.Info("create review successfully", applog.Uint("review_id", reviewResp.ID))
+	ctx.JSON(http.StatusCreated, reviewResp)
+}
+
+// 查询电影的已发布评价 GET /api/v1/movies/:id/reviews
+func (h *ReviewHandler) ListMovieReviews(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "ListMovieReviews"))
+
+	movieID, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Warn("failed to get movie id", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	var req request.ListMovieReviewsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		logger.Warn("failed to bind list movie reviews request", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req.MovieID = movieID
+
+	reviewsResp, err := h.reviewService.ListMovieReviews(ctx, &req)
+	if err != nil {
+		h.writeError(ctx, logger, err, "failed to list movie reviews")
+		return
+	}
+
+	logger.Info("list movie reviews successfully", applog.Int("total", reviewsResp.Pagination.TotalCount))
+	ctx.JSON(http.StatusOK, reviewsResp)
+}
+
+// 修改自己的评价 PUT /api/v1/reviews/:id
+func (h *ReviewHandler) UpdateReview(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "UpdateReview"))
+
+	reviewID, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Warn("failed to get review id", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	var req request.UpdateReviewRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Warn("failed to bind update review request", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req.ID = reviewID
+	req.UserID = ctx.GetUint(middleware.UserIDKey)
+
+	reviewResp, err := h.reviewService.UpdateReview(ctx, &req)
+	if err != nil {
+		h.writeError(ctx, logger, err, "failed to update review")
+		return
+	}
+
+	logger.Info("update review successfully", applog.Uint("review_id", reviewResp.ID))
+	ctx.JSON(http.StatusOK, reviewResp)
+}
+
+// 删除自己的评价 DELETE /api/v1/reviews/:id
+func (h *ReviewHandler) DeleteReview(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "DeleteReview"))
+
+	reviewID, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Warn("failed to get review id", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	req := request.DeleteReviewRequest{ID: reviewID, UserID: ctx.GetUint(middleware.UserIDKey)}
+	if err := h.reviewService.DeleteReview(ctx, &req); err != nil {
+		h.writeError(ctx, logger, err, "failed to delete review")
+		return
+	}
+
+	logger.Info("delete review successfully", applog.Uint("review_id", reviewID))
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+// 投"有用"票 POST /api/v1/reviews/:id/helpful
+func (h *ReviewHandler) VoteHelpful(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "VoteHelpful"))
+
+	reviewID, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Warn("failed to get review id", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	req := request.VoteReviewRequest{ID: reviewID, UserID: ctx.GetUint(middleware.UserIDKey)}
+	reviewResp, err := h.reviewService.VoteHelpful(ctx, &req)
+	if err != nil {
+		h.writeError(ctx, logger, err, "failed to vote review")
+		return
+	}
+
+	logger.Info("vote review successfully", applog.Uint("review_id", reviewID))
+	ctx.JSON(http.StatusOK, reviewResp)
+}
+
+// 撤销"有用"投票 DELETE /api/v1/reviews/:id/helpful
+func (h *ReviewHandler) UnvoteHelpful(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "UnvoteHelpful"))
+
+	reviewID, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Warn("failed to get review id", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	req := request.VoteReviewRequest{ID: reviewID, UserID: ctx.GetUint(middleware.UserIDKey)}
+	reviewResp, err := h.reviewService.UnvoteHelpful(ctx, &req)
+	if err != nil {
+		h.writeError(ctx, logger, err, "failed to unvote review")
+		return
+	}
+
+	logger.Info("unvote review successfully", applog.Uint("review_id", reviewID))
+	ctx.JSON(http.StatusOK, reviewResp)
+}
+
+// 管理员查询评价 GET /api/v1/admin/reviews
+func (h *ReviewHandler) ListReviews(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "ListReviews"))
+
+	var req request.ListReviewsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		logger.Warn("failed to bind list reviews request", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	reviewsResp, err := h.reviewService.ListReviews(ctx, &req)
+	if err != nil {
+		h.writeError(ctx, logger, err, "failed to list reviews")
+		return
+	}
+
+	logger.Info("list reviews successfully", applog.Int("total", reviewsResp.Pagination.TotalCount))
+	ctx.JSON(http.StatusOK, reviewsResp)
+}
+
+// 审核通过 POST /api/v1/admin/reviews/:id/approve
+func (h *ReviewHandler) ApproveReview(ctx *gin.Context) {
+	h.moderateReview(ctx, request.ReviewActionApprove)
+}
+
+// 审核驳回 POST /api/v1/admin/reviews/:id/reject
+func (h *ReviewHandler) RejectReview(ctx *gin.Context) {
+	h.moderateReview(ctx, request.ReviewActionReject)
+}
+
+// 隐藏评价 POST /api/v1/admin/reviews/:id/hide
+func (h *ReviewHandler) HideReview(ctx *gin.Context) {
+	h.moderateReview(ctx, request.ReviewActionHide)
+}
+
+// 恢复评价 POST /api/v1/admin/reviews/:id/restore
+func (h *ReviewHandler) RestoreReview(ctx *gin.Context) {
+	h.moderateReview(ctx, request.ReviewActionRestore)
+}
+
+func (h *ReviewHandler) moderateReview(ctx *gin.Context, action string) {
+	logger := h.logger.With(applog.String("Method", "ModerateReview"), applog.String("action", action))
+
+	reviewID, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Warn("failed to get review id", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	// 审核备注可选，允许空请求体
+	var req request.ModerateReviewRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			logger.Warn("failed to bind moderate review request", applog.Error(err))
+			i18n.WriteError(ctx, http.StatusBadRequest, err)
+			return
+		}
+	}
+	req.ID = reviewID
+	req.Action = action
+
+	reviewResp, err := h.reviewService.ModerateReview(ctx, &req)
+	if err != nil {
+		h.writeError(ctx, logger, err, "failed to moderate review")
+		return
+	}
+
+	logger.Info("moderate review successfully", applog.Uint("review_id", reviewID), applog.String("status", reviewResp.Status))
+	ctx.JSON(http.StatusOK, reviewResp)
+}
+
+// 将评价相关错误映射为HTTP状态码
+func (h *ReviewHandler) writeError(ctx *gin.Context, logger applog.Logger, err error, msg string) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, review.ErrReviewNotFound), errors.Is(err, movie.ErrMovieNotFound),
+		errors.Is(err, review.ErrReviewVoteNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, review.ErrInvalidReview):
+		status = http.StatusBadRequest
+	case errors.Is(err, review.ErrNotVerifiedViewer), errors.Is(err, review.ErrReviewNotOwned),
+		errors.Is(err, review.ErrVoteOwnReview):
+		status = http.StatusForbidden
+	case errors.Is(err, review.ErrReviewAlreadyExists), errors.Is(err, review.ErrReviewAlreadyVoted),
+		errors.Is(err, review.ErrInvalidReviewTransition), errors.Is(err, review.ErrReviewNotPublished),
+		errors.Is(err, lock.ErrLockAlreadyAcquired):
+		status = http.StatusConflict
+	case errors.Is(err, shared.ErrCircuitReadOperationBusy), errors.Is(err, shared.ErrCircuitWriteOperationBusy):
+		status = http.StatusServiceUnavailable
+	}
+
+	if status == http.StatusInternalServerError {
+		logger.Error(msg, applog.Error(err))
+	} else {
+		logger.Warn(msg, applog.Error(err))
+	}
+	i18n.WriteError(ctx, status, err)
+}
@@ -3,8 +3,13 @@ package handlers
 import (
 	"errors"
 	"mrs/internal/api/dto/request"
+	"mrs/internal/api/i18n"
+	"mrs/internal/api/middleware"
 	"mrs/internal/app"
+	"mrs/internal/domain/cinema"
+	"mrs/internal/domain/movie"
 	"mrs/internal/domain/shared"
+	"mrs/internal/domain/shared/lock"
 	"mrs/internal/domain/showtime"
 	applog "mrs/pkg/log"
 	"net/http"
@@ -27,7 +32,7 @@ func (h *ShowtimeHandler) CreateShowtime(ctx *gin.Context) {
 	var req request.CreateShowtimeRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		logger.Error("failed to bind request", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -36,11 +41,26 @@ func (h *ShowtimeHandler) CreateShowtime(ctx *gin.Context) {
 		// 熔断器打开
 		if errors.Is(err, shared.ErrCircuitWriteOperationBusy) {
 			logger.Warn("circuit breaker is open", applog.Error(err))
-			ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+			i18n.WriteError(ctx, http.StatusServiceUnavailable, err)
+			return
+		}
+		if errors.Is(err, showtime.ErrShowtimeOutsideHours) {
+			logger.Warn("showtime outside cinema opening hours")
+			i18n.WriteError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		if errors.Is(err, movie.ErrMovieNotFound) {
+			logger.Warn("movie not found")
+			i18n.WriteError(ctx, http.StatusNotFound, err)
+			return
+		}
+		if errors.Is(err, movie.ErrMovieArchived) {
+			logger.Warn("movie is archived")
+			i18n.WriteError(ctx, http.StatusConflict, err)
 			return
 		}
 		logger.Error("failed to create showtime", applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 	logger.Info("showtime created successfully", applog.Uint("showtime_id", uint(showtimeResp.ID)))
@@ -53,7 +73,7 @@ func (h *ShowtimeHandler) GetShowtime(ctx *gin.Context) {
 	id, err := getIDFromPath(ctx)
 	if err != nil {
 		logger.Error("failed to get id from path", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 	req := request.GetShowtimeRequest{ID: id}
@@ -63,16 +83,16 @@ func (h *ShowtimeHandler) GetShowtime(ctx *gin.Context) {
 		// 熔断器打开
 		if errors.Is(err, shared.ErrCircuitReadOperationBusy) {
 			logger.Warn("circuit breaker is open", applog.Error(err))
-			ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+			i18n.WriteError(ctx, http.StatusServiceUnavailable, err)
 			return
 		}
 		if errors.Is(err, showtime.ErrShowtimeNotFound) {
 			logger.Warn("showtime not found")
-			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			i18n.WriteError(ctx, http.StatusNotFound, err)
 			return
 		}
 		logger.Error("failed to get showtime", applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 	logger.Info("showtime retrieved successfully", applog.Uint("showtime_id", uint(showtimeResp.ID)))
@@ -85,7 +105,7 @@ func (h *ShowtimeHandler) ListShowtimes(ctx *gin.Context) {
 	var req request.ListShowtimesRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
 		logger.Error("failed to bind request", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -94,11 +114,37 @@ func (h *ShowtimeHandler) ListShowtimes(ctx *gin.Context) {
 		// 熔断器打开
 		if errors.Is(err, shared.ErrCircuitReadOperationBusy) {
 			logger.Warn("circuit breaker is open", applog.Error(err))
-			ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+			i18n.WriteError(ctx, http.StatusServiceUnavailable, err)
 			return
 		}
 		logger.Error("failed to list showtimes", applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	logger.Info("list showtimes successfully")
+	ctx.JSON(http.StatusOK, showtimeResp)
+}
+
+// 管理员列出放映场次（分页），可按状态过滤 GET /api/v1/admin/showtimes
+func (h *ShowtimeHandler) ListAdminShowtimes(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "ListAdminShowtimes"))
+	var req request.ListAdminShowtimesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		logger.Error("failed to bind request", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	showtimeResp, err := h.showtimeService.ListAdminShowtimes(ctx, &req)
+	if err != nil {
+		// 熔断器打开
+		if errors.Is(err, shared.ErrCircuitReadOperationBusy) {
+			logger.Warn("circuit breaker is open", applog.Error(err))
+			i18n.WriteError(ctx, http.StatusServiceUnavailable, err)
+			return
+		}
+		logger.Error("failed to list showtimes", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 	logger.Info("list showtimes successfully")
@@ -111,13 +157,13 @@ func (h *ShowtimeHandler) UpdateShowtime(ctx *gin.Context) {
 	var req request.UpdateShowtimeRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		logger.Error("failed to bind request", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 	id, err := getIDFromPath(ctx)
 	if err != nil {
 		logger.Error("failed to get id from path", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 	req.ID = id
@@ -127,16 +173,36 @@ func (h *ShowtimeHandler) UpdateShowtime(ctx *gin.Context) {
 		// 熔断器打开
 		if errors.Is(err, shared.ErrCircuitWriteOperationBusy) {
 			logger.Warn("circuit breaker is open", applog.Error(err))
-			ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+			i18n.WriteError(ctx, http.StatusServiceUnavailable, err)
 			return
 		}
 		if errors.Is(err, showtime.ErrShowtimeNotFound) {
 			logger.Warn("showtime not found")
-			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			i18n.WriteError(ctx, http.StatusNotFound, err)
+			return
+		}
+		if errors.Is(err, showtime.ErrShowtimeOutsideHours) {
+			logger.Warn("showtime outside cinema opening hours")
+			i18n.WriteError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		if errors.Is(err, movie.ErrMovieNotFound) {
+			logger.Warn("movie not found")
+			i18n.WriteError(ctx, http.StatusNotFound, err)
+			return
+		}
+		if errors.Is(err, movie.ErrMovieArchived) {
+			logger.Warn("movie is archived")
+			i18n.WriteError(ctx, http.StatusConflict, err)
+			return
+		}
+		if errors.Is(err, showtime.ErrShowtimeHallChangeHasBookings) {
+			logger.Warn("showtime has live bookings, cannot change hall")
+			i18n.WriteError(ctx, http.StatusConflict, err)
 			return
 		}
 		logger.Error("failed to update showtime", applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 	logger.Info("showtime updated successfully", applog.Uint("showtime_id", uint(showtimeResp.ID)))
@@ -149,7 +215,7 @@ func (h *ShowtimeHandler) DeleteShowtime(ctx *gin.Context) {
 	id, err := getIDFromPath(ctx)
 	if err != nil {
 		logger.Error("failed to get id from path", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 	req := request.DeleteShowtimeRequest{ID: id}
@@ -158,50 +224,140 @@ func (h *ShowtimeHandler) DeleteShowtime(ctx *gin.Context) {
 		// 熔断器打开
 		if errors.Is(err, shared.ErrCircuitWriteOperationBusy) {
 			logger.Warn("circuit breaker is open", applog.Error(err))
-			ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+			i18n.WriteError(ctx, http.StatusServiceUnavailable, err)
 			return
 		}
 		if errors.Is(err, showtime.ErrShowtimeNotFound) {
 			logger.Warn("showtime not found")
-			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			i18n.WriteError(ctx, http.StatusNotFound, err)
+			return
+		}
+		// 存在有效订单，需先取消场次
+		if errors.Is(err, showtime.ErrShowtimeHasLiveBookings) {
+			logger.Warn("showtime has live bookings")
+			i18n.WriteError(ctx, http.StatusConflict, err)
 			return
 		}
 		logger.Error("failed to delete showtime", applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 	logger.Info("showtime deleted successfully", applog.Uint("showtime_id", uint(req.ID)))
 	ctx.JSON(http.StatusNoContent, nil)
 }
 
+// 取消放映场次 POST /api/v1/admin/showtimes/:id/cancel
+func (h *ShowtimeHandler) CancelShowtime(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "CancelShowtime"))
+	var req request.CancelShowtimeRequest
+	// 请求体可选（取消原因、批次大小）
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			logger.Error("failed to bind request", applog.Error(err))
+			i18n.WriteError(ctx, http.StatusBadRequest, err)
+			return
+		}
+	}
+	id, err := getIDFromPath(ctx)
+	if err != nil {
+		logger.Error("failed to get id from path", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req.ID = id
+
+	cancelResp, err := h.showtimeService.CancelShowtime(ctx, &req)
+	if err != nil {
+		// 熔断器打开
+		if errors.Is(err, shared.ErrCircuitWriteOperationBusy) {
+			logger.Warn("circuit breaker is open", applog.Error(err))
+			i18n.WriteError(ctx, http.StatusServiceUnavailable, err)
+			return
+		}
+		if errors.Is(err, showtime.ErrShowtimeNotFound) {
+			logger.Warn("showtime not found")
+			i18n.WriteError(ctx, http.StatusNotFound, err)
+			return
+		}
+		if errors.Is(err, showtime.ErrShowtimeEnded) {
+			logger.Warn("showtime has already ended")
+			i18n.WriteError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		// 场次正在被其他请求处理（预订或取消）
+		if errors.Is(err, lock.ErrLockAlreadyAcquired) {
+			logger.Warn("showtime is locked by another process")
+			i18n.WriteError(ctx, http.StatusConflict, err)
+			return
+		}
+		logger.Error("failed to cancel showtime", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	logger.Info("showtime cancelled successfully", applog.Uint("showtime_id", id),
+		applog.Int("total_bookings", cancelResp.TotalBookings))
+	ctx.JSON(http.StatusOK, cancelResp)
+}
+
 // 获取放映场次座位表 GET /api/v1/showtimes/:id/seatmap
 func (h *ShowtimeHandler) GetSeatMap(ctx *gin.Context) {
 	logger := h.logger.With(applog.String("Method", "GetSeatMap"))
 	id, err := getIDFromPath(ctx)
 	if err != nil {
 		logger.Error("failed to get id from path", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
-	req := request.GetSeatMapRequest{ShowtimeID: id}
+	req := request.GetSeatMapRequest{ShowtimeID: id, UserID: ctx.GetUint(middleware.UserIDKey)}
 
 	seatMapResp, err := h.showtimeService.GetSeatMap(ctx, &req)
 	if err != nil {
 		// 熔断器打开
 		if errors.Is(err, shared.ErrCircuitReadOperationBusy) {
 			logger.Warn("circuit breaker is open", applog.Error(err))
-			ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+			i18n.WriteError(ctx, http.StatusServiceUnavailable, err)
 			return
 		}
 		if errors.Is(err, showtime.ErrShowtimeEnded) {
 			logger.Warn("showtime has already ended")
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			i18n.WriteError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		if errors.Is(err, showtime.ErrShowtimeCancelled) {
+			logger.Warn("showtime has been cancelled")
+			i18n.WriteError(ctx, http.StatusBadRequest, err)
 			return
 		}
 		logger.Error("failed to get seat map", applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 	logger.Info("seat map retrieved successfully", applog.Uint("showtime_id", id))
 	ctx.JSON(http.StatusOK, seatMapResp)
 }
+
+// 迁移场次布局版本 POST /api/v1/admin/showtimes/layout-migrations
+func (h *ShowtimeHandler) MigrateShowtimeLayouts(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "MigrateShowtimeLayouts"))
+	var req request.MigrateShowtimeLayoutsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Error("failed to bind request", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	migrationResp, err := h.showtimeService.MigrateShowtimeLayouts(ctx, &req)
+	if err != nil {
+		if errors.Is(err, cinema.ErrCinemaHallNotFound) || errors.Is(err, cinema.ErrLayoutVersionNotFound) {
+			logger.Warn("cinema hall or layout version not found")
+			i18n.WriteError(ctx, http.StatusNotFound, err)
+			return
+		}
+		logger.Error("failed to migrate showtime layouts", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	logger.Info("showtime layouts migrated successfully",
+		applog.Int("migrated", migrationResp.Migrated), applog.Int("conflicted", migrationResp.Conflicted))
+	ctx.JSON(http.StatusOK, migrationResp)
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"errors"
+	"mrs/internal/api/dto/request"
+	"mrs/internal/api/export"
+	"mrs/internal/api/i18n"
+	"mrs/internal/app"
+	"mrs/internal/domain/shared"
+	applog "mrs/pkg/log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TrendingHandler struct {
+	trendingService app.TrendingService
+	logger          applog.Logger
+}
+
+func NewTrendingHandler(trendingService app.TrendingService, logger applog.Logger) *TrendingHandler {
+	return &TrendingHandler{
+		trendingService: trendingService,
+		logger:          logger.With(applog.String("Handler", "TrendingHandler")),
+	}
+}
+
+// 热门电影 GET /api/v1/movies/trending?window=1h|today|7d
+func (h *TrendingHandler) GetTrendingMovies(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "GetTrendingMovies"))
+
+	var req request.GetTrendingMoviesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		logger.Warn("failed to bind get trending movies request", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req.Locales = i18n.Locales(ctx)
+
+	trendingResp, err := h.trendingService.GetTrendingMovies(ctx, &req)
+	if err != nil {
+		h.writeError(ctx, logger, err, "failed to get trending movies")
+		return
+	}
+
+	logger.Info("get trending movies successfully", applog.String("window", trendingResp.Window),
+		applog.Int("count", len(trendingResp.Movies)))
+	ctx.JSON(http.StatusOK, trendingResp)
+}
+
+// 售票排行榜 GET /api/v1/admin/reports/leaderboard?window=1h|today|7d&format=json|csv|xlsx
+func (h *TrendingHandler) GetLeaderboard(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "GetLeaderboard"))
+
+	var req request.GetLeaderboardRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		logger.Warn("failed to bind get leaderboard request", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req.Locales = i18n.Locales(ctx)
+
+	leaderboardResp, err := h.trendingService.GetLeaderboard(ctx, &req)
+	if err != nil {
+		h.writeError(ctx, logger, err, "failed to get leaderboard")
+		return
+	}
+
+	if isFileExport(req.Format) {
+		if err := exportReportFile(ctx, logger, req.Format, "leaderboard", func(t *export.Tables) error {
+			return export.WriteLeaderboard(t, leaderboardResp)
+		}); err != nil {
+			h.writeError(ctx, logger, err, "failed to create leaderboard writer")
+		}
+		return
+	}
+
+	logger.Info("get leaderboard successfully", applog.String("window", leaderboardResp.Window))
+	ctx.JSON(http.StatusOK, leaderboardResp)
+}
+
+func (h *TrendingHandler) writeError(ctx *gin.Context, logger applog.Logger, err error, msg string) {
+	// 熔断器打开
+	if errors.Is(err, shared.ErrCircuitReadOperationBusy) {
+		logger.Warn(msg, applog.Error(err))
+		i18n.WriteError(ctx, http.StatusServiceUnavailable, err)
+		return
+	}
+	logger.Error(msg, applog.Error(err))
+	i18n.WriteError(ctx, http.StatusInternalServerError, err)
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"mrs/internal/api/dto/request"
 	"mrs/internal/api/dto/response"
+	"mrs/internal/api/i18n"
 	"mrs/internal/api/middleware"
 	"mrs/internal/app"
 	"mrs/internal/domain/user"
@@ -31,7 +32,7 @@ func (h *UserHandler) Register(ctx *gin.Context) {
 	var req request.RegisterUserRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		logger.Warn("failed to bind register request", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -41,11 +42,16 @@ func (h *UserHandler) Register(ctx *gin.Context) {
 		// 用户可能已存在
 		if errors.Is(err, user.ErrUserAlreadyExists) {
 			logger.Warn("User registration conflict", applog.Error(err))
-			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			i18n.WriteError(ctx, http.StatusConflict, err)
+			return
+		}
+		if errors.Is(err, user.ErrInvalidBirthDate) {
+			logger.Warn("invalid birth date", applog.Error(err))
+			i18n.WriteError(ctx, http.StatusBadRequest, err)
 			return
 		}
 		logger.Error("user registration service failed", applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -62,11 +68,11 @@ func (h *UserHandler) GetUserProfile(ctx *gin.Context) {
 	if err != nil {
 		if errors.Is(err, user.ErrUserNotFound) {
 			logger.Warn("user profile not found", applog.Uint("user_id", id))
-			ctx.JSON(http.StatusNotFound, gin.H{"error": "User profile not found"})
+			i18n.WriteError(ctx, http.StatusNotFound, err)
 			return
 		}
 		logger.Error("Failed to get user profile", applog.Uint("user_id", id), applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error: User ID format issue"})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -80,7 +86,7 @@ func (h *UserHandler) GetUser(ctx *gin.Context) {
 	id, err := getIDFromPath(ctx)
 	if err != nil {
 		logger.Error("failed to parse user_id", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -88,17 +94,18 @@ func (h *UserHandler) GetUser(ctx *gin.Context) {
 	if err != nil {
 		if errors.Is(err, user.ErrUserNotFound) {
 			logger.Warn("user not found", applog.Uint("user_id", uint(id)))
-			ctx.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			i18n.WriteError(ctx, http.StatusNotFound, err)
 			return
 		}
 		logger.Error("failed to get user", applog.Uint("user_id", uint(id)), applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 	}
 
 	userProfileResp := response.UserProfileResponse{
-		Username: userResp.Username,
-		Email:    userResp.Email,
-		RoleName: userResp.RoleName,
+		Username:  userResp.Username,
+		Email:     userResp.Email,
+		RoleName:  userResp.RoleName,
+		BirthDate: userResp.BirthDate,
 	}
 
 	logger.Info("user retrieved successfully", applog.Uint("user_id", uint(id)))
@@ -111,33 +118,35 @@ func (h *UserHandler) UpdateUserProfile(ctx *gin.Context) {
 	var req request.UpdateUserRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		logger.Warn("failed to bind update request", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
-	id, err := getIDFromPath(ctx)
-	if err != nil {
-		logger.Error("failed to parse user_id", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
-		return
-	}
-	req.ID = uint(id)
+	// /users/me 路由没有路径参数，使用认证中间件写入的用户ID
+	id := ctx.GetUint(middleware.UserIDKey)
+	req.ID = id
 
 	userResp, err := h.userService.UpdateUser(ctx, &req)
 	if err != nil {
+		if errors.Is(err, user.ErrInvalidBirthDate) {
+			logger.Warn("invalid birth date", applog.Error(err))
+			i18n.WriteError(ctx, http.StatusBadRequest, err)
+			return
+		}
 		if errors.Is(err, user.ErrUserNotFound) {
 			logger.Warn("user not found", applog.Uint("user_id", id))
-			ctx.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			i18n.WriteError(ctx, http.StatusNotFound, err)
 			return
 		}
 		logger.Error("failed to update user", applog.Uint("user_id", id), applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 	userProfileResp := response.UserProfileResponse{
-		Username: userResp.Username,
-		Email:    userResp.Email,
-		RoleName: userResp.RoleName,
+		Username:  userResp.Username,
+		Email:     userResp.Email,
+		RoleName:  userResp.RoleName,
+		BirthDate: userResp.BirthDate,
 	}
 	logger.Info("user profile updated successfully", applog.Uint("user_id", id))
 	ctx.JSON(http.StatusOK, userProfileResp)
@@ -149,22 +158,27 @@ func (h *UserHandler) UpdateUser(ctx *gin.Context) {
 	var req request.UpdateUserRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		logger.Warn("failed to bind update request", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	id, err := getIDFromPath(ctx)
 	if err != nil {
 		logger.Error("failed to parse user_id", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 	req.ID = uint(id)
 
 	userResp, err := h.userService.UpdateUser(ctx, &req)
 	if err != nil {
+		if errors.Is(err, user.ErrInvalidBirthDate) {
+			logger.Warn("invalid birth date", applog.Error(err))
+			i18n.WriteError(ctx, http.StatusBadRequest, err)
+			return
+		}
 		logger.Error("failed to update user", applog.Uint("user_id", uint(id)), applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -178,7 +192,7 @@ func (h *UserHandler) DeleteUser(ctx *gin.Context) {
 	id, err := getIDFromPath(ctx)
 	if err != nil {
 		logger.Error("failed to parse user_id", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -186,11 +200,11 @@ func (h *UserHandler) DeleteUser(ctx *gin.Context) {
 	if err != nil {
 		if errors.Is(err, user.ErrUserNotFound) {
 			logger.Warn("user not found", applog.Uint("user_id", uint(id)))
-			ctx.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			i18n.WriteError(ctx, http.StatusNotFound, err)
 			return
 		}
 		logger.Error("failed to delete user", applog.Uint("user_id", uint(id)), applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -204,14 +218,14 @@ func (h *UserHandler) ListUsers(ctx *gin.Context) {
 	var req request.ListUserRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
 		logger.Warn("failed to bind list users request", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	userResp, err := h.userService.ListUsers(ctx, &req)
 	if err != nil {
 		logger.Error("failed to list users", applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -225,7 +239,7 @@ func (h *UserHandler) CreateRole(ctx *gin.Context) {
 	var req request.CreateRoleRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		logger.Warn("failed to bind create role request", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -233,11 +247,11 @@ func (h *UserHandler) CreateRole(ctx *gin.Context) {
 	if err != nil {
 		if errors.Is(err, user.ErrRoleAlreadyExists) {
 			logger.Warn("role already exists", applog.String("role_name", req.Name))
-			ctx.JSON(http.StatusConflict, gin.H{"error": "Role already exists"})
+			i18n.WriteError(ctx, http.StatusConflict, err)
 			return
 		}
 		logger.Error("failed to create role", applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -251,7 +265,7 @@ func (h *UserHandler) ListRoles(ctx *gin.Context) {
 	roles, err := h.userService.ListRoles(ctx)
 	if err != nil {
 		logger.Error("failed to list roles", applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -266,14 +280,14 @@ func (h *UserHandler) UpdateRole(ctx *gin.Context) {
 	id, err := getIDFromPath(ctx)
 	if err != nil {
 		logger.Error("failed to parse role_id", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role ID"})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	var req request.UpdateRoleRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		logger.Warn("failed to bind update role request", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 	req.ID = id
@@ -281,7 +295,7 @@ func (h *UserHandler) UpdateRole(ctx *gin.Context) {
 	roleResp, err := h.userService.UpdateRole(ctx, &req)
 	if err != nil {
 		logger.Error("failed to update role", applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -295,7 +309,7 @@ func (h *UserHandler) DeleteRole(ctx *gin.Context) {
 	id, err := getIDFromPath(ctx)
 	if err != nil {
 		logger.Error("failed to parse role_id", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role ID"})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -303,11 +317,11 @@ func (h *UserHandler) DeleteRole(ctx *gin.Context) {
 	if err != nil {
 		if errors.Is(err, user.ErrRoleReferenced) {
 			logger.Warn("role is referenced by users")
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Role is referenced by users"})
+			i18n.WriteError(ctx, http.StatusBadRequest, err)
 			return
 		}
 		logger.Error("failed to delete role", applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -321,14 +335,14 @@ func (h *UserHandler) AssignRoleToUser(ctx *gin.Context) {
 	var req request.AssignRoleToUserRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		logger.Warn("failed to bind assign role to user request", applog.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	err := h.userService.AssignRoleToUser(ctx, &req)
 	if err != nil {
 		logger.Error("failed to assign role to user", applog.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		i18n.WriteError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
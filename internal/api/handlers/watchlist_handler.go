@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"errors"
+	"mrs/internal/api/dto/request"
+	"mrs/internal/api/i18n"
+	"mrs/internal/api/middleware"
+	"mrs/internal/app"
+	"mrs/internal/domain/cinema"
+	"mrs/internal/domain/movie"
+	"mrs/internal/domain/shared"
+	"mrs/internal/domain/watchlist"
+	applog "mrs/pkg/log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WatchlistHandler struct {
+	watchlistService app.WatchlistService
+	logger           applog.Logger
+}
+
+func NewWatchlistHandler(watchlistService app.WatchlistService, logger applog.Logger) *WatchlistHandler {
+	return &WatchlistHandler{
+		watchlistService: watchlistService,
+		logger:           logger.With(applog.String("Handler", "WatchlistHandler")),
+	}
+}
+
+// 关注电影 POST /api/v1/users/me/watchlist
+func (h *WatchlistHandler) AddToWatchlist(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "AddToWatchlist"))
+
+	var req request.AddWatchlistRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Warn("failed to bind add watchlist request", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req.UserID = ctx.GetUint(middleware.UserIDKey)
+
+	entryResp, err := h.watchlistService.AddToWatchlist(ctx, &req)
+	if err != nil {
+		h.writeError(ctx, logger, err, "failed to add to watchlist")
+		return
+	}
+
+	logger.Info("add to watchlist successfully", applog.Uint("watchlist_entry_id", entryResp.ID))
+	ctx.JSON(http.StatusCreated, entryResp)
+}
+
+// 取消关注 DELETE /api/v1/users/me/watchlist/:movie_id
+func (h *WatchlistHandler) RemoveFromWatchlist(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "RemoveFromWatchlist"))
+
+	movieID, err := getUintParam(ctx, "movie_id")
+	if err != nil {
+		logger.Warn("failed to get movie id", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	req := request.RemoveWatchlistRequest{UserID: ctx.GetUint(middleware.UserIDKey), MovieID: movieID}
+	if err := h.watchlistService.RemoveFromWatchlist(ctx, &req); err != nil {
+		h.writeError(ctx, logger, err, "failed to remove from watchlist")
+		return
+	}
+
+	logger.Info("remove from watchlist successfully", applog.Uint("movie_id", movieID))
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+// 查询自己的关注列表 GET /api/v1/users/me/watchlist
+func (h *WatchlistHandler) ListWatchlist(ctx *gin.Context) {
+	logger := h.logger.With(applog.String("Method", "ListWatchlist"))
+
+	var req request.ListWatchlistRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		logger.Warn("failed to bind list watchlist request", applog.Error(err))
+		i18n.WriteError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req.UserID = ctx.GetUint(middleware.UserIDKey)
+
+	watchlistResp, err := h.watchlistService.ListWatchlist(ctx, &req)
+	if err != nil {
+		h.writeError(ctx, logger, err, "failed to list watchlist")
+		return
+	}
+
+	logger.Info("list watchlist successfully", applog.Int("total", watchlistResp.Pagination.TotalCount))
+	ctx.JSON(http.StatusOK, watchlistResp)
+}
+
+// 将关注列表相关错误映射为HTTP状态码
+func (h *WatchlistHandler) writeError(ctx *gin.Context, logger applog.Logger, err error, msg string) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, watchlist.ErrWatchlistEntryNotFound), errors.Is(err, movie.ErrMovieNotFound),
+		errors.Is(err, cinema.ErrCinemaNotFound), errors.Is(err, cinema.ErrCinemaHallNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, watchlist.ErrInvalidWatchlistPreference):
+		status = http.StatusBadRequest
+	case errors.Is(err, watchlist.ErrWatchlistEntryAlreadyExists), errors.Is(err, movie.ErrMovieArchived):
+		status = http.StatusConflict
+	case errors.Is(err, shared.ErrCircuitReadOperationBusy), errors.Is(err, shared.ErrCircuitWriteOperationBusy):
+		status = http.StatusServiceUnavailable
+	}
+
+	if status == http.StatusInternalServerError {
+		logger.Error(msg, applog.Error(err))
+	} else {
+		logger.Warn(msg, applog.Error(err))
+	}
+	i18n.WriteError(ctx, status, err)
+}
@@ -0,0 +1,204 @@
+package i18n
+
+import (
+	"errors"
+	"mrs/internal/api/export"
+	"mrs/internal/domain/booking"
+	"mrs/internal/domain/cinema"
+	"mrs/internal/domain/loyalty"
+	"mrs/internal/domain/movie"
+	"mrs/internal/domain/report"
+	"mrs/internal/domain/review"
+	"mrs/internal/domain/shared"
+	"mrs/internal/domain/shared/blob"
+	"mrs/internal/domain/shared/lock"
+	"mrs/internal/domain/shared/vo"
+	"mrs/internal/domain/showtime"
+	"mrs/internal/domain/user"
+	"mrs/internal/domain/watchlist"
+	"net/http"
+)
+
+// 错误消息目录：把领域错误映射为稳定的错误码与各语言的消息。
+// 错误码一经发布不再修改，客户端应依据错误码而不是消息文本判断错误类型。
+// 新增领域错误时在此登记；未登记的错误按HTTP状态码使用通用错误码。
+
+// 目录支持的消息语言，第一个为默认语言
+var catalogLanguages = []vo.Locale{"en", "zh"}
+
+// 目录条目
+type entry struct {
+	err      error
+	code     string
+	messages map[string]string // 语言 -> 消息
+}
+
+func e(err error, code, en, zh string) entry {
+	return entry{err: err, code: code, messages: map[string]string{"en": en, "zh": zh}}
+}
+
+// 按顺序匹配（errors.Is），同时包装多个错误时先登记的优先
+var catalog = []entry{
+	// 通用
+	e(shared.ErrCircuitReadOperationBusy, "SERVICE_BUSY", "The service is busy, please try again later", "服务繁忙，请稍后重试"),
+	e(shared.ErrCircuitWriteOperationBusy, "SERVICE_BUSY", "The service is busy, please try again later", "服务繁忙，请稍后重试"),
+	e(lock.ErrLockAlreadyAcquired, "RESOURCE_LOCKED", "The resource is being modified by another request, please try again", "资源正在被其他请求修改，请重试"),
+	e(lock.ErrRetryLockFailed, "RESOURCE_LOCKED", "The resource is being modified by another request, please try again", "资源正在被其他请求修改，请重试"),
+	e(shared.ErrInvalidInput, "INVALID_INPUT", "Invalid input", "输入无效"),
+	e(vo.ErrInvalidLocale, "INVALID_LOCALE", "Invalid language tag", "无效的语言标签"),
+	e(blob.ErrBlobNotFound, "MEDIA_FILE_NOT_FOUND", "Media file not found", "媒体文件不存在"),
+	e(blob.ErrInvalidBlobKey, "INVALID_MEDIA_KEY", "Invalid media file path", "无效的媒体文件路径"),
+
+	// 用户与角色
+	e(user.ErrUserNotFound, "USER_NOT_FOUND", "User not found", "用户不存在"),
+	e(user.ErrUserAlreadyExists, "USER_ALREADY_EXISTS", "User already exists", "用户已存在"),
+	e(user.ErrInvalidUsername, "INVALID_USERNAME", "Invalid username format", "用户名格式无效"),
+	e(user.ErrInvalidEmail, "INVALID_EMAIL", "Invalid email format", "邮箱格式无效"),
+	e(user.ErrWeakPassword, "WEAK_PASSWORD", "Password does not meet strength requirements", "密码强度不足"),
+	e(user.ErrInvalidPassword, "INVALID_CREDENTIALS", "Invalid username or password", "用户名或密码错误"),
+	e(user.ErrInvalidBirthDate, "INVALID_BIRTH_DATE", "Invalid birth date", "出生日期无效"),
+	e(user.ErrDataConflict, "DATA_CONFLICT", "Data conflict", "数据冲突"),
+	e(user.ErrVersionConflict, "VERSION_CONFLICT", "The record was modified by someone else, please reload", "记录已被他人修改，请刷新后重试"),
+	e(user.ErrRoleNotFound, "ROLE_NOT_FOUND", "Role not found", "角色不存在"),
+	e(user.ErrRoleAlreadyExists, "ROLE_ALREADY_EXISTS", "Role already exists", "角色已存在"),
+	e(user.ErrRoleReferenced, "ROLE_IN_USE", "Role is assigned to users and cannot be deleted", "角色已分配给用户，无法删除"),
+	e(user.ErrRolePermissionDenied, "PERMISSION_DENIED", "Permission denied", "权限不足"),
+	e(user.ErrInvalidPermissionAssignment, "INVALID_PERMISSION_ASSIGNMENT", "Invalid permission assignment", "无效的权限分配"),
+	e(user.ErrInvalidRoleName, "INVALID_ROLE_NAME", "Invalid role name format", "角色名称格式无效"),
+	e(user.ErrInvalidPermissionFormat, "INVALID_PERMISSION_FORMAT", "Invalid permission format", "权限格式无效"),
+
+	// 电影、类型、影人与媒体
+	e(movie.ErrMovieNotFound, "MOVIE_NOT_FOUND", "Movie not found", "电影不存在"),
+	e(movie.ErrMovieAlreadyExists, "MOVIE_ALREADY_EXISTS", "A movie with this title already exists", "同名电影已存在"),
+	e(movie.ErrInvalidMovieDuration, "INVALID_MOVIE_DURATION", "Invalid movie duration", "电影时长无效"),
+	e(movie.ErrInvalidReleaseDate, "INVALID_RELEASE_DATE", "Invalid release date", "上映日期无效"),
+	e(movie.ErrInvalidAgeRating, "INVALID_AGE_RATING", "Invalid age rating", "年龄分级无效"),
+	e(movie.ErrMovieArchived, "MOVIE_ARCHIVED", "The movie has been archived", "电影已下映"),
+	e(movie.ErrMovieNotArchived, "MOVIE_NOT_ARCHIVED", "The movie is not archived", "电影未下映"),
+	e(movie.ErrMovieHasUpcomingShowtimes, "MOVIE_HAS_UPCOMING_SHOWTIMES", "The movie still has upcoming showtimes", "电影仍有未结束的场次"),
+	e(movie.ErrGenreNotFound, "GENRE_NOT_FOUND", "Genre not found", "类型不存在"),
+	e(movie.ErrGenreAlreadyExists, "GENRE_ALREADY_EXISTS", "Genre already exists", "类型已存在"),
+	e(movie.ErrGenreReferenced, "GENRE_IN_USE", "Genre is used by movies and cannot be deleted", "类型已被电影使用，无法删除"),
+	e(movie.ErrPersonNotFound, "PERSON_NOT_FOUND", "Person not found", "影人不存在"),
+	e(movie.ErrPersonAlreadyExists, "PERSON_ALREADY_EXISTS", "Person already exists", "影人已存在"),
+	e(movie.ErrPersonReferenced, "PERSON_IN_USE", "Person is credited in movies and cannot be deleted", "影人已参与电影，无法删除"),
+	e(movie.ErrInvalidCredit, "INVALID_CREDIT", "Invalid movie credit", "演职员信息无效"),
+	e(movie.ErrInvalidCatalogFeed, "INVALID_CATALOG_FEED", "Invalid catalog feed", "片单文件无效"),
+	e(movie.ErrInvalidCatalogEntry, "INVALID_CATALOG_ENTRY", "Invalid catalog entry", "片单记录无效"),
+	e(movie.ErrMediaAssetNotFound, "MEDIA_NOT_FOUND", "Media asset not found", "媒体资源不存在"),
+	e(movie.ErrUnsupportedMediaType, "UNSUPPORTED_MEDIA_TYPE", "Unsupported media type", "不支持的媒体类型"),
+	e(movie.ErrMediaTooLarge, "MEDIA_TOO_LARGE", "Media file is too large", "媒体文件过大"),
+	e(movie.ErrInvalidMediaImage, "INVALID_MEDIA_IMAGE", "Invalid image file", "图片文件无效"),
+	e(movie.ErrTranslationNotFound, "TRANSLATION_NOT_FOUND", "Translation not found", "翻译不存在"),
+
+	// 影院、影厅与座位
+	e(cinema.ErrCinemaNotFound, "CINEMA_NOT_FOUND", "Cinema not found", "影院不存在"),
+	e(cinema.ErrCinemaAlreadyExists, "CINEMA_ALREADY_EXISTS", "Cinema already exists", "影院已存在"),
+	e(cinema.ErrCinemaReferenced, "CINEMA_IN_USE", "Cinema still has halls and cannot be deleted", "影院下仍有影厅，无法删除"),
+	e(cinema.ErrInvalidTimezone, "INVALID_TIMEZONE", "Invalid cinema timezone", "影院时区无效"),
+	e(cinema.ErrInvalidOpeningHours, "INVALID_OPENING_HOURS", "Invalid cinema opening hours", "影院营业时间无效"),
+	e(cinema.ErrCinemaHallNotFound, "CINEMA_HALL_NOT_FOUND", "Cinema hall not found", "影厅不存在"),
+	e(cinema.ErrCinemaHallAlreadyExists, "CINEMA_HALL_ALREADY_EXISTS", "Cinema hall already exists", "影厅已存在"),
+	e(cinema.ErrCinemaHallCapacityExceeded, "CINEMA_HALL_CAPACITY_EXCEEDED", "Cinema hall capacity exceeded", "超出影厅容量"),
+	e(cinema.ErrCinemaHallReferenced, "CINEMA_HALL_IN_USE", "Cinema hall is in use and cannot be deleted", "影厅仍在使用中，无法删除"),
+	e(cinema.ErrInvalidHallLayout, "INVALID_HALL_LAYOUT", "Invalid cinema hall layout", "影厅布局无效"),
+	e(cinema.ErrInvalidLayoutFormat, "INVALID_HALL_LAYOUT_FORMAT", "Invalid cinema hall layout format", "影厅布局格式无效"),
+	e(cinema.ErrLayoutVersionNotFound, "HALL_LAYOUT_VERSION_NOT_FOUND", "Cinema hall layout version not found", "影厅布局版本不存在"),
+	e(cinema.ErrSeatNotFound, "SEAT_NOT_FOUND", "Seat not found", "座位不存在"),
+	e(cinema.ErrSeatRowNumberConflict, "SEAT_POSITION_CONFLICT", "A seat already exists at this row and number", "该排号位置已有座位"),
+	e(cinema.ErrInvalidSeatType, "INVALID_SEAT_TYPE", "Invalid seat type", "座位类型无效"),
+	e(cinema.ErrSeatNotAvailable, "SEAT_NOT_AVAILABLE", "The selected seat is not available for this showtime", "所选座位在该场次不可用"),
+	e(cinema.ErrSeatRestrictionNotFound, "SEAT_RESTRICTION_NOT_FOUND", "Seat restriction not found", "座位限制不存在"),
+	e(cinema.ErrInvalidSeatRestriction, "INVALID_SEAT_RESTRICTION", "Invalid seat restriction", "座位限制无效"),
+	e(cinema.ErrInvalidDistancingPolicy, "INVALID_DISTANCING_POLICY", "Invalid distancing policy", "社交距离策略无效"),
+
+	// 场次
+	e(showtime.ErrShowtimeNotFound, "SHOWTIME_NOT_FOUND", "Showtime not found", "场次不存在"),
+	e(showtime.ErrShowtimeOverlap, "SHOWTIME_OVERLAP", "The showtime overlaps with an existing showtime in this hall", "场次与该影厅已有场次时间冲突"),
+	e(showtime.ErrShowtimeInPast, "SHOWTIME_IN_PAST", "Showtimes cannot be scheduled in the past", "不能安排过去时间的场次"),
+	e(showtime.ErrShowtimeInvalidTimeRange, "INVALID_SHOWTIME_TIME_RANGE", "Invalid showtime start/end time", "场次开始/结束时间无效"),
+	e(showtime.ErrShowtimeNoSeatsAvailable, "SHOWTIME_SOLD_OUT", "No seats are available for this showtime", "该场次已无可用座位"),
+	e(showtime.ErrShowtimeEnded, "SHOWTIME_ENDED", "The showtime has ended", "场次已结束"),
+	e(showtime.ErrShowtimeCancelled, "SHOWTIME_CANCELLED", "The showtime has been cancelled", "场次已取消"),
+	e(showtime.ErrShowtimeHasLiveBookings, "SHOWTIME_HAS_BOOKINGS", "The showtime has active bookings and cannot be deleted", "场次仍有有效订单，无法删除"),
+	e(showtime.ErrShowtimeOutsideHours, "SHOWTIME_OUTSIDE_OPENING_HOURS", "The showtime starts outside the cinema's opening hours", "场次开始时间不在影院营业时间内"),
+	e(showtime.ErrShowtimeHallChangeHasBookings, "SHOWTIME_HALL_CHANGE_HAS_BOOKINGS", "The showtime has active bookings and cannot be moved to another hall", "场次仍有有效订单，无法更换影厅"),
+
+	// 订单
+	e(booking.ErrBookingNotFound, "BOOKING_NOT_FOUND", "Booking not found", "订单不存在"),
+	e(booking.ErrBookingAlreadyExists, "BOOKING_ALREADY_EXISTS", "Booking already exists", "订单已存在"),
+	e(booking.ErrBookedSeatAlreadyLocked, "SEAT_ALREADY_LOCKED", "The selected seat is being booked by someone else", "所选座位正被他人预订"),
+	e(booking.ErrBookedSeatNotFound, "BOOKED_SEAT_NOT_FOUND", "Booked seat not found", "已订座位不存在"),
+	e(booking.ErrBookingNotPending, "BOOKING_NOT_PENDING", "The booking is not pending", "订单不是待支付状态"),
+	e(booking.ErrAgeRestricted, "AGE_RESTRICTED", "You do not meet the age requirement for this movie", "不满足该电影的年龄要求"),
+	e(booking.ErrInvalidReportRange, "INVALID_REPORT_RANGE", "Invalid report date range", "报表日期范围无效"),
+	e(booking.ErrInvalidTimezone, "INVALID_TIMEZONE", "Invalid timezone", "时区无效"),
+	e(export.ErrUnsupportedFormat, "UNSUPPORTED_EXPORT_FORMAT", "Unsupported export format", "不支持的导出格式"),
+	e(booking.ErrTooManyReportPeriods, "TOO_MANY_REPORT_PERIODS", "The report covers too many periods, narrow the date range or use a coarser granularity", "报表周期数过多，请缩小日期范围或使用更粗的粒度"),
+
+	// 会员积分
+	e(loyalty.ErrInsufficientPoints, "INSUFFICIENT_LOYALTY_POINTS", "You do not have enough loyalty points", "积分余额不足"),
+	e(loyalty.ErrRedemptionLimitExceeded, "LOYALTY_REDEMPTION_LIMIT_EXCEEDED", "Too many loyalty points for this booking", "抵扣积分超过本单上限"),
+	e(loyalty.ErrBookingNotOpen, "BOOKING_NOT_OPEN", "Booking for this showtime is not open yet for your membership tier", "该场次尚未对你的会员等级开放购票"),
+
+	// 报表订阅
+	e(report.ErrSubscriptionNotFound, "REPORT_SUBSCRIPTION_NOT_FOUND", "Report subscription not found", "报表订阅不存在"),
+	e(report.ErrInvalidSubscription, "INVALID_REPORT_SUBSCRIPTION", "Invalid report subscription", "报表订阅无效"),
+	e(report.ErrInvalidCron, "INVALID_CRON_EXPRESSION", "Invalid cron expression", "cron 表达式无效"),
+	e(report.ErrDelivererUnavailable, "DELIVERY_CHANNEL_UNAVAILABLE", "The delivery channel is not configured on the server", "服务器未配置该投递渠道"),
+
+	// 评价
+	e(review.ErrReviewNotFound, "REVIEW_NOT_FOUND", "Review not found", "评价不存在"),
+	e(review.ErrReviewAlreadyExists, "REVIEW_ALREADY_EXISTS", "You have already reviewed this movie", "你已评价过该电影"),
+	e(review.ErrInvalidReview, "INVALID_REVIEW", "Invalid review", "评价内容无效"),
+	e(review.ErrInvalidReviewTransition, "INVALID_REVIEW_TRANSITION", "Invalid review status transition", "评价状态无法进行该变更"),
+	e(review.ErrNotVerifiedViewer, "NOT_VERIFIED_VIEWER", "Only viewers who have watched the movie can review it", "只有看过该电影的观众才能评价"),
+	e(review.ErrReviewNotOwned, "REVIEW_NOT_OWNED", "The review does not belong to you", "该评价不属于你"),
+	e(review.ErrReviewNotPublished, "REVIEW_NOT_PUBLISHED", "The review is not published", "评价未发布"),
+	e(review.ErrVoteOwnReview, "CANNOT_VOTE_OWN_REVIEW", "You cannot vote for your own review", "不能给自己的评价投票"),
+	e(review.ErrReviewAlreadyVoted, "REVIEW_ALREADY_VOTED", "You have already voted for this review", "你已为该评价投过票"),
+	e(review.ErrReviewVoteNotFound, "REVIEW_VOTE_NOT_FOUND", "Review vote not found", "投票不存在"),
+
+	// 关注列表
+	e(watchlist.ErrWatchlistEntryNotFound, "WATCHLIST_ENTRY_NOT_FOUND", "The movie is not in your watchlist", "该电影不在关注列表中"),
+	e(watchlist.ErrWatchlistEntryAlreadyExists, "WATCHLIST_ENTRY_ALREADY_EXISTS", "The movie is already in your watchlist", "该电影已在关注列表中"),
+	e(watchlist.ErrInvalidWatchlistPreference, "INVALID_WATCHLIST_PREFERENCE", "The preferred hall does not belong to the preferred cinema", "偏好影厅不属于偏好影院"),
+}
+
+// 未登记错误按HTTP状态码使用的通用错误码与消息
+var statusEntries = map[int]entry{
+	http.StatusBadRequest:            e(nil, "INVALID_REQUEST", "Invalid request", "请求无效"),
+	http.StatusUnauthorized:          e(nil, "UNAUTHORIZED", "Authentication required", "需要登录"),
+	http.StatusForbidden:             e(nil, "FORBIDDEN", "You do not have permission to perform this action", "没有执行该操作的权限"),
+	http.StatusNotFound:              e(nil, "NOT_FOUND", "Resource not found", "资源不存在"),
+	http.StatusConflict:              e(nil, "CONFLICT", "The request conflicts with the current state", "请求与当前状态冲突"),
+	http.StatusRequestEntityTooLarge: e(nil, "PAYLOAD_TOO_LARGE", "Request body is too large", "请求体过大"),
+	http.StatusUnsupportedMediaType:  e(nil, "UNSUPPORTED_MEDIA_TYPE", "Unsupported media type", "不支持的媒体类型"),
+	http.StatusServiceUnavailable:    e(nil, "SERVICE_UNAVAILABLE", "The service is temporarily unavailable", "服务暂时不可用"),
+}
+
+var internalEntry = e(nil, "INTERNAL_ERROR", "Internal server error", "服务器内部错误")
+
+// 查找错误对应的条目，未登记时按状态码回退到通用条目
+func lookup(err error, status int) entry {
+	if err != nil {
+		for _, item := range catalog {
+			if errors.Is(err, item.err) {
+				return item
+			}
+		}
+	}
+	if item, ok := statusEntries[status]; ok {
+		return item
+	}
+	return internalEntry
+}
+
+// 按偏好语言选择消息，没有匹配的语言时使用默认语言
+func (item entry) message(locales []vo.Locale) string {
+	language := catalogLanguages[0]
+	if locale, ok := vo.NegotiateLocale(locales, catalogLanguages); ok {
+		language = locale
+	}
+	return item.messages[string(language)]
+}
@@ -0,0 +1,45 @@
+package i18n
+
+import (
+	"mrs/internal/api/dto/response"
+	"mrs/internal/domain/shared/vo"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 上下文中保存请求偏好语言的键（由 Locale 中间件解析 Accept-Language 写入）
+const LocalesKey = "locales"
+
+// 获取请求的偏好语言，按权重降序；未经过中间件时直接解析请求头
+func Locales(ctx *gin.Context) []vo.Locale {
+	if value, ok := ctx.Get(LocalesKey); ok {
+		if locales, ok := value.([]vo.Locale); ok {
+			return locales
+		}
+	}
+	return vo.ParseAcceptLanguage(ctx.GetHeader("Accept-Language"))
+}
+
+// 构造本地化的错误响应
+func NewErrorResponse(ctx *gin.Context, status int, err error) *response.ErrorResponse {
+	item := lookup(err, status)
+	resp := &response.ErrorResponse{
+		Code:  item.code,
+		Error: item.message(Locales(ctx)),
+	}
+	if err != nil && status < http.StatusInternalServerError && err.Error() != resp.Error {
+		resp.Detail = err.Error()
+	}
+	return resp
+}
+
+// 写入错误响应
+func WriteError(ctx *gin.Context, status int, err error) {
+	ctx.JSON(status, NewErrorResponse(ctx, status, err))
+}
+
+// 写入错误响应并中止后续处理（用于中间件）
+func AbortWithError(ctx *gin.Context, status int, err error) {
+	ctx.AbortWithStatusJSON(status, NewErrorResponse(ctx, status, err))
+}
@@ -0,0 +1,86 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"mrs/internal/api/dto/response"
+	"mrs/internal/domain/movie"
+	"mrs/internal/domain/shared/vo"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newContext(acceptLanguage string) *gin.Context {
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	if acceptLanguage != "" {
+		ctx.Request.Header.Set("Accept-Language", acceptLanguage)
+	}
+	return ctx
+}
+
+func TestLocales(t *testing.T) {
+	ctx := newContext("en;q=0.5, zh-TW")
+	if got, want := Locales(ctx), []vo.Locale{"zh-TW", "en"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Locales() from header = %v, want %v", got, want)
+	}
+
+	// 中间件写入的语言优先于请求头
+	ctx.Set(LocalesKey, []vo.Locale{"fr"})
+	if got, want := Locales(ctx), []vo.Locale{"fr"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Locales() from context = %v, want %v", got, want)
+	}
+}
+
+func TestNewErrorResponse(t *testing.T) {
+	wrapped := fmt.Errorf("find movie 42: %w", movie.ErrMovieNotFound)
+	unregistered := errors.New("key: 'Limit' failed on the 'max' tag")
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		status         int
+		err            error
+		want           response.ErrorResponse
+	}{
+		{"default language", "", http.StatusNotFound, movie.ErrMovieNotFound,
+			response.ErrorResponse{Code: "MOVIE_NOT_FOUND", Error: "Movie not found", Detail: movie.ErrMovieNotFound.Error()}},
+		{"zh-TW falls back to zh", "zh-TW, en;q=0.5", http.StatusNotFound, wrapped,
+			response.ErrorResponse{Code: "MOVIE_NOT_FOUND", Error: "电影不存在", Detail: wrapped.Error()}},
+		{"weights pick en", "zh;q=0.3, en-GB;q=0.8", http.StatusNotFound, wrapped,
+			response.ErrorResponse{Code: "MOVIE_NOT_FOUND", Error: "Movie not found", Detail: wrapped.Error()}},
+		{"unsupported language uses default", "fr", http.StatusConflict, movie.ErrMovieArchived,
+			response.ErrorResponse{Code: "MOVIE_ARCHIVED", Error: "The movie has been archived", Detail: movie.ErrMovieArchived.Error()}},
+		{"unregistered error by status", "zh-CN", http.StatusBadRequest, unregistered,
+			response.ErrorResponse{Code: "INVALID_REQUEST", Error: "请求无效", Detail: unregistered.Error()}},
+		{"nil error by status", "", http.StatusUnauthorized, nil,
+			response.ErrorResponse{Code: "UNAUTHORIZED", Error: "Authentication required"}},
+		{"internal error hides detail", "", http.StatusInternalServerError, unregistered,
+			response.ErrorResponse{Code: "INTERNAL_ERROR", Error: "Internal server error"}},
+		{"unknown status is internal", "zh", http.StatusTeapot, nil,
+			response.ErrorResponse{Code: "INTERNAL_ERROR", Error: "服务器内部错误"}},
+	}
+	for _, tt := range tests {
+		got := NewErrorResponse(newContext(tt.acceptLanguage), tt.status, tt.err)
+		if *got != tt.want {
+			t.Errorf("%s: NewErrorResponse() = %+v, want %+v", tt.name, *got, tt.want)
+		}
+	}
+}
+
+func TestCatalog(t *testing.T) {
+	for _, item := range catalog {
+		if item.err == nil || item.code == "" {
+			t.Errorf("catalog entry %q: missing error or code", item.code)
+		}
+		for _, language := range catalogLanguages {
+			if item.messages[string(language)] == "" {
+				t.Errorf("catalog entry %q: missing %s message", item.code, language)
+			}
+		}
+	}
+}
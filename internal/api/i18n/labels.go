@@ -0,0 +1,93 @@
+package i18n
+
+import "mrs/internal/domain/shared/vo"
+
+// 报表导出的表名与列名（表头）。键与 JSON 字段名一致，未登记的键原样返回
+var labels = map[string]map[string]string{
+	// 表名
+	"summary":          {"en": "Summary", "zh": "汇总"},
+	"previous_period":  {"en": "Previous Period", "zh": "上一周期"},
+	"series":           {"en": "Series", "zh": "时间序列"},
+	"series_breakdown": {"en": "Series Breakdown", "zh": "时间序列拆分"},
+	"previous_series":  {"en": "Previous Series", "zh": "上一周期时间序列"},
+	"by_movie":         {"en": "By Movie", "zh": "按电影"},
+	"by_hall":          {"en": "By Hall", "zh": "按影厅"},
+	"by_weekday":       {"en": "By Weekday", "zh": "按星期"},
+	"by_time_slot":     {"en": "By Time Slot", "zh": "按时段"},
+	"by_hour":          {"en": "By Hour", "zh": "按小时"},
+	"showtimes":        {"en": "Showtimes", "zh": "场次"},
+	"bookings":         {"en": "Bookings", "zh": "订单"},
+
+	// 列名
+	"report_date":         {"en": "Report Date", "zh": "报告日期"},
+	"start_date":          {"en": "Start Date", "zh": "开始日期"},
+	"end_date":            {"en": "End Date", "zh": "结束日期"},
+	"date_basis":          {"en": "Date Basis", "zh": "日期口径"},
+	"timezone":            {"en": "Timezone", "zh": "时区"},
+	"total_revenue":       {"en": "Total Revenue", "zh": "总收入"},
+	"total_bookings":      {"en": "Total Bookings", "zh": "总订单数"},
+	"total_tickets":       {"en": "Total Tickets", "zh": "总票数"},
+	"revenue_change":      {"en": "Revenue Change", "zh": "收入变化"},
+	"bookings_change":     {"en": "Bookings Change", "zh": "订单数变化"},
+	"tickets_change":      {"en": "Tickets Change", "zh": "票数变化"},
+	"period_start":        {"en": "Period Start", "zh": "周期开始"},
+	"id":                  {"en": "ID", "zh": "ID"},
+	"name":                {"en": "Name", "zh": "名称"},
+	"key":                 {"en": "Key", "zh": "键"},
+	"label":               {"en": "Label", "zh": "名称"},
+	"revenue":             {"en": "Revenue", "zh": "收入"},
+	"tickets":             {"en": "Tickets", "zh": "票数"},
+	"showtime_id":         {"en": "Showtime ID", "zh": "场次ID"},
+	"movie_id":            {"en": "Movie ID", "zh": "电影ID"},
+	"movie_title":         {"en": "Movie", "zh": "电影"},
+	"cinema_hall_id":      {"en": "Hall ID", "zh": "影厅ID"},
+	"hall_name":           {"en": "Hall", "zh": "影厅"},
+	"cinema_name":         {"en": "Cinema", "zh": "影院"},
+	"start_time":          {"en": "Start Time", "zh": "开始时间"},
+	"capacity":            {"en": "Capacity", "zh": "容量"},
+	"seats_sold":          {"en": "Seats Sold", "zh": "已售座位"},
+	"load_factor":         {"en": "Load Factor", "zh": "上座率"},
+	"average_load_factor": {"en": "Average Load Factor", "zh": "平均上座率"},
+	"overall_load_factor": {"en": "Overall Load Factor", "zh": "整体上座率"},
+	"empty_seat_revenue":  {"en": "Empty Seat Revenue", "zh": "空座收入机会"},
+	"booking_id":          {"en": "Booking ID", "zh": "订单ID"},
+	"user_id":             {"en": "User ID", "zh": "用户ID"},
+	"status":              {"en": "Status", "zh": "状态"},
+	"booked_at":           {"en": "Booked At", "zh": "下单时间"},
+	"showtime_start":      {"en": "Showtime", "zh": "场次时间"},
+	"total_amount":        {"en": "Total Amount", "zh": "订单金额"},
+
+	// 转化漏斗的列名
+	"sessions":                       {"en": "Sessions", "zh": "会话数"},
+	"seats_locked":                   {"en": "Seats Locked", "zh": "锁座会话"},
+	"bookings_created":               {"en": "Bookings Created", "zh": "下单会话"},
+	"bookings_confirmed":             {"en": "Bookings Confirmed", "zh": "确认会话"},
+	"bookings_cancelled":             {"en": "Bookings Cancelled", "zh": "取消会话"},
+	"abandoned":                      {"en": "Abandoned", "zh": "流失会话"},
+	"lock_rate":                      {"en": "Lock Rate", "zh": "锁座率"},
+	"booking_rate":                   {"en": "Booking Rate", "zh": "下单率"},
+	"confirm_rate":                   {"en": "Confirm Rate", "zh": "支付率"},
+	"conversion_rate":                {"en": "Conversion Rate", "zh": "转化率"},
+	"abandonment_rate":               {"en": "Abandonment Rate", "zh": "流失率"},
+	"median_time_to_confirm_seconds": {"en": "Median Time to Confirm (s)", "zh": "确认用时中位数（秒）"},
+
+	// 售票排行榜的表名与列名
+	"movies":           {"en": "Movies", "zh": "电影"},
+	"window":           {"en": "Window", "zh": "统计窗口"},
+	"generated_at":     {"en": "Generated At", "zh": "生成时间"},
+	"rank":             {"en": "Rank", "zh": "排名"},
+	"cinema_hall_name": {"en": "Hall", "zh": "影厅"},
+}
+
+// 按偏好语言获取标签，没有匹配的语言时使用默认语言
+func Label(locales []vo.Locale, key string) string {
+	messages, ok := labels[key]
+	if !ok {
+		return key
+	}
+	language := catalogLanguages[0]
+	if locale, ok := vo.NegotiateLocale(locales, catalogLanguages); ok {
+		language = locale
+	}
+	return messages[string(language)]
+}
@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"errors"
+	"mrs/internal/api/i18n"
 	"mrs/internal/domain/user"
 	"mrs/internal/utils"
 	applog "mrs/pkg/log"
@@ -21,6 +23,15 @@ const (
 	// UsernameKey            = "username" // 如果也需要用户名
 )
 
+// 认证失败的原因，作为错误响应的 detail 返回
+var (
+	errAuthorizationRequired = errors.New("authorization header is required")
+	errInvalidAuthorization  = errors.New("authorization header format must be Bearer {token}")
+	errTokenMissing          = errors.New("token is missing")
+	errInvalidToken          = errors.New("invalid or expired token")
+	errNotAdmin              = errors.New("user is not admin")
+)
+
 // AuthMiddleware 检查用户是否已认证
 func AuthMiddleware(jwtManager utils.JWTManager, logger applog.Logger) Auth {
 	return func(ctx *gin.Context) {
@@ -30,14 +41,14 @@ func AuthMiddleware(jwtManager utils.JWTManager, logger applog.Logger) Auth {
 		authHeader := ctx.GetHeader(AuthorizationHeaderKey)
 		if authHeader == "" {
 			inlogger.Warn("authorization header is missing")
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is required"})
+			i18n.AbortWithError(ctx, http.StatusUnauthorized, errAuthorizationRequired)
 			return
 		}
 
 		// 校验 Authorization 格式
 		if !strings.HasPrefix(authHeader, BearerSchema) {
 			inlogger.Warn("authorization header format is invalid", applog.String("header", authHeader))
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header format must be Bearer {token}"})
+			i18n.AbortWithError(ctx, http.StatusUnauthorized, errInvalidAuthorization)
 			return
 		}
 
@@ -45,7 +56,7 @@ func AuthMiddleware(jwtManager utils.JWTManager, logger applog.Logger) Auth {
 		tokenString := strings.TrimSpace(strings.TrimPrefix(authHeader, BearerSchema))
 		if tokenString == "" {
 			inlogger.Warn("token is empty after trimming Bearer prefix", applog.String("header", authHeader))
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token is missing"})
+			i18n.AbortWithError(ctx, http.StatusUnauthorized, errTokenMissing)
 			return
 		}
 
@@ -54,7 +65,7 @@ func AuthMiddleware(jwtManager utils.JWTManager, logger applog.Logger) Auth {
 		if err != nil {
 			inlogger.Warn("failed to verify token", applog.Error(err))
 			// 可以根据 err 类型返回更具体的错误，例如 token 过期
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			i18n.AbortWithError(ctx, http.StatusUnauthorized, errInvalidToken)
 			return
 		}
 		// 将用户信息存入 Gin 上下文
@@ -74,7 +85,7 @@ func AdminMiddleware(jwtManager utils.JWTManager, logger applog.Logger) Admin {
 		userRoleName := ctx.GetString(UserRoleNameKey)
 		if userRoleName != user.AdminRoleName {
 			inlogger.Warn("user is not admin", applog.String("role", userRoleName))
-			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "User is not admin"})
+			i18n.AbortWithError(ctx, http.StatusForbidden, errNotAdmin)
 			return
 		}
 
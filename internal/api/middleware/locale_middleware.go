@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"mrs/internal/api/i18n"
+	"mrs/internal/domain/shared/vo"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Locale gin.HandlerFunc // 语言协商中间件
+
+// LocaleMiddleware 解析 Accept-Language 请求头，将偏好语言列表存入 Gin 上下文
+func LocaleMiddleware() Locale {
+	return func(ctx *gin.Context) {
+		ctx.Set(i18n.LocalesKey, vo.ParseAcceptLanguage(ctx.GetHeader("Accept-Language")))
+		// 响应内容随请求语言变化，提示中间缓存按语言区分
+		ctx.Header("Vary", "Accept-Language")
+		ctx.Next()
+	}
+}
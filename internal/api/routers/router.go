@@ -19,18 +19,31 @@ func SetupRouter(
 	showtimeHandler *handlers.ShowtimeHandler,
 	bookingHandler *handlers.BookingHandler,
 	reportHandler *handlers.ReportHandler,
+	reviewHandler *handlers.ReviewHandler,
+	personHandler *handlers.PersonHandler,
+	mediaHandler *handlers.MediaHandler,
+	watchlistHandler *handlers.WatchlistHandler,
+	recommendationHandler *handlers.RecommendationHandler,
+	reportSubscriptionHandler *handlers.ReportSubscriptionHandler,
+	trendingHandler *handlers.TrendingHandler,
+	loyaltyHandler *handlers.LoyaltyHandler,
 	authMiddleware middleware.Auth,
 	adminMiddleware middleware.Admin,
 	loggerMiddleware middleware.Logger,
+	localeMiddleware middleware.Locale,
 	// ... 其他处理器 ...
 ) *gin.Engine {
 	router := gin.New()
 	router.Use(gin.Recovery())
 	router.Use(gin.HandlerFunc(loggerMiddleware))
+	router.Use(gin.HandlerFunc(localeMiddleware))
 
 	// 健康检查路由
 	router.GET("/health", healthHandler.CheckHealth)
 
+	// 媒体文件（文件名为内容哈希，公开访问）
+	router.GET("/media/*key", mediaHandler.GetMedia)
+
 	apiV1 := router.Group("/api/v1")
 
 	// 管理员路由，必须通过认证和权限验证
@@ -56,6 +69,15 @@ func SetupRouter(
 		{
 			authUserRoutes.GET("/me", userHandler.GetUserProfile)    // 获取个人信息
 			authUserRoutes.PUT("/me", userHandler.UpdateUserProfile) // 更新个人信息
+
+			authUserRoutes.GET("/me/watchlist", watchlistHandler.ListWatchlist)                    // 查询关注列表
+			authUserRoutes.POST("/me/watchlist", watchlistHandler.AddToWatchlist)                  // 关注电影
+			authUserRoutes.DELETE("/me/watchlist/:movie_id", watchlistHandler.RemoveFromWatchlist) // 取消关注
+
+			authUserRoutes.GET("/me/recommendations", recommendationHandler.GetRecommendations) // 个性化推荐
+
+			authUserRoutes.GET("/me/loyalty", loyaltyHandler.GetLoyaltyAccount)        // 积分账户与会员等级
+			authUserRoutes.GET("/me/loyalty/ledger", loyaltyHandler.ListLoyaltyLedger) // 积分流水
 		}
 	}
 	userAdminRoutes := adminRoutes.Group("/users")
@@ -81,13 +103,23 @@ func SetupRouter(
 	movieRoutes.Use(gin.HandlerFunc(authMiddleware))
 	{
 		movieRoutes.GET("", movieHandler.ListMovies)
-		movieRoutes.GET("/:id", movieHandler.GetMovie) // 获取单个电影
+		movieRoutes.GET("/search", movieHandler.SearchMovies)
+		movieRoutes.GET("/trending", trendingHandler.GetTrendingMovies) // 热门电影（按售出票数）
+		movieRoutes.GET("/:id", movieHandler.GetMovie)                  // 获取单个电影
+		movieRoutes.GET("/:id/reviews", reviewHandler.ListMovieReviews)
+		movieRoutes.POST("/:id/reviews", reviewHandler.CreateReview)
 	}
 	movieAdminRoutes := adminRoutes.Group("/movies")
 	{
 		movieAdminRoutes.POST("", movieHandler.CreateMovie)
+		movieAdminRoutes.POST("/import", movieHandler.ImportMovies) // 批量导入片单（json/csv）
 		movieAdminRoutes.PUT("/:id", movieHandler.UpdateMovie)
 		movieAdminRoutes.DELETE("/:id", movieHandler.DeleteMovie)
+		movieAdminRoutes.POST("/:id/archive", movieHandler.ArchiveMovie)         // 手动下映
+		movieAdminRoutes.POST("/:id/restore", movieHandler.RestoreMovie)         // 恢复已下映的电影
+		movieAdminRoutes.POST("/:id/media/:kind", mediaHandler.UploadMovieMedia) // 上传海报/背景图/预告片
+		movieAdminRoutes.PUT("/:id/translations/:locale", movieHandler.UpsertMovieTranslation)
+		movieAdminRoutes.DELETE("/:id/translations/:locale", movieHandler.DeleteMovieTranslation)
 	}
 
 	genreRoutes := apiV1.Group("/genres")
@@ -100,6 +132,37 @@ func SetupRouter(
 		genreAdminRoutes.POST("", movieHandler.CreateGenre)
 		genreAdminRoutes.PUT("/:id", movieHandler.UpdateGenre)
 		genreAdminRoutes.DELETE("/:id", movieHandler.DeleteGenre)
+		genreAdminRoutes.PUT("/:id/translations/:locale", movieHandler.UpsertGenreTranslation)
+		genreAdminRoutes.DELETE("/:id/translations/:locale", movieHandler.DeleteGenreTranslation)
+	}
+
+	// 影人路由
+	personRoutes := apiV1.Group("/people")
+	personRoutes.Use(gin.HandlerFunc(authMiddleware))
+	{
+		personRoutes.GET("", personHandler.ListPeople)
+		personRoutes.GET("/:id", personHandler.GetPerson)
+		personRoutes.GET("/:id/filmography", personHandler.GetFilmography) // 作品年表，可按职务过滤
+	}
+	personAdminRoutes := adminRoutes.Group("/people")
+	{
+		personAdminRoutes.POST("", personHandler.CreatePerson)
+		personAdminRoutes.PUT("/:id", personHandler.UpdatePerson)
+		personAdminRoutes.DELETE("/:id", personHandler.DeletePerson)
+	}
+
+	// 影院管理路由
+	cinemaRoutes := apiV1.Group("/cinemas")
+	cinemaRoutes.Use(gin.HandlerFunc(authMiddleware))
+	{
+		cinemaRoutes.GET("", cinemaHandler.ListAllCinemas)
+		cinemaRoutes.GET("/:id", cinemaHandler.GetCinema) // 包含影院下的影厅列表
+	}
+	cinemaAdminRoutes := adminRoutes.Group("/cinemas")
+	{
+		cinemaAdminRoutes.POST("", cinemaHandler.CreateCinema)
+		cinemaAdminRoutes.PUT("/:id", cinemaHandler.UpdateCinema)
+		cinemaAdminRoutes.DELETE("/:id", cinemaHandler.DeleteCinema)
 	}
 
 	// 影厅管理路由
@@ -114,6 +177,14 @@ func SetupRouter(
 		cinemaHallAdminRoutes.POST("", cinemaHandler.CreateCinemaHall)
 		cinemaHallAdminRoutes.PUT("/:id", cinemaHandler.UpdateCinemaHall)
 		cinemaHallAdminRoutes.DELETE("/:id", cinemaHandler.DeleteCinemaHall)
+		cinemaHallAdminRoutes.GET("/:id/layout", cinemaHandler.ExportCinemaHallLayout)
+		cinemaHallAdminRoutes.PUT("/:id/layout", cinemaHandler.ImportCinemaHallLayout)
+		cinemaHallAdminRoutes.GET("/:id/layout-versions", cinemaHandler.ListLayoutVersions)
+		cinemaHallAdminRoutes.POST("/:id/seat-restrictions", cinemaHandler.CreateSeatRestrictions)
+		cinemaHallAdminRoutes.GET("/:id/seat-restrictions", cinemaHandler.ListSeatRestrictions)
+		cinemaHallAdminRoutes.DELETE("/:id/seat-restrictions/:restriction_id", cinemaHandler.DeleteSeatRestriction)
+		cinemaHallAdminRoutes.PUT("/:id/distancing-policy", cinemaHandler.UpdateDistancingPolicy)
+		cinemaHallAdminRoutes.DELETE("/:id/distancing-policy", cinemaHandler.DeleteDistancingPolicy)
 	}
 
 	// 放映场次管理路由
@@ -126,9 +197,13 @@ func SetupRouter(
 	}
 	showtimeAdminRoutes := adminRoutes.Group("/showtimes")
 	{
+		showtimeAdminRoutes.GET("", showtimeHandler.ListAdminShowtimes) // 包含已取消的场次
 		showtimeAdminRoutes.POST("", showtimeHandler.CreateShowtime)
 		showtimeAdminRoutes.PUT("/:id", showtimeHandler.UpdateShowtime)
 		showtimeAdminRoutes.DELETE("/:id", showtimeHandler.DeleteShowtime)
+		showtimeAdminRoutes.POST("/:id/cancel", showtimeHandler.CancelShowtime) // 取消场次（批量取消/退款订单）
+		// 迁移未开始场次的影厅布局版本
+		showtimeAdminRoutes.POST("/layout-migrations", showtimeHandler.MigrateShowtimeLayouts)
 	}
 
 	// 订单管理路由
@@ -141,11 +216,47 @@ func SetupRouter(
 		bookingRoutes.POST("/:id/cancel", bookingHandler.CancelBooking)
 		bookingRoutes.POST("/:id/confirm", bookingHandler.ConfirmBooking)
 	}
+	bookingAdminRoutes := adminRoutes.Group("/bookings")
+	{
+		bookingAdminRoutes.GET("/:id/check-in", bookingHandler.GetCheckIn) // 检票信息（含年龄要求）
+	}
+
+	// 评价路由（只能修改、删除自己的评价）
+	reviewRoutes := apiV1.Group("/reviews")
+	reviewRoutes.Use(gin.HandlerFunc(authMiddleware))
+	{
+		reviewRoutes.PUT("/:id", reviewHandler.UpdateReview)
+		reviewRoutes.DELETE("/:id", reviewHandler.DeleteReview)
+		reviewRoutes.POST("/:id/helpful", reviewHandler.VoteHelpful)
+		reviewRoutes.DELETE("/:id/helpful", reviewHandler.UnvoteHelpful)
+	}
+	reviewAdminRoutes := adminRoutes.Group("/reviews")
+	{
+		reviewAdminRoutes.GET("", reviewHandler.ListReviews)
+		reviewAdminRoutes.POST("/:id/approve", reviewHandler.ApproveReview)
+		reviewAdminRoutes.POST("/:id/reject", reviewHandler.RejectReview)
+		reviewAdminRoutes.POST("/:id/hide", reviewHandler.HideReview)
+		reviewAdminRoutes.POST("/:id/restore", reviewHandler.RestoreReview)
+	}
 
 	// 报表管理路由
 	reportRoutes := adminRoutes.Group("/reports")
 	{
 		reportRoutes.GET("/sales", reportHandler.GenerateSalesReport)
+		reportRoutes.GET("/occupancy", reportHandler.GenerateOccupancyReport)
+		reportRoutes.GET("/funnel", reportHandler.GenerateFunnelReport)
+		reportRoutes.GET("/bookings", reportHandler.ExportBookings)
+		reportRoutes.GET("/leaderboard", trendingHandler.GetLeaderboard) // 电影与场次售票排行榜
+
+		// 报表订阅：按 cron 定期生成报表并投递
+		subscriptionRoutes := reportRoutes.Group("/subscriptions")
+		subscriptionRoutes.POST("", reportSubscriptionHandler.CreateSubscription)
+		subscriptionRoutes.GET("", reportSubscriptionHandler.ListSubscriptions)
+		subscriptionRoutes.GET("/:id", reportSubscriptionHandler.GetSubscription)
+		subscriptionRoutes.PUT("/:id", reportSubscriptionHandler.UpdateSubscription)
+		subscriptionRoutes.DELETE("/:id", reportSubscriptionHandler.DeleteSubscription)
+		subscriptionRoutes.POST("/:id/run", reportSubscriptionHandler.RunSubscription) // 立即执行一次
+		subscriptionRoutes.GET("/:id/runs", reportSubscriptionHandler.ListRuns)        // 执行记录
 	}
 	return router
 }
@@ -3,14 +3,20 @@ package app
 import (
 	"context"
 	"errors"
+	"fmt"
 	"mrs/internal/api/dto/request"
 	"mrs/internal/api/dto/response"
+	"mrs/internal/domain/analytics"
 	"mrs/internal/domain/booking"
 	"mrs/internal/domain/cinema"
+	"mrs/internal/domain/loyalty"
+	"mrs/internal/domain/movie"
 	"mrs/internal/domain/shared"
 	"mrs/internal/domain/shared/lock"
 	"mrs/internal/domain/shared/vo"
 	"mrs/internal/domain/showtime"
+	"mrs/internal/domain/trending"
+	"mrs/internal/domain/user"
 	applog "mrs/pkg/log"
 	"time"
 )
@@ -21,6 +27,7 @@ type BookingService interface {
 	GetBooking(ctx context.Context, req *request.GetBookingRequest) (*response.BookingResponse, error)
 	CancelBooking(ctx context.Context, req *request.CancelBookingRequest) (*response.BookingResponse, error)
 	ConfirmBooking(ctx context.Context, req *request.ConfirmBookingRequest) (*response.BookingResponse, error)
+	GetCheckIn(ctx context.Context, req *request.GetCheckInRequest) (*response.CheckInResponse, error)
 }
 
 type bookingService struct {
@@ -31,6 +38,12 @@ type bookingService struct {
 	showtimeCache   showtime.ShowtimeCache
 	showtimeService ShowtimeService
 	lockProvider    lock.LockProvider
+	userRepo        user.UserRepository
+	agePolicy       *movie.AgeRatingPolicy
+	trendingCache   trending.TrendingCache
+	funnelRepo      analytics.FunnelEventRepository
+	loyaltyRepo     loyalty.LedgerRepository
+	loyaltyProgram  *loyalty.Program
 	logger          applog.Logger
 }
 
@@ -42,6 +55,12 @@ func NewBookingService(
 	showtimeCache showtime.ShowtimeCache,
 	showtimeService ShowtimeService,
 	lockProvider lock.LockProvider,
+	userRepo user.UserRepository,
+	agePolicy *movie.AgeRatingPolicy,
+	trendingCache trending.TrendingCache,
+	funnelRepo analytics.FunnelEventRepository,
+	loyaltyRepo loyalty.LedgerRepository,
+	loyaltyProgram *loyalty.Program,
 	logger applog.Logger) BookingService {
 
 	return &bookingService{
@@ -52,6 +71,12 @@ func NewBookingService(
 		showtimeCache:   showtimeCache,
 		showtimeService: showtimeService,
 		lockProvider:    lockProvider,
+		userRepo:        userRepo,
+		agePolicy:       agePolicy,
+		trendingCache:   trendingCache,
+		funnelRepo:      funnelRepo,
+		loyaltyRepo:     loyaltyRepo,
+		loyaltyProgram:  loyaltyProgram,
 		logger:          logger.With(applog.String("Service", "BookingService")),
 	}
 }
@@ -61,6 +86,20 @@ func (s *bookingService) CreateBooking(ctx context.Context, req *request.CreateB
 	logger := s.logger.With(applog.String("Method", "CreateBooking"))
 	lockKey := cinema.GetShowtimeSeatsLockKey(vo.ShowtimeID(req.ShowtimeID))
 
+	// 获取分布式锁（场次锁）
+	// 先加锁再读取场次信息，与取消场次互斥，避免为已取消的场次创建订单
+	lk, err := s.lockProvider.Acquire(ctx, lockKey, lock.DefaultLockTTL)
+	if err != nil {
+		if errors.Is(err, lock.ErrLockAlreadyAcquired) {
+			logger.Warn("another process is initializing the seat map, will retry locking seats...",
+				applog.Uint("showtimeID", uint(req.ShowtimeID)))
+			return nil, booking.ErrBookedSeatAlreadyLocked
+		}
+		logger.Error("failed to acquire lock", applog.Error(err))
+		return nil, err
+	}
+	defer lk.Release(ctx)
+
 	// 获取场次信息
 	st, err := s.showtimeService.GetShowtime(ctx, &request.GetShowtimeRequest{
 		ID: req.ShowtimeID,
@@ -70,24 +109,35 @@ func (s *bookingService) CreateBooking(ctx context.Context, req *request.CreateB
 		return nil, err
 	}
 
+	// 检查场次是否已取消
+	if st.Status == string(showtime.ShowtimeStatusCancelled) {
+		logger.Warn("showtime has been cancelled")
+		return nil, showtime.ErrShowtimeCancelled
+	}
+
 	// 检查场次是否已结束
 	if st.EndTime.Before(time.Now()) {
 		logger.Warn("showtime has ended", applog.String("end_time", st.EndTime.Format(time.DateTime)))
 		return nil, showtime.ErrShowtimeEnded
 	}
 
-	// 获取分布式锁（场次锁）
-	lk, err := s.lockProvider.Acquire(ctx, lockKey, lock.DefaultLockTTL)
+	// 年龄分级核验（按开场时的年龄），在锁定座位前完成
+	ageRequirement, ageCheck, err := s.checkAgeRequirement(ctx, vo.UserID(req.UserID), st)
 	if err != nil {
-		if errors.Is(err, lock.ErrLockAlreadyAcquired) {
-			logger.Warn("another process is initializing the seat map, will retry locking seats...",
-				applog.Uint("showtimeID", uint(req.ShowtimeID)))
-			return nil, booking.ErrBookedSeatAlreadyLocked
-		}
-		logger.Error("failed to acquire lock", applog.Error(err))
 		return nil, err
 	}
-	defer lk.Release(ctx)
+
+	// 会员等级决定购票窗口与等级折扣，在锁定座位前完成
+	account, err := s.loyaltyRepo.GetAccount(ctx, vo.UserID(req.UserID))
+	if err != nil {
+		logger.Error("failed to get loyalty account", applog.Error(err))
+		return nil, err
+	}
+	tier := s.loyaltyProgram.TierFor(account.QualifyingPoints)
+	if err := s.loyaltyProgram.CheckBookingWindow(tier, st.StartTime, time.Now()); err != nil {
+		logger.Warn("booking is not open for tier", applog.String("tier", tier.Name), applog.Error(err))
+		return nil, err
+	}
 
 	// 获取座位ID列表
 	seatIDs := make([]vo.SeatID, len(req.SeatIDs))
@@ -106,6 +156,12 @@ func (s *bookingService) CreateBooking(ctx context.Context, req *request.CreateB
 		return nil, err
 	}
 
+	// 锁座事件只用于统计，写入失败不影响下单
+	lockedEvent := analytics.NewFunnelEvent(analytics.EventSeatsLocked, vo.UserID(req.UserID), vo.ShowtimeID(req.ShowtimeID), len(seatIDs))
+	if err := s.funnelRepo.Append(ctx, lockedEvent); err != nil {
+		logger.Warn("failed to record seats locked event", applog.Error(err))
+	}
+
 	// 若创建订单失败，则释放座位锁
 	defer func() {
 		if err != nil {
@@ -122,11 +178,15 @@ func (s *bookingService) CreateBooking(ctx context.Context, req *request.CreateB
 	// 创建订单
 	totalPrice := float64(len(seatIDs)) * st.Price
 	booking := booking.NewBooking(vo.UserID(req.UserID), vo.ShowtimeID(req.ShowtimeID), bookedSeats, totalPrice)
+	booking.SetAgeRequirement(ageRequirement, ageCheck)
 
 	// 使用事务，确保两个操作要么都成功，要么都失败(先创建订单，再将订单ID写入bookedSeats)
 	err = s.uow.Execute(ctx, func(ctx context.Context, provider shared.RepositoryProvider) error {
 		bookingRepo := provider.GetBookingRepository()
 		bookedSeatRepo := provider.GetBookedSeatRepository()
+		if err = s.applyLoyaltyDiscount(ctx, provider, booking, account, req.RedeemPoints); err != nil {
+			return err
+		}
 		booking, err = bookingRepo.Create(ctx, booking)
 		if err != nil {
 			logger.Error("failed to create booking", applog.Error(err))
@@ -144,6 +204,23 @@ func (s *bookingService) CreateBooking(ctx context.Context, req *request.CreateB
 			logger.Error("failed to create booked seats", applog.Error(err))
 			return err
 		}
+
+		// 订单事件与订单在同一事务中写入
+		createdEvent := analytics.NewFunnelEvent(analytics.EventBookingCreated, booking.UserID, booking.ShowtimeID, len(bookedSeats))
+		createdEvent.BookingID = booking.ID
+		if err = provider.GetFunnelEventRepository().Append(ctx, createdEvent); err != nil {
+			logger.Error("failed to record booking created event", applog.Error(err))
+			return err
+		}
+
+		// 抵扣的积分与订单在同一事务中扣减
+		if booking.Discount != nil && booking.Discount.PointsRedeemed > 0 {
+			redeemEntry := loyalty.NewRedeemEntry(booking, booking.Discount.PointsRedeemed)
+			if err = provider.GetLoyaltyLedgerRepository().Append(ctx, redeemEntry); err != nil {
+				logger.Error("failed to redeem loyalty points", applog.Error(err))
+				return err
+			}
+		}
 		return nil
 	})
 
@@ -152,10 +229,93 @@ func (s *bookingService) CreateBooking(ctx context.Context, req *request.CreateB
 		return nil, err
 	}
 
-	logger.Info("create booking successfully", applog.Float64("total_price", totalPrice))
+	// 社交距离的间隔座位依赖已售座位，订单变化后需要重建座位表（仍持有场次锁）
+	s.refreshDistancedSeatMap(ctx, vo.ShowtimeID(req.ShowtimeID))
+
+	logger.Info("create booking successfully", applog.Float64("total_price", totalPrice),
+		applog.Float64("total_amount", booking.TotalAmount), applog.String("tier", tier.Name))
 	return response.ToBookingResponse(booking), nil
 }
 
+// applyLoyaltyDiscount 按会员等级与抵扣积分计算订单优惠（在下单事务中调用）。
+// 抵扣积分时锁定积分账户并重新汇总余额，避免并发下单重复使用同一批积分
+func (s *bookingService) applyLoyaltyDiscount(ctx context.Context, provider shared.RepositoryProvider,
+	bk *booking.Booking, account *loyalty.Account, points int) error {
+	logger := s.logger.With(applog.String("Method", "applyLoyaltyDiscount"),
+		applog.Uint("user_id", uint(bk.UserID)), applog.Int("points", points))
+
+	if points > 0 {
+		var err error
+		account, err = provider.GetLoyaltyLedgerRepository().LockAccount(ctx, bk.UserID)
+		if err != nil {
+			logger.Error("failed to lock loyalty account", applog.Error(err))
+			return err
+		}
+	}
+
+	discount, err := s.loyaltyProgram.Quote(account, bk.TotalAmount, points)
+	if err != nil {
+		logger.Warn("failed to redeem loyalty points", applog.Int("balance", account.Balance), applog.Error(err))
+		return err
+	}
+	bk.ApplyDiscount(discount)
+	return nil
+}
+
+// checkAgeRequirement 按电影分级与用户在开场时的年龄核验观影资格
+// 未达年龄时按策略拒绝下单或仅标记；未登记出生日期的用户标记为待核验，由检票员查验证件
+func (s *bookingService) checkAgeRequirement(ctx context.Context, userID vo.UserID,
+	st *response.ShowtimeResponse) (*movie.AgeRequirement, movie.AgeCheck, error) {
+	logger := s.logger.With(applog.String("Method", "checkAgeRequirement"),
+		applog.Uint("user_id", uint(userID)), applog.Uint("showtime_id", st.ID))
+
+	if st.Movie == nil || st.Movie.AgeRating == "" {
+		return nil, movie.AgeCheckNotRequired, nil
+	}
+	requirement, err := s.agePolicy.Requirement(st.Movie.AgeRating)
+	if err != nil {
+		// 对照表中没有的历史分级无法判断年龄要求，交由检票员核验
+		logger.Warn("age rating not configured", applog.String("age_rating", st.Movie.AgeRating), applog.Error(err))
+		return &movie.AgeRequirement{Rating: st.Movie.AgeRating}, movie.AgeCheckUnverified, nil
+	}
+	if !requirement.Restricted() {
+		return requirement, movie.AgeCheckNotRequired, nil
+	}
+
+	usr, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		logger.Error("failed to find user", applog.Error(err))
+		return nil, "", err
+	}
+	age, known := usr.AgeAt(st.StartTime)
+	check := requirement.Check(age, known)
+
+	if check == movie.AgeCheckUnderAge && s.agePolicy.Enforcement == movie.AgeEnforcementReject {
+		logger.Warn("user is under age", applog.String("age_rating", requirement.Rating), applog.Int("age", age))
+		return nil, "", fmt.Errorf("%w: %s requires age %d", booking.ErrAgeRestricted, requirement.Rating, requirement.MinAge)
+	}
+
+	logger.Info("age requirement checked", applog.String("age_rating", requirement.Rating), applog.String("check", string(check)))
+	return requirement, check, nil
+}
+
+// refreshDistancedSeatMap 若场次座位表启用了社交距离，则按最新订单重建座位表（调用方需持有场次锁）
+func (s *bookingService) refreshDistancedSeatMap(ctx context.Context, showtimeID vo.ShowtimeID) {
+	logger := s.logger.With(applog.String("Method", "refreshDistancedSeatMap"), applog.Uint("showtime_id", uint(showtimeID)))
+
+	seatMap, err := s.seatCache.GetSeatMap(ctx, showtimeID)
+	if err != nil || !seatMap.Distancing {
+		return
+	}
+	if err := s.showtimeService.InitSeatMap(ctx, showtimeID); err != nil {
+		// 重建失败时失效座位表，下次访问时重新初始化
+		logger.Warn("failed to rebuild seat map, invalidating", applog.Error(err))
+		if err := s.seatCache.InvalidateSeatMap(ctx, showtimeID); err != nil {
+			logger.Error("failed to invalidate seat map", applog.Error(err))
+		}
+	}
+}
+
 // lockSeatsWithRetry 尝试锁定座位，如果缓存未初始化则初始化后重试
 func (s *bookingService) lockSeatsWithRetry(ctx context.Context, showtimeID vo.ShowtimeID, seatIDs []vo.SeatID) error {
 	logger := s.logger.With(applog.String("Method", "lockSeatsWithRetry"))
@@ -233,12 +393,12 @@ func (s *bookingService) CancelBooking(ctx context.Context, req *request.CancelB
 	logger := s.logger.With(applog.String("Method", "CancelBooking"))
 
 	lockKey := cinema.GetShowtimeSeatsLockKey(vo.ShowtimeID(req.ID))
-	lock, err := s.lockProvider.Acquire(ctx, lockKey, lock.DefaultLockTTL)
+	lk, err := s.lockProvider.Acquire(ctx, lockKey, lock.DefaultLockTTL)
 	if err != nil {
 		logger.Error("failed to acquire lock", applog.Error(err))
 		return nil, err
 	}
-	defer lock.Release(ctx)
+	defer lk.Release(ctx)
 
 	var bk *booking.Booking
 	// 使用事务，保证操作的原子性
@@ -269,13 +429,24 @@ func (s *bookingService) CancelBooking(ctx context.Context, req *request.CancelB
 			return err
 		}
 
+		if err = provider.GetFunnelEventRepository().Append(ctx, analytics.NewBookingEvent(analytics.EventBookingCancelled, bk)); err != nil {
+			logger.Error("failed to record booking cancelled event", applog.Error(err))
+			return err
+		}
+
+		// 退回订单抵扣的积分
+		if err = s.reverseLoyalty(ctx, provider, bk.ID); err != nil {
+			logger.Error("failed to restore loyalty points", applog.Error(err))
+			return err
+		}
+
 		if err = bookedSeatRepo.DeleteByBookingID(ctx, vo.BookingID(req.ID)); err != nil {
 			logger.Error("failed to delete booked seats", applog.Error(err))
 			return err
 		}
 
 		// 释放座位锁
-		if err = s.seatCache.ReleaseSeats(ctx, vo.ShowtimeID(req.ID), seatIDs); err != nil {
+		if err = s.seatCache.ReleaseSeats(ctx, bk.ShowtimeID, seatIDs); err != nil {
 			logger.Error("failed to release seats", applog.Error(err))
 			return err
 		}
@@ -286,15 +457,34 @@ func (s *bookingService) CancelBooking(ctx context.Context, req *request.CancelB
 		return nil, err
 	}
 
+	// 释放的座位可能解除相邻座位的社交距离封锁，与下单互斥后重建座位表
+	if stLock, err := s.lockProvider.Acquire(ctx, cinema.GetShowtimeSeatsLockKey(bk.ShowtimeID), lock.DefaultLockTTL); err == nil {
+		s.refreshDistancedSeatMap(ctx, bk.ShowtimeID)
+		stLock.Release(ctx)
+	} else {
+		logger.Warn("failed to acquire showtime lock, seat map will refresh on expiry", applog.Error(err))
+	}
+
 	logger.Info("cancel booking successfully", applog.String("status", string(bk.Status)))
 	return response.ToBookingResponse(bk), nil
 }
 
+// reverseLoyalty 冲销订单的积分流水（在事务中调用）
+func (s *bookingService) reverseLoyalty(ctx context.Context, provider shared.RepositoryProvider, bookingIDs ...vo.BookingID) error {
+	ledgerRepo := provider.GetLoyaltyLedgerRepository()
+	entries, err := ledgerRepo.FindByBookingIDs(ctx, bookingIDs)
+	if err != nil {
+		return err
+	}
+	return ledgerRepo.Append(ctx, loyalty.Reversals(entries)...)
+}
+
 // ConfirmBooking 确认订单（简单实现，后续需要对接支付系统）
 func (s *bookingService) ConfirmBooking(ctx context.Context, req *request.ConfirmBookingRequest) (*response.BookingResponse, error) {
 	logger := s.logger.With(applog.String("Method", "ConfirmBooking"))
 
 	var bk *booking.Booking
+	var movieID vo.MovieID
 	var err error
 	err = s.uow.Execute(ctx, func(ctx context.Context, provider shared.RepositoryProvider) error {
 		bookingRepo := provider.GetBookingRepository()
@@ -314,6 +504,30 @@ func (s *bookingService) ConfirmBooking(ctx context.Context, req *request.Confir
 			logger.Error("failed to update booking", applog.Error(err))
 			return err
 		}
+
+		// 同一事务中累加每日销售事实
+		st, err := provider.GetShowtimeRepository().FindByID(ctx, bk.ShowtimeID)
+		if err != nil {
+			logger.Error("failed to get showtime", applog.Error(err))
+			return err
+		}
+		if err := provider.GetDailySalesRepository().Add(ctx, booking.ConfirmedSales(bk, st.MovieID, st.CinemaHallID)); err != nil {
+			logger.Error("failed to add daily sales", applog.Error(err))
+			return err
+		}
+		if err := provider.GetFunnelEventRepository().Append(ctx, analytics.NewBookingEvent(analytics.EventBookingConfirmed, bk)); err != nil {
+			logger.Error("failed to record booking confirmed event", applog.Error(err))
+			return err
+		}
+
+		// 按实付金额获得积分
+		if points := s.loyaltyProgram.EarnPoints(bk.TotalAmount); points > 0 {
+			if err := provider.GetLoyaltyLedgerRepository().Append(ctx, loyalty.NewEarnEntry(bk, points)); err != nil {
+				logger.Error("failed to earn loyalty points", applog.Error(err))
+				return err
+			}
+		}
+		movieID = st.MovieID
 		return nil
 	})
 	if err != nil {
@@ -321,6 +535,35 @@ func (s *bookingService) ConfirmBooking(ctx context.Context, req *request.Confir
 		return nil, err
 	}
 
+	// 事务提交后累加热门排行计数器，失败时由定时重建修正
+	if err := s.trendingCache.Add(ctx, trending.ConfirmedSale(bk, movieID)); err != nil {
+		logger.Warn("failed to add trending counters", applog.Error(err))
+	}
+
 	logger.Info("confirm booking successfully", applog.String("status", string(bk.Status)))
 	return response.ToBookingResponse(bk), nil
 }
+
+// GetCheckIn 检票信息：展示订单的场次、座位与年龄要求，供检票员入场核验
+func (s *bookingService) GetCheckIn(ctx context.Context, req *request.GetCheckInRequest) (*response.CheckInResponse, error) {
+	logger := s.logger.With(applog.String("Method", "GetCheckIn"), applog.Uint("booking_id", req.ID))
+
+	bk, err := s.bookingRepo.FindByID(ctx, vo.BookingID(req.ID))
+	if err != nil {
+		if errors.Is(err, booking.ErrBookingNotFound) {
+			logger.Warn("booking not found")
+			return nil, err
+		}
+		logger.Error("failed to get booking", applog.Error(err))
+		return nil, err
+	}
+
+	st, err := s.showtimeService.GetShowtime(ctx, &request.GetShowtimeRequest{ID: uint(bk.ShowtimeID)})
+	if err != nil {
+		logger.Error("failed to get showtime", applog.Error(err))
+		return nil, err
+	}
+
+	logger.Info("get check-in info successfully", applog.String("age_check", string(bk.AgeCheck)))
+	return response.ToCheckInResponse(bk, st), nil
+}
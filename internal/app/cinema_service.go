@@ -9,39 +9,177 @@ import (
 	"mrs/internal/domain/cinema"
 	"mrs/internal/domain/shared"
 	"mrs/internal/domain/shared/vo"
+	"mrs/internal/domain/showtime"
 	applog "mrs/pkg/log"
+	"time"
 )
 
 type CinemaService interface {
+	CreateCinema(ctx context.Context, req *request.CreateCinemaRequest) (*response.CinemaResponse, error)
+	GetCinema(ctx context.Context, req *request.GetCinemaRequest) (*response.CinemaResponse, error)
+	ListAllCinemas(ctx context.Context) (*response.ListAllCinemasResponse, error)
+	UpdateCinema(ctx context.Context, req *request.UpdateCinemaRequest) (*response.CinemaResponse, error)
+	DeleteCinema(ctx context.Context, req *request.DeleteCinemaRequest) error
+
 	CreateCinemaHall(ctx context.Context, req *request.CreateCinemaHallRequest) (*response.CinemaHallResponse, error)
 	GetCinemaHall(ctx context.Context, req *request.GetCinemaHallRequest) (*response.CinemaHallResponse, error)
-	ListAllCinemaHalls(ctx context.Context) (*response.ListAllCinemaHallsResponse, error)
+	ListAllCinemaHalls(ctx context.Context, req *request.ListCinemaHallsRequest) (*response.ListAllCinemaHallsResponse, error)
 	UpdateCinemaHall(ctx context.Context, req *request.UpdateCinemaHallRequest) (*response.CinemaHallResponse, error)
 	DeleteCinemaHall(ctx context.Context, req *request.DeleteCinemaHallRequest) error
+
+	ImportCinemaHallLayout(ctx context.Context, req *request.ImportCinemaHallLayoutRequest) (*response.CinemaHallResponse, error)
+	ExportCinemaHallLayout(ctx context.Context, req *request.ExportCinemaHallLayoutRequest) (*response.CinemaHallLayoutFileResponse, error)
+	ListLayoutVersions(ctx context.Context, req *request.ListLayoutVersionsRequest) (*response.ListLayoutVersionsResponse, error)
+
+	CreateSeatRestrictions(ctx context.Context, req *request.CreateSeatRestrictionsRequest) (*response.ListSeatRestrictionsResponse, error)
+	ListSeatRestrictions(ctx context.Context, req *request.ListSeatRestrictionsRequest) (*response.ListSeatRestrictionsResponse, error)
+	DeleteSeatRestriction(ctx context.Context, req *request.DeleteSeatRestrictionRequest) error
+	UpdateDistancingPolicy(ctx context.Context, req *request.UpdateDistancingPolicyRequest) (*response.CinemaHallResponse, error)
+	DeleteDistancingPolicy(ctx context.Context, req *request.DeleteDistancingPolicyRequest) error
 }
 
 type cinemaService struct {
-	uow             shared.UnitOfWork
-	cinemaHallRepo  cinema.CinemaHallRepository
-	seatRepo        cinema.SeatRepository
-	cinemaHallCache cinema.CinemaHallCache
-	logger          applog.Logger
+	uow                 shared.UnitOfWork
+	cinemaRepo          cinema.CinemaRepository
+	cinemaHallRepo      cinema.CinemaHallRepository
+	seatRepo            cinema.SeatRepository
+	seatRestrictionRepo cinema.SeatRestrictionRepository
+	layoutVersionRepo   cinema.HallLayoutVersionRepository
+	cinemaHallCache     cinema.CinemaHallCache
+	seatCache           cinema.SeatCache
+	logger              applog.Logger
 }
 
 func NewCinemaService(
 	uow shared.UnitOfWork,
+	cinemaRepo cinema.CinemaRepository,
 	cinemaHallRepo cinema.CinemaHallRepository,
 	seatRepo cinema.SeatRepository,
+	seatRestrictionRepo cinema.SeatRestrictionRepository,
+	layoutVersionRepo cinema.HallLayoutVersionRepository,
 	cinemaHallCache cinema.CinemaHallCache,
+	seatCache cinema.SeatCache,
 	logger applog.Logger,
 ) CinemaService {
 	return &cinemaService{
-		uow:             uow,
-		cinemaHallRepo:  cinemaHallRepo,
-		seatRepo:        seatRepo,
-		cinemaHallCache: cinemaHallCache,
-		logger:          logger.With(applog.String("Service", "CinemaHallService")),
+		uow:                 uow,
+		cinemaRepo:          cinemaRepo,
+		cinemaHallRepo:      cinemaHallRepo,
+		seatRepo:            seatRepo,
+		seatRestrictionRepo: seatRestrictionRepo,
+		layoutVersionRepo:   layoutVersionRepo,
+		cinemaHallCache:     cinemaHallCache,
+		seatCache:           seatCache,
+		logger:              logger.With(applog.String("Service", "CinemaHallService")),
+	}
+}
+
+// 创建影院
+func (s *cinemaService) CreateCinema(ctx context.Context, req *request.CreateCinemaRequest) (*response.CinemaResponse, error) {
+	logger := s.logger.With(applog.String("Method", "CreateCinema"), applog.String("cinema_name", req.Name))
+
+	c := req.ToDomain()
+	if err := c.Validate(); err != nil {
+		logger.Warn("invalid cinema", applog.Error(err))
+		return nil, err
+	}
+
+	c, err := s.cinemaRepo.Create(ctx, c)
+	if err != nil {
+		logger.Error("failed to create cinema", applog.Error(err))
+		return nil, err
 	}
+
+	logger.Info("create cinema successfully", applog.Uint("cinema_id", uint(c.ID)))
+	return response.ToCinemaResponse(c, []*cinema.CinemaHall{}), nil
+}
+
+// 获取影院（包含影院下的影厅列表）
+func (s *cinemaService) GetCinema(ctx context.Context, req *request.GetCinemaRequest) (*response.CinemaResponse, error) {
+	logger := s.logger.With(applog.String("Method", "GetCinema"), applog.Uint("cinema_id", req.ID))
+
+	c, err := s.cinemaRepo.FindByID(ctx, vo.CinemaID(req.ID))
+	if err != nil {
+		logger.Warn("failed to get cinema", applog.Error(err))
+		return nil, err
+	}
+
+	hallsResp, err := s.ListAllCinemaHalls(ctx, &request.ListCinemaHallsRequest{CinemaID: req.ID})
+	if err != nil {
+		logger.Error("failed to list cinema halls", applog.Error(err))
+		return nil, err
+	}
+
+	resp := response.ToCinemaResponse(c, nil)
+	resp.CinemaHalls = hallsResp.CinemaHalls
+	logger.Info("get cinema successfully")
+	return resp, nil
+}
+
+// 获取所有影院
+func (s *cinemaService) ListAllCinemas(ctx context.Context) (*response.ListAllCinemasResponse, error) {
+	logger := s.logger.With(applog.String("Method", "ListAllCinemas"))
+
+	cinemas, err := s.cinemaRepo.ListAll(ctx)
+	if err != nil {
+		logger.Error("failed to list all cinemas", applog.Error(err))
+		return nil, err
+	}
+
+	logger.Info("list all cinemas successfully", applog.Int("cinema_count", len(cinemas)))
+	return response.ToListAllCinemasResponse(cinemas), nil
+}
+
+// 更新影院
+func (s *cinemaService) UpdateCinema(ctx context.Context, req *request.UpdateCinemaRequest) (*response.CinemaResponse, error) {
+	logger := s.logger.With(applog.String("Method", "UpdateCinema"), applog.Uint("cinema_id", req.ID))
+
+	c := req.ToDomain()
+	if err := c.Validate(); err != nil {
+		logger.Warn("invalid cinema", applog.Error(err))
+		return nil, err
+	}
+
+	if err := s.cinemaRepo.Update(ctx, c); err != nil {
+		logger.Warn("failed to update cinema", applog.Error(err))
+		return nil, err
+	}
+
+	// 更新操作响应报文需要包含完整内容
+	resp, err := s.GetCinema(ctx, &request.GetCinemaRequest{ID: req.ID})
+	if err != nil {
+		logger.Error("failed to get cinema", applog.Error(err))
+		return nil, err
+	}
+
+	logger.Info("update cinema successfully")
+	return resp, nil
+}
+
+// 删除影院（影院下仍有影厅时禁止删除）
+func (s *cinemaService) DeleteCinema(ctx context.Context, req *request.DeleteCinemaRequest) error {
+	logger := s.logger.With(applog.String("Method", "DeleteCinema"), applog.Uint("cinema_id", req.ID))
+
+	err := s.uow.Execute(ctx, func(ctx context.Context, provider shared.RepositoryProvider) error {
+		cinemaRepo := provider.GetCinemaRepository()
+		hallCount, err := cinemaRepo.CountHalls(ctx, vo.CinemaID(req.ID))
+		if err != nil {
+			logger.Error("failed to count cinema halls", applog.Error(err))
+			return err
+		}
+		if hallCount > 0 {
+			logger.Warn("cinema still has cinema halls", applog.Int64("hall_count", hallCount))
+			return fmt.Errorf("ServiceError: %w", cinema.ErrCinemaReferenced)
+		}
+		return cinemaRepo.Delete(ctx, vo.CinemaID(req.ID))
+	})
+	if err != nil {
+		logger.Warn("failed to delete cinema", applog.Error(err))
+		return err
+	}
+
+	logger.Info("delete cinema successfully")
+	return nil
 }
 
 // 创建影厅
@@ -49,9 +187,32 @@ func (s *cinemaService) CreateCinemaHall(ctx context.Context, req *request.Creat
 	logger := s.logger.With(applog.String("Method", "CreateCinemaHall"), applog.String("cinema_hall_name", req.Name))
 
 	cinemaHall := req.ToDomain()
+	// 补全布局与座位，并同步影厅行列数
+	if err := cinemaHall.PrepareLayout(); err != nil {
+		logger.Warn("invalid cinema hall layout", applog.Error(err))
+		return nil, err
+	}
 
 	// 创建影厅时，需要创建座位，所以需要使用事务
 	err := s.uow.Execute(ctx, func(ctx context.Context, provider shared.RepositoryProvider) error {
+		// 指定了所属影院时，需要确认影院存在
+		if cinemaHall.CinemaID > 0 {
+			if _, err := provider.GetCinemaRepository().FindByID(ctx, cinemaHall.CinemaID); err != nil {
+				logger.Warn("cinema of hall not found", applog.Error(err))
+				return err
+			}
+		}
+		// 唯一索引不约束未归属影院的影厅，需要显式检查同名影厅
+		exists, err := provider.GetCinemaHallRepository().ExistsByName(ctx, cinemaHall.CinemaID, cinemaHall.Name, 0)
+		if err != nil {
+			logger.Error("failed to check cinema hall name", applog.Error(err))
+			return err
+		}
+		if exists {
+			logger.Warn("cinema hall already exists")
+			return cinema.ErrCinemaHallAlreadyExists
+		}
+
 		// 创建影厅，得到创建后的影厅ID
 		createdCinemaHall, err := provider.GetCinemaHallRepository().Create(ctx, cinemaHall)
 		if err != nil {
@@ -63,13 +224,8 @@ func (s *cinemaService) CreateCinemaHall(ctx context.Context, req *request.Creat
 			return err
 		}
 
-		// 如果请求中没有座位，则生成默认座位
-		if len(cinemaHall.Seats) == 0 {
-			cinemaHall.Seats = cinema.GenerateDefaultSeats(createdCinemaHall.ID)
-		} else {
-			for i := range cinemaHall.Seats {
-				cinemaHall.Seats[i].CinemaHallID = createdCinemaHall.ID
-			}
+		for i := range cinemaHall.Seats {
+			cinemaHall.Seats[i].CinemaHallID = createdCinemaHall.ID
 		}
 		cinemaHall.ID = createdCinemaHall.ID
 
@@ -79,6 +235,12 @@ func (s *cinemaService) CreateCinemaHall(ctx context.Context, req *request.Creat
 			return err
 		}
 		cinemaHall.Seats = seats
+
+		// 记录初始布局版本
+		if _, err := provider.GetHallLayoutVersionRepository().Create(ctx, cinemaHall.LayoutSnapshot()); err != nil {
+			logger.Error("failed to create layout version", applog.Error(err))
+			return err
+		}
 		return nil
 	})
 	if err != nil {
@@ -126,16 +288,16 @@ func (s *cinemaService) GetCinemaHall(ctx context.Context, req *request.GetCinem
 	return response.ToCinemaHallResponse(cinemaHall), nil
 }
 
-// 获取所有影厅
-func (s *cinemaService) ListAllCinemaHalls(ctx context.Context) (*response.ListAllCinemaHallsResponse, error) {
-	logger := s.logger.With(applog.String("Method", "ListAllCinemaHalls"))
+// 获取所有影厅（可按影院筛选）
+func (s *cinemaService) ListAllCinemaHalls(ctx context.Context, req *request.ListCinemaHallsRequest) (*response.ListAllCinemaHallsResponse, error) {
+	logger := s.logger.With(applog.String("Method", "ListAllCinemaHalls"), applog.Uint("cinema_id", req.CinemaID))
 
 	listResult, err := s.cinemaHallCache.GetAllCinemaHalls(ctx)
 	// 只有当列表缓存命中且所有影厅都存在时，才返回缓存数据
 	// 否则再次调用仓库层获取所有影厅并写入缓存
 	if err == nil && len(listResult.MissingCinemaHallIDs) == 0 {
 		logger.Info("get all cinema halls from cache successfully", applog.Int("cinema_hall_count", len(listResult.CinemaHalls)))
-		return response.ToListAllCinemaHallsResponse(listResult.CinemaHalls), nil
+		return response.ToListAllCinemaHallsResponse(filterHallsByCinema(listResult.CinemaHalls, vo.CinemaID(req.CinemaID))), nil
 	}
 
 	logger.Warn("cinema hall list not found in cache", applog.Error(err))
@@ -153,7 +315,21 @@ func (s *cinemaService) ListAllCinemaHalls(ctx context.Context) (*response.ListA
 	}
 
 	logger.Info("list all cinema halls successfully", applog.Int("cinema_hall_count", len(cinemaHalls)))
-	return response.ToListAllCinemaHallsResponse(cinemaHalls), nil
+	return response.ToListAllCinemaHallsResponse(filterHallsByCinema(cinemaHalls, vo.CinemaID(req.CinemaID))), nil
+}
+
+// 影厅数量有限，全量列表已被缓存，按影院筛选直接在内存中进行
+func filterHallsByCinema(halls []*cinema.CinemaHall, cinemaID vo.CinemaID) []*cinema.CinemaHall {
+	if cinemaID == 0 {
+		return halls
+	}
+	filtered := make([]*cinema.CinemaHall, 0, len(halls))
+	for _, hall := range halls {
+		if hall.CinemaID == cinemaID {
+			filtered = append(filtered, hall)
+		}
+	}
+	return filtered
 }
 
 // 更新影厅
@@ -161,12 +337,48 @@ func (s *cinemaService) UpdateCinemaHall(ctx context.Context, req *request.Updat
 	logger := s.logger.With(applog.String("Method", "UpdateCinemaHall"), applog.Uint("cinema_hall_id", req.ID))
 
 	cinemaHall := req.ToDomain()
+	// 调整所属影院时，需要确认影院存在
+	if cinemaHall.CinemaID > 0 {
+		if _, err := s.cinemaRepo.FindByID(ctx, cinemaHall.CinemaID); err != nil {
+			logger.Warn("cinema of hall not found", applog.Error(err))
+			return nil, err
+		}
+	}
+	// 调整名称或所属影院时，检查目标影院内是否已有同名影厅（未归属影院的影厅不受唯一索引约束）
+	if cinemaHall.Name != "" || cinemaHall.CinemaID > 0 {
+		current, err := s.cinemaHallRepo.FindByID(ctx, cinemaHall.ID)
+		if err != nil {
+			logger.Warn("failed to get cinema hall", applog.Error(err))
+			return nil, err
+		}
+		cinemaID, name := current.CinemaID, current.Name
+		if cinemaHall.CinemaID > 0 {
+			cinemaID = cinemaHall.CinemaID
+		}
+		if cinemaHall.Name != "" {
+			name = cinemaHall.Name
+		}
+		exists, err := s.cinemaHallRepo.ExistsByName(ctx, cinemaID, name, cinemaHall.ID)
+		if err != nil {
+			logger.Error("failed to check cinema hall name", applog.Error(err))
+			return nil, err
+		}
+		if exists {
+			logger.Warn("cinema hall already exists")
+			return nil, cinema.ErrCinemaHallAlreadyExists
+		}
+	}
+
 	// 影厅更新只涉及单条记录，不需要事务
 	if err := s.cinemaHallRepo.Update(ctx, cinemaHall); err != nil {
 		if errors.Is(err, cinema.ErrCinemaHallNotFound) {
 			logger.Warn("cinema hall not found")
 			return nil, err
 		}
+		if errors.Is(err, cinema.ErrCinemaHallAlreadyExists) {
+			logger.Warn("cinema hall already exists", applog.Error(err))
+			return nil, err
+		}
 		logger.Error("failed to update cinema hall", applog.Error(err))
 		return nil, err
 	}
@@ -223,3 +435,382 @@ func (s *cinemaService) DeleteCinemaHall(ctx context.Context, req *request.Delet
 	logger.Info("delete cinema hall successfully", applog.Uint("cinema_hall_id", req.ID))
 	return nil
 }
+
+// 导入影厅布局：解析并校验布局文件，以新的布局与座位生成影厅的新布局版本
+// 已有场次仍固定在旧版本上，需要通过场次布局迁移切换到新版本
+func (s *cinemaService) ImportCinemaHallLayout(ctx context.Context, req *request.ImportCinemaHallLayoutRequest) (*response.CinemaHallResponse, error) {
+	logger := s.logger.With(applog.String("Method", "ImportCinemaHallLayout"),
+		applog.Uint("cinema_hall_id", req.ID), applog.String("format", req.Format))
+
+	format := cinema.LayoutFormatASCII
+	if req.Format != "" {
+		f, err := cinema.ParseLayoutFormat(req.Format)
+		if err != nil {
+			logger.Warn("invalid layout format", applog.Error(err))
+			return nil, err
+		}
+		format = f
+	}
+
+	layout, seats, err := cinema.DecodeLayout(format, req.Data)
+	if err != nil {
+		logger.Warn("invalid layout file", applog.Error(err))
+		return nil, err
+	}
+
+	var cinemaHall *cinema.CinemaHall
+	err = s.uow.Execute(ctx, func(ctx context.Context, provider shared.RepositoryProvider) error {
+		cinemaHall, err = provider.GetCinemaHallRepository().FindByID(ctx, vo.CinemaHallID(req.ID))
+		if err != nil {
+			logger.Warn("cinema hall not found", applog.Error(err))
+			return err
+		}
+		previous := cinemaHall.LayoutSnapshot()
+		previousSeats := cinemaHall.Seats
+		next := cinemaHall.ReplaceLayout(layout, seats)
+		if req.DryRun {
+			return nil
+		}
+
+		versionRepo := provider.GetHallLayoutVersionRepository()
+		// 历史影厅没有版本记录，替换前先为当前布局补一份快照
+		if _, err := versionRepo.FindByHallAndVersion(ctx, cinemaHall.ID, previous.Version); err != nil {
+			if !errors.Is(err, cinema.ErrLayoutVersionNotFound) {
+				logger.Error("failed to find current layout version", applog.Error(err))
+				return err
+			}
+			if _, err := versionRepo.Create(ctx, previous); err != nil {
+				logger.Error("failed to snapshot current layout version", applog.Error(err))
+				return err
+			}
+		}
+
+		// 旧版本座位软删除保留，已固定旧版本的场次仍按旧座位售卖
+		if err := provider.GetSeatRepository().DeleteByHallID(ctx, cinemaHall.ID); err != nil && !errors.Is(err, shared.ErrNoRowsAffected) {
+			logger.Error("failed to retire seats", applog.Error(err))
+			return err
+		}
+		created, err := provider.GetSeatRepository().CreateBatch(ctx, seats)
+		if err != nil {
+			logger.Error("failed to create seats", applog.Error(err))
+			return err
+		}
+		cinemaHall.Seats = created
+
+		if err := provider.GetCinemaHallRepository().Update(ctx, cinemaHall); err != nil {
+			logger.Error("failed to update cinema hall layout", applog.Error(err))
+			return err
+		}
+		if _, err := versionRepo.Create(ctx, next); err != nil {
+			logger.Error("failed to create layout version", applog.Error(err))
+			return err
+		}
+
+		// 座位限制按排号+座位号延续到新版本
+		return carryOverSeatRestrictions(ctx, provider, cinemaHall.ID, previousSeats, created)
+	})
+	if err != nil {
+		logger.Error("failed to import cinema hall layout", applog.Error(err))
+		return nil, err
+	}
+	if req.DryRun {
+		logger.Info("validate cinema hall layout successfully", applog.Int("seat_count", len(seats)))
+		return response.ToCinemaHallResponse(cinemaHall), nil
+	}
+
+	if err := s.cinemaHallCache.DeleteCinemaHall(ctx, cinemaHall.ID); err != nil {
+		logger.Warn("failed to delete cinema hall from cache", applog.Error(err))
+	}
+
+	logger.Info("import cinema hall layout successfully",
+		applog.Int("seat_count", len(cinemaHall.Seats)), applog.Int("layout_version", cinemaHall.LayoutVersion))
+	return response.ToCinemaHallResponse(cinemaHall), nil
+}
+
+// 导出影厅布局
+func (s *cinemaService) ExportCinemaHallLayout(ctx context.Context, req *request.ExportCinemaHallLayoutRequest) (*response.CinemaHallLayoutFileResponse, error) {
+	logger := s.logger.With(applog.String("Method", "ExportCinemaHallLayout"),
+		applog.Uint("cinema_hall_id", req.ID), applog.String("format", req.Format))
+
+	format := cinema.LayoutFormatASCII
+	if req.Format != "" {
+		f, err := cinema.ParseLayoutFormat(req.Format)
+		if err != nil {
+			logger.Warn("invalid layout format", applog.Error(err))
+			return nil, err
+		}
+		format = f
+	}
+
+	cinemaHall, err := s.cinemaHallRepo.FindByID(ctx, vo.CinemaHallID(req.ID))
+	if err != nil {
+		logger.Warn("failed to get cinema hall", applog.Error(err))
+		return nil, err
+	}
+	// 历史影厅没有保存布局，按排号/座位号推断
+	layout, seats := cinemaHall.Layout, cinemaHall.Seats
+	if layout == nil {
+		layout = cinema.InferLayout(seats)
+	}
+
+	// 导出指定的历史版本
+	version := cinemaHall.LayoutVersion
+	if req.Version > 0 && req.Version != cinemaHall.LayoutVersion {
+		version = req.Version
+		layoutVersion, err := s.layoutVersionRepo.FindByHallAndVersion(ctx, cinemaHall.ID, version)
+		if err != nil {
+			logger.Warn("failed to get layout version", applog.Error(err))
+			return nil, err
+		}
+		if seats, err = s.seatRepo.FindByHallAndVersion(ctx, cinemaHall.ID, version); err != nil {
+			logger.Error("failed to get seats of layout version", applog.Error(err))
+			return nil, err
+		}
+		layout = layoutVersion.Layout
+	}
+
+	content, err := cinema.EncodeLayout(format, layout, seats)
+	if err != nil {
+		logger.Error("failed to encode cinema hall layout", applog.Error(err))
+		return nil, err
+	}
+
+	logger.Info("export cinema hall layout successfully", applog.Int("size", len(content)))
+	return &response.CinemaHallLayoutFileResponse{
+		Filename:    fmt.Sprintf("cinema-hall-%d-layout-v%d.%s", cinemaHall.ID, version, format.Extension()),
+		ContentType: format.ContentType(),
+		Content:     content,
+	}, nil
+}
+
+// 查询影厅的布局版本
+func (s *cinemaService) ListLayoutVersions(ctx context.Context, req *request.ListLayoutVersionsRequest) (*response.ListLayoutVersionsResponse, error) {
+	logger := s.logger.With(applog.String("Method", "ListLayoutVersions"), applog.Uint("cinema_hall_id", req.ID))
+
+	cinemaHall, err := s.cinemaHallRepo.FindByID(ctx, vo.CinemaHallID(req.ID))
+	if err != nil {
+		logger.Warn("failed to get cinema hall", applog.Error(err))
+		return nil, err
+	}
+
+	versions, err := s.layoutVersionRepo.ListByHallID(ctx, cinemaHall.ID)
+	if err != nil {
+		logger.Error("failed to list layout versions", applog.Error(err))
+		return nil, err
+	}
+	// 历史影厅没有版本记录时，返回当前布局
+	if len(versions) == 0 {
+		versions = []*cinema.HallLayoutVersion{cinemaHall.LayoutSnapshot()}
+	}
+
+	logger.Info("list layout versions successfully", applog.Int("count", len(versions)))
+	return response.ToListLayoutVersionsResponse(cinemaHall.LayoutVersion, versions), nil
+}
+
+// 将旧版本座位上的限制复制到新版本中排号+座位号相同的座位
+func carryOverSeatRestrictions(ctx context.Context, provider shared.RepositoryProvider,
+	hallID vo.CinemaHallID, from, to []*cinema.Seat) error {
+	restrictionRepo := provider.GetSeatRestrictionRepository()
+	restrictions, err := restrictionRepo.ListByHallID(ctx, hallID)
+	if err != nil {
+		return err
+	}
+
+	matched := cinema.MatchSeats(from, to)
+	now := time.Now()
+	carried := make([]*cinema.SeatRestriction, 0, len(restrictions))
+	for _, r := range restrictions {
+		target, ok := matched[r.SeatID]
+		if !ok || (r.EndsAt != nil && !r.EndsAt.After(now)) {
+			continue
+		}
+		copied := *r
+		copied.ID = 0
+		copied.SeatID = target.ID
+		carried = append(carried, &copied)
+	}
+	if len(carried) == 0 {
+		return nil
+	}
+	_, err = restrictionRepo.CreateBatch(ctx, carried)
+	return err
+}
+
+// 为影厅座位创建限制（停用/保留/封锁）
+func (s *cinemaService) CreateSeatRestrictions(ctx context.Context, req *request.CreateSeatRestrictionsRequest) (*response.ListSeatRestrictionsResponse, error) {
+	logger := s.logger.With(applog.String("Method", "CreateSeatRestrictions"),
+		applog.Uint("cinema_hall_id", req.CinemaHallID), applog.String("kind", req.Kind))
+
+	restrictions := req.ToDomain()
+	for _, r := range restrictions {
+		if err := r.Validate(); err != nil {
+			logger.Warn("invalid seat restriction", applog.Error(err))
+			return nil, err
+		}
+	}
+
+	var created []*cinema.SeatRestriction
+	err := s.uow.Execute(ctx, func(ctx context.Context, provider shared.RepositoryProvider) error {
+		hall, err := provider.GetCinemaHallRepository().FindByID(ctx, vo.CinemaHallID(req.CinemaHallID))
+		if err != nil {
+			logger.Warn("cinema hall not found", applog.Error(err))
+			return err
+		}
+
+		// 座位必须属于该影厅
+		hallSeats := make(map[vo.SeatID]bool, len(hall.Seats))
+		for _, seat := range hall.Seats {
+			hallSeats[seat.ID] = true
+		}
+		for _, r := range restrictions {
+			if !hallSeats[r.SeatID] {
+				logger.Warn("seat does not belong to cinema hall", applog.Uint("seat_id", uint(r.SeatID)))
+				return fmt.Errorf("%w: seat %d does not belong to hall %d", cinema.ErrInvalidSeatRestriction, r.SeatID, hall.ID)
+			}
+		}
+
+		created, err = provider.GetSeatRestrictionRepository().CreateBatch(ctx, restrictions)
+		if err != nil {
+			logger.Error("failed to create seat restrictions", applog.Error(err))
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("failed to create seat restrictions", applog.Error(err))
+		return nil, err
+	}
+
+	s.invalidateHallSeatMaps(ctx, vo.CinemaHallID(req.CinemaHallID))
+
+	logger.Info("create seat restrictions successfully", applog.Int("count", len(created)))
+	return response.ToListSeatRestrictionsResponse(created), nil
+}
+
+// 查询影厅的座位限制
+func (s *cinemaService) ListSeatRestrictions(ctx context.Context, req *request.ListSeatRestrictionsRequest) (*response.ListSeatRestrictionsResponse, error) {
+	logger := s.logger.With(applog.String("Method", "ListSeatRestrictions"), applog.Uint("cinema_hall_id", req.CinemaHallID))
+
+	if _, err := s.cinemaHallRepo.FindByID(ctx, vo.CinemaHallID(req.CinemaHallID)); err != nil {
+		logger.Warn("cinema hall not found", applog.Error(err))
+		return nil, err
+	}
+
+	restrictions, err := s.seatRestrictionRepo.ListByHallID(ctx, vo.CinemaHallID(req.CinemaHallID))
+	if err != nil {
+		logger.Error("failed to list seat restrictions", applog.Error(err))
+		return nil, err
+	}
+
+	if req.ActiveOnly {
+		now := time.Now()
+		active := make([]*cinema.SeatRestriction, 0, len(restrictions))
+		for _, r := range restrictions {
+			if r.EndsAt == nil || r.EndsAt.After(now) {
+				active = append(active, r)
+			}
+		}
+		restrictions = active
+	}
+
+	logger.Info("list seat restrictions successfully", applog.Int("count", len(restrictions)))
+	return response.ToListSeatRestrictionsResponse(restrictions), nil
+}
+
+// 删除座位限制
+func (s *cinemaService) DeleteSeatRestriction(ctx context.Context, req *request.DeleteSeatRestrictionRequest) error {
+	logger := s.logger.With(applog.String("Method", "DeleteSeatRestriction"),
+		applog.Uint("cinema_hall_id", req.CinemaHallID), applog.Uint("restriction_id", req.ID))
+
+	restriction, err := s.seatRestrictionRepo.FindByID(ctx, vo.SeatRestrictionID(req.ID))
+	if err != nil {
+		logger.Warn("seat restriction not found", applog.Error(err))
+		return err
+	}
+	if restriction.CinemaHallID != vo.CinemaHallID(req.CinemaHallID) {
+		logger.Warn("seat restriction does not belong to cinema hall")
+		return fmt.Errorf("%w(id): %v", cinema.ErrSeatRestrictionNotFound, req.ID)
+	}
+
+	if err := s.seatRestrictionRepo.Delete(ctx, restriction.ID); err != nil {
+		logger.Error("failed to delete seat restriction", applog.Error(err))
+		return err
+	}
+
+	s.invalidateHallSeatMaps(ctx, restriction.CinemaHallID)
+
+	logger.Info("delete seat restriction successfully")
+	return nil
+}
+
+// 设置影厅的社交距离策略
+func (s *cinemaService) UpdateDistancingPolicy(ctx context.Context, req *request.UpdateDistancingPolicyRequest) (*response.CinemaHallResponse, error) {
+	logger := s.logger.With(applog.String("Method", "UpdateDistancingPolicy"), applog.Uint("cinema_hall_id", req.CinemaHallID))
+
+	policy := req.ToDomain()
+	if err := policy.Validate(); err != nil {
+		logger.Warn("invalid distancing policy", applog.Error(err))
+		return nil, err
+	}
+
+	if err := s.cinemaHallRepo.UpdateDistancingPolicy(ctx, vo.CinemaHallID(req.CinemaHallID), policy); err != nil {
+		logger.Error("failed to update distancing policy", applog.Error(err))
+		return nil, err
+	}
+	hall, err := s.cinemaHallRepo.FindByID(ctx, vo.CinemaHallID(req.CinemaHallID))
+	if err != nil {
+		logger.Error("failed to find cinema hall", applog.Error(err))
+		return nil, err
+	}
+
+	if err := s.cinemaHallCache.DeleteCinemaHall(ctx, hall.ID); err != nil {
+		logger.Warn("failed to delete cinema hall from cache", applog.Error(err))
+	}
+	s.invalidateHallSeatMaps(ctx, hall.ID)
+
+	logger.Info("update distancing policy successfully")
+	return response.ToCinemaHallResponse(hall), nil
+}
+
+// 清除影厅的社交距离策略
+func (s *cinemaService) DeleteDistancingPolicy(ctx context.Context, req *request.DeleteDistancingPolicyRequest) error {
+	logger := s.logger.With(applog.String("Method", "DeleteDistancingPolicy"), applog.Uint("cinema_hall_id", req.CinemaHallID))
+
+	if err := s.cinemaHallRepo.UpdateDistancingPolicy(ctx, vo.CinemaHallID(req.CinemaHallID), nil); err != nil {
+		logger.Error("failed to delete distancing policy", applog.Error(err))
+		return err
+	}
+
+	if err := s.cinemaHallCache.DeleteCinemaHall(ctx, vo.CinemaHallID(req.CinemaHallID)); err != nil {
+		logger.Warn("failed to delete cinema hall from cache", applog.Error(err))
+	}
+	s.invalidateHallSeatMaps(ctx, vo.CinemaHallID(req.CinemaHallID))
+
+	logger.Info("delete distancing policy successfully")
+	return nil
+}
+
+// 失效影厅未结束场次的座位表，下次访问时按最新的座位限制重新初始化
+func (s *cinemaService) invalidateHallSeatMaps(ctx context.Context, hallID vo.CinemaHallID) {
+	logger := s.logger.With(applog.String("Method", "invalidateHallSeatMaps"), applog.Uint("cinema_hall_id", uint(hallID)))
+
+	// 座位表最长保留到场次结束后，向前多取一天以覆盖正在放映的场次
+	now := time.Now()
+	var showtimes []*showtime.Showtime
+	err := s.uow.Execute(ctx, func(ctx context.Context, provider shared.RepositoryProvider) error {
+		var err error
+		showtimes, err = provider.GetShowtimeRepository().FindShowtimesByHallAndDateRanges(ctx, hallID, now.AddDate(0, 0, -1), now.AddDate(1, 0, 0))
+		return err
+	})
+	if err != nil {
+		logger.Warn("failed to find showtimes of hall", applog.Error(err))
+		return
+	}
+
+	for _, st := range showtimes {
+		if err := s.seatCache.InvalidateSeatMap(ctx, st.ID); err != nil {
+			logger.Warn("failed to invalidate seat map", applog.Uint("showtime_id", uint(st.ID)), applog.Error(err))
+		}
+	}
+	logger.Info("invalidate seat maps of hall successfully", applog.Int("count", len(showtimes)))
+}
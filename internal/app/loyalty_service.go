@@ -0,0 +1,71 @@
+package app
+
+import (
+	"context"
+	"math"
+	"mrs/internal/api/dto/request"
+	"mrs/internal/api/dto/response"
+	"mrs/internal/domain/loyalty"
+	"mrs/internal/domain/shared/vo"
+	applog "mrs/pkg/log"
+)
+
+// 积分的获得、抵扣与冲销在订单与场次服务的事务中完成，这里只提供查询
+type LoyaltyService interface {
+	GetLoyaltyAccount(ctx context.Context, req *request.GetLoyaltyAccountRequest) (*response.LoyaltyAccountResponse, error)
+	ListLoyaltyLedger(ctx context.Context, req *request.ListLoyaltyLedgerRequest) (*response.PaginatedLoyaltyLedgerResponse, error)
+}
+
+type loyaltyService struct {
+	ledgerRepo loyalty.LedgerRepository
+	program    *loyalty.Program
+	logger     applog.Logger
+}
+
+func NewLoyaltyService(ledgerRepo loyalty.LedgerRepository, program *loyalty.Program, logger applog.Logger) LoyaltyService {
+	return &loyaltyService{
+		ledgerRepo: ledgerRepo,
+		program:    program,
+		logger:     logger.With(applog.String("Service", "LoyaltyService")),
+	}
+}
+
+func (s *loyaltyService) GetLoyaltyAccount(ctx context.Context, req *request.GetLoyaltyAccountRequest) (*response.LoyaltyAccountResponse, error) {
+	logger := s.logger.With(applog.String("Method", "GetLoyaltyAccount"), applog.Uint("user_id", req.UserID))
+
+	account, err := s.ledgerRepo.GetAccount(ctx, vo.UserID(req.UserID))
+	if err != nil {
+		logger.Error("failed to get loyalty account", applog.Error(err))
+		return nil, err
+	}
+
+	resp := response.ToLoyaltyAccountResponse(account, s.program)
+	logger.Info("get loyalty account successfully", applog.Int("balance", account.Balance), applog.String("tier", resp.Tier.Name))
+	return resp, nil
+}
+
+func (s *loyaltyService) ListLoyaltyLedger(ctx context.Context, req *request.ListLoyaltyLedgerRequest) (*response.PaginatedLoyaltyLedgerResponse, error) {
+	logger := s.logger.With(applog.String("Method", "ListLoyaltyLedger"), applog.Uint("user_id", req.UserID))
+
+	entries, total, err := s.ledgerRepo.ListByUser(ctx, vo.UserID(req.UserID), req.Page, req.PageSize)
+	if err != nil {
+		logger.Error("failed to list loyalty entries", applog.Error(err))
+		return nil, err
+	}
+
+	entryResponses := make([]*response.LoyaltyEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		entryResponses = append(entryResponses, response.ToLoyaltyEntryResponse(entry))
+	}
+
+	logger.Info("list loyalty ledger successfully", applog.Int64("total", total))
+	return &response.PaginatedLoyaltyLedgerResponse{
+		Pagination: response.PaginationResponse{
+			Page:       req.Page,
+			PageSize:   req.PageSize,
+			TotalCount: int(total),
+			TotalPages: int(math.Ceil(float64(total) / float64(req.PageSize))),
+		},
+		Entries: entryResponses,
+	}, nil
+}
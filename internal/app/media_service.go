@@ -0,0 +1,225 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mrs/internal/api/dto/request"
+	"mrs/internal/api/dto/response"
+	"mrs/internal/domain/movie"
+	"mrs/internal/domain/shared"
+	"mrs/internal/domain/shared/blob"
+	"mrs/internal/domain/shared/vo"
+	applog "mrs/pkg/log"
+)
+
+// 嗅探内容类型需要读取的文件头长度
+const sniffLength = 512
+
+type MediaService interface {
+	UploadMovieMedia(ctx context.Context, req *request.UploadMovieMediaRequest) (*response.MediaAssetResponse, error)
+	OpenMedia(ctx context.Context, req *request.GetMediaRequest) (*blob.Object, error)
+}
+
+type mediaService struct {
+	uow        shared.UnitOfWork
+	blobStore  blob.BlobStore
+	policy     *movie.MediaPolicy
+	movieCache movie.MovieCache
+	logger     applog.Logger
+}
+
+func NewMediaService(
+	uow shared.UnitOfWork,
+	blobStore blob.BlobStore,
+	policy *movie.MediaPolicy,
+	movieCache movie.MovieCache,
+	logger applog.Logger,
+) MediaService {
+	return &mediaService{
+		uow:        uow,
+		blobStore:  blobStore,
+		policy:     policy,
+		movieCache: movieCache,
+		logger:     logger.With(applog.String("Service", "MediaService")),
+	}
+}
+
+// 上传电影媒体文件：校验类型与大小，生成缩略图，以内容哈希命名写入存储，再替换电影的媒体记录
+func (s *mediaService) UploadMovieMedia(ctx context.Context, req *request.UploadMovieMediaRequest) (*response.MediaAssetResponse, error) {
+	logger := s.logger.With(applog.String("Method", "UploadMovieMedia"), applog.Uint("movie_id", req.MovieID),
+		applog.String("kind", string(req.Kind)), applog.String("filename", req.Filename), applog.Int64("size", req.Size))
+	movieID := vo.MovieID(req.MovieID)
+
+	// 处理文件前先确认电影存在（使用事务内未经熔断装饰的仓库，以区分不存在与繁忙）
+	err := s.uow.Execute(ctx, func(ctx context.Context, provider shared.RepositoryProvider) error {
+		_, err := provider.GetMovieRepository().FindByID(ctx, movieID)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, movie.ErrMovieNotFound) {
+			logger.Warn("movie not found")
+			return nil, err
+		}
+		logger.Error("failed to find movie", applog.Error(err))
+		return nil, err
+	}
+
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(req.Content, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		logger.Error("failed to read media content", applog.Error(err))
+		return nil, fmt.Errorf("read media content error: %w", err)
+	}
+	contentType, err := s.policy.Validate(req.Kind, head[:n], req.Size)
+	if err != nil {
+		logger.Warn("invalid media file", applog.Error(err))
+		return nil, err
+	}
+
+	hash, err := hashContent(req.Content)
+	if err != nil {
+		logger.Error("failed to hash media content", applog.Error(err))
+		return nil, err
+	}
+
+	asset := &movie.MediaAsset{
+		MovieID:     movieID,
+		Kind:        req.Kind,
+		ContentType: contentType,
+		Hash:        hash,
+		Size:        req.Size,
+	}
+
+	// 图片先解码校验，避免把无法处理的文件写入存储
+	if req.Kind.IsImage() {
+		if err := s.storeImageVariants(ctx, asset, req.Content); err != nil {
+			logger.Warn("failed to store image variants", applog.Error(err))
+			return nil, err
+		}
+	}
+
+	original := &movie.MediaVariant{
+		Name:   movie.MediaVariantOriginal,
+		Width:  asset.Width,
+		Height: asset.Height,
+		Key:    movie.MediaKey(req.Kind, hash, movie.MediaVariantOriginal, contentType),
+		Size:   req.Size,
+	}
+	original.URL = s.blobStore.URL(original.Key)
+	if _, err := req.Content.Seek(0, io.SeekStart); err != nil {
+		logger.Error("failed to seek media content", applog.Error(err))
+		return nil, fmt.Errorf("seek media content error: %w", err)
+	}
+	if err := s.blobStore.Put(ctx, original.Key, req.Content, req.Size, contentType); err != nil {
+		logger.Error("failed to store original media", applog.Error(err))
+		return nil, err
+	}
+	asset.Variants = append([]*movie.MediaVariant{original}, asset.Variants...)
+
+	// 替换记录；旧文件以内容哈希命名，可能被其他电影共用，因此保留在存储中
+	err = s.uow.Execute(ctx, func(ctx context.Context, provider shared.RepositoryProvider) error {
+		mediaRepo := provider.GetMediaAssetRepository()
+		if err := mediaRepo.DeleteByMovieAndKind(ctx, movieID, req.Kind); err != nil {
+			return err
+		}
+		created, err := mediaRepo.Create(ctx, asset)
+		if err != nil {
+			return err
+		}
+		asset = created
+
+		// 海报同步到电影的 poster_url，兼容只读取该字段的客户端
+		if req.Kind == movie.MediaKindPoster {
+			return provider.GetMovieRepository().Update(ctx, &movie.Movie{ID: movieID, PosterURL: original.URL})
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("failed to save media asset", applog.Error(err))
+		return nil, err
+	}
+
+	// 为保证缓存一致性，在进行写操作时，先更新数据库，再删除缓存
+	if err := s.movieCache.DeleteMovie(ctx, movieID); err != nil {
+		logger.Warn("failed to delete movie from cache", applog.Error(err))
+	}
+
+	logger.Info("upload movie media successfully", applog.Uint("media_asset_id", uint(asset.ID)),
+		applog.String("hash", hash), applog.Int("variants", len(asset.Variants)))
+	return response.ToMediaAssetResponse(asset), nil
+}
+
+// 解码图片并按策略中的宽度生成缩略图写入存储，不放大小于目标宽度的图片
+func (s *mediaService) storeImageVariants(ctx context.Context, asset *movie.MediaAsset, content io.ReadSeeker) error {
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("seek media content error: %w", err)
+	}
+	img, err := movie.DecodeMediaImage(content, asset.ContentType, s.policy.MaxImagePixels)
+	if err != nil {
+		return err
+	}
+	asset.Width, asset.Height = img.Bounds().Dx(), img.Bounds().Dy()
+
+	var buf bytes.Buffer
+	for _, width := range s.policy.Widths(asset.Kind) {
+		thumbnail, ok := movie.ResizeImage(img, width)
+		if !ok {
+			continue
+		}
+		buf.Reset()
+		if err := movie.EncodeMediaImage(&buf, thumbnail, asset.ContentType); err != nil {
+			return fmt.Errorf("encode thumbnail error: %w", err)
+		}
+
+		name := movie.MediaVariantName(width)
+		variant := &movie.MediaVariant{
+			Name:   name,
+			Width:  thumbnail.Bounds().Dx(),
+			Height: thumbnail.Bounds().Dy(),
+			Key:    movie.MediaKey(asset.Kind, asset.Hash, name, asset.ContentType),
+			Size:   int64(buf.Len()),
+		}
+		variant.URL = s.blobStore.URL(variant.Key)
+		if err := s.blobStore.Put(ctx, variant.Key, bytes.NewReader(buf.Bytes()), variant.Size, asset.ContentType); err != nil {
+			return err
+		}
+		asset.Variants = append(asset.Variants, variant)
+	}
+	return nil
+}
+
+// 读取存储中的媒体文件，调用方负责关闭 Body
+func (s *mediaService) OpenMedia(ctx context.Context, req *request.GetMediaRequest) (*blob.Object, error) {
+	logger := s.logger.With(applog.String("Method", "OpenMedia"), applog.String("key", req.Key))
+
+	object, err := s.blobStore.Get(ctx, req.Key)
+	if err != nil {
+		if errors.Is(err, blob.ErrBlobNotFound) || errors.Is(err, blob.ErrInvalidBlobKey) {
+			logger.Warn("media not found", applog.Error(err))
+			return nil, err
+		}
+		logger.Error("failed to open media", applog.Error(err))
+		return nil, err
+	}
+	return object, nil
+}
+
+// 计算内容的SHA-256，结束后回到文件开头
+func hashContent(content io.ReadSeeker) (string, error) {
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("seek media content error: %w", err)
+	}
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, content); err != nil {
+		return "", fmt.Errorf("hash media content error: %w", err)
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("seek media content error: %w", err)
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
@@ -3,6 +3,7 @@ package app
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math"
 	"mrs/internal/api/dto/request"
 	"mrs/internal/api/dto/response"
@@ -11,6 +12,8 @@ import (
 	"mrs/internal/domain/shared"
 	"mrs/internal/domain/shared/vo"
 	applog "mrs/pkg/log"
+	"sort"
+	"time"
 )
 
 type MovieService interface {
@@ -19,18 +22,30 @@ type MovieService interface {
 	GetMovie(ctx context.Context, req *request.GetMovieRequest) (*response.MovieResponse, error)
 	DeleteMovie(ctx context.Context, req *request.DeleteMovieRequest) error
 	ListMovies(ctx context.Context, req *request.ListMovieRequest) (*response.PaginatedMovieResponse, error)
+	SearchMovies(ctx context.Context, req *request.SearchMoviesRequest) (*response.SearchMoviesResponse, error)
+	ImportMovies(ctx context.Context, req *request.ImportMoviesRequest) (*response.ImportMoviesResponse, error)
+	ArchiveMovie(ctx context.Context, req *request.ArchiveMovieRequest) (*response.MovieResponse, error)
+	RestoreMovie(ctx context.Context, req *request.RestoreMovieRequest) (*response.MovieResponse, error)
+	// 按上映日期与场次推进电影生命周期状态（由定时任务调用）
+	RefreshMovieStatuses(ctx context.Context) error
 	CreateGenre(ctx context.Context, req *request.CreateGenreRequest) (*response.GenreResponse, error)
-	ListAllGenres(ctx context.Context) (*response.ListAllGenresResponse, error)
+	ListAllGenres(ctx context.Context, req *request.ListGenresRequest) (*response.ListAllGenresResponse, error)
 	UpdateGenre(ctx context.Context, req *request.UpdateGenreRequest) (*response.GenreResponse, error)
 	DeleteGenre(ctx context.Context, req *request.DeleteGenreRequest) error
+	UpsertMovieTranslation(ctx context.Context, req *request.UpsertMovieTranslationRequest) (*response.MovieTranslationResponse, error)
+	DeleteMovieTranslation(ctx context.Context, req *request.DeleteMovieTranslationRequest) error
+	UpsertGenreTranslation(ctx context.Context, req *request.UpsertGenreTranslationRequest) (*response.GenreTranslationResponse, error)
+	DeleteGenreTranslation(ctx context.Context, req *request.DeleteGenreTranslationRequest) error
 }
 
 type movieService struct {
-	uow        shared.UnitOfWork
-	movieRepo  movie.MovieRepository
-	genreRepo  movie.GenreRepository
-	movieCache movie.MovieCache
-	logger     applog.Logger
+	uow         shared.UnitOfWork
+	movieRepo   movie.MovieRepository
+	genreRepo   movie.GenreRepository
+	movieCache  movie.MovieCache
+	searchIndex movie.MovieSearchIndex
+	agePolicy   *movie.AgeRatingPolicy
+	logger      applog.Logger
 }
 
 func NewMovieService(
@@ -38,14 +53,18 @@ func NewMovieService(
 	movieRepo movie.MovieRepository,
 	genreRepo movie.GenreRepository,
 	movieCache movie.MovieCache,
+	searchIndex movie.MovieSearchIndex,
+	agePolicy *movie.AgeRatingPolicy,
 	logger applog.Logger,
 ) MovieService {
 	return &movieService{
-		uow:        uow,
-		movieRepo:  movieRepo,
-		genreRepo:  genreRepo,
-		movieCache: movieCache,
-		logger:     logger.With(applog.String("Service", "MovieService")),
+		uow:         uow,
+		movieRepo:   movieRepo,
+		genreRepo:   genreRepo,
+		movieCache:  movieCache,
+		searchIndex: searchIndex,
+		agePolicy:   agePolicy,
+		logger:      logger.With(applog.String("Service", "MovieService")),
 	}
 }
 
@@ -55,6 +74,12 @@ func (s *movieService) CreateMovie(ctx context.Context,
 	logger := s.logger.With(applog.String("Method", "CreateMovie"))
 
 	mv := req.ToMovie()
+	if err := s.agePolicy.ValidateRating(mv.AgeRating); err != nil {
+		logger.Warn("invalid age rating", applog.Error(err))
+		return nil, err
+	}
+	// 新电影总是从即将上映开始，之后由定时任务推进
+	mv.Status = movie.StatusComingSoon
 
 	// 开启事务
 	err := s.uow.Execute(ctx, func(ctx context.Context, provider shared.RepositoryProvider) error {
@@ -80,6 +105,21 @@ func (s *movieService) CreateMovie(ctx context.Context,
 			logger.Error("failed to replace genres for movie", applog.Error(err))
 			return err
 		}
+
+		// 关联演职员
+		if len(req.Credits) > 0 {
+			if err := s.replaceCredits(ctx, provider, mv, req.Credits); err != nil {
+				return err
+			}
+			// 未提供演员文本时，由演员姓名生成，保持 cast 字段与演职员一致
+			if req.Cast == "" && mv.ActorNames() != "" {
+				mv.Cast = mv.ActorNames()
+				if err := movieRepo.Update(ctx, &movie.Movie{ID: mv.ID, Cast: mv.Cast}); err != nil {
+					logger.Error("failed to update movie cast", applog.Error(err))
+					return err
+				}
+			}
+		}
 		return nil
 	})
 
@@ -88,6 +128,11 @@ func (s *movieService) CreateMovie(ctx context.Context,
 		return nil, err
 	}
 
+	// 搜索索引在事务提交后更新，失败不影响电影创建
+	if err := s.searchIndex.Index(ctx, mv); err != nil {
+		logger.Warn("failed to index movie", applog.Error(err))
+	}
+
 	logger.Info("create movie successfully", applog.Uint("movie_id", uint(mv.ID)))
 	return response.ToMovieResponse(mv), nil
 }
@@ -108,8 +153,13 @@ func (s *movieService) UpdateMovie(ctx context.Context, req *request.UpdateMovie
 		req.AgeRating == "" &&
 		req.Cast == "")
 
+	if err := s.agePolicy.ValidateRating(mv.AgeRating); err != nil {
+		logger.Warn("invalid age rating", applog.Error(err))
+		return nil, err
+	}
+
 	// 根据请求内容，存在是否更新类型字段与是否更新其他字段等四种情况
-	if !hasOtherUpdate && len(req.GenreNames) == 0 {
+	if !hasOtherUpdate && len(req.GenreNames) == 0 && req.Credits == nil {
 		logger.Info("no update")
 		return s.GetMovie(ctx, &request.GetMovieRequest{ID: uint(mv.ID)})
 	}
@@ -128,6 +178,17 @@ func (s *movieService) UpdateMovie(ctx context.Context, req *request.UpdateMovie
 			return err
 		}
 
+		// 如果有演职员更新（空数组表示清空）
+		if req.Credits != nil {
+			if err := s.replaceCredits(ctx, provider, mv, req.Credits); err != nil {
+				return err
+			}
+			if req.Cast == "" && mv.ActorNames() != "" {
+				mv.Cast = mv.ActorNames()
+				hasOtherUpdate = true
+			}
+		}
+
 		// 如果有类型更新
 		if len(req.GenreNames) > 0 {
 			// 检查并创建电影类型
@@ -142,11 +203,11 @@ func (s *movieService) UpdateMovie(ctx context.Context, req *request.UpdateMovie
 				logger.Error("failed to replace genres for movie", applog.Error(err))
 				return err
 			}
+		}
 
-			// 如果只更新类型,则直接返回
-			if !hasOtherUpdate {
-				return nil
-			}
+		// 如果只更新类型或演职员,则直接返回
+		if !hasOtherUpdate {
+			return nil
 		}
 
 		// 更新电影其他字段
@@ -175,10 +236,58 @@ func (s *movieService) UpdateMovie(ctx context.Context, req *request.UpdateMovie
 		return nil, err
 	}
 
+	if err := s.searchIndex.Index(ctx, mv); err != nil {
+		logger.Warn("failed to index movie", applog.Error(err))
+	}
+
 	logger.Info("update movie successfully", applog.Uint("movie_id", uint(mv.ID)))
 	return response.ToMovieResponse(mv), nil
 }
 
+// 按姓名查找或创建影人，并替换电影的演职员
+func (s *movieService) replaceCredits(ctx context.Context, provider shared.RepositoryProvider,
+	mv *movie.Movie, reqs []*request.CreditRequest) error {
+	logger := s.logger.With(applog.String("Method", "replaceCredits"), applog.Uint("movie_id", uint(mv.ID)))
+
+	names := make([]string, 0, len(reqs))
+	for _, c := range reqs {
+		names = append(names, c.PersonName)
+	}
+
+	credits := make([]*movie.Credit, 0, len(reqs))
+	if len(names) > 0 {
+		people, err := provider.GetPersonRepository().FindOrCreateByNames(ctx, names)
+		if err != nil {
+			logger.Error("failed to find or create people", applog.Error(err))
+			return err
+		}
+		peopleByName := make(map[string]*movie.Person, len(people))
+		for _, p := range people {
+			peopleByName[p.Name] = p
+		}
+
+		for _, c := range reqs {
+			credits = append(credits, &movie.Credit{
+				Person:       peopleByName[c.PersonName],
+				Role:         movie.CreditRole(c.Role),
+				Character:    c.Character,
+				BillingOrder: c.BillingOrder,
+			})
+		}
+		// 与仓库读取时的顺序保持一致
+		sort.SliceStable(credits, func(i, j int) bool {
+			return credits[i].BillingOrder < credits[j].BillingOrder
+		})
+	}
+
+	if err := provider.GetMovieRepository().ReplaceCreditsForMovie(ctx, mv.ID, credits); err != nil {
+		logger.Error("failed to replace credits for movie", applog.Error(err))
+		return err
+	}
+	mv.Credits = credits
+	return nil
+}
+
 // 获取电影详情
 func (s *movieService) GetMovie(ctx context.Context, req *request.GetMovieRequest) (*response.MovieResponse, error) {
 	logger := s.logger.With(applog.String("Method", "GetMovie"), applog.Uint("movie_id", req.ID))
@@ -186,7 +295,7 @@ func (s *movieService) GetMovie(ctx context.Context, req *request.GetMovieReques
 	mv, err := s.movieCache.GetMovie(ctx, vo.MovieID(req.ID))
 	if err == nil {
 		logger.Info("get movie from cache successfully")
-		return response.ToMovieResponse(mv), nil
+		return response.ToMovieResponse(mv.Localize(req.Locales)), nil
 	}
 
 	mv, err = s.movieRepo.FindByID(ctx, vo.MovieID(req.ID))
@@ -210,7 +319,7 @@ func (s *movieService) GetMovie(ctx context.Context, req *request.GetMovieReques
 	}
 
 	logger.Info("get movie by id successfully")
-	return response.ToMovieResponse(mv), nil
+	return response.ToMovieResponse(mv.Localize(req.Locales)), nil
 }
 
 // 删除电影
@@ -234,6 +343,10 @@ func (s *movieService) DeleteMovie(ctx context.Context, req *request.DeleteMovie
 		logger.Warn("failed to delete movie from cache", applog.Error(err))
 	}
 
+	if err := s.searchIndex.Remove(ctx, vo.MovieID(req.ID)); err != nil {
+		logger.Warn("failed to remove movie from search index", applog.Error(err))
+	}
+
 	logger.Info("delete movie successfully")
 	return nil
 }
@@ -253,7 +366,7 @@ func (s *movieService) ListMovies(ctx context.Context, req *request.ListMovieReq
 		movies = movies[startIndex:endIndex]
 		moviesResponse := make([]*response.MovieSimpleResponse, 0, len(movies))
 		for _, movie := range movies {
-			moviesResponse = append(moviesResponse, response.ToMovieSimpleResponse(movie))
+			moviesResponse = append(moviesResponse, response.ToMovieSimpleResponse(movie.Localize(req.Locales)))
 		}
 		return &response.PaginatedMovieResponse{
 			Pagination: response.PaginationResponse{
@@ -308,6 +421,340 @@ func (s *movieService) ListMovies(ctx context.Context, req *request.ListMovieReq
 	return fn(movies), nil
 }
 
+// 全文搜索电影，按相关度排序并返回高亮摘要
+func (s *movieService) SearchMovies(ctx context.Context, req *request.SearchMoviesRequest) (*response.SearchMoviesResponse, error) {
+	logger := s.logger.With(applog.String("Method", "SearchMovies"), applog.String("query", req.Query))
+
+	result, err := s.searchIndex.Search(ctx, req.ToDomain())
+	if err != nil {
+		logger.Error("failed to search movies", applog.Error(err))
+		return nil, err
+	}
+
+	ids := make([]vo.MovieID, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		ids = append(ids, hit.MovieID)
+	}
+	movies := make(map[vo.MovieID]*movie.Movie, len(ids))
+	if len(ids) > 0 {
+		found, err := s.movieRepo.FindByIDs(ctx, ids)
+		if err != nil {
+			logger.Error("failed to find movies", applog.Error(err))
+			return nil, err
+		}
+		for _, mv := range found {
+			movies[mv.ID] = mv
+		}
+	}
+
+	// 按索引返回的相关度顺序组装结果，索引中残留但已删除的电影直接跳过
+	// 索引只覆盖默认语言的文本，因此高亮基于原文生成，其余字段按偏好语言本地化
+	terms := movie.QueryTerms(req.Query)
+	results := make([]*response.MovieSearchResultResponse, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		mv, ok := movies[hit.MovieID]
+		if !ok {
+			logger.Warn("indexed movie not found", applog.Uint("movie_id", uint(hit.MovieID)))
+			continue
+		}
+		results = append(results, response.ToMovieSearchResultResponse(mv.Localize(req.Locales), hit.Score, movie.BuildHighlights(mv, terms)))
+	}
+
+	logger.Info("search movies successfully", applog.Int64("total", result.Total))
+	return &response.SearchMoviesResponse{
+		Pagination: response.PaginationResponse{
+			Page:       req.Page,
+			PageSize:   req.PageSize,
+			TotalCount: int(result.Total),
+			TotalPages: int(math.Ceil(float64(result.Total) / float64(req.PageSize))),
+		},
+		Results: results,
+	}, nil
+}
+
+// 批量导入片单：以标题匹配已有电影，存在则更新、不存在则创建
+// 每条记录在独立事务中导入，单条失败不影响其他记录；试运行只计算差异
+func (s *movieService) ImportMovies(ctx context.Context, req *request.ImportMoviesRequest) (*response.ImportMoviesResponse, error) {
+	logger := s.logger.With(applog.String("Method", "ImportMovies"),
+		applog.String("format", req.Format), applog.Bool("dry_run", req.DryRun))
+
+	format := movie.CatalogFormatJSON
+	if req.Format != "" {
+		f, err := movie.ParseCatalogFormat(req.Format)
+		if err != nil {
+			logger.Warn("invalid catalog format", applog.Error(err))
+			return nil, err
+		}
+		format = f
+	}
+
+	entries, err := movie.DecodeCatalog(format, req.Data)
+	if err != nil {
+		logger.Warn("invalid catalog feed", applog.Error(err))
+		return nil, err
+	}
+
+	result := &response.ImportMoviesResponse{
+		DryRun: req.DryRun,
+		Rows:   make([]*response.ImportMovieRowResponse, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		result.Add(s.importMovie(ctx, entry, req.DryRun))
+	}
+
+	logger.Info("import movies successfully",
+		applog.Int("total", result.Summary.Total), applog.Int("created", result.Summary.Created),
+		applog.Int("updated", result.Summary.Updated), applog.Int("failed", result.Summary.Failed))
+	return result, nil
+}
+
+// 导入单条片单记录
+func (s *movieService) importMovie(ctx context.Context, entry *movie.CatalogEntry, dryRun bool) *response.ImportMovieRowResponse {
+	logger := s.logger.With(applog.String("Method", "importMovie"),
+		applog.Int("row", entry.Row), applog.String("title", entry.Title))
+
+	row := &response.ImportMovieRowResponse{Row: entry.Row, Title: entry.Title}
+	fail := func(err error) *response.ImportMovieRowResponse {
+		row.Action = response.ImportActionError
+		row.Error = err.Error()
+		return row
+	}
+	if entry.Err != nil {
+		logger.Warn("invalid catalog entry", applog.Error(entry.Err))
+		return fail(entry.Err)
+	}
+	if err := s.agePolicy.ValidateRating(entry.AgeRating); err != nil {
+		logger.Warn("invalid age rating", applog.Error(err))
+		return fail(err)
+	}
+
+	// 查找与写入在同一事务中完成，避免匹配结果在写入前失效
+	var existing *movie.Movie
+	mv := entry.ToMovie()
+	err := s.uow.Execute(ctx, func(ctx context.Context, provider shared.RepositoryProvider) error {
+		movieRepo := provider.GetMovieRepository()
+		found, err := movieRepo.FindByTitle(ctx, entry.Title)
+		if err != nil && !errors.Is(err, movie.ErrMovieNotFound) {
+			return err
+		}
+		existing = found
+
+		row.Changes = entry.Diff(existing)
+		switch {
+		case existing == nil:
+			row.Action = response.ImportActionCreate
+		case len(row.Changes) == 0:
+			row.Action = response.ImportActionUnchanged
+		default:
+			row.Action = response.ImportActionUpdate
+		}
+		if dryRun || row.Action == response.ImportActionUnchanged {
+			return nil
+		}
+
+		if existing == nil {
+			created, err := movieRepo.Create(ctx, mv)
+			if err != nil {
+				return err
+			}
+			mv = created
+		} else {
+			// 仅更新片单中填写的字段
+			mv.ID = existing.ID
+			if err := movieRepo.Update(ctx, mv); err != nil {
+				return err
+			}
+			if entry.SameGenres(existing) {
+				return nil
+			}
+		}
+
+		genres, err := provider.GetGenreRepository().FindOrCreateByNames(ctx, entry.GenreNames)
+		if err != nil {
+			return err
+		}
+		return movieRepo.ReplaceGenresForMovie(ctx, mv, genres)
+	})
+	if err != nil {
+		logger.Error("failed to import movie", applog.Error(err))
+		return fail(err)
+	}
+	if existing != nil {
+		row.MovieID = uint(existing.ID)
+	}
+	if dryRun || row.Action == response.ImportActionUnchanged {
+		return row
+	}
+	row.MovieID = uint(mv.ID)
+
+	if existing != nil {
+		if err := s.movieCache.DeleteMovie(ctx, mv.ID); err != nil {
+			logger.Warn("failed to delete movie from cache", applog.Error(err))
+		}
+	}
+
+	// 重新加载完整电影（含类型）用于更新搜索索引
+	if indexed, err := s.movieRepo.FindByID(ctx, mv.ID); err != nil {
+		logger.Warn("failed to reload imported movie", applog.Error(err))
+	} else if err := s.searchIndex.Index(ctx, indexed); err != nil {
+		logger.Warn("failed to index movie", applog.Error(err))
+	}
+
+	logger.Info("import movie successfully", applog.String("action", row.Action), applog.Uint("movie_id", row.MovieID))
+	return row
+}
+
+// 手动归档电影（下映），仍有未结束的场次时不允许归档；重复归档直接返回
+func (s *movieService) ArchiveMovie(ctx context.Context, req *request.ArchiveMovieRequest) (*response.MovieResponse, error) {
+	logger := s.logger.With(applog.String("Method", "ArchiveMovie"), applog.Uint("movie_id", req.ID))
+	movieID := vo.MovieID(req.ID)
+
+	err := s.uow.Execute(ctx, func(ctx context.Context, provider shared.RepositoryProvider) error {
+		movieRepo := provider.GetMovieRepository()
+		mv, err := movieRepo.FindByID(ctx, movieID)
+		if err != nil {
+			logger.Warn("failed to find movie", applog.Error(err))
+			return err
+		}
+		if mv.IsArchived() {
+			logger.Info("movie already archived")
+			return nil
+		}
+
+		upcoming, err := provider.GetShowtimeRepository().FindMovieIDsWithUpcomingShowtimes(ctx, []vo.MovieID{movieID}, time.Now())
+		if err != nil {
+			logger.Error("failed to check upcoming showtimes", applog.Error(err))
+			return err
+		}
+		if len(upcoming) > 0 {
+			logger.Warn("movie has upcoming showtimes")
+			return fmt.Errorf("%w(id): %v", movie.ErrMovieHasUpcomingShowtimes, movieID)
+		}
+
+		if err := movieRepo.UpdateStatus(ctx, movieID, movie.StatusArchived); err != nil {
+			logger.Error("failed to archive movie", applog.Error(err))
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("failed to archive movie", applog.Error(err))
+		return nil, err
+	}
+
+	logger.Info("archive movie successfully")
+	return s.reloadMovie(ctx, logger, movieID)
+}
+
+// 恢复已归档的电影：重新回到即将上映，允许再次排片，之后由定时任务推进
+func (s *movieService) RestoreMovie(ctx context.Context, req *request.RestoreMovieRequest) (*response.MovieResponse, error) {
+	logger := s.logger.With(applog.String("Method", "RestoreMovie"), applog.Uint("movie_id", req.ID))
+	movieID := vo.MovieID(req.ID)
+
+	err := s.uow.Execute(ctx, func(ctx context.Context, provider shared.RepositoryProvider) error {
+		movieRepo := provider.GetMovieRepository()
+		mv, err := movieRepo.FindByID(ctx, movieID)
+		if err != nil {
+			logger.Warn("failed to find movie", applog.Error(err))
+			return err
+		}
+		if !mv.IsArchived() {
+			logger.Warn("movie is not archived", applog.String("status", string(mv.Status)))
+			return fmt.Errorf("%w(id): %v", movie.ErrMovieNotArchived, movieID)
+		}
+
+		if err := movieRepo.UpdateStatus(ctx, movieID, movie.StatusComingSoon); err != nil {
+			logger.Error("failed to restore movie", applog.Error(err))
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("failed to restore movie", applog.Error(err))
+		return nil, err
+	}
+
+	logger.Info("restore movie successfully")
+	return s.reloadMovie(ctx, logger, movieID)
+}
+
+// 状态变更后删除缓存并返回最新的电影详情
+func (s *movieService) reloadMovie(ctx context.Context, logger applog.Logger, movieID vo.MovieID) (*response.MovieResponse, error) {
+	if err := s.movieCache.DeleteMovie(ctx, movieID); err != nil {
+		logger.Warn("failed to delete movie from cache", applog.Error(err))
+	}
+
+	mv, err := s.movieRepo.FindByID(ctx, movieID)
+	if err != nil {
+		logger.Error("failed to find movie", applog.Error(err))
+		return nil, err
+	}
+	return response.ToMovieResponse(mv), nil
+}
+
+// 推进所有未归档电影的生命周期状态：
+// 即将上映 -> 正在热映（已到上映日期且有未结束的场次）-> 已下映（场次全部结束）
+// 状态变化的电影删除详情缓存；列表缓存按较短的过期时间自然刷新
+func (s *movieService) RefreshMovieStatuses(ctx context.Context) error {
+	logger := s.logger.With(applog.String("Method", "RefreshMovieStatuses"))
+	now := time.Now()
+
+	changed := make([]vo.MovieID, 0)
+	err := s.uow.Execute(ctx, func(ctx context.Context, provider shared.RepositoryProvider) error {
+		movieRepo := provider.GetMovieRepository()
+		movies, err := movieRepo.FindByStatuses(ctx, movie.ActiveStatuses)
+		if err != nil {
+			logger.Error("failed to find active movies", applog.Error(err))
+			return err
+		}
+		if len(movies) == 0 {
+			return nil
+		}
+
+		movieIDs := make([]vo.MovieID, len(movies))
+		for i, mv := range movies {
+			movieIDs[i] = mv.ID
+		}
+		upcomingIDs, err := provider.GetShowtimeRepository().FindMovieIDsWithUpcomingShowtimes(ctx, movieIDs, now)
+		if err != nil {
+			logger.Error("failed to find movies with upcoming showtimes", applog.Error(err))
+			return err
+		}
+		upcoming := make(map[vo.MovieID]bool, len(upcomingIDs))
+		for _, id := range upcomingIDs {
+			upcoming[id] = true
+		}
+
+		for _, mv := range movies {
+			next := mv.NextStatus(upcoming[mv.ID], now)
+			if next == mv.Status {
+				continue
+			}
+			if err := movieRepo.UpdateStatus(ctx, mv.ID, next); err != nil {
+				logger.Error("failed to update movie status", applog.Error(err), applog.Uint("movie_id", uint(mv.ID)))
+				return err
+			}
+			logger.Info("movie status changed", applog.Uint("movie_id", uint(mv.ID)),
+				applog.String("from", string(mv.Status)), applog.String("to", string(next)))
+			changed = append(changed, mv.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("failed to refresh movie statuses", applog.Error(err))
+		return err
+	}
+
+	for _, id := range changed {
+		if err := s.movieCache.DeleteMovie(ctx, id); err != nil {
+			logger.Warn("failed to delete movie from cache", applog.Error(err), applog.Uint("movie_id", uint(id)))
+		}
+	}
+
+	logger.Info("refresh movie statuses successfully", applog.Int("changed", len(changed)))
+	return nil
+}
+
 // 创建类型
 func (s *movieService) CreateGenre(ctx context.Context, req *request.CreateGenreRequest) (*response.GenreResponse, error) {
 	logger := s.logger.With(applog.String("Method", "CreateGenre"))
@@ -383,7 +830,7 @@ func (s *movieService) DeleteGenre(ctx context.Context, req *request.DeleteGenre
 }
 
 // 获取类型列表
-func (s *movieService) ListAllGenres(ctx context.Context) (*response.ListAllGenresResponse, error) {
+func (s *movieService) ListAllGenres(ctx context.Context, req *request.ListGenresRequest) (*response.ListAllGenresResponse, error) {
 	logger := s.logger.With(applog.String("Method", "ListGenres"))
 
 	genres, err := s.genreRepo.ListAll(ctx)
@@ -393,5 +840,128 @@ func (s *movieService) ListAllGenres(ctx context.Context) (*response.ListAllGenr
 	}
 
 	logger.Info("list genres successfully", applog.Int("total", len(genres)))
-	return response.ToListAllGenresResponse(genres), nil
+	return response.ToListAllGenresResponse(movie.LocalizeGenres(genres, req.Locales)), nil
+}
+
+// 新增或覆盖电影翻译
+func (s *movieService) UpsertMovieTranslation(ctx context.Context, req *request.UpsertMovieTranslationRequest) (*response.MovieTranslationResponse, error) {
+	logger := s.logger.With(applog.String("Method", "UpsertMovieTranslation"), applog.Uint("movie_id", req.MovieID), applog.String("locale", req.Locale))
+	movieID := vo.MovieID(req.MovieID)
+
+	locale, err := vo.ParseLocale(req.Locale)
+	if err != nil {
+		logger.Warn("invalid locale", applog.Error(err))
+		return nil, err
+	}
+	translation := &movie.MovieTranslation{Locale: locale, Title: req.Title, Description: req.Description}
+
+	err = s.uow.Execute(ctx, func(ctx context.Context, provider shared.RepositoryProvider) error {
+		if _, err := provider.GetMovieRepository().FindByID(ctx, movieID); err != nil {
+			logger.Warn("failed to find movie", applog.Error(err))
+			return err
+		}
+		return provider.GetTranslationRepository().UpsertMovieTranslation(ctx, movieID, translation)
+	})
+	if err != nil {
+		if errors.Is(err, movie.ErrMovieNotFound) {
+			return nil, err
+		}
+		logger.Error("failed to upsert movie translation", applog.Error(err))
+		return nil, err
+	}
+
+	if err := s.movieCache.DeleteMovie(ctx, movieID); err != nil {
+		logger.Warn("failed to delete movie from cache", applog.Error(err))
+	}
+
+	logger.Info("upsert movie translation successfully")
+	return response.ToMovieTranslationResponse(movieID, translation), nil
+}
+
+// 删除电影翻译
+func (s *movieService) DeleteMovieTranslation(ctx context.Context, req *request.DeleteMovieTranslationRequest) error {
+	logger := s.logger.With(applog.String("Method", "DeleteMovieTranslation"), applog.Uint("movie_id", req.MovieID), applog.String("locale", req.Locale))
+	movieID := vo.MovieID(req.MovieID)
+
+	locale, err := vo.ParseLocale(req.Locale)
+	if err != nil {
+		logger.Warn("invalid locale", applog.Error(err))
+		return err
+	}
+
+	// 仓库底层实现会根据RowAffected判断翻译是否存在
+	if err := s.uow.Execute(ctx, func(ctx context.Context, provider shared.RepositoryProvider) error {
+		return provider.GetTranslationRepository().DeleteMovieTranslation(ctx, movieID, locale)
+	}); err != nil {
+		if errors.Is(err, movie.ErrTranslationNotFound) {
+			logger.Warn("movie translation not found")
+			return err
+		}
+		logger.Error("failed to delete movie translation", applog.Error(err))
+		return err
+	}
+
+	if err := s.movieCache.DeleteMovie(ctx, movieID); err != nil {
+		logger.Warn("failed to delete movie from cache", applog.Error(err))
+	}
+
+	logger.Info("delete movie translation successfully")
+	return nil
+}
+
+// 新增或覆盖类型翻译
+// 缓存中的电影详情与列表携带类型翻译，按各自的过期时间自然刷新
+func (s *movieService) UpsertGenreTranslation(ctx context.Context, req *request.UpsertGenreTranslationRequest) (*response.GenreTranslationResponse, error) {
+	logger := s.logger.With(applog.String("Method", "UpsertGenreTranslation"), applog.Uint("genre_id", req.GenreID), applog.String("locale", req.Locale))
+	genreID := vo.GenreID(req.GenreID)
+
+	locale, err := vo.ParseLocale(req.Locale)
+	if err != nil {
+		logger.Warn("invalid locale", applog.Error(err))
+		return nil, err
+	}
+	translation := &movie.GenreTranslation{Locale: locale, Name: req.Name}
+
+	err = s.uow.Execute(ctx, func(ctx context.Context, provider shared.RepositoryProvider) error {
+		if _, err := provider.GetGenreRepository().FindByID(ctx, genreID); err != nil {
+			logger.Warn("failed to find genre", applog.Error(err))
+			return err
+		}
+		return provider.GetTranslationRepository().UpsertGenreTranslation(ctx, genreID, translation)
+	})
+	if err != nil {
+		if errors.Is(err, movie.ErrGenreNotFound) {
+			return nil, err
+		}
+		logger.Error("failed to upsert genre translation", applog.Error(err))
+		return nil, err
+	}
+
+	logger.Info("upsert genre translation successfully")
+	return response.ToGenreTranslationResponse(genreID, translation), nil
+}
+
+// 删除类型翻译
+func (s *movieService) DeleteGenreTranslation(ctx context.Context, req *request.DeleteGenreTranslationRequest) error {
+	logger := s.logger.With(applog.String("Method", "DeleteGenreTranslation"), applog.Uint("genre_id", req.GenreID), applog.String("locale", req.Locale))
+
+	locale, err := vo.ParseLocale(req.Locale)
+	if err != nil {
+		logger.Warn("invalid locale", applog.Error(err))
+		return err
+	}
+
+	if err := s.uow.Execute(ctx, func(ctx context.Context, provider shared.RepositoryProvider) error {
+		return provider.GetTranslationRepository().DeleteGenreTranslation(ctx, vo.GenreID(req.GenreID), locale)
+	}); err != nil {
+		if errors.Is(err, movie.ErrTranslationNotFound) {
+			logger.Warn("genre translation not found")
+			return err
+		}
+		logger.Error("failed to delete genre translation", applog.Error(err))
+		return err
+	}
+
+	logger.Info("delete genre translation successfully")
+	return nil
 }
@@ -0,0 +1,180 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"math"
+	"mrs/internal/api/dto/request"
+	"mrs/internal/api/dto/response"
+
+	"mrs/internal/domain/movie"
+	"mrs/internal/domain/shared"
+	"mrs/internal/domain/shared/vo"
+	applog "mrs/pkg/log"
+)
+
+type PersonService interface {
+	CreatePerson(ctx context.Context, req *request.CreatePersonRequest) (*response.PersonResponse, error)
+	GetPerson(ctx context.Context, req *request.GetPersonRequest) (*response.PersonResponse, error)
+	ListPeople(ctx context.Context, req *request.ListPeopleRequest) (*response.PaginatedPersonResponse, error)
+	UpdatePerson(ctx context.Context, req *request.UpdatePersonRequest) (*response.PersonResponse, error)
+	DeletePerson(ctx context.Context, req *request.DeletePersonRequest) error
+	GetFilmography(ctx context.Context, req *request.GetFilmographyRequest) (*response.FilmographyResponse, error)
+}
+
+type personService struct {
+	uow        shared.UnitOfWork
+	personRepo movie.PersonRepository
+	logger     applog.Logger
+}
+
+func NewPersonService(
+	uow shared.UnitOfWork,
+	personRepo movie.PersonRepository,
+	logger applog.Logger,
+) PersonService {
+	return &personService{
+		uow:        uow,
+		personRepo: personRepo,
+		logger:     logger.With(applog.String("Service", "PersonService")),
+	}
+}
+
+// 创建影人
+func (s *personService) CreatePerson(ctx context.Context, req *request.CreatePersonRequest) (*response.PersonResponse, error) {
+	logger := s.logger.With(applog.String("Method", "CreatePerson"), applog.String("name", req.Name))
+
+	person, err := s.personRepo.Create(ctx, req.ToDomain())
+	if err != nil {
+		if errors.Is(err, movie.ErrPersonAlreadyExists) {
+			logger.Warn("person already exists")
+			return nil, err
+		}
+		logger.Error("failed to create person", applog.Error(err))
+		return nil, err
+	}
+
+	logger.Info("create person successfully", applog.Uint("person_id", uint(person.ID)))
+	return response.ToPersonResponse(person), nil
+}
+
+// 获取影人详情
+func (s *personService) GetPerson(ctx context.Context, req *request.GetPersonRequest) (*response.PersonResponse, error) {
+	logger := s.logger.With(applog.String("Method", "GetPerson"), applog.Uint("person_id", req.ID))
+
+	person, err := s.personRepo.FindByID(ctx, vo.PersonID(req.ID))
+	if err != nil {
+		if errors.Is(err, movie.ErrPersonNotFound) {
+			logger.Warn("person not found")
+			return nil, err
+		}
+		logger.Error("failed to get person", applog.Error(err))
+		return nil, err
+	}
+
+	logger.Info("get person successfully")
+	return response.ToPersonResponse(person), nil
+}
+
+// 获取影人列表
+func (s *personService) ListPeople(ctx context.Context, req *request.ListPeopleRequest) (*response.PaginatedPersonResponse, error) {
+	logger := s.logger.With(applog.String("Method", "ListPeople"))
+
+	people, total, err := s.personRepo.List(ctx, req.ToDomain())
+	if err != nil {
+		logger.Error("failed to list people", applog.Error(err))
+		return nil, err
+	}
+
+	peopleResp := make([]*response.PersonResponse, 0, len(people))
+	for _, person := range people {
+		peopleResp = append(peopleResp, response.ToPersonResponse(person))
+	}
+
+	logger.Info("list people successfully", applog.Int64("total", total))
+	return &response.PaginatedPersonResponse{
+		Pagination: response.PaginationResponse{
+			Page:       req.Page,
+			PageSize:   req.PageSize,
+			TotalCount: int(total),
+			TotalPages: int(math.Ceil(float64(total) / float64(req.PageSize))),
+		},
+		People: peopleResp,
+	}, nil
+}
+
+// 更新影人
+func (s *personService) UpdatePerson(ctx context.Context, req *request.UpdatePersonRequest) (*response.PersonResponse, error) {
+	logger := s.logger.With(applog.String("Method", "UpdatePerson"), applog.Uint("person_id", req.ID))
+
+	person := req.ToDomain()
+	if err := s.personRepo.Update(ctx, person); err != nil {
+		if errors.Is(err, movie.ErrPersonNotFound) || errors.Is(err, movie.ErrPersonAlreadyExists) {
+			logger.Warn("failed to update person", applog.Error(err))
+			return nil, err
+		}
+		logger.Error("failed to update person", applog.Error(err))
+		return nil, err
+	}
+
+	logger.Info("update person successfully")
+	return response.ToPersonResponse(person), nil
+}
+
+// 删除影人
+func (s *personService) DeletePerson(ctx context.Context, req *request.DeletePersonRequest) error {
+	logger := s.logger.With(applog.String("Method", "DeletePerson"), applog.Uint("person_id", req.ID))
+
+	err := s.uow.Execute(ctx, func(ctx context.Context, provider shared.RepositoryProvider) error {
+		personRepo := provider.GetPersonRepository()
+
+		// 与类型相同，在应用层模拟外键约束：仍被电影引用的影人不能删除
+		referenced, err := personRepo.CheckPersonReferenced(ctx, vo.PersonID(req.ID))
+		if err != nil {
+			logger.Error("failed to check person referenced", applog.Error(err))
+			return err
+		}
+		if referenced {
+			logger.Warn("person is referenced by movie")
+			return movie.ErrPersonReferenced
+		}
+
+		if err := personRepo.Delete(ctx, vo.PersonID(req.ID)); err != nil {
+			logger.Error("failed to delete person", applog.Error(err))
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("failed to delete person", applog.Error(err))
+		return err
+	}
+
+	logger.Info("delete person successfully")
+	return nil
+}
+
+// 获取影人作品年表
+func (s *personService) GetFilmography(ctx context.Context, req *request.GetFilmographyRequest) (*response.FilmographyResponse, error) {
+	logger := s.logger.With(applog.String("Method", "GetFilmography"),
+		applog.Uint("person_id", req.ID), applog.String("role", req.Role))
+
+	person, err := s.personRepo.FindByID(ctx, vo.PersonID(req.ID))
+	if err != nil {
+		if errors.Is(err, movie.ErrPersonNotFound) {
+			logger.Warn("person not found")
+			return nil, err
+		}
+		logger.Error("failed to get person", applog.Error(err))
+		return nil, err
+	}
+
+	entries, err := s.personRepo.FindFilmography(ctx, person.ID, movie.CreditRole(req.Role))
+	if err != nil {
+		logger.Error("failed to find filmography", applog.Error(err))
+		return nil, err
+	}
+
+	logger.Info("get filmography successfully", applog.Int("count", len(entries)))
+	return response.ToFilmographyResponse(person, entries), nil
+}
@@ -0,0 +1,269 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"mrs/internal/api/dto/request"
+	"mrs/internal/api/dto/response"
+	"mrs/internal/domain/movie"
+	"mrs/internal/domain/recommendation"
+	"mrs/internal/domain/shared"
+	"mrs/internal/domain/shared/vo"
+	applog "mrs/pkg/log"
+	"time"
+)
+
+const (
+	defaultRecommendationLimit  = 10  // 默认返回的推荐数量
+	recommendationUserBatchSize = 500 // 批处理任务每批处理的用户数
+)
+
+type RecommendationService interface {
+	GetRecommendations(ctx context.Context, req *request.GetRecommendationsRequest) (*response.RecommendationListResponse, error)
+	// 重算热门列表与近期活跃用户的个性化推荐并写入缓存（由定时任务调用）
+	RefreshRecommendations(ctx context.Context) error
+}
+
+type recommendationService struct {
+	recommendationRepo  recommendation.RecommendationRepository
+	movieRepo           movie.MovieRepository
+	recommendationCache recommendation.RecommendationCache
+	logger              applog.Logger
+}
+
+func NewRecommendationService(
+	recommendationRepo recommendation.RecommendationRepository,
+	movieRepo movie.MovieRepository,
+	recommendationCache recommendation.RecommendationCache,
+	logger applog.Logger,
+) RecommendationService {
+	return &recommendationService{
+		recommendationRepo:  recommendationRepo,
+		movieRepo:           movieRepo,
+		recommendationCache: recommendationCache,
+		logger:              logger.With(applog.String("Service", "RecommendationService")),
+	}
+}
+
+// 查询用户的推荐：优先读取缓存，未命中时即时计算并写入缓存
+func (s *recommendationService) GetRecommendations(ctx context.Context, req *request.GetRecommendationsRequest) (*response.RecommendationListResponse, error) {
+	logger := s.logger.With(applog.String("Method", "GetRecommendations"), applog.Uint("user_id", req.UserID))
+	userID := vo.UserID(req.UserID)
+
+	list, err := s.recommendationCache.GetRecommendations(ctx, userID)
+	if err != nil {
+		if !errors.Is(err, shared.ErrCacheMissing) {
+			logger.Warn("failed to get recommendations from cache", applog.Error(err))
+		}
+
+		candidates, err := s.loadCandidates(ctx)
+		if err != nil {
+			logger.Error("failed to load candidate movies", applog.Error(err))
+			return nil, err
+		}
+		popular, err := s.popular(ctx, false)
+		if err != nil {
+			logger.Error("failed to load popular movies", applog.Error(err))
+			return nil, err
+		}
+		list, err = s.computeAndCache(ctx, userID, candidates, popular)
+		if err != nil {
+			logger.Error("failed to compute recommendations", applog.Error(err))
+			return nil, err
+		}
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultRecommendationLimit
+	}
+	resp, err := s.toResponse(ctx, list, limit)
+	if err != nil {
+		logger.Error("failed to load recommended movies", applog.Error(err))
+		return nil, err
+	}
+
+	logger.Info("get recommendations successfully", applog.String("source", resp.Source),
+		applog.Int("count", len(resp.Recommendations)))
+	return resp, nil
+}
+
+// 先重算热门列表，再按ID游标分批重算近期有订单的用户；单个用户失败只记录日志
+func (s *recommendationService) RefreshRecommendations(ctx context.Context) error {
+	logger := s.logger.With(applog.String("Method", "RefreshRecommendations"))
+
+	candidates, err := s.loadCandidates(ctx)
+	if err != nil {
+		logger.Error("failed to load candidate movies", applog.Error(err))
+		return err
+	}
+	popular, err := s.popular(ctx, true)
+	if err != nil {
+		logger.Error("failed to refresh popular movies", applog.Error(err))
+		return err
+	}
+
+	since := time.Now().Add(-recommendation.ActiveUserWindow)
+	refreshed, failed := 0, 0
+	var cursor vo.UserID
+	for {
+		userIDs, err := s.recommendationRepo.FindActiveUserIDs(ctx, since, cursor, recommendationUserBatchSize)
+		if err != nil {
+			logger.Error("failed to find active users", applog.Error(err))
+			return err
+		}
+		for _, userID := range userIDs {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if _, err := s.computeAndCache(ctx, userID, candidates, popular); err != nil {
+				logger.Warn("failed to refresh user recommendations", applog.Uint("user_id", uint(userID)), applog.Error(err))
+				failed++
+				continue
+			}
+			refreshed++
+		}
+		if len(userIDs) < recommendationUserBatchSize {
+			break
+		}
+		cursor = userIDs[len(userIDs)-1]
+	}
+
+	logger.Info("refresh recommendations successfully", applog.Int("refreshed", refreshed), applog.Int("failed", failed))
+	return nil
+}
+
+// 候选电影：即将上映与正在热映的电影（含类型）
+func (s *recommendationService) loadCandidates(ctx context.Context) ([]*movie.Movie, error) {
+	active, err := s.movieRepo.FindByStatuses(ctx, movie.ActiveStatuses)
+	if err != nil {
+		return nil, err
+	}
+	if len(active) == 0 {
+		return []*movie.Movie{}, nil
+	}
+	ids := make([]vo.MovieID, len(active))
+	for i, mv := range active {
+		ids[i] = mv.ID
+	}
+	return s.movieRepo.FindByIDs(ctx, ids)
+}
+
+// 热门推荐列表，refresh 为 false 时优先读取缓存
+func (s *recommendationService) popular(ctx context.Context, refresh bool) (*recommendation.RecommendationList, error) {
+	if !refresh {
+		list, err := s.recommendationCache.GetPopular(ctx)
+		if err == nil {
+			return list, nil
+		}
+		if !errors.Is(err, shared.ErrCacheMissing) {
+			s.logger.Warn("failed to get popular movies from cache", applog.Error(err))
+		}
+	}
+
+	now := time.Now()
+	popular, err := s.recommendationRepo.FindPopularMovies(ctx, movie.ActiveStatuses,
+		now.Add(-recommendation.PopularityWindow), recommendation.MaxRecommendations)
+	if err != nil {
+		return nil, err
+	}
+	list := &recommendation.RecommendationList{
+		Source:          recommendation.SourcePopular,
+		Recommendations: recommendation.RankPopular(popular, recommendation.MaxRecommendations),
+		GeneratedAt:     now,
+	}
+	// 缓存失败不影响结果
+	if err := s.recommendationCache.SetPopular(ctx, list, 0); err != nil {
+		s.logger.Warn("failed to set popular movies to cache", applog.Error(err))
+	}
+	return list, nil
+}
+
+// 计算用户的推荐并写入缓存：没有订票记录或没有个性化结果时使用热门列表
+func (s *recommendationService) computeAndCache(ctx context.Context, userID vo.UserID,
+	candidates []*movie.Movie, popular *recommendation.RecommendationList) (*recommendation.RecommendationList, error) {
+	watchedIDs, err := s.recommendationRepo.FindWatchedMovieIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	recs := []*recommendation.Recommendation{}
+	if len(watchedIDs) > 0 {
+		watched, err := s.movieRepo.FindByIDs(ctx, watchedIDs)
+		if err != nil {
+			return nil, err
+		}
+		coAttendance, err := s.recommendationRepo.FindCoAttendance(ctx, watchedIDs)
+		if err != nil {
+			return nil, err
+		}
+		audienceIDs := append([]vo.MovieID{}, watchedIDs...)
+		for _, co := range coAttendance {
+			audienceIDs = append(audienceIDs, co.OtherMovieID)
+		}
+		audience, err := s.recommendationRepo.CountAudience(ctx, audienceIDs)
+		if err != nil {
+			return nil, err
+		}
+		recs = recommendation.Rank(watched, candidates, coAttendance, audience, recommendation.MaxRecommendations)
+	}
+
+	list := &recommendation.RecommendationList{
+		UserID:      userID,
+		Source:      recommendation.SourcePersonalized,
+		GeneratedAt: time.Now(),
+	}
+	expiration := recommendation.DefaultRecommendationExpiration
+	if len(recs) == 0 {
+		list.Source = recommendation.SourcePopular
+		expiration = recommendation.DefaultPopularExpiration
+	}
+	list.Recommendations = recommendation.FillWithPopular(recs, popular.Recommendations,
+		watchedIDs, recommendation.MaxRecommendations)
+
+	if err := s.recommendationCache.SetRecommendations(ctx, list, expiration); err != nil {
+		s.logger.Warn("failed to set recommendations to cache", applog.Uint("user_id", uint(userID)), applog.Error(err))
+	}
+	return list, nil
+}
+
+// 加载推荐电影的详情，跳过已删除或已下映的电影（缓存可能滞后）
+func (s *recommendationService) toResponse(ctx context.Context, list *recommendation.RecommendationList, limit int) (*response.RecommendationListResponse, error) {
+	resp := &response.RecommendationListResponse{
+		Source:          list.Source,
+		GeneratedAt:     list.GeneratedAt,
+		Recommendations: make([]*response.RecommendationResponse, 0, limit),
+	}
+	if len(list.Recommendations) == 0 {
+		return resp, nil
+	}
+
+	ids := make([]vo.MovieID, len(list.Recommendations))
+	for i, rec := range list.Recommendations {
+		ids[i] = rec.MovieID
+	}
+	movies, err := s.movieRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	moviesByID := make(map[vo.MovieID]*movie.Movie, len(movies))
+	for _, mv := range movies {
+		moviesByID[mv.ID] = mv
+	}
+
+	for _, rec := range list.Recommendations {
+		if len(resp.Recommendations) >= limit {
+			break
+		}
+		mv, ok := moviesByID[rec.MovieID]
+		if !ok || mv.IsArchived() {
+			continue
+		}
+		resp.Recommendations = append(resp.Recommendations, &response.RecommendationResponse{
+			Movie:   response.ToMovieSimpleResponse(mv),
+			Score:   rec.Score,
+			Reasons: rec.Reasons,
+		})
+	}
+	return resp, nil
+}
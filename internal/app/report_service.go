@@ -5,58 +5,379 @@ import (
 	"fmt"
 	"mrs/internal/api/dto/request"
 	"mrs/internal/api/dto/response"
+	"mrs/internal/domain/analytics"
 	"mrs/internal/domain/booking"
+	"mrs/internal/domain/shared"
 	applog "mrs/pkg/log"
 	"time"
 )
 
 type ReportService interface {
 	GenerateSalesReport(ctx context.Context, req *request.GenerateSalesReportRequest) (*response.GenerateSalesReportResponse, error)
+	GenerateOccupancyReport(ctx context.Context, req *request.GenerateOccupancyReportRequest) (*response.GenerateOccupancyReportResponse, error)
+	GenerateFunnelReport(ctx context.Context, req *request.GenerateFunnelReportRequest) (*response.GenerateFunnelReportResponse, error)
+	// 逐条读取订单明细并交给 fn 处理（时间已转换到请求的时区），fn 返回错误时停止
+	ExportBookings(ctx context.Context, req *request.ExportBookingsRequest, fn func(*booking.SalesRecord) error) error
+	// 按订单表重建每日销售事实（回填历史数据或修复偏差），每个业务日期一个事务
+	RebuildDailySales(ctx context.Context, req *request.RebuildDailySalesRequest) (*response.RebuildDailySalesResponse, error)
 }
 
 type reportService struct {
-	logger      applog.Logger
-	bookingRepo booking.BookingRepository
+	logger         applog.Logger
+	uow            shared.UnitOfWork
+	bookingRepo    booking.BookingRepository
+	dailySalesRepo booking.DailySalesRepository
+	funnelRepo     analytics.FunnelEventRepository
 }
 
 func NewReportService(
 	logger applog.Logger,
+	uow shared.UnitOfWork,
 	bookingRepo booking.BookingRepository,
+	dailySalesRepo booking.DailySalesRepository,
+	funnelRepo analytics.FunnelEventRepository,
 ) ReportService {
 	return &reportService{
-		logger:      logger.With(applog.String("Service", "ReportService")),
-		bookingRepo: bookingRepo,
+		logger:         logger.With(applog.String("Service", "ReportService")),
+		uow:            uow,
+		bookingRepo:    bookingRepo,
+		dailySalesRepo: dailySalesRepo,
+		funnelRepo:     funnelRepo,
 	}
 }
 
+// 销售统计的数据源：订单表（实时汇总）或每日销售事实表
+type salesReader interface {
+	GetSalesStatistics(ctx context.Context, options *booking.SalesQueryOptions) (*booking.SalesStatistics, error)
+	GetSalesGroups(ctx context.Context, options *booking.SalesQueryOptions, query *booking.SalesGroupQuery) ([]*booking.SalesBucket, error)
+}
+
+// 选择数据源：事实表只有按天的下单时间粒度，按下单时间口径时要求时间范围按整天划分，
+// 时间序列还要求粒度不小于一天且报表时区与服务器时区的日界一致；按场次口径时总是读取事实表
+func (s *reportService) salesReader(options *booking.SalesQueryOptions, granularity booking.SalesGranularity, loc *time.Location) salesReader {
+	if options.DateBasis == booking.SalesDateBasisShowtime {
+		return s.dailySalesRepo
+	}
+	if !options.DayAligned() {
+		return s.bookingRepo
+	}
+	if granularity == "" {
+		return s.dailySalesRepo
+	}
+	if granularity == booking.SalesGranularityHour || !sameDayBoundaries(loc, options.StartDate, options.EndDate) {
+		return s.bookingRepo
+	}
+	return s.dailySalesRepo
+}
+
+// 报表时区与服务器时区在时间范围两端（及当前时间）的偏移是否相同
+func sameDayBoundaries(loc *time.Location, start, end time.Time) bool {
+	for _, t := range []time.Time{start, end, time.Now()} {
+		if t.IsZero() {
+			continue
+		}
+		_, localOffset := t.In(time.Local).Zone()
+		_, reportOffset := t.In(loc).Zone()
+		if localOffset != reportOffset {
+			return false
+		}
+	}
+	return true
+}
+
+// 销售报告：总体销售数据、按电影与影厅的拆分，可选的时间序列与上一周期对比
 func (s *reportService) GenerateSalesReport(ctx context.Context, req *request.GenerateSalesReportRequest) (*response.GenerateSalesReportResponse, error) {
 	logger := s.logger.With(applog.String("Method", "GenerateSalesReport"))
 
-	// 1. 准备查询选项
+	// 1. 校验时区与时间范围
+	loc, err := reportLocation(req.Timezone)
+	if err != nil {
+		logger.Warn("invalid timezone", applog.String("timezone", req.Timezone), applog.Error(err))
+		return nil, err
+	}
+	if err := checkReportRange(req.StartDate, req.EndDate); err != nil {
+		logger.Warn("invalid report range", applog.Error(err))
+		return nil, err
+	}
+	if req.Compare && (req.StartDate.IsZero() || req.EndDate.IsZero()) {
+		logger.Warn("comparison requires start and end dates")
+		return nil, fmt.Errorf("%w: comparison requires start and end dates", booking.ErrInvalidReportRange)
+	}
+
+	// 2. 准备查询选项
+	dateBasis := booking.SalesDateBasis(req.DateBasis)
+	if dateBasis == "" {
+		dateBasis = booking.SalesDateBasisBooking
+	}
 	options := &booking.SalesQueryOptions{
-		MovieID:   req.MovieID,
-		CinemaID:  req.CinemaID,
-		StartDate: req.StartDate,
-		EndDate:   req.EndDate,
+		MovieID:      req.MovieID,
+		CinemaID:     req.CinemaID,
+		CinemaHallID: req.CinemaHallID,
+		StartDate:    req.StartDate,
+		EndDate:      req.EndDate,
+		DateBasis:    dateBasis,
 	}
 
-	// 2. 获取销售统计数据
-	stats, err := s.bookingRepo.GetSalesStatistics(ctx, options)
+	// 3. 获取销售统计数据
+	reader := s.salesReader(options, "", loc)
+	stats, err := reader.GetSalesStatistics(ctx, options)
 	if err != nil {
 		logger.Error("failed to get sales statistics", applog.Error(err))
 		return nil, fmt.Errorf("failed to get sales statistics: %w", err)
 	}
+	byMovie, err := reader.GetSalesGroups(ctx, options, &booking.SalesGroupQuery{Dimension: booking.SalesDimensionMovie})
+	if err != nil {
+		logger.Error("failed to get sales by movie", applog.Error(err))
+		return nil, fmt.Errorf("failed to get sales by movie: %w", err)
+	}
+	byHall, err := reader.GetSalesGroups(ctx, options, &booking.SalesGroupQuery{Dimension: booking.SalesDimensionHall})
+	if err != nil {
+		logger.Error("failed to get sales by hall", applog.Error(err))
+		return nil, fmt.Errorf("failed to get sales by hall: %w", err)
+	}
 
-	// 3. 构建响应
+	// 4. 构建响应
 	now := time.Now()
 	resp := &response.GenerateSalesReportResponse{
-		ReportDate:    now.Format("2006-01-02 15:04:05"),
-		StartDate:     req.StartDate.Format("2006-01-02"),
-		EndDate:       req.EndDate.Format("2006-01-02"),
+		ReportDate:    now.In(loc).Format("2006-01-02 15:04:05"),
+		StartDate:     formatReportDate(req.StartDate, loc),
+		EndDate:       formatReportDate(req.EndDate, loc),
 		TotalRevenue:  stats.TotalRevenue,
 		TotalBookings: stats.TotalBookings,
+		TotalTickets:  stats.TotalTickets,
+		DateBasis:     string(dateBasis),
+		Timezone:      loc.String(),
+		Granularity:   req.Granularity,
+		ByMovie:       response.ToSalesBreakdownResponses(byMovie),
+		ByHall:        response.ToSalesBreakdownResponses(byHall),
+	}
+
+	// 5. 时间序列
+	if req.Granularity != "" {
+		series, err := s.salesSeries(ctx, options, req, loc)
+		if err != nil {
+			logger.Warn("failed to build sales series", applog.Error(err))
+			return nil, err
+		}
+		resp.Series = response.ToSalesSeriesResponses(series)
+	}
+
+	// 6. 与上一周期对比
+	if req.Compare {
+		prevStart, prevEnd := booking.PreviousPeriod(req.StartDate, req.EndDate)
+		prevOptions := *options
+		prevOptions.StartDate = prevStart
+		prevOptions.EndDate = prevEnd
+
+		prevStats, err := s.salesReader(&prevOptions, "", loc).GetSalesStatistics(ctx, &prevOptions)
+		if err != nil {
+			logger.Error("failed to get previous period sales statistics", applog.Error(err))
+			return nil, fmt.Errorf("failed to get previous period sales statistics: %w", err)
+		}
+		var prevSeries []*booking.SalesSeriesPoint
+		if req.Granularity != "" {
+			prevSeries, err = s.salesSeries(ctx, &prevOptions, req, loc)
+			if err != nil {
+				logger.Warn("failed to build previous period sales series", applog.Error(err))
+				return nil, err
+			}
+		}
+		resp.PreviousPeriod = response.ToSalesPeriodComparisonResponse(prevStart.In(loc), prevEnd.In(loc), stats, prevStats, prevSeries)
 	}
 
 	logger.Info("generate sales report successfully")
 	return resp, nil
 }
+
+// 按报表时区与粒度生成时间序列
+func (s *reportService) salesSeries(ctx context.Context, options *booking.SalesQueryOptions,
+	req *request.GenerateSalesReportRequest, loc *time.Location) ([]*booking.SalesSeriesPoint, error) {
+	group := &booking.SalesGroupQuery{
+		BucketMinutes: booking.BucketMinutesFor(loc, options.StartDate, options.EndDate),
+		Dimension:     booking.SalesDimension(req.Breakdown),
+	}
+	granularity := booking.SalesGranularity(req.Granularity)
+	buckets, err := s.salesReader(options, granularity, loc).GetSalesGroups(ctx, options, group)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sales groups: %w", err)
+	}
+	return booking.BuildSalesSeries(buckets, granularity, loc, options.StartDate, options.EndDate)
+}
+
+// 解析报表时区，为空时使用服务器时区
+func reportLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w(timezone): %v", booking.ErrInvalidTimezone, name)
+	}
+	return loc, nil
+}
+
+func checkReportRange(start, end time.Time) error {
+	if !start.IsZero() && !end.IsZero() && end.Before(start) {
+		return fmt.Errorf("%w: end date before start date", booking.ErrInvalidReportRange)
+	}
+	return nil
+}
+
+// 报表日期按报表时区显示，未指定时为空
+func formatReportDate(t time.Time, loc *time.Location) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.In(loc).Format("2006-01-02")
+}
+
+// 上座率报告：按场次统计已售座位占影厅容量的比例，并按电影、影厅、星期与时段聚合
+func (s *reportService) GenerateOccupancyReport(ctx context.Context, req *request.GenerateOccupancyReportRequest) (*response.GenerateOccupancyReportResponse, error) {
+	logger := s.logger.With(applog.String("Method", "GenerateOccupancyReport"))
+
+	options := &booking.SalesQueryOptions{
+		MovieID:      req.MovieID,
+		CinemaID:     req.CinemaID,
+		CinemaHallID: req.CinemaHallID,
+		StartDate:    req.StartDate,
+		EndDate:      req.EndDate,
+	}
+
+	items, err := s.bookingRepo.GetShowtimeOccupancy(ctx, options)
+	if err != nil {
+		logger.Error("failed to get showtime occupancy", applog.Error(err))
+		return nil, fmt.Errorf("failed to get showtime occupancy: %w", err)
+	}
+
+	showtimes := make([]*response.ShowtimeOccupancyResponse, len(items))
+	for i, item := range items {
+		showtimes[i] = response.ToShowtimeOccupancyResponse(item)
+	}
+	resp := &response.GenerateOccupancyReportResponse{
+		ReportDate: time.Now().Format("2006-01-02 15:04:05"),
+		StartDate:  req.StartDate.Format("2006-01-02"),
+		EndDate:    req.EndDate.Format("2006-01-02"),
+		Summary:    response.ToOccupancyGroupResponse(booking.SummarizeOccupancy(items)),
+		ByMovie:    response.ToOccupancyGroupResponses(booking.GroupOccupancy(items, booking.OccupancyByMovie)),
+		ByHall:     response.ToOccupancyGroupResponses(booking.GroupOccupancy(items, booking.OccupancyByHall)),
+		ByWeekday:  response.ToOccupancyGroupResponses(booking.GroupOccupancy(items, booking.OccupancyByWeekday)),
+		ByTimeSlot: response.ToOccupancyGroupResponses(booking.GroupOccupancy(items, booking.OccupancyByTimeSlot)),
+		Showtimes:  showtimes,
+	}
+
+	logger.Info("generate occupancy report successfully", applog.Int("showtimes", len(items)))
+	return resp, nil
+}
+
+// 转化漏斗报告：按会话统计查看座位图、锁座、下单与确认各阶段的转化率，并按电影、小时与时段聚合
+func (s *reportService) GenerateFunnelReport(ctx context.Context, req *request.GenerateFunnelReportRequest) (*response.GenerateFunnelReportResponse, error) {
+	logger := s.logger.With(applog.String("Method", "GenerateFunnelReport"))
+
+	options := &analytics.FunnelQueryOptions{
+		MovieID:      req.MovieID,
+		CinemaID:     req.CinemaID,
+		CinemaHallID: req.CinemaHallID,
+		StartDate:    req.StartDate,
+		EndDate:      req.EndDate,
+	}
+
+	counts, err := s.funnelRepo.CountFunnel(ctx, options)
+	if err != nil {
+		logger.Error("failed to count funnel", applog.Error(err))
+		return nil, fmt.Errorf("failed to count funnel: %w", err)
+	}
+	durations, err := s.funnelRepo.FindConfirmDurations(ctx, options)
+	if err != nil {
+		logger.Error("failed to find confirm durations", applog.Error(err))
+		return nil, fmt.Errorf("failed to find confirm durations: %w", err)
+	}
+
+	resp := &response.GenerateFunnelReportResponse{
+		ReportDate: time.Now().Format("2006-01-02 15:04:05"),
+		StartDate:  req.StartDate.Format("2006-01-02"),
+		EndDate:    req.EndDate.Format("2006-01-02"),
+		Summary:    response.ToFunnelGroupResponse(analytics.SummarizeFunnel(counts, durations)),
+		ByMovie:    response.ToFunnelGroupResponses(analytics.GroupFunnel(counts, durations, analytics.FunnelByMovie)),
+		ByHour:     response.ToFunnelGroupResponses(analytics.GroupFunnel(counts, durations, analytics.FunnelByHour)),
+		ByTimeSlot: response.ToFunnelGroupResponses(analytics.GroupFunnel(counts, durations, analytics.FunnelByTimeSlot)),
+	}
+	for _, stage := range analytics.UntrackedStages {
+		resp.UntrackedStages = append(resp.UntrackedStages, string(stage))
+	}
+
+	logger.Info("generate funnel report successfully", applog.Int("rows", len(counts)), applog.Int("confirmed", len(durations)))
+	return resp, nil
+}
+
+// 订单明细导出：由仓储逐行读取，不在内存中汇总
+func (s *reportService) ExportBookings(ctx context.Context, req *request.ExportBookingsRequest, fn func(*booking.SalesRecord) error) error {
+	logger := s.logger.With(applog.String("Method", "ExportBookings"))
+
+	loc, err := reportLocation(req.Timezone)
+	if err != nil {
+		logger.Warn("invalid timezone", applog.String("timezone", req.Timezone), applog.Error(err))
+		return err
+	}
+	if err := checkReportRange(req.StartDate, req.EndDate); err != nil {
+		logger.Warn("invalid report range", applog.Error(err))
+		return err
+	}
+
+	options := &booking.SalesQueryOptions{
+		MovieID:      req.MovieID,
+		CinemaID:     req.CinemaID,
+		CinemaHallID: req.CinemaHallID,
+		StartDate:    req.StartDate,
+		EndDate:      req.EndDate,
+		DateBasis:    booking.SalesDateBasis(req.DateBasis),
+		Status:       booking.BookingStatus(req.Status),
+	}
+	count := 0
+	err = s.bookingRepo.StreamSalesRecords(ctx, options, func(record *booking.SalesRecord) error {
+		record.BookedAt = record.BookedAt.In(loc)
+		record.ShowtimeStart = record.ShowtimeStart.In(loc)
+		count++
+		return fn(record)
+	})
+	if err != nil {
+		logger.Error("failed to export bookings", applog.Int("count", count), applog.Error(err))
+		return fmt.Errorf("failed to export bookings: %w", err)
+	}
+
+	logger.Info("export bookings successfully", applog.Int("count", count))
+	return nil
+}
+
+// 重建每日销售事实：逐个业务日期在独立事务中删除并重新汇总，避免长事务锁住大量订单
+func (s *reportService) RebuildDailySales(ctx context.Context, req *request.RebuildDailySalesRequest) (*response.RebuildDailySalesResponse, error) {
+	logger := s.logger.With(applog.String("Method", "RebuildDailySales"))
+
+	start, end := booking.SalesDate(req.StartDate), booking.SalesDate(req.EndDate)
+	if end.Before(start) {
+		logger.Warn("end date before start date")
+		return nil, fmt.Errorf("%w: end date before start date", booking.ErrInvalidReportRange)
+	}
+	logger = logger.With(applog.String("start", start.Format(time.DateOnly)), applog.String("end", end.Format(time.DateOnly)))
+
+	resp := &response.RebuildDailySalesResponse{
+		StartDate: start.Format(time.DateOnly),
+		EndDate:   end.Format(time.DateOnly),
+	}
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		var rows int64
+		err := s.uow.Execute(ctx, func(ctx context.Context, provider shared.RepositoryProvider) error {
+			var err error
+			rows, err = provider.GetDailySalesRepository().Rebuild(ctx, day, day)
+			return err
+		})
+		if err != nil {
+			logger.Error("failed to rebuild daily sales", applog.String("date", day.Format(time.DateOnly)), applog.Error(err))
+			return nil, fmt.Errorf("failed to rebuild daily sales(%s): %w", day.Format(time.DateOnly), err)
+		}
+		resp.Days++
+		resp.Rows += rows
+	}
+
+	logger.Info("rebuild daily sales successfully", applog.Int("days", resp.Days), applog.Int64("rows", resp.Rows))
+	return resp, nil
+}
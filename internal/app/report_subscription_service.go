@@ -0,0 +1,504 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"mrs/internal/api/dto/request"
+	"mrs/internal/api/dto/response"
+	"mrs/internal/api/export"
+	"mrs/internal/api/i18n"
+	"mrs/internal/domain/booking"
+	"mrs/internal/domain/report"
+	"mrs/internal/domain/shared/vo"
+	applog "mrs/pkg/log"
+	"time"
+)
+
+// 每次扫描处理的到期订阅数与重试数
+const reportSubscriptionBatchSize = 100
+
+type ReportSubscriptionService interface {
+	CreateSubscription(ctx context.Context, req *request.CreateReportSubscriptionRequest) (*response.ReportSubscriptionResponse, error)
+	// 整体替换订阅内容，并按新的 cron 表达式重新计算下一次执行时间
+	UpdateSubscription(ctx context.Context, req *request.UpdateReportSubscriptionRequest) (*response.ReportSubscriptionResponse, error)
+	DeleteSubscription(ctx context.Context, id uint) error
+	GetSubscription(ctx context.Context, id uint) (*response.ReportSubscriptionResponse, error)
+	ListSubscriptions(ctx context.Context, req *request.ListReportSubscriptionsRequest) (*response.PaginatedReportSubscriptionResponse, error)
+	ListRuns(ctx context.Context, req *request.ListReportRunsRequest) (*response.PaginatedReportRunResponse, error)
+	// 立即执行一次（不影响计划），报表周期按当前时间计算，返回执行记录
+	RunSubscription(ctx context.Context, id uint) (*response.ReportRunResponse, error)
+	// 执行到期的订阅与到期的失败重试（由定时任务调用）
+	DispatchReportSubscriptions(ctx context.Context) error
+}
+
+type reportSubscriptionService struct {
+	subscriptionRepo report.SubscriptionRepository
+	runRepo          report.RunRepository
+	reportService    ReportService
+	deliverers       report.Deliverers
+	logger           applog.Logger
+}
+
+func NewReportSubscriptionService(
+	subscriptionRepo report.SubscriptionRepository,
+	runRepo report.RunRepository,
+	reportService ReportService,
+	deliverers report.Deliverers,
+	logger applog.Logger,
+) ReportSubscriptionService {
+	return &reportSubscriptionService{
+		subscriptionRepo: subscriptionRepo,
+		runRepo:          runRepo,
+		reportService:    reportService,
+		deliverers:       deliverers,
+		logger:           logger.With(applog.String("Service", "ReportSubscriptionService")),
+	}
+}
+
+// 校验订阅、确认投递渠道可用，并计算下一次执行时间
+func (s *reportSubscriptionService) prepare(sub *report.Subscription, now time.Time) error {
+	if err := sub.Validate(); err != nil {
+		return err
+	}
+	if _, ok := s.deliverers[sub.Channel]; !ok {
+		return fmt.Errorf("%w: %s", report.ErrDelivererUnavailable, sub.Channel)
+	}
+	return sub.ScheduleNext(now)
+}
+
+// 解析订阅的语言标签，为空时表头使用默认语言
+func subscriptionLocale(tag string) (vo.Locale, error) {
+	if tag == "" {
+		return "", nil
+	}
+	locale, err := vo.ParseLocale(tag)
+	if err != nil {
+		return "", fmt.Errorf("%w: invalid locale %q", report.ErrInvalidSubscription, tag)
+	}
+	return locale, nil
+}
+
+func (s *reportSubscriptionService) CreateSubscription(ctx context.Context, req *request.CreateReportSubscriptionRequest) (*response.ReportSubscriptionResponse, error) {
+	logger := s.logger.With(applog.String("Method", "CreateSubscription"), applog.String("name", req.Name))
+
+	locale, err := subscriptionLocale(req.Locale)
+	if err != nil {
+		logger.Warn("invalid locale", applog.Error(err))
+		return nil, err
+	}
+	sub := req.ToDomain(locale)
+	sub.CreatedBy = vo.UserID(req.CreatedBy)
+	if err := s.prepare(sub, time.Now()); err != nil {
+		logger.Warn("invalid report subscription", applog.Error(err))
+		return nil, err
+	}
+
+	sub, err = s.subscriptionRepo.Create(ctx, sub)
+	if err != nil {
+		logger.Error("failed to create report subscription", applog.Error(err))
+		return nil, err
+	}
+
+	logger.Info("create report subscription successfully", applog.Uint("subscription_id", uint(sub.ID)))
+	return response.ToReportSubscriptionResponse(sub), nil
+}
+
+func (s *reportSubscriptionService) UpdateSubscription(ctx context.Context, req *request.UpdateReportSubscriptionRequest) (*response.ReportSubscriptionResponse, error) {
+	logger := s.logger.With(applog.String("Method", "UpdateSubscription"), applog.Uint("subscription_id", req.ID))
+
+	locale, err := subscriptionLocale(req.Locale)
+	if err != nil {
+		logger.Warn("invalid locale", applog.Error(err))
+		return nil, err
+	}
+	sub := req.ToDomain(locale)
+	sub.ID = vo.ReportSubscriptionID(req.ID)
+	if err := s.prepare(sub, time.Now()); err != nil {
+		logger.Warn("invalid report subscription", applog.Error(err))
+		return nil, err
+	}
+
+	if err := s.subscriptionRepo.Update(ctx, sub); err != nil {
+		logger.Warn("failed to update report subscription", applog.Error(err))
+		return nil, err
+	}
+
+	// 更新操作响应报文需要包含完整内容
+	resp, err := s.GetSubscription(ctx, req.ID)
+	if err != nil {
+		logger.Error("failed to get report subscription", applog.Error(err))
+		return nil, err
+	}
+
+	logger.Info("update report subscription successfully")
+	return resp, nil
+}
+
+func (s *reportSubscriptionService) DeleteSubscription(ctx context.Context, id uint) error {
+	logger := s.logger.With(applog.String("Method", "DeleteSubscription"), applog.Uint("subscription_id", id))
+
+	if err := s.subscriptionRepo.Delete(ctx, vo.ReportSubscriptionID(id)); err != nil {
+		logger.Warn("failed to delete report subscription", applog.Error(err))
+		return err
+	}
+
+	logger.Info("delete report subscription successfully")
+	return nil
+}
+
+func (s *reportSubscriptionService) GetSubscription(ctx context.Context, id uint) (*response.ReportSubscriptionResponse, error) {
+	logger := s.logger.With(applog.String("Method", "GetSubscription"), applog.Uint("subscription_id", id))
+
+	sub, err := s.subscriptionRepo.FindByID(ctx, vo.ReportSubscriptionID(id))
+	if err != nil {
+		logger.Warn("failed to find report subscription", applog.Error(err))
+		return nil, err
+	}
+
+	logger.Info("get report subscription successfully")
+	return response.ToReportSubscriptionResponse(sub), nil
+}
+
+func (s *reportSubscriptionService) ListSubscriptions(ctx context.Context, req *request.ListReportSubscriptionsRequest) (*response.PaginatedReportSubscriptionResponse, error) {
+	logger := s.logger.With(applog.String("Method", "ListSubscriptions"))
+
+	subs, total, err := s.subscriptionRepo.List(ctx, req.Page, req.PageSize)
+	if err != nil {
+		logger.Error("failed to list report subscriptions", applog.Error(err))
+		return nil, err
+	}
+
+	subscriptionResponses := make([]*response.ReportSubscriptionResponse, 0, len(subs))
+	for _, sub := range subs {
+		subscriptionResponses = append(subscriptionResponses, response.ToReportSubscriptionResponse(sub))
+	}
+
+	logger.Info("list report subscriptions successfully", applog.Int64("total", total))
+	return &response.PaginatedReportSubscriptionResponse{
+		Pagination: response.PaginationResponse{
+			Page:       req.Page,
+			PageSize:   req.PageSize,
+			TotalCount: int(total),
+			TotalPages: int(math.Ceil(float64(total) / float64(req.PageSize))),
+		},
+		Subscriptions: subscriptionResponses,
+	}, nil
+}
+
+func (s *reportSubscriptionService) ListRuns(ctx context.Context, req *request.ListReportRunsRequest) (*response.PaginatedReportRunResponse, error) {
+	logger := s.logger.With(applog.String("Method", "ListRuns"), applog.Uint("subscription_id", req.SubscriptionID))
+
+	id := vo.ReportSubscriptionID(req.SubscriptionID)
+	if _, err := s.subscriptionRepo.FindByID(ctx, id); err != nil {
+		logger.Warn("failed to find report subscription", applog.Error(err))
+		return nil, err
+	}
+	runs, total, err := s.runRepo.ListBySubscription(ctx, id, req.Page, req.PageSize)
+	if err != nil {
+		logger.Error("failed to list report runs", applog.Error(err))
+		return nil, err
+	}
+
+	runResponses := make([]*response.ReportRunResponse, 0, len(runs))
+	for _, run := range runs {
+		runResponses = append(runResponses, response.ToReportRunResponse(run))
+	}
+
+	logger.Info("list report runs successfully", applog.Int64("total", total))
+	return &response.PaginatedReportRunResponse{
+		Pagination: response.PaginationResponse{
+			Page:       req.Page,
+			PageSize:   req.PageSize,
+			TotalCount: int(total),
+			TotalPages: int(math.Ceil(float64(total) / float64(req.PageSize))),
+		},
+		Runs: runResponses,
+	}, nil
+}
+
+func (s *reportSubscriptionService) RunSubscription(ctx context.Context, id uint) (*response.ReportRunResponse, error) {
+	logger := s.logger.With(applog.String("Method", "RunSubscription"), applog.Uint("subscription_id", id))
+
+	sub, err := s.subscriptionRepo.FindByID(ctx, vo.ReportSubscriptionID(id))
+	if err != nil {
+		logger.Warn("failed to find report subscription", applog.Error(err))
+		return nil, err
+	}
+
+	now := time.Now()
+	run, err := s.execute(ctx, sub, report.NewRun(sub.ID, report.RunTriggerManual, now, now))
+	if err != nil {
+		logger.Error("failed to record report run", applog.Error(err))
+		return nil, err
+	}
+
+	logger.Info("run report subscription", applog.Uint("run_id", uint(run.ID)), applog.String("status", string(run.Status)))
+	return response.ToReportRunResponse(run), nil
+}
+
+// 先执行到期的订阅，再执行到期的重试。领取成功后才执行，多个实例同时扫描时每次执行只发生一次。
+// 服务停机错过的多次计划只补执行一次（下一次执行时间按当前时间计算）
+func (s *reportSubscriptionService) DispatchReportSubscriptions(ctx context.Context) error {
+	logger := s.logger.With(applog.String("Method", "DispatchReportSubscriptions"))
+	now := time.Now()
+
+	subs, err := s.subscriptionRepo.FindDue(ctx, now, reportSubscriptionBatchSize)
+	if err != nil {
+		logger.Error("failed to find due report subscriptions", applog.Error(err))
+		return err
+	}
+	executed, failed := 0, 0
+	for _, sub := range subs {
+		scheduledAt := *sub.NextRunAt
+		if err := sub.ScheduleNext(now); err != nil {
+			// 表达式在保存后失效（如时区数据变化），停止计划，避免每次扫描都重复执行
+			logger.Error("failed to schedule next run, disabling schedule", applog.Uint("subscription_id", uint(sub.ID)), applog.Error(err))
+			sub.NextRunAt = nil
+		}
+		claimed, err := s.subscriptionRepo.ClaimDue(ctx, sub.ID, scheduledAt, sub.NextRunAt)
+		if err != nil {
+			logger.Error("failed to claim report subscription", applog.Uint("subscription_id", uint(sub.ID)), applog.Error(err))
+			return err
+		}
+		if !claimed {
+			continue
+		}
+
+		run, err := s.execute(ctx, sub, report.NewRun(sub.ID, report.RunTriggerSchedule, scheduledAt, time.Now()))
+		if err != nil {
+			logger.Error("failed to record report run", applog.Uint("subscription_id", uint(sub.ID)), applog.Error(err))
+			return err
+		}
+		executed++
+		if run.Status == report.RunStatusFailed {
+			failed++
+		}
+	}
+
+	retries, err := s.runRepo.FindDueRetries(ctx, now, reportSubscriptionBatchSize)
+	if err != nil {
+		logger.Error("failed to find due report retries", applog.Error(err))
+		return err
+	}
+	retried := 0
+	for _, previous := range retries {
+		claimed, err := s.runRepo.ClaimRetry(ctx, previous.ID, *previous.RetryAt)
+		if err != nil {
+			logger.Error("failed to claim report retry", applog.Uint("run_id", uint(previous.ID)), applog.Error(err))
+			return err
+		}
+		if !claimed {
+			continue
+		}
+
+		sub, err := s.subscriptionRepo.FindByID(ctx, previous.SubscriptionID)
+		if err != nil {
+			if errors.Is(err, report.ErrSubscriptionNotFound) {
+				continue
+			}
+			logger.Error("failed to find report subscription", applog.Uint("subscription_id", uint(previous.SubscriptionID)), applog.Error(err))
+			return err
+		}
+		// 停用的订阅不再重试
+		if !sub.Enabled {
+			logger.Info("skip retry of disabled report subscription", applog.Uint("subscription_id", uint(sub.ID)))
+			continue
+		}
+
+		run, err := s.execute(ctx, sub, previous.Retry(time.Now()))
+		if err != nil {
+			logger.Error("failed to record report run", applog.Uint("subscription_id", uint(sub.ID)), applog.Error(err))
+			return err
+		}
+		retried++
+		if run.Status == report.RunStatusFailed {
+			failed++
+		}
+	}
+
+	if executed > 0 || retried > 0 {
+		logger.Info("dispatch report subscriptions successfully",
+			applog.Int("executed", executed), applog.Int("retried", retried), applog.Int("failed", failed))
+	}
+	return nil
+}
+
+// 执行一次：记录执行记录，生成报表并投递，按结果更新执行记录（失败时安排重试）。
+// 只有执行记录读写失败时返回错误，生成与投递的错误记录在执行记录中
+func (s *reportSubscriptionService) execute(ctx context.Context, sub *report.Subscription, run *report.Run) (*report.Run, error) {
+	logger := s.logger.With(applog.String("Method", "execute"), applog.Uint("subscription_id", uint(sub.ID)),
+		applog.String("trigger", string(run.Trigger)), applog.Int("attempt", run.Attempt))
+
+	loc, locErr := sub.Location()
+	if locErr != nil {
+		loc = time.Local
+	}
+	run.PeriodStart, run.PeriodEnd = sub.Parameters.Period.Range(run.ScheduledAt, loc)
+	run, err := s.runRepo.Create(ctx, run)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := s.generate(ctx, sub, run)
+	if err == nil {
+		err = locErr
+	}
+	if err == nil {
+		err = s.deliver(ctx, sub, run, file)
+	}
+
+	if err != nil {
+		run.Fail(err, time.Now())
+		logger.Warn("report subscription run failed", applog.Uint("run_id", uint(run.ID)), applog.Error(err))
+	} else {
+		run.Succeed(file.Name, int64(len(file.Data)), time.Now())
+		logger.Info("report subscription run succeeded", applog.Uint("run_id", uint(run.ID)),
+			applog.String("file", file.Name), applog.Int("size", len(file.Data)))
+	}
+	if err := s.runRepo.Update(ctx, run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// 通过 ReportService 生成报表，按订阅的格式与语言写出文件。
+// 销售与上座率报告的 json 格式与报表接口的响应相同，订单明细的 json 格式与导出接口相同
+func (s *reportSubscriptionService) generate(ctx context.Context, sub *report.Subscription, run *report.Run) (*report.File, error) {
+	params := sub.Parameters
+	format := export.Format(sub.Format)
+	var buf bytes.Buffer
+	var name string
+
+	switch sub.ReportType {
+	case report.TypeSales:
+		name = "sales-report"
+		resp, err := s.reportService.GenerateSalesReport(ctx, &request.GenerateSalesReportRequest{
+			MovieID:      params.MovieID,
+			CinemaID:     params.CinemaID,
+			CinemaHallID: params.CinemaHallID,
+			StartDate:    run.PeriodStart,
+			EndDate:      run.PeriodEnd,
+			DateBasis:    params.DateBasis,
+			Granularity:  params.Granularity,
+			Timezone:     sub.Timezone,
+			Breakdown:    params.Breakdown,
+			Compare:      params.Compare,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeReport(&buf, sub, resp, func(t *export.Tables) error { return export.WriteSalesReport(t, resp) }); err != nil {
+			return nil, err
+		}
+	case report.TypeOccupancy:
+		name = "occupancy-report"
+		resp, err := s.reportService.GenerateOccupancyReport(ctx, &request.GenerateOccupancyReportRequest{
+			MovieID:      params.MovieID,
+			CinemaID:     params.CinemaID,
+			CinemaHallID: params.CinemaHallID,
+			StartDate:    run.PeriodStart,
+			EndDate:      run.PeriodEnd,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeReport(&buf, sub, resp, func(t *export.Tables) error { return export.WriteOccupancyReport(t, resp) }); err != nil {
+			return nil, err
+		}
+	case report.TypeBookings:
+		name = "bookings"
+		tables, err := newSubscriptionTables(&buf, sub, false)
+		if err != nil {
+			return nil, err
+		}
+		if err := tables.Begin("bookings", export.BookingColumns...); err != nil {
+			return nil, err
+		}
+		err = s.reportService.ExportBookings(ctx, &request.ExportBookingsRequest{
+			MovieID:      params.MovieID,
+			CinemaID:     params.CinemaID,
+			CinemaHallID: params.CinemaHallID,
+			StartDate:    run.PeriodStart,
+			EndDate:      run.PeriodEnd,
+			DateBasis:    params.DateBasis,
+			Status:       params.Status,
+			Timezone:     sub.Timezone,
+		}, func(r *booking.SalesRecord) error {
+			return tables.W.WriteRow(export.BookingRow(r)...)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := tables.W.Close(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown report type %q", report.ErrInvalidSubscription, sub.ReportType)
+	}
+
+	return &report.File{
+		Name:        export.FileName(name, format, run.PeriodStart),
+		ContentType: export.ContentType(format),
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+// 写出已生成的报表：json 格式直接编码响应，其余格式按表格写出
+func writeReport(w io.Writer, sub *report.Subscription, resp any, write func(*export.Tables) error) error {
+	if sub.Format == report.FormatJSON {
+		return json.NewEncoder(w).Encode(resp)
+	}
+	tables, err := newSubscriptionTables(w, sub, true)
+	if err != nil {
+		return err
+	}
+	if err := write(tables); err != nil {
+		return err
+	}
+	return tables.W.Close()
+}
+
+// 按订阅的语言创建表格写入器（表头与 CSV 数字格式），未指定语言时使用默认语言
+func newSubscriptionTables(w io.Writer, sub *report.Subscription, sections bool) (*export.Tables, error) {
+	var locales []vo.Locale
+	opts := export.Options{Locale: "en", Sections: sections}
+	if sub.Locale != "" {
+		locales = []vo.Locale{sub.Locale}
+		opts.Locale = sub.Locale
+	}
+	tw, err := export.NewTableWriter(export.Format(sub.Format), w, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &export.Tables{W: tw, Label: func(key string) string { return i18n.Label(locales, key) }}, nil
+}
+
+// 通过订阅的渠道投递报表文件
+func (s *reportSubscriptionService) deliver(ctx context.Context, sub *report.Subscription, run *report.Run, file *report.File) error {
+	deliverer, ok := s.deliverers[sub.Channel]
+	if !ok {
+		return fmt.Errorf("%w: %s", report.ErrDelivererUnavailable, sub.Channel)
+	}
+
+	loc, _ := sub.Location()
+	start, end := run.PeriodStart.In(loc), run.PeriodEnd.In(loc)
+	period := start.Format(time.DateOnly)
+	if end.Format(time.DateOnly) != period {
+		period += " ~ " + end.Format(time.DateOnly)
+	}
+	body := fmt.Sprintf("Report: %s\nType: %s\nPeriod: %s (%s)\nAttachment: %s\n",
+		sub.Name, sub.ReportType, period, loc.String(), file.Name)
+
+	return deliverer.Deliver(ctx, &report.Delivery{
+		Subscription: sub,
+		Run:          run,
+		Subject:      fmt.Sprintf("%s: %s", sub.Name, period),
+		Body:         body,
+		File:         file,
+	})
+}
@@ -0,0 +1,351 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"mrs/internal/api/dto/request"
+	"mrs/internal/api/dto/response"
+	"mrs/internal/domain/booking"
+	"mrs/internal/domain/movie"
+	"mrs/internal/domain/review"
+	"mrs/internal/domain/shared"
+	"mrs/internal/domain/shared/lock"
+	"mrs/internal/domain/shared/vo"
+	applog "mrs/pkg/log"
+	"time"
+)
+
+type ReviewService interface {
+	CreateReview(ctx context.Context, req *request.CreateReviewRequest) (*response.ReviewResponse, error)
+	ListMovieReviews(ctx context.Context, req *request.ListMovieReviewsRequest) (*response.PaginatedReviewResponse, error)
+	UpdateReview(ctx context.Context, req *request.UpdateReviewRequest) (*response.ReviewResponse, error)
+	DeleteReview(ctx context.Context, req *request.DeleteReviewRequest) error
+	VoteHelpful(ctx context.Context, req *request.VoteReviewRequest) (*response.ReviewResponse, error)
+	UnvoteHelpful(ctx context.Context, req *request.VoteReviewRequest) (*response.ReviewResponse, error)
+	ListReviews(ctx context.Context, req *request.ListReviewsRequest) (*response.PaginatedReviewResponse, error)
+	ModerateReview(ctx context.Context, req *request.ModerateReviewRequest) (*response.ReviewResponse, error)
+}
+
+type reviewService struct {
+	uow          shared.UnitOfWork
+	reviewRepo   review.ReviewRepository
+	bookingRepo  booking.BookingRepository
+	movieRepo    movie.MovieRepository
+	movieCache   movie.MovieCache
+	lockProvider lock.LockProvider
+	logger       applog.Logger
+}
+
+func NewReviewService(
+	uow shared.UnitOfWork,
+	reviewRepo review.ReviewRepository,
+	bookingRepo booking.BookingRepository,
+	movieRepo movie.MovieRepository,
+	movieCache movie.MovieCache,
+	lockProvider lock.LockProvider,
+	logger applog.Logger,
+) ReviewService {
+	return &reviewService{
+		uow:          uow,
+		reviewRepo:   reviewRepo,
+		bookingRepo:  bookingRepo,
+		movieRepo:    movieRepo,
+		movieCache:   movieCache,
+		lockProvider: lockProvider,
+		logger:       logger.With(applog.String("Service", "ReviewService")),
+	}
+}
+
+// 发表评价，只有看过电影的用户可以评价，新评价进入待审核状态
+func (s *reviewService) CreateReview(ctx context.Context, req *request.CreateReviewRequest) (*response.ReviewResponse, error) {
+	logger := s.logger.With(applog.String("Method", "CreateReview"),
+		applog.Uint("movie_id", req.MovieID), applog.Uint("user_id", req.UserID))
+
+	if _, err := s.movieRepo.FindByID(ctx, vo.MovieID(req.MovieID)); err != nil {
+		logger.Error("failed to find movie", applog.Error(err))
+		return nil, err
+	}
+
+	attended, err := s.bookingRepo.HasAttendedMovie(ctx, vo.UserID(req.UserID), vo.MovieID(req.MovieID), time.Now())
+	if err != nil {
+		logger.Error("failed to check attended movie", applog.Error(err))
+		return nil, err
+	}
+	if !attended {
+		logger.Warn("user has not watched the movie")
+		return nil, review.ErrNotVerifiedViewer
+	}
+
+	rv, err := review.NewReview(vo.MovieID(req.MovieID), vo.UserID(req.UserID), req.Score, req.Content)
+	if err != nil {
+		logger.Warn("invalid review", applog.Error(err))
+		return nil, err
+	}
+	rv, err = s.reviewRepo.Create(ctx, rv)
+	if err != nil {
+		logger.Error("failed to create review", applog.Error(err))
+		return nil, err
+	}
+
+	logger.Info("create review successfully", applog.Uint("review_id", uint(rv.ID)))
+	return response.ToReviewResponse(rv), nil
+}
+
+// 查询电影的已发布评价
+func (s *reviewService) ListMovieReviews(ctx context.Context, req *request.ListMovieReviewsRequest) (*response.PaginatedReviewResponse, error) {
+	logger := s.logger.With(applog.String("Method", "ListMovieReviews"), applog.Uint("movie_id", req.MovieID))
+
+	if _, err := s.movieRepo.FindByID(ctx, vo.MovieID(req.MovieID)); err != nil {
+		logger.Error("failed to find movie", applog.Error(err))
+		return nil, err
+	}
+
+	return s.listReviews(ctx, logger, req.ToDomain())
+}
+
+// 管理员查询评价
+func (s *reviewService) ListReviews(ctx context.Context, req *request.ListReviewsRequest) (*response.PaginatedReviewResponse, error) {
+	logger := s.logger.With(applog.String("Method", "ListReviews"))
+	return s.listReviews(ctx, logger, req.ToDomain())
+}
+
+func (s *reviewService) listReviews(ctx context.Context, logger applog.Logger, options *review.ReviewQueryOptions) (*response.PaginatedReviewResponse, error) {
+	reviews, total, err := s.reviewRepo.List(ctx, options)
+	if err != nil {
+		logger.Error("failed to list reviews", applog.Error(err))
+		return nil, err
+	}
+
+	reviewResponses := make([]*response.ReviewResponse, 0, len(reviews))
+	for _, rv := range reviews {
+		reviewResponses = append(reviewResponses, response.ToReviewResponse(rv))
+	}
+
+	logger.Info("list reviews successfully", applog.Int64("total", total))
+	return &response.PaginatedReviewResponse{
+		Pagination: response.PaginationResponse{
+			Page:       options.Page,
+			PageSize:   options.PageSize,
+			TotalCount: int(total),
+			TotalPages: int(math.Ceil(float64(total) / float64(options.PageSize))),
+		},
+		Reviews: reviewResponses,
+	}, nil
+}
+
+// 修改自己的评价，修改后重新进入待审核状态（已发布的评价会从评分聚合中扣除）
+func (s *reviewService) UpdateReview(ctx context.Context, req *request.UpdateReviewRequest) (*response.ReviewResponse, error) {
+	logger := s.logger.With(applog.String("Method", "UpdateReview"),
+		applog.Uint("review_id", req.ID), applog.Uint("user_id", req.UserID))
+
+	rv, err := s.mutateReview(ctx, vo.ReviewID(req.ID), func(rv *review.Review) error {
+		if rv.UserID != vo.UserID(req.UserID) {
+			return review.ErrReviewNotOwned
+		}
+		return rv.Edit(req.Score, req.Content)
+	})
+	if err != nil {
+		logger.Error("failed to update review", applog.Error(err))
+		return nil, err
+	}
+
+	logger.Info("update review successfully")
+	return response.ToReviewResponse(rv), nil
+}
+
+// 管理员审核评价：通过、驳回、隐藏或恢复
+func (s *reviewService) ModerateReview(ctx context.Context, req *request.ModerateReviewRequest) (*response.ReviewResponse, error) {
+	logger := s.logger.With(applog.String("Method", "ModerateReview"),
+		applog.Uint("review_id", req.ID), applog.String("action", req.Action))
+
+	rv, err := s.mutateReview(ctx, vo.ReviewID(req.ID), func(rv *review.Review) error {
+		switch req.Action {
+		case request.ReviewActionApprove:
+			return rv.Approve()
+		case request.ReviewActionReject:
+			return rv.Reject(req.Note)
+		case request.ReviewActionHide:
+			return rv.Hide(req.Note)
+		case request.ReviewActionRestore:
+			return rv.Restore()
+		}
+		return fmt.Errorf("%w: unknown action %q", review.ErrInvalidReviewTransition, req.Action)
+	})
+	if err != nil {
+		logger.Error("failed to moderate review", applog.Error(err))
+		return nil, err
+	}
+
+	logger.Info("moderate review successfully", applog.String("status", string(rv.Status)))
+	return response.ToReviewResponse(rv), nil
+}
+
+// 删除自己的评价
+func (s *reviewService) DeleteReview(ctx context.Context, req *request.DeleteReviewRequest) error {
+	logger := s.logger.With(applog.String("Method", "DeleteReview"),
+		applog.Uint("review_id", req.ID), applog.Uint("user_id", req.UserID))
+
+	lk, err := s.lockProvider.Acquire(ctx, review.GetReviewLockKey(vo.ReviewID(req.ID)), lock.DefaultLockTTL)
+	if err != nil {
+		logger.Error("failed to acquire lock", applog.Error(err))
+		return err
+	}
+	defer lk.Release(ctx)
+
+	var rv *review.Review
+	err = s.uow.Execute(ctx, func(ctx context.Context, provider shared.RepositoryProvider) error {
+		reviewRepo := provider.GetReviewRepository()
+		rv, err = reviewRepo.FindByID(ctx, vo.ReviewID(req.ID))
+		if err != nil {
+			return err
+		}
+		if rv.UserID != vo.UserID(req.UserID) {
+			return review.ErrReviewNotOwned
+		}
+		if err := reviewRepo.Delete(ctx, rv.ID); err != nil {
+			return err
+		}
+		count, score := rv.RatingContribution()
+		return s.applyRatingDelta(ctx, provider, rv.MovieID, -count, -score)
+	})
+	if err != nil {
+		logger.Error("failed to delete review", applog.Error(err))
+		return err
+	}
+
+	if count, _ := rv.RatingContribution(); count != 0 {
+		s.refreshMovieCache(ctx, rv.MovieID)
+	}
+
+	logger.Info("delete review successfully")
+	return nil
+}
+
+// 为已发布的评价投"有用"票，不能为自己的评价投票
+func (s *reviewService) VoteHelpful(ctx context.Context, req *request.VoteReviewRequest) (*response.ReviewResponse, error) {
+	logger := s.logger.With(applog.String("Method", "VoteHelpful"),
+		applog.Uint("review_id", req.ID), applog.Uint("user_id", req.UserID))
+
+	rv, err := s.vote(ctx, req, func(ctx context.Context, reviewRepo review.ReviewRepository, rv *review.Review) error {
+		if rv.Status != review.ReviewStatusPublished {
+			return review.ErrReviewNotPublished
+		}
+		if rv.UserID == vo.UserID(req.UserID) {
+			return review.ErrVoteOwnReview
+		}
+		return reviewRepo.AddHelpfulVote(ctx, rv.ID, vo.UserID(req.UserID))
+	})
+	if err != nil {
+		logger.Error("failed to vote review", applog.Error(err))
+		return nil, err
+	}
+
+	logger.Info("vote review successfully")
+	return response.ToReviewResponse(rv), nil
+}
+
+// 撤销"有用"投票
+func (s *reviewService) UnvoteHelpful(ctx context.Context, req *request.VoteReviewRequest) (*response.ReviewResponse, error) {
+	logger := s.logger.With(applog.String("Method", "UnvoteHelpful"),
+		applog.Uint("review_id", req.ID), applog.Uint("user_id", req.UserID))
+
+	rv, err := s.vote(ctx, req, func(ctx context.Context, reviewRepo review.ReviewRepository, rv *review.Review) error {
+		return reviewRepo.RemoveHelpfulVote(ctx, rv.ID, vo.UserID(req.UserID))
+	})
+	if err != nil {
+		logger.Error("failed to unvote review", applog.Error(err))
+		return nil, err
+	}
+
+	logger.Info("unvote review successfully")
+	return response.ToReviewResponse(rv), nil
+}
+
+// 在事务中执行投票操作并返回最新的评价（投票表的唯一索引保证重复投票被拒绝，无需加锁）
+func (s *reviewService) vote(ctx context.Context, req *request.VoteReviewRequest,
+	fn func(ctx context.Context, reviewRepo review.ReviewRepository, rv *review.Review) error) (*review.Review, error) {
+	var rv *review.Review
+	err := s.uow.Execute(ctx, func(ctx context.Context, provider shared.RepositoryProvider) error {
+		reviewRepo := provider.GetReviewRepository()
+		var err error
+		rv, err = reviewRepo.FindByID(ctx, vo.ReviewID(req.ID))
+		if err != nil {
+			return err
+		}
+		if err := fn(ctx, reviewRepo, rv); err != nil {
+			return err
+		}
+		rv, err = reviewRepo.FindByID(ctx, rv.ID)
+		return err
+	})
+	return rv, err
+}
+
+// 在评价锁与事务内修改评价，并按修改前后对评分聚合的贡献差值增量更新电影评分
+func (s *reviewService) mutateReview(ctx context.Context, id vo.ReviewID, fn func(rv *review.Review) error) (*review.Review, error) {
+	lk, err := s.lockProvider.Acquire(ctx, review.GetReviewLockKey(id), lock.DefaultLockTTL)
+	if err != nil {
+		return nil, err
+	}
+	defer lk.Release(ctx)
+
+	var rv *review.Review
+	var ratingChanged bool
+	err = s.uow.Execute(ctx, func(ctx context.Context, provider shared.RepositoryProvider) error {
+		reviewRepo := provider.GetReviewRepository()
+		rv, err = reviewRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		beforeCount, beforeScore := rv.RatingContribution()
+		if err := fn(rv); err != nil {
+			return err
+		}
+		if err := reviewRepo.Update(ctx, rv); err != nil {
+			return err
+		}
+		afterCount, afterScore := rv.RatingContribution()
+
+		ratingChanged = afterCount != beforeCount || afterScore != beforeScore
+		return s.applyRatingDelta(ctx, provider, rv.MovieID, afterCount-beforeCount, afterScore-beforeScore)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if ratingChanged {
+		s.refreshMovieCache(ctx, rv.MovieID)
+	}
+
+	// 返回最新的评价（包含更新时间与投票数）
+	return s.reviewRepo.FindByID(ctx, id)
+}
+
+func (s *reviewService) applyRatingDelta(ctx context.Context, provider shared.RepositoryProvider,
+	movieID vo.MovieID, countDelta, scoreDelta int) error {
+	if countDelta == 0 && scoreDelta == 0 {
+		return nil
+	}
+	return provider.GetMovieRepository().ApplyReviewDelta(ctx, movieID, countDelta, scoreDelta)
+}
+
+// 评分聚合变化后刷新电影缓存，刷新失败时删除缓存，由下次读取回源
+func (s *reviewService) refreshMovieCache(ctx context.Context, movieID vo.MovieID) {
+	logger := s.logger.With(applog.String("Method", "refreshMovieCache"), applog.Uint("movie_id", uint(movieID)))
+
+	mv, err := s.movieRepo.FindByID(ctx, movieID)
+	if err == nil {
+		err = s.movieCache.SetMovie(ctx, mv, movie.DefaultMovieExpiration)
+	}
+	if err != nil {
+		logger.Warn("failed to refresh movie cache", applog.Error(err))
+		if err := s.movieCache.DeleteMovie(ctx, movieID); err != nil {
+			logger.Warn("failed to delete movie from cache", applog.Error(err))
+		}
+		return
+	}
+
+	logger.Info("refresh movie cache successfully")
+}
@@ -8,12 +8,17 @@ import (
 	"math/rand/v2"
 	"mrs/internal/api/dto/request"
 	"mrs/internal/api/dto/response"
+	"mrs/internal/domain/analytics"
 	"mrs/internal/domain/booking"
 	"mrs/internal/domain/cinema"
+	"mrs/internal/domain/loyalty"
+	"mrs/internal/domain/movie"
+	"mrs/internal/domain/notification"
 	"mrs/internal/domain/shared"
 	"mrs/internal/domain/shared/lock"
 	"mrs/internal/domain/shared/vo"
 	"mrs/internal/domain/showtime"
+	"mrs/internal/domain/trending"
 	applog "mrs/pkg/log"
 	"time"
 )
@@ -23,20 +28,29 @@ type ShowtimeService interface {
 	GetShowtime(ctx context.Context, req *request.GetShowtimeRequest) (*response.ShowtimeResponse, error)
 	UpdateShowtime(ctx context.Context, req *request.UpdateShowtimeRequest) (*response.ShowtimeResponse, error)
 	DeleteShowtime(ctx context.Context, req *request.DeleteShowtimeRequest) error
+	CancelShowtime(ctx context.Context, req *request.CancelShowtimeRequest) (*response.CancelShowtimeResponse, error)
 	ListShowtimes(ctx context.Context, req *request.ListShowtimesRequest) (*response.PaginatedShowtimeResponse, error)
+	ListAdminShowtimes(ctx context.Context, req *request.ListAdminShowtimesRequest) (*response.PaginatedShowtimeResponse, error)
 	GetSeatMap(ctx context.Context, req *request.GetSeatMapRequest) (*response.SeatMapResponse, error)
 	InitSeatMap(ctx context.Context, showtimeID vo.ShowtimeID) error
+	MigrateShowtimeLayouts(ctx context.Context, req *request.MigrateShowtimeLayoutsRequest) (*response.MigrateShowtimeLayoutsResponse, error)
+	// 批量写入缓冲中的查看座位图事件（定时任务）
+	FlushSeatMapViews(ctx context.Context) error
 }
 
 type showtimeService struct {
-	uow          shared.UnitOfWork
-	showRepo     showtime.ShowtimeRepository
-	seatRepo     cinema.SeatRepository
-	bookingRepo  booking.BookingRepository
-	showCache    showtime.ShowtimeCache
-	seatCache    cinema.SeatCache
-	lockProvider lock.LockProvider
-	logger       applog.Logger
+	uow           shared.UnitOfWork
+	showRepo      showtime.ShowtimeRepository
+	seatRepo      cinema.SeatRepository
+	bookingRepo   booking.BookingRepository
+	showCache     showtime.ShowtimeCache
+	seatCache     cinema.SeatCache
+	lockProvider  lock.LockProvider
+	notifier      notification.Notifier
+	trendingCache trending.TrendingCache
+	funnelRepo    analytics.FunnelEventRepository
+	viewBuffer    *analytics.SeatMapViewBuffer
+	logger        applog.Logger
 }
 
 func NewShowtimeService(
@@ -47,17 +61,24 @@ func NewShowtimeService(
 	showCache showtime.ShowtimeCache,
 	seatCache cinema.SeatCache,
 	lockProvider lock.LockProvider,
+	notifier notification.Notifier,
+	trendingCache trending.TrendingCache,
+	funnelRepo analytics.FunnelEventRepository,
 	logger applog.Logger,
 ) ShowtimeService {
 	return &showtimeService{
-		uow:          uow,
-		showRepo:     showRepo,
-		seatRepo:     seatRepo,
-		bookingRepo:  bookingRepo,
-		showCache:    showCache,
-		seatCache:    seatCache,
-		lockProvider: lockProvider,
-		logger:       logger.With(applog.String("Service", "ShowtimeService")),
+		uow:           uow,
+		showRepo:      showRepo,
+		seatRepo:      seatRepo,
+		bookingRepo:   bookingRepo,
+		showCache:     showCache,
+		seatCache:     seatCache,
+		lockProvider:  lockProvider,
+		notifier:      notifier,
+		trendingCache: trendingCache,
+		funnelRepo:    funnelRepo,
+		viewBuffer:    analytics.NewSeatMapViewBuffer(analytics.SeatMapViewDedupWindow, analytics.MaxPendingSeatMapViews),
+		logger:        logger.With(applog.String("Service", "ShowtimeService")),
 	}
 }
 
@@ -70,6 +91,14 @@ func (s *showtimeService) CreateShowtime(ctx context.Context, req *request.Creat
 	err := s.uow.Execute(ctx, func(ctx context.Context, provider shared.RepositoryProvider) error {
 		var err error
 		showtimeRepo := provider.GetShowtimeRepository()
+		if err := checkMovieSchedulable(ctx, provider, st.MovieID); err != nil {
+			logger.Warn("movie cannot be scheduled", applog.Error(err))
+			return err
+		}
+		if err := checkOpeningHours(ctx, provider, st.CinemaHallID, st.StartTime); err != nil {
+			logger.Warn("showtime outside cinema opening hours", applog.Error(err))
+			return err
+		}
 		overlap, err := showtimeRepo.CheckOverlap(ctx, st.CinemaHallID, st.StartTime, st.EndTime)
 		if err != nil {
 			logger.Error("failed to check overlap", applog.Error(err))
@@ -79,6 +108,13 @@ func (s *showtimeService) CreateShowtime(ctx context.Context, req *request.Creat
 			logger.Error("showtime overlaps with existing showtime", applog.Uint("cinema_hall_id", uint(st.CinemaHallID)))
 			return fmt.Errorf("ServiceError: %w", showtime.ErrShowtimeOverlap)
 		}
+		// 场次固定引用影厅当前的布局版本
+		hall, err := provider.GetCinemaHallRepository().FindByID(ctx, st.CinemaHallID)
+		if err != nil {
+			logger.Warn("failed to find cinema hall", applog.Error(err))
+			return err
+		}
+		st.LayoutVersion = hall.LayoutVersion
 		st, err = showtimeRepo.Create(ctx, st)
 		if err != nil {
 			logger.Error("failed to create showtime", applog.Error(err))
@@ -120,10 +156,64 @@ func (s *showtimeService) GetShowtime(ctx context.Context, req *request.GetShowt
 func (s *showtimeService) UpdateShowtime(ctx context.Context, req *request.UpdateShowtimeRequest) (*response.ShowtimeResponse, error) {
 	logger := s.logger.With(applog.String("Method", "UpdateShowtime"), applog.Uint("showtime_id", req.ID))
 	st := req.ToDomain()
+	hallChanged := false
 
 	// 更新场次时，需要检查是否重叠，如果重叠，则返回错误。否则更新场次。
 	err := s.uow.Execute(ctx, func(ctx context.Context, provider shared.RepositoryProvider) error {
 		showtimeRepo := provider.GetShowtimeRepository()
+		current, err := showtimeRepo.FindByID(ctx, st.ID)
+		if err != nil {
+			logger.Warn("failed to find showtime", applog.Error(err))
+			return err
+		}
+
+		// 已归档电影的场次不允许再调整排期
+		movieID := current.MovieID
+		if st.MovieID > 0 {
+			movieID = st.MovieID
+		}
+		if err := checkMovieSchedulable(ctx, provider, movieID); err != nil {
+			logger.Warn("movie cannot be scheduled", applog.Error(err))
+			return err
+		}
+
+		// 调整了开始时间或影厅时，需要重新检查影院营业时间
+		if !st.StartTime.IsZero() || st.CinemaHallID > 0 {
+			hallID, startTime := current.CinemaHallID, current.StartTime
+			if st.CinemaHallID > 0 {
+				hallID = st.CinemaHallID
+			}
+			if !st.StartTime.IsZero() {
+				startTime = st.StartTime
+			}
+			if err := checkOpeningHours(ctx, provider, hallID, startTime); err != nil {
+				logger.Warn("showtime outside cinema opening hours", applog.Error(err))
+				return err
+			}
+		}
+
+		// 更换影厅时改为引用新影厅的当前布局版本。已售座位指向原影厅的座位，
+		// 存在有效订单时不允许更换影厅（同一影厅的布局变更应使用布局迁移）
+		if st.CinemaHallID > 0 && st.CinemaHallID != current.CinemaHallID {
+			liveCount, err := provider.GetBookingRepository().CountLiveByShowtimeID(ctx, st.ID)
+			if err != nil {
+				logger.Error("failed to count live bookings", applog.Error(err))
+				return err
+			}
+			if liveCount > 0 {
+				logger.Warn("showtime has live bookings, cannot change hall", applog.Int64("live_bookings", liveCount))
+				return fmt.Errorf("ServiceError: %w", showtime.ErrShowtimeHallChangeHasBookings)
+			}
+			hallChanged = true
+
+			hall, err := provider.GetCinemaHallRepository().FindByID(ctx, st.CinemaHallID)
+			if err != nil {
+				logger.Warn("failed to find cinema hall", applog.Error(err))
+				return err
+			}
+			st.LayoutVersion = hall.LayoutVersion
+		}
+
 		// 检查是否重叠
 		overlap, err := showtimeRepo.CheckOverlap(ctx, st.CinemaHallID, st.StartTime, st.EndTime, st.ID)
 		if err != nil {
@@ -145,6 +235,19 @@ func (s *showtimeService) UpdateShowtime(ctx context.Context, req *request.Updat
 			logger.Error("failed to update showtime", applog.Error(err))
 			return err
 		}
+		// Update 会忽略零值字段，无障碍标记（基于当前值合并请求中传入的字段）与字幕语言单独更新
+		if req.HasAccessibility() {
+			if err := showtimeRepo.UpdateAccessibility(ctx, st.ID, req.MergeAccessibility(current.Accessibility)); err != nil {
+				logger.Error("failed to update showtime accessibility", applog.Error(err))
+				return err
+			}
+		}
+		if req.SubtitleLanguage != nil {
+			if err := showtimeRepo.UpdateSubtitleLanguage(ctx, st.ID, *req.SubtitleLanguage); err != nil {
+				logger.Error("failed to update showtime subtitle language", applog.Error(err))
+				return err
+			}
+		}
 		return nil
 	})
 	if err != nil {
@@ -156,6 +259,12 @@ func (s *showtimeService) UpdateShowtime(ctx context.Context, req *request.Updat
 	if err := s.showCache.DeleteShowtime(ctx, vo.ShowtimeID(req.ID)); err != nil {
 		logger.Warn("failed to delete showtime from cache", applog.Error(err))
 	}
+	// 座位表按原影厅初始化，更换影厅后需要重建
+	if hallChanged {
+		if err := s.seatCache.InvalidateSeatMap(ctx, vo.ShowtimeID(req.ID)); err != nil {
+			logger.Warn("failed to invalidate seat map", applog.Error(err))
+		}
+	}
 
 	// 更新操作响应报文需要包含完整内容
 	st, err = s.showRepo.FindByID(ctx, vo.ShowtimeID(req.ID))
@@ -168,17 +277,34 @@ func (s *showtimeService) UpdateShowtime(ctx context.Context, req *request.Updat
 	return response.ToShowtimeResponse(st), nil
 }
 
-// 删除场次
+// 删除场次（存在有效订单时禁止删除，应使用取消场次）
 func (s *showtimeService) DeleteShowtime(ctx context.Context, req *request.DeleteShowtimeRequest) error {
 	logger := s.logger.With(applog.String("Method", "DeleteShowtime"), applog.Uint("showtime_id", req.ID))
-	// 单条场次删除，不需要事务
-	err := s.showRepo.Delete(ctx, vo.ShowtimeID(req.ID))
-	if err != nil {
+
+	// 检查有效订单与删除场次需要在同一事务中完成
+	err := s.uow.Execute(ctx, func(ctx context.Context, provider shared.RepositoryProvider) error {
+		liveCount, err := provider.GetBookingRepository().CountLiveByShowtimeID(ctx, vo.ShowtimeID(req.ID))
+		if err != nil {
+			logger.Error("failed to count live bookings", applog.Error(err))
+			return err
+		}
+		if liveCount > 0 {
+			logger.Warn("showtime has live bookings", applog.Int64("live_bookings", liveCount))
+			return fmt.Errorf("ServiceError: %w", showtime.ErrShowtimeHasLiveBookings)
+		}
+
 		// 如果场次不存在，则返回错误(仓库底层实现会根据RowAffected判断记录是否存在)
-		if errors.Is(err, showtime.ErrShowtimeNotFound) {
-			logger.Warn("showtime not found")
+		if err := provider.GetShowtimeRepository().Delete(ctx, vo.ShowtimeID(req.ID)); err != nil {
+			if errors.Is(err, showtime.ErrShowtimeNotFound) {
+				logger.Warn("showtime not found")
+				return err
+			}
+			logger.Error("failed to delete showtime", applog.Error(err))
 			return err
 		}
+		return nil
+	})
+	if err != nil {
 		logger.Error("failed to delete showtime", applog.Error(err))
 		return err
 	}
@@ -187,35 +313,250 @@ func (s *showtimeService) DeleteShowtime(ctx context.Context, req *request.Delet
 	if err := s.showCache.DeleteShowtime(ctx, vo.ShowtimeID(req.ID)); err != nil {
 		logger.Warn("failed to delete showtime from cache", applog.Error(err))
 	}
+	if err := s.seatCache.InvalidateSeatMap(ctx, vo.ShowtimeID(req.ID)); err != nil {
+		logger.Warn("failed to invalidate seat map", applog.Error(err))
+	}
 
 	logger.Info("delete showtime successfully", applog.Uint("showtime_id", req.ID))
 	return nil
 }
 
+// 取消场次：标记场次为已取消（不删除记录），分批取消或退款所有有效订单，
+// 失效座位表缓存，并通知受影响的用户，最终返回汇总报告。
+// 已取消的场次允许重复执行，用于处理上次中断后剩余的订单。
+func (s *showtimeService) CancelShowtime(ctx context.Context, req *request.CancelShowtimeRequest) (*response.CancelShowtimeResponse, error) {
+	logger := s.logger.With(applog.String("Method", "CancelShowtime"), applog.Uint("showtime_id", req.ID))
+	showtimeID := vo.ShowtimeID(req.ID)
+
+	batchSize := req.BatchSize
+	if batchSize <= 0 {
+		batchSize = showtime.DefaultCancelBatchSize
+	}
+
+	// 获取场次锁，与创建订单互斥，保证场次标记为取消后不会再产生新订单
+	lk, err := s.lockProvider.Acquire(ctx, cinema.GetShowtimeSeatsLockKey(showtimeID), lock.DefaultLockTTL)
+	if err != nil {
+		logger.Error("failed to acquire lock", applog.Error(err))
+		return nil, err
+	}
+
+	var st *showtime.Showtime
+	err = s.uow.Execute(ctx, func(ctx context.Context, provider shared.RepositoryProvider) error {
+		showtimeRepo := provider.GetShowtimeRepository()
+		st, err = showtimeRepo.FindByID(ctx, showtimeID)
+		if err != nil {
+			logger.Error("failed to find showtime", applog.Error(err))
+			return err
+		}
+		if st.IsCancelled() {
+			logger.Info("showtime already cancelled, continue processing remaining bookings")
+			return nil
+		}
+		if st.EndTime.Before(time.Now()) {
+			logger.Warn("showtime has ended", applog.Time("end_time", st.EndTime))
+			return fmt.Errorf("ServiceError: %w", showtime.ErrShowtimeEnded)
+		}
+
+		st.Cancel(req.Reason)
+		if err := showtimeRepo.Update(ctx, st); err != nil {
+			logger.Error("failed to mark showtime cancelled", applog.Error(err))
+			return err
+		}
+		return nil
+	})
+
+	// 先更新数据库，再删除缓存（释放锁前完成，保证后续订单读取到取消状态）
+	if err == nil {
+		if err := s.showCache.DeleteShowtime(ctx, showtimeID); err != nil {
+			logger.Warn("failed to delete showtime from cache", applog.Error(err))
+		}
+		if err := s.seatCache.InvalidateSeatMap(ctx, showtimeID); err != nil {
+			logger.Warn("failed to invalidate seat map", applog.Error(err))
+		}
+	}
+	if releaseErr := lk.Release(ctx); releaseErr != nil {
+		logger.Error("failed to release lock", applog.Error(releaseErr))
+	}
+	if err != nil {
+		logger.Error("failed to cancel showtime", applog.Error(err))
+		return nil, err
+	}
+
+	summary := &response.CancelShowtimeResponse{
+		ShowtimeID: uint(st.ID),
+		Status:     string(st.Status),
+		Reason:     st.CancelReason,
+	}
+	if st.CancelledAt != nil {
+		summary.CancelledAt = *st.CancelledAt
+	}
+
+	// 分批处理有效订单，每批一个事务，避免长事务锁住大量订单
+	notifiedUsers := make(map[vo.UserID]struct{})
+	var lastID vo.BookingID
+	for {
+		var bks []*booking.Booking
+		err := s.uow.Execute(ctx, func(ctx context.Context, provider shared.RepositoryProvider) error {
+			var err error
+			bookingRepo := provider.GetBookingRepository()
+			bks, err = bookingRepo.FindLiveByShowtimeID(ctx, showtimeID, lastID, batchSize)
+			if err != nil {
+				logger.Error("failed to find live bookings", applog.Error(err))
+				return err
+			}
+			if len(bks) == 0 {
+				return nil
+			}
+
+			// 待支付订单直接取消，已确认订单需要退款
+			allIDs := make([]vo.BookingID, 0, len(bks))
+			cancelIDs := make([]vo.BookingID, 0, len(bks))
+			refundIDs := make([]vo.BookingID, 0, len(bks))
+			refunds := make([]*booking.DailySales, 0, len(bks))
+			funnelEvents := make([]*analytics.FunnelEvent, 0, len(bks))
+			for _, bk := range bks {
+				allIDs = append(allIDs, bk.ID)
+				funnelEvents = append(funnelEvents, analytics.NewBookingEvent(analytics.EventBookingCancelled, bk))
+				if bk.Status == booking.BookingStatusConfirmed {
+					bk.Refund()
+					refundIDs = append(refundIDs, bk.ID)
+					refunds = append(refunds, booking.RefundedSales(bk, st.MovieID, st.CinemaHallID))
+				} else {
+					bk.Cancel()
+					cancelIDs = append(cancelIDs, bk.ID)
+				}
+			}
+
+			if err := bookingRepo.UpdateStatusBatch(ctx, cancelIDs, booking.BookingStatusCanceled); err != nil {
+				logger.Error("failed to cancel bookings", applog.Error(err))
+				return err
+			}
+			if err := bookingRepo.UpdateStatusBatch(ctx, refundIDs, booking.BookingStatusRefunded); err != nil {
+				logger.Error("failed to refund bookings", applog.Error(err))
+				return err
+			}
+			if err := provider.GetDailySalesRepository().Add(ctx, refunds...); err != nil {
+				logger.Error("failed to add refunded daily sales", applog.Error(err))
+				return err
+			}
+			if err := provider.GetFunnelEventRepository().Append(ctx, funnelEvents...); err != nil {
+				logger.Error("failed to record booking cancelled events", applog.Error(err))
+				return err
+			}
+			// 冲销订单获得的积分并退回抵扣的积分
+			ledgerRepo := provider.GetLoyaltyLedgerRepository()
+			entries, err := ledgerRepo.FindByBookingIDs(ctx, allIDs)
+			if err != nil {
+				logger.Error("failed to find loyalty entries", applog.Error(err))
+				return err
+			}
+			if err := ledgerRepo.Append(ctx, loyalty.Reversals(entries)...); err != nil {
+				logger.Error("failed to reverse loyalty points", applog.Error(err))
+				return err
+			}
+			if err := provider.GetBookedSeatRepository().DeleteByBookingIDs(ctx, allIDs); err != nil {
+				logger.Error("failed to release booked seats", applog.Error(err))
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			logger.Error("failed to process booking batch",
+				applog.Int("batch", summary.Batches+1), applog.Uint("after_id", uint(lastID)), applog.Error(err))
+			return nil, err
+		}
+		if len(bks) == 0 {
+			break
+		}
+		lastID = bks[len(bks)-1].ID
+		summary.Batches++
+
+		events := make([]*notification.Event, 0, len(bks))
+		sales := make([]*trending.Sale, 0, len(bks))
+		for _, bk := range bks {
+			summary.TotalBookings++
+			summary.ReleasedSeats += len(bk.BookedSeats)
+			refundAmount := 0.0
+			if bk.Status == booking.BookingStatusRefunded {
+				summary.RefundedBookings++
+				refundAmount = bk.TotalAmount
+				summary.RefundAmount += refundAmount
+				sales = append(sales, trending.RefundedSale(bk, st.MovieID))
+			} else {
+				summary.CancelledBookings++
+			}
+			notifiedUsers[bk.UserID] = struct{}{}
+			events = append(events, notification.NewEvent(notification.EventShowtimeCancelled, bk.UserID,
+				"showtime cancelled", map[string]any{
+					"showtime_id":    uint(showtimeID),
+					"booking_id":     uint(bk.ID),
+					"booking_status": string(bk.Status),
+					"refund_amount":  refundAmount,
+					"reason":         st.CancelReason,
+				}))
+		}
+
+		// 退款的票从热门排行中扣除，失败时由定时重建修正
+		if err := s.trendingCache.Add(ctx, sales...); err != nil {
+			logger.Warn("failed to subtract trending counters", applog.Error(err))
+		}
+
+		// 通知失败不影响取消结果
+		if err := s.notifier.Notify(ctx, events); err != nil {
+			logger.Warn("failed to notify users", applog.Error(err))
+		}
+	}
+	summary.NotifiedUsers = len(notifiedUsers)
+
+	logger.Info("cancel showtime successfully",
+		applog.Int("total_bookings", summary.TotalBookings),
+		applog.Int("refunded_bookings", summary.RefundedBookings),
+		applog.Float64("refund_amount", summary.RefundAmount))
+	return summary, nil
+}
+
 func (s *showtimeService) ListShowtimes(ctx context.Context,
 	req *request.ListShowtimesRequest) (*response.PaginatedShowtimeResponse, error) {
-	logger := s.logger.With(applog.String("Method", "ListShowtimes"),
-		applog.Uint("movie_id", req.MovieID),
-		applog.Uint("cinema_hall_id", req.CinemaHallID),
-		applog.Time("date", req.Date))
+	return s.listShowtimes(ctx, req.ToDomain())
+}
+
+// 管理员查询场次，可按状态过滤，未指定状态时包含已取消的场次
+func (s *showtimeService) ListAdminShowtimes(ctx context.Context,
+	req *request.ListAdminShowtimesRequest) (*response.PaginatedShowtimeResponse, error) {
+	return s.listShowtimes(ctx, req.ToDomain())
+}
 
-	options := req.ToDomain()
+func (s *showtimeService) listShowtimes(ctx context.Context,
+	options *showtime.ShowtimeQueryOptions) (*response.PaginatedShowtimeResponse, error) {
+	logger := s.logger.With(applog.String("Method", "ListShowtimes"),
+		applog.Uint("movie_id", uint(options.MovieID)),
+		applog.Uint("cinema_hall_id", uint(options.CinemaHallID)),
+		applog.Time("date", options.Date),
+		applog.String("status", string(options.Status)))
 
 	var showtimes []*showtime.Showtime
 	var fn = func(showtimes []*showtime.Showtime) *response.PaginatedShowtimeResponse {
+		// 列表缓存只保存场次ID，缓存后被取消的场次在这里过滤
+		matched := make([]*showtime.Showtime, 0, len(showtimes))
+		for _, st := range showtimes {
+			if options.MatchStatus(st) {
+				matched = append(matched, st)
+			}
+		}
+		showtimes = matched
 		responseShowtimes := make([]*response.ShowtimeSimpleResponse, 0, len(showtimes))
 		total := len(showtimes)
-		startIndex := (req.Page - 1) * req.PageSize
-		endIndex := min(startIndex+req.PageSize, total)
+		startIndex := (options.Page - 1) * options.PageSize
+		endIndex := min(startIndex+options.PageSize, total)
 		showtimes = showtimes[startIndex:endIndex]
 		for _, showtime := range showtimes {
 			responseShowtimes = append(responseShowtimes, response.ToShowtimeSimpleResponse(showtime))
 		}
 		return &response.PaginatedShowtimeResponse{
 			Pagination: response.PaginationResponse{
-				Page:       req.Page,
-				PageSize:   req.PageSize,
-				TotalPages: int(math.Ceil(float64(total) / float64(req.PageSize))),
+				Page:       options.Page,
+				PageSize:   options.PageSize,
+				TotalPages: int(math.Ceil(float64(total) / float64(options.PageSize))),
 				TotalCount: int(total),
 			},
 			Showtimes: responseShowtimes,
@@ -264,12 +605,42 @@ func (s *showtimeService) ListShowtimes(ctx context.Context,
 	return fn(showtimes), nil
 }
 
-// 获取座位表
+// 获取座位表，成功后记录用户查看座位图的漏斗事件（写入缓冲，由定时任务批量写入）
 func (s *showtimeService) GetSeatMap(ctx context.Context, req *request.GetSeatMapRequest) (*response.SeatMapResponse, error) {
+	seatMapResp, err := s.getSeatMap(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if req.UserID != 0 {
+		s.viewBuffer.Add(vo.UserID(req.UserID), vo.ShowtimeID(req.ShowtimeID), time.Now())
+	}
+	return seatMapResp, nil
+}
+
+// 漏斗事件只用于统计，写入失败时丢弃该批事件，不重试
+func (s *showtimeService) FlushSeatMapViews(ctx context.Context) error {
+	logger := s.logger.With(applog.String("Method", "FlushSeatMapViews"))
+
+	events, dropped := s.viewBuffer.Drain(time.Now())
+	if dropped > 0 {
+		logger.Warn("seat map view buffer full, events dropped", applog.Int("dropped", dropped))
+	}
+	if len(events) == 0 {
+		return nil
+	}
+	if err := s.funnelRepo.Append(ctx, events...); err != nil {
+		logger.Error("failed to record seat map viewed events", applog.Int("count", len(events)), applog.Error(err))
+		return err
+	}
+	logger.Debug("flush seat map viewed events successfully", applog.Int("count", len(events)))
+	return nil
+}
+
+func (s *showtimeService) getSeatMap(ctx context.Context, req *request.GetSeatMapRequest) (*response.SeatMapResponse, error) {
 	logger := s.logger.With(applog.String("Method", "GetSeatMap"), applog.Uint("showtime_id", req.ShowtimeID))
-	seatInfos, err := s.seatCache.GetSeatMap(ctx, vo.ShowtimeID(req.ShowtimeID))
+	seatMap, err := s.seatCache.GetSeatMap(ctx, vo.ShowtimeID(req.ShowtimeID))
 	if err == nil {
-		return &response.SeatMapResponse{Seats: seatInfos}, nil
+		return response.ToSeatMapResponse(seatMap), nil
 	}
 
 	// 缓存未命中，且不是缓存缺失错误
@@ -287,11 +658,11 @@ func (s *showtimeService) GetSeatMap(ctx context.Context, req *request.GetSeatMa
 			for i := 0; i < lock.DefaultMaxRetries; i++ {
 				randomFactor := 1 + 0.1*rand.Float64()
 				time.Sleep(time.Duration(float64(lock.DefaultBackoff) * float64(i+1) * randomFactor))
-				seatInfos, cacheErr := s.seatCache.GetSeatMap(ctx, vo.ShowtimeID(req.ShowtimeID))
+				seatMap, cacheErr := s.seatCache.GetSeatMap(ctx, vo.ShowtimeID(req.ShowtimeID))
 				if cacheErr == nil {
 					logger.Info("successfully got seat map from cache after waiting",
 						applog.Uint("showtimeID", req.ShowtimeID))
-					return &response.SeatMapResponse{Seats: seatInfos}, nil
+					return response.ToSeatMapResponse(seatMap), nil
 				}
 			}
 
@@ -307,19 +678,24 @@ func (s *showtimeService) GetSeatMap(ctx context.Context, req *request.GetSeatMa
 			return nil, err
 		}
 
+		if errors.Is(err, showtime.ErrShowtimeCancelled) {
+			logger.Warn("showtime has been cancelled, skipping cache initialization")
+			return nil, err
+		}
+
 		// 如果是其他初始化错误，则直接返回
 		logger.Error("failed to init seat map", applog.Error(err))
 		return nil, err
 	}
 
-	seatInfos, err = s.seatCache.GetSeatMap(ctx, vo.ShowtimeID(req.ShowtimeID))
+	seatMap, err = s.seatCache.GetSeatMap(ctx, vo.ShowtimeID(req.ShowtimeID))
 	if err != nil {
 		logger.Error("failed to get seat map from cache", applog.Error(err))
 		return nil, err
 	}
 
 	logger.Info("init seat map successfully", applog.Uint("showtime_id", uint(req.ShowtimeID)))
-	return &response.SeatMapResponse{Seats: seatInfos}, nil
+	return response.ToSeatMapResponse(seatMap), nil
 }
 
 // 初始化座位表
@@ -340,6 +716,12 @@ func (s *showtimeService) InitSeatMap(ctx context.Context, showtimeID vo.Showtim
 	// 座位表缓存过期时间设置为场次结束时间后10分钟
 	expireTime := time.Until(showtimeResp.EndTime.Add(time.Minute * 10))
 
+	// 已取消的场次不再提供座位表
+	if showtimeResp.Status == string(showtime.ShowtimeStatusCancelled) {
+		logger.Warn("showtime has been cancelled, skipping cache initialization")
+		return fmt.Errorf("ServiceError: %w", showtime.ErrShowtimeCancelled)
+	}
+
 	// 如果场次已经结束，则不应该再为其初始化缓存
 	if expireTime <= 0 {
 		logger.Warn("showtime has already ended, skipping cache initialization",
@@ -369,21 +751,40 @@ func (s *showtimeService) InitSeatMap(ctx context.Context, showtimeID vo.Showtim
 		}
 	}()
 
-	// 获取座位表
-	var seats []*cinema.Seat
+	// 获取影厅（含座位与布局）、有效订单及座位限制
+	var hall *cinema.CinemaHall
 	var bks []*booking.Booking
+	var restrictions []*cinema.SeatRestriction
+	var seats []*cinema.Seat
+	var layout *cinema.HallLayout
 	err = s.uow.Execute(ctx, func(ctx context.Context, provider shared.RepositoryProvider) error {
-		seats, err = provider.GetSeatRepository().FindByHallID(ctx, vo.CinemaHallID(showtimeResp.CinemaHall.ID))
+		hall, err = provider.GetCinemaHallRepository().FindByID(ctx, vo.CinemaHallID(showtimeResp.CinemaHall.ID))
 		if err != nil {
-			logger.Error("failed to find seats", applog.Error(err))
+			logger.Error("failed to find cinema hall", applog.Error(err))
 			return err
 		}
 
+		// 场次固定在旧布局版本时，使用该版本的座位与布局，保证位图偏移与已售座位一致
+		seats, layout = hall.Seats, hall.Layout
+		if version := showtimeResp.LayoutVersion; version > 0 && version != hall.LayoutVersion {
+			seats, layout, err = findLayoutVersion(ctx, provider, hall.ID, version)
+			if err != nil {
+				logger.Error("failed to find layout version of showtime", applog.Int("version", version), applog.Error(err))
+				return err
+			}
+		}
+
 		bks, err = provider.GetBookingRepository().FindByShowtimeID(ctx, vo.ShowtimeID(showtimeResp.ID))
 		if err != nil {
 			logger.Error("failed to find booked seats", applog.Error(err))
 			return err
 		}
+
+		restrictions, err = provider.GetSeatRestrictionRepository().ListByHallID(ctx, hall.ID)
+		if err != nil {
+			logger.Error("failed to find seat restrictions", applog.Error(err))
+			return err
+		}
 		return nil
 	})
 
@@ -392,14 +793,45 @@ func (s *showtimeService) InitSeatMap(ctx context.Context, showtimeID vo.Showtim
 		return err
 	}
 
+	// 已取消/已退款订单的座位应重新开放
 	bookedSeatIDs := make([]vo.SeatID, 0, len(bks)*2)
+	groups := make([][]vo.SeatID, 0, len(bks))
+	booked := make(map[vo.SeatID]bool)
 	for _, bk := range bks {
+		if !bk.IsLive() {
+			continue
+		}
+		group := make([]vo.SeatID, 0, len(bk.BookedSeats))
 		for _, seat := range bk.BookedSeats {
-			bookedSeatIDs = append(bookedSeatIDs, vo.SeatID(seat.ID))
+			bookedSeatIDs = append(bookedSeatIDs, seat.SeatID)
+			group = append(group, seat.SeatID)
+			booked[seat.SeatID] = true
 		}
+		groups = append(groups, group)
 	}
 
-	if err := s.seatCache.InitSeatMap(ctx, showtimeID, seats, bookedSeatIDs, expireTime); err != nil {
+	// 历史影厅没有保存布局，按排号/座位号推断网格坐标
+	if layout == nil {
+		layout = cinema.InferLayout(seats)
+	}
+
+	// 计算本场次不可售的座位：生效中的座位限制 + 社交距离间隔，已售座位保持售出状态
+	blocks := &cinema.SeatMapBlocks{Reasons: make(map[vo.SeatID]string)}
+	for _, r := range restrictions {
+		if r.Overlaps(showtimeResp.StartTime, showtimeResp.EndTime) && !booked[r.SeatID] {
+			blocks.Reasons[r.SeatID] = string(r.Kind)
+		}
+	}
+	if hall.DistancingPolicy.AppliesTo(showtimeResp.StartTime, showtimeResp.EndTime) {
+		blocks.Distancing = true
+		for _, id := range hall.DistancingPolicy.BlockedSeats(layout, seats, groups) {
+			if _, ok := blocks.Reasons[id]; !ok && !booked[id] {
+				blocks.Reasons[id] = cinema.SeatBlockReasonDistancing
+			}
+		}
+	}
+
+	if err := s.seatCache.InitSeatMap(ctx, showtimeID, layout, seats, bookedSeatIDs, blocks, expireTime); err != nil {
 		logger.Error("failed to init seat map", applog.Error(err))
 		return err
 	}
@@ -407,3 +839,223 @@ func (s *showtimeService) InitSeatMap(ctx context.Context, showtimeID vo.Showtim
 	logger.Info("init seat map successfully", applog.Uint("showtime_id", uint(showtimeID)))
 	return nil
 }
+
+// 检查场次开始时间是否处于影厅所属影院的营业时间内，影厅未归属影院时不做限制
+func checkOpeningHours(ctx context.Context, provider shared.RepositoryProvider, hallID vo.CinemaHallID, startTime time.Time) error {
+	site, err := provider.GetCinemaRepository().FindByHallID(ctx, hallID)
+	if err != nil {
+		if errors.Is(err, cinema.ErrCinemaNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !site.IsOpenAt(startTime) {
+		return fmt.Errorf("ServiceError: %w", showtime.ErrShowtimeOutsideHours)
+	}
+	return nil
+}
+
+// 检查电影是否允许排片：电影必须存在且未归档
+func checkMovieSchedulable(ctx context.Context, provider shared.RepositoryProvider, movieID vo.MovieID) error {
+	mv, err := provider.GetMovieRepository().FindByID(ctx, movieID)
+	if err != nil {
+		return err
+	}
+	if mv.IsArchived() {
+		return fmt.Errorf("ServiceError: %w", movie.ErrMovieArchived)
+	}
+	return nil
+}
+
+// 查询影厅指定布局版本的座位与布局（历史影厅没有版本记录时布局为空，由调用方推断）
+func findLayoutVersion(ctx context.Context, provider shared.RepositoryProvider,
+	hallID vo.CinemaHallID, version int) ([]*cinema.Seat, *cinema.HallLayout, error) {
+	seats, err := provider.GetSeatRepository().FindByHallAndVersion(ctx, hallID, version)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(seats) == 0 {
+		return nil, nil, fmt.Errorf("%w(version): %v", cinema.ErrLayoutVersionNotFound, version)
+	}
+	layoutVersion, err := provider.GetHallLayoutVersionRepository().FindByHallAndVersion(ctx, hallID, version)
+	if err != nil {
+		if errors.Is(err, cinema.ErrLayoutVersionNotFound) {
+			return seats, nil, nil
+		}
+		return nil, nil, err
+	}
+	return seats, layoutVersion.Layout, nil
+}
+
+// 将影厅未开始的场次迁移到指定布局版本（默认影厅当前版本）
+// 已售座位按排号+座位号对应到新版本座位，新版本中不存在的已售座位记为冲突，存在冲突的场次保持原版本
+func (s *showtimeService) MigrateShowtimeLayouts(ctx context.Context, req *request.MigrateShowtimeLayoutsRequest) (*response.MigrateShowtimeLayoutsResponse, error) {
+	logger := s.logger.With(applog.String("Method", "MigrateShowtimeLayouts"),
+		applog.Uint("cinema_hall_id", req.CinemaHallID), applog.Bool("dry_run", req.DryRun))
+
+	hallID := vo.CinemaHallID(req.CinemaHallID)
+	now := time.Now()
+	targetVersion := req.TargetVersion
+	var targetSeats []*cinema.Seat
+	var showtimes []*showtime.Showtime
+	err := s.uow.Execute(ctx, func(ctx context.Context, provider shared.RepositoryProvider) error {
+		hall, err := provider.GetCinemaHallRepository().FindByID(ctx, hallID)
+		if err != nil {
+			logger.Warn("failed to find cinema hall", applog.Error(err))
+			return err
+		}
+		if targetVersion == 0 {
+			targetVersion = hall.LayoutVersion
+		}
+		targetSeats = hall.Seats
+		if targetVersion != hall.LayoutVersion {
+			if targetSeats, _, err = findLayoutVersion(ctx, provider, hallID, targetVersion); err != nil {
+				logger.Warn("failed to find target layout version", applog.Error(err))
+				return err
+			}
+		}
+
+		showtimes, err = provider.GetShowtimeRepository().FindUpcomingByHallID(ctx, hallID, now)
+		if err != nil {
+			logger.Error("failed to find showtimes of hall", applog.Error(err))
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("failed to prepare layout migration", applog.Error(err))
+		return nil, err
+	}
+
+	result := &response.MigrateShowtimeLayoutsResponse{
+		CinemaHallID:  req.CinemaHallID,
+		TargetVersion: targetVersion,
+		DryRun:        req.DryRun,
+		Showtimes:     make([]*response.ShowtimeLayoutMigrationResponse, 0, len(showtimes)),
+	}
+	for _, st := range showtimes {
+		if st.LayoutVersion == targetVersion {
+			continue
+		}
+		item := s.migrateShowtimeLayout(ctx, st, targetVersion, targetSeats, req.DryRun)
+		switch {
+		case item.Error != "":
+			result.Failed++
+		case len(item.Conflicts) > 0:
+			result.Conflicted++
+		case item.WouldMigrate:
+			result.WouldMigrate++
+		default:
+			result.Migrated++
+		}
+		result.Showtimes = append(result.Showtimes, item)
+	}
+
+	logger.Info("migrate showtime layouts successfully",
+		applog.Int("target_version", targetVersion),
+		applog.Int("migrated", result.Migrated),
+		applog.Int("would_migrate", result.WouldMigrate),
+		applog.Int("conflicted", result.Conflicted),
+		applog.Int("failed", result.Failed))
+	return result, nil
+}
+
+// 迁移单个场次：与下单互斥，在同一事务中改写已售座位并切换场次的布局版本
+func (s *showtimeService) migrateShowtimeLayout(ctx context.Context, st *showtime.Showtime,
+	targetVersion int, targetSeats []*cinema.Seat, dryRun bool) *response.ShowtimeLayoutMigrationResponse {
+	logger := s.logger.With(applog.String("Method", "migrateShowtimeLayout"), applog.Uint("showtime_id", uint(st.ID)))
+	item := &response.ShowtimeLayoutMigrationResponse{
+		ShowtimeID:  uint(st.ID),
+		StartTime:   st.StartTime,
+		FromVersion: st.LayoutVersion,
+		Conflicts:   []*response.LayoutMigrationConflictResponse{},
+	}
+
+	if !dryRun {
+		lk, err := s.lockProvider.Acquire(ctx, cinema.GetShowtimeSeatsLockKey(st.ID), lock.DefaultLockTTL)
+		if err != nil {
+			logger.Warn("failed to acquire showtime lock", applog.Error(err))
+			item.Error = err.Error()
+			return item
+		}
+		defer lk.Release(ctx)
+	}
+
+	err := s.uow.Execute(ctx, func(ctx context.Context, provider shared.RepositoryProvider) error {
+		fromSeats, err := provider.GetSeatRepository().FindByHallAndVersion(ctx, st.CinemaHallID, st.LayoutVersion)
+		if err != nil {
+			return err
+		}
+		bks, err := provider.GetBookingRepository().FindByShowtimeID(ctx, st.ID)
+		if err != nil {
+			return err
+		}
+
+		fromByID := make(map[vo.SeatID]*cinema.Seat, len(fromSeats))
+		for _, seat := range fromSeats {
+			fromByID[seat.ID] = seat
+		}
+		matched := cinema.MatchSeats(fromSeats, targetSeats)
+		reassigned := make([]*booking.BookedSeat, 0)
+		for _, bk := range bks {
+			if !bk.IsLive() {
+				continue
+			}
+			for _, bs := range bk.BookedSeats {
+				target, ok := matched[bs.SeatID]
+				if !ok {
+					conflict := &response.LayoutMigrationConflictResponse{
+						BookingID: uint(bk.ID),
+						SeatID:    uint(bs.SeatID),
+						Reason:    "seat does not exist in target layout version",
+					}
+					if seat, ok := fromByID[bs.SeatID]; ok {
+						conflict.RowIdentifier, conflict.SeatNumber = seat.RowIdentifier, seat.SeatNumber
+					}
+					item.Conflicts = append(item.Conflicts, conflict)
+					continue
+				}
+				bs.SeatID = target.ID
+				reassigned = append(reassigned, bs)
+			}
+		}
+		item.ReassignedSeats = len(reassigned)
+		if len(item.Conflicts) > 0 {
+			return nil
+		}
+		if dryRun {
+			item.WouldMigrate = true
+			return nil
+		}
+
+		for _, bs := range reassigned {
+			if err := provider.GetBookedSeatRepository().Update(ctx, bs); err != nil {
+				return err
+			}
+		}
+		if err := provider.GetShowtimeRepository().UpdateLayoutVersion(ctx, st.ID, targetVersion); err != nil {
+			return err
+		}
+		item.Migrated = true
+		return nil
+	})
+	if err != nil {
+		logger.Error("failed to migrate showtime layout", applog.Error(err))
+		item.Migrated, item.WouldMigrate = false, false
+		item.Error = err.Error()
+		return item
+	}
+	if !item.Migrated {
+		return item
+	}
+
+	// 场次缓存包含布局版本，座位表需要按新版本重新初始化
+	if err := s.showCache.DeleteShowtime(ctx, st.ID); err != nil {
+		logger.Warn("failed to delete showtime from cache", applog.Error(err))
+	}
+	if err := s.seatCache.InvalidateSeatMap(ctx, st.ID); err != nil {
+		logger.Warn("failed to invalidate seat map", applog.Error(err))
+	}
+	logger.Info("migrate showtime layout successfully", applog.Int("reassigned_seats", item.ReassignedSeats))
+	return item
+}
@@ -0,0 +1,272 @@
+package app
+
+import (
+	"context"
+	"mrs/internal/api/dto/request"
+	"mrs/internal/api/dto/response"
+	"mrs/internal/domain/movie"
+	"mrs/internal/domain/shared/vo"
+	"mrs/internal/domain/showtime"
+	"mrs/internal/domain/trending"
+	applog "mrs/pkg/log"
+	"time"
+)
+
+const defaultTrendingLimit = 10 // 默认返回的排行数量
+
+type TrendingService interface {
+	GetTrendingMovies(ctx context.Context, req *request.GetTrendingMoviesRequest) (*response.TrendingMoviesResponse, error)
+	GetLeaderboard(ctx context.Context, req *request.GetLeaderboardRequest) (*response.LeaderboardResponse, error)
+	// 从订单表重建全部分桶的售票计数器（由定时任务调用）
+	RebuildTrending(ctx context.Context) error
+}
+
+type trendingService struct {
+	trendingRepo  trending.TrendingRepository
+	trendingCache trending.TrendingCache
+	movieRepo     movie.MovieRepository
+	showtimeRepo  showtime.ShowtimeRepository
+	logger        applog.Logger
+}
+
+func NewTrendingService(
+	trendingRepo trending.TrendingRepository,
+	trendingCache trending.TrendingCache,
+	movieRepo movie.MovieRepository,
+	showtimeRepo showtime.ShowtimeRepository,
+	logger applog.Logger,
+) TrendingService {
+	return &trendingService{
+		trendingRepo:  trendingRepo,
+		trendingCache: trendingCache,
+		movieRepo:     movieRepo,
+		showtimeRepo:  showtimeRepo,
+		logger:        logger.With(applog.String("Service", "TrendingService")),
+	}
+}
+
+// 热门电影：窗口内售出票数最多的电影，跳过已删除或已下映的电影
+func (s *trendingService) GetTrendingMovies(ctx context.Context, req *request.GetTrendingMoviesRequest) (*response.TrendingMoviesResponse, error) {
+	window := trendingWindow(req.Window)
+	logger := s.logger.With(applog.String("Method", "GetTrendingMovies"), applog.String("window", string(window)))
+
+	now := time.Now()
+	entries, err := s.trendingCache.Ranking(ctx, trending.SubjectMovie, window.Buckets(now))
+	if err != nil {
+		logger.Error("failed to get trending movies", applog.Error(err))
+		return nil, err
+	}
+
+	moviesByID, err := s.loadMovies(ctx, entries)
+	if err != nil {
+		logger.Error("failed to load trending movies", applog.Error(err))
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultTrendingLimit
+	}
+	resp := &response.TrendingMoviesResponse{
+		Window:      string(window),
+		GeneratedAt: now,
+		Movies:      make([]*response.TrendingMovieResponse, 0, min(len(entries), limit)),
+	}
+	for _, entry := range entries {
+		if len(resp.Movies) >= limit {
+			break
+		}
+		mv, ok := moviesByID[vo.MovieID(entry.ID)]
+		if !ok || mv.IsArchived() {
+			continue
+		}
+		resp.Movies = append(resp.Movies, &response.TrendingMovieResponse{
+			Rank:    len(resp.Movies) + 1,
+			Movie:   response.ToMovieSimpleResponse(mv.Localize(req.Locales)),
+			Tickets: entry.Tickets,
+		})
+	}
+
+	logger.Info("get trending movies successfully", applog.Int("count", len(resp.Movies)))
+	return resp, nil
+}
+
+// 售票排行榜：窗口内的电影与场次排行，包含已下映的电影与已取消的场次
+func (s *trendingService) GetLeaderboard(ctx context.Context, req *request.GetLeaderboardRequest) (*response.LeaderboardResponse, error) {
+	window := trendingWindow(req.Window)
+	logger := s.logger.With(applog.String("Method", "GetLeaderboard"), applog.String("window", string(window)))
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultTrendingLimit
+	}
+	now := time.Now()
+	buckets := window.Buckets(now)
+
+	movieEntries, err := s.trendingCache.Ranking(ctx, trending.SubjectMovie, buckets)
+	if err != nil {
+		logger.Error("failed to get movie ranking", applog.Error(err))
+		return nil, err
+	}
+	movieEntries = movieEntries[:min(len(movieEntries), limit)]
+	moviesByID, err := s.loadMovies(ctx, movieEntries)
+	if err != nil {
+		logger.Error("failed to load ranked movies", applog.Error(err))
+		return nil, err
+	}
+
+	showtimeEntries, err := s.trendingCache.Ranking(ctx, trending.SubjectShowtime, buckets)
+	if err != nil {
+		logger.Error("failed to get showtime ranking", applog.Error(err))
+		return nil, err
+	}
+	showtimeEntries = showtimeEntries[:min(len(showtimeEntries), limit)]
+	showtimesByID, err := s.loadShowtimes(ctx, showtimeEntries)
+	if err != nil {
+		logger.Error("failed to load ranked showtimes", applog.Error(err))
+		return nil, err
+	}
+	// 场次的电影标题同样本地化，补充加载不在电影排行中的电影及其翻译
+	if err := s.loadShowtimeMovies(ctx, showtimesByID, moviesByID); err != nil {
+		logger.Error("failed to load movies of ranked showtimes", applog.Error(err))
+		return nil, err
+	}
+
+	resp := &response.LeaderboardResponse{
+		Window:      string(window),
+		GeneratedAt: now,
+		Movies:      make([]*response.TrendingMovieResponse, 0, len(movieEntries)),
+		Showtimes:   make([]*response.TrendingShowtimeResponse, 0, len(showtimeEntries)),
+	}
+	for _, entry := range movieEntries {
+		mv, ok := moviesByID[vo.MovieID(entry.ID)]
+		if !ok {
+			continue
+		}
+		resp.Movies = append(resp.Movies, &response.TrendingMovieResponse{
+			Rank:    len(resp.Movies) + 1,
+			Movie:   response.ToMovieSimpleResponse(mv.Localize(req.Locales)),
+			Tickets: entry.Tickets,
+		})
+	}
+	for _, entry := range showtimeEntries {
+		st, ok := showtimesByID[vo.ShowtimeID(entry.ID)]
+		if !ok {
+			continue
+		}
+		item := &response.TrendingShowtimeResponse{
+			Rank:     len(resp.Showtimes) + 1,
+			Showtime: response.ToShowtimeSimpleResponse(st),
+			Tickets:  entry.Tickets,
+		}
+		if mv, ok := moviesByID[st.MovieID]; ok {
+			item.MovieTitle = mv.Localize(req.Locales).Title
+		} else if st.Movie != nil {
+			item.MovieTitle = st.Movie.Title
+		}
+		if st.CinemaHall != nil {
+			item.CinemaHallName = st.CinemaHall.Name
+		}
+		resp.Showtimes = append(resp.Showtimes, item)
+	}
+
+	logger.Info("get leaderboard successfully", applog.Int("movies", len(resp.Movies)), applog.Int("showtimes", len(resp.Showtimes)))
+	return resp, nil
+}
+
+// 分别按5分钟与按天汇总订单表，再原子地替换全部分桶。
+// 汇总与替换之间确认或退款的订单可能被漏计或重复计入，下一次重建时修正
+func (s *trendingService) RebuildTrending(ctx context.Context) error {
+	logger := s.logger.With(applog.String("Method", "RebuildTrending"))
+
+	now := time.Now()
+	counts := make([]*trending.TicketCount, 0)
+	for _, window := range []trending.Window{trending.WindowHour, trending.WindowWeek} {
+		buckets := window.Buckets(now)
+		oldest := buckets[len(buckets)-1]
+		windowCounts, err := s.trendingRepo.CountTickets(ctx, oldest.Granularity, oldest.Start)
+		if err != nil {
+			logger.Error("failed to count tickets", applog.String("window", string(window)), applog.Error(err))
+			return err
+		}
+		counts = append(counts, windowCounts...)
+	}
+
+	if err := s.trendingCache.Replace(ctx, trending.RebuildBuckets(now), counts); err != nil {
+		logger.Error("failed to replace trending counters", applog.Error(err))
+		return err
+	}
+
+	logger.Info("rebuild trending counters successfully", applog.Int("counts", len(counts)))
+	return nil
+}
+
+// 未指定窗口时使用今天
+func trendingWindow(window string) trending.Window {
+	if w := trending.Window(window); w.Valid() {
+		return w
+	}
+	return trending.WindowToday
+}
+
+func (s *trendingService) loadMovies(ctx context.Context, entries []*trending.Entry) (map[vo.MovieID]*movie.Movie, error) {
+	moviesByID := make(map[vo.MovieID]*movie.Movie, len(entries))
+	if len(entries) == 0 {
+		return moviesByID, nil
+	}
+	ids := make([]vo.MovieID, len(entries))
+	for i, entry := range entries {
+		ids[i] = vo.MovieID(entry.ID)
+	}
+	movies, err := s.movieRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, mv := range movies {
+		moviesByID[mv.ID] = mv
+	}
+	return moviesByID, nil
+}
+
+// 加载场次所属、尚未加载的电影，写入 moviesByID
+func (s *trendingService) loadShowtimeMovies(ctx context.Context, showtimesByID map[vo.ShowtimeID]*showtime.Showtime, moviesByID map[vo.MovieID]*movie.Movie) error {
+	ids := make([]vo.MovieID, 0)
+	seen := make(map[vo.MovieID]bool)
+	for _, st := range showtimesByID {
+		if _, ok := moviesByID[st.MovieID]; ok || seen[st.MovieID] {
+			continue
+		}
+		seen[st.MovieID] = true
+		ids = append(ids, st.MovieID)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	movies, err := s.movieRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, mv := range movies {
+		moviesByID[mv.ID] = mv
+	}
+	return nil
+}
+
+func (s *trendingService) loadShowtimes(ctx context.Context, entries []*trending.Entry) (map[vo.ShowtimeID]*showtime.Showtime, error) {
+	showtimesByID := make(map[vo.ShowtimeID]*showtime.Showtime, len(entries))
+	if len(entries) == 0 {
+		return showtimesByID, nil
+	}
+	ids := make([]vo.ShowtimeID, len(entries))
+	for i, entry := range entries {
+		ids[i] = vo.ShowtimeID(entry.ID)
+	}
+	showtimes, err := s.showtimeRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, st := range showtimes {
+		showtimesByID[st.ID] = st
+	}
+	return showtimesByID, nil
+}
@@ -12,6 +12,7 @@ import (
 	"mrs/internal/domain/user"
 	"mrs/internal/utils"
 	applog "mrs/pkg/log"
+	"time"
 )
 
 type UserService interface {
@@ -57,11 +58,16 @@ func (s *userService) Register(ctx context.Context, req *request.RegisterUserReq
 		applog.String("username", req.Username),
 		applog.String("email", req.Email))
 	// 数据库底层存在用户名和邮箱的唯一性约束，因此不需要再验证
+	if err := user.ValidateBirthDate(req.BirthDate.TimePtr(), time.Now()); err != nil {
+		logger.Warn("invalid birth date", applog.Error(err))
+		return nil, err
+	}
 
 	// 创建用户实体并生成哈希密码
 	newUser := user.User{
-		Username: req.Username,
-		Email:    req.Email,
+		Username:  req.Username,
+		Email:     req.Email,
+		BirthDate: req.BirthDate.TimePtr(),
 	}
 	if err := newUser.SetPassword(req.Password, s.hasher); err != nil {
 		logger.Error("failed to hash password", applog.Error(err))
@@ -118,6 +124,10 @@ func (s *userService) GetUser(ctx context.Context, req *request.GetUserRequest)
 func (s *userService) UpdateUser(ctx context.Context, req *request.UpdateUserRequest) (*response.UserResponse, error) {
 	logger := s.logger.With(applog.String("Method", "UpdateUserProfile"), applog.Uint("user_id", req.ID))
 	usr := req.ToDomain()
+	if err := user.ValidateBirthDate(usr.BirthDate, time.Now()); err != nil {
+		logger.Warn("invalid birth date", applog.Error(err))
+		return nil, err
+	}
 	if req.Password != "" {
 		if err := usr.SetPassword(req.Password, s.hasher); err != nil {
 			logger.Error("failed to hash password", applog.Error(err))
@@ -135,6 +145,13 @@ func (s *userService) UpdateUser(ctx context.Context, req *request.UpdateUserReq
 		return nil, err
 	}
 
+	// 只更新了非空字段，响应需要重新加载完整的用户信息（含角色）
+	usr, err := s.userRepo.FindByID(ctx, usr.ID)
+	if err != nil {
+		logger.Error("failed to find updated user", applog.Error(err))
+		return nil, err
+	}
+
 	logger.Info("update user successfully")
 	return response.ToUserResponse(usr), nil
 }
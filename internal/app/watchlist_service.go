@@ -0,0 +1,230 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"mrs/internal/api/dto/request"
+	"mrs/internal/api/dto/response"
+	"mrs/internal/domain/movie"
+	"mrs/internal/domain/notification"
+	"mrs/internal/domain/shared"
+	"mrs/internal/domain/shared/vo"
+	"mrs/internal/domain/showtime"
+	"mrs/internal/domain/watchlist"
+	applog "mrs/pkg/log"
+	"time"
+)
+
+// 提醒任务每批扫描的关注条目数
+const watchlistAlertBatchSize = 500
+
+type WatchlistService interface {
+	AddToWatchlist(ctx context.Context, req *request.AddWatchlistRequest) (*response.WatchlistEntryResponse, error)
+	RemoveFromWatchlist(ctx context.Context, req *request.RemoveWatchlistRequest) error
+	ListWatchlist(ctx context.Context, req *request.ListWatchlistRequest) (*response.PaginatedWatchlistResponse, error)
+	// 扫描尚未提醒的关注条目，为已排出匹配场次的条目发送提醒（由定时任务调用）
+	DispatchWatchlistAlerts(ctx context.Context) error
+}
+
+type watchlistService struct {
+	uow           shared.UnitOfWork
+	watchlistRepo watchlist.WatchlistRepository
+	showtimeRepo  showtime.ShowtimeRepository
+	notifier      notification.Notifier
+	logger        applog.Logger
+}
+
+func NewWatchlistService(
+	uow shared.UnitOfWork,
+	watchlistRepo watchlist.WatchlistRepository,
+	showtimeRepo showtime.ShowtimeRepository,
+	notifier notification.Notifier,
+	logger applog.Logger,
+) WatchlistService {
+	return &watchlistService{
+		uow:           uow,
+		watchlistRepo: watchlistRepo,
+		showtimeRepo:  showtimeRepo,
+		notifier:      notifier,
+		logger:        logger.With(applog.String("Service", "WatchlistService")),
+	}
+}
+
+// 关注电影：电影必须存在且未下架，偏好影厅必须属于偏好影院
+func (s *watchlistService) AddToWatchlist(ctx context.Context, req *request.AddWatchlistRequest) (*response.WatchlistEntryResponse, error) {
+	logger := s.logger.With(applog.String("Method", "AddToWatchlist"),
+		applog.Uint("user_id", req.UserID), applog.Uint("movie_id", req.MovieID))
+
+	entry := watchlist.NewWatchlistEntry(vo.UserID(req.UserID), vo.MovieID(req.MovieID),
+		vo.CinemaID(req.PreferredCinemaID), vo.CinemaHallID(req.PreferredHallID))
+	err := s.uow.Execute(ctx, func(ctx context.Context, provider shared.RepositoryProvider) error {
+		mv, err := provider.GetMovieRepository().FindByID(ctx, entry.MovieID)
+		if err != nil {
+			logger.Warn("failed to find movie", applog.Error(err))
+			return err
+		}
+		if mv.IsArchived() {
+			logger.Warn("movie is archived")
+			return fmt.Errorf("%w(id): %v", movie.ErrMovieArchived, mv.ID)
+		}
+
+		if entry.PreferredCinemaID != 0 {
+			if _, err := provider.GetCinemaRepository().FindByID(ctx, entry.PreferredCinemaID); err != nil {
+				logger.Warn("failed to find preferred cinema", applog.Error(err))
+				return err
+			}
+		}
+		if entry.PreferredHallID != 0 {
+			hall, err := provider.GetCinemaHallRepository().FindByID(ctx, entry.PreferredHallID)
+			if err != nil {
+				logger.Warn("failed to find preferred cinema hall", applog.Error(err))
+				return err
+			}
+			if entry.PreferredCinemaID != 0 && hall.CinemaID != entry.PreferredCinemaID {
+				logger.Warn("preferred hall does not belong to preferred cinema", applog.Uint("hall_cinema_id", uint(hall.CinemaID)))
+				return fmt.Errorf("%w: hall %d does not belong to cinema %d",
+					watchlist.ErrInvalidWatchlistPreference, entry.PreferredHallID, entry.PreferredCinemaID)
+			}
+		}
+
+		entry, err = provider.GetWatchlistRepository().Create(ctx, entry)
+		if err != nil {
+			logger.Warn("failed to create watchlist entry", applog.Error(err))
+			return err
+		}
+		entry.Movie = mv
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("add to watchlist successfully", applog.Uint("watchlist_entry_id", uint(entry.ID)))
+	return response.ToWatchlistEntryResponse(entry), nil
+}
+
+// 取消关注
+func (s *watchlistService) RemoveFromWatchlist(ctx context.Context, req *request.RemoveWatchlistRequest) error {
+	logger := s.logger.With(applog.String("Method", "RemoveFromWatchlist"),
+		applog.Uint("user_id", req.UserID), applog.Uint("movie_id", req.MovieID))
+
+	if err := s.watchlistRepo.Delete(ctx, vo.UserID(req.UserID), vo.MovieID(req.MovieID)); err != nil {
+		logger.Warn("failed to delete watchlist entry", applog.Error(err))
+		return err
+	}
+
+	logger.Info("remove from watchlist successfully")
+	return nil
+}
+
+// 查询自己的关注列表
+func (s *watchlistService) ListWatchlist(ctx context.Context, req *request.ListWatchlistRequest) (*response.PaginatedWatchlistResponse, error) {
+	logger := s.logger.With(applog.String("Method", "ListWatchlist"), applog.Uint("user_id", req.UserID))
+
+	entries, total, err := s.watchlistRepo.ListByUser(ctx, vo.UserID(req.UserID), req.Page, req.PageSize)
+	if err != nil {
+		logger.Error("failed to list watchlist entries", applog.Error(err))
+		return nil, err
+	}
+
+	entryResponses := make([]*response.WatchlistEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		entryResponses = append(entryResponses, response.ToWatchlistEntryResponse(entry))
+	}
+
+	logger.Info("list watchlist successfully", applog.Int64("total", total))
+	return &response.PaginatedWatchlistResponse{
+		Pagination: response.PaginationResponse{
+			Page:       req.Page,
+			PageSize:   req.PageSize,
+			TotalCount: int(total),
+			TotalPages: int(math.Ceil(float64(total) / float64(req.PageSize))),
+		},
+		Entries: entryResponses,
+	}, nil
+}
+
+// 按ID游标分批扫描未提醒的条目。通知发送成功后才标记已提醒，
+// 发送失败的批次保持未提醒状态，下一次任务执行时重试（至少一次投递）
+func (s *watchlistService) DispatchWatchlistAlerts(ctx context.Context) error {
+	logger := s.logger.With(applog.String("Method", "DispatchWatchlistAlerts"))
+	now := time.Now()
+
+	notified := 0
+	var cursor vo.WatchlistEntryID
+	for {
+		entries, err := s.watchlistRepo.FindPending(ctx, cursor, watchlistAlertBatchSize)
+		if err != nil {
+			logger.Error("failed to find pending watchlist entries", applog.Error(err))
+			return err
+		}
+		if len(entries) == 0 {
+			break
+		}
+		cursor = entries[len(entries)-1].ID
+
+		count, err := s.dispatchBatch(ctx, entries, now)
+		if err != nil {
+			logger.Error("failed to dispatch watchlist alerts", applog.Error(err), applog.Int("notified", notified))
+			return err
+		}
+		notified += count
+
+		if len(entries) < watchlistAlertBatchSize {
+			break
+		}
+	}
+
+	if notified > 0 {
+		logger.Info("dispatch watchlist alerts successfully", applog.Int("notified", notified))
+	}
+	return nil
+}
+
+// 为一批条目匹配场次并发送提醒，返回已提醒的条目数
+func (s *watchlistService) dispatchBatch(ctx context.Context, entries []*watchlist.WatchlistEntry, now time.Time) (int, error) {
+	movieIDs := make([]vo.MovieID, 0, len(entries))
+	seen := make(map[vo.MovieID]struct{}, len(entries))
+	for _, entry := range entries {
+		if _, ok := seen[entry.MovieID]; ok {
+			continue
+		}
+		seen[entry.MovieID] = struct{}{}
+		movieIDs = append(movieIDs, entry.MovieID)
+	}
+
+	showtimes, err := s.showtimeRepo.FindUpcomingByMovieIDs(ctx, movieIDs, now)
+	if err != nil {
+		return 0, err
+	}
+	if len(showtimes) == 0 {
+		return 0, nil
+	}
+	byMovie := make(map[vo.MovieID][]*showtime.Showtime)
+	for _, st := range showtimes {
+		byMovie[st.MovieID] = append(byMovie[st.MovieID], st)
+	}
+
+	events := make([]*notification.Event, 0)
+	ids := make([]vo.WatchlistEntryID, 0)
+	for _, entry := range entries {
+		st := entry.FirstMatch(byMovie[entry.MovieID])
+		if st == nil {
+			continue
+		}
+		events = append(events, entry.AlertEvent(st))
+		ids = append(ids, entry.ID)
+	}
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	if err := s.notifier.Notify(ctx, events); err != nil {
+		return 0, err
+	}
+	if err := s.watchlistRepo.MarkNotified(ctx, ids, now); err != nil {
+		return 0, err
+	}
+	return len(ids), nil
+}
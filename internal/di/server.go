@@ -0,0 +1,91 @@
+package di
+
+import (
+	"mrs/internal/app"
+	"mrs/internal/infrastructure/config"
+	"mrs/internal/infrastructure/scheduler"
+	applog "mrs/pkg/log"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+)
+
+// 定时任务的默认执行间隔
+const (
+	defaultMovieLifecycleInterval     = time.Minute
+	defaultWatchlistAlertInterval     = time.Minute
+	defaultRecommendationInterval     = time.Hour
+	defaultReportSubscriptionInterval = time.Minute
+	defaultTrendingRebuildInterval    = 10 * time.Minute
+	defaultFunnelFlushInterval        = 5 * time.Second
+)
+
+// Server 聚合了 HTTP 引擎与后台定时任务
+type Server struct {
+	Engine    *gin.Engine
+	Scheduler *scheduler.Scheduler
+}
+
+func NewServer(engine *gin.Engine, sched *scheduler.Scheduler) *Server {
+	return &Server{Engine: engine, Scheduler: sched}
+}
+
+// NewScheduler 创建调度器并注册后台定时任务
+func NewScheduler(
+	cfg config.SchedulerConfig,
+	movieService app.MovieService,
+	watchlistService app.WatchlistService,
+	recommendationService app.RecommendationService,
+	reportSubscriptionService app.ReportSubscriptionService,
+	trendingService app.TrendingService,
+	showtimeService app.ShowtimeService,
+	logger applog.Logger,
+) *scheduler.Scheduler {
+	sched := scheduler.NewScheduler(logger)
+	sched.Register(scheduler.Job{
+		Name:     "movie_lifecycle",
+		Interval: intervalOrDefault(cfg.MovieLifecycleInterval, defaultMovieLifecycleInterval),
+		Run:      movieService.RefreshMovieStatuses,
+	})
+	sched.Register(scheduler.Job{
+		Name:     "watchlist_alerts",
+		Interval: intervalOrDefault(cfg.WatchlistAlertInterval, defaultWatchlistAlertInterval),
+		Run:      watchlistService.DispatchWatchlistAlerts,
+	})
+	sched.Register(scheduler.Job{
+		Name:     "recommendations",
+		Interval: intervalOrDefault(cfg.RecommendationInterval, defaultRecommendationInterval),
+		Run:      recommendationService.RefreshRecommendations,
+	})
+	sched.Register(scheduler.Job{
+		Name:     "report_subscriptions",
+		Interval: intervalOrDefault(cfg.ReportSubscriptionInterval, defaultReportSubscriptionInterval),
+		Run:      reportSubscriptionService.DispatchReportSubscriptions,
+	})
+	sched.Register(scheduler.Job{
+		Name:     "trending_rebuild",
+		Interval: intervalOrDefault(cfg.TrendingRebuildInterval, defaultTrendingRebuildInterval),
+		Run:      trendingService.RebuildTrending,
+	})
+	sched.Register(scheduler.Job{
+		Name:     "funnel_flush",
+		Interval: intervalOrDefault(cfg.FunnelFlushInterval, defaultFunnelFlushInterval),
+		Run:      showtimeService.FlushSeatMapViews,
+	})
+	return sched
+}
+
+// 间隔为0时使用默认值，小于0时保持原值（任务被禁用）
+func intervalOrDefault(interval, def time.Duration) time.Duration {
+	if interval == 0 {
+		return def
+	}
+	return interval
+}
+
+// ServerSet 提供了服务进程（HTTP 引擎 + 定时任务）
+var ServerSet = wire.NewSet(
+	NewScheduler,
+	NewServer,
+)
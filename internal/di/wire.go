@@ -7,8 +7,11 @@ import (
 	"mrs/internal/app"
 	"mrs/internal/infrastructure/cache"
 	"mrs/internal/infrastructure/config"
+	"mrs/internal/infrastructure/delivery"
+	"mrs/internal/infrastructure/notification"
 	"mrs/internal/infrastructure/persistence/decorators"
 	"mrs/internal/infrastructure/persistence/mysql/repository"
+	"mrs/internal/infrastructure/storage"
 	"mrs/internal/utils"
 	applog "mrs/pkg/log"
 
@@ -19,7 +22,7 @@ import (
 // ConfigSet 提供了配置加载
 var ConfigSet = wire.NewSet(
 	config.LoadConfig,
-	wire.FieldsOf(new(*config.Config), "DatabaseConfig", "RedisConfig", "LogConfig", "AuthConfig", "JWTConfig", "ServerConfig"),
+	wire.FieldsOf(new(*config.Config), "DatabaseConfig", "RedisConfig", "LogConfig", "AuthConfig", "JWTConfig", "ServerConfig", "StorageConfig", "AgeRatingConfig", "LoyaltyConfig", "SchedulerConfig", "ReportDeliveryConfig"),
 )
 
 // LoggerSet 提供了日志组件
@@ -52,11 +55,26 @@ var RepositorySet = wire.NewSet(
 	repository.NewGormRoleRepository,
 	decorators.NewMovieRepository,
 	repository.NewGormGenreRepository,
+	repository.NewGormPersonRepository,
+	repository.NewGormCinemaRepository,
 	repository.NewGormCinemaHallRepository,
 	repository.NewGormSeatRepository,
+	repository.NewGormSeatRestrictionRepository,
+	repository.NewGormHallLayoutVersionRepository,
 	decorators.NewShowtimeRepository,
 	repository.NewGormBookingRepository,
 	repository.NewGormBookedSeatRepository,
+	repository.NewGormDailySalesRepository,
+	repository.NewGormMovieSearchIndex,
+	repository.NewGormReviewRepository,
+	repository.NewGormMediaAssetRepository,
+	repository.NewGormWatchlistRepository,
+	repository.NewGormRecommendationRepository,
+	repository.NewGormReportSubscriptionRepository,
+	repository.NewGormReportRunRepository,
+	repository.NewGormTrendingRepository,
+	repository.NewGormFunnelEventRepository,
+	repository.NewGormLoyaltyLedgerRepository,
 )
 
 // CacheSet 提供了缓存组件
@@ -65,6 +83,30 @@ var CacheSet = wire.NewSet(
 	cache.NewRedisShowtimeCache,
 	cache.NewCinemaHallCache,
 	cache.NewRedisSeatCache,
+	cache.NewRedisRecommendationCache,
+	cache.NewRedisTrendingCache,
+)
+
+// StorageSet 提供了媒体文件存储组件
+var StorageSet = wire.NewSet(
+	storage.NewBlobStore,
+	storage.NewMediaPolicy,
+)
+
+// PolicySet 提供了由配置生成的业务策略
+var PolicySet = wire.NewSet(
+	config.NewAgeRatingPolicy,
+	config.NewLoyaltyProgram,
+)
+
+// NotificationSet 提供了通知组件
+var NotificationSet = wire.NewSet(
+	notification.NewLogNotifier,
+)
+
+// DeliverySet 提供了报表订阅的投递组件
+var DeliverySet = wire.NewSet(
+	delivery.NewDeliverers,
 )
 
 // ServiceSet 提供了服务组件
@@ -76,6 +118,14 @@ var ServiceSet = wire.NewSet(
 	app.NewShowtimeService,
 	app.NewBookingService,
 	app.NewReportService,
+	app.NewReviewService,
+	app.NewPersonService,
+	app.NewMediaService,
+	app.NewWatchlistService,
+	app.NewRecommendationService,
+	app.NewReportSubscriptionService,
+	app.NewTrendingService,
+	app.NewLoyaltyService,
 )
 
 // HandlerSet 提供了处理器组件
@@ -88,6 +138,14 @@ var HandlerSet = wire.NewSet(
 	handlers.NewShowtimeHandler,
 	handlers.NewBookingHandler,
 	handlers.NewReportHandler,
+	handlers.NewReviewHandler,
+	handlers.NewPersonHandler,
+	handlers.NewMediaHandler,
+	handlers.NewWatchlistHandler,
+	handlers.NewRecommendationHandler,
+	handlers.NewReportSubscriptionHandler,
+	handlers.NewTrendingHandler,
+	handlers.NewLoyaltyHandler,
 )
 
 // MiddlewareSet 提供了中间件组件
@@ -95,6 +153,7 @@ var MiddlewareSet = wire.NewSet(
 	middleware.AdminMiddleware,
 	middleware.AuthMiddleware,
 	middleware.LoggerMiddleware,
+	middleware.LocaleMiddleware,
 )
 
 // RouterSet 提供了路由组件
@@ -111,6 +170,10 @@ var FullAppSet = wire.NewSet(
 	UtilsSet,
 	RepositorySet,
 	CacheSet,
+	StorageSet,
+	PolicySet,
+	NotificationSet,
+	DeliverySet,
 	ServiceSet,
 	HandlerSet,
 	MiddlewareSet,
@@ -0,0 +1,180 @@
+package analytics
+
+import (
+	"fmt"
+	"mrs/internal/domain/booking"
+	"mrs/internal/domain/shared/vo"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// 漏斗的聚合维度
+type FunnelDimension string
+
+const (
+	FunnelByMovie    FunnelDimension = "movie"
+	FunnelByHour     FunnelDimension = "hour"      // 会话开始时间的小时（0~23）
+	FunnelByTimeSlot FunnelDimension = "time_slot" // 会话开始时间所在的时段（与上座率报告的放映时段划分相同）
+)
+
+// 漏斗查询条件，时间范围按事件发生时间过滤
+type FunnelQueryOptions struct {
+	MovieID      uint
+	CinemaID     uint
+	CinemaHallID uint
+	StartDate    time.Time
+	EndDate      time.Time
+}
+
+// 按电影与会话开始小时汇总的会话数（仓储查询结果）
+type FunnelCount struct {
+	MovieID    vo.MovieID
+	MovieTitle string
+	Hour       int // 会话开始时间的小时（服务器时区）
+	Sessions   int // 会话数，即进入漏斗的会话
+	Locked     int // 锁定过座位的会话
+	Booked     int // 创建过订单的会话
+	Confirmed  int // 确认过订单的会话
+	Cancelled  int // 取消过订单且没有确认订单的会话
+}
+
+// 订单从创建到确认所用的时间
+type ConfirmDuration struct {
+	MovieID    vo.MovieID
+	MovieTitle string
+	Hour       int // 所属会话开始时间的小时
+	Seconds    float64
+}
+
+// 按维度聚合的漏斗
+type FunnelGroup struct {
+	Key       string // 维度取值：电影ID、小时（00~23）或时段
+	Label     string // 展示名称：电影标题、小时（09:00）或时段
+	Sessions  int
+	Locked    int
+	Booked    int
+	Confirmed int
+	Cancelled int
+
+	durations []float64
+	order     int
+}
+
+func (g *FunnelGroup) add(c *FunnelCount) {
+	g.Sessions += c.Sessions
+	g.Locked += c.Locked
+	g.Booked += c.Booked
+	g.Confirmed += c.Confirmed
+	g.Cancelled += c.Cancelled
+}
+
+func ratio(numerator, denominator int) float64 {
+	if denominator <= 0 {
+		return 0
+	}
+	return float64(numerator) / float64(denominator)
+}
+
+// 锁座率 = 锁定过座位的会话 / 会话数
+func (g *FunnelGroup) LockRate() float64 {
+	return ratio(g.Locked, g.Sessions)
+}
+
+// 下单率 = 创建过订单的会话 / 会话数
+func (g *FunnelGroup) BookingRate() float64 {
+	return ratio(g.Booked, g.Sessions)
+}
+
+// 支付率 = 确认过订单的会话 / 创建过订单的会话
+func (g *FunnelGroup) ConfirmRate() float64 {
+	return ratio(g.Confirmed, g.Booked)
+}
+
+// 整体转化率 = 确认过订单的会话 / 会话数
+func (g *FunnelGroup) ConversionRate() float64 {
+	return ratio(g.Confirmed, g.Sessions)
+}
+
+// 流失的会话：创建了订单但没有确认（已取消或仍待支付）
+func (g *FunnelGroup) Abandoned() int {
+	return max(g.Booked-g.Confirmed, 0)
+}
+
+// 流失率 = 流失的会话 / 创建过订单的会话
+func (g *FunnelGroup) AbandonmentRate() float64 {
+	return ratio(g.Abandoned(), g.Booked)
+}
+
+// 创建订单到确认的中位时间（秒），没有确认的订单时返回 false
+func (g *FunnelGroup) MedianTimeToConfirm() (float64, bool) {
+	if len(g.durations) == 0 {
+		return 0, false
+	}
+	return Median(g.durations), true
+}
+
+// 中位数（偶数个时取中间两个的平均值），values 不会被修改
+func Median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// 汇总所有会话
+func SummarizeFunnel(counts []*FunnelCount, durations []*ConfirmDuration) *FunnelGroup {
+	group := &FunnelGroup{Key: "all", Label: "all"}
+	for _, count := range counts {
+		group.add(count)
+	}
+	for _, duration := range durations {
+		group.durations = append(group.durations, duration.Seconds)
+	}
+	return group
+}
+
+// 按维度聚合漏斗：电影按ID升序，小时从0点开始，时段从早场开始
+func GroupFunnel(counts []*FunnelCount, durations []*ConfirmDuration, dimension FunnelDimension) []*FunnelGroup {
+	groups := make(map[string]*FunnelGroup)
+	result := make([]*FunnelGroup, 0)
+	group := func(movieID vo.MovieID, movieTitle string, hour int) *FunnelGroup {
+		key, label, order := funnelKey(movieID, movieTitle, hour, dimension)
+		g, ok := groups[key]
+		if !ok {
+			g = &FunnelGroup{Key: key, Label: label, order: order}
+			groups[key] = g
+			result = append(result, g)
+		}
+		return g
+	}
+	for _, count := range counts {
+		group(count.MovieID, count.MovieTitle, count.Hour).add(count)
+	}
+	for _, duration := range durations {
+		g := group(duration.MovieID, duration.MovieTitle, duration.Hour)
+		g.durations = append(g.durations, duration.Seconds)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].order < result[j].order
+	})
+	return result
+}
+
+func funnelKey(movieID vo.MovieID, movieTitle string, hour int, dimension FunnelDimension) (string, string, int) {
+	switch dimension {
+	case FunnelByMovie:
+		return strconv.FormatUint(uint64(movieID), 10), movieTitle, int(movieID)
+	case FunnelByHour:
+		return fmt.Sprintf("%02d", hour), fmt.Sprintf("%02d:00", hour), hour
+	default:
+		slot := booking.TimeSlotOfHour(hour)
+		return string(slot), string(slot), slot.Order()
+	}
+}
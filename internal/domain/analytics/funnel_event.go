@@ -0,0 +1,54 @@
+package analytics
+
+import (
+	"mrs/internal/domain/booking"
+	"mrs/internal/domain/shared/vo"
+	"time"
+)
+
+// 关于转化漏斗：用户查看座位图、锁定座位、创建订单、确认订单或取消订单时各追加一条事件（只插入不修改）。
+// 同一用户对同一场次的事件构成一次会话，时间范围按事件发生时间过滤，会话按范围内最先发生的事件计入小时与时段；
+// 每个阶段统计到达该阶段或之后阶段的会话数，因此跳过座位图直接下单的会话也计入前面的阶段。
+// 当前没有待支付订单超时关闭的机制，未确认也未取消的订单计入流失（abandoned）但不单独统计。
+
+// 漏斗事件类型
+type EventType string
+
+const (
+	EventSeatMapViewed    EventType = "seat_map_viewed"   // 查看座位图
+	EventSeatsLocked      EventType = "seats_locked"      // 锁定座位
+	EventBookingCreated   EventType = "booking_created"   // 创建订单（待支付）
+	EventBookingConfirmed EventType = "booking_confirmed" // 确认订单
+	EventBookingCancelled EventType = "booking_cancelled" // 取消订单（用户取消待支付订单，或场次取消时取消/退款订单）
+)
+
+// 漏斗中尚未记录的阶段：没有待支付订单超时关闭的机制，不会产生订单过期事件，报告中随结果返回
+var UntrackedStages = []EventType{"booking_expired"}
+
+// 漏斗事件
+type FunnelEvent struct {
+	ID         vo.FunnelEventID
+	Type       EventType
+	UserID     vo.UserID
+	ShowtimeID vo.ShowtimeID
+	BookingID  vo.BookingID // 订单事件的订单ID，其余事件为0
+	Seats      int          // 锁定或预订的座位数，查看座位图时为0
+	OccurredAt time.Time
+}
+
+func NewFunnelEvent(eventType EventType, userID vo.UserID, showtimeID vo.ShowtimeID, seats int) *FunnelEvent {
+	return &FunnelEvent{
+		Type:       eventType,
+		UserID:     userID,
+		ShowtimeID: showtimeID,
+		Seats:      seats,
+		OccurredAt: time.Now(),
+	}
+}
+
+// 订单事件（调用方需加载座位）
+func NewBookingEvent(eventType EventType, b *booking.Booking) *FunnelEvent {
+	event := NewFunnelEvent(eventType, b.UserID, b.ShowtimeID, len(b.BookedSeats))
+	event.BookingID = b.ID
+	return event
+}
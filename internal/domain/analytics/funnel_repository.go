@@ -0,0 +1,12 @@
+package analytics
+
+import "context"
+
+// FunnelEventRepository 漏斗事件的追加与统计查询（事件只插入，不修改也不删除）
+type FunnelEventRepository interface {
+	Append(ctx context.Context, events ...*FunnelEvent) error
+	// 按电影与会话开始小时汇总各阶段的会话数
+	CountFunnel(ctx context.Context, options *FunnelQueryOptions) ([]*FunnelCount, error)
+	// 时间范围内会话中已确认订单从创建到确认所用的时间
+	FindConfirmDurations(ctx context.Context, options *FunnelQueryOptions) ([]*ConfirmDuration, error)
+}
@@ -0,0 +1,133 @@
+package analytics
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMedian(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   float64
+	}{
+		{"empty", nil, 0},
+		{"single", []float64{42}, 42},
+		{"odd count", []float64{30, 10, 20}, 20},
+		{"even count averages middle values", []float64{40, 10, 30, 20}, 25},
+		{"duplicates", []float64{5, 5, 5, 100}, 5},
+		{"outlier does not move median", []float64{1, 2, 3, 4, 10000}, 3},
+		{"fractions", []float64{0.5, 1.5}, 1},
+	}
+	for _, tt := range tests {
+		input := append([]float64(nil), tt.values...)
+		if got := Median(input); got != tt.want {
+			t.Errorf("%s: Median(%v) = %v, want %v", tt.name, tt.values, got, tt.want)
+		}
+		if !reflect.DeepEqual(input, append([]float64(nil), tt.values...)) {
+			t.Errorf("%s: Median modified input: %v", tt.name, input)
+		}
+	}
+}
+
+func TestFunnelGroup_Rates(t *testing.T) {
+	tests := []struct {
+		name  string
+		group FunnelGroup
+		// 锁座率、下单率、支付率、转化率、流失率
+		want      [5]float64
+		abandoned int
+	}{
+		{"empty", FunnelGroup{}, [5]float64{0, 0, 0, 0, 0}, 0},
+		{"full funnel", FunnelGroup{Sessions: 10, Locked: 8, Booked: 5, Confirmed: 4, Cancelled: 1},
+			[5]float64{0.8, 0.5, 0.8, 0.4, 0.2}, 1},
+		{"nothing booked", FunnelGroup{Sessions: 4, Locked: 2}, [5]float64{0.5, 0, 0, 0, 0}, 0},
+		{"pending bookings abandoned", FunnelGroup{Sessions: 4, Locked: 4, Booked: 4, Confirmed: 1},
+			[5]float64{1, 1, 0.25, 0.25, 0.75}, 3},
+	}
+	for _, tt := range tests {
+		g := tt.group
+		got := [5]float64{g.LockRate(), g.BookingRate(), g.ConfirmRate(), g.ConversionRate(), g.AbandonmentRate()}
+		if got != tt.want {
+			t.Errorf("%s: rates = %v, want %v", tt.name, got, tt.want)
+		}
+		if g.Abandoned() != tt.abandoned {
+			t.Errorf("%s: Abandoned() = %d, want %d", tt.name, g.Abandoned(), tt.abandoned)
+		}
+	}
+}
+
+func TestSummarizeFunnel(t *testing.T) {
+	counts := []*FunnelCount{
+		{MovieID: 1, Hour: 10, Sessions: 3, Locked: 2, Booked: 2, Confirmed: 1},
+		{MovieID: 2, Hour: 20, Sessions: 2, Locked: 2, Booked: 1, Confirmed: 1, Cancelled: 1},
+	}
+	durations := []*ConfirmDuration{{MovieID: 1, Hour: 10, Seconds: 90}, {MovieID: 2, Hour: 20, Seconds: 30}}
+
+	group := SummarizeFunnel(counts, durations)
+	if group.Sessions != 5 || group.Locked != 4 || group.Booked != 3 || group.Confirmed != 2 || group.Cancelled != 1 {
+		t.Errorf("SummarizeFunnel() = %+v, want {Sessions: 5, Locked: 4, Booked: 3, Confirmed: 2, Cancelled: 1}", *group)
+	}
+	if median, ok := group.MedianTimeToConfirm(); !ok || median != 60 {
+		t.Errorf("MedianTimeToConfirm() = (%v, %v), want (60, true)", median, ok)
+	}
+
+	if _, ok := SummarizeFunnel(counts, nil).MedianTimeToConfirm(); ok {
+		t.Errorf("MedianTimeToConfirm() without confirmed bookings: ok = true, want false")
+	}
+}
+
+func TestGroupFunnel(t *testing.T) {
+	counts := []*FunnelCount{
+		{MovieID: 2, MovieTitle: "B", Hour: 23, Sessions: 1, Booked: 1},
+		{MovieID: 1, MovieTitle: "A", Hour: 9, Sessions: 2, Booked: 2, Confirmed: 2},
+		{MovieID: 2, MovieTitle: "B", Hour: 9, Sessions: 3, Booked: 1, Confirmed: 1},
+		{MovieID: 1, MovieTitle: "A", Hour: 2, Sessions: 1},
+	}
+	durations := []*ConfirmDuration{
+		{MovieID: 1, MovieTitle: "A", Hour: 9, Seconds: 10},
+		{MovieID: 1, MovieTitle: "A", Hour: 9, Seconds: 50},
+		{MovieID: 2, MovieTitle: "B", Hour: 9, Seconds: 120},
+		// 会话所在的小时没有计数时也单独成组
+		{MovieID: 3, MovieTitle: "C", Hour: 14, Seconds: 7},
+	}
+
+	type group struct {
+		Key, Label string
+		Sessions   int
+		Median     float64
+		HasMedian  bool
+	}
+	tests := []struct {
+		dimension FunnelDimension
+		want      []group
+	}{
+		{FunnelByMovie, []group{
+			{"1", "A", 3, 30, true},
+			{"2", "B", 4, 120, true},
+			{"3", "C", 0, 7, true},
+		}},
+		{FunnelByHour, []group{
+			{"02", "02:00", 1, 0, false},
+			{"09", "09:00", 5, 50, true},
+			{"14", "14:00", 0, 7, true},
+			{"23", "23:00", 1, 0, false},
+		}},
+		{FunnelByTimeSlot, []group{
+			{"morning", "morning", 5, 50, true},
+			{"afternoon", "afternoon", 0, 7, true},
+			{"late_night", "late_night", 2, 0, false},
+		}},
+	}
+	for _, tt := range tests {
+		groups := GroupFunnel(counts, durations, tt.dimension)
+		got := make([]group, len(groups))
+		for i, g := range groups {
+			median, ok := g.MedianTimeToConfirm()
+			got[i] = group{g.Key, g.Label, g.Sessions, median, ok}
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("GroupFunnel(%s) = %+v, want %+v", tt.dimension, got, tt.want)
+		}
+	}
+}
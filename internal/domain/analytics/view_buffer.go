@@ -0,0 +1,74 @@
+package analytics
+
+import (
+	"mrs/internal/domain/shared/vo"
+	"sync"
+	"time"
+)
+
+// 查看座位图是高频的读操作（客户端会轮询），事件先写入内存缓冲，由定时任务批量写入数据库。
+// 同一用户对同一场次在去重窗口内只记录第一次查看：漏斗按会话统计，重复的查看事件不影响结果。
+// 缓冲已满时丢弃新事件；进程退出时尚未写入的事件会丢失（最多一个写入间隔）。
+
+const (
+	SeatMapViewDedupWindow = 10 * time.Minute // 查看座位图事件的去重窗口
+	MaxPendingSeatMapViews = 10000            // 缓冲中等待写入的事件上限
+)
+
+type viewKey struct {
+	userID     vo.UserID
+	showtimeID vo.ShowtimeID
+}
+
+// 查看座位图事件的缓冲（并发安全）
+type SeatMapViewBuffer struct {
+	mu         sync.Mutex
+	window     time.Duration
+	maxPending int
+	lastSeen   map[viewKey]time.Time
+	pending    []*FunnelEvent
+	dropped    int
+}
+
+func NewSeatMapViewBuffer(window time.Duration, maxPending int) *SeatMapViewBuffer {
+	return &SeatMapViewBuffer{
+		window:     window,
+		maxPending: maxPending,
+		lastSeen:   make(map[viewKey]time.Time),
+	}
+}
+
+// 记录一次查看，返回事件是否进入缓冲（去重窗口内的重复查看与缓冲已满时返回false）
+func (b *SeatMapViewBuffer) Add(userID vo.UserID, showtimeID vo.ShowtimeID, now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	key := viewKey{userID: userID, showtimeID: showtimeID}
+	if seen, ok := b.lastSeen[key]; ok && now.Sub(seen) < b.window {
+		return false
+	}
+	if len(b.pending) >= b.maxPending {
+		b.dropped++
+		return false
+	}
+	b.lastSeen[key] = now
+	event := NewFunnelEvent(EventSeatMapViewed, userID, showtimeID, 0)
+	event.OccurredAt = now
+	b.pending = append(b.pending, event)
+	return true
+}
+
+// 取出等待写入的事件与上次取出后因缓冲已满丢弃的事件数，并清理已过去重窗口的记录
+func (b *SeatMapViewBuffer) Drain(now time.Time) ([]*FunnelEvent, int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for key, seen := range b.lastSeen {
+		if now.Sub(seen) >= b.window {
+			delete(b.lastSeen, key)
+		}
+	}
+	events, dropped := b.pending, b.dropped
+	b.pending, b.dropped = nil, 0
+	return events, dropped
+}
@@ -0,0 +1,65 @@
+package analytics
+
+import (
+	"mrs/internal/domain/shared/vo"
+	"testing"
+	"time"
+)
+
+func TestSeatMapViewBuffer_DeduplicatesWithinWindow(t *testing.T) {
+	buffer := NewSeatMapViewBuffer(10*time.Minute, 100)
+	start := time.Date(2026, 10, 18, 20, 0, 0, 0, time.UTC)
+
+	steps := []struct {
+		name     string
+		userID   uint
+		showtime uint
+		at       time.Duration
+		want     bool
+	}{
+		{"first view", 1, 10, 0, true},
+		{"repeat within window", 1, 10, 5 * time.Minute, false},
+		{"other showtime", 1, 11, 5 * time.Minute, true},
+		{"other user", 2, 10, 5 * time.Minute, true},
+		{"repeat after window", 1, 10, 10 * time.Minute, true},
+	}
+	for _, step := range steps {
+		got := buffer.Add(vo.UserID(step.userID), vo.ShowtimeID(step.showtime), start.Add(step.at))
+		if got != step.want {
+			t.Errorf("%s: Add = %v, want %v", step.name, got, step.want)
+		}
+	}
+
+	events, dropped := buffer.Drain(start.Add(10 * time.Minute))
+	if len(events) != 4 || dropped != 0 {
+		t.Fatalf("Drain = %d events, %d dropped, want 4 events, 0 dropped", len(events), dropped)
+	}
+	if events[0].Type != EventSeatMapViewed || !events[0].OccurredAt.Equal(start) {
+		t.Errorf("first event = %+v, want seat_map_viewed at %v", events[0], start)
+	}
+	if events, _ := buffer.Drain(start.Add(11 * time.Minute)); len(events) != 0 {
+		t.Errorf("second Drain = %d events, want 0", len(events))
+	}
+
+	// 去重窗口过后（记录已被 Drain 清理），同一用户对同一场次可以再次记录
+	if !buffer.Add(vo.UserID(2), vo.ShowtimeID(10), start.Add(16*time.Minute)) {
+		t.Error("Add after dedup window expired = false, want true")
+	}
+}
+
+func TestSeatMapViewBuffer_DropsWhenFull(t *testing.T) {
+	buffer := NewSeatMapViewBuffer(time.Minute, 2)
+	now := time.Now()
+	for i := uint(1); i <= 3; i++ {
+		buffer.Add(vo.UserID(i), vo.ShowtimeID(1), now)
+	}
+
+	events, dropped := buffer.Drain(now)
+	if len(events) != 2 || dropped != 1 {
+		t.Fatalf("Drain = %d events, %d dropped, want 2 events, 1 dropped", len(events), dropped)
+	}
+	// 被丢弃的查看没有记入去重窗口
+	if !buffer.Add(vo.UserID(3), vo.ShowtimeID(1), now) {
+		t.Error("Add of previously dropped view = false, want true")
+	}
+}
@@ -12,4 +12,5 @@ type BookedSeatRepository interface {
 	Update(ctx context.Context, bookedSeat *BookedSeat) error
 	Delete(ctx context.Context, id vo.BookedSeatID) error
 	DeleteByBookingID(ctx context.Context, bookingID vo.BookingID) error
+	DeleteByBookingIDs(ctx context.Context, bookingIDs []vo.BookingID) error
 }
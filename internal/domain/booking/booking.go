@@ -1,6 +1,8 @@
 package booking
 
 import (
+	"math"
+	"mrs/internal/domain/movie"
 	"mrs/internal/domain/shared/vo"
 	"time"
 )
@@ -12,8 +14,12 @@ const (
 	BookingStatusPending   BookingStatus = "pending"
 	BookingStatusConfirmed BookingStatus = "confirmed"
 	BookingStatusCanceled  BookingStatus = "canceled"
+	BookingStatusRefunded  BookingStatus = "refunded"
 )
 
+// 有效订单状态（仍占用座位的订单）
+var LiveBookingStatuses = []BookingStatus{BookingStatusPending, BookingStatusConfirmed}
+
 // Booking 表示一个电影票订单
 type Booking struct {
 	ID          vo.BookingID
@@ -23,6 +29,32 @@ type Booking struct {
 	TotalAmount float64
 	BookingTime time.Time
 	Status      BookingStatus
+
+	// 订单确认时间（未确认的订单为nil），热门榜按确认时间统计售票
+	ConfirmedAt *time.Time
+
+	// 下单时电影分级对应的年龄要求快照与核验结果，检票时展示（电影无年龄限制时为nil）
+	AgeRequirement *movie.AgeRequirement
+	AgeCheck       movie.AgeCheck
+
+	// 下单时的会员折扣与积分抵扣（没有优惠时为nil），TotalAmount 为优惠后的实付金额
+	Discount *Discount
+}
+
+// 订单优惠：会员等级折扣与积分抵扣，票面金额（座位价格之和）减去优惠即为实付金额
+type Discount struct {
+	Tier           string  // 下单时的会员等级
+	TierDiscount   float64 // 等级折扣金额
+	PointsRedeemed int     // 抵扣使用的积分
+	PointsDiscount float64 // 积分抵扣金额
+}
+
+// 优惠总金额
+func (d *Discount) Amount() float64 {
+	if d == nil {
+		return 0
+	}
+	return d.TierDiscount + d.PointsDiscount
 }
 
 func NewBooking(userID vo.UserID, showtimeID vo.ShowtimeID, bookedSeats []*BookedSeat, totalAmount float64) *Booking {
@@ -36,9 +68,38 @@ func NewBooking(userID vo.UserID, showtimeID vo.ShowtimeID, bookedSeats []*Booke
 	}
 }
 
+// 记录下单时的年龄要求与核验结果
+func (b *Booking) SetAgeRequirement(requirement *movie.AgeRequirement, check movie.AgeCheck) {
+	if !requirement.Restricted() && check != movie.AgeCheckUnverified {
+		b.AgeRequirement, b.AgeCheck = nil, movie.AgeCheckNotRequired
+		return
+	}
+	b.AgeRequirement, b.AgeCheck = requirement, check
+}
+
+// 应用优惠并扣减实付金额，没有优惠时不记录
+func (b *Booking) ApplyDiscount(d *Discount) {
+	if d == nil || (d.Amount() <= 0 && d.PointsRedeemed <= 0) {
+		b.Discount = nil
+		return
+	}
+	b.Discount = d
+	b.TotalAmount = math.Round((b.TotalAmount-d.Amount())*100) / 100
+}
+
 // 确认订单
 func (b *Booking) Confirm() {
+	now := time.Now()
 	b.Status = BookingStatusConfirmed
+	b.ConfirmedAt = &now
+}
+
+// 售出时间：确认时间，早期没有记录确认时间的订单退回到下单时间
+func (b *Booking) SoldAt() time.Time {
+	if b.ConfirmedAt != nil {
+		return *b.ConfirmedAt
+	}
+	return b.BookingTime
 }
 
 // 取消订单
@@ -46,4 +107,14 @@ func (b *Booking) Cancel() {
 	b.Status = BookingStatusCanceled
 }
 
+// 退款订单（已确认的订单取消时需要退款）
+func (b *Booking) Refund() {
+	b.Status = BookingStatusRefunded
+}
+
+// 订单是否仍有效（占用座位）
+func (b *Booking) IsLive() bool {
+	return b.Status == BookingStatusPending || b.Status == BookingStatusConfirmed
+}
+
 // 索引: 在(user_id, booking_time) 和 (showtime_id) 上创建索引
@@ -11,10 +11,22 @@ type BookingRepository interface {
 	FindByID(ctx context.Context, id vo.BookingID) (*Booking, error)
 	FindByUserID(ctx context.Context, userID vo.UserID) ([]*Booking, error)
 	FindByShowtimeID(ctx context.Context, showtimeID vo.ShowtimeID) ([]*Booking, error)
+	// 按ID升序分批查询场次下的有效订单（afterID为上一批最后一个订单ID）
+	FindLiveByShowtimeID(ctx context.Context, showtimeID vo.ShowtimeID, afterID vo.BookingID, limit int) ([]*Booking, error)
+	CountLiveByShowtimeID(ctx context.Context, showtimeID vo.ShowtimeID) (int64, error)
+	// 用户是否有该电影在 before 之前开场的已确认订单（即看过该电影）
+	HasAttendedMovie(ctx context.Context, userID vo.UserID, movieID vo.MovieID, before time.Time) (bool, error)
 	List(ctx context.Context, options *BookingQueryOptions) ([]*Booking, int64, error)
 	Update(ctx context.Context, booking *Booking) error
+	UpdateStatusBatch(ctx context.Context, ids []vo.BookingID, status BookingStatus) error
 	Delete(ctx context.Context, id vo.BookingID) error
 	GetSalesStatistics(ctx context.Context, options *SalesQueryOptions) (*SalesStatistics, error)
+	// 按时间桶和/或维度分组的销售统计，按维度分组时按收入降序返回
+	GetSalesGroups(ctx context.Context, options *SalesQueryOptions, query *SalesGroupQuery) ([]*SalesBucket, error)
+	// 按日期口径的时间升序逐条读取订单明细，不一次性加载到内存；fn 返回错误时停止读取并返回该错误
+	StreamSalesRecords(ctx context.Context, options *SalesQueryOptions, fn func(*SalesRecord) error) error
+	// 按场次统计上座情况，时间范围按场次开始时间过滤，按开始时间升序返回
+	GetShowtimeOccupancy(ctx context.Context, options *SalesQueryOptions) ([]*ShowtimeOccupancy, error)
 }
 
 // BookingQueryOptions 表示查询订单的选项
@@ -27,14 +39,20 @@ type BookingQueryOptions struct {
 
 // SalesQueryOptions 表示销售统计查询的选项
 type SalesQueryOptions struct {
-	MovieID   uint
-	CinemaID  uint
-	StartDate time.Time
-	EndDate   time.Time
+	MovieID      uint
+	CinemaID     uint // 影院ID（按影院下所有影厅统计）
+	CinemaHallID uint // 影厅ID
+	StartDate    time.Time
+	EndDate      time.Time
+	// 时间范围的日期口径，默认按下单时间
+	DateBasis SalesDateBasis
+	// 订单状态，默认只统计已确认的订单
+	Status BookingStatus
 }
 
 // SalesStatistics 表示销售统计结果
 type SalesStatistics struct {
 	TotalRevenue  float64
 	TotalBookings int
+	TotalTickets  int
 }
@@ -0,0 +1,150 @@
+package booking
+
+import (
+	"context"
+	"math"
+	"mrs/internal/domain/shared/vo"
+	"time"
+)
+
+// 每日销售事实：按 业务日期 × 场次 × 电影 × 影厅 预聚合的订单数据，报表从这里读取而不是实时汇总订单表。
+// 业务日期为下单时间在服务器时区的自然日。订单确认时累加确认数据，退款时累加退款数据，
+// 退款计入原订单的业务日期，因此净值（确认 - 退款）与按订单表统计的已确认订单一致。
+type DailySales struct {
+	Date         time.Time // 业务日期（服务器时区零点）
+	ShowtimeID   vo.ShowtimeID
+	MovieID      vo.MovieID
+	CinemaHallID vo.CinemaHallID
+
+	Bookings  int     // 确认的订单数
+	Tickets   int     // 确认的票数
+	Gross     float64 // 票面金额（座位价格之和）
+	Discounts float64 // 优惠金额（票面金额 - 实付金额）
+
+	RefundedBookings int     // 退款的订单数
+	RefundedTickets  int     // 退款的票数
+	Refunds          float64 // 退款金额（退款订单的实付金额）
+}
+
+// 净收入 = 票面金额 - 优惠 - 退款
+func (d *DailySales) NetRevenue() float64 {
+	return d.Gross - d.Discounts - d.Refunds
+}
+
+func (d *DailySales) NetBookings() int {
+	return d.Bookings - d.RefundedBookings
+}
+
+func (d *DailySales) NetTickets() int {
+	return d.Tickets - d.RefundedTickets
+}
+
+// 事实的主键
+type DailySalesKey struct {
+	Date         time.Time
+	ShowtimeID   vo.ShowtimeID
+	MovieID      vo.MovieID
+	CinemaHallID vo.CinemaHallID
+}
+
+func (d *DailySales) Key() DailySalesKey {
+	return DailySalesKey{Date: d.Date, ShowtimeID: d.ShowtimeID, MovieID: d.MovieID, CinemaHallID: d.CinemaHallID}
+}
+
+func (d *DailySales) add(other *DailySales) {
+	d.Bookings += other.Bookings
+	d.Tickets += other.Tickets
+	d.Gross += other.Gross
+	d.Discounts += other.Discounts
+	d.RefundedBookings += other.RefundedBookings
+	d.RefundedTickets += other.RefundedTickets
+	d.Refunds += other.Refunds
+}
+
+// 按主键合并增量（同一批次中同一场次的多个订单只需一次写入），保持首次出现的顺序
+func MergeDailySales(deltas []*DailySales) []*DailySales {
+	merged := make([]*DailySales, 0, len(deltas))
+	index := make(map[DailySalesKey]*DailySales, len(deltas))
+	for _, delta := range deltas {
+		key := delta.Key()
+		if item, ok := index[key]; ok {
+			item.add(delta)
+			continue
+		}
+		item := *delta
+		index[key] = &item
+		merged = append(merged, &item)
+	}
+	return merged
+}
+
+// 业务日期：服务器时区的自然日
+func SalesDate(t time.Time) time.Time {
+	t = t.In(time.Local)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// 订单的票面金额（调用方需加载座位）
+func (b *Booking) GrossAmount() float64 {
+	gross := 0.0
+	for _, seat := range b.BookedSeats {
+		gross += seat.Price
+	}
+	return gross
+}
+
+// 订单确认产生的事实增量（调用方需加载座位）
+func ConfirmedSales(b *Booking, movieID vo.MovieID, hallID vo.CinemaHallID) *DailySales {
+	gross := b.GrossAmount()
+	return &DailySales{
+		Date:         SalesDate(b.BookingTime),
+		ShowtimeID:   b.ShowtimeID,
+		MovieID:      movieID,
+		CinemaHallID: hallID,
+		Bookings:     1,
+		Tickets:      len(b.BookedSeats),
+		Gross:        gross,
+		Discounts:    math.Round((gross-b.TotalAmount)*100) / 100,
+	}
+}
+
+// 已确认订单退款产生的事实增量（调用方需在释放座位前加载座位）
+func RefundedSales(b *Booking, movieID vo.MovieID, hallID vo.CinemaHallID) *DailySales {
+	return &DailySales{
+		Date:             SalesDate(b.BookingTime),
+		ShowtimeID:       b.ShowtimeID,
+		MovieID:          movieID,
+		CinemaHallID:     hallID,
+		RefundedBookings: 1,
+		RefundedTickets:  len(b.BookedSeats),
+		Refunds:          b.TotalAmount,
+	}
+}
+
+// 时间范围是否按业务日期整天划分（忽略秒以下的部分）：开始为零点，结束为当天最后一秒，
+// 此时按下单时间口径的统计可以直接读取每日销售事实
+func (o *SalesQueryOptions) DayAligned() bool {
+	if !o.StartDate.IsZero() && !o.StartDate.Truncate(time.Second).Equal(SalesDate(o.StartDate)) {
+		return false
+	}
+	if !o.EndDate.IsZero() {
+		next := o.EndDate.Truncate(time.Second).Add(time.Second)
+		if !next.Equal(SalesDate(next)) {
+			return false
+		}
+	}
+	return true
+}
+
+// 每日销售事实仓储
+type DailySalesRepository interface {
+	// 累加事实增量，行不存在时插入；应与订单状态变更在同一事务中调用
+	Add(ctx context.Context, deltas ...*DailySales) error
+	// 按订单表重新汇总 [start, end] 业务日期内的事实（先删除再写入），返回写入的行数
+	Rebuild(ctx context.Context, start, end time.Time) (int64, error)
+
+	// 与 BookingRepository 同名方法语义相同，只统计已确认订单（净值）。
+	// 按下单时间口径时，时间范围按业务日期过滤，时间桶为业务日期零点
+	GetSalesStatistics(ctx context.Context, options *SalesQueryOptions) (*SalesStatistics, error)
+	GetSalesGroups(ctx context.Context, options *SalesQueryOptions, query *SalesGroupQuery) ([]*SalesBucket, error)
+}
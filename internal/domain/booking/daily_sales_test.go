@@ -0,0 +1,124 @@
+package booking
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSalesDate(t *testing.T) {
+	shanghai := loadLocation(t, "Asia/Shanghai")
+	setLocal(t, shanghai)
+
+	tests := []struct {
+		in   time.Time
+		want time.Time
+	}{
+		{time.Date(2026, 6, 15, 0, 0, 0, 0, shanghai), time.Date(2026, 6, 15, 0, 0, 0, 0, shanghai)},
+		{time.Date(2026, 6, 15, 23, 59, 59, 999, shanghai), time.Date(2026, 6, 15, 0, 0, 0, 0, shanghai)},
+		// 业务日期按服务器时区划分：UTC 6月14日16:30 为上海时间6月15日00:30
+		{time.Date(2026, 6, 14, 16, 30, 0, 0, time.UTC), time.Date(2026, 6, 15, 0, 0, 0, 0, shanghai)},
+		{time.Date(2026, 6, 15, 15, 59, 0, 0, time.UTC), time.Date(2026, 6, 15, 0, 0, 0, 0, shanghai)},
+	}
+	for _, tt := range tests {
+		if got := SalesDate(tt.in); !got.Equal(tt.want) {
+			t.Errorf("SalesDate(%v) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestConfirmedAndRefundedSales(t *testing.T) {
+	setLocal(t, time.UTC)
+	bookedAt := time.Date(2026, 6, 15, 20, 30, 0, 0, time.UTC)
+	b := &Booking{
+		ShowtimeID:  7,
+		BookingTime: bookedAt,
+		TotalAmount: 95.5,
+		BookedSeats: []*BookedSeat{{Price: 60}, {Price: 40.2}},
+	}
+	day := time.Date(2026, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	confirmed := ConfirmedSales(b, 3, 5)
+	want := DailySales{Date: day, ShowtimeID: 7, MovieID: 3, CinemaHallID: 5, Bookings: 1, Tickets: 2, Gross: 100.2, Discounts: 4.7}
+	if !reflect.DeepEqual(*confirmed, want) {
+		t.Errorf("ConfirmedSales() = %+v, want %+v", *confirmed, want)
+	}
+
+	refunded := RefundedSales(b, 3, 5)
+	want = DailySales{Date: day, ShowtimeID: 7, MovieID: 3, CinemaHallID: 5, RefundedBookings: 1, RefundedTickets: 2, Refunds: 95.5}
+	if !reflect.DeepEqual(*refunded, want) {
+		t.Errorf("RefundedSales() = %+v, want %+v", *refunded, want)
+	}
+
+	// 确认后全额退款，净值归零
+	merged := MergeDailySales([]*DailySales{confirmed, refunded})
+	if len(merged) != 1 {
+		t.Fatalf("len(MergeDailySales) = %d, want 1", len(merged))
+	}
+	if got := merged[0]; got.NetRevenue() > 1e-9 || got.NetRevenue() < -1e-9 || got.NetBookings() != 0 || got.NetTickets() != 0 {
+		t.Errorf("net = (%v, %d, %d), want (0, 0, 0)", got.NetRevenue(), got.NetBookings(), got.NetTickets())
+	}
+}
+
+func TestMergeDailySales(t *testing.T) {
+	day := time.Date(2026, 6, 15, 0, 0, 0, 0, time.UTC)
+	next := day.AddDate(0, 0, 1)
+	deltas := []*DailySales{
+		{Date: day, ShowtimeID: 1, MovieID: 1, CinemaHallID: 1, Bookings: 1, Tickets: 2, Gross: 100},
+		{Date: next, ShowtimeID: 1, MovieID: 1, CinemaHallID: 1, Bookings: 1, Tickets: 1, Gross: 50},
+		{Date: day, ShowtimeID: 2, MovieID: 1, CinemaHallID: 1, Bookings: 1, Tickets: 1, Gross: 40},
+		{Date: day, ShowtimeID: 1, MovieID: 1, CinemaHallID: 1, Bookings: 1, Tickets: 3, Gross: 150, Discounts: 10},
+		{Date: day, ShowtimeID: 1, MovieID: 1, CinemaHallID: 1, RefundedBookings: 1, RefundedTickets: 2, Refunds: 100},
+	}
+	want := []DailySales{
+		{Date: day, ShowtimeID: 1, MovieID: 1, CinemaHallID: 1, Bookings: 2, Tickets: 5, Gross: 250, Discounts: 10,
+			RefundedBookings: 1, RefundedTickets: 2, Refunds: 100},
+		{Date: next, ShowtimeID: 1, MovieID: 1, CinemaHallID: 1, Bookings: 1, Tickets: 1, Gross: 50},
+		{Date: day, ShowtimeID: 2, MovieID: 1, CinemaHallID: 1, Bookings: 1, Tickets: 1, Gross: 40},
+	}
+
+	merged := MergeDailySales(deltas)
+	got := make([]DailySales, len(merged))
+	for i, item := range merged {
+		got[i] = *item
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeDailySales() = %+v, want %+v", got, want)
+	}
+	// 合并不修改输入
+	if deltas[0].Bookings != 1 || deltas[0].Tickets != 2 {
+		t.Errorf("MergeDailySales() modified input: %+v", *deltas[0])
+	}
+	if first := merged[0]; first.NetRevenue() != 140 || first.NetBookings() != 1 || first.NetTickets() != 3 {
+		t.Errorf("net = (%v, %d, %d), want (140, 1, 3)", first.NetRevenue(), first.NetBookings(), first.NetTickets())
+	}
+}
+
+func TestSalesQueryOptions_DayAligned(t *testing.T) {
+	shanghai := loadLocation(t, "Asia/Shanghai")
+	setLocal(t, shanghai)
+	midnight := time.Date(2026, 6, 15, 0, 0, 0, 0, shanghai)
+	lastSecond := midnight.AddDate(0, 0, 2).Add(-time.Second)
+
+	tests := []struct {
+		name       string
+		start, end time.Time
+		want       bool
+	}{
+		{"unbounded", time.Time{}, time.Time{}, true},
+		{"whole days", midnight, lastSecond, true},
+		{"sub-second end", midnight, lastSecond.Add(999 * time.Millisecond), true},
+		{"open start", time.Time{}, lastSecond, true},
+		{"open end", midnight, time.Time{}, true},
+		{"start mid-day", midnight.Add(time.Hour), lastSecond, false},
+		{"end at midnight", midnight, midnight.AddDate(0, 0, 1), false},
+		{"utc midnight is not server midnight", time.Date(2026, 6, 15, 0, 0, 0, 0, time.UTC), time.Time{}, false},
+		{"same instant in another zone", midnight.In(time.UTC), lastSecond.In(time.UTC), true},
+	}
+	for _, tt := range tests {
+		options := &SalesQueryOptions{StartDate: tt.start, EndDate: tt.end}
+		if got := options.DayAligned(); got != tt.want {
+			t.Errorf("%s: DayAligned() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
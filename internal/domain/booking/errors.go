@@ -8,4 +8,10 @@ var (
 	ErrBookedSeatAlreadyLocked = errors.New("booked seat already locked")
 	ErrBookedSeatNotFound      = errors.New("booked seat not found")
 	ErrBookingNotPending       = errors.New("booking is not pending")
+	ErrAgeRestricted           = errors.New("user does not meet the age requirement")
+
+	// 报表相关错误
+	ErrInvalidReportRange   = errors.New("invalid report date range")
+	ErrInvalidTimezone      = errors.New("invalid timezone")
+	ErrTooManyReportPeriods = errors.New("too many report periods")
 )
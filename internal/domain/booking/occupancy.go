@@ -0,0 +1,176 @@
+package booking
+
+import (
+	"mrs/internal/domain/shared/vo"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 上座率的聚合维度
+type OccupancyDimension string
+
+const (
+	OccupancyByMovie    OccupancyDimension = "movie"
+	OccupancyByHall     OccupancyDimension = "hall"
+	OccupancyByWeekday  OccupancyDimension = "weekday"
+	OccupancyByTimeSlot OccupancyDimension = "time_slot"
+)
+
+// 放映时段，按场次开始时间（服务器本地时间）划分
+type TimeSlot string
+
+const (
+	TimeSlotMorning   TimeSlot = "morning"    // 06:00 - 12:00
+	TimeSlotAfternoon TimeSlot = "afternoon"  // 12:00 - 17:00
+	TimeSlotEvening   TimeSlot = "evening"    // 17:00 - 22:00
+	TimeSlotLateNight TimeSlot = "late_night" // 22:00 - 次日06:00
+)
+
+// 时段的展示顺序
+var timeSlotOrder = map[TimeSlot]int{
+	TimeSlotMorning:   0,
+	TimeSlotAfternoon: 1,
+	TimeSlotEvening:   2,
+	TimeSlotLateNight: 3,
+}
+
+// 时段的展示顺序（早场在前）
+func (s TimeSlot) Order() int {
+	return timeSlotOrder[s]
+}
+
+func TimeSlotOf(t time.Time) TimeSlot {
+	return TimeSlotOfHour(t.Hour())
+}
+
+// 小时（0~23）所在的时段
+func TimeSlotOfHour(hour int) TimeSlot {
+	switch {
+	case hour >= 6 && hour < 12:
+		return TimeSlotMorning
+	case hour >= 12 && hour < 17:
+		return TimeSlotAfternoon
+	case hour >= 17 && hour < 22:
+		return TimeSlotEvening
+	default:
+		return TimeSlotLateNight
+	}
+}
+
+// 单个场次的上座情况（已取消的场次不参与统计）
+type ShowtimeOccupancy struct {
+	ShowtimeID   vo.ShowtimeID
+	MovieID      vo.MovieID
+	MovieTitle   string
+	CinemaHallID vo.CinemaHallID
+	HallName     string
+	StartTime    time.Time
+	Price        float64 // 场次票价
+	Capacity     int     // 可售容量：场次所用布局版本的座位数，不含场次时间内受限制的座位
+	SeatsSold    int     // 已确认订单的座位数
+	Revenue      float64 // 已售座位的实收金额
+}
+
+// 上座率 = 已售座位数 / 可售容量
+func (o *ShowtimeOccupancy) LoadFactor() float64 {
+	if o.Capacity <= 0 {
+		return 0
+	}
+	return float64(o.SeatsSold) / float64(o.Capacity)
+}
+
+func (o *ShowtimeOccupancy) EmptySeats() int {
+	return max(o.Capacity-o.SeatsSold, 0)
+}
+
+// 空座收入机会：空座全部按场次票价售出可增加的收入
+func (o *ShowtimeOccupancy) EmptySeatRevenue() float64 {
+	return float64(o.EmptySeats()) * o.Price
+}
+
+// 按维度聚合的上座情况
+type OccupancyGroup struct {
+	Key              string // 维度取值：电影ID、影厅ID、星期（monday...）或时段
+	Label            string // 展示名称：电影标题、影厅名称，星期与时段同 Key
+	Showtimes        int
+	Capacity         int
+	SeatsSold        int
+	Revenue          float64
+	EmptySeatRevenue float64
+
+	loadFactorSum float64
+	order         int
+}
+
+func (g *OccupancyGroup) add(o *ShowtimeOccupancy) {
+	g.Showtimes++
+	g.Capacity += o.Capacity
+	g.SeatsSold += o.SeatsSold
+	g.Revenue += o.Revenue
+	g.EmptySeatRevenue += o.EmptySeatRevenue()
+	g.loadFactorSum += o.LoadFactor()
+}
+
+// 平均上座率：各场次上座率的算术平均，每个场次权重相同
+func (g *OccupancyGroup) AverageLoadFactor() float64 {
+	if g.Showtimes == 0 {
+		return 0
+	}
+	return g.loadFactorSum / float64(g.Showtimes)
+}
+
+// 整体上座率：总售出座位数 / 总容量，大厅权重更高
+func (g *OccupancyGroup) OverallLoadFactor() float64 {
+	if g.Capacity <= 0 {
+		return 0
+	}
+	return float64(g.SeatsSold) / float64(g.Capacity)
+}
+
+// 汇总所有场次
+func SummarizeOccupancy(items []*ShowtimeOccupancy) *OccupancyGroup {
+	group := &OccupancyGroup{Key: "all", Label: "all"}
+	for _, item := range items {
+		group.add(item)
+	}
+	return group
+}
+
+// 按维度聚合场次上座情况：电影与影厅按ID升序，星期从周一开始，时段从早场开始
+func GroupOccupancy(items []*ShowtimeOccupancy, dimension OccupancyDimension) []*OccupancyGroup {
+	groups := make(map[string]*OccupancyGroup)
+	result := make([]*OccupancyGroup, 0)
+	for _, item := range items {
+		key, label, order := occupancyKey(item, dimension)
+		group, ok := groups[key]
+		if !ok {
+			group = &OccupancyGroup{Key: key, Label: label, order: order}
+			groups[key] = group
+			result = append(result, group)
+		}
+		group.add(item)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].order < result[j].order
+	})
+	return result
+}
+
+func occupancyKey(o *ShowtimeOccupancy, dimension OccupancyDimension) (string, string, int) {
+	switch dimension {
+	case OccupancyByMovie:
+		return strconv.FormatUint(uint64(o.MovieID), 10), o.MovieTitle, int(o.MovieID)
+	case OccupancyByHall:
+		return strconv.FormatUint(uint64(o.CinemaHallID), 10), o.HallName, int(o.CinemaHallID)
+	case OccupancyByWeekday:
+		weekday := o.StartTime.Weekday()
+		name := strings.ToLower(weekday.String())
+		// 周日排在最后
+		return name, name, (int(weekday) + 6) % 7
+	default:
+		slot := TimeSlotOf(o.StartTime)
+		return string(slot), string(slot), timeSlotOrder[slot]
+	}
+}
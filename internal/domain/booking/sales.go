@@ -0,0 +1,208 @@
+package booking
+
+import (
+	"mrs/internal/domain/shared/vo"
+	"sort"
+	"time"
+)
+
+// 销售统计的日期口径
+type SalesDateBasis string
+
+const (
+	SalesDateBasisBooking  SalesDateBasis = "booking"  // 按下单时间（bookings.created_at）
+	SalesDateBasisShowtime SalesDateBasis = "showtime" // 按场次开始时间（showtimes.start_time）
+)
+
+// 时间序列的粒度
+type SalesGranularity string
+
+const (
+	SalesGranularityHour  SalesGranularity = "hour"
+	SalesGranularityDay   SalesGranularity = "day"
+	SalesGranularityWeek  SalesGranularity = "week" // 周一为一周的开始
+	SalesGranularityMonth SalesGranularity = "month"
+)
+
+// 销售统计的拆分维度
+type SalesDimension string
+
+const (
+	SalesDimensionMovie SalesDimension = "movie"
+	SalesDimensionHall  SalesDimension = "hall"
+)
+
+// 单个时间序列的最大周期数
+const MaxSalesSeriesPeriods = 5000
+
+// 分组统计查询条件
+type SalesGroupQuery struct {
+	// 按时间桶分组的桶长度（分钟），0 表示不按时间分组
+	BucketMinutes int
+	// 按电影或影厅分组，为空表示不分组
+	Dimension SalesDimension
+}
+
+// 分组统计结果
+type SalesBucket struct {
+	Start         time.Time // 时间桶起始时间，未按时间分组时为零值
+	DimensionID   uint      // 电影或影厅ID，未按维度分组时为0
+	DimensionName string
+	Revenue       float64
+	Bookings      int
+	Tickets       int
+}
+
+func (b *SalesBucket) add(other *SalesBucket) {
+	b.Revenue += other.Revenue
+	b.Bookings += other.Bookings
+	b.Tickets += other.Tickets
+}
+
+// 订单明细（报表导出使用）
+type SalesRecord struct {
+	BookingID     vo.BookingID
+	UserID        vo.UserID
+	Status        BookingStatus
+	BookedAt      time.Time // 下单时间
+	MovieID       vo.MovieID
+	MovieTitle    string
+	CinemaName    string
+	HallName      string
+	ShowtimeStart time.Time
+	Tickets       int
+	TotalAmount   float64
+}
+
+// 时间序列中的一个周期
+type SalesSeriesPoint struct {
+	PeriodStart time.Time
+	Revenue     float64
+	Bookings    int
+	Tickets     int
+	Breakdown   []*SalesBucket // 按维度拆分，按收入降序
+}
+
+// SQL 聚合使用的时间桶长度：服务器时区与报表时区的偏移都是整小时时按小时聚合，
+// 否则（如 UTC+5:30）按15分钟聚合，保证时间桶能完整落入报表时区的周期内
+func BucketMinutesFor(loc *time.Location, start, end time.Time) int {
+	instants := []time.Time{time.Now()}
+	for _, t := range []time.Time{start, end} {
+		if !t.IsZero() {
+			instants = append(instants, t)
+		}
+	}
+	for _, t := range instants {
+		_, localOffset := t.In(time.Local).Zone()
+		_, reportOffset := t.In(loc).Zone()
+		if localOffset%3600 != 0 || reportOffset%3600 != 0 {
+			return 15
+		}
+	}
+	return 60
+}
+
+// 返回 t 在 loc 时区所属周期的起始时间
+func (g SalesGranularity) Truncate(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	switch g {
+	case SalesGranularityHour:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, loc)
+	case SalesGranularityWeek:
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+		return day.AddDate(0, 0, -((int(t.Weekday()) + 6) % 7))
+	case SalesGranularityMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc)
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	}
+}
+
+// 返回下一个周期的起始时间（periodStart 须为 Truncate 的结果）
+func (g SalesGranularity) Next(periodStart time.Time) time.Time {
+	switch g {
+	case SalesGranularityHour:
+		return periodStart.Add(time.Hour)
+	case SalesGranularityWeek:
+		return periodStart.AddDate(0, 0, 7)
+	case SalesGranularityMonth:
+		return periodStart.AddDate(0, 1, 0)
+	default:
+		return periodStart.AddDate(0, 0, 1)
+	}
+}
+
+// 将时间桶按报表时区与粒度合并为连续的时间序列，没有销售的周期补零
+// start 或 end 为零值时以时间桶的最早或最晚时间代替
+func BuildSalesSeries(buckets []*SalesBucket, g SalesGranularity, loc *time.Location, start, end time.Time) ([]*SalesSeriesPoint, error) {
+	for _, bucket := range buckets {
+		if start.IsZero() || bucket.Start.Before(start) {
+			start = bucket.Start
+		}
+		if end.IsZero() || bucket.Start.After(end) {
+			end = bucket.Start
+		}
+	}
+	if start.IsZero() || end.IsZero() {
+		return []*SalesSeriesPoint{}, nil
+	}
+
+	points := make([]*SalesSeriesPoint, 0)
+	index := make(map[int64]*SalesSeriesPoint)
+	for period := g.Truncate(start, loc); !period.After(end); period = g.Next(period) {
+		if len(points) >= MaxSalesSeriesPeriods {
+			return nil, ErrTooManyReportPeriods
+		}
+		point := &SalesSeriesPoint{PeriodStart: period, Breakdown: []*SalesBucket{}}
+		points = append(points, point)
+		index[period.Unix()] = point
+	}
+
+	breakdowns := make(map[*SalesSeriesPoint]map[uint]*SalesBucket)
+	for _, bucket := range buckets {
+		point, ok := index[g.Truncate(bucket.Start, loc).Unix()]
+		if !ok {
+			continue
+		}
+		point.Revenue += bucket.Revenue
+		point.Bookings += bucket.Bookings
+		point.Tickets += bucket.Tickets
+		if bucket.DimensionID == 0 {
+			continue
+		}
+
+		items, ok := breakdowns[point]
+		if !ok {
+			items = make(map[uint]*SalesBucket)
+			breakdowns[point] = items
+		}
+		item, ok := items[bucket.DimensionID]
+		if !ok {
+			item = &SalesBucket{DimensionID: bucket.DimensionID, DimensionName: bucket.DimensionName}
+			items[bucket.DimensionID] = item
+			point.Breakdown = append(point.Breakdown, item)
+		}
+		item.add(bucket)
+	}
+	for _, point := range points {
+		sort.SliceStable(point.Breakdown, func(i, j int) bool {
+			return point.Breakdown[i].Revenue > point.Breakdown[j].Revenue
+		})
+	}
+	return points, nil
+}
+
+// 紧邻当前周期之前、长度相同的上一周期（两端均包含）
+func PreviousPeriod(start, end time.Time) (time.Time, time.Time) {
+	prevEnd := start.Add(-time.Microsecond)
+	return prevEnd.Add(-end.Sub(start)), prevEnd
+}
+
+// 相对上一周期的变化比例，上一周期为0时无法计算，返回nil
+func SalesChange(current, previous float64) *float64 {
+	if previous == 0 {
+		return nil
+	}
+	change := (current - previous) / previous
+	return &change
+}
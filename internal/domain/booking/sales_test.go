@@ -0,0 +1,252 @@
+package booking
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func loadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("LoadLocation(%q) failed: %v", name, err)
+	}
+	return loc
+}
+
+// 固定服务器时区，测试结束后恢复
+func setLocal(t *testing.T, loc *time.Location) {
+	t.Helper()
+	original := time.Local
+	time.Local = loc
+	t.Cleanup(func() { time.Local = original })
+}
+
+func utc(month time.Month, day, hour, minute int) time.Time {
+	return time.Date(2026, month, day, hour, minute, 0, 0, time.UTC)
+}
+
+// 序列点的可比较形式
+type seriesPoint struct {
+	PeriodStart string
+	Revenue     float64
+	Tickets     int
+}
+
+func seriesPoints(points []*SalesSeriesPoint) []seriesPoint {
+	values := make([]seriesPoint, len(points))
+	for i, point := range points {
+		values[i] = seriesPoint{point.PeriodStart.Format(time.RFC3339), point.Revenue, point.Tickets}
+	}
+	return values
+}
+
+func TestBucketMinutesFor(t *testing.T) {
+	kolkata := loadLocation(t, "Asia/Kolkata")
+	tests := []struct {
+		name  string
+		local *time.Location
+		loc   *time.Location
+		want  int
+	}{
+		{"same zone", time.UTC, time.UTC, 60},
+		{"whole hour offset", time.UTC, loadLocation(t, "Asia/Shanghai"), 60},
+		{"daylight saving zone", time.UTC, loadLocation(t, "America/New_York"), 60},
+		{"half hour report zone", time.UTC, kolkata, 15},
+		{"half hour with daylight saving", time.UTC, loadLocation(t, "Australia/Adelaide"), 15},
+		{"quarter hour report zone", time.UTC, loadLocation(t, "Asia/Kathmandu"), 15},
+		{"half hour server zone", kolkata, time.UTC, 15},
+	}
+	for _, tt := range tests {
+		setLocal(t, tt.local)
+		if got := BucketMinutesFor(tt.loc, utc(time.June, 1, 0, 0), utc(time.June, 30, 0, 0)); got != tt.want {
+			t.Errorf("%s: BucketMinutesFor(%s) = %d, want %d", tt.name, tt.loc, got, tt.want)
+		}
+	}
+}
+
+func TestSalesGranularity_Truncate(t *testing.T) {
+	shanghai := loadLocation(t, "Asia/Shanghai")
+	in := utc(time.June, 17, 17, 20) // 上海时间 6月18日（周四）01:20
+
+	tests := []struct {
+		granularity SalesGranularity
+		want        time.Time
+	}{
+		{SalesGranularityHour, time.Date(2026, 6, 18, 1, 0, 0, 0, shanghai)},
+		{SalesGranularityDay, time.Date(2026, 6, 18, 0, 0, 0, 0, shanghai)},
+		{SalesGranularityWeek, time.Date(2026, 6, 15, 0, 0, 0, 0, shanghai)},
+		{SalesGranularityMonth, time.Date(2026, 6, 1, 0, 0, 0, 0, shanghai)},
+	}
+	for _, tt := range tests {
+		if got := tt.granularity.Truncate(in, shanghai); !got.Equal(tt.want) {
+			t.Errorf("%s.Truncate(%v) = %v, want %v", tt.granularity, in, got, tt.want)
+		}
+	}
+
+	// 周日属于以周一开始的当周
+	sunday := time.Date(2026, 6, 21, 23, 0, 0, 0, shanghai)
+	if got, want := SalesGranularityWeek.Truncate(sunday, shanghai), time.Date(2026, 6, 15, 0, 0, 0, 0, shanghai); !got.Equal(want) {
+		t.Errorf("week.Truncate(%v) = %v, want %v", sunday, got, want)
+	}
+}
+
+func TestBuildSalesSeries(t *testing.T) {
+	kolkata := loadLocation(t, "Asia/Kolkata")
+	newYork := loadLocation(t, "America/New_York")
+	shanghai := loadLocation(t, "Asia/Shanghai")
+
+	// 15分钟时间桶（UTC），印度时间分别为 6月14日23:45、6月15日00:00、00:45、01:00
+	quarterHours := []*SalesBucket{
+		{Start: utc(time.June, 14, 18, 15), Revenue: 10, Tickets: 1},
+		{Start: utc(time.June, 14, 18, 30), Revenue: 20, Tickets: 2},
+		{Start: utc(time.June, 14, 19, 15), Revenue: 30, Tickets: 3},
+		{Start: utc(time.June, 14, 19, 30), Revenue: 40, Tickets: 4},
+	}
+	// 小时时间桶跨越纽约夏令时开始（2026年3月8日02:00跳到03:00）
+	dstHours := []*SalesBucket{
+		{Start: utc(time.March, 8, 4, 0), Revenue: 1, Tickets: 1},  // 3月7日23:00 EST
+		{Start: utc(time.March, 8, 5, 0), Revenue: 2, Tickets: 1},  // 3月8日00:00 EST
+		{Start: utc(time.March, 8, 7, 0), Revenue: 4, Tickets: 1},  // 3月8日03:00 EDT
+		{Start: utc(time.March, 9, 3, 0), Revenue: 8, Tickets: 1},  // 3月8日23:00 EDT
+		{Start: utc(time.March, 9, 4, 0), Revenue: 16, Tickets: 1}, // 3月9日00:00 EDT
+	}
+
+	tests := []struct {
+		name        string
+		buckets     []*SalesBucket
+		granularity SalesGranularity
+		loc         *time.Location
+		start, end  time.Time
+		want        []seriesPoint
+	}{
+		{"empty", nil, SalesGranularityDay, kolkata, time.Time{}, time.Time{}, []seriesPoint{}},
+		{"quarter hours merged into half hour zone hours", quarterHours, SalesGranularityHour, kolkata, time.Time{}, time.Time{}, []seriesPoint{
+			{"2026-06-14T23:00:00+05:30", 10, 1},
+			{"2026-06-15T00:00:00+05:30", 50, 5},
+			{"2026-06-15T01:00:00+05:30", 40, 4},
+		}},
+		{"quarter hours merged into half hour zone days", quarterHours, SalesGranularityDay, kolkata, time.Time{}, time.Time{}, []seriesPoint{
+			{"2026-06-14T00:00:00+05:30", 10, 1},
+			{"2026-06-15T00:00:00+05:30", 90, 9},
+		}},
+		{"same buckets in utc", quarterHours, SalesGranularityDay, time.UTC, time.Time{}, time.Time{}, []seriesPoint{
+			{"2026-06-14T00:00:00Z", 100, 10},
+		}},
+		{"daylight saving day has 23 hours", dstHours, SalesGranularityDay, newYork, time.Time{}, time.Time{}, []seriesPoint{
+			{"2026-03-07T00:00:00-05:00", 1, 1},
+			{"2026-03-08T00:00:00-05:00", 14, 3},
+			{"2026-03-09T00:00:00-04:00", 16, 1},
+		}},
+		{"empty periods filled with zero", []*SalesBucket{{Start: time.Date(2026, 6, 2, 10, 0, 0, 0, shanghai), Revenue: 5, Tickets: 1}},
+			SalesGranularityDay, shanghai, time.Date(2026, 6, 1, 0, 0, 0, 0, shanghai), time.Date(2026, 6, 3, 23, 59, 59, 0, shanghai), []seriesPoint{
+				{"2026-06-01T00:00:00+08:00", 0, 0},
+				{"2026-06-02T00:00:00+08:00", 5, 1},
+				{"2026-06-03T00:00:00+08:00", 0, 0},
+			}},
+		{"weeks start on monday", []*SalesBucket{
+			{Start: time.Date(2026, 6, 21, 23, 0, 0, 0, shanghai), Revenue: 1, Tickets: 1},
+			{Start: time.Date(2026, 6, 22, 0, 0, 0, 0, shanghai), Revenue: 2, Tickets: 1},
+		}, SalesGranularityWeek, shanghai, time.Time{}, time.Time{}, []seriesPoint{
+			{"2026-06-15T00:00:00+08:00", 1, 1},
+			{"2026-06-22T00:00:00+08:00", 2, 1},
+		}},
+		{"months", []*SalesBucket{
+			{Start: time.Date(2026, 1, 31, 23, 0, 0, 0, shanghai), Revenue: 1, Tickets: 1},
+			{Start: time.Date(2026, 3, 1, 0, 0, 0, 0, shanghai), Revenue: 2, Tickets: 1},
+		}, SalesGranularityMonth, shanghai, time.Time{}, time.Time{}, []seriesPoint{
+			{"2026-01-01T00:00:00+08:00", 1, 1},
+			{"2026-02-01T00:00:00+08:00", 0, 0},
+			{"2026-03-01T00:00:00+08:00", 2, 1},
+		}},
+		{"range extended to earliest bucket", quarterHours, SalesGranularityDay, kolkata,
+			time.Date(2026, 6, 15, 0, 0, 0, 0, kolkata), time.Date(2026, 6, 15, 23, 59, 59, 0, kolkata), []seriesPoint{
+				{"2026-06-14T00:00:00+05:30", 10, 1},
+				{"2026-06-15T00:00:00+05:30", 90, 9},
+			}},
+	}
+	for _, tt := range tests {
+		points, err := BuildSalesSeries(tt.buckets, tt.granularity, tt.loc, tt.start, tt.end)
+		if err != nil {
+			t.Errorf("%s: BuildSalesSeries() error = %v", tt.name, err)
+			continue
+		}
+		if got := seriesPoints(points); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: BuildSalesSeries() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBuildSalesSeries_Breakdown(t *testing.T) {
+	buckets := []*SalesBucket{
+		{Start: utc(time.June, 1, 10, 0), DimensionID: 1, DimensionName: "A", Revenue: 10, Bookings: 1, Tickets: 1},
+		{Start: utc(time.June, 1, 11, 0), DimensionID: 2, DimensionName: "B", Revenue: 30, Bookings: 1, Tickets: 3},
+		{Start: utc(time.June, 1, 12, 0), DimensionID: 1, DimensionName: "A", Revenue: 25, Bookings: 2, Tickets: 2},
+	}
+	points, err := BuildSalesSeries(buckets, SalesGranularityDay, time.UTC, time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("BuildSalesSeries() error = %v", err)
+	}
+	if len(points) != 1 {
+		t.Fatalf("len(points) = %d, want 1", len(points))
+	}
+	point := points[0]
+	if point.Revenue != 65 || point.Bookings != 4 || point.Tickets != 6 {
+		t.Errorf("point = {Revenue: %v, Bookings: %d, Tickets: %d}, want {65, 4, 6}", point.Revenue, point.Bookings, point.Tickets)
+	}
+	want := []SalesBucket{
+		{DimensionID: 1, DimensionName: "A", Revenue: 35, Bookings: 3, Tickets: 3},
+		{DimensionID: 2, DimensionName: "B", Revenue: 30, Bookings: 1, Tickets: 3},
+	}
+	got := make([]SalesBucket, len(point.Breakdown))
+	for i, item := range point.Breakdown {
+		got[i] = *item
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Breakdown = %+v, want %+v", got, want)
+	}
+}
+
+func TestBuildSalesSeries_TooManyPeriods(t *testing.T) {
+	start := utc(time.January, 1, 0, 0)
+	_, err := BuildSalesSeries(nil, SalesGranularityHour, time.UTC, start, start.AddDate(1, 0, 0))
+	if !errors.Is(err, ErrTooManyReportPeriods) {
+		t.Errorf("BuildSalesSeries(1 year of hours) error = %v, want %v", err, ErrTooManyReportPeriods)
+	}
+}
+
+func TestPreviousPeriod(t *testing.T) {
+	start := utc(time.June, 8, 0, 0)
+	end := utc(time.June, 14, 23, 59).Add(59*time.Second + 999999*time.Microsecond)
+	prevStart, prevEnd := PreviousPeriod(start, end)
+	if want := utc(time.June, 1, 0, 0); !prevStart.Equal(want) {
+		t.Errorf("PreviousPeriod() start = %v, want %v", prevStart, want)
+	}
+	if want := start.Add(-time.Microsecond); !prevEnd.Equal(want) {
+		t.Errorf("PreviousPeriod() end = %v, want %v", prevEnd, want)
+	}
+}
+
+func TestSalesChange(t *testing.T) {
+	tests := []struct {
+		current, previous float64
+		want              *float64
+	}{
+		{150, 100, ptr(0.5)},
+		{50, 100, ptr(-0.5)},
+		{0, 100, ptr(-1)},
+		{100, 0, nil},
+	}
+	for _, tt := range tests {
+		got := SalesChange(tt.current, tt.previous)
+		if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+			t.Errorf("SalesChange(%v, %v) = %v, want %v", tt.current, tt.previous, got, tt.want)
+		}
+	}
+}
+
+func ptr(f float64) *float64 {
+	return &f
+}
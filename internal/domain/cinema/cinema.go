@@ -0,0 +1,108 @@
+package cinema
+
+import (
+	"fmt"
+	"mrs/internal/domain/shared/vo"
+	"time"
+)
+
+// 未指定时区时的默认值
+const DefaultCinemaTimezone = "Asia/Shanghai"
+
+// 影院（场馆），一个影院下包含多个影厅
+type Cinema struct {
+	ID           vo.CinemaID     // 影院ID
+	Name         string          // 影院名称
+	Address      string          // 详细地址
+	City         string          // 所在城市
+	Phone        string          // 联系电话
+	Timezone     string          // IANA 时区，如 Asia/Shanghai
+	OpeningHours []*OpeningHours // 营业时间（按星期配置，为空表示不限制）
+}
+
+// 营业时间，OpenTime/CloseTime 格式为 HH:MM
+// CloseTime 小于等于 OpenTime 表示营业至次日（跨午夜）
+type OpeningHours struct {
+	Weekday   time.Weekday `json:"weekday"`
+	OpenTime  string       `json:"open_time"`
+	CloseTime string       `json:"close_time"`
+}
+
+// 解析HH:MM为当天的分钟数
+func parseClock(clock string) (int, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// 获取影院所在时区
+func (c *Cinema) Location() (*time.Location, error) {
+	tz := c.Timezone
+	if tz == "" {
+		tz = DefaultCinemaTimezone
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTimezone, tz)
+	}
+	return loc, nil
+}
+
+// 校验时区与营业时间配置
+func (c *Cinema) Validate() error {
+	if _, err := c.Location(); err != nil {
+		return err
+	}
+	for _, oh := range c.OpeningHours {
+		if oh.Weekday < time.Sunday || oh.Weekday > time.Saturday {
+			return fmt.Errorf("%w: invalid weekday %d", ErrInvalidOpeningHours, oh.Weekday)
+		}
+		if _, err := parseClock(oh.OpenTime); err != nil {
+			return fmt.Errorf("%w: invalid open time %q", ErrInvalidOpeningHours, oh.OpenTime)
+		}
+		if _, err := parseClock(oh.CloseTime); err != nil {
+			return fmt.Errorf("%w: invalid close time %q", ErrInvalidOpeningHours, oh.CloseTime)
+		}
+	}
+	return nil
+}
+
+// 判断给定时刻影院是否处于营业时间内（按影院所在时区计算）
+// 未配置营业时间时视为全天营业
+func (c *Cinema) IsOpenAt(t time.Time) bool {
+	if len(c.OpeningHours) == 0 {
+		return true
+	}
+	loc, err := c.Location()
+	if err != nil {
+		return false
+	}
+	local := t.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	prevWeekday := (local.Weekday() + 6) % 7
+
+	for _, oh := range c.OpeningHours {
+		open, err1 := parseClock(oh.OpenTime)
+		closing, err2 := parseClock(oh.CloseTime)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		if closing > open {
+			// 当天营业
+			if oh.Weekday == local.Weekday() && minute >= open && minute < closing {
+				return true
+			}
+			continue
+		}
+		// 跨午夜营业：当天开门之后，或前一天营业延续到今天凌晨
+		if oh.Weekday == local.Weekday() && minute >= open {
+			return true
+		}
+		if oh.Weekday == prevWeekday && minute < closing {
+			return true
+		}
+	}
+	return false
+}
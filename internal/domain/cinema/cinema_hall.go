@@ -7,12 +7,92 @@ import (
 // 影厅
 type CinemaHall struct {
 	ID          vo.CinemaHallID // 影厅ID
+	CinemaID    vo.CinemaID     // 所属影院ID（为0表示未归属任何影院）
 	Name        string          // 影厅名称
 	ScreenType  string          // 屏幕类型
 	SoundSystem string          // 音响系统
 	RowCount    int             // 行数
 	ColCount    int             // 列数
 
+	// 几何布局（过道、台阶、分区等），RowCount/ColCount 与布局尺寸保持一致
+	Layout *HallLayout
+	// 社交距离策略，为空表示不启用
+	DistancingPolicy *DistancingPolicy
+	// 当前布局版本，新建场次固定引用该版本
+	LayoutVersion int
+
 	// 多对多关系
 	Seats []*Seat // 聚合内部可以直接持有同一聚合内其他实体的引用
 }
+
+// 设置布局并同步行列数
+func (h *CinemaHall) ApplyLayout(layout *HallLayout) {
+	h.Layout = layout
+	h.RowCount = layout.Rows
+	h.ColCount = layout.Cols
+}
+
+// 补全并校验影厅布局与座位：
+//   - 布局和座位都为空时，使用默认布局生成座位
+//   - 只有布局时，按布局生成座位
+//   - 只有座位时，按排号/座位号推断网格坐标和布局
+//
+// 座位未指定分区时，使用布局中对应单元格的分区
+func (h *CinemaHall) PrepareLayout() error {
+	layout := h.Layout
+	switch {
+	case layout == nil && len(h.Seats) == 0:
+		layout = NewGridLayout(DefaultLayoutRows, DefaultLayoutCols)
+		h.Seats = layout.GenerateSeats(h.ID)
+	case layout == nil:
+		layout = InferLayout(h.Seats)
+	case len(h.Seats) == 0:
+		h.Seats = layout.GenerateSeats(h.ID)
+	}
+	for _, seat := range h.Seats {
+		if seat.Block == "" {
+			seat.Block = layout.BlockAt(seat.GridRow, seat.GridCol)
+		}
+	}
+	if err := layout.Validate(h.Seats); err != nil {
+		return err
+	}
+	h.ApplyLayout(layout)
+	if h.LayoutVersion < InitialLayoutVersion {
+		h.LayoutVersion = InitialLayoutVersion
+	}
+	for _, seat := range h.Seats {
+		seat.LayoutVersion = h.LayoutVersion
+	}
+	return nil
+}
+
+// 当前布局的版本快照
+func (h *CinemaHall) LayoutSnapshot() *HallLayoutVersion {
+	layout := h.Layout
+	if layout == nil {
+		layout = InferLayout(h.Seats)
+	}
+	return &HallLayoutVersion{
+		CinemaHallID: h.ID,
+		Version:      h.LayoutVersion,
+		Layout:       layout,
+		SeatCount:    len(h.Seats),
+	}
+}
+
+// 以新的布局与座位替换当前布局，版本号递增，返回新版本快照
+// 旧版本的座位由调用方保留，供已固定旧版本的场次继续使用
+func (h *CinemaHall) ReplaceLayout(layout *HallLayout, seats []*Seat) *HallLayoutVersion {
+	if h.LayoutVersion < InitialLayoutVersion {
+		h.LayoutVersion = InitialLayoutVersion
+	}
+	h.LayoutVersion++
+	for _, seat := range seats {
+		seat.CinemaHallID = h.ID
+		seat.LayoutVersion = h.LayoutVersion
+	}
+	h.Seats = seats
+	h.ApplyLayout(layout)
+	return h.LayoutSnapshot()
+}
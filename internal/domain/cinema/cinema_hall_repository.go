@@ -9,7 +9,11 @@ type CinemaHallRepository interface {
 	Create(ctx context.Context, hall *CinemaHall) (*CinemaHall, error)
 	FindByID(ctx context.Context, id vo.CinemaHallID) (*CinemaHall, error)
 	FindByName(ctx context.Context, name string) (*CinemaHall, error)
+	// 同一影院内（cinemaID 为 0 表示未归属影院的影厅）是否已有同名影厅，excludeID 为需要排除的影厅
+	ExistsByName(ctx context.Context, cinemaID vo.CinemaID, name string, excludeID vo.CinemaHallID) (bool, error)
 	ListAll(ctx context.Context) ([]*CinemaHall, error)
 	Update(ctx context.Context, hall *CinemaHall) error
+	// 设置社交距离策略，policy 为 nil 时清除
+	UpdateDistancingPolicy(ctx context.Context, id vo.CinemaHallID, policy *DistancingPolicy) error
 	Delete(ctx context.Context, id vo.CinemaHallID) error
 }
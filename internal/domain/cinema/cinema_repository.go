@@ -0,0 +1,18 @@
+package cinema
+
+import (
+	"context"
+	"mrs/internal/domain/shared/vo"
+)
+
+type CinemaRepository interface {
+	Create(ctx context.Context, cinema *Cinema) (*Cinema, error)
+	FindByID(ctx context.Context, id vo.CinemaID) (*Cinema, error)
+	// 查询影厅所属的影院，影厅未归属任何影院时返回 ErrCinemaNotFound
+	FindByHallID(ctx context.Context, hallID vo.CinemaHallID) (*Cinema, error)
+	ListAll(ctx context.Context) ([]*Cinema, error)
+	Update(ctx context.Context, cinema *Cinema) error
+	Delete(ctx context.Context, id vo.CinemaID) error
+	// 统计影院下的影厅数量
+	CountHalls(ctx context.Context, id vo.CinemaID) (int64, error)
+}
@@ -0,0 +1,123 @@
+package cinema
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCinema_IsOpenAt(t *testing.T) {
+	// 2026-10-16 为周五，2026-10-17 为周六，2026-10-18 为周日
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		t.Fatalf("LoadLocation failed: %v", err)
+	}
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2026, 10, day, hour, min, 0, 0, shanghai)
+	}
+
+	cinema := &Cinema{
+		OpeningHours: []*OpeningHours{
+			{Weekday: time.Friday, OpenTime: "10:00", CloseTime: "02:00"}, // 营业至周六凌晨
+			{Weekday: time.Saturday, OpenTime: "09:00", CloseTime: "23:30"},
+		},
+	}
+	tests := []struct {
+		name string
+		t    time.Time
+		want bool
+	}{
+		{"before open", at(16, 9, 59), false},
+		{"at open", at(16, 10, 0), true},
+		{"friday night", at(16, 23, 59), true},
+		{"overnight after midnight", at(17, 1, 59), true},
+		{"overnight at close", at(17, 2, 0), false},
+		{"saturday gap", at(17, 8, 59), false},
+		{"saturday open", at(17, 9, 0), true},
+		{"saturday before close", at(17, 23, 29), true},
+		{"saturday at close", at(17, 23, 30), false},
+		// 周六不跨午夜，周日凌晨不营业
+		{"sunday early", at(18, 0, 30), false},
+		{"sunday not configured", at(18, 12, 0), false},
+		// 按影院时区判断：UTC 周五 17:30 即上海时间周六 01:30
+		{"utc time converted", time.Date(2026, 10, 16, 17, 30, 0, 0, time.UTC), true},
+		{"utc time converted after close", time.Date(2026, 10, 16, 18, 30, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cinema.IsOpenAt(tt.t); got != tt.want {
+				t.Errorf("IsOpenAt(%v) = %v, want %v", tt.t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCinema_IsOpenAt_Timezone(t *testing.T) {
+	hours := []*OpeningHours{{Weekday: time.Saturday, OpenTime: "10:00", CloseTime: "22:00"}}
+	// 同一时刻：UTC 周六 15:00
+	instant := time.Date(2026, 10, 17, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		timezone string
+		want     bool
+	}{
+		{"", false},                    // 默认 Asia/Shanghai：周六 23:00
+		{"Asia/Shanghai", false},       // 周六 23:00
+		{"Europe/London", true},        // 周六 16:00（夏令时）
+		{"America/Los_Angeles", false}, // 周六 08:00
+		{"Pacific/Kiritimati", false},  // 周日 05:00
+		{"Invalid/Zone", false},        // 时区无效时视为不营业
+	}
+	for _, tt := range tests {
+		c := &Cinema{Timezone: tt.timezone, OpeningHours: hours}
+		if got := c.IsOpenAt(instant); got != tt.want {
+			t.Errorf("IsOpenAt(%v) in %q = %v, want %v", instant, tt.timezone, got, tt.want)
+		}
+	}
+}
+
+func TestCinema_IsOpenAt_AllDay(t *testing.T) {
+	// 未配置营业时间视为全天营业
+	if c := (&Cinema{}); !c.IsOpenAt(time.Date(2026, 10, 18, 3, 0, 0, 0, time.UTC)) {
+		t.Errorf("IsOpenAt without opening hours = false, want true")
+	}
+	// 关门时间等于开门时间表示营业 24 小时
+	c := &Cinema{OpeningHours: []*OpeningHours{{Weekday: time.Sunday, OpenTime: "06:00", CloseTime: "06:00"}}}
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	tests := []struct {
+		t    time.Time
+		want bool
+	}{
+		{time.Date(2026, 10, 18, 5, 59, 0, 0, shanghai), false},
+		{time.Date(2026, 10, 18, 6, 0, 0, 0, shanghai), true},
+		{time.Date(2026, 10, 19, 5, 59, 0, 0, shanghai), true},
+		{time.Date(2026, 10, 19, 6, 0, 0, 0, shanghai), false},
+	}
+	for _, tt := range tests {
+		if got := c.IsOpenAt(tt.t); got != tt.want {
+			t.Errorf("IsOpenAt(%v) = %v, want %v", tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestCinema_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cinema  Cinema
+		wantErr error
+	}{
+		{"default timezone", Cinema{}, nil},
+		{"valid", Cinema{Timezone: "Europe/Berlin", OpeningHours: []*OpeningHours{{Weekday: time.Monday, OpenTime: "09:00", CloseTime: "01:00"}}}, nil},
+		{"invalid timezone", Cinema{Timezone: "Mars/Olympus"}, ErrInvalidTimezone},
+		{"invalid weekday", Cinema{OpeningHours: []*OpeningHours{{Weekday: 7, OpenTime: "09:00", CloseTime: "18:00"}}}, ErrInvalidOpeningHours},
+		{"invalid open time", Cinema{OpeningHours: []*OpeningHours{{Weekday: time.Monday, OpenTime: "9am", CloseTime: "18:00"}}}, ErrInvalidOpeningHours},
+		{"invalid close time", Cinema{OpeningHours: []*OpeningHours{{Weekday: time.Monday, OpenTime: "09:00", CloseTime: "24:00"}}}, ErrInvalidOpeningHours},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cinema.Validate()
+			if tt.wantErr == nil && err != nil || tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
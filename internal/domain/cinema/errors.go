@@ -2,12 +2,24 @@ package cinema
 
 import "errors"
 
+// Cinema 相关错误
+var (
+	ErrCinemaNotFound      = errors.New("cinema not found")
+	ErrCinemaAlreadyExists = errors.New("cinema already exists")
+	ErrCinemaReferenced    = errors.New("cinema still has cinema halls, cannot delete")
+	ErrInvalidTimezone     = errors.New("invalid cinema timezone")
+	ErrInvalidOpeningHours = errors.New("invalid cinema opening hours")
+)
+
 // CinemaHall 相关错误
 var (
 	ErrCinemaHallNotFound         = errors.New("cinema hall not found")
 	ErrCinemaHallAlreadyExists    = errors.New("cinema hall already exists")
 	ErrCinemaHallCapacityExceeded = errors.New("cinema hall capacity exceeded")
 	ErrCinemaHallReferenced       = errors.New("cinema hall is referenced by other records, cannot delete")
+	ErrInvalidHallLayout          = errors.New("invalid cinema hall layout")
+	ErrInvalidLayoutFormat        = errors.New("invalid cinema hall layout format")
+	ErrLayoutVersionNotFound      = errors.New("cinema hall layout version not found")
 )
 
 // Seat 相关错误
@@ -17,3 +29,10 @@ var (
 	ErrInvalidSeatType       = errors.New("invalid seat type")
 	ErrSeatNotAvailable      = errors.New("seat not available for showtime")
 )
+
+// SeatRestriction 相关错误
+var (
+	ErrSeatRestrictionNotFound = errors.New("seat restriction not found")
+	ErrInvalidSeatRestriction  = errors.New("invalid seat restriction")
+	ErrInvalidDistancingPolicy = errors.New("invalid distancing policy")
+)
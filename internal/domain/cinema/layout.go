@@ -0,0 +1,330 @@
+package cinema
+
+import (
+	"fmt"
+	"mrs/internal/domain/shared/vo"
+	"sort"
+)
+
+// 关于座位布局：影厅被划分为 Rows x Cols 的网格，座位占据网格中的一个单元格，
+// 过道、空位、台阶等非座位元素以矩形区域的形式描述，座位分区（如左区/中区/右区）同样以矩形区域描述。
+// 网格坐标从0开始，行号越小越靠近屏幕（屏幕在顶部时）。
+
+// 屏幕位置
+type ScreenPosition string
+
+const (
+	ScreenPositionTop    ScreenPosition = "top"    // 屏幕位于第0行一侧
+	ScreenPositionBottom ScreenPosition = "bottom" // 屏幕位于最后一行一侧
+)
+
+// 布局元素类型
+type LayoutElementType string
+
+const (
+	LayoutElementAisle LayoutElementType = "aisle" // 过道
+	LayoutElementGap   LayoutElementType = "gap"   // 空位（无座位也不可通行）
+	LayoutElementStair LayoutElementType = "stair" // 台阶
+)
+
+// 默认布局尺寸
+const (
+	DefaultLayoutRows = 10
+	DefaultLayoutCols = 10
+)
+
+// 布局元素，占据从 (Row, Col) 开始的 RowSpan x ColSpan 矩形区域
+type LayoutElement struct {
+	Type    LayoutElementType `json:"type" yaml:"type"`
+	Row     int               `json:"row" yaml:"row"`
+	Col     int               `json:"col" yaml:"col"`
+	RowSpan int               `json:"row_span" yaml:"row_span"`
+	ColSpan int               `json:"col_span" yaml:"col_span"`
+}
+
+// 是否覆盖指定单元格
+func (e *LayoutElement) Covers(row, col int) bool {
+	return row >= e.Row && row < e.Row+e.spanRows() && col >= e.Col && col < e.Col+e.spanCols()
+}
+
+func (e *LayoutElement) spanRows() int {
+	if e.RowSpan <= 0 {
+		return 1
+	}
+	return e.RowSpan
+}
+
+func (e *LayoutElement) spanCols() int {
+	if e.ColSpan <= 0 {
+		return 1
+	}
+	return e.ColSpan
+}
+
+// 座位分区，包含 [StartRow, EndRow] x [StartCol, EndCol] 范围内的座位
+type SeatBlock struct {
+	Name     string `json:"name" yaml:"name"`
+	StartRow int    `json:"start_row" yaml:"start_row"`
+	EndRow   int    `json:"end_row" yaml:"end_row"`
+	StartCol int    `json:"start_col" yaml:"start_col"`
+	EndCol   int    `json:"end_col" yaml:"end_col"`
+}
+
+// 是否包含指定单元格
+func (b *SeatBlock) Contains(row, col int) bool {
+	return row >= b.StartRow && row <= b.EndRow && col >= b.StartCol && col <= b.EndCol
+}
+
+// 影厅布局
+type HallLayout struct {
+	Rows   int            `json:"rows" yaml:"rows"`
+	Cols   int            `json:"cols" yaml:"cols"`
+	Screen ScreenPosition `json:"screen" yaml:"screen"`
+	// 弧形排的弯曲程度：最外侧座位相对中间座位向屏幕方向偏移的行数，0 表示直排
+	Curvature float64          `json:"curvature" yaml:"curvature"`
+	Elements  []*LayoutElement `json:"elements" yaml:"elements"`
+	Blocks    []*SeatBlock     `json:"blocks" yaml:"blocks"`
+}
+
+// 生成不含过道的矩形网格布局
+func NewGridLayout(rows, cols int) *HallLayout {
+	return &HallLayout{
+		Rows:     rows,
+		Cols:     cols,
+		Screen:   ScreenPositionTop,
+		Elements: []*LayoutElement{},
+		Blocks:   []*SeatBlock{},
+	}
+}
+
+// 获取覆盖指定单元格的布局元素，没有则返回nil
+func (l *HallLayout) ElementAt(row, col int) *LayoutElement {
+	for _, e := range l.Elements {
+		if e.Covers(row, col) {
+			return e
+		}
+	}
+	return nil
+}
+
+// 获取指定单元格所在的分区名称，不属于任何分区时返回空字符串
+func (l *HallLayout) BlockAt(row, col int) string {
+	for _, b := range l.Blocks {
+		if b.Contains(row, col) {
+			return b.Name
+		}
+	}
+	return ""
+}
+
+// 计算单元格的渲染坐标（以单元格为单位，屏幕始终位于 y 较小的一侧）
+func (l *HallLayout) Position(row, col int) (x, y float64) {
+	x = float64(col)
+	y = float64(row)
+	if l.Screen == ScreenPositionBottom {
+		y = float64(l.Rows - 1 - row)
+	}
+	if l.Curvature != 0 && l.Cols > 1 {
+		// 弧形排：越靠两侧越向屏幕方向偏移，偏移量与到中轴距离的平方成正比
+		center := float64(l.Cols-1) / 2
+		d := (float64(col) - center) / center
+		y -= l.Curvature * d * d
+	}
+	return x, y
+}
+
+// 校验布局本身以及座位与布局的一致性
+func (l *HallLayout) Validate(seats []*Seat) error {
+	if l.Rows <= 0 || l.Cols <= 0 {
+		return fmt.Errorf("%w: rows and cols must be positive", ErrInvalidHallLayout)
+	}
+	if l.Screen != ScreenPositionTop && l.Screen != ScreenPositionBottom {
+		return fmt.Errorf("%w: invalid screen position %q", ErrInvalidHallLayout, l.Screen)
+	}
+	if l.Curvature < 0 {
+		return fmt.Errorf("%w: curvature must not be negative", ErrInvalidHallLayout)
+	}
+	for i, e := range l.Elements {
+		switch e.Type {
+		case LayoutElementAisle, LayoutElementGap, LayoutElementStair:
+		default:
+			return fmt.Errorf("%w: element %d has invalid type %q", ErrInvalidHallLayout, i, e.Type)
+		}
+		if e.Row < 0 || e.Col < 0 || e.Row+e.spanRows() > l.Rows || e.Col+e.spanCols() > l.Cols {
+			return fmt.Errorf("%w: element %d is out of bounds", ErrInvalidHallLayout, i)
+		}
+	}
+	for i, b := range l.Blocks {
+		if b.Name == "" {
+			return fmt.Errorf("%w: block %d has empty name", ErrInvalidHallLayout, i)
+		}
+		if b.StartRow < 0 || b.StartCol < 0 || b.StartRow > b.EndRow || b.StartCol > b.EndCol ||
+			b.EndRow >= l.Rows || b.EndCol >= l.Cols {
+			return fmt.Errorf("%w: block %q is out of bounds", ErrInvalidHallLayout, b.Name)
+		}
+	}
+
+	occupied := make(map[[2]int]*Seat, len(seats))
+	for _, s := range seats {
+		if s.GridRow < 0 || s.GridCol < 0 || s.GridRow >= l.Rows || s.GridCol >= l.Cols {
+			return fmt.Errorf("%w: seat %s%s at (%d,%d) is out of bounds",
+				ErrInvalidHallLayout, s.RowIdentifier, s.SeatNumber, s.GridRow, s.GridCol)
+		}
+		if e := l.ElementAt(s.GridRow, s.GridCol); e != nil {
+			return fmt.Errorf("%w: seat %s%s at (%d,%d) overlaps %s",
+				ErrInvalidHallLayout, s.RowIdentifier, s.SeatNumber, s.GridRow, s.GridCol, e.Type)
+		}
+		cell := [2]int{s.GridRow, s.GridCol}
+		if other, ok := occupied[cell]; ok {
+			return fmt.Errorf("%w: seats %s%s and %s%s share cell (%d,%d)", ErrInvalidHallLayout,
+				other.RowIdentifier, other.SeatNumber, s.RowIdentifier, s.SeatNumber, s.GridRow, s.GridCol)
+		}
+		occupied[cell] = s
+	}
+	return nil
+}
+
+// 为布局中每个非元素单元格生成座位
+// 整排都是过道/台阶的行不分配排号，排号从A开始依次分配；座位号在每排内从01开始依次分配
+func (l *HallLayout) GenerateSeats(cinemaHallID vo.CinemaHallID) []*Seat {
+	seats := make([]*Seat, 0, l.Rows*l.Cols)
+	rowIndex := 0
+	for r := 0; r < l.Rows; r++ {
+		number := 0
+		rowSeats := make([]*Seat, 0, l.Cols)
+		for c := 0; c < l.Cols; c++ {
+			if l.ElementAt(r, c) != nil {
+				continue
+			}
+			number++
+			rowSeats = append(rowSeats, &Seat{
+				CinemaHallID:  cinemaHallID,
+				RowIdentifier: rowLabel(rowIndex),
+				SeatNumber:    fmt.Sprintf("%02d", number),
+				Type:          SeatTypeStandard,
+				GridRow:       r,
+				GridCol:       c,
+				Block:         l.BlockAt(r, c),
+			})
+		}
+		if len(rowSeats) > 0 {
+			seats = append(seats, rowSeats...)
+			rowIndex++
+		}
+	}
+	return seats
+}
+
+// 排号：A..Z, AA..AZ, ...
+func rowLabel(index int) string {
+	label := ""
+	for index >= 0 {
+		label = string(rune('A'+index%26)) + label
+		index = index/26 - 1
+	}
+	return label
+}
+
+// 判断两个座位是否相邻：同一排、网格上紧挨着且属于同一分区（中间没有过道或空位）
+func (l *HallLayout) AreAdjacent(a, b *Seat) bool {
+	if a.GridRow != b.GridRow {
+		return false
+	}
+	diff := a.GridCol - b.GridCol
+	if diff != 1 && diff != -1 {
+		return false
+	}
+	return l.BlockAt(a.GridRow, a.GridCol) == l.BlockAt(b.GridRow, b.GridCol)
+}
+
+// 将一排中连续相邻的座位划分为若干段（过道、空位或分区边界会截断）
+func (l *HallLayout) RowSegments(seats []*Seat) [][]*Seat {
+	byRow := make(map[int][]*Seat)
+	rows := make([]int, 0)
+	for _, s := range seats {
+		if _, ok := byRow[s.GridRow]; !ok {
+			rows = append(rows, s.GridRow)
+		}
+		byRow[s.GridRow] = append(byRow[s.GridRow], s)
+	}
+	sort.Ints(rows)
+
+	segments := make([][]*Seat, 0)
+	for _, r := range rows {
+		rowSeats := byRow[r]
+		sort.Slice(rowSeats, func(i, j int) bool { return rowSeats[i].GridCol < rowSeats[j].GridCol })
+		current := []*Seat{rowSeats[0]}
+		for _, s := range rowSeats[1:] {
+			if l.AreAdjacent(current[len(current)-1], s) {
+				current = append(current, s)
+				continue
+			}
+			segments = append(segments, current)
+			current = []*Seat{s}
+		}
+		segments = append(segments, current)
+	}
+	return segments
+}
+
+// 查找孤立空座：两侧均为已占用座位（或一侧为段边界）的单个空座
+// 用于选座规则，避免选座后留下无法单独售出的空位
+func (l *HallLayout) FindOrphanSeats(seats []*Seat, occupied map[vo.SeatID]bool) []vo.SeatID {
+	orphans := make([]vo.SeatID, 0)
+	for _, segment := range l.RowSegments(seats) {
+		if len(segment) < 2 {
+			continue
+		}
+		for i, s := range segment {
+			if occupied[s.ID] {
+				continue
+			}
+			leftBlocked := i == 0 || occupied[segment[i-1].ID]
+			rightBlocked := i == len(segment)-1 || occupied[segment[i+1].ID]
+			leftOccupied := i > 0 && occupied[segment[i-1].ID]
+			rightOccupied := i < len(segment)-1 && occupied[segment[i+1].ID]
+			if leftBlocked && rightBlocked && (leftOccupied || rightOccupied) {
+				orphans = append(orphans, s.ID)
+			}
+		}
+	}
+	return orphans
+}
+
+// 为没有网格坐标的座位（历史数据）推断坐标并生成与之匹配的布局
+// 排按排号排序、座位按座位号排序依次排列
+func InferLayout(seats []*Seat) *HallLayout {
+	rowKeys := make([]string, 0)
+	byRow := make(map[string][]*Seat)
+	for _, s := range seats {
+		if _, ok := byRow[s.RowIdentifier]; !ok {
+			rowKeys = append(rowKeys, s.RowIdentifier)
+		}
+		byRow[s.RowIdentifier] = append(byRow[s.RowIdentifier], s)
+	}
+	sort.Slice(rowKeys, func(i, j int) bool {
+		if len(rowKeys[i]) != len(rowKeys[j]) {
+			return len(rowKeys[i]) < len(rowKeys[j])
+		}
+		return rowKeys[i] < rowKeys[j]
+	})
+
+	cols := 0
+	for r, key := range rowKeys {
+		rowSeats := byRow[key]
+		sort.Slice(rowSeats, func(i, j int) bool {
+			if len(rowSeats[i].SeatNumber) != len(rowSeats[j].SeatNumber) {
+				return len(rowSeats[i].SeatNumber) < len(rowSeats[j].SeatNumber)
+			}
+			return rowSeats[i].SeatNumber < rowSeats[j].SeatNumber
+		})
+		for c, s := range rowSeats {
+			s.GridRow = r
+			s.GridCol = c
+		}
+		if len(rowSeats) > cols {
+			cols = len(rowSeats)
+		}
+	}
+	return NewGridLayout(len(rowKeys), cols)
+}
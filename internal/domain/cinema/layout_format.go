@@ -0,0 +1,484 @@
+package cinema
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 关于布局导入导出：支持三种声明式格式
+//   - ascii: 每行一排，行首为排号（无座位的行用 - 表示），之后每个字符表示一个网格单元格，
+//     S/V/W 分别表示普通/VIP/轮椅座位，| 表示过道，. 表示空位，= 表示台阶；
+//     以 @ 开头的行为指令：@screen top|bottom、@curvature <float>、@block <名称> <起始行> <起始列> <结束行> <结束列>；
+//     以 # 开头的行为注释。座位号在每排内从左到右依次为 01、02...
+//   - json / yaml: LayoutDocument 结构，完整保留排号、座位号、坐标与分区
+
+// 布局文件格式
+type LayoutFormat string
+
+const (
+	LayoutFormatASCII LayoutFormat = "ascii"
+	LayoutFormatJSON  LayoutFormat = "json"
+	LayoutFormatYAML  LayoutFormat = "yaml"
+)
+
+// 解析布局文件格式
+func ParseLayoutFormat(s string) (LayoutFormat, error) {
+	switch f := LayoutFormat(strings.ToLower(s)); f {
+	case LayoutFormatASCII, LayoutFormatJSON, LayoutFormatYAML:
+		return f, nil
+	case "txt":
+		return LayoutFormatASCII, nil
+	case "yml":
+		return LayoutFormatYAML, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidLayoutFormat, s)
+	}
+}
+
+// 布局文件的MIME类型
+func (f LayoutFormat) ContentType() string {
+	switch f {
+	case LayoutFormatJSON:
+		return "application/json"
+	case LayoutFormatYAML:
+		return "application/yaml"
+	default:
+		return "text/plain; charset=utf-8"
+	}
+}
+
+// 布局文件扩展名
+func (f LayoutFormat) Extension() string {
+	if f == LayoutFormatASCII {
+		return "txt"
+	}
+	return string(f)
+}
+
+// json/yaml 格式的布局文件
+type LayoutDocument struct {
+	HallLayout `yaml:",inline"`
+	Seats      []*SeatDocument `json:"seats" yaml:"seats"`
+}
+
+// 布局文件中的座位
+type SeatDocument struct {
+	Row     string   `json:"row" yaml:"row"`
+	Number  string   `json:"number" yaml:"number"`
+	Type    SeatType `json:"type" yaml:"type"`
+	GridRow int      `json:"grid_row" yaml:"grid_row"`
+	GridCol int      `json:"grid_col" yaml:"grid_col"`
+	Block   string   `json:"block,omitempty" yaml:"block,omitempty"`
+}
+
+// 布局导入问题，ascii 格式定位到行列（从1开始），json/yaml 格式定位到字段路径
+type LayoutIssue struct {
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+func (i *LayoutIssue) String() string {
+	switch {
+	case i.Line > 0 && i.Column > 0:
+		return fmt.Sprintf("line %d, column %d: %s", i.Line, i.Column, i.Message)
+	case i.Line > 0:
+		return fmt.Sprintf("line %d: %s", i.Line, i.Message)
+	case i.Field != "":
+		return fmt.Sprintf("%s: %s", i.Field, i.Message)
+	default:
+		return i.Message
+	}
+}
+
+// 布局导入错误，汇总全部问题，便于一次性修正
+type LayoutImportError struct {
+	Issues []*LayoutIssue
+}
+
+func (e *LayoutImportError) Error() string {
+	msgs := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		msgs[i] = issue.String()
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalidHallLayout, strings.Join(msgs, "; "))
+}
+
+func (e *LayoutImportError) Unwrap() error {
+	return ErrInvalidHallLayout
+}
+
+// ascii 格式的单元格字符
+const (
+	asciiStandard   = 'S'
+	asciiVIP        = 'V'
+	asciiWheelchair = 'W'
+	asciiAisle      = '|'
+	asciiGap        = '.'
+	asciiStair      = '='
+	asciiNoRowLabel = "-"
+)
+
+var asciiSeatTypes = map[rune]SeatType{
+	asciiStandard:   SeatTypeStandard,
+	asciiVIP:        SeatTypeVIP,
+	asciiWheelchair: SeatTypeWheelchair,
+}
+
+var asciiElementTypes = map[rune]LayoutElementType{
+	asciiAisle: LayoutElementAisle,
+	asciiGap:   LayoutElementGap,
+	asciiStair: LayoutElementStair,
+}
+
+// 解析布局文件，返回布局和座位（座位未设置影厅ID）
+func DecodeLayout(format LayoutFormat, data []byte) (*HallLayout, []*Seat, error) {
+	switch format {
+	case LayoutFormatASCII:
+		return decodeASCIILayout(data)
+	case LayoutFormatJSON, LayoutFormatYAML:
+		var doc LayoutDocument
+		var err error
+		if format == LayoutFormatJSON {
+			err = json.Unmarshal(data, &doc)
+		} else {
+			err = yaml.Unmarshal(data, &doc)
+		}
+		if err != nil {
+			return nil, nil, &LayoutImportError{Issues: []*LayoutIssue{{Message: err.Error()}}}
+		}
+		return decodeLayoutDocument(&doc)
+	default:
+		return nil, nil, fmt.Errorf("%w: %q", ErrInvalidLayoutFormat, format)
+	}
+}
+
+// 导出布局文件
+func EncodeLayout(format LayoutFormat, layout *HallLayout, seats []*Seat) ([]byte, error) {
+	switch format {
+	case LayoutFormatASCII:
+		return encodeASCIILayout(layout, seats), nil
+	case LayoutFormatJSON:
+		return json.MarshalIndent(newLayoutDocument(layout, seats), "", "  ")
+	case LayoutFormatYAML:
+		return yaml.Marshal(newLayoutDocument(layout, seats))
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidLayoutFormat, format)
+	}
+}
+
+func newLayoutDocument(layout *HallLayout, seats []*Seat) *LayoutDocument {
+	doc := &LayoutDocument{HallLayout: *layout, Seats: make([]*SeatDocument, len(seats))}
+	for i, s := range seats {
+		doc.Seats[i] = &SeatDocument{
+			Row:     s.RowIdentifier,
+			Number:  s.SeatNumber,
+			Type:    s.Type,
+			GridRow: s.GridRow,
+			GridCol: s.GridCol,
+			Block:   s.Block,
+		}
+	}
+	return doc
+}
+
+func decodeLayoutDocument(doc *LayoutDocument) (*HallLayout, []*Seat, error) {
+	layout := doc.HallLayout
+	if layout.Screen == "" {
+		layout.Screen = ScreenPositionTop
+	}
+	if layout.Elements == nil {
+		layout.Elements = []*LayoutElement{}
+	}
+	if layout.Blocks == nil {
+		layout.Blocks = []*SeatBlock{}
+	}
+
+	issues := make([]*LayoutIssue, 0)
+	// 先校验布局本身，布局不合法时座位坐标的校验没有意义
+	if err := layout.Validate(nil); err != nil {
+		issues = append(issues, &LayoutIssue{Message: err.Error()})
+		return nil, nil, &LayoutImportError{Issues: issues}
+	}
+
+	seats := make([]*Seat, len(doc.Seats))
+	seen := make(map[string]int)
+	cells := make(map[[2]int]int)
+	for i, sd := range doc.Seats {
+		field := fmt.Sprintf("seats[%d]", i)
+		if sd.Type == "" {
+			sd.Type = SeatTypeStandard
+		}
+		seat := &Seat{
+			RowIdentifier: sd.Row,
+			SeatNumber:    sd.Number,
+			Type:          sd.Type,
+			GridRow:       sd.GridRow,
+			GridCol:       sd.GridCol,
+			Block:         sd.Block,
+		}
+		if seat.Block == "" {
+			seat.Block = layout.BlockAt(seat.GridRow, seat.GridCol)
+		}
+		seats[i] = seat
+
+		if sd.Row == "" || sd.Number == "" {
+			issues = append(issues, &LayoutIssue{Field: field, Message: "row and number are required"})
+			continue
+		}
+		if !sd.Type.IsValid() {
+			issues = append(issues, &LayoutIssue{Field: field + ".type", Message: fmt.Sprintf("unknown seat type %q", sd.Type)})
+		}
+		key := sd.Row + "\x00" + sd.Number
+		if first, ok := seen[key]; ok {
+			issues = append(issues, &LayoutIssue{Field: field,
+				Message: fmt.Sprintf("duplicate seat %s%s (first defined at seats[%d])", sd.Row, sd.Number, first)})
+		} else {
+			seen[key] = i
+		}
+		issues = append(issues, checkSeatCell(&layout, seat, cells, i, field)...)
+	}
+	if len(issues) > 0 {
+		return nil, nil, &LayoutImportError{Issues: issues}
+	}
+	return &layout, seats, nil
+}
+
+// 校验座位所在单元格：越界、与过道等元素重叠、与其他座位重叠
+func checkSeatCell(layout *HallLayout, seat *Seat, cells map[[2]int]int, index int, field string) []*LayoutIssue {
+	if seat.GridRow < 0 || seat.GridCol < 0 || seat.GridRow >= layout.Rows || seat.GridCol >= layout.Cols {
+		return []*LayoutIssue{{Field: field, Message: fmt.Sprintf("seat %s%s at (%d,%d) is out of bounds %dx%d",
+			seat.RowIdentifier, seat.SeatNumber, seat.GridRow, seat.GridCol, layout.Rows, layout.Cols)}}
+	}
+	if e := layout.ElementAt(seat.GridRow, seat.GridCol); e != nil {
+		return []*LayoutIssue{{Field: field, Message: fmt.Sprintf("seat %s%s at (%d,%d) overlaps %s",
+			seat.RowIdentifier, seat.SeatNumber, seat.GridRow, seat.GridCol, e.Type)}}
+	}
+	cell := [2]int{seat.GridRow, seat.GridCol}
+	if other, ok := cells[cell]; ok {
+		return []*LayoutIssue{{Field: field, Message: fmt.Sprintf("seat %s%s at (%d,%d) overlaps seats[%d]",
+			seat.RowIdentifier, seat.SeatNumber, seat.GridRow, seat.GridCol, other)}}
+	}
+	cells[cell] = index
+	return nil
+}
+
+func decodeASCIILayout(data []byte) (*HallLayout, []*Seat, error) {
+	layout := NewGridLayout(0, 0)
+	issues := make([]*LayoutIssue, 0)
+	seats := make([]*Seat, 0)
+	rowLines := make(map[string]int)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "@") {
+			if issue := applyASCIIDirective(layout, trimmed); issue != nil {
+				issue.Line = lineNo
+				issues = append(issues, issue)
+			}
+			continue
+		}
+
+		// 排号与单元格之间以空白分隔
+		fields := strings.Fields(trimmed)
+		if len(fields) != 2 {
+			issues = append(issues, &LayoutIssue{Line: lineNo, Message: "expected '<row> <cells>'"})
+			continue
+		}
+		label, cells := fields[0], fields[1]
+		cellOffset := strings.Index(line, cells)
+		gridRow := layout.Rows
+		layout.Rows++
+
+		number := 0
+		for i, ch := range []rune(cells) {
+			column := cellOffset + i + 1
+			if t, ok := asciiSeatTypes[ch]; ok {
+				number++
+				seats = append(seats, &Seat{
+					RowIdentifier: label,
+					SeatNumber:    fmt.Sprintf("%02d", number),
+					Type:          t,
+					GridRow:       gridRow,
+					GridCol:       i,
+				})
+				continue
+			}
+			if t, ok := asciiElementTypes[ch]; ok {
+				layout.addCell(t, gridRow, i)
+				continue
+			}
+			issues = append(issues, &LayoutIssue{Line: lineNo, Column: column, Message: fmt.Sprintf("unknown cell %q", ch)})
+		}
+		if len([]rune(cells)) > layout.Cols {
+			layout.Cols = len([]rune(cells))
+		}
+
+		switch {
+		case label == asciiNoRowLabel && number > 0:
+			issues = append(issues, &LayoutIssue{Line: lineNo, Message: "row with seats requires a row identifier"})
+		case label != asciiNoRowLabel && number == 0:
+			issues = append(issues, &LayoutIssue{Line: lineNo, Message: fmt.Sprintf("row %s has no seats, use '-' as row identifier", label)})
+		case label != asciiNoRowLabel:
+			if first, ok := rowLines[label]; ok {
+				issues = append(issues, &LayoutIssue{Line: lineNo, Message: fmt.Sprintf("duplicate row identifier %s (first defined at line %d)", label, first)})
+			} else {
+				rowLines[label] = lineNo
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		issues = append(issues, &LayoutIssue{Message: err.Error()})
+	}
+	if layout.Rows == 0 && len(issues) == 0 {
+		issues = append(issues, &LayoutIssue{Message: "layout has no rows"})
+	}
+	if len(issues) > 0 {
+		return nil, nil, &LayoutImportError{Issues: issues}
+	}
+
+	// 较短的行视为右侧为空位
+	for r := 0; r < layout.Rows; r++ {
+		for c := 0; c < layout.Cols; c++ {
+			if layout.ElementAt(r, c) == nil && !hasSeatAt(seats, r, c) {
+				layout.addCell(LayoutElementGap, r, c)
+			}
+		}
+	}
+	for _, seat := range seats {
+		seat.Block = layout.BlockAt(seat.GridRow, seat.GridCol)
+	}
+	if err := layout.Validate(seats); err != nil {
+		return nil, nil, &LayoutImportError{Issues: []*LayoutIssue{{Message: err.Error()}}}
+	}
+	return layout, seats, nil
+}
+
+// 添加单个单元格元素，与同一行左侧相邻的同类元素合并
+func (l *HallLayout) addCell(t LayoutElementType, row, col int) {
+	for _, e := range l.Elements {
+		if e.Type == t && e.Row == row && e.spanRows() == 1 && e.Col+e.spanCols() == col {
+			e.ColSpan = e.spanCols() + 1
+			return
+		}
+	}
+	l.Elements = append(l.Elements, &LayoutElement{Type: t, Row: row, Col: col, RowSpan: 1, ColSpan: 1})
+}
+
+func hasSeatAt(seats []*Seat, row, col int) bool {
+	for _, s := range seats {
+		if s.GridRow == row && s.GridCol == col {
+			return true
+		}
+	}
+	return false
+}
+
+func applyASCIIDirective(layout *HallLayout, line string) *LayoutIssue {
+	fields := strings.Fields(line)
+	switch fields[0] {
+	case "@screen":
+		if len(fields) != 2 {
+			return &LayoutIssue{Message: "usage: @screen top|bottom"}
+		}
+		layout.Screen = ScreenPosition(fields[1])
+		if layout.Screen != ScreenPositionTop && layout.Screen != ScreenPositionBottom {
+			return &LayoutIssue{Message: fmt.Sprintf("invalid screen position %q", fields[1])}
+		}
+	case "@curvature":
+		if len(fields) != 2 {
+			return &LayoutIssue{Message: "usage: @curvature <number>"}
+		}
+		v, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil || v < 0 {
+			return &LayoutIssue{Message: fmt.Sprintf("invalid curvature %q", fields[1])}
+		}
+		layout.Curvature = v
+	case "@block":
+		if len(fields) != 6 {
+			return &LayoutIssue{Message: "usage: @block <name> <start_row> <start_col> <end_row> <end_col>"}
+		}
+		bounds := make([]int, 4)
+		for i, f := range fields[2:] {
+			v, err := strconv.Atoi(f)
+			if err != nil || v < 0 {
+				return &LayoutIssue{Message: fmt.Sprintf("invalid block bound %q", f)}
+			}
+			bounds[i] = v
+		}
+		layout.Blocks = append(layout.Blocks, &SeatBlock{
+			Name: fields[1], StartRow: bounds[0], StartCol: bounds[1], EndRow: bounds[2], EndCol: bounds[3],
+		})
+	default:
+		return &LayoutIssue{Message: fmt.Sprintf("unknown directive %s", fields[0])}
+	}
+	return nil
+}
+
+// 导出为 ascii 格式。ascii 格式按位置重新编号，排号、座位号不连续的影厅应导出为 json/yaml
+func encodeASCIILayout(layout *HallLayout, seats []*Seat) []byte {
+	grid := make([][]rune, layout.Rows)
+	labels := make([]string, layout.Rows)
+	for r := range grid {
+		grid[r] = make([]rune, layout.Cols)
+		labels[r] = asciiNoRowLabel
+		for c := range grid[r] {
+			grid[r][c] = asciiGap
+			if e := layout.ElementAt(r, c); e != nil {
+				for ch, t := range asciiElementTypes {
+					if t == e.Type {
+						grid[r][c] = ch
+					}
+				}
+			}
+		}
+	}
+	for _, s := range seats {
+		if s.GridRow < 0 || s.GridRow >= layout.Rows || s.GridCol < 0 || s.GridCol >= layout.Cols {
+			continue
+		}
+		ch := asciiStandard
+		switch s.Type {
+		case SeatTypeVIP:
+			ch = asciiVIP
+		case SeatTypeWheelchair:
+			ch = asciiWheelchair
+		}
+		grid[s.GridRow][s.GridCol] = ch
+		labels[s.GridRow] = s.RowIdentifier
+	}
+
+	width := len(asciiNoRowLabel)
+	for _, label := range labels {
+		if len(label) > width {
+			width = len(label)
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "@screen %s\n", layout.Screen)
+	if layout.Curvature != 0 {
+		fmt.Fprintf(&buf, "@curvature %s\n", strconv.FormatFloat(layout.Curvature, 'f', -1, 64))
+	}
+	for _, b := range layout.Blocks {
+		fmt.Fprintf(&buf, "@block %s %d %d %d %d\n", b.Name, b.StartRow, b.StartCol, b.EndRow, b.EndCol)
+	}
+	for r := range grid {
+		fmt.Fprintf(&buf, "%-*s %s\n", width, labels[r], string(grid[r]))
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,201 @@
+package cinema
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const testASCIILayout = "# 示例影厅\r\n" +
+	"@screen bottom\r\n" +
+	"@curvature 0.5\r\n" +
+	"@block left 0 0 2 1\r\n" +
+	"A SS|SS\r\n" +
+	"B VV|WS\r\n" +
+	"- ==|==\r\n" +
+	"C SS\r\n"
+
+func TestDecodeLayout_ASCII(t *testing.T) {
+	layout, seats, err := DecodeLayout(LayoutFormatASCII, []byte(testASCIILayout))
+	if err != nil {
+		t.Fatalf("DecodeLayout failed: %v", err)
+	}
+	if layout.Rows != 4 || layout.Cols != 5 || layout.Screen != ScreenPositionBottom || layout.Curvature != 0.5 || len(layout.Blocks) != 1 {
+		t.Errorf("layout = %dx%d screen %s curvature %v blocks %d, want 4x5 screen bottom curvature 0.5 blocks 1",
+			layout.Rows, layout.Cols, layout.Screen, layout.Curvature, len(layout.Blocks))
+	}
+
+	type seat struct {
+		row, number string
+		seatType    SeatType
+		gridRow     int
+		gridCol     int
+		block       string
+	}
+	want := []seat{
+		{"A", "01", SeatTypeStandard, 0, 0, "left"},
+		{"A", "02", SeatTypeStandard, 0, 1, "left"},
+		{"A", "03", SeatTypeStandard, 0, 3, ""},
+		{"A", "04", SeatTypeStandard, 0, 4, ""},
+		{"B", "01", SeatTypeVIP, 1, 0, "left"},
+		{"B", "02", SeatTypeVIP, 1, 1, "left"},
+		{"B", "03", SeatTypeWheelchair, 1, 3, ""},
+		{"B", "04", SeatTypeStandard, 1, 4, ""},
+		{"C", "01", SeatTypeStandard, 3, 0, ""},
+		{"C", "02", SeatTypeStandard, 3, 1, ""},
+	}
+	if len(seats) != len(want) {
+		t.Fatalf("DecodeLayout returned %d seats, want %d", len(seats), len(want))
+	}
+	for i, s := range seats {
+		got := seat{s.RowIdentifier, s.SeatNumber, s.Type, s.GridRow, s.GridCol, s.Block}
+		if got != want[i] {
+			t.Errorf("seat %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+
+	cells := []struct {
+		row, col int
+		want     LayoutElementType
+	}{
+		{0, 2, LayoutElementAisle},
+		{2, 0, LayoutElementStair},
+		{2, 2, LayoutElementAisle},
+		{2, 4, LayoutElementStair},
+		// 较短的行右侧补为空位
+		{3, 2, LayoutElementGap},
+		{3, 4, LayoutElementGap},
+	}
+	for _, c := range cells {
+		e := layout.ElementAt(c.row, c.col)
+		if e == nil || e.Type != c.want {
+			t.Errorf("ElementAt(%d, %d) = %+v, want %s", c.row, c.col, e, c.want)
+		}
+	}
+	// 同一行相邻的同类单元格合并为一个元素
+	if e := layout.ElementAt(2, 0); e == nil || e.ColSpan != 2 {
+		t.Errorf("stair at (2,0) = %+v, want col span 2", e)
+	}
+}
+
+func TestDecodeLayout_ASCIIIssues(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []LayoutIssue // Message 为期望包含的片段
+	}{
+		{"unknown cell", "A SX", []LayoutIssue{{Line: 1, Column: 4, Message: "unknown cell 'X'"}}},
+		{"seats without row identifier", "- SS", []LayoutIssue{{Line: 1, Message: "requires a row identifier"}}},
+		{"row without seats", "A S\nB ||", []LayoutIssue{{Line: 2, Message: "row B has no seats"}}},
+		{"duplicate row", "A SS\n# comment\nA SS", []LayoutIssue{{Line: 3, Message: "first defined at line 1"}}},
+		{"too many fields", "A SS SS", []LayoutIssue{{Line: 1, Message: "expected '<row> <cells>'"}}},
+		{"invalid screen", "@screen left\nA S", []LayoutIssue{{Line: 1, Message: "invalid screen position"}}},
+		{"negative curvature", "@curvature -1\nA S", []LayoutIssue{{Line: 1, Message: "invalid curvature"}}},
+		{"block usage", "@block left 0 0\nA S", []LayoutIssue{{Line: 1, Message: "usage: @block"}}},
+		{"unknown directive", "@seats 10\nA S", []LayoutIssue{{Line: 1, Message: "unknown directive @seats"}}},
+		{"no rows", "# only comments\n\n", []LayoutIssue{{Message: "layout has no rows"}}},
+		// 指令本身合法，但分区超出布局范围，由布局校验报告
+		{"block out of bounds", "@block left 0 0 5 5\nA SS", []LayoutIssue{{Message: `block "left" is out of bounds`}}},
+		{"all issues reported", "A SX\n- SS\nA S", []LayoutIssue{
+			{Line: 1, Column: 4, Message: "unknown cell"},
+			{Line: 2, Message: "requires a row identifier"},
+			{Line: 3, Message: "duplicate row identifier A"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := DecodeLayout(LayoutFormatASCII, []byte(tt.input))
+			if !errors.Is(err, ErrInvalidHallLayout) {
+				t.Fatalf("DecodeLayout error = %v, want %v", err, ErrInvalidHallLayout)
+			}
+			var importErr *LayoutImportError
+			if !errors.As(err, &importErr) {
+				t.Fatalf("DecodeLayout error = %T, want *LayoutImportError", err)
+			}
+			if len(importErr.Issues) != len(tt.want) {
+				t.Fatalf("issues = %v, want %d issues", err, len(tt.want))
+			}
+			for i, issue := range importErr.Issues {
+				w := tt.want[i]
+				if issue.Line != w.Line || issue.Column != w.Column || !strings.Contains(issue.Message, w.Message) {
+					t.Errorf("issue %d = %s, want line %d, column %d, message containing %q",
+						i, issue, w.Line, w.Column, w.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestLayout_RoundTrip(t *testing.T) {
+	layout, seats, err := DecodeLayout(LayoutFormatASCII, []byte(testASCIILayout))
+	if err != nil {
+		t.Fatalf("DecodeLayout failed: %v", err)
+	}
+
+	for _, format := range []LayoutFormat{LayoutFormatASCII, LayoutFormatJSON, LayoutFormatYAML} {
+		t.Run(string(format), func(t *testing.T) {
+			data, err := EncodeLayout(format, layout, seats)
+			if err != nil {
+				t.Fatalf("EncodeLayout failed: %v", err)
+			}
+			gotLayout, gotSeats, err := DecodeLayout(format, data)
+			if err != nil {
+				t.Fatalf("DecodeLayout of encoded layout failed: %v\n%s", err, data)
+			}
+			if gotLayout.Rows != layout.Rows || gotLayout.Cols != layout.Cols ||
+				gotLayout.Screen != layout.Screen || gotLayout.Curvature != layout.Curvature {
+				t.Errorf("layout = %+v, want %+v", gotLayout, layout)
+			}
+			for r := 0; r < layout.Rows; r++ {
+				for c := 0; c < layout.Cols; c++ {
+					want, got := layout.ElementAt(r, c), gotLayout.ElementAt(r, c)
+					if (want == nil) != (got == nil) || want != nil && want.Type != got.Type {
+						t.Errorf("ElementAt(%d, %d) = %+v, want %+v", r, c, got, want)
+					}
+				}
+			}
+			if len(gotSeats) != len(seats) {
+				t.Fatalf("decoded %d seats, want %d", len(gotSeats), len(seats))
+			}
+			for i, s := range gotSeats {
+				w := seats[i]
+				if s.RowIdentifier != w.RowIdentifier || s.SeatNumber != w.SeatNumber || s.Type != w.Type ||
+					s.GridRow != w.GridRow || s.GridCol != w.GridCol || s.Block != w.Block {
+					t.Errorf("seat %d = %+v, want %+v", i, s, w)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeLayout_DocumentIssues(t *testing.T) {
+	input := `{
+		"rows": 1, "cols": 3,
+		"elements": [{"type": "aisle", "row": 0, "col": 1, "row_span": 1, "col_span": 1}],
+		"seats": [
+			{"row": "A", "number": "01", "grid_row": 0, "grid_col": 0},
+			{"row": "A", "number": "01", "grid_row": 0, "grid_col": 2},
+			{"row": "A", "number": "02", "grid_row": 0, "grid_col": 1},
+			{"row": "A", "number": "03", "type": "SOFA", "grid_row": 0, "grid_col": 5}
+		]
+	}`
+	_, _, err := DecodeLayout(LayoutFormatJSON, []byte(input))
+	var importErr *LayoutImportError
+	if !errors.As(err, &importErr) {
+		t.Fatalf("DecodeLayout error = %v, want *LayoutImportError", err)
+	}
+	want := []LayoutIssue{
+		{Field: "seats[1]", Message: "duplicate seat A01"},
+		{Field: "seats[2]", Message: "overlaps aisle"},
+		{Field: "seats[3].type", Message: `unknown seat type "SOFA"`},
+		{Field: "seats[3]", Message: "out of bounds"},
+	}
+	if len(importErr.Issues) != len(want) {
+		t.Fatalf("issues = %v, want %d issues", err, len(want))
+	}
+	for i, issue := range importErr.Issues {
+		if issue.Field != want[i].Field || !strings.Contains(issue.Message, want[i].Message) {
+			t.Errorf("issue %d = %s, want field %s, message containing %q", i, issue, want[i].Field, want[i].Message)
+		}
+	}
+}
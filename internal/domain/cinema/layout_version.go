@@ -0,0 +1,47 @@
+package cinema
+
+import (
+	"mrs/internal/domain/shared/vo"
+	"time"
+)
+
+// 影厅创建时的布局版本号，历史数据默认也属于该版本
+const InitialLayoutVersion = 1
+
+// 影厅布局版本（不可变快照）
+// 每次修改布局都会生成新版本及一组新座位，旧版本座位保留（软删除），
+// 已开售的场次固定引用创建时的版本，座位表位图偏移不会因布局修改而变化。
+type HallLayoutVersion struct {
+	ID           vo.HallLayoutVersionID
+	CinemaHallID vo.CinemaHallID
+	Version      int
+	Layout       *HallLayout
+	SeatCount    int
+	CreatedAt    time.Time
+}
+
+// 座位的业务标识（排号+座位号），用于在不同布局版本之间对应座位
+type SeatLabel struct {
+	RowIdentifier string
+	SeatNumber    string
+}
+
+func (s *Seat) Label() SeatLabel {
+	return SeatLabel{RowIdentifier: s.RowIdentifier, SeatNumber: s.SeatNumber}
+}
+
+// 按排号+座位号将 from 中的座位对应到 to 中的座位，返回 from 座位ID -> to 座位
+// 新版本中不存在的座位不会出现在结果中
+func MatchSeats(from, to []*Seat) map[vo.SeatID]*Seat {
+	byLabel := make(map[SeatLabel]*Seat, len(to))
+	for _, s := range to {
+		byLabel[s.Label()] = s
+	}
+	matched := make(map[vo.SeatID]*Seat, len(from))
+	for _, s := range from {
+		if target, ok := byLabel[s.Label()]; ok {
+			matched[s.ID] = target
+		}
+	}
+	return matched
+}
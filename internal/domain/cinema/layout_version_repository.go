@@ -0,0 +1,13 @@
+package cinema
+
+import (
+	"context"
+	"mrs/internal/domain/shared/vo"
+)
+
+type HallLayoutVersionRepository interface {
+	Create(ctx context.Context, version *HallLayoutVersion) (*HallLayoutVersion, error)
+	FindByHallAndVersion(ctx context.Context, hallID vo.CinemaHallID, version int) (*HallLayoutVersion, error)
+	// 按版本号升序返回影厅的全部布局版本
+	ListByHallID(ctx context.Context, hallID vo.CinemaHallID) ([]*HallLayoutVersion, error)
+}
@@ -13,6 +13,14 @@ const (
 	SeatTypeWheelchair = "WHEELCHAIR"
 )
 
+func (t SeatType) IsValid() bool {
+	switch t {
+	case SeatTypeStandard, SeatTypeVIP, SeatTypeWheelchair:
+		return true
+	}
+	return false
+}
+
 // 注意：座位的可用性通常与特定的Showtime相关，而不是座位本身的静态属性。
 type Seat struct {
 	ID vo.SeatID
@@ -21,22 +29,19 @@ type Seat struct {
 	RowIdentifier string // 座位所在排的标识,如 A、B、C
 	SeatNumber    string // 座位在该排中的编号,如 1、2、3
 	Type          SeatType
+
+	// 座位在影厅布局网格中的位置（从0开始）
+	GridRow int
+	GridCol int
+	Block   string // 所属分区名称，为空表示不属于任何分区
+
+	// 座位所属的布局版本，修改布局时旧版本座位被保留给已有场次
+	LayoutVersion int
 }
 
+// 生成默认布局（10x10 无过道网格）对应的座位
 func GenerateDefaultSeats(cinemaHallID vo.CinemaHallID) []*Seat {
-	rows, columns := 10, 10
-	seats := make([]*Seat, 0, rows*columns)
-	for i := 0; i < rows; i++ {
-		for j := 1; j <= columns; j++ {
-			seats = append(seats, &Seat{
-				CinemaHallID:  cinemaHallID,
-				RowIdentifier: string(rune('A' + i)),
-				SeatNumber:    fmt.Sprintf("%02d", j),
-				Type:          SeatTypeStandard,
-			})
-		}
-	}
-	return seats
+	return NewGridLayout(DefaultLayoutRows, DefaultLayoutCols).GenerateSeats(cinemaHallID)
 }
 
 // 座位状态枚举
@@ -45,8 +50,12 @@ type SeatStatus int
 const (
 	SeatStatusAvailable SeatStatus = iota // 可用
 	SeatStatusLocked                      // 已锁定
+	SeatStatusBlocked                     // 不可售（座位限制或社交距离）
 )
 
+// 座位因社交距离策略被封锁，其余封锁原因为 SeatRestrictionKind
+const SeatBlockReasonDistancing = "DISTANCING"
+
 // 座位静态信息
 type SeatInfo struct {
 	ID            vo.SeatID  `json:"id"`             // 座位ID
@@ -54,6 +63,28 @@ type SeatInfo struct {
 	SeatNumber    string     `json:"seat_number"`    // 座位在该排中的编号,如 1、2、3
 	Type          SeatType   `json:"type"`           // 座位类型
 	Status        SeatStatus `json:"status"`         // 座位状态
+	// 不可售原因，仅 Status 为 SeatStatusBlocked 时有值
+	BlockReason string `json:"block_reason,omitempty"`
+
+	// 布局信息，用于前端渲染座位图
+	GridRow int     `json:"grid_row"`        // 网格行
+	GridCol int     `json:"grid_col"`        // 网格列
+	Block   string  `json:"block,omitempty"` // 所属分区
+	X       float64 `json:"x"`               // 渲染横坐标（单元格为单位）
+	Y       float64 `json:"y"`               // 渲染纵坐标（单元格为单位，屏幕一侧为0）
+}
+
+// 座位图：布局 + 座位状态
+type SeatMap struct {
+	Layout     *HallLayout `json:"layout"`
+	Distancing bool        `json:"distancing"` // 是否启用社交距离（订单变化时需要重新计算封锁座位）
+	Seats      []*SeatInfo `json:"seats"`
+}
+
+// 初始化座位表时需要封锁的座位
+type SeatMapBlocks struct {
+	Reasons    map[vo.SeatID]string `json:"reasons"`    // 座位ID -> 封锁原因
+	Distancing bool                 `json:"distancing"` // 是否启用社交距离
 }
 
 // 获取座位显示名称
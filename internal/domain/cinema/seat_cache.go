@@ -13,14 +13,16 @@ import (
 type SeatCache interface {
 	LockSeats(ctx context.Context, showtimeID vo.ShowtimeID, seatIDs []vo.SeatID) error
 	ReleaseSeats(ctx context.Context, showtimeID vo.ShowtimeID, seatIDs []vo.SeatID) error
-	GetSeatMap(ctx context.Context, showtimeID vo.ShowtimeID) ([]*SeatInfo, error)
-	InitSeatMap(ctx context.Context, showtimeID vo.ShowtimeID, hallLayout []*Seat, bookedSeatIDs []vo.SeatID, expireTime time.Duration) error
+	GetSeatMap(ctx context.Context, showtimeID vo.ShowtimeID) (*SeatMap, error)
+	InitSeatMap(ctx context.Context, showtimeID vo.ShowtimeID, layout *HallLayout, seats []*Seat, bookedSeatIDs []vo.SeatID, blocks *SeatMapBlocks, expireTime time.Duration) error
 	InvalidateSeatMap(ctx context.Context, showtimeID vo.ShowtimeID) error // 失效座位表（大多数情况下，座位表会自动过期，但若修改座位时需要手动失效）
 }
 
 const (
 	ShowtimeSeatsBitmapKeyFormat   = "seatmap:showtime:%d:bitmap"    // 场次座位状态位图
 	ShowtimeSeatsInfoKeyFormat     = "seatmap:showtime:%d:info"      // 场次座位静态信息
+	ShowtimeSeatsLayoutKeyFormat   = "seatmap:showtime:%d:layout"    // 场次影厅几何布局
+	ShowtimeSeatsBlockedKeyFormat  = "seatmap:showtime:%d:blocked"   // 场次封锁座位及原因
 	ShowtimeSeatsLockKeyFormat     = "seatmap:showtime:%d:locks"     // 座位临时锁定
 	ShowtimeSeatsInitLockKeyFormat = "seatmap:showtime:%d:init:lock" // 初始化座位表的锁，防止并发初始化座位表
 )
@@ -44,3 +46,13 @@ func GetShowtimeSeatsBitmapKey(showtimeID vo.ShowtimeID) string {
 func GetShowtimeSeatsInfoKey(showtimeID vo.ShowtimeID) string {
 	return fmt.Sprintf(ShowtimeSeatsInfoKeyFormat, showtimeID)
 }
+
+// 生成影厅几何布局的缓存键
+func GetShowtimeSeatsLayoutKey(showtimeID vo.ShowtimeID) string {
+	return fmt.Sprintf(ShowtimeSeatsLayoutKeyFormat, showtimeID)
+}
+
+// 生成封锁座位信息的缓存键
+func GetShowtimeSeatsBlockedKey(showtimeID vo.ShowtimeID) string {
+	return fmt.Sprintf(ShowtimeSeatsBlockedKeyFormat, showtimeID)
+}
@@ -10,6 +10,8 @@ type SeatRepository interface {
 
 	FindByID(ctx context.Context, id vo.SeatID) (*Seat, error)
 	FindByHallID(ctx context.Context, hallID vo.CinemaHallID) ([]*Seat, error)
+	// 查询影厅指定布局版本的座位（包含已被新版本替换的座位）
+	FindByHallAndVersion(ctx context.Context, hallID vo.CinemaHallID, version int) ([]*Seat, error)
 	GetSeatsByIDs(ctx context.Context, seatIDs []vo.SeatID) ([]*Seat, error)
 
 	Update(ctx context.Context, seat *Seat) error
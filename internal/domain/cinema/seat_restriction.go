@@ -0,0 +1,153 @@
+package cinema
+
+import (
+	"fmt"
+	"mrs/internal/domain/shared/vo"
+	"time"
+)
+
+// 关于座位限制：座位本身是静态的，限制描述某个座位在一段时间内不可售出的原因。
+// 限制只影响与其时间范围重叠的场次，在初始化场次座位表时生效。
+
+// 座位限制类型
+type SeatRestrictionKind string
+
+const (
+	SeatRestrictionOutOfService SeatRestrictionKind = "OUT_OF_SERVICE" // 故障维修
+	SeatRestrictionHouseSeat    SeatRestrictionKind = "HOUSE_SEAT"     // 内部保留座（嘉宾、媒体等）
+	SeatRestrictionBlocked      SeatRestrictionKind = "BLOCKED"        // 其他原因封锁
+)
+
+func (k SeatRestrictionKind) IsValid() bool {
+	switch k {
+	case SeatRestrictionOutOfService, SeatRestrictionHouseSeat, SeatRestrictionBlocked:
+		return true
+	}
+	return false
+}
+
+// 座位限制
+type SeatRestriction struct {
+	ID           vo.SeatRestrictionID
+	SeatID       vo.SeatID
+	CinemaHallID vo.CinemaHallID
+	Kind         SeatRestrictionKind
+	Reason       string
+	StartsAt     *time.Time // 为空表示立即生效
+	EndsAt       *time.Time // 为空表示长期有效
+	CreatedAt    time.Time
+}
+
+func (r *SeatRestriction) Validate() error {
+	if !r.Kind.IsValid() {
+		return fmt.Errorf("%w: invalid kind %q", ErrInvalidSeatRestriction, r.Kind)
+	}
+	if r.StartsAt != nil && r.EndsAt != nil && !r.EndsAt.After(*r.StartsAt) {
+		return fmt.Errorf("%w: ends_at must be after starts_at", ErrInvalidSeatRestriction)
+	}
+	return nil
+}
+
+// 是否与 [start, end) 时间段重叠
+func (r *SeatRestriction) Overlaps(start, end time.Time) bool {
+	return overlaps(r.StartsAt, r.EndsAt, start, end)
+}
+
+func overlaps(from, until *time.Time, start, end time.Time) bool {
+	if from != nil && !from.Before(end) {
+		return false
+	}
+	if until != nil && !until.After(start) {
+		return false
+	}
+	return true
+}
+
+// 社交距离策略：自动封锁每组已订座位的相邻座位
+type DistancingPolicy struct {
+	// 每组已订座位左右两侧封锁的座位数（不跨越过道和分区）
+	SeatsBetweenGroups int `json:"seats_between_groups"`
+	// 是否同时封锁前后排同一列的座位
+	BlockFrontAndBack bool `json:"block_front_and_back"`
+	// 生效时间段，为空表示不限
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+	EndsAt   *time.Time `json:"ends_at,omitempty"`
+}
+
+func (p *DistancingPolicy) Validate() error {
+	if p.SeatsBetweenGroups < 0 {
+		return fmt.Errorf("%w: seats_between_groups must not be negative", ErrInvalidDistancingPolicy)
+	}
+	if p.SeatsBetweenGroups == 0 && !p.BlockFrontAndBack {
+		return fmt.Errorf("%w: policy blocks nothing", ErrInvalidDistancingPolicy)
+	}
+	if p.StartsAt != nil && p.EndsAt != nil && !p.EndsAt.After(*p.StartsAt) {
+		return fmt.Errorf("%w: ends_at must be after starts_at", ErrInvalidDistancingPolicy)
+	}
+	return nil
+}
+
+// 策略是否对 [start, end) 时间段的场次生效
+func (p *DistancingPolicy) AppliesTo(start, end time.Time) bool {
+	return p != nil && overlaps(p.StartsAt, p.EndsAt, start, end)
+}
+
+// 计算需要封锁的座位：groups 为每个订单的座位，返回的座位不包含已订座位
+func (p *DistancingPolicy) BlockedSeats(layout *HallLayout, seats []*Seat, groups [][]vo.SeatID) []vo.SeatID {
+	occupied := make(map[vo.SeatID]bool)
+	for _, group := range groups {
+		for _, id := range group {
+			occupied[id] = true
+		}
+	}
+
+	blocked := make(map[vo.SeatID]bool)
+	block := func(s *Seat) {
+		if !occupied[s.ID] {
+			blocked[s.ID] = true
+		}
+	}
+
+	if p.SeatsBetweenGroups > 0 {
+		for _, segment := range layout.RowSegments(seats) {
+			for i, s := range segment {
+				if !occupied[s.ID] {
+					continue
+				}
+				for d := 1; d <= p.SeatsBetweenGroups; d++ {
+					if i-d >= 0 {
+						block(segment[i-d])
+					}
+					if i+d < len(segment) {
+						block(segment[i+d])
+					}
+				}
+			}
+		}
+	}
+
+	if p.BlockFrontAndBack {
+		byCell := make(map[[2]int]*Seat, len(seats))
+		for _, s := range seats {
+			byCell[[2]int{s.GridRow, s.GridCol}] = s
+		}
+		for _, s := range seats {
+			if !occupied[s.ID] {
+				continue
+			}
+			for _, dr := range []int{-1, 1} {
+				if neighbour, ok := byCell[[2]int{s.GridRow + dr, s.GridCol}]; ok {
+					block(neighbour)
+				}
+			}
+		}
+	}
+
+	result := make([]vo.SeatID, 0, len(blocked))
+	for _, s := range seats {
+		if blocked[s.ID] {
+			result = append(result, s.ID)
+		}
+	}
+	return result
+}
@@ -0,0 +1,14 @@
+package cinema
+
+import (
+	"context"
+	"mrs/internal/domain/shared/vo"
+)
+
+type SeatRestrictionRepository interface {
+	CreateBatch(ctx context.Context, restrictions []*SeatRestriction) ([]*SeatRestriction, error)
+	FindByID(ctx context.Context, id vo.SeatRestrictionID) (*SeatRestriction, error)
+	// 查询影厅的全部座位限制（含已过期的），由调用方按时间筛选
+	ListByHallID(ctx context.Context, hallID vo.CinemaHallID) ([]*SeatRestriction, error)
+	Delete(ctx context.Context, id vo.SeatRestrictionID) error
+}
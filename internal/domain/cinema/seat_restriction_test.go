@@ -0,0 +1,113 @@
+package cinema
+
+import (
+	"mrs/internal/domain/shared/vo"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// 三排座位，每排 4+2 个，中间为过道；C 排前两个座位单独划为 vip 分区
+// 座位ID按解析顺序从1开始：A01-A06 为 1-6，B01-B06 为 7-12，C01-C06 为 13-18
+const testDistancingLayout = "@block vip 2 0 2 1\n" +
+	"A SSSS|SS\n" +
+	"B SSSS|SS\n" +
+	"C SSSS|SS\n"
+
+func newTestDistancingHall(t *testing.T) (*HallLayout, []*Seat) {
+	t.Helper()
+	layout, seats, err := DecodeLayout(LayoutFormatASCII, []byte(testDistancingLayout))
+	if err != nil {
+		t.Fatalf("DecodeLayout failed: %v", err)
+	}
+	for i, s := range seats {
+		s.ID = vo.SeatID(i + 1)
+	}
+	return layout, seats
+}
+
+func TestDistancingPolicy_BlockedSeats(t *testing.T) {
+	layout, seats := newTestDistancingHall(t)
+	tests := []struct {
+		name   string
+		policy DistancingPolicy
+		groups [][]vo.SeatID
+		want   []vo.SeatID
+	}{
+		{"no bookings", DistancingPolicy{SeatsBetweenGroups: 1, BlockFrontAndBack: true}, nil, []vo.SeatID{}},
+		{"one seat each side", DistancingPolicy{SeatsBetweenGroups: 1}, [][]vo.SeatID{{2}}, []vo.SeatID{1, 3}},
+		{"two seats each side", DistancingPolicy{SeatsBetweenGroups: 2}, [][]vo.SeatID{{1}}, []vo.SeatID{2, 3}},
+		{"group blocks only its outer neighbours", DistancingPolicy{SeatsBetweenGroups: 1}, [][]vo.SeatID{{2, 3}}, []vo.SeatID{1, 4}},
+		// 过道另一侧的座位不封锁
+		{"aisle stops blocking", DistancingPolicy{SeatsBetweenGroups: 2}, [][]vo.SeatID{{4}}, []vo.SeatID{2, 3}},
+		// 分区边界同样截断
+		{"block boundary stops blocking", DistancingPolicy{SeatsBetweenGroups: 1}, [][]vo.SeatID{{14}}, []vo.SeatID{13}},
+		// 两组之间的座位只封锁一次，已订座位不会被封锁
+		{"seat between groups", DistancingPolicy{SeatsBetweenGroups: 1}, [][]vo.SeatID{{1, 2}, {4}}, []vo.SeatID{3}},
+		{"adjacent groups", DistancingPolicy{SeatsBetweenGroups: 1}, [][]vo.SeatID{{1}, {2}}, []vo.SeatID{3}},
+		{"front and back", DistancingPolicy{BlockFrontAndBack: true}, [][]vo.SeatID{{8}}, []vo.SeatID{2, 14}},
+		{"front and back at first row", DistancingPolicy{BlockFrontAndBack: true}, [][]vo.SeatID{{5}}, []vo.SeatID{11}},
+		{"both rules", DistancingPolicy{SeatsBetweenGroups: 1, BlockFrontAndBack: true}, [][]vo.SeatID{{8}}, []vo.SeatID{2, 7, 9, 14}},
+		{"front and back skips booked seats", DistancingPolicy{BlockFrontAndBack: true}, [][]vo.SeatID{{8}, {2}}, []vo.SeatID{14}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.policy.BlockedSeats(layout, seats, tt.groups)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BlockedSeats(%v) = %v, want %v", tt.groups, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDistancingPolicy_Validate(t *testing.T) {
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+	tests := []struct {
+		name    string
+		policy  DistancingPolicy
+		wantErr bool
+	}{
+		{"seats between groups", DistancingPolicy{SeatsBetweenGroups: 1}, false},
+		{"front and back only", DistancingPolicy{BlockFrontAndBack: true}, false},
+		{"with period", DistancingPolicy{SeatsBetweenGroups: 1, StartsAt: &start, EndsAt: &end}, false},
+		{"negative seats", DistancingPolicy{SeatsBetweenGroups: -1, BlockFrontAndBack: true}, true},
+		{"blocks nothing", DistancingPolicy{}, true},
+		{"ends before starts", DistancingPolicy{SeatsBetweenGroups: 1, StartsAt: &end, EndsAt: &start}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.policy.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDistancingPolicy_AppliesTo(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	until := from.Add(7 * 24 * time.Hour)
+	policy := &DistancingPolicy{SeatsBetweenGroups: 1, StartsAt: &from, EndsAt: &until}
+	tests := []struct {
+		name       string
+		policy     *DistancingPolicy
+		start, end time.Time
+		want       bool
+	}{
+		{"inside period", policy, from.Add(time.Hour), from.Add(3 * time.Hour), true},
+		// 场次跨越生效起点或终点时同样生效
+		{"overlaps start", policy, from.Add(-time.Hour), from.Add(time.Hour), true},
+		{"overlaps end", policy, until.Add(-time.Hour), until.Add(time.Hour), true},
+		{"ends at start", policy, from.Add(-2 * time.Hour), from, false},
+		{"starts at end", policy, until, until.Add(2 * time.Hour), false},
+		{"unbounded", &DistancingPolicy{SeatsBetweenGroups: 1}, from.AddDate(-1, 0, 0), from, true},
+		{"nil policy", nil, from, until, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.AppliesTo(tt.start, tt.end); got != tt.want {
+				t.Errorf("AppliesTo(%v, %v) = %v, want %v", tt.start, tt.end, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package loyalty
+
+import "errors"
+
+var (
+	ErrInsufficientPoints      = errors.New("insufficient loyalty points")
+	ErrRedemptionLimitExceeded = errors.New("loyalty points exceed the redemption limit")
+	ErrBookingNotOpen          = errors.New("booking is not open yet for this tier")
+	ErrInvalidLoyaltyProgram   = errors.New("invalid loyalty program")
+)
@@ -0,0 +1,100 @@
+package loyalty
+
+import (
+	"mrs/internal/domain/booking"
+	"mrs/internal/domain/shared/vo"
+	"time"
+)
+
+// 关于积分账本：每次积分变动追加一条流水，流水不修改也不删除，余额由流水求和得到。
+// 订单确认时按实付金额获得积分，下单时可用积分抵扣；订单取消或退款时追加反向流水冲销该订单的积分变动。
+// 冲销获得的积分时不检查余额，已用掉的积分被冲销后余额可能为负，之后获得的积分先抵平负数。
+
+// 流水类型
+type EntryType string
+
+const (
+	EntryEarn    EntryType = "earn"    // 订单确认获得积分（正数）
+	EntryReverse EntryType = "reverse" // 订单退款冲销获得的积分（负数）
+	EntryRedeem  EntryType = "redeem"  // 下单时抵扣积分（负数）
+	EntryRestore EntryType = "restore" // 订单取消或退款退回抵扣的积分（正数）
+)
+
+// 是否计入等级积分：等级只按消费获得的积分计算，抵扣积分不会降级
+func (t EntryType) Qualifying() bool {
+	return t == EntryEarn || t == EntryReverse
+}
+
+// 积分流水
+type Entry struct {
+	ID        vo.LoyaltyEntryID
+	UserID    vo.UserID
+	BookingID vo.BookingID
+	Type      EntryType
+	Points    int // 正数增加余额，负数减少余额
+	CreatedAt time.Time
+}
+
+func newEntry(entryType EntryType, userID vo.UserID, bookingID vo.BookingID, points int) *Entry {
+	return &Entry{
+		UserID:    userID,
+		BookingID: bookingID,
+		Type:      entryType,
+		Points:    points,
+		CreatedAt: time.Now(),
+	}
+}
+
+// 订单确认获得积分
+func NewEarnEntry(b *booking.Booking, points int) *Entry {
+	return newEntry(EntryEarn, b.UserID, b.ID, points)
+}
+
+// 下单抵扣积分
+func NewRedeemEntry(b *booking.Booking, points int) *Entry {
+	return newEntry(EntryRedeem, b.UserID, b.ID, -points)
+}
+
+// 冲销订单的积分变动：获得的积分追加 reverse，抵扣的积分追加 restore。
+// 按订单汇总已有流水后冲销净额，重复调用时净额为0，不会重复冲销
+func Reversals(entries []*Entry) []*Entry {
+	type net struct {
+		userID    vo.UserID
+		bookingID vo.BookingID
+		earned    int
+		redeemed  int
+	}
+	nets := make(map[vo.BookingID]*net)
+	order := make([]*net, 0)
+	for _, entry := range entries {
+		n, ok := nets[entry.BookingID]
+		if !ok {
+			n = &net{userID: entry.UserID, bookingID: entry.BookingID}
+			nets[entry.BookingID] = n
+			order = append(order, n)
+		}
+		if entry.Type.Qualifying() {
+			n.earned += entry.Points
+		} else {
+			n.redeemed += entry.Points
+		}
+	}
+
+	reversals := make([]*Entry, 0, len(order))
+	for _, n := range order {
+		if n.earned != 0 {
+			reversals = append(reversals, newEntry(EntryReverse, n.userID, n.bookingID, -n.earned))
+		}
+		if n.redeemed != 0 {
+			reversals = append(reversals, newEntry(EntryRestore, n.userID, n.bookingID, -n.redeemed))
+		}
+	}
+	return reversals
+}
+
+// 积分账户（由流水汇总得到）
+type Account struct {
+	UserID           vo.UserID
+	Balance          int // 可用积分 = 全部流水之和
+	QualifyingPoints int // 等级积分 = 获得与冲销流水之和
+}
@@ -0,0 +1,19 @@
+package loyalty
+
+import (
+	"context"
+	"mrs/internal/domain/shared/vo"
+)
+
+// LedgerRepository 积分流水只追加，余额由流水汇总
+type LedgerRepository interface {
+	Append(ctx context.Context, entries ...*Entry) error
+	// 汇总用户的积分账户
+	GetAccount(ctx context.Context, userID vo.UserID) (*Account, error)
+	// 锁定用户后汇总积分账户，同一用户的抵扣在事务内串行执行（需在事务中调用）
+	LockAccount(ctx context.Context, userID vo.UserID) (*Account, error)
+	// 订单的全部流水
+	FindByBookingIDs(ctx context.Context, bookingIDs []vo.BookingID) ([]*Entry, error)
+	// 分页查询用户的流水，按时间倒序
+	ListByUser(ctx context.Context, userID vo.UserID, page, pageSize int) ([]*Entry, int64, error)
+}
@@ -0,0 +1,88 @@
+package loyalty
+
+import (
+	"mrs/internal/domain/booking"
+	"mrs/internal/domain/shared/vo"
+	"testing"
+)
+
+// 按流水汇总账户（与仓库实现的求和规则一致）
+func sumAccount(entries []*Entry) Account {
+	var account Account
+	for _, entry := range entries {
+		account.Balance += entry.Points
+		if entry.Type.Qualifying() {
+			account.QualifyingPoints += entry.Points
+		}
+	}
+	return account
+}
+
+func testBooking(id uint) *booking.Booking {
+	return &booking.Booking{ID: vo.BookingID(id), UserID: vo.UserID(7)}
+}
+
+func TestReversals(t *testing.T) {
+	entries := []*Entry{
+		NewRedeemEntry(testBooking(1), 50),
+		NewEarnEntry(testBooking(1), 120),
+		NewEarnEntry(testBooking(2), 30),
+	}
+
+	reversals := Reversals(entries)
+	type want struct {
+		bookingID vo.BookingID
+		entryType EntryType
+		points    int
+	}
+	wants := []want{
+		{1, EntryReverse, -120},
+		{1, EntryRestore, 50},
+		{2, EntryReverse, -30},
+	}
+	if len(reversals) != len(wants) {
+		t.Fatalf("Reversals returned %d entries, want %d", len(reversals), len(wants))
+	}
+	for i, r := range reversals {
+		got := want{r.BookingID, r.Type, r.Points}
+		if got != wants[i] || r.UserID != 7 {
+			t.Errorf("reversal %d = %+v (user %d), want %+v (user 7)", i, got, r.UserID, wants[i])
+		}
+	}
+	if account := sumAccount(append(entries, reversals...)); account != (Account{}) {
+		t.Errorf("account after reversal = %+v, want zero", account)
+	}
+}
+
+func TestReversals_Idempotent(t *testing.T) {
+	entries := []*Entry{
+		NewEarnEntry(testBooking(1), 120),
+		NewRedeemEntry(testBooking(1), 50),
+	}
+	entries = append(entries, Reversals(entries)...)
+
+	// 第二次冲销时净额为0，不再追加流水
+	if again := Reversals(entries); len(again) != 0 {
+		t.Errorf("second Reversals returned %d entries, want 0", len(again))
+	}
+	if again := Reversals(nil); len(again) != 0 {
+		t.Errorf("Reversals(nil) returned %d entries, want 0", len(again))
+	}
+}
+
+func TestReversals_NegativeBalance(t *testing.T) {
+	// 订单1获得 100 积分，订单2用掉 80 积分，之后订单1退款
+	earned := NewEarnEntry(testBooking(1), 100)
+	ledger := []*Entry{earned, NewRedeemEntry(testBooking(2), 80)}
+	ledger = append(ledger, Reversals([]*Entry{earned})...)
+
+	if account := sumAccount(ledger); account.Balance != -80 || account.QualifyingPoints != 0 {
+		t.Errorf("account after refund = %+v, want balance -80, qualifying 0", account)
+	}
+
+	// 之后获得的积分先抵平负数
+	ledger = append(ledger, NewEarnEntry(testBooking(3), 30))
+	if account := sumAccount(ledger); account.Balance != -50 || account.QualifyingPoints != 30 {
+		t.Errorf("account after next earn = %+v, want balance -50, qualifying 30", account)
+	}
+}
@@ -0,0 +1,154 @@
+package loyalty
+
+import (
+	"fmt"
+	"math"
+	"mrs/internal/domain/booking"
+	"sort"
+	"strings"
+	"time"
+)
+
+// 会员等级：等级积分达到 MinPoints 即升级，等级享受票价折扣与提前购票
+type Tier struct {
+	Name         string
+	MinPoints    int           // 达到该等级所需的等级积分
+	Discount     float64       // 票面金额的折扣比例（0~1）
+	EarlyBooking time.Duration // 比普通购票窗口提前开放的时间
+}
+
+// 积分规则与等级
+type Program struct {
+	EarnRate       float64       // 每实付1元获得的积分，向下取整
+	PointValue     float64       // 每积分抵扣的金额
+	MaxRedeemRatio float64       // 积分最多抵扣等级折扣后金额的比例（0~1）
+	BookingWindow  time.Duration // 场次开始前多久开放购票，0表示不限制（此时提前购票不生效）
+
+	tiers []*Tier // 按 MinPoints 升序，第一个等级的 MinPoints 为0
+}
+
+func NewProgram(earnRate, pointValue, maxRedeemRatio float64, bookingWindow time.Duration, tiers []*Tier) (*Program, error) {
+	if earnRate < 0 || pointValue < 0 || maxRedeemRatio < 0 || maxRedeemRatio > 1 || bookingWindow < 0 {
+		return nil, fmt.Errorf("%w: invalid earn rate, point value, redeem ratio or booking window", ErrInvalidLoyaltyProgram)
+	}
+	if len(tiers) == 0 {
+		return nil, fmt.Errorf("%w: no tiers", ErrInvalidLoyaltyProgram)
+	}
+
+	sorted := append([]*Tier(nil), tiers...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].MinPoints < sorted[j].MinPoints
+	})
+	if sorted[0].MinPoints != 0 {
+		return nil, fmt.Errorf("%w: the lowest tier must start at 0 points", ErrInvalidLoyaltyProgram)
+	}
+	names := make(map[string]struct{}, len(sorted))
+	for i, tier := range sorted {
+		key := strings.ToLower(strings.TrimSpace(tier.Name))
+		if key == "" || tier.Discount < 0 || tier.Discount >= 1 || tier.EarlyBooking < 0 {
+			return nil, fmt.Errorf("%w: invalid tier %q", ErrInvalidLoyaltyProgram, tier.Name)
+		}
+		if _, ok := names[key]; ok {
+			return nil, fmt.Errorf("%w: duplicate tier %q", ErrInvalidLoyaltyProgram, tier.Name)
+		}
+		if i > 0 && tier.MinPoints == sorted[i-1].MinPoints {
+			return nil, fmt.Errorf("%w: tiers %q and %q have the same threshold", ErrInvalidLoyaltyProgram, sorted[i-1].Name, tier.Name)
+		}
+		names[key] = struct{}{}
+	}
+
+	return &Program{
+		EarnRate:       earnRate,
+		PointValue:     pointValue,
+		MaxRedeemRatio: maxRedeemRatio,
+		BookingWindow:  bookingWindow,
+		tiers:          sorted,
+	}, nil
+}
+
+// 全部等级（按门槛升序）
+func (p *Program) Tiers() []*Tier {
+	return p.tiers
+}
+
+// 等级积分对应的等级
+func (p *Program) TierFor(qualifyingPoints int) *Tier {
+	tier := p.tiers[0]
+	for _, t := range p.tiers[1:] {
+		if qualifyingPoints < t.MinPoints {
+			break
+		}
+		tier = t
+	}
+	return tier
+}
+
+// 下一等级，已是最高等级时返回nil
+func (p *Program) NextTier(tier *Tier) *Tier {
+	for _, t := range p.tiers {
+		if t.MinPoints > tier.MinPoints {
+			return t
+		}
+	}
+	return nil
+}
+
+// 实付金额获得的积分
+func (p *Program) EarnPoints(amount float64) int {
+	if amount <= 0 {
+		return 0
+	}
+	return int(math.Floor(amount*p.EarnRate + 1e-9))
+}
+
+// 可抵扣的积分上限
+func (p *Program) MaxRedeemablePoints(payable float64) int {
+	if p.PointValue <= 0 || payable <= 0 {
+		return 0
+	}
+	return int(math.Floor(payable*p.MaxRedeemRatio/p.PointValue + 1e-9))
+}
+
+// 计算订单优惠：先按等级折扣，再用积分抵扣剩余金额
+func (p *Program) Quote(account *Account, gross float64, points int) (*booking.Discount, error) {
+	tier := p.TierFor(account.QualifyingPoints)
+	tierDiscount := roundAmount(gross * tier.Discount)
+	payable := gross - tierDiscount
+
+	// 冲销后余额可能为负，不抵扣积分时不检查余额
+	if points > 0 && points > account.Balance {
+		return nil, fmt.Errorf("%w: balance %d, requested %d", ErrInsufficientPoints, account.Balance, points)
+	}
+	if limit := p.MaxRedeemablePoints(payable); points > limit {
+		return nil, fmt.Errorf("%w: at most %d points", ErrRedemptionLimitExceeded, limit)
+	}
+
+	return &booking.Discount{
+		Tier:           tier.Name,
+		TierDiscount:   tierDiscount,
+		PointsRedeemed: points,
+		PointsDiscount: roundAmount(float64(points) * p.PointValue),
+	}, nil
+}
+
+// 该等级可以开始购票的时间，不限制购票窗口时返回零值
+func (p *Program) BookingOpensAt(tier *Tier, showtimeStart time.Time) time.Time {
+	if p.BookingWindow <= 0 {
+		return time.Time{}
+	}
+	return showtimeStart.Add(-(p.BookingWindow + tier.EarlyBooking))
+}
+
+// 检查该等级在 now 是否可以购买场次的票
+func (p *Program) CheckBookingWindow(tier *Tier, showtimeStart, now time.Time) error {
+	opensAt := p.BookingOpensAt(tier, showtimeStart)
+	if !opensAt.IsZero() && now.Before(opensAt) {
+		return fmt.Errorf("%w: opens at %s for tier %s", ErrBookingNotOpen, opensAt.Format(time.DateTime), tier.Name)
+	}
+	return nil
+}
+
+// 金额保留两位小数
+func roundAmount(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,178 @@
+package loyalty
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestProgram(t *testing.T, bookingWindow time.Duration) *Program {
+	t.Helper()
+	// 故意乱序传入，NewProgram 按门槛排序
+	program, err := NewProgram(1, 0.01, 0.5, bookingWindow, []*Tier{
+		{Name: "Gold", MinPoints: 1000, Discount: 0.05, EarlyBooking: 24 * time.Hour},
+		{Name: "Silver", MinPoints: 0},
+		{Name: "Platinum", MinPoints: 5000, Discount: 0.1, EarlyBooking: 48 * time.Hour},
+	})
+	if err != nil {
+		t.Fatalf("NewProgram failed: %v", err)
+	}
+	return program
+}
+
+func TestNewProgram_Invalid(t *testing.T) {
+	base := func() []*Tier { return []*Tier{{Name: "Silver"}, {Name: "Gold", MinPoints: 1000}} }
+	tests := []struct {
+		name           string
+		earnRate       float64
+		maxRedeemRatio float64
+		tiers          []*Tier
+	}{
+		{"negative earn rate", -1, 0.5, base()},
+		{"redeem ratio above 1", 1, 1.5, base()},
+		{"no tiers", 1, 0.5, nil},
+		{"lowest tier above 0", 1, 0.5, []*Tier{{Name: "Gold", MinPoints: 1000}}},
+		{"duplicate names", 1, 0.5, []*Tier{{Name: "Silver"}, {Name: " silver ", MinPoints: 10}}},
+		{"same threshold", 1, 0.5, []*Tier{{Name: "Silver"}, {Name: "Gold", MinPoints: 10}, {Name: "VIP", MinPoints: 10}}},
+		{"full discount", 1, 0.5, []*Tier{{Name: "Silver", Discount: 1}}},
+		{"empty name", 1, 0.5, []*Tier{{Name: " "}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewProgram(tt.earnRate, 0.01, tt.maxRedeemRatio, 0, tt.tiers)
+			if !errors.Is(err, ErrInvalidLoyaltyProgram) {
+				t.Errorf("NewProgram error = %v, want %v", err, ErrInvalidLoyaltyProgram)
+			}
+		})
+	}
+}
+
+func TestProgram_TierFor(t *testing.T) {
+	program := newTestProgram(t, 0)
+	tests := []struct {
+		points int
+		want   string
+	}{
+		{-100, "Silver"}, // 冲销后等级积分可能为负
+		{0, "Silver"},
+		{999, "Silver"},
+		{1000, "Gold"},
+		{4999, "Gold"},
+		{5000, "Platinum"},
+		{1000000, "Platinum"},
+	}
+	for _, tt := range tests {
+		if got := program.TierFor(tt.points); got.Name != tt.want {
+			t.Errorf("TierFor(%d) = %s, want %s", tt.points, got.Name, tt.want)
+		}
+	}
+
+	if next := program.NextTier(program.TierFor(0)); next == nil || next.Name != "Gold" {
+		t.Errorf("NextTier(Silver) = %v, want Gold", next)
+	}
+	if next := program.NextTier(program.TierFor(5000)); next != nil {
+		t.Errorf("NextTier(Platinum) = %v, want nil", next)
+	}
+}
+
+func TestProgram_EarnPoints(t *testing.T) {
+	program := newTestProgram(t, 0)
+	tests := []struct {
+		earnRate float64
+		amount   float64
+		want     int
+	}{
+		{1, 99.99, 99},
+		{1, 100, 100},
+		{1, 0.5, 0},
+		{1, 0, 0},
+		{1, -10, 0},
+		{0.1, 30, 3},
+		// 0.29*100 的浮点结果略小于 29，不应向下取整为 28
+		{100, 0.29, 29},
+	}
+	for _, tt := range tests {
+		program.EarnRate = tt.earnRate
+		if got := program.EarnPoints(tt.amount); got != tt.want {
+			t.Errorf("EarnPoints(%v) with rate %v = %d, want %d", tt.amount, tt.earnRate, got, tt.want)
+		}
+	}
+}
+
+func TestProgram_Quote(t *testing.T) {
+	program := newTestProgram(t, 0)
+	tests := []struct {
+		name    string
+		account Account
+		gross   float64
+		points  int
+		tier    string
+		tierOff float64
+		ptsOff  float64
+		wantErr error
+	}{
+		{name: "no discount", account: Account{Balance: 0}, gross: 100, tier: "Silver"},
+		{name: "tier discount rounded down", account: Account{QualifyingPoints: 5000}, gross: 12.34, tier: "Platinum", tierOff: 1.23},
+		{name: "tier discount rounded up", account: Account{QualifyingPoints: 1000}, gross: 12.36, tier: "Gold", tierOff: 0.62},
+		{name: "points discount", account: Account{Balance: 333}, gross: 100, points: 333, tier: "Silver", ptsOff: 3.33},
+		// 积分上限按等级折扣后的金额计算：(100-10)*0.5/0.01 = 4500
+		{name: "redeem cap after tier discount", account: Account{Balance: 10000, QualifyingPoints: 5000}, gross: 100, points: 4500, tier: "Platinum", tierOff: 10, ptsOff: 45},
+		{name: "redeem cap exceeded", account: Account{Balance: 10000, QualifyingPoints: 5000}, gross: 100, points: 4501, wantErr: ErrRedemptionLimitExceeded},
+		{name: "cap before tier discount does not apply", account: Account{Balance: 10000, QualifyingPoints: 5000}, gross: 100, points: 5000, wantErr: ErrRedemptionLimitExceeded},
+		{name: "insufficient points", account: Account{Balance: 100}, gross: 100, points: 101, wantErr: ErrInsufficientPoints},
+		{name: "negative balance", account: Account{Balance: -50}, gross: 100, points: 1, wantErr: ErrInsufficientPoints},
+		{name: "negative balance without redemption", account: Account{Balance: -50}, gross: 100, tier: "Silver"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discount, err := program.Quote(&tt.account, tt.gross, tt.points)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Quote error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Quote failed: %v", err)
+			}
+			if discount.Tier != tt.tier || discount.TierDiscount != tt.tierOff ||
+				discount.PointsRedeemed != tt.points || discount.PointsDiscount != tt.ptsOff {
+				t.Errorf("Quote = %+v, want tier %s, tier discount %v, points %d, points discount %v",
+					discount, tt.tier, tt.tierOff, tt.points, tt.ptsOff)
+			}
+		})
+	}
+}
+
+func TestProgram_CheckBookingWindow(t *testing.T) {
+	start := time.Date(2026, 10, 25, 20, 0, 0, 0, time.UTC)
+	program := newTestProgram(t, 7*24*time.Hour)
+	silver, gold, platinum := program.TierFor(0), program.TierFor(1000), program.TierFor(5000)
+
+	tests := []struct {
+		name    string
+		program *Program
+		tier    *Tier
+		now     time.Time
+		wantErr bool
+	}{
+		{"silver before window", program, silver, start.Add(-7*24*time.Hour - time.Minute), true},
+		{"silver at window", program, silver, start.Add(-7 * 24 * time.Hour), false},
+		{"gold one day early", program, gold, start.Add(-8 * 24 * time.Hour), false},
+		{"gold before early window", program, gold, start.Add(-8*24*time.Hour - time.Minute), true},
+		{"platinum two days early", program, platinum, start.Add(-9 * 24 * time.Hour), false},
+		{"after showtime start", program, silver, start.Add(time.Hour), false},
+		{"unlimited window", newTestProgram(t, 0), silver, start.AddDate(-1, 0, 0), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.program.CheckBookingWindow(tt.tier, start, tt.now)
+			if tt.wantErr && !errors.Is(err, ErrBookingNotOpen) {
+				t.Errorf("CheckBookingWindow error = %v, want %v", err, ErrBookingNotOpen)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("CheckBookingWindow error = %v, want nil", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package movie
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 年龄分级对应的观影年龄要求
+type AgeRequirement struct {
+	Rating            string // 分级名称，如 PG-13
+	MinAge            int    // 独立观影的最低年龄，0表示不限
+	Accompanied       bool   // 是否允许未达年龄者在成人陪同下观影
+	AccompaniedMinAge int    // 成人陪同时的最低年龄（仅 Accompanied 为 true 时有效）
+}
+
+// 是否存在年龄限制
+func (r *AgeRequirement) Restricted() bool {
+	return r != nil && r.MinAge > 0
+}
+
+// 下单时的年龄核验结果
+type AgeCheck string
+
+const (
+	AgeCheckNotRequired AgeCheck = "not_required" // 电影无年龄限制
+	AgeCheckPassed      AgeCheck = "passed"       // 已达独立观影年龄
+	AgeCheckAccompanied AgeCheck = "accompanied"  // 需成人陪同观影
+	AgeCheckUnverified  AgeCheck = "unverified"   // 用户未登记出生日期，需检票时核验证件
+	AgeCheckUnderAge    AgeCheck = "under_age"    // 未达年龄要求
+)
+
+// 是否需要检票员在入场时核验
+func (c AgeCheck) NeedsVerification() bool {
+	return c == AgeCheckAccompanied || c == AgeCheckUnverified || c == AgeCheckUnderAge
+}
+
+// 根据观影时的年龄判断是否满足要求，ageKnown为false表示用户未登记出生日期
+func (r *AgeRequirement) Check(age int, ageKnown bool) AgeCheck {
+	switch {
+	case !r.Restricted():
+		return AgeCheckNotRequired
+	case !ageKnown:
+		return AgeCheckUnverified
+	case age >= r.MinAge:
+		return AgeCheckPassed
+	case r.Accompanied && age >= r.AccompaniedMinAge:
+		return AgeCheckAccompanied
+	}
+	return AgeCheckUnderAge
+}
+
+// 未达年龄时的处理方式
+type AgeEnforcement string
+
+const (
+	AgeEnforcementReject AgeEnforcement = "reject" // 拒绝下单
+	AgeEnforcementFlag   AgeEnforcement = "flag"   // 允许下单，标记后由检票员核验
+)
+
+func (e AgeEnforcement) IsValid() bool {
+	return e == AgeEnforcementReject || e == AgeEnforcementFlag
+}
+
+// 年龄分级策略：分级 -> 年龄要求对照表
+type AgeRatingPolicy struct {
+	Enforcement  AgeEnforcement
+	requirements map[string]*AgeRequirement
+}
+
+func NewAgeRatingPolicy(enforcement AgeEnforcement, requirements []*AgeRequirement) (*AgeRatingPolicy, error) {
+	if !enforcement.IsValid() {
+		return nil, fmt.Errorf("%w: unknown enforcement %q", ErrInvalidAgeRating, enforcement)
+	}
+	policy := &AgeRatingPolicy{
+		Enforcement:  enforcement,
+		requirements: make(map[string]*AgeRequirement, len(requirements)),
+	}
+	for _, req := range requirements {
+		key := NormalizeAgeRating(req.Rating)
+		if key == "" || req.MinAge < 0 || (req.Accompanied && (req.AccompaniedMinAge < 0 || req.AccompaniedMinAge > req.MinAge)) {
+			return nil, fmt.Errorf("%w: invalid requirement for %q", ErrInvalidAgeRating, req.Rating)
+		}
+		if _, ok := policy.requirements[key]; ok {
+			return nil, fmt.Errorf("%w: duplicate rating %q", ErrInvalidAgeRating, req.Rating)
+		}
+		policy.requirements[key] = req
+	}
+	return policy, nil
+}
+
+// 分级名称不区分大小写
+func NormalizeAgeRating(rating string) string {
+	return strings.ToUpper(strings.TrimSpace(rating))
+}
+
+// 查询分级对应的年龄要求；分级为空时返回nil，未配置的分级返回 ErrInvalidAgeRating
+func (p *AgeRatingPolicy) Requirement(rating string) (*AgeRequirement, error) {
+	key := NormalizeAgeRating(rating)
+	if key == "" {
+		return nil, nil
+	}
+	req, ok := p.requirements[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidAgeRating, rating)
+	}
+	return req, nil
+}
+
+// 校验电影的分级是否在对照表中（允许为空）
+func (p *AgeRatingPolicy) ValidateRating(rating string) error {
+	_, err := p.Requirement(rating)
+	return err
+}
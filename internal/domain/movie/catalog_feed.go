@@ -0,0 +1,373 @@
+package movie
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// 关于片单导入：支持两种格式，字段名一致
+//   - json: 对象数组，genres 为字符串数组
+//   - csv: 首行为表头（列顺序任意，title 与 release_date 必须存在），genres 以 | 分隔
+//
+// release_date 支持 2006-01-02 与 RFC3339 两种写法（只取日期部分）；以标题匹配已有电影（忽略大小写），
+// 片单中未填写的可选字段（描述、片长、演员、海报、分级）保持原值不变
+
+// 片单格式
+type CatalogFormat string
+
+const (
+	CatalogFormatJSON CatalogFormat = "json"
+	CatalogFormatCSV  CatalogFormat = "csv"
+)
+
+// csv 格式中类型名称的分隔符
+const catalogGenreSeparator = "|"
+
+// 片单字段名（同时用作 csv 表头与差异报告中的字段名）
+const (
+	CatalogFieldTitle           = "title"
+	CatalogFieldDescription     = "description"
+	CatalogFieldReleaseDate     = "release_date"
+	CatalogFieldDurationMinutes = "duration_minutes"
+	CatalogFieldGenres          = "genres"
+	CatalogFieldCast            = "cast"
+	CatalogFieldPosterURL       = "poster_url"
+	CatalogFieldAgeRating       = "age_rating"
+)
+
+var catalogColumns = []string{
+	CatalogFieldTitle, CatalogFieldDescription, CatalogFieldReleaseDate, CatalogFieldDurationMinutes,
+	CatalogFieldGenres, CatalogFieldCast, CatalogFieldPosterURL, CatalogFieldAgeRating,
+}
+
+// 解析片单格式
+func ParseCatalogFormat(s string) (CatalogFormat, error) {
+	switch f := CatalogFormat(strings.ToLower(s)); f {
+	case CatalogFormatJSON, CatalogFormatCSV:
+		return f, nil
+	default:
+		return "", fmt.Errorf("%w: unsupported format %q", ErrInvalidCatalogFeed, s)
+	}
+}
+
+// 片单中的一条电影记录
+type CatalogEntry struct {
+	Row             int // 在源文件中的位置：csv 为行号（表头为第1行），json 为数组下标（从1开始）
+	Title           string
+	Description     string
+	ReleaseDate     time.Time
+	DurationMinutes int
+	GenreNames      []string
+	Cast            string
+	PosterURL       string
+	AgeRating       string
+	Err             error // 解析或校验失败的原因，非空时该记录不会导入
+}
+
+// 字段差异，用于试运行报告
+type CatalogFieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// json 格式的记录
+type catalogRecord struct {
+	Title           string   `json:"title"`
+	Description     string   `json:"description"`
+	ReleaseDate     string   `json:"release_date"`
+	DurationMinutes int      `json:"duration_minutes"`
+	Genres          []string `json:"genres"`
+	Cast            string   `json:"cast"`
+	PosterURL       string   `json:"poster_url"`
+	AgeRating       string   `json:"age_rating"`
+}
+
+// 解析片单。文件整体无法解析时返回错误；单条记录的问题记录在 CatalogEntry.Err 中，不影响其他记录
+func DecodeCatalog(format CatalogFormat, data []byte) ([]*CatalogEntry, error) {
+	var entries []*CatalogEntry
+	var err error
+	switch format {
+	case CatalogFormatJSON:
+		entries, err = decodeCatalogJSON(data)
+	case CatalogFormatCSV:
+		entries, err = decodeCatalogCSV(data)
+	default:
+		return nil, fmt.Errorf("%w: unsupported format %q", ErrInvalidCatalogFeed, format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%w: no records", ErrInvalidCatalogFeed)
+	}
+
+	// 同一片单中标题重复时，仅导入第一条（与按标题匹配已有电影一致，忽略大小写）
+	firstRows := make(map[string]int, len(entries))
+	for _, entry := range entries {
+		if entry.Err != nil {
+			continue
+		}
+		key := strings.ToLower(entry.Title)
+		if row, ok := firstRows[key]; ok {
+			entry.Err = fmt.Errorf("%w: duplicate title, first seen at row %d", ErrInvalidCatalogEntry, row)
+			continue
+		}
+		firstRows[key] = entry.Row
+	}
+	return entries, nil
+}
+
+func decodeCatalogJSON(data []byte) ([]*CatalogEntry, error) {
+	// 先拆分为原始记录，单条记录类型错误时只影响该记录
+	var raws []json.RawMessage
+	if err := json.Unmarshal(data, &raws); err != nil {
+		return nil, fmt.Errorf("%w: expected a JSON array of movies: %w", ErrInvalidCatalogFeed, err)
+	}
+
+	entries := make([]*CatalogEntry, 0, len(raws))
+	for i, raw := range raws {
+		var record catalogRecord
+		if err := json.Unmarshal(raw, &record); err != nil {
+			entries = append(entries, &CatalogEntry{
+				Row: i + 1,
+				Err: fmt.Errorf("%w: %w", ErrInvalidCatalogEntry, err),
+			})
+			continue
+		}
+		entries = append(entries, newCatalogEntry(i+1, &record))
+	}
+	return entries, nil
+}
+
+func decodeCatalogCSV(data []byte) ([]*CatalogEntry, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read csv header: %w", ErrInvalidCatalogFeed, err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !slices.Contains(catalogColumns, name) {
+			return nil, fmt.Errorf("%w: unknown csv column %q", ErrInvalidCatalogFeed, name)
+		}
+		columns[name] = i
+	}
+	for _, required := range []string{CatalogFieldTitle, CatalogFieldReleaseDate} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("%w: missing csv column %q", ErrInvalidCatalogFeed, required)
+		}
+	}
+
+	entries := make([]*CatalogEntry, 0)
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil && !errors.Is(err, csv.ErrFieldCount) {
+			// 引号等语法错误无法继续定位后续记录，整个文件视为无效
+			return nil, fmt.Errorf("%w: %w", ErrInvalidCatalogFeed, err)
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			// 列数不一致只影响当前行
+			entries = append(entries, &CatalogEntry{
+				Row: line,
+				Err: fmt.Errorf("%w: expected %d columns, got %d", ErrInvalidCatalogEntry, len(header), len(fields)),
+			})
+			continue
+		}
+
+		value := func(name string) string {
+			if i, ok := columns[name]; ok {
+				return strings.TrimSpace(fields[i])
+			}
+			return ""
+		}
+		record := &catalogRecord{
+			Title:       value(CatalogFieldTitle),
+			Description: value(CatalogFieldDescription),
+			ReleaseDate: value(CatalogFieldReleaseDate),
+			Cast:        value(CatalogFieldCast),
+			PosterURL:   value(CatalogFieldPosterURL),
+			AgeRating:   value(CatalogFieldAgeRating),
+		}
+		if genres := value(CatalogFieldGenres); genres != "" {
+			record.Genres = strings.Split(genres, catalogGenreSeparator)
+		}
+		if duration := value(CatalogFieldDurationMinutes); duration != "" {
+			minutes, err := strconv.Atoi(duration)
+			if err != nil {
+				entries = append(entries, &CatalogEntry{
+					Row:   line,
+					Title: record.Title,
+					Err:   fmt.Errorf("%w: %s must be an integer", ErrInvalidCatalogEntry, CatalogFieldDurationMinutes),
+				})
+				continue
+			}
+			record.DurationMinutes = minutes
+		}
+		entries = append(entries, newCatalogEntry(line, record))
+	}
+	return entries, nil
+}
+
+// 规范化并校验单条记录
+func newCatalogEntry(row int, record *catalogRecord) *CatalogEntry {
+	entry := &CatalogEntry{
+		Row:             row,
+		Title:           strings.TrimSpace(record.Title),
+		Description:     strings.TrimSpace(record.Description),
+		DurationMinutes: record.DurationMinutes,
+		Cast:            strings.TrimSpace(record.Cast),
+		PosterURL:       strings.TrimSpace(record.PosterURL),
+		AgeRating:       strings.TrimSpace(record.AgeRating),
+	}
+
+	// 类型名称去空白、去重
+	for _, name := range record.Genres {
+		name = strings.TrimSpace(name)
+		if name != "" && !slices.Contains(entry.GenreNames, name) {
+			entry.GenreNames = append(entry.GenreNames, name)
+		}
+	}
+
+	problems := make([]string, 0)
+	if entry.Title == "" {
+		problems = append(problems, "title is required")
+	} else if utf8.RuneCountInString(entry.Title) > 255 {
+		problems = append(problems, "title must be at most 255 characters")
+	}
+	if releaseDate, err := parseCatalogDate(record.ReleaseDate); err != nil {
+		problems = append(problems, err.Error())
+	} else {
+		entry.ReleaseDate = releaseDate
+	}
+	if entry.DurationMinutes < 0 {
+		problems = append(problems, "duration_minutes must not be negative")
+	}
+	if len(entry.GenreNames) == 0 {
+		problems = append(problems, "at least one genre is required")
+	}
+	if utf8.RuneCountInString(entry.Description) > 1000 {
+		problems = append(problems, "description must be at most 1000 characters")
+	}
+	if utf8.RuneCountInString(entry.Cast) > 1000 {
+		problems = append(problems, "cast must be at most 1000 characters")
+	}
+	if utf8.RuneCountInString(entry.AgeRating) > 50 {
+		problems = append(problems, "age_rating must be at most 50 characters")
+	}
+	if entry.PosterURL != "" {
+		if u, err := url.ParseRequestURI(entry.PosterURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			problems = append(problems, "poster_url must be an absolute http(s) URL")
+		}
+	}
+
+	if len(problems) > 0 {
+		entry.Err = fmt.Errorf("%w: %s", ErrInvalidCatalogEntry, strings.Join(problems, "; "))
+	}
+	return entry
+}
+
+func parseCatalogDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, errors.New("release_date is required")
+	}
+	// 上映日期只保留日期部分，按本地时区的零点保存，与从数据库读回的日期一致
+	if t, err := time.ParseInLocation(time.DateOnly, s, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local), nil
+	}
+	return time.Time{}, fmt.Errorf("release_date %q must be YYYY-MM-DD or RFC3339", s)
+}
+
+// 转换为电影实体（类型由应用层通过 FindOrCreateByNames 关联）
+func (e *CatalogEntry) ToMovie() *Movie {
+	return &Movie{
+		Title:           e.Title,
+		Description:     e.Description,
+		ReleaseDate:     e.ReleaseDate,
+		DurationMinutes: e.DurationMinutes,
+		PosterURL:       e.PosterURL,
+		AgeRating:       e.AgeRating,
+		Cast:            e.Cast,
+	}
+}
+
+// 计算导入后相对已有电影的字段差异；existing 为 nil 表示新建，列出全部已填写字段
+func (e *CatalogEntry) Diff(existing *Movie) []*CatalogFieldChange {
+	if existing == nil {
+		existing = &Movie{}
+	}
+	changes := make([]*CatalogFieldChange, 0)
+	add := func(field, old, next string) {
+		if old != next {
+			changes = append(changes, &CatalogFieldChange{Field: field, Old: old, New: next})
+		}
+	}
+	// 可选字段为空时保持原值，不计入差异
+	addOptional := func(field, old, next string) {
+		if next != "" {
+			add(field, old, next)
+		}
+	}
+
+	add(CatalogFieldTitle, existing.Title, e.Title)
+	addOptional(CatalogFieldDescription, existing.Description, e.Description)
+	add(CatalogFieldReleaseDate, formatCatalogDate(existing.ReleaseDate), formatCatalogDate(e.ReleaseDate))
+	if e.DurationMinutes > 0 {
+		add(CatalogFieldDurationMinutes, formatCatalogInt(existing.DurationMinutes), formatCatalogInt(e.DurationMinutes))
+	}
+	if !e.SameGenres(existing) {
+		add(CatalogFieldGenres, existing.GenreText(), strings.Join(e.GenreNames, ", "))
+	}
+	addOptional(CatalogFieldCast, existing.Cast, e.Cast)
+	addOptional(CatalogFieldPosterURL, existing.PosterURL, e.PosterURL)
+	addOptional(CatalogFieldAgeRating, existing.AgeRating, e.AgeRating)
+	return changes
+}
+
+// 类型集合是否与已有电影一致（忽略顺序）
+func (e *CatalogEntry) SameGenres(existing *Movie) bool {
+	if len(e.GenreNames) != len(existing.Genres) {
+		return false
+	}
+	for _, genre := range existing.Genres {
+		if !slices.Contains(e.GenreNames, genre.Name) {
+			return false
+		}
+	}
+	return true
+}
+
+func formatCatalogDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.DateOnly)
+}
+
+func formatCatalogInt(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
@@ -0,0 +1,315 @@
+package movie
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCatalogFormat(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    CatalogFormat
+		wantErr bool
+	}{
+		{"json", CatalogFormatJSON, false},
+		{"CSV", CatalogFormatCSV, false},
+		{"xml", "", true},
+		{"", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseCatalogFormat(tt.input)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidCatalogFeed) {
+				t.Errorf("ParseCatalogFormat(%q) error = %v, want %v", tt.input, err, ErrInvalidCatalogFeed)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseCatalogFormat(%q) = %q, %v, want %q", tt.input, got, err, tt.want)
+		}
+	}
+}
+
+func TestDecodeCatalog_CSV(t *testing.T) {
+	// 带 BOM，表头大小写与顺序任意，省略部分可选列
+	input := "\ufeffRelease_Date, Title ,genres,duration_minutes,poster_url\n" +
+		"2024-03-01,沙丘2, 科幻 | 冒险 |科幻,166,https://example.com/dune.jpg\n" +
+		"2024-05-20,\"Title, with comma\",剧情,,\n"
+	entries, err := DecodeCatalog(CatalogFormatCSV, []byte(input))
+	if err != nil {
+		t.Fatalf("DecodeCatalog failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("DecodeCatalog returned %d entries, want 2", len(entries))
+	}
+
+	first := entries[0]
+	if first.Err != nil {
+		t.Fatalf("entry 1 error = %v", first.Err)
+	}
+	if first.Row != 2 || first.Title != "沙丘2" || first.DurationMinutes != 166 || first.PosterURL != "https://example.com/dune.jpg" {
+		t.Errorf("entry 1 = %+v", first)
+	}
+	// 类型按 | 拆分、去空白并去重
+	if got := strings.Join(first.GenreNames, ","); got != "科幻,冒险" {
+		t.Errorf("entry 1 genres = %s, want 科幻,冒险", got)
+	}
+	if want := time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local); !first.ReleaseDate.Equal(want) {
+		t.Errorf("entry 1 release date = %v, want %v", first.ReleaseDate, want)
+	}
+
+	second := entries[1]
+	if second.Err != nil || second.Row != 3 || second.Title != "Title, with comma" || second.DurationMinutes != 0 {
+		t.Errorf("entry 2 = %+v, err %v", second, second.Err)
+	}
+}
+
+func TestDecodeCatalog_CSVFeedErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"empty file", "", "failed to read csv header"},
+		{"unknown column", "title,release_date,rating\n", `unknown csv column "rating"`},
+		{"missing release_date", "title,genres\nA,剧情\n", `missing csv column "release_date"`},
+		{"missing title", "release_date,genres\n2024-01-01,剧情\n", `missing csv column "title"`},
+		{"header only", "title,release_date,genres\n", "no records"},
+		{"broken quote", "title,release_date,genres\n\"A\"B,2024-01-01,剧情\n", `extraneous or missing " in quoted-field`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeCatalog(CatalogFormatCSV, []byte(tt.input))
+			if !errors.Is(err, ErrInvalidCatalogFeed) || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("DecodeCatalog error = %v, want %v containing %q", err, ErrInvalidCatalogFeed, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeCatalog_EntryErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string // 为空表示该记录合法
+	}{
+		{"valid", "A,2024-01-01,剧情,90", ""},
+		{"rfc3339 date", "A,2024-01-01T20:00:00+08:00,剧情,90", ""},
+		{"missing title", ",2024-01-01,剧情,90", "title is required"},
+		{"long title", strings.Repeat("长", 256) + ",2024-01-01,剧情,90", "title must be at most 255 characters"},
+		{"missing date", "A,,剧情,90", "release_date is required"},
+		{"invalid date", "A,01/02/2024,剧情,90", `release_date "01/02/2024" must be YYYY-MM-DD or RFC3339`},
+		{"non-numeric duration", "A,2024-01-01,剧情,ninety", "duration_minutes must be an integer"},
+		{"negative duration", "A,2024-01-01,剧情,-1", "duration_minutes must not be negative"},
+		{"no genres", "A,2024-01-01, | ,90", "at least one genre is required"},
+		{"field count", "A,2024-01-01,剧情", "expected 4 columns, got 3"},
+		// 一条记录的多个问题一并报告
+		{"all problems", ",bad,,-5", "title is required; release_date \"bad\" must be YYYY-MM-DD or RFC3339; duration_minutes must not be negative; at least one genre is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := "title,release_date,genres,duration_minutes\n" + tt.input + "\n"
+			entries, err := DecodeCatalog(CatalogFormatCSV, []byte(input))
+			if err != nil {
+				t.Fatalf("DecodeCatalog failed: %v", err)
+			}
+			entry := entries[0]
+			if entry.Row != 2 {
+				t.Errorf("row = %d, want 2", entry.Row)
+			}
+			if tt.want == "" {
+				if entry.Err != nil {
+					t.Errorf("entry error = %v, want nil", entry.Err)
+				}
+				return
+			}
+			if !errors.Is(entry.Err, ErrInvalidCatalogEntry) || !strings.Contains(entry.Err.Error(), tt.want) {
+				t.Errorf("entry error = %v, want %v containing %q", entry.Err, ErrInvalidCatalogEntry, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeCatalog_JSON(t *testing.T) {
+	input := `[
+		{"title": " Dune ", "release_date": "2021-10-22", "genres": ["科幻", " 冒险 ", ""], "duration_minutes": 155,
+		 "description": "沙丘", "cast": "Timothée Chalamet", "age_rating": "PG-13"},
+		{"title": "Bad", "release_date": "2021-10-22", "genres": ["科幻"], "duration_minutes": "long"},
+		{"title": "No Poster Scheme", "release_date": "2021-10-22", "genres": ["科幻"], "poster_url": "example.com/a.jpg"},
+		{"title": "dune", "release_date": "2021-10-22", "genres": ["科幻"]},
+		{"title": "DUNE ", "release_date": "2021-10-22", "genres": ["科幻"]}
+	]`
+	entries, err := DecodeCatalog(CatalogFormatJSON, []byte(input))
+	if err != nil {
+		t.Fatalf("DecodeCatalog failed: %v", err)
+	}
+	if len(entries) != 5 {
+		t.Fatalf("DecodeCatalog returned %d entries, want 5", len(entries))
+	}
+
+	dune := entries[0]
+	if dune.Err != nil || dune.Row != 1 || dune.Title != "Dune" || dune.DurationMinutes != 155 ||
+		dune.Description != "沙丘" || dune.Cast != "Timothée Chalamet" || dune.AgeRating != "PG-13" {
+		t.Errorf("entry 1 = %+v, err %v", dune, dune.Err)
+	}
+	if got := strings.Join(dune.GenreNames, ","); got != "科幻,冒险" {
+		t.Errorf("entry 1 genres = %s, want 科幻,冒险", got)
+	}
+
+	wantErrs := []string{
+		"",
+		"cannot unmarshal string",
+		"poster_url must be an absolute http(s) URL",
+		// 标题比较忽略大小写，与按标题匹配已有电影一致
+		"duplicate title, first seen at row 1",
+		"duplicate title, first seen at row 1",
+	}
+	for i, want := range wantErrs {
+		entry := entries[i]
+		if entry.Row != i+1 {
+			t.Errorf("entry %d row = %d, want %d", i+1, entry.Row, i+1)
+		}
+		if want == "" {
+			continue
+		}
+		if !errors.Is(entry.Err, ErrInvalidCatalogEntry) || !strings.Contains(entry.Err.Error(), want) {
+			t.Errorf("entry %d error = %v, want %v containing %q", i+1, entry.Err, ErrInvalidCatalogEntry, want)
+		}
+	}
+}
+
+func TestDecodeCatalog_JSONFeedErrors(t *testing.T) {
+	for _, input := range []string{`{"title": "A"}`, `[`, `[]`} {
+		if _, err := DecodeCatalog(CatalogFormatJSON, []byte(input)); !errors.Is(err, ErrInvalidCatalogFeed) {
+			t.Errorf("DecodeCatalog(%s) error = %v, want %v", input, err, ErrInvalidCatalogFeed)
+		}
+	}
+	if _, err := DecodeCatalog("xml", []byte(`[]`)); !errors.Is(err, ErrInvalidCatalogFeed) {
+		t.Errorf("DecodeCatalog(xml) error = %v, want %v", err, ErrInvalidCatalogFeed)
+	}
+}
+
+// 西半球时区中，片单日期与从数据库读回的上映日期应视为同一天
+func TestDecodeCatalog_ReleaseDateTimezone(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("LoadLocation failed: %v", err)
+	}
+	local := time.Local
+	time.Local = newYork
+	defer func() { time.Local = local }()
+
+	entries, err := DecodeCatalog(CatalogFormatJSON, []byte(`[
+		{"title": "A", "release_date": "2024-03-01", "genres": ["剧情"]},
+		{"title": "B", "release_date": "2024-03-01T23:30:00+08:00", "genres": ["剧情"]}
+	]`))
+	if err != nil {
+		t.Fatalf("DecodeCatalog failed: %v", err)
+	}
+	for _, entry := range entries {
+		if entry.Err != nil {
+			t.Fatalf("entry %d error = %v", entry.Row, entry.Err)
+		}
+		// 模拟导入后从数据库读回（按本地时区）再次导入同一片单
+		stored := entry.ToMovie()
+		stored.ReleaseDate = stored.ReleaseDate.In(time.Local)
+		stored.Genres = []*Genre{{Name: "剧情"}}
+		if got := stored.ReleaseDate.Format(time.DateOnly); got != "2024-03-01" {
+			t.Errorf("entry %d stored release date = %s, want 2024-03-01", entry.Row, got)
+		}
+		if changes := entry.Diff(stored); len(changes) != 0 {
+			t.Errorf("entry %d Diff = %+v, want no changes", entry.Row, changes[0])
+		}
+	}
+}
+
+func TestCatalogEntry_Diff(t *testing.T) {
+	entry := &CatalogEntry{
+		Title:           "Dune",
+		ReleaseDate:     time.Date(2021, 10, 22, 0, 0, 0, 0, time.Local),
+		DurationMinutes: 155,
+		GenreNames:      []string{"科幻", "冒险"},
+		Cast:            "Timothée Chalamet",
+	}
+	existing := func() *Movie {
+		return &Movie{
+			Title:           "Dune",
+			Description:     "旧描述",
+			ReleaseDate:     time.Date(2021, 10, 22, 0, 0, 0, 0, time.Local),
+			DurationMinutes: 155,
+			Genres:          []*Genre{{Name: "冒险"}, {Name: "科幻"}},
+			Cast:            "Timothée Chalamet",
+			PosterURL:       "https://example.com/old.jpg",
+		}
+	}
+
+	tests := []struct {
+		name     string
+		existing *Movie
+		mutate   func(m *Movie)
+		want     []CatalogFieldChange
+	}{
+		// 类型顺序不同、片单未填写描述与海报时没有差异
+		{"unchanged", existing(), nil, []CatalogFieldChange{}},
+		{"new movie", nil, nil, []CatalogFieldChange{
+			{Field: CatalogFieldTitle, Old: "", New: "Dune"},
+			{Field: CatalogFieldReleaseDate, Old: "", New: "2021-10-22"},
+			{Field: CatalogFieldDurationMinutes, Old: "", New: "155"},
+			{Field: CatalogFieldGenres, Old: "", New: "科幻, 冒险"},
+			{Field: CatalogFieldCast, Old: "", New: "Timothée Chalamet"},
+		}},
+		{"changed fields", existing(), func(m *Movie) {
+			m.ReleaseDate = m.ReleaseDate.AddDate(0, 0, 1)
+			m.DurationMinutes = 150
+			m.Genres = []*Genre{{Name: "科幻"}}
+			m.Cast = ""
+		}, []CatalogFieldChange{
+			{Field: CatalogFieldReleaseDate, Old: "2021-10-23", New: "2021-10-22"},
+			{Field: CatalogFieldDurationMinutes, Old: "150", New: "155"},
+			{Field: CatalogFieldGenres, Old: "科幻", New: "科幻, 冒险"},
+			{Field: CatalogFieldCast, Old: "", New: "Timothée Chalamet"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mutate != nil {
+				tt.mutate(tt.existing)
+			}
+			changes := entry.Diff(tt.existing)
+			if len(changes) != len(tt.want) {
+				t.Fatalf("Diff returned %d changes %v, want %d", len(changes), changes, len(tt.want))
+			}
+			for i, change := range changes {
+				if *change != tt.want[i] {
+					t.Errorf("change %d = %+v, want %+v", i, *change, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCatalogEntry_SameGenres(t *testing.T) {
+	entry := &CatalogEntry{GenreNames: []string{"科幻", "冒险"}}
+	tests := []struct {
+		genres []string
+		want   bool
+	}{
+		{[]string{"科幻", "冒险"}, true},
+		{[]string{"冒险", "科幻"}, true},
+		{[]string{"科幻"}, false},
+		{[]string{"科幻", "剧情"}, false},
+		{[]string{"科幻", "冒险", "剧情"}, false},
+		{nil, false},
+	}
+	for _, tt := range tests {
+		existing := &Movie{}
+		for _, name := range tt.genres {
+			existing.Genres = append(existing.Genres, &Genre{Name: name})
+		}
+		if got := entry.SameGenres(existing); got != tt.want {
+			t.Errorf("SameGenres(%v) = %v, want %v", tt.genres, got, tt.want)
+		}
+	}
+}
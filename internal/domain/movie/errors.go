@@ -17,3 +17,37 @@ var (
 	ErrInvalidReleaseDate   = errors.New("invalid release date")
 	ErrInvalidAgeRating     = errors.New("invalid age rating")
 )
+
+// 生命周期相关错误
+var (
+	ErrMovieArchived             = errors.New("movie is archived")
+	ErrMovieNotArchived          = errors.New("movie is not archived")
+	ErrMovieHasUpcomingShowtimes = errors.New("movie has upcoming showtimes")
+)
+
+// Person 相关错误
+var (
+	ErrPersonNotFound      = errors.New("person not found")
+	ErrPersonAlreadyExists = errors.New("person already exists")
+	ErrPersonReferenced    = errors.New("person is referenced by movie credits, cannot delete")
+	ErrInvalidCredit       = errors.New("invalid credit")
+)
+
+// 片单导入相关错误
+var (
+	ErrInvalidCatalogFeed  = errors.New("invalid catalog feed")
+	ErrInvalidCatalogEntry = errors.New("invalid catalog entry")
+)
+
+// 媒体资源相关错误
+var (
+	ErrMediaAssetNotFound   = errors.New("media asset not found")
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+	ErrMediaTooLarge        = errors.New("media file too large")
+	ErrInvalidMediaImage    = errors.New("invalid media image")
+)
+
+// 翻译相关错误
+var (
+	ErrTranslationNotFound = errors.New("translation not found")
+)
@@ -6,4 +6,7 @@ import "mrs/internal/domain/shared/vo"
 type Genre struct {
 	ID   vo.GenreID // 类型ID
 	Name string     // 类型名称
+
+	// 类型名称的其他语言翻译
+	Translations []*GenreTranslation
 }
@@ -0,0 +1,50 @@
+package movie
+
+import "time"
+
+// 电影生命周期状态
+type Status string
+
+const (
+	StatusComingSoon Status = "coming_soon" // 即将上映：未到上映日期，或已上映但尚无未结束的场次
+	StatusNowShowing Status = "now_showing" // 正在热映：已上映且仍有未结束的场次
+	StatusArchived   Status = "archived"    // 已下映：最后一场放映结束，或由管理员手动归档
+)
+
+// 默认列表只展示未归档的电影
+var ActiveStatuses = []Status{StatusComingSoon, StatusNowShowing}
+
+// 状态是否合法
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusComingSoon, StatusNowShowing, StatusArchived:
+		return true
+	}
+	return false
+}
+
+// 是否已归档
+func (m *Movie) IsArchived() bool {
+	return m.Status == StatusArchived
+}
+
+// 根据上映日期与是否仍有未结束的场次计算下一个生命周期状态：
+// 1. 已归档的电影保持归档，只能由管理员恢复
+// 2. 未到上映日期 -> 即将上映（包括上映日期被推迟的情况）
+// 3. 已上映且有未结束的场次 -> 正在热映
+// 4. 正在热映且场次全部结束 -> 已下映
+// 5. 已上映但从未排片 -> 保持即将上映，等待排片
+func (m *Movie) NextStatus(hasUpcomingShowtimes bool, now time.Time) Status {
+	switch {
+	case m.Status == StatusArchived:
+		return StatusArchived
+	case now.Before(m.ReleaseDate):
+		return StatusComingSoon
+	case hasUpcomingShowtimes:
+		return StatusNowShowing
+	case m.Status == StatusNowShowing:
+		return StatusArchived
+	default:
+		return StatusComingSoon
+	}
+}
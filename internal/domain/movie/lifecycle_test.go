@@ -0,0 +1,54 @@
+package movie
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMovie_NextStatus(t *testing.T) {
+	now := time.Date(2026, 6, 15, 12, 0, 0, 0, time.Local)
+	released := now.AddDate(0, 0, -3)
+	upcoming := now.AddDate(0, 0, 3)
+
+	tests := []struct {
+		name        string
+		status      Status
+		releaseDate time.Time
+		hasUpcoming bool
+		want        Status
+	}{
+		{"coming soon before release", StatusComingSoon, upcoming, true, StatusComingSoon},
+		{"coming soon released with showtimes", StatusComingSoon, released, true, StatusNowShowing},
+		{"coming soon released without showtimes", StatusComingSoon, released, false, StatusComingSoon},
+		{"released exactly now", StatusComingSoon, now, true, StatusNowShowing},
+		{"now showing keeps showing", StatusNowShowing, released, true, StatusNowShowing},
+		{"now showing last showtime ended", StatusNowShowing, released, false, StatusArchived},
+		{"now showing release postponed", StatusNowShowing, upcoming, true, StatusComingSoon},
+		{"archived stays archived", StatusArchived, released, true, StatusArchived},
+		{"archived before release", StatusArchived, upcoming, false, StatusArchived},
+	}
+	for _, tt := range tests {
+		m := &Movie{Status: tt.status, ReleaseDate: tt.releaseDate}
+		if got := m.NextStatus(tt.hasUpcoming, now); got != tt.want {
+			t.Errorf("%s: NextStatus(%v) = %s, want %s", tt.name, tt.hasUpcoming, got, tt.want)
+		}
+	}
+}
+
+func TestStatus_IsValid(t *testing.T) {
+	tests := []struct {
+		status Status
+		want   bool
+	}{
+		{StatusComingSoon, true},
+		{StatusNowShowing, true},
+		{StatusArchived, true},
+		{"", false},
+		{"released", false},
+	}
+	for _, tt := range tests {
+		if got := tt.status.IsValid(); got != tt.want {
+			t.Errorf("Status(%q).IsValid() = %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package movie
+
+import (
+	"context"
+	"fmt"
+	"mrs/internal/domain/shared/vo"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// 媒体类型
+type MediaKind string
+
+const (
+	MediaKindPoster   MediaKind = "poster"   // 海报
+	MediaKindBackdrop MediaKind = "backdrop" // 背景图
+	MediaKindTrailer  MediaKind = "trailer"  // 预告片
+)
+
+func (k MediaKind) IsValid() bool {
+	switch k {
+	case MediaKindPoster, MediaKindBackdrop, MediaKindTrailer:
+		return true
+	}
+	return false
+}
+
+// 是否为图片类媒体（需要生成缩略图）
+func (k MediaKind) IsImage() bool {
+	return k == MediaKindPoster || k == MediaKindBackdrop
+}
+
+// 存储目录前缀，如 posters/
+func (k MediaKind) KeyPrefix() string {
+	return string(k) + "s/"
+}
+
+// 允许上传的内容类型及对应的文件扩展名
+const (
+	ContentTypeJPEG = "image/jpeg"
+	ContentTypePNG  = "image/png"
+	ContentTypeMP4  = "video/mp4"
+	ContentTypeWebM = "video/webm"
+)
+
+var mediaExtensions = map[string]string{
+	ContentTypeJPEG: ".jpg",
+	ContentTypePNG:  ".png",
+	ContentTypeMP4:  ".mp4",
+	ContentTypeWebM: ".webm",
+}
+
+// 原始文件的变体名称，缩略图以宽度命名（如 w185）
+const MediaVariantOriginal = "original"
+
+func MediaVariantName(width int) string {
+	return fmt.Sprintf("w%d", width)
+}
+
+// 媒体文件的一个尺寸版本
+type MediaVariant struct {
+	Name   string `json:"name"`   // original 或 w<宽度>
+	Width  int    `json:"width"`  // 视频为0
+	Height int    `json:"height"` // 视频为0
+	Key    string `json:"key"`    // 存储键，文件名为内容哈希
+	URL    string `json:"url"`    // 公开访问地址
+	Size   int64  `json:"size"`   // 字节数
+}
+
+// 电影的媒体资源，每部电影每种类型只保留一份，重新上传会替换
+type MediaAsset struct {
+	ID          vo.MediaAssetID
+	MovieID     vo.MovieID
+	Kind        MediaKind
+	ContentType string
+	Hash        string // 原始文件内容的SHA-256（十六进制）
+	Size        int64
+	Width       int
+	Height      int
+	Variants    []*MediaVariant // 第一个为原始文件，其余按宽度升序
+	CreatedAt   time.Time
+}
+
+// 按名称查找变体
+func (a *MediaAsset) Variant(name string) *MediaVariant {
+	for _, variant := range a.Variants {
+		if variant.Name == name {
+			return variant
+		}
+	}
+	return nil
+}
+
+// 生成基于内容哈希的存储键，相同内容总是得到相同的键
+func MediaKey(kind MediaKind, hash, variant, contentType string) string {
+	name := hash
+	if variant != "" && variant != MediaVariantOriginal {
+		name += "_" + variant
+	}
+	return kind.KeyPrefix() + name + mediaExtensions[contentType]
+}
+
+// MediaAssetRepository 定义了电影媒体资源的持久化操作接口。
+type MediaAssetRepository interface {
+	Create(ctx context.Context, asset *MediaAsset) (*MediaAsset, error)
+	FindByMovieID(ctx context.Context, movieID vo.MovieID) ([]*MediaAsset, error)
+	FindByMovieAndKind(ctx context.Context, movieID vo.MovieID, kind MediaKind) (*MediaAsset, error)
+	// 删除电影的某类媒体记录（不删除存储中的文件），不存在时不报错
+	DeleteByMovieAndKind(ctx context.Context, movieID vo.MovieID, kind MediaKind) error
+}
+
+// 媒体上传策略
+type MediaPolicy struct {
+	MaxImageSize   int64 // 图片最大字节数
+	MaxVideoSize   int64 // 视频最大字节数
+	MaxImagePixels int   // 图片最大像素数（宽×高），防止解码超大图片耗尽内存
+	PosterWidths   []int // 海报缩略图宽度
+	BackdropWidths []int // 背景图缩略图宽度
+}
+
+// 各类媒体允许的最大字节数
+func (p *MediaPolicy) MaxSize(kind MediaKind) int64 {
+	if kind.IsImage() {
+		return p.MaxImageSize
+	}
+	return p.MaxVideoSize
+}
+
+// 所有类型中最大的上传限制，用于限制请求体大小
+func (p *MediaPolicy) MaxUploadSize() int64 {
+	return max(p.MaxImageSize, p.MaxVideoSize)
+}
+
+// 需要生成的缩略图宽度
+func (p *MediaPolicy) Widths(kind MediaKind) []int {
+	switch kind {
+	case MediaKindPoster:
+		return p.PosterWidths
+	case MediaKindBackdrop:
+		return p.BackdropWidths
+	}
+	return nil
+}
+
+// 根据文件头嗅探内容类型，并校验是否允许作为该类媒体上传
+// 不信任客户端声明的Content-Type
+func (p *MediaPolicy) Validate(kind MediaKind, head []byte, size int64) (string, error) {
+	if !kind.IsValid() {
+		return "", fmt.Errorf("%w: unknown media kind %q", ErrUnsupportedMediaType, kind)
+	}
+	if size <= 0 {
+		return "", fmt.Errorf("%w: empty file", ErrUnsupportedMediaType)
+	}
+	if limit := p.MaxSize(kind); size > limit {
+		return "", fmt.Errorf("%w: %d bytes exceeds limit of %d bytes", ErrMediaTooLarge, size, limit)
+	}
+
+	contentType := DetectMediaContentType(head)
+	allowed := false
+	switch contentType {
+	case ContentTypeJPEG, ContentTypePNG:
+		allowed = kind.IsImage()
+	case ContentTypeMP4, ContentTypeWebM:
+		allowed = kind == MediaKindTrailer
+	}
+	if !allowed {
+		return "", fmt.Errorf("%w: %s is not allowed for %s", ErrUnsupportedMediaType, contentType, kind)
+	}
+	return contentType, nil
+}
+
+// 嗅探内容类型，忽略参数部分（如 charset）
+func DetectMediaContentType(head []byte) string {
+	contentType := http.DetectContentType(head)
+	if i := strings.IndexByte(contentType, ';'); i >= 0 {
+		contentType = contentType[:i]
+	}
+	return strings.TrimSpace(contentType)
+}
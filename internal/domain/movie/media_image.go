@@ -0,0 +1,87 @@
+package movie
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+)
+
+// 缩略图的JPEG压缩质量
+const thumbnailJPEGQuality = 85
+
+// 解码图片：先读取尺寸校验像素上限，再完整解码，读取结束后保持在文件末尾
+func DecodeMediaImage(r io.ReadSeeker, contentType string, maxPixels int) (image.Image, error) {
+	cfg, format, err := image.DecodeConfig(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidMediaImage, err)
+	}
+	if "image/"+format != contentType {
+		return nil, fmt.Errorf("%w: format %s does not match content type %s", ErrInvalidMediaImage, format, contentType)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, fmt.Errorf("%w: empty image", ErrInvalidMediaImage)
+	}
+	if maxPixels > 0 && cfg.Width*cfg.Height > maxPixels {
+		return nil, fmt.Errorf("%w: %dx%d exceeds limit of %d pixels", ErrMediaTooLarge, cfg.Width, cfg.Height, maxPixels)
+	}
+
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("seek media image error: %w", err)
+	}
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidMediaImage, err)
+	}
+	return img, nil
+}
+
+// 按宽度等比缩放图片（仅缩小），使用区域平均（box filter）采样
+// 目标宽度不小于原图时返回false，由调用方跳过该尺寸
+func ResizeImage(src image.Image, width int) (image.Image, bool) {
+	bounds := src.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	if width <= 0 || width >= srcW {
+		return nil, false
+	}
+	height := max(1, (srcH*width+srcW/2)/srcW)
+
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*srcH/height
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*srcH/height)
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*srcW/width
+			x1 := max(x0+1, bounds.Min.X+(x+1)*srcW/width)
+
+			// 以预乘alpha累加，避免透明像素的颜色渗入边缘
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r += uint64(cr)
+					g += uint64(cg)
+					b += uint64(cb)
+					a += uint64(ca)
+					n++
+				}
+			}
+			c := color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n)}
+			dst.Set(x, y, c)
+		}
+	}
+	return dst, true
+}
+
+// 按原图格式编码缩略图：JPEG保持JPEG，PNG保持PNG以保留透明度
+func EncodeMediaImage(w io.Writer, img image.Image, contentType string) error {
+	switch contentType {
+	case ContentTypeJPEG:
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: thumbnailJPEGQuality})
+	case ContentTypePNG:
+		return png.Encode(w, img)
+	}
+	return fmt.Errorf("%w: cannot encode %s", ErrUnsupportedMediaType, contentType)
+}
@@ -17,4 +17,36 @@ type Movie struct {
 	AgeRating       string     // 年龄分级 (例如 PG-13)
 	Cast            string     // 主要演员 (简单起见用文本，复杂系统可设计为关联表)
 	Genres          []*Genre   // 类型（多对多关系）
+	Credits         []*Credit  // 演职员（按署名顺序）
+
+	// 生命周期状态，由定时任务根据上映日期与场次推进
+	Status Status
+
+	// 海报、背景图与预告片（每种类型至多一份）
+	Media []*MediaAsset
+
+	// 标题与描述的其他语言翻译（原始字段为默认语言）
+	Translations []*MovieTranslation
+
+	// 用户评价聚合（仅统计已发布的评价，随评价状态变化增量维护）
+	ReviewCount    int
+	ReviewScoreSum int
+}
+
+// 用户评价平均分，无评价时为0
+func (m *Movie) ReviewAverage() float64 {
+	if m.ReviewCount == 0 {
+		return 0
+	}
+	return float64(m.ReviewScoreSum) / float64(m.ReviewCount)
+}
+
+// 按类型查找媒体资源
+func (m *Movie) MediaOf(kind MediaKind) *MediaAsset {
+	for _, asset := range m.Media {
+		if asset.Kind == kind {
+			return asset
+		}
+	}
+	return nil
 }
@@ -47,13 +47,29 @@ func GetMovieListKey(options *MovieQueryOptions) string {
 	}
 
 	// 规范化参数以确保键的一致性
+	status := statusKey(options.Statuses)
 	var sb strings.Builder
 	sb.WriteString(movieListKeyPrefix)
 	sb.WriteString(fmt.Sprintf("%s=%v:", "title", options.Title))              // 构建器追加字符串
 	sb.WriteString(fmt.Sprintf("%s=%v:", "release_year", options.ReleaseYear)) // 构建器追加字符串
 	sb.WriteString(fmt.Sprintf("%s=%v:", "genre_name", options.GenreName))     // 构建器追加字符串
+	sb.WriteString(fmt.Sprintf("%s=%v:", "person_id", options.PersonID))       // 构建器追加字符串
+	sb.WriteString(fmt.Sprintf("%s=%v:", "credit_role", options.CreditRole))   // 构建器追加字符串
+	sb.WriteString(fmt.Sprintf("%s=%v:", "status", status))                    // 构建器追加字符串
 	sb.WriteString(fmt.Sprintf("%s=%v:", "page", options.Page))                // 构建器追加字符串
 	sb.WriteString(fmt.Sprintf("%s=%v:", "page_size", options.PageSize))       // 构建器追加字符串
 
 	return strings.TrimRight(sb.String(), ":") // 移除字符串右侧的:符号
 }
+
+// 状态过滤在缓存键中的表示，每种状态组合对应独立的列表缓存
+func statusKey(statuses []Status) string {
+	if len(statuses) == 0 {
+		return "all"
+	}
+	parts := make([]string, len(statuses))
+	for i, status := range statuses {
+		parts[i] = string(status)
+	}
+	return strings.Join(parts, ",")
+}
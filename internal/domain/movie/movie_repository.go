@@ -21,12 +21,25 @@ type MovieRepository interface {
 	// AddGenreToMovie(ctx context.Context, movie *Movie, genre *Genre) error
 	// RemoveGenreToMovie(ctx context.Context, movie *Movie, genre *Genre) error
 	ReplaceGenresForMovie(ctx context.Context, movie *Movie, genres []*Genre) error
+	// 替换电影的演职员
+	ReplaceCreditsForMovie(ctx context.Context, id vo.MovieID, credits []*Credit) error
+	// 增量更新用户评价聚合（评价数与总分的变化量）
+	ApplyReviewDelta(ctx context.Context, id vo.MovieID, countDelta int, scoreDelta int) error
+	// 查询处于指定生命周期状态的电影（不预加载关联）
+	FindByStatuses(ctx context.Context, statuses []Status) ([]*Movie, error)
+	// 仅更新电影的生命周期状态
+	UpdateStatus(ctx context.Context, id vo.MovieID, status Status) error
 }
 
 type MovieQueryOptions struct {
 	Title       string // 标题（模糊查询）
 	ReleaseYear int    // 上映年份
 	GenreName   string // 类型
+	PersonID    uint   // 参与的影人
+	CreditRole  string // 影人职务（需与PersonID同时使用）
 	Page        int    // 页码（从1开始）
 	PageSize    int    // 每页数量
+
+	// 生命周期状态（为空时不过滤）
+	Statuses []Status
 }
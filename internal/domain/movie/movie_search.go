@@ -0,0 +1,263 @@
+package movie
+
+import (
+	"context"
+	"mrs/internal/domain/shared/vo"
+	"strings"
+	"unicode"
+)
+
+// 参与全文搜索的字段
+const (
+	SearchFieldTitle       = "title"
+	SearchFieldDescription = "description"
+	SearchFieldCast        = "cast"
+	SearchFieldGenres      = "genres"
+)
+
+const (
+	DefaultSnippetLength = 120     // 摘要片段的最大长度（字符数）
+	HighlightPreTag      = "<em>"  // 高亮起始标记
+	HighlightPostTag     = "</em>" // 高亮结束标记
+	snippetEllipsis      = "..."   // 摘要被截断时的省略标记
+	prefixMatchWeight    = 0.8     // 前缀匹配的权重
+	typoMatchWeight      = 0.6     // 编辑距离为1的拼写容错权重
+	typoMatchDecay       = 0.2     // 编辑距离每增加1，权重递减
+	minPrefixMatchLength = 3       // 允许前缀匹配的最小词长
+)
+
+// 全文搜索查询条件
+type MovieSearchQuery struct {
+	Query    string // 用户输入的关键字
+	Page     int
+	PageSize int
+}
+
+// 单条命中结果，仅包含电影ID与相关度得分，电影详情由调用方回表加载
+type MovieSearchHit struct {
+	MovieID vo.MovieID
+	Score   float64
+}
+
+// 搜索结果（已按相关度降序排列）
+type MovieSearchResult struct {
+	Hits  []*MovieSearchHit
+	Total int64
+}
+
+// 电影全文搜索索引，覆盖标题、描述、演员与类型名称
+type MovieSearchIndex interface {
+	// 写入或覆盖电影的索引文档（电影需包含类型）
+	Index(ctx context.Context, mv *Movie) error
+	// 从索引中移除电影
+	Remove(ctx context.Context, id vo.MovieID) error
+	// 按相关度排序的分页搜索，支持拼写容错
+	Search(ctx context.Context, query *MovieSearchQuery) (*MovieSearchResult, error)
+}
+
+// 词元在原文中的位置（按rune计算，左闭右开）
+type tokenSpan struct {
+	start int
+	end   int
+}
+
+// 切分词元：连续的字母数字作为一个词，汉字等表意文字逐字切分
+func tokenSpans(runes []rune) []tokenSpan {
+	spans := make([]tokenSpan, 0)
+	start := -1
+	for i, r := range runes {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			if start >= 0 {
+				spans = append(spans, tokenSpan{start: start, end: i})
+				start = -1
+			}
+			spans = append(spans, tokenSpan{start: i, end: i + 1})
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if start < 0 {
+				start = i
+			}
+		default:
+			if start >= 0 {
+				spans = append(spans, tokenSpan{start: start, end: i})
+				start = -1
+			}
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, tokenSpan{start: start, end: len(runes)})
+	}
+	return spans
+}
+
+// 将文本切分为小写词元
+func Tokenize(text string) []string {
+	runes := []rune(text)
+	spans := tokenSpans(runes)
+	tokens := make([]string, 0, len(spans))
+	for _, span := range spans {
+		tokens = append(tokens, strings.ToLower(string(runes[span.start:span.end])))
+	}
+	return tokens
+}
+
+// 切分查询关键字并去重，保持原有顺序
+func QueryTerms(query string) []string {
+	tokens := Tokenize(query)
+	seen := make(map[string]struct{}, len(tokens))
+	terms := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		if _, ok := seen[token]; ok {
+			continue
+		}
+		seen[token] = struct{}{}
+		terms = append(terms, token)
+	}
+	return terms
+}
+
+// 根据词长决定允许的拼写错误数：短词必须精确匹配，长词最多容忍两处错误
+func MaxTypos(term string) int {
+	n := len([]rune(term))
+	switch {
+	case n < 4:
+		return 0
+	case n < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// 判断索引词元是否匹配查询词，返回匹配权重：精确匹配 1，前缀匹配次之，拼写容错最低
+func MatchTerm(term, token string) (float64, bool) {
+	if term == token {
+		return 1, true
+	}
+	if len([]rune(term)) >= minPrefixMatchLength && strings.HasPrefix(token, term) {
+		return prefixMatchWeight, true
+	}
+	maxTypos := MaxTypos(term)
+	if maxTypos == 0 {
+		return 0, false
+	}
+	if distance := editDistance([]rune(term), []rune(token), maxTypos); distance <= maxTypos {
+		return typoMatchWeight - typoMatchDecay*float64(distance-1), true
+	}
+	return 0, false
+}
+
+// 计算两个词的编辑距离（相邻字符交换计为一次编辑），超过limit时提前返回limit+1
+func editDistance(a, b []rune, limit int) int {
+	if abs(len(a)-len(b)) > limit {
+		return limit + 1
+	}
+	prevPrev := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				curr[j] = min(curr[j], prevPrev[j-2]+1)
+			}
+			rowMin = min(rowMin, curr[j])
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		prevPrev, prev, curr = prev, curr, prevPrev
+	}
+	return prev[len(b)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// 在文本中高亮匹配查询词的词元，并截取包含首个命中位置的摘要片段
+// 未命中任何查询词时返回false
+func Highlight(text string, terms []string, maxLength int) (string, bool) {
+	runes := []rune(text)
+	matched := make([]tokenSpan, 0)
+	for _, span := range tokenSpans(runes) {
+		token := strings.ToLower(string(runes[span.start:span.end]))
+		for _, term := range terms {
+			if _, ok := MatchTerm(term, token); ok {
+				matched = append(matched, span)
+				break
+			}
+		}
+	}
+	if len(matched) == 0 {
+		return "", false
+	}
+
+	// 摘要窗口：首个命中词元前保留约四分之一的上下文
+	start, end := 0, len(runes)
+	if maxLength > 0 && len(runes) > maxLength {
+		start = max(0, matched[0].start-maxLength/4)
+		end = min(len(runes), start+maxLength)
+		start = max(0, end-maxLength)
+	}
+
+	var sb strings.Builder
+	if start > 0 {
+		sb.WriteString(snippetEllipsis)
+	}
+	cursor := start
+	for _, span := range matched {
+		if span.start < cursor || span.end > end {
+			continue
+		}
+		sb.WriteString(string(runes[cursor:span.start]))
+		sb.WriteString(HighlightPreTag)
+		sb.WriteString(string(runes[span.start:span.end]))
+		sb.WriteString(HighlightPostTag)
+		cursor = span.end
+	}
+	sb.WriteString(string(runes[cursor:end]))
+	if end < len(runes) {
+		sb.WriteString(snippetEllipsis)
+	}
+	return sb.String(), true
+}
+
+// 生成电影各字段的高亮摘要，仅包含命中的字段
+func BuildHighlights(mv *Movie, terms []string) map[string]string {
+	highlights := make(map[string]string)
+	if snippet, ok := Highlight(mv.Title, terms, 0); ok {
+		highlights[SearchFieldTitle] = snippet
+	}
+	if snippet, ok := Highlight(mv.Description, terms, DefaultSnippetLength); ok {
+		highlights[SearchFieldDescription] = snippet
+	}
+	if snippet, ok := Highlight(mv.Cast, terms, DefaultSnippetLength); ok {
+		highlights[SearchFieldCast] = snippet
+	}
+	if snippet, ok := Highlight(mv.GenreText(), terms, 0); ok {
+		highlights[SearchFieldGenres] = snippet
+	}
+	return highlights
+}
+
+// 类型名称拼接为文本，供索引与高亮使用
+func (m *Movie) GenreText() string {
+	names := make([]string, 0, len(m.Genres))
+	for _, genre := range m.Genres {
+		names = append(names, genre.Name)
+	}
+	return strings.Join(names, ", ")
+}
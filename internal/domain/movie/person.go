@@ -0,0 +1,61 @@
+package movie
+
+import (
+	"mrs/internal/domain/shared/vo"
+	"strings"
+	"time"
+)
+
+// 影人（演员及主创人员）
+type Person struct {
+	ID        vo.PersonID
+	Name      string     // 姓名（唯一）
+	Bio       string     // 简介
+	BirthDate *time.Time // 出生日期
+	PhotoURL  string     // 照片URL
+}
+
+// 职务
+type CreditRole string
+
+const (
+	CreditRoleActor    CreditRole = "actor"    // 演员
+	CreditRoleDirector CreditRole = "director" // 导演
+	CreditRoleWriter   CreditRole = "writer"   // 编剧
+	CreditRoleComposer CreditRole = "composer" // 作曲
+)
+
+func (r CreditRole) IsValid() bool {
+	switch r {
+	case CreditRoleActor, CreditRoleDirector, CreditRoleWriter, CreditRoleComposer:
+		return true
+	}
+	return false
+}
+
+// 电影的演职员信息
+type Credit struct {
+	Person       *Person
+	Role         CreditRole
+	Character    string // 饰演角色（仅演员）
+	BillingOrder int    // 署名顺序，越小越靠前
+}
+
+// 影人作品年表中的一条记录
+type FilmographyEntry struct {
+	Movie        *Movie
+	Role         CreditRole
+	Character    string
+	BillingOrder int
+}
+
+// 按署名顺序拼接演员姓名，用于兼容仍读取 Cast 文本的客户端与全文搜索
+func (m *Movie) ActorNames() string {
+	names := make([]string, 0, len(m.Credits))
+	for _, credit := range m.Credits {
+		if credit.Role == CreditRoleActor && credit.Person != nil {
+			names = append(names, credit.Person.Name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,28 @@
+package movie
+
+import (
+	"context"
+	"mrs/internal/domain/shared/vo"
+)
+
+// PersonRepository 定义了影人实体的持久化操作接口。
+type PersonRepository interface {
+	Create(ctx context.Context, person *Person) (*Person, error)
+	FindByID(ctx context.Context, id vo.PersonID) (*Person, error)
+	// 分页和按姓名模糊过滤，返回总数
+	List(ctx context.Context, options *PersonQueryOptions) ([]*Person, int64, error)
+	Update(ctx context.Context, person *Person) error
+	// 与Genre相同，被电影引用的影人不能删除，由服务层检查
+	Delete(ctx context.Context, id vo.PersonID) error
+	CheckPersonReferenced(ctx context.Context, id vo.PersonID) (bool, error)
+	// 常用：查找或创建
+	FindOrCreateByNames(ctx context.Context, names []string) ([]*Person, error)
+	// 作品年表，按上映日期倒序；role为空表示不限职务
+	FindFilmography(ctx context.Context, id vo.PersonID, role CreditRole) ([]*FilmographyEntry, error)
+}
+
+type PersonQueryOptions struct {
+	Name     string // 姓名（模糊查询）
+	Page     int
+	PageSize int
+}
@@ -0,0 +1,101 @@
+package movie
+
+import (
+	"context"
+	"mrs/internal/domain/shared/vo"
+)
+
+// 关于本地化：电影的标题、描述与类型名称以原始字段作为默认语言，
+// 其他语言的翻译单独存储。查询时按请求的偏好语言协商（见 vo.NegotiateLocale），
+// 没有匹配的翻译或翻译字段为空时回退到默认语言。
+
+// 电影元数据翻译
+type MovieTranslation struct {
+	Locale      vo.Locale
+	Title       string
+	Description string // 为空时回退到默认语言的描述
+}
+
+// 类型名称翻译
+type GenreTranslation struct {
+	Locale vo.Locale
+	Name   string
+}
+
+// TranslationRepository 电影与类型翻译的持久化操作（同一对象同一语言只有一条翻译）
+type TranslationRepository interface {
+	// 新增或覆盖电影翻译
+	UpsertMovieTranslation(ctx context.Context, movieID vo.MovieID, translation *MovieTranslation) error
+	DeleteMovieTranslation(ctx context.Context, movieID vo.MovieID, locale vo.Locale) error
+	// 新增或覆盖类型翻译
+	UpsertGenreTranslation(ctx context.Context, genreID vo.GenreID, translation *GenreTranslation) error
+	DeleteGenreTranslation(ctx context.Context, genreID vo.GenreID, locale vo.Locale) error
+}
+
+// 按偏好语言返回本地化后的副本（不修改原对象），类型名称一并本地化
+func (m *Movie) Localize(preferred []vo.Locale) *Movie {
+	if len(preferred) == 0 {
+		return m
+	}
+	localized := *m
+	if t := m.translationFor(preferred); t != nil {
+		if t.Title != "" {
+			localized.Title = t.Title
+		}
+		if t.Description != "" {
+			localized.Description = t.Description
+		}
+	}
+	localized.Genres = LocalizeGenres(m.Genres, preferred)
+	return &localized
+}
+
+func (m *Movie) translationFor(preferred []vo.Locale) *MovieTranslation {
+	available := make([]vo.Locale, len(m.Translations))
+	for i, t := range m.Translations {
+		available[i] = t.Locale
+	}
+	locale, ok := vo.NegotiateLocale(preferred, available)
+	if !ok {
+		return nil
+	}
+	for _, t := range m.Translations {
+		if t.Locale == locale {
+			return t
+		}
+	}
+	return nil
+}
+
+// 按偏好语言返回本地化后的副本（不修改原对象）
+func (g *Genre) Localize(preferred []vo.Locale) *Genre {
+	if len(preferred) == 0 || len(g.Translations) == 0 {
+		return g
+	}
+	available := make([]vo.Locale, len(g.Translations))
+	for i, t := range g.Translations {
+		available[i] = t.Locale
+	}
+	locale, ok := vo.NegotiateLocale(preferred, available)
+	if !ok {
+		return g
+	}
+	localized := *g
+	for _, t := range g.Translations {
+		if t.Locale == locale && t.Name != "" {
+			localized.Name = t.Name
+		}
+	}
+	return &localized
+}
+
+func LocalizeGenres(genres []*Genre, preferred []vo.Locale) []*Genre {
+	if len(preferred) == 0 {
+		return genres
+	}
+	localized := make([]*Genre, len(genres))
+	for i, genre := range genres {
+		localized[i] = genre.Localize(preferred)
+	}
+	return localized
+}
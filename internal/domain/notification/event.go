@@ -0,0 +1,35 @@
+package notification
+
+import (
+	"mrs/internal/domain/shared/vo"
+	"time"
+)
+
+// 通知事件类型
+type EventType string
+
+const (
+	EventShowtimeCancelled EventType = "showtime.cancelled" // 场次取消
+
+	EventWatchlistTicketsOnSale     EventType = "watchlist.tickets_on_sale"    // 关注的电影开售（排出第一个场次）
+	EventWatchlistShowtimeAvailable EventType = "watchlist.showtime_available" // 关注的电影在偏好影院/影厅排片
+)
+
+// Event 表示一条发送给用户的通知事件
+type Event struct {
+	Type       EventType      // 事件类型
+	UserID     vo.UserID      // 接收通知的用户
+	Subject    string         // 通知标题
+	Payload    map[string]any // 事件附带数据（如场次ID、退款金额）
+	OccurredAt time.Time      // 事件发生时间
+}
+
+func NewEvent(eventType EventType, userID vo.UserID, subject string, payload map[string]any) *Event {
+	return &Event{
+		Type:       eventType,
+		UserID:     userID,
+		Subject:    subject,
+		Payload:    payload,
+		OccurredAt: time.Now(),
+	}
+}
@@ -0,0 +1,8 @@
+package notification
+
+import "context"
+
+// Notifier 通知投递接口，具体投递方式（日志、邮件、消息队列等）由基础设施层实现
+type Notifier interface {
+	Notify(ctx context.Context, events []*Event) error
+}
@@ -0,0 +1,214 @@
+package recommendation
+
+import (
+	"math"
+	"mrs/internal/domain/movie"
+	"mrs/internal/domain/shared/vo"
+	"sort"
+	"time"
+)
+
+// 关于推荐：候选电影为即将上映和正在热映的电影（排除用户已订过的电影），
+// 得分由两部分加权组成：
+//   - 协同过滤（item-item）：候选电影与用户看过的电影之间的共同观众余弦相似度之和
+//   - 类型偏好：用户看过的电影中各类型的占比，候选电影所属类型的占比之和（不超过1）
+// 两部分均归一化到[0,1]后加权求和。个性化结果不足时用热门电影补齐；
+// 没有订票记录的新用户直接使用热门电影列表。
+
+// 推荐来源
+const (
+	SourcePersonalized = "personalized" // 基于订票历史的个性化推荐
+	SourcePopular      = "popular"      // 热门电影（冷启动）
+)
+
+// 推荐理由
+const (
+	ReasonSimilarAudience = "similar_audience" // 与看过的电影有共同观众
+	ReasonGenre           = "genre"            // 符合偏好的类型
+	ReasonPopular         = "popular"          // 近期热门
+)
+
+const (
+	MaxRecommendations = 50                  // 每个用户缓存的推荐数量上限
+	PopularityWindow   = 30 * 24 * time.Hour // 统计热门电影的时间窗口
+	ActiveUserWindow   = 90 * 24 * time.Hour // 批处理任务只为该窗口内有订单的用户预计算
+
+	collaborativeWeight = 0.7 // 协同过滤得分权重
+	genreWeight         = 0.3 // 类型偏好得分权重
+)
+
+// 单条推荐
+type Recommendation struct {
+	MovieID vo.MovieID
+	Score   float64
+	Reasons []string
+}
+
+// 用户的推荐列表（缓存单元）
+type RecommendationList struct {
+	UserID          vo.UserID
+	Source          string
+	Recommendations []*Recommendation
+	GeneratedAt     time.Time
+}
+
+// 共同观看统计：看过MovieID的用户中同时看过OtherMovieID的人数
+type CoAttendance struct {
+	MovieID      vo.MovieID
+	OtherMovieID vo.MovieID
+	Viewers      int
+}
+
+// 热门电影：时间窗口内的有效订单数
+type PopularMovie struct {
+	MovieID  vo.MovieID
+	Bookings int
+}
+
+// 根据用户看过的电影为候选电影打分，返回按得分降序排列的前limit条推荐
+// audience 为各电影的观众人数（需覆盖看过的电影与候选电影），用于计算余弦相似度
+func Rank(watched, candidates []*movie.Movie, coAttendance []*CoAttendance,
+	audience map[vo.MovieID]int, limit int) []*Recommendation {
+	if len(watched) == 0 || len(candidates) == 0 {
+		return []*Recommendation{}
+	}
+
+	watchedSet := make(map[vo.MovieID]struct{}, len(watched))
+	for _, mv := range watched {
+		watchedSet[mv.ID] = struct{}{}
+	}
+
+	// 协同过滤：sim(i,j) = co(i,j) / sqrt(n_i * n_j)
+	collaborative := make(map[vo.MovieID]float64)
+	for _, co := range coAttendance {
+		if _, ok := watchedSet[co.MovieID]; !ok {
+			continue
+		}
+		ni, nj := audience[co.MovieID], audience[co.OtherMovieID]
+		if ni == 0 || nj == 0 {
+			continue
+		}
+		collaborative[co.OtherMovieID] += float64(co.Viewers) / math.Sqrt(float64(ni)*float64(nj))
+	}
+	maxCollaborative := 0.0
+	for _, score := range collaborative {
+		maxCollaborative = max(maxCollaborative, score)
+	}
+
+	// 类型偏好：各类型在看过的电影中出现的比例
+	genreShare := make(map[vo.GenreID]float64)
+	for _, mv := range watched {
+		for _, genre := range mv.Genres {
+			genreShare[genre.ID] += 1 / float64(len(watched))
+		}
+	}
+
+	recommendations := make([]*Recommendation, 0, len(candidates))
+	for _, mv := range candidates {
+		if _, ok := watchedSet[mv.ID]; ok {
+			continue
+		}
+		rec := &Recommendation{MovieID: mv.ID, Reasons: make([]string, 0, 2)}
+		if cf := collaborative[mv.ID]; cf > 0 {
+			rec.Score += collaborativeWeight * cf / maxCollaborative
+			rec.Reasons = append(rec.Reasons, ReasonSimilarAudience)
+		}
+		affinity := 0.0
+		for _, genre := range mv.Genres {
+			affinity += genreShare[genre.ID]
+		}
+		if affinity > 0 {
+			rec.Score += genreWeight * min(affinity, 1)
+			rec.Reasons = append(rec.Reasons, ReasonGenre)
+		}
+		if rec.Score > 0 {
+			rec.Score = roundScore(rec.Score)
+			recommendations = append(recommendations, rec)
+		}
+	}
+
+	sortRecommendations(recommendations)
+	if len(recommendations) > limit {
+		recommendations = recommendations[:limit]
+	}
+	return recommendations
+}
+
+// 将热门电影转换为推荐，得分为订单数相对最热门电影的比例
+func RankPopular(popular []*PopularMovie, limit int) []*Recommendation {
+	recommendations := make([]*Recommendation, 0, min(len(popular), limit))
+	if len(popular) == 0 {
+		return recommendations
+	}
+	top := 0
+	for _, p := range popular {
+		top = max(top, p.Bookings)
+	}
+	for _, p := range popular {
+		if top == 0 {
+			break
+		}
+		recommendations = append(recommendations, &Recommendation{
+			MovieID: p.MovieID,
+			Score:   roundScore(float64(p.Bookings) / float64(top)),
+			Reasons: []string{ReasonPopular},
+		})
+	}
+	sortRecommendations(recommendations)
+	if len(recommendations) > limit {
+		recommendations = recommendations[:limit]
+	}
+	return recommendations
+}
+
+// 用热门推荐补齐个性化推荐，跳过已看过和已在列表中的电影
+// 补齐项的得分不会超过个性化推荐的最低分，保证个性化结果排在前面；没有个性化推荐时保留热门得分
+func FillWithPopular(recommendations, popular []*Recommendation, exclude []vo.MovieID, limit int) []*Recommendation {
+	if len(recommendations) >= limit {
+		return recommendations
+	}
+	personalized := len(recommendations) > 0
+	seen := make(map[vo.MovieID]struct{}, len(recommendations)+len(exclude))
+	for _, id := range exclude {
+		seen[id] = struct{}{}
+	}
+	floor := 1.0
+	for _, rec := range recommendations {
+		seen[rec.MovieID] = struct{}{}
+		floor = min(floor, rec.Score)
+	}
+	for _, p := range popular {
+		if len(recommendations) >= limit {
+			break
+		}
+		if _, ok := seen[p.MovieID]; ok {
+			continue
+		}
+		seen[p.MovieID] = struct{}{}
+		score := p.Score
+		if personalized {
+			score = roundScore(p.Score * floor * 0.5)
+		}
+		recommendations = append(recommendations, &Recommendation{
+			MovieID: p.MovieID,
+			Score:   score,
+			Reasons: p.Reasons,
+		})
+	}
+	return recommendations
+}
+
+// 按得分降序排列，得分相同时按电影ID升序保证结果稳定
+func sortRecommendations(recommendations []*Recommendation) {
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		if recommendations[i].Score != recommendations[j].Score {
+			return recommendations[i].Score > recommendations[j].Score
+		}
+		return recommendations[i].MovieID < recommendations[j].MovieID
+	})
+}
+
+// 得分保留4位小数
+func roundScore(score float64) float64 {
+	return math.Round(score*10000) / 10000
+}
@@ -0,0 +1,33 @@
+package recommendation
+
+import (
+	"context"
+	"fmt"
+	"mrs/internal/domain/shared/vo"
+	"time"
+)
+
+const (
+	// 个性化推荐由批处理任务定期重算，过期时间需大于任务间隔
+	DefaultRecommendationExpiration = 24 * time.Hour
+	// 热门列表（以及使用热门列表的新用户）过期较快，便于新用户下单后尽快获得个性化推荐
+	DefaultPopularExpiration = time.Hour
+)
+
+// 推荐缓存键前缀
+const (
+	recommendationKeyPrefix = "recommendations:user:"
+	PopularKey              = "recommendations:popular"
+)
+
+// RecommendationCache 推荐结果缓存接口，未命中时返回 shared.ErrCacheMissing
+type RecommendationCache interface {
+	GetRecommendations(ctx context.Context, userID vo.UserID) (*RecommendationList, error)
+	SetRecommendations(ctx context.Context, list *RecommendationList, expiration time.Duration) error
+	GetPopular(ctx context.Context) (*RecommendationList, error)
+	SetPopular(ctx context.Context, list *RecommendationList, expiration time.Duration) error
+}
+
+func GetRecommendationKey(userID vo.UserID) string {
+	return fmt.Sprintf("%s%d", recommendationKeyPrefix, userID)
+}
@@ -0,0 +1,22 @@
+package recommendation
+
+import (
+	"context"
+	"mrs/internal/domain/movie"
+	"mrs/internal/domain/shared/vo"
+	"time"
+)
+
+// RecommendationRepository 基于已确认订单的只读统计查询
+type RecommendationRepository interface {
+	// 用户有已确认订单的电影ID
+	FindWatchedMovieIDs(ctx context.Context, userID vo.UserID) ([]vo.MovieID, error)
+	// 给定电影与其他电影的共同观众人数
+	FindCoAttendance(ctx context.Context, movieIDs []vo.MovieID) ([]*CoAttendance, error)
+	// 各电影的观众人数（去重用户数）
+	CountAudience(ctx context.Context, movieIDs []vo.MovieID) (map[vo.MovieID]int, error)
+	// since 之后下单、且电影处于指定状态的热门电影，按订单数降序
+	FindPopularMovies(ctx context.Context, statuses []movie.Status, since time.Time, limit int) ([]*PopularMovie, error)
+	// since 之后有已确认订单的用户ID，按ID游标分页
+	FindActiveUserIDs(ctx context.Context, since time.Time, afterID vo.UserID, limit int) ([]vo.UserID, error)
+}
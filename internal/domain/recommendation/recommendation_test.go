@@ -0,0 +1,151 @@
+package recommendation
+
+import (
+	"mrs/internal/domain/movie"
+	"mrs/internal/domain/shared/vo"
+	"reflect"
+	"testing"
+)
+
+func newMovie(id vo.MovieID, genres ...vo.GenreID) *movie.Movie {
+	mv := &movie.Movie{ID: id}
+	for _, genre := range genres {
+		mv.Genres = append(mv.Genres, &movie.Genre{ID: genre})
+	}
+	return mv
+}
+
+func recommendationValues(recs []*Recommendation) []Recommendation {
+	values := make([]Recommendation, len(recs))
+	for i, rec := range recs {
+		values[i] = *rec
+	}
+	return values
+}
+
+func TestRank(t *testing.T) {
+	watched := []*movie.Movie{newMovie(1, 1), newMovie(2, 1, 2)}
+	audience := map[vo.MovieID]int{1: 4, 2: 1, 3: 4, 4: 2, 5: 1}
+	coAttendance := []*CoAttendance{
+		{MovieID: 1, OtherMovieID: 3, Viewers: 2}, // 2/sqrt(4*4) = 0.5
+		{MovieID: 2, OtherMovieID: 3, Viewers: 1}, // 1/sqrt(1*4) = 0.5
+		{MovieID: 1, OtherMovieID: 4, Viewers: 2}, // 2/sqrt(4*2) ≈ 0.7071
+		{MovieID: 9, OtherMovieID: 5, Viewers: 3}, // 9 不是看过的电影，忽略
+		{MovieID: 1, OtherMovieID: 6, Viewers: 1}, // 6 没有观众人数，忽略
+	}
+	candidates := []*movie.Movie{
+		newMovie(1, 1),    // 已看过
+		newMovie(3, 2),    // 协同 1.0，类型 0.5
+		newMovie(4, 3),    // 协同 0.7071，无匹配类型
+		newMovie(5),       // 无得分
+		newMovie(6, 1, 2), // 类型占比 1.5，截断为 1
+	}
+
+	tests := []struct {
+		name       string
+		watched    []*movie.Movie
+		candidates []*movie.Movie
+		limit      int
+		want       []Recommendation
+	}{
+		{"no history", nil, candidates, 10, []Recommendation{}},
+		{"no candidates", watched, nil, 10, []Recommendation{}},
+		{"ranked", watched, candidates, 10, []Recommendation{
+			{MovieID: 3, Score: 0.85, Reasons: []string{ReasonSimilarAudience, ReasonGenre}},
+			{MovieID: 4, Score: 0.495, Reasons: []string{ReasonSimilarAudience}},
+			{MovieID: 6, Score: 0.3, Reasons: []string{ReasonGenre}},
+		}},
+		{"limited", watched, candidates, 2, []Recommendation{
+			{MovieID: 3, Score: 0.85, Reasons: []string{ReasonSimilarAudience, ReasonGenre}},
+			{MovieID: 4, Score: 0.495, Reasons: []string{ReasonSimilarAudience}},
+		}},
+		{"ties by movie id", watched, []*movie.Movie{newMovie(8, 2), newMovie(7, 2)}, 10, []Recommendation{
+			{MovieID: 7, Score: 0.15, Reasons: []string{ReasonGenre}},
+			{MovieID: 8, Score: 0.15, Reasons: []string{ReasonGenre}},
+		}},
+	}
+	for _, tt := range tests {
+		got := recommendationValues(Rank(tt.watched, tt.candidates, coAttendance, audience, tt.limit))
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Rank() = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRankPopular(t *testing.T) {
+	popular := []*PopularMovie{{MovieID: 2, Bookings: 10}, {MovieID: 1, Bookings: 5}, {MovieID: 3, Bookings: 10}, {MovieID: 4, Bookings: 0}}
+	reasons := []string{ReasonPopular}
+
+	tests := []struct {
+		name    string
+		popular []*PopularMovie
+		limit   int
+		want    []Recommendation
+	}{
+		{"empty", nil, 10, []Recommendation{}},
+		{"no bookings", []*PopularMovie{{MovieID: 1}}, 10, []Recommendation{}},
+		{"relative to top", popular, 3, []Recommendation{
+			{MovieID: 2, Score: 1, Reasons: reasons},
+			{MovieID: 3, Score: 1, Reasons: reasons},
+			{MovieID: 1, Score: 0.5, Reasons: reasons},
+		}},
+	}
+	for _, tt := range tests {
+		got := recommendationValues(RankPopular(tt.popular, tt.limit))
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: RankPopular() = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFillWithPopular(t *testing.T) {
+	reasons := []string{ReasonPopular}
+	popular := []*Recommendation{
+		{MovieID: 3, Score: 1, Reasons: reasons}, // 已在个性化列表中
+		{MovieID: 5, Score: 1, Reasons: reasons},
+		{MovieID: 1, Score: 0.5, Reasons: reasons}, // 已看过
+		{MovieID: 6, Score: 0.2, Reasons: reasons},
+		{MovieID: 7, Score: 0.1, Reasons: reasons},
+	}
+	personalized := func() []*Recommendation {
+		return []*Recommendation{
+			{MovieID: 3, Score: 0.85, Reasons: []string{ReasonGenre}},
+			{MovieID: 4, Score: 0.4, Reasons: []string{ReasonGenre}},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		recommendations []*Recommendation
+		limit           int
+		want            []Recommendation
+	}{
+		{"already full", personalized(), 2, []Recommendation{
+			{MovieID: 3, Score: 0.85, Reasons: []string{ReasonGenre}},
+			{MovieID: 4, Score: 0.4, Reasons: []string{ReasonGenre}},
+		}},
+		{"filled below personalized floor", personalized(), 4, []Recommendation{
+			{MovieID: 3, Score: 0.85, Reasons: []string{ReasonGenre}},
+			{MovieID: 4, Score: 0.4, Reasons: []string{ReasonGenre}},
+			{MovieID: 5, Score: 0.2, Reasons: reasons},
+			{MovieID: 6, Score: 0.04, Reasons: reasons},
+		}},
+		{"cold start keeps popular scores", nil, 2, []Recommendation{
+			{MovieID: 3, Score: 1, Reasons: reasons},
+			{MovieID: 5, Score: 1, Reasons: reasons},
+		}},
+		{"not enough popular", personalized(), 10, []Recommendation{
+			{MovieID: 3, Score: 0.85, Reasons: []string{ReasonGenre}},
+			{MovieID: 4, Score: 0.4, Reasons: []string{ReasonGenre}},
+			{MovieID: 5, Score: 0.2, Reasons: reasons},
+			{MovieID: 6, Score: 0.04, Reasons: reasons},
+			{MovieID: 7, Score: 0.02, Reasons: reasons},
+		}},
+	}
+	for _, tt := range tests {
+		got := recommendationValues(FillWithPopular(tt.recommendations, popular, []vo.MovieID{1}, tt.limit))
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: FillWithPopular() = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package report
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 五段式 cron 表达式：分 时 日 月 周。
+// 每段支持 *、列表（1,15）、范围（1-5）与步长（*/15、8-18/2），月与周支持英文缩写（JAN、MON），
+// 周取值 0-7（0 与 7 均为周日）；另支持 @hourly、@daily、@weekly、@monthly 简写。
+// 日与周同时限定时按 Vixie cron 的语义，满足其一即可；与 Vixie cron 一致，以 * 开头的段
+// （包括 */2 这样的步长）视为不限定，此时日与周需要同时满足
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domStar, dowStar              bool
+}
+
+var cronMacros = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+}
+
+type cronBounds struct {
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteBounds = cronBounds{min: 0, max: 59}
+	hourBounds   = cronBounds{min: 0, max: 23}
+	domBounds    = cronBounds{min: 1, max: 31}
+	monthBounds  = cronBounds{min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	dowBounds = cronBounds{min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// 解析 cron 表达式
+func ParseCron(expr string) (*CronSchedule, error) {
+	spec := strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(spec)]; ok {
+		spec = macro
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%w: expected 5 fields, got %d in %q", ErrInvalidCron, len(fields), expr)
+	}
+
+	s := &CronSchedule{
+		domStar: isStarField(fields[2]),
+		dowStar: isStarField(fields[4]),
+	}
+	var err error
+	for _, f := range []struct {
+		bits   *uint64
+		field  string
+		bounds cronBounds
+	}{
+		{&s.minute, fields[0], minuteBounds},
+		{&s.hour, fields[1], hourBounds},
+		{&s.dom, fields[2], domBounds},
+		{&s.month, fields[3], monthBounds},
+		{&s.dow, fields[4], dowBounds},
+	} {
+		if *f.bits, err = parseCronField(f.field, f.bounds); err != nil {
+			return nil, fmt.Errorf("%w: %v in %q", ErrInvalidCron, err, expr)
+		}
+	}
+	// 7 与 0 均表示周日
+	if s.dow&(1<<7) != 0 {
+		s.dow = s.dow&^(1<<7) | 1
+	}
+	return s, nil
+}
+
+// Vixie cron 只看段的第一个字符
+func isStarField(field string) bool {
+	return strings.HasPrefix(field, "*") || strings.HasPrefix(field, "?")
+}
+
+// 解析一段，返回取值的位图
+func parseCronField(field string, bounds cronBounds) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %q", part)
+			}
+			rangePart, step = part[:i], n
+		}
+
+		var low, high int
+		switch {
+		case rangePart == "*" || rangePart == "?":
+			low, high = bounds.min, bounds.max
+		case strings.Contains(rangePart, "-"):
+			i := strings.Index(rangePart, "-")
+			var err error
+			if low, err = cronValue(rangePart[:i], bounds); err != nil {
+				return 0, err
+			}
+			if high, err = cronValue(rangePart[i+1:], bounds); err != nil {
+				return 0, err
+			}
+			if high < low {
+				return 0, fmt.Errorf("invalid range %q", rangePart)
+			}
+		default:
+			value, err := cronValue(rangePart, bounds)
+			if err != nil {
+				return 0, err
+			}
+			// 形如 5/15 表示从 5 开始到最大值
+			low, high = value, value
+			if step > 1 {
+				high = bounds.max
+			}
+		}
+
+		for v := low; v <= high; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func cronValue(s string, bounds cronBounds) (int, error) {
+	if v, ok := bounds.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	if v < bounds.min || v > bounds.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", v, bounds.min, bounds.max)
+	}
+	return v, nil
+}
+
+// 匹配的年份上限：超过后认为表达式不会再触发（如 2 月 30 日）
+const cronSearchYears = 5
+
+// 小时段取全部小时（如 *）
+const cronAllHours = 1<<24 - 1
+
+// Next 返回 after 之后（不含）的第一个触发时间，按 after 所在时区的墙上时间计算；不会再触发时返回零值。
+// 夏令时的处理与 Vixie cron 一致：跳过的墙上时间中有匹配的时刻时，在跳变后的第一个时刻触发一次；
+// 回拨后重复的时段只在第一次出现时触发，小时段为全部小时的表达式（如每 15 分钟）两次都会触发
+func (s *CronSchedule) Next(after time.Time) time.Time {
+	loc := after.Location()
+	prev := after.Truncate(time.Minute)
+	t := prev.Add(time.Minute)
+	limit := t.Year() + cronSearchYears
+
+	// 第一分钟本身也可能跨过跳变
+	if s.skippedMatch(prev, t) {
+		return t
+	}
+	for t.Year() <= limit {
+		prev = t
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.dayMatches(t.Day(), t.Weekday()):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case s.hour&(1<<uint(t.Hour())) == 0 || (s.hour != cronAllHours && repeatedWallTime(t)):
+			next := time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			if !next.After(t) {
+				// 夏令时结束时重复的小时，按绝对时间前进到下一个整点
+				next = t.Add(-time.Duration(t.Minute()) * time.Minute).Add(time.Hour)
+			}
+			t = next
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+		if s.skippedMatch(prev, t) {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (s *CronSchedule) dayMatches(day int, weekday time.Weekday) bool {
+	domMatch := s.dom&(1<<uint(day)) != 0
+	dowMatch := s.dow&(1<<uint(weekday)) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// 从 prev 前进到 next 时，夏令时开始跳过的墙上时间（紧挨 next 之前）中是否有匹配的时刻。
+// 其余被跨过的墙上时间都是按月、日、时判断过不匹配的，无需再检查
+func (s *CronSchedule) skippedMatch(prev, next time.Time) bool {
+	gap := wallClock(next).Sub(wallClock(prev)) - next.Sub(prev)
+	for w := wallClock(next).Add(-gap); w.Before(wallClock(next)); w = w.Add(time.Minute) {
+		if s.month&(1<<uint(w.Month())) != 0 && s.dayMatches(w.Day(), w.Weekday()) &&
+			s.hour&(1<<uint(w.Hour())) != 0 && s.minute&(1<<uint(w.Minute())) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// 墙上时间（以 UTC 表示，便于按分钟计算差值）
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, time.UTC)
+}
+
+// t 的墙上时间在夏令时结束回拨前已经出现过
+func repeatedWallTime(t time.Time) bool {
+	start, _ := t.ZoneBounds()
+	if start.IsZero() {
+		return false
+	}
+	_, before := start.Add(-time.Second).Zone()
+	_, offset := t.Zone()
+	return before > offset && t.Sub(start) < time.Duration(before-offset)*time.Second
+}
@@ -0,0 +1,158 @@
+package report
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseCron_Invalid(t *testing.T) {
+	tests := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"*/x * * * *",
+		"5-1 * * * *",
+		"foo * * * *",
+		"* * * JAN-FOO *",
+		"@yearly",
+	}
+	for _, expr := range tests {
+		if _, err := ParseCron(expr); !errors.Is(err, ErrInvalidCron) {
+			t.Errorf("ParseCron(%q) error = %v, want %v", expr, err, ErrInvalidCron)
+		}
+	}
+}
+
+func TestCronSchedule_Next(t *testing.T) {
+	// 2026-10-18 为周日
+	after := time.Date(2026, 10, 18, 10, 7, 30, 0, time.UTC)
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2026, month, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name  string
+		expr  string
+		after time.Time
+		want  time.Time
+	}{
+		{"every minute", "* * * * *", after, at(10, 18, 10, 8)},
+		{"minute step", "*/15 * * * *", after, at(10, 18, 10, 15)},
+		{"step from value", "5/20 * * * *", after, at(10, 18, 10, 25)},
+		{"list", "0,45 * * * *", after, at(10, 18, 10, 45)},
+		{"range with step", "0 8-18/4 * * *", after, at(10, 18, 12, 0)},
+		{"range with step wraps to next day", "0 8-18/4 * * *", at(10, 18, 16, 0), at(10, 19, 8, 0)},
+		{"exact time is exclusive", "30 10 * * *", at(10, 18, 10, 30), at(10, 19, 10, 30)},
+		{"weekday names", "0 9 * * MON-FRI", after, at(10, 19, 9, 0)},
+		{"weekday range over weekend", "0 9 * * mon-fri", at(10, 23, 9, 0), at(10, 26, 9, 0)},
+		{"sunday as 7", "0 9 * * 7", after, at(10, 25, 9, 0)},
+		{"sunday as 0", "0 9 * * 0", after, at(10, 25, 9, 0)},
+		{"month names", "0 0 1 JAN,jul *", after, time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"question mark", "0 0 ? * SAT", after, at(10, 24, 0, 0)},
+		{"hourly macro", "@hourly", after, at(10, 18, 11, 0)},
+		{"daily macro", "@daily", after, at(10, 19, 0, 0)},
+		{"weekly macro", "@WEEKLY", after, at(10, 25, 0, 0)},
+		{"monthly macro", "@monthly", after, at(11, 1, 0, 0)},
+
+		// 日与周都限定时满足其一即可（10-23 为周五，11-13 为周五）
+		{"dom or dow: dow first", "0 0 13 * FRI", after, at(10, 23, 0, 0)},
+		{"dom or dow: dom first", "0 0 20 * FRI", after, at(10, 20, 0, 0)},
+		{"dom only", "0 0 13 * *", after, at(11, 13, 0, 0)},
+		{"dow only", "0 0 * * FRI", after, at(10, 23, 0, 0)},
+		// 以 * 开头的步长视为不限定，日与周需要同时满足：周一且为 1/11/21/31 日
+		{"dom step counts as star", "0 0 */10 * MON", after, at(12, 21, 0, 0)},
+		// 13 日且为周日/二/四/六
+		{"dow step counts as star", "0 0 13 * */2", after, at(12, 13, 0, 0)},
+
+		{"leap day", "0 0 29 2 *", after, time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"never fires", "0 0 30 2 *", after, time.Time{}},
+		{"never fires in april", "0 0 31 4 *", after, time.Time{}},
+		// 2100 年不是闰年，下一个 2 月 29 日在 2104 年，超出 5 年的搜索范围
+		{"beyond search limit", "0 0 29 2 *", time.Date(2096, 3, 1, 0, 0, 0, 0, time.UTC), time.Time{}},
+		{"last year of search limit", "0 0 29 2 *", time.Date(2099, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2104, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ParseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseCron(%q) failed: %v", tt.expr, err)
+			}
+			if got := s.Next(tt.after); !got.Equal(tt.want) {
+				t.Errorf("Next(%q, %v) = %v, want %v", tt.expr, tt.after, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronSchedule_NextTimeZone(t *testing.T) {
+	newYork := loadLocation(t, "America/New_York")
+	kolkata := loadLocation(t, "Asia/Kolkata")
+	ny := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2026, month, day, hour, minute, 0, 0, newYork)
+	}
+	// 2026 年纽约 3 月 8 日 02:00 跳到 03:00，11 月 1 日 02:00 回拨到 01:00，
+	// 用固定时区区分重复的墙上时间，再转换到纽约时区
+	edt := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2026, month, day, hour, minute, 0, 0, time.FixedZone("EDT", -4*3600)).In(newYork)
+	}
+	est := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2026, month, day, hour, minute, 0, 0, time.FixedZone("EST", -5*3600)).In(newYork)
+	}
+
+	tests := []struct {
+		name  string
+		expr  string
+		after time.Time
+		want  time.Time
+	}{
+		{"wall clock of after's zone", "0 9 * * *", time.Date(2026, 10, 18, 9, 0, 0, 0, kolkata), time.Date(2026, 10, 19, 9, 0, 0, 0, kolkata)},
+		{"half hour offset", "0 */2 * * *", time.Date(2026, 10, 18, 10, 10, 0, 0, kolkata), time.Date(2026, 10, 18, 12, 0, 0, 0, kolkata)},
+		{"daily across spring forward", "0 9 * * *", ny(3, 7, 9, 0), ny(3, 8, 9, 0)},
+
+		// 跳过的 02:30 顺延到跳变后的第一个时刻（03:00 EDT），之后恢复正常
+		{"skipped time runs after jump", "30 2 * * *", ny(3, 7, 12, 0), edt(3, 8, 3, 0)},
+		{"skipped time reached minute by minute", "30 2 * * *", ny(3, 8, 1, 59), edt(3, 8, 3, 0)},
+		{"skipped time next day", "30 2 * * *", edt(3, 8, 3, 0), ny(3, 9, 2, 30)},
+		{"skipped hour with every minute", "* * * * *", est(3, 8, 1, 59), edt(3, 8, 3, 0)},
+		{"hourly skips missing hour", "0 * * * *", est(3, 8, 1, 30), edt(3, 8, 3, 0)},
+
+		// 回拨后重复的 01:30 只在第一次出现时触发
+		{"repeated time runs once", "30 1 * * *", ny(10, 31, 12, 0), edt(11, 1, 1, 30)},
+		{"repeated time not run again", "30 1 * * *", edt(11, 1, 1, 30), ny(11, 2, 1, 30)},
+		{"after repeated hour", "0 2 * * *", est(11, 1, 1, 30), est(11, 1, 2, 0)},
+		// 小时段为全部小时时，重复的时段按绝对时间照常触发
+		{"every hour runs in repeated hour", "*/30 * * * *", edt(11, 1, 1, 30), est(11, 1, 1, 0)},
+		{"every hour after repeated hour", "0 * * * *", est(11, 1, 1, 0), est(11, 1, 2, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ParseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseCron(%q) failed: %v", tt.expr, err)
+			}
+			got := s.Next(tt.after)
+			if !got.Equal(tt.want) {
+				t.Errorf("Next(%q, %v) = %v, want %v", tt.expr, tt.after, got, tt.want)
+			}
+			if got.Location() != tt.after.Location() {
+				t.Errorf("Next(%q) location = %v, want %v", tt.expr, got.Location(), tt.after.Location())
+			}
+		})
+	}
+}
+
+func loadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("time zone %s not available: %v", name, err)
+	}
+	return loc
+}
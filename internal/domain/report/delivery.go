@@ -0,0 +1,27 @@
+package report
+
+import "context"
+
+// 生成的报表文件
+type File struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// 一次投递：订阅的收件人、邮件主题与正文（Webhook 与本地文件忽略正文）以及报表文件
+type Delivery struct {
+	Subscription *Subscription
+	Run          *Run
+	Subject      string
+	Body         string
+	File         *File
+}
+
+// 报表投递，不同渠道由不同的实现负责（SMTP、Webhook、本地文件）
+type Deliverer interface {
+	Deliver(ctx context.Context, delivery *Delivery) error
+}
+
+// 按渠道选择投递实现，未配置的渠道不在其中
+type Deliverers map[Channel]Deliverer
@@ -0,0 +1,10 @@
+package report
+
+import "errors"
+
+var (
+	ErrSubscriptionNotFound = errors.New("report subscription not found")
+	ErrInvalidSubscription  = errors.New("invalid report subscription")
+	ErrInvalidCron          = errors.New("invalid cron expression")
+	ErrDelivererUnavailable = errors.New("report delivery channel is not configured")
+)
@@ -0,0 +1,98 @@
+package report
+
+import (
+	"mrs/internal/domain/shared/vo"
+	"time"
+)
+
+// 执行状态
+type RunStatus string
+
+const (
+	RunStatusRunning   RunStatus = "running"
+	RunStatusSucceeded RunStatus = "succeeded"
+	RunStatusFailed    RunStatus = "failed"
+)
+
+// 触发方式
+type RunTrigger string
+
+const (
+	RunTriggerSchedule RunTrigger = "schedule" // 按 cron 计划执行
+	RunTriggerManual   RunTrigger = "manual"   // 管理员手动执行
+	RunTriggerRetry    RunTrigger = "retry"    // 失败后重试
+)
+
+// 每次计划执行最多尝试的次数（含首次），以及第 n 次失败后等待的时间
+const MaxRunAttempts = 3
+
+var retryDelays = []time.Duration{5 * time.Minute, 30 * time.Minute}
+
+// 执行记录：每次尝试一条，重试与首次执行的计划时间相同
+type Run struct {
+	ID             vo.ReportRunID
+	SubscriptionID vo.ReportSubscriptionID
+	Trigger        RunTrigger
+	ScheduledAt    time.Time // 计划执行时间（手动执行时为请求时间），报表周期按此时间计算
+	Attempt        int       // 第几次尝试，从 1 开始
+	Status         RunStatus
+
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	FileName    string
+	FileSize    int64
+	Error       string
+
+	// 失败后计划重试的时间；为空表示不再重试（成功、尝试次数用尽或重试已发起）
+	RetryAt    *time.Time
+	StartedAt  time.Time
+	FinishedAt *time.Time
+}
+
+func NewRun(subscriptionID vo.ReportSubscriptionID, trigger RunTrigger, scheduledAt time.Time, now time.Time) *Run {
+	return &Run{
+		SubscriptionID: subscriptionID,
+		Trigger:        trigger,
+		ScheduledAt:    scheduledAt,
+		Attempt:        1,
+		Status:         RunStatusRunning,
+		StartedAt:      now,
+	}
+}
+
+// 为失败的执行创建下一次尝试
+func (r *Run) Retry(now time.Time) *Run {
+	return &Run{
+		SubscriptionID: r.SubscriptionID,
+		Trigger:        RunTriggerRetry,
+		ScheduledAt:    r.ScheduledAt,
+		Attempt:        r.Attempt + 1,
+		Status:         RunStatusRunning,
+		StartedAt:      now,
+	}
+}
+
+func (r *Run) Succeed(fileName string, fileSize int64, now time.Time) {
+	r.Status = RunStatusSucceeded
+	r.FileName = fileName
+	r.FileSize = fileSize
+	r.Error = ""
+	r.RetryAt = nil
+	r.FinishedAt = &now
+}
+
+// 标记失败，尚未用尽尝试次数时安排重试
+func (r *Run) Fail(err error, now time.Time) {
+	r.Status = RunStatusFailed
+	r.Error = err.Error()
+	r.FinishedAt = &now
+	r.RetryAt = nil
+	if r.Attempt < MaxRunAttempts {
+		delay := retryDelays[len(retryDelays)-1]
+		if r.Attempt-1 < len(retryDelays) {
+			delay = retryDelays[r.Attempt-1]
+		}
+		retryAt := now.Add(delay)
+		r.RetryAt = &retryAt
+	}
+}
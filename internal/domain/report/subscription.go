@@ -0,0 +1,204 @@
+package report
+
+import (
+	"fmt"
+	"mrs/internal/domain/shared/vo"
+	"net/mail"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+// 关于报表订阅：管理员按 cron 表达式定期生成报表，并通过邮件、Webhook 或本地文件投递。
+// 定时任务扫描到期的订阅，每次执行（包括失败后的重试）都记录一条执行记录。
+// cron 表达式与报表周期都按订阅的时区计算，例如每天 07:00 发送前一天的销售报告。
+
+// 报表类型
+type Type string
+
+const (
+	TypeSales     Type = "sales"     // 销售报告
+	TypeOccupancy Type = "occupancy" // 上座率报告
+	TypeBookings  Type = "bookings"  // 订单明细
+)
+
+// 报表文件格式
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+	FormatJSON Format = "json"
+)
+
+// 投递渠道
+type Channel string
+
+const (
+	ChannelEmail   Channel = "email"   // 收件人为邮箱地址
+	ChannelWebhook Channel = "webhook" // 收件人为 http(s) 地址
+	ChannelFile    Channel = "file"    // 收件人为输出目录下的相对子目录，为空时写入以订阅ID命名的目录
+)
+
+// 报表覆盖的周期，相对于计划执行时间
+type Period string
+
+const (
+	PeriodPreviousDay   Period = "previous_day"   // 前一天
+	PeriodPreviousWeek  Period = "previous_week"  // 上一周（周一至周日）
+	PeriodPreviousMonth Period = "previous_month" // 上个月
+)
+
+// Range 返回计划执行时间 at 对应的报表周期 [start, end]，按 loc 的自然日划分，end 为周期最后一秒
+func (p Period) Range(at time.Time, loc *time.Location) (time.Time, time.Time) {
+	at = at.In(loc)
+	today := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, loc)
+	var start, end time.Time
+	switch p {
+	case PeriodPreviousWeek:
+		// 本周一
+		end = today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+		start = end.AddDate(0, 0, -7)
+	case PeriodPreviousMonth:
+		end = time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, loc)
+		start = end.AddDate(0, -1, 0)
+	default:
+		end = today
+		start = today.AddDate(0, 0, -1)
+	}
+	return start, end.Add(-time.Second)
+}
+
+// 报表参数，含义与报表接口的同名查询参数相同，未使用的参数被忽略
+type Parameters struct {
+	Period       Period `json:"period,omitempty"`
+	MovieID      uint   `json:"movie_id,omitempty"`
+	CinemaID     uint   `json:"cinema_id,omitempty"`
+	CinemaHallID uint   `json:"cinema_hall_id,omitempty"`
+	DateBasis    string `json:"date_basis,omitempty"`  // 销售报告与订单明细
+	Granularity  string `json:"granularity,omitempty"` // 销售报告
+	Breakdown    string `json:"breakdown,omitempty"`   // 销售报告
+	Compare      bool   `json:"compare,omitempty"`     // 销售报告
+	Status       string `json:"status,omitempty"`      // 订单明细
+}
+
+// 报表订阅
+type Subscription struct {
+	ID         vo.ReportSubscriptionID
+	Name       string
+	ReportType Type
+	Parameters Parameters
+	Cron       string
+	Timezone   string    // IANA 时区名称，为空时使用服务器时区
+	Locale     vo.Locale // 表头语言与 CSV 数字格式
+	Format     Format
+	Channel    Channel
+	Recipients []string
+	Enabled    bool
+
+	NextRunAt *time.Time // 下一次计划执行时间，停用时为空
+	CreatedBy vo.UserID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// 订阅的时区
+func (s *Subscription) Location() (*time.Location, error) {
+	if s.Timezone == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid timezone %q", ErrInvalidSubscription, s.Timezone)
+	}
+	return loc, nil
+}
+
+// 校验订阅的报表类型、格式、cron 表达式、时区与收件人
+func (s *Subscription) Validate() error {
+	switch s.ReportType {
+	case TypeSales, TypeOccupancy, TypeBookings:
+	default:
+		return fmt.Errorf("%w: unknown report type %q", ErrInvalidSubscription, s.ReportType)
+	}
+	switch s.Format {
+	case FormatCSV, FormatXLSX, FormatJSON:
+	default:
+		return fmt.Errorf("%w: unknown format %q", ErrInvalidSubscription, s.Format)
+	}
+	switch s.Parameters.Period {
+	case "", PeriodPreviousDay, PeriodPreviousWeek, PeriodPreviousMonth:
+	default:
+		return fmt.Errorf("%w: unknown period %q", ErrInvalidSubscription, s.Parameters.Period)
+	}
+	if _, err := ParseCron(s.Cron); err != nil {
+		return err
+	}
+	if _, err := s.Location(); err != nil {
+		return err
+	}
+	return s.validateRecipients()
+}
+
+func (s *Subscription) validateRecipients() error {
+	switch s.Channel {
+	case ChannelEmail, ChannelWebhook:
+		if len(s.Recipients) == 0 {
+			return fmt.Errorf("%w: %s channel requires at least one recipient", ErrInvalidSubscription, s.Channel)
+		}
+	case ChannelFile:
+	default:
+		return fmt.Errorf("%w: unknown channel %q", ErrInvalidSubscription, s.Channel)
+	}
+
+	for _, recipient := range s.Recipients {
+		switch s.Channel {
+		case ChannelEmail:
+			if addr, err := mail.ParseAddress(recipient); err != nil || addr.Address != recipient {
+				return fmt.Errorf("%w: invalid email address %q", ErrInvalidSubscription, recipient)
+			}
+		case ChannelWebhook:
+			u, err := url.Parse(recipient)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("%w: invalid webhook url %q", ErrInvalidSubscription, recipient)
+			}
+		case ChannelFile:
+			if !IsRelativeDir(recipient) {
+				return fmt.Errorf("%w: invalid output directory %q", ErrInvalidSubscription, recipient)
+			}
+		}
+	}
+	return nil
+}
+
+// 是否为不越出根目录的相对路径
+func IsRelativeDir(dir string) bool {
+	if dir == "" || strings.HasPrefix(dir, "/") || strings.Contains(dir, "\\") {
+		return false
+	}
+	cleaned := path.Clean(dir)
+	return cleaned != "." && cleaned != ".." && !strings.HasPrefix(cleaned, "../")
+}
+
+// 按 cron 表达式计算 after 之后的下一次执行时间；停用的订阅没有计划
+func (s *Subscription) ScheduleNext(after time.Time) error {
+	if !s.Enabled {
+		s.NextRunAt = nil
+		return nil
+	}
+	schedule, err := ParseCron(s.Cron)
+	if err != nil {
+		return err
+	}
+	loc, err := s.Location()
+	if err != nil {
+		return err
+	}
+	next := schedule.Next(after.In(loc))
+	if next.IsZero() {
+		return fmt.Errorf("%w: %q never fires", ErrInvalidCron, s.Cron)
+	}
+	s.NextRunAt = &next
+	return nil
+}
@@ -0,0 +1,33 @@
+package report
+
+import (
+	"context"
+	"mrs/internal/domain/shared/vo"
+	"time"
+)
+
+type SubscriptionRepository interface {
+	Create(ctx context.Context, subscription *Subscription) (*Subscription, error)
+	Update(ctx context.Context, subscription *Subscription) error
+	// 删除订阅及其执行记录
+	Delete(ctx context.Context, id vo.ReportSubscriptionID) error
+	FindByID(ctx context.Context, id vo.ReportSubscriptionID) (*Subscription, error)
+	// 分页查询订阅，按ID倒序
+	List(ctx context.Context, page, pageSize int) ([]*Subscription, int64, error)
+	// 查询已到计划执行时间的启用订阅，按计划时间排序
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*Subscription, error)
+	// 领取一次计划执行：仅当下一次执行时间仍为 expected 时更新为 next，
+	// 返回是否领取成功（多个实例同时扫描时只有一个能领取到）
+	ClaimDue(ctx context.Context, id vo.ReportSubscriptionID, expected time.Time, next *time.Time) (bool, error)
+}
+
+type RunRepository interface {
+	Create(ctx context.Context, run *Run) (*Run, error)
+	Update(ctx context.Context, run *Run) error
+	// 分页查询订阅的执行记录，按开始时间倒序
+	ListBySubscription(ctx context.Context, id vo.ReportSubscriptionID, page, pageSize int) ([]*Run, int64, error)
+	// 查询已到重试时间的失败记录
+	FindDueRetries(ctx context.Context, now time.Time, limit int) ([]*Run, error)
+	// 领取一次重试：仅当重试时间仍为 expected 时清空，返回是否领取成功
+	ClaimRetry(ctx context.Context, id vo.ReportRunID, expected time.Time) (bool, error)
+}
@@ -0,0 +1,16 @@
+package review
+
+import "errors"
+
+var (
+	ErrReviewNotFound          = errors.New("review not found")
+	ErrReviewAlreadyExists     = errors.New("review already exists")
+	ErrInvalidReview           = errors.New("invalid review")
+	ErrInvalidReviewTransition = errors.New("invalid review status transition")
+	ErrNotVerifiedViewer       = errors.New("only viewers who have watched the movie can review it")
+	ErrReviewNotOwned          = errors.New("review does not belong to user")
+	ErrReviewNotPublished      = errors.New("review is not published")
+	ErrVoteOwnReview           = errors.New("cannot vote for own review")
+	ErrReviewAlreadyVoted      = errors.New("review already voted")
+	ErrReviewVoteNotFound      = errors.New("review vote not found")
+)
@@ -0,0 +1,127 @@
+package review
+
+import (
+	"fmt"
+	"mrs/internal/domain/shared/vo"
+	"time"
+)
+
+// 关于评价：只有看过电影的用户（已确认订单且场次已开始）才能评价，每人每部电影一条。
+// 新建或修改后的评价需经过审核，只有已发布的评价计入电影的评分聚合。
+
+// 评分范围
+const (
+	MinScore = 1
+	MaxScore = 10
+)
+
+// 评价状态
+type ReviewStatus string
+
+const (
+	ReviewStatusPending   ReviewStatus = "pending"   // 待审核
+	ReviewStatusPublished ReviewStatus = "published" // 已发布
+	ReviewStatusRejected  ReviewStatus = "rejected"  // 审核未通过
+	ReviewStatusHidden    ReviewStatus = "hidden"    // 发布后被管理员隐藏
+)
+
+func (s ReviewStatus) IsValid() bool {
+	switch s {
+	case ReviewStatusPending, ReviewStatusPublished, ReviewStatusRejected, ReviewStatusHidden:
+		return true
+	}
+	return false
+}
+
+// 用户评价
+type Review struct {
+	ID             vo.ReviewID
+	MovieID        vo.MovieID
+	UserID         vo.UserID
+	Score          int
+	Content        string
+	Status         ReviewStatus
+	ModerationNote string // 审核备注（驳回或隐藏原因）
+	HelpfulCount   int    // "有用"投票数
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func NewReview(movieID vo.MovieID, userID vo.UserID, score int, content string) (*Review, error) {
+	r := &Review{
+		MovieID: movieID,
+		UserID:  userID,
+		Score:   score,
+		Content: content,
+		Status:  ReviewStatusPending,
+	}
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Review) Validate() error {
+	if r.Score < MinScore || r.Score > MaxScore {
+		return fmt.Errorf("%w: score must be between %d and %d", ErrInvalidReview, MinScore, MaxScore)
+	}
+	return nil
+}
+
+// 评价对电影评分聚合的贡献（评价数, 总分），只有已发布的评价计入
+func (r *Review) RatingContribution() (count int, score int) {
+	if r.Status == ReviewStatusPublished {
+		return 1, r.Score
+	}
+	return 0, 0
+}
+
+// 用户修改评价，修改后需重新审核
+func (r *Review) Edit(score int, content string) error {
+	r.Score = score
+	r.Content = content
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	r.Status = ReviewStatusPending
+	r.ModerationNote = ""
+	return nil
+}
+
+// 审核通过
+func (r *Review) Approve() error {
+	return r.transition(ReviewStatusPublished, "", ReviewStatusPending)
+}
+
+// 审核驳回
+func (r *Review) Reject(note string) error {
+	return r.transition(ReviewStatusRejected, note, ReviewStatusPending)
+}
+
+// 隐藏已发布的评价
+func (r *Review) Hide(note string) error {
+	return r.transition(ReviewStatusHidden, note, ReviewStatusPublished)
+}
+
+// 恢复被隐藏或驳回的评价
+func (r *Review) Restore() error {
+	return r.transition(ReviewStatusPublished, "", ReviewStatusHidden, ReviewStatusRejected)
+}
+
+func (r *Review) transition(to ReviewStatus, note string, from ...ReviewStatus) error {
+	for _, status := range from {
+		if r.Status == status {
+			r.Status = to
+			r.ModerationNote = note
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: cannot change status from %s to %s", ErrInvalidReviewTransition, r.Status, to)
+}
+
+// 评价锁，串行化同一评价的修改与审核，保证评分聚合的增量计算正确
+const reviewLockKeyFormat = "review:%d:lock"
+
+func GetReviewLockKey(id vo.ReviewID) string {
+	return fmt.Sprintf(reviewLockKeyFormat, id)
+}
@@ -0,0 +1,34 @@
+package review
+
+import (
+	"context"
+	"mrs/internal/domain/shared/vo"
+)
+
+type ReviewRepository interface {
+	Create(ctx context.Context, review *Review) (*Review, error)
+	FindByID(ctx context.Context, id vo.ReviewID) (*Review, error)
+	List(ctx context.Context, options *ReviewQueryOptions) ([]*Review, int64, error)
+	// 更新评分、内容、状态与审核备注
+	Update(ctx context.Context, review *Review) error
+	Delete(ctx context.Context, id vo.ReviewID) error
+	// 记录"有用"投票并同步增减评价的投票数
+	AddHelpfulVote(ctx context.Context, id vo.ReviewID, userID vo.UserID) error
+	RemoveHelpfulVote(ctx context.Context, id vo.ReviewID, userID vo.UserID) error
+}
+
+// 评价排序方式
+const (
+	ReviewSortRecent  = "recent"  // 最新优先
+	ReviewSortHelpful = "helpful" // 投票数优先
+)
+
+// ReviewQueryOptions 表示查询评价的选项，零值字段不参与过滤
+type ReviewQueryOptions struct {
+	MovieID  vo.MovieID
+	UserID   vo.UserID
+	Status   ReviewStatus
+	SortBy   string
+	Page     int
+	PageSize int
+}
@@ -0,0 +1,123 @@
+package review
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestReview_Moderation(t *testing.T) {
+	approve := func(r *Review) error { return r.Approve() }
+	reject := func(r *Review) error { return r.Reject("spam") }
+	hide := func(r *Review) error { return r.Hide("spoiler") }
+	restore := func(r *Review) error { return r.Restore() }
+
+	tests := []struct {
+		name     string
+		from     ReviewStatus
+		action   func(*Review) error
+		want     ReviewStatus
+		wantNote string
+		wantErr  bool
+	}{
+		{"approve pending", ReviewStatusPending, approve, ReviewStatusPublished, "", false},
+		{"approve published", ReviewStatusPublished, approve, ReviewStatusPublished, "", true},
+		{"approve rejected", ReviewStatusRejected, approve, ReviewStatusRejected, "", true},
+		{"approve hidden", ReviewStatusHidden, approve, ReviewStatusHidden, "", true},
+
+		{"reject pending", ReviewStatusPending, reject, ReviewStatusRejected, "spam", false},
+		{"reject published", ReviewStatusPublished, reject, ReviewStatusPublished, "", true},
+		{"reject hidden", ReviewStatusHidden, reject, ReviewStatusHidden, "", true},
+
+		{"hide published", ReviewStatusPublished, hide, ReviewStatusHidden, "spoiler", false},
+		{"hide pending", ReviewStatusPending, hide, ReviewStatusPending, "", true},
+		{"hide rejected", ReviewStatusRejected, hide, ReviewStatusRejected, "", true},
+
+		{"restore hidden", ReviewStatusHidden, restore, ReviewStatusPublished, "", false},
+		{"restore rejected", ReviewStatusRejected, restore, ReviewStatusPublished, "", false},
+		{"restore pending", ReviewStatusPending, restore, ReviewStatusPending, "", true},
+		{"restore published", ReviewStatusPublished, restore, ReviewStatusPublished, "", true},
+	}
+	for _, tt := range tests {
+		r := &Review{Score: 8, Status: tt.from}
+		err := tt.action(r)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidReviewTransition) {
+				t.Errorf("%s: error = %v, want %v", tt.name, err, ErrInvalidReviewTransition)
+			}
+		} else if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+		}
+		if r.Status != tt.want || r.ModerationNote != tt.wantNote {
+			t.Errorf("%s: status = %s (note %q), want %s (note %q)", tt.name, r.Status, r.ModerationNote, tt.want, tt.wantNote)
+		}
+	}
+}
+
+func TestReview_Edit(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    ReviewStatus
+		score   int
+		want    ReviewStatus
+		wantErr bool
+	}{
+		{"published back to pending", ReviewStatusPublished, 9, ReviewStatusPending, false},
+		{"rejected back to pending", ReviewStatusRejected, 6, ReviewStatusPending, false},
+		{"hidden back to pending", ReviewStatusHidden, 7, ReviewStatusPending, false},
+		{"invalid score keeps status", ReviewStatusPublished, MaxScore + 1, ReviewStatusPublished, true},
+	}
+	for _, tt := range tests {
+		r := &Review{Score: 8, Status: tt.from, ModerationNote: "note"}
+		err := r.Edit(tt.score, "updated")
+		if (err != nil) != tt.wantErr || (err != nil && !errors.Is(err, ErrInvalidReview)) {
+			t.Errorf("%s: Edit() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+		if r.Status != tt.want {
+			t.Errorf("%s: status = %s, want %s", tt.name, r.Status, tt.want)
+		}
+		if !tt.wantErr && r.ModerationNote != "" {
+			t.Errorf("%s: moderation note = %q, want cleared", tt.name, r.ModerationNote)
+		}
+	}
+}
+
+func TestNewReview(t *testing.T) {
+	tests := []struct {
+		score   int
+		wantErr bool
+	}{
+		{MinScore - 1, true},
+		{MinScore, false},
+		{MaxScore, false},
+		{MaxScore + 1, true},
+	}
+	for _, tt := range tests {
+		r, err := NewReview(1, 1, tt.score, "")
+		if (err != nil) != tt.wantErr {
+			t.Errorf("NewReview(score=%d) error = %v, wantErr %v", tt.score, err, tt.wantErr)
+			continue
+		}
+		if err == nil && r.Status != ReviewStatusPending {
+			t.Errorf("NewReview(score=%d) status = %s, want %s", tt.score, r.Status, ReviewStatusPending)
+		}
+	}
+}
+
+func TestReview_RatingContribution(t *testing.T) {
+	tests := []struct {
+		status    ReviewStatus
+		wantCount int
+		wantScore int
+	}{
+		{ReviewStatusPending, 0, 0},
+		{ReviewStatusPublished, 1, 7},
+		{ReviewStatusRejected, 0, 0},
+		{ReviewStatusHidden, 0, 0},
+	}
+	for _, tt := range tests {
+		r := &Review{Score: 7, Status: tt.status}
+		if count, score := r.RatingContribution(); count != tt.wantCount || score != tt.wantScore {
+			t.Errorf("RatingContribution(%s) = (%d, %d), want (%d, %d)", tt.status, count, score, tt.wantCount, tt.wantScore)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package blob
+
+import (
+	"context"
+	"io"
+)
+
+// 存储对象
+type Object struct {
+	Body        io.ReadCloser
+	ContentType string
+	Size        int64
+}
+
+// BlobStore 定义了媒体文件等二进制对象的存储接口
+// key 为以 / 分隔的相对路径，由调用方生成（如 posters/<hash>_w185.jpg）
+type BlobStore interface {
+	// 写入对象，已存在时覆盖
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	// 读取对象，调用方负责关闭 Body；不存在时返回 ErrBlobNotFound
+	Get(ctx context.Context, key string) (*Object, error)
+	Delete(ctx context.Context, key string) error
+	// 对象的公开访问地址
+	URL(key string) string
+}
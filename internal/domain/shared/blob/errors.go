@@ -0,0 +1,8 @@
+package blob
+
+import "errors"
+
+var (
+	ErrBlobNotFound   = errors.New("blob not found")
+	ErrInvalidBlobKey = errors.New("invalid blob key")
+)
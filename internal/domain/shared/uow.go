@@ -2,11 +2,15 @@ package shared
 
 import (
 	"context"
+	"mrs/internal/domain/analytics"
 	"mrs/internal/domain/booking"
 	"mrs/internal/domain/cinema"
+	"mrs/internal/domain/loyalty"
 	"mrs/internal/domain/movie"
+	"mrs/internal/domain/review"
 	"mrs/internal/domain/showtime"
 	"mrs/internal/domain/user"
+	"mrs/internal/domain/watchlist"
 )
 
 // RepositoryProvider 提供所有领域对象的仓库接口，用于在事务上下文中获取仓库实例。
@@ -16,11 +20,22 @@ type RepositoryProvider interface {
 	GetRoleRepository() user.RoleRepository
 	GetMovieRepository() movie.MovieRepository
 	GetGenreRepository() movie.GenreRepository
+	GetPersonRepository() movie.PersonRepository
+	GetMediaAssetRepository() movie.MediaAssetRepository
+	GetTranslationRepository() movie.TranslationRepository
 	GetShowtimeRepository() showtime.ShowtimeRepository
+	GetCinemaRepository() cinema.CinemaRepository
 	GetCinemaHallRepository() cinema.CinemaHallRepository
 	GetSeatRepository() cinema.SeatRepository
+	GetSeatRestrictionRepository() cinema.SeatRestrictionRepository
+	GetHallLayoutVersionRepository() cinema.HallLayoutVersionRepository
 	GetBookingRepository() booking.BookingRepository
 	GetBookedSeatRepository() booking.BookedSeatRepository
+	GetDailySalesRepository() booking.DailySalesRepository
+	GetFunnelEventRepository() analytics.FunnelEventRepository
+	GetLoyaltyLedgerRepository() loyalty.LedgerRepository
+	GetReviewRepository() review.ReviewRepository
+	GetWatchlistRepository() watchlist.WatchlistRepository
 }
 
 // UnitOfWork 定义了单元工作的接口。
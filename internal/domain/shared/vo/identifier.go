@@ -18,3 +18,25 @@ type SeatID uint
 type BookingID uint
 
 type BookedSeatID uint
+
+type CinemaID uint
+
+type SeatRestrictionID uint
+
+type HallLayoutVersionID uint
+
+type ReviewID uint
+
+type PersonID uint
+
+type MediaAssetID uint
+
+type WatchlistEntryID uint
+
+type ReportSubscriptionID uint
+
+type ReportRunID uint
+
+type FunnelEventID uint
+
+type LoyaltyEntryID uint
@@ -0,0 +1,109 @@
+package vo
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 语言标签（BCP 47 简化形式），如 en、zh-CN、zh-Hant-TW
+type Locale string
+
+// Accept-Language 中最多解析的语言数，避免超长请求头
+const maxAcceptLanguages = 10
+
+var (
+	ErrInvalidLocale = errors.New("invalid locale")
+
+	localePattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+)
+
+// 解析并规范化语言标签：语言小写，文字（4位）首字母大写，地区（2位）大写
+func ParseLocale(s string) (Locale, error) {
+	s = strings.ReplaceAll(strings.TrimSpace(s), "_", "-")
+	if len(s) > 35 || !localePattern.MatchString(s) {
+		return "", ErrInvalidLocale
+	}
+	parts := strings.Split(s, "-")
+	parts[0] = strings.ToLower(parts[0])
+	for i := 1; i < len(parts); i++ {
+		switch len(parts[i]) {
+		case 2:
+			parts[i] = strings.ToUpper(parts[i])
+		case 4:
+			parts[i] = strings.ToUpper(parts[i][:1]) + strings.ToLower(parts[i][1:])
+		default:
+			parts[i] = strings.ToLower(parts[i])
+		}
+	}
+	return Locale(strings.Join(parts, "-")), nil
+}
+
+// 语言部分，如 zh-CN 的语言为 zh
+func (l Locale) Language() string {
+	language, _, _ := strings.Cut(string(l), "-")
+	return language
+}
+
+// 解析 Accept-Language 请求头，按权重降序返回语言列表（权重相同保持原有顺序）
+// 忽略无效标签、通配符 * 与权重为0的语言
+func ParseAcceptLanguage(header string) []Locale {
+	type weighted struct {
+		locale Locale
+		q      float64
+	}
+	items := make([]weighted, 0)
+	seen := make(map[Locale]struct{})
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+		locale, err := ParseLocale(tag)
+		if err != nil {
+			continue
+		}
+		if _, ok := seen[locale]; ok {
+			continue
+		}
+		seen[locale] = struct{}{}
+		items = append(items, weighted{locale: locale, q: q})
+		if len(items) >= maxAcceptLanguages {
+			break
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].q > items[j].q })
+
+	locales := make([]Locale, len(items))
+	for i, item := range items {
+		locales[i] = item.locale
+	}
+	return locales
+}
+
+// 按偏好顺序在可用语言中协商：每个偏好语言先精确匹配，再按语言部分匹配（zh-TW 可回退到 zh 或 zh-CN）
+func NegotiateLocale(preferred, available []Locale) (Locale, bool) {
+	for _, p := range preferred {
+		for _, a := range available {
+			if strings.EqualFold(string(a), string(p)) {
+				return a, true
+			}
+		}
+		for _, a := range available {
+			if a.Language() == p.Language() {
+				return a, true
+			}
+		}
+	}
+	return "", false
+}
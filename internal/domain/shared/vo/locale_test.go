@@ -0,0 +1,88 @@
+package vo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLocale(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Locale
+		wantErr bool
+	}{
+		{"en", "en", false},
+		{"zh-cn", "zh-CN", false},
+		{" ZH_tw ", "zh-TW", false},
+		{"zh-hant-tw", "zh-Hant-TW", false},
+		{"es-419", "es-419", false},
+		{"", "", true},
+		{"*", "", true},
+		{"e", "", true},
+		{"en-", "", true},
+		{"en us", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseLocale(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseLocale(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseLocale(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   []Locale
+	}{
+		{"empty", "", []Locale{}},
+		{"single", "zh-CN", []Locale{"zh-CN"}},
+		{"keeps order without weights", "fr, en-US", []Locale{"fr", "en-US"}},
+		{"sorted by weight", "en;q=0.5, zh-TW;q=0.9, fr;q=0.7", []Locale{"zh-TW", "fr", "en"}},
+		{"default weight is 1", "en;q=0.8, zh", []Locale{"zh", "en"}},
+		{"equal weights keep order", "de;q=0.5, fr;q=0.5, en", []Locale{"en", "de", "fr"}},
+		{"skips zero weight and wildcard", "en;q=0, *;q=0.1, zh", []Locale{"zh"}},
+		{"skips invalid tags and weights", "zh-TW, e!, fr;q=abc, en;q=0.3", []Locale{"zh-TW", "en"}},
+		{"normalizes and removes duplicates", "zh-tw, zh-TW;q=0.2, ZH_TW", []Locale{"zh-TW"}},
+	}
+	for _, tt := range tests {
+		if got := ParseAcceptLanguage(tt.header); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: ParseAcceptLanguage(%q) = %v, want %v", tt.name, tt.header, got, tt.want)
+		}
+	}
+
+	if got := ParseAcceptLanguage("en,fr,de,es,it,pt,ru,ja,ko,nl,sv"); len(got) != maxAcceptLanguages {
+		t.Errorf("ParseAcceptLanguage(11 languages) returned %d locales, want %d", len(got), maxAcceptLanguages)
+	}
+}
+
+func TestNegotiateLocale(t *testing.T) {
+	tests := []struct {
+		name      string
+		preferred []Locale
+		available []Locale
+		want      Locale
+		wantOK    bool
+	}{
+		{"exact match", []Locale{"zh-TW"}, []Locale{"zh", "zh-TW"}, "zh-TW", true},
+		{"zh-TW falls back to zh", []Locale{"zh-TW"}, []Locale{"en", "zh"}, "zh", true},
+		{"zh-TW falls back to zh-CN", []Locale{"zh-TW"}, []Locale{"en", "zh-CN"}, "zh-CN", true},
+		{"zh falls back to regional", []Locale{"zh"}, []Locale{"zh-CN"}, "zh-CN", true},
+		{"earlier preference wins over exact match", []Locale{"zh-TW", "en"}, []Locale{"en", "zh-CN"}, "zh-CN", true},
+		{"next preference when no match", []Locale{"fr", "en-GB"}, []Locale{"zh", "en"}, "en", true},
+		{"no match", []Locale{"fr"}, []Locale{"en", "zh"}, "", false},
+		{"no preference", nil, []Locale{"en"}, "", false},
+		{"nothing available", []Locale{"en"}, nil, "", false},
+	}
+	for _, tt := range tests {
+		got, ok := NegotiateLocale(tt.preferred, tt.available)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("%s: NegotiateLocale(%v, %v) = (%q, %v), want (%q, %v)", tt.name, tt.preferred, tt.available, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
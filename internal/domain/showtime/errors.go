@@ -10,4 +10,9 @@ var (
 	ErrShowtimeInvalidTimeRange = errors.New("invalid showtime start/end time range")
 	ErrShowtimeNoSeatsAvailable = errors.New("no seats available for this showtime")
 	ErrShowtimeEnded            = errors.New("showtime has ended")
+	ErrShowtimeCancelled        = errors.New("showtime has been cancelled")
+	ErrShowtimeHasLiveBookings  = errors.New("showtime has live bookings, cannot delete")
+	ErrShowtimeOutsideHours     = errors.New("showtime starts outside cinema opening hours")
+
+	ErrShowtimeHallChangeHasBookings = errors.New("showtime has live bookings, cannot change cinema hall")
 )
@@ -7,6 +7,49 @@ import (
 	"time"
 )
 
+// 场次状态枚举
+type ShowtimeStatus string
+
+const (
+	ShowtimeStatusScheduled ShowtimeStatus = "scheduled" // 正常排期
+	ShowtimeStatusCancelled ShowtimeStatus = "cancelled" // 已取消
+)
+
+// 放映格式枚举
+type ProjectionFormat string
+
+const (
+	ProjectionFormat2D          ProjectionFormat = "2D"
+	ProjectionFormat3D          ProjectionFormat = "3D"
+	ProjectionFormatIMAX        ProjectionFormat = "IMAX"
+	ProjectionFormatIMAX3D      ProjectionFormat = "IMAX_3D"
+	ProjectionFormat4DX         ProjectionFormat = "4DX"
+	ProjectionFormatDolbyCinema ProjectionFormat = "DOLBY_CINEMA"
+)
+
+// 未指定放映格式时的默认值
+const DefaultProjectionFormat = ProjectionFormat2D
+
+// 放映格式是否合法
+func (f ProjectionFormat) IsValid() bool {
+	switch f {
+	case ProjectionFormat2D, ProjectionFormat3D, ProjectionFormatIMAX,
+		ProjectionFormatIMAX3D, ProjectionFormat4DX, ProjectionFormatDolbyCinema:
+		return true
+	}
+	return false
+}
+
+// 无障碍服务标记
+type Accessibility struct {
+	AudioDescription bool // 口述影像（视障观众）
+	ClosedCaptions   bool // 隐藏式字幕（听障观众）
+	SensoryFriendly  bool // 感官友好场（调暗灯光、降低音量）
+}
+
+// 取消场次时每批处理的默认订单数量
+const DefaultCancelBatchSize = 200
+
 // 场次
 type Showtime struct {
 	ID           vo.ShowtimeID      // 场次ID
@@ -18,4 +61,29 @@ type Showtime struct {
 	StartTime time.Time // 放映开始时间
 	EndTime   time.Time // 放映结束时间
 	Price     float64   // 票价
+
+	Format           ProjectionFormat // 放映格式（2D/3D/IMAX等）
+	AudioLanguage    string           // 音轨语言（ISO 639-1，如 en、zh）
+	SubtitleLanguage string           // 字幕语言（为空表示无字幕）
+	Accessibility    Accessibility    // 无障碍服务标记
+
+	Status       ShowtimeStatus // 场次状态
+	CancelledAt  *time.Time     // 取消时间
+	CancelReason string         // 取消原因
+
+	// 固定引用的影厅布局版本，创建场次时取影厅当前版本
+	LayoutVersion int
+}
+
+// 取消场次（只标记状态，不删除记录）
+func (s *Showtime) Cancel(reason string) {
+	now := time.Now()
+	s.Status = ShowtimeStatusCancelled
+	s.CancelledAt = &now
+	s.CancelReason = reason
+}
+
+// 场次是否已取消
+func (s *Showtime) IsCancelled() bool {
+	return s.Status == ShowtimeStatusCancelled
 }
@@ -11,8 +11,9 @@ type ShowtimeRepository interface {
 	Create(ctx context.Context, showtime *Showtime) (*Showtime, error)
 	FindByID(ctx context.Context, id vo.ShowtimeID) (*Showtime, error)
 	FindByIDs(ctx context.Context, ids []vo.ShowtimeID) ([]*Showtime, error)
-	// 分页查询支持过滤条件（如电影ID/影厅ID/日期范围）
+	// 分页查询支持过滤条件（如电影ID/影厅ID/日期范围/放映格式/语言/无障碍服务）
 	List(ctx context.Context, options *ShowtimeQueryOptions) ([]*Showtime, int64, error)
+	// 只更新非零值字段；无障碍标记与字幕语言可能需要写入零值，使用下面的专门方法更新
 	Update(ctx context.Context, showtime *Showtime) error
 	Delete(ctx context.Context, id vo.ShowtimeID) error
 
@@ -24,13 +25,43 @@ type ShowtimeRepository interface {
 	// 查询指定影厅在日期范围内的所有场次
 	FindShowtimesByHallAndDateRanges(ctx context.Context, hallID vo.CinemaHallID,
 		startDate, endDate time.Time) ([]*Showtime, error)
+	// 将场次迁移到指定的影厅布局版本
+	UpdateLayoutVersion(ctx context.Context, id vo.ShowtimeID, version int) error
+	// 设置场次的无障碍标记（包括 false）
+	UpdateAccessibility(ctx context.Context, id vo.ShowtimeID, accessibility Accessibility) error
+	// 设置场次的字幕语言，为空表示清除字幕
+	UpdateSubtitleLanguage(ctx context.Context, id vo.ShowtimeID, language string) error
+	// 在给定电影中筛选出仍有未结束（且未取消）场次的电影ID
+	FindMovieIDsWithUpcomingShowtimes(ctx context.Context, movieIDs []vo.MovieID, now time.Time) ([]vo.MovieID, error)
+	// 查询给定电影尚未开始的正常场次（预加载影厅），按开始时间升序
+	FindUpcomingByMovieIDs(ctx context.Context, movieIDs []vo.MovieID, now time.Time) ([]*Showtime, error)
+	// 查询影厅全部尚未开始的正常场次，按开始时间升序
+	FindUpcomingByHallID(ctx context.Context, hallID vo.CinemaHallID, now time.Time) ([]*Showtime, error)
 }
 
 // 放映查询选项
 type ShowtimeQueryOptions struct {
 	MovieID      vo.MovieID      // 电影ID
+	CinemaID     vo.CinemaID     // 影院ID
 	CinemaHallID vo.CinemaHallID // 影厅ID
 	Date         time.Time       // 日期
-	Page         int             // 页码（从1开始）
-	PageSize     int             // 每页数量
+
+	Format           ProjectionFormat // 放映格式
+	AudioLanguage    string           // 音轨语言
+	SubtitleLanguage string           // 字幕语言
+	AudioDescription bool             // 为true时仅返回提供口述影像的场次
+	ClosedCaptions   bool             // 为true时仅返回提供隐藏式字幕的场次
+	SensoryFriendly  bool             // 为true时仅返回感官友好场
+	Status           ShowtimeStatus   // 场次状态，为空时不返回已取消的场次
+	IncludeCancelled bool             // 为true且未指定状态时返回全部状态的场次（仅管理员查询）
+	Page             int              // 页码（从1开始）
+	PageSize         int              // 每页数量
+}
+
+// 场次状态是否符合查询条件（列表缓存中的场次可能在缓存后被取消）
+func (o *ShowtimeQueryOptions) MatchStatus(st *Showtime) bool {
+	if o.Status != "" {
+		return st.Status == o.Status
+	}
+	return o.IncludeCancelled || !st.IsCancelled()
 }
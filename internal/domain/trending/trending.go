@@ -0,0 +1,179 @@
+package trending
+
+import (
+	"mrs/internal/domain/booking"
+	"mrs/internal/domain/shared/vo"
+	"sort"
+	"time"
+)
+
+// 关于热门排行：按电影与场次统计已确认订单的票数，计数器保存在 Redis 有序集合中。
+// 售出的票按订单确认时间计入两种分桶：5分钟桶（用于最近一小时）与自然日桶（用于今天与近7天），
+// 查询时汇总窗口内的分桶。订单确认时累加、退款时扣减原确认时间所在的分桶，已过期的分桶不再写入；
+// 定时任务从订单表重建全部分桶，修正计数器与数据库之间的偏差。
+// 与每日销售事实一致，时间按服务器时区划分。
+
+// 统计窗口
+type Window string
+
+const (
+	WindowHour  Window = "1h"    // 最近一小时（5分钟粒度，当前分桶与之前11个分桶）
+	WindowToday Window = "today" // 今天
+	WindowWeek  Window = "7d"    // 近7天（今天与之前6天）
+)
+
+// 所有统计窗口
+var Windows = []Window{WindowHour, WindowToday, WindowWeek}
+
+// 排行对象
+type Subject string
+
+const (
+	SubjectMovie    Subject = "movie"
+	SubjectShowtime Subject = "showtime"
+)
+
+// 所有排行对象
+var Subjects = []Subject{SubjectMovie, SubjectShowtime}
+
+// 分桶粒度
+type Granularity string
+
+const (
+	GranularityFiveMinutes Granularity = "5m"
+	GranularityDay         Granularity = "day"
+)
+
+const (
+	BucketMinutes = 5                  // 5分钟桶的分钟数
+	hourBuckets   = 60 / BucketMinutes // 最近一小时包含的分桶数
+	weekDays      = 7                  // 近7天包含的日桶数
+)
+
+// 分桶：粒度与开始时间（服务器时区）
+type Bucket struct {
+	Granularity Granularity
+	Start       time.Time
+}
+
+// 时间所在分桶的开始时间，按服务器时区的钟面时间截断
+func (g Granularity) Truncate(t time.Time) time.Time {
+	t = t.In(time.Local)
+	if g == GranularityDay {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()/BucketMinutes*BucketMinutes, 0, 0, time.Local)
+}
+
+// 分桶的过期时间：此后不再属于任何统计窗口
+func (b Bucket) ExpireAt() time.Time {
+	if b.Granularity == GranularityDay {
+		return b.Start.AddDate(0, 0, weekDays)
+	}
+	return b.Start.Add(time.Hour)
+}
+
+// 窗口是否有效
+func (w Window) Valid() bool {
+	switch w {
+	case WindowHour, WindowToday, WindowWeek:
+		return true
+	}
+	return false
+}
+
+// 窗口在 now 时包含的分桶，从新到旧
+func (w Window) Buckets(now time.Time) []Bucket {
+	switch w {
+	case WindowHour:
+		start := GranularityFiveMinutes.Truncate(now)
+		buckets := make([]Bucket, hourBuckets)
+		for i := range buckets {
+			buckets[i] = Bucket{Granularity: GranularityFiveMinutes, Start: start.Add(-time.Duration(i*BucketMinutes) * time.Minute)}
+		}
+		return buckets
+	case WindowWeek:
+		today := GranularityDay.Truncate(now)
+		buckets := make([]Bucket, weekDays)
+		for i := range buckets {
+			buckets[i] = Bucket{Granularity: GranularityDay, Start: today.AddDate(0, 0, -i)}
+		}
+		return buckets
+	default:
+		return []Bucket{{Granularity: GranularityDay, Start: GranularityDay.Truncate(now)}}
+	}
+}
+
+// 重建计数器时覆盖的分桶：最近一小时的5分钟桶与近7天的日桶
+func RebuildBuckets(now time.Time) []Bucket {
+	return append(WindowHour.Buckets(now), WindowWeek.Buckets(now)...)
+}
+
+// 一笔售票（退款时票数为负数），按确认时间计入分桶
+type Sale struct {
+	MovieID    vo.MovieID
+	ShowtimeID vo.ShowtimeID
+	Tickets    int
+	SoldAt     time.Time
+}
+
+// 订单确认产生的售票（调用方需加载座位）
+func ConfirmedSale(b *booking.Booking, movieID vo.MovieID) *Sale {
+	return &Sale{MovieID: movieID, ShowtimeID: b.ShowtimeID, Tickets: len(b.BookedSeats), SoldAt: b.SoldAt()}
+}
+
+// 已确认订单退款扣减的售票（调用方需在释放座位前加载座位）
+func RefundedSale(b *booking.Booking, movieID vo.MovieID) *Sale {
+	return &Sale{MovieID: movieID, ShowtimeID: b.ShowtimeID, Tickets: -len(b.BookedSeats), SoldAt: b.SoldAt()}
+}
+
+// 售票在 now 时计入的分桶：5分钟桶与日桶，跳过已离开所有窗口的分桶
+func (s *Sale) Buckets(now time.Time) []Bucket {
+	buckets := make([]Bucket, 0, 2)
+	for _, g := range []Granularity{GranularityFiveMinutes, GranularityDay} {
+		bucket := Bucket{Granularity: g, Start: g.Truncate(s.SoldAt)}
+		if bucket.ExpireAt().After(now) {
+			buckets = append(buckets, bucket)
+		}
+	}
+	return buckets
+}
+
+// 排行对象的ID
+func (s *Sale) Member(subject Subject) uint {
+	if subject == SubjectShowtime {
+		return uint(s.ShowtimeID)
+	}
+	return uint(s.MovieID)
+}
+
+// 从订单表汇总的分桶票数（重建计数器）
+type TicketCount struct {
+	Bucket     Bucket
+	MovieID    vo.MovieID
+	ShowtimeID vo.ShowtimeID
+	Tickets    int
+}
+
+// 排行项
+type Entry struct {
+	ID      uint // 电影ID或场次ID
+	Tickets int
+}
+
+// 汇总各分桶的票数，按票数降序排列（票数相同时按ID升序），跳过票数不大于0的项
+func Rank(totals map[uint]int) []*Entry {
+	entries := make([]*Entry, 0, len(totals))
+	for id, tickets := range totals {
+		if tickets > 0 {
+			entries = append(entries, &Entry{ID: id, Tickets: tickets})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Tickets != entries[j].Tickets {
+			return entries[i].Tickets > entries[j].Tickets
+		}
+		return entries[i].ID < entries[j].ID
+	})
+	return entries
+}
@@ -0,0 +1,23 @@
+package trending
+
+import (
+	"context"
+	"fmt"
+)
+
+// 计数器键前缀，完整的键为 trending:<subject>:<granularity>:<分桶开始时间>
+const trendingKeyPrefix = "trending:"
+
+// TrendingCache 售票计数器接口（Redis 有序集合，成员为电影或场次ID，分数为票数）
+type TrendingCache interface {
+	// 累加售票（退款时票数为负数），跳过已过期的分桶
+	Add(ctx context.Context, sales ...*Sale) error
+	// 汇总分桶内的票数，按票数降序
+	Ranking(ctx context.Context, subject Subject, buckets []Bucket) ([]*Entry, error)
+	// 用从订单表汇总的票数原子地替换分桶，counts 中没有票数的分桶被清空
+	Replace(ctx context.Context, buckets []Bucket, counts []*TicketCount) error
+}
+
+func GetTrendingKey(subject Subject, bucket Bucket) string {
+	return fmt.Sprintf("%s%s:%s:%s", trendingKeyPrefix, subject, bucket.Granularity, bucket.Start.Format("200601021504"))
+}
@@ -0,0 +1,12 @@
+package trending
+
+import (
+	"context"
+	"time"
+)
+
+// TrendingRepository 基于已确认订单的只读统计查询
+type TrendingRepository interface {
+	// since 之后下单的已确认订单的票数，按分桶与场次汇总
+	CountTickets(ctx context.Context, granularity Granularity, since time.Time) ([]*TicketCount, error)
+}
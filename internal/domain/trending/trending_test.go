@@ -0,0 +1,142 @@
+package trending
+
+import (
+	"mrs/internal/domain/booking"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func at(hour, minute int) time.Time {
+	return time.Date(2026, 6, 15, hour, minute, 30, 0, time.Local)
+}
+
+func TestGranularity_Truncate(t *testing.T) {
+	tests := []struct {
+		granularity Granularity
+		in          time.Time
+		want        time.Time
+	}{
+		{GranularityFiveMinutes, at(10, 0), at(10, 0).Add(-30 * time.Second)},
+		{GranularityFiveMinutes, at(10, 4), at(10, 0).Add(-30 * time.Second)},
+		{GranularityFiveMinutes, at(10, 5), at(10, 5).Add(-30 * time.Second)},
+		{GranularityFiveMinutes, at(23, 59), at(23, 55).Add(-30 * time.Second)},
+		{GranularityDay, at(0, 0), time.Date(2026, 6, 15, 0, 0, 0, 0, time.Local)},
+		{GranularityDay, at(23, 59), time.Date(2026, 6, 15, 0, 0, 0, 0, time.Local)},
+	}
+	for _, tt := range tests {
+		if got := tt.granularity.Truncate(tt.in); !got.Equal(tt.want) {
+			t.Errorf("%s.Truncate(%v) = %v, want %v", tt.granularity, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestWindow_Buckets(t *testing.T) {
+	now := at(10, 7)
+	today := time.Date(2026, 6, 15, 0, 0, 0, 0, time.Local)
+
+	hour := WindowHour.Buckets(now)
+	if len(hour) != 12 {
+		t.Fatalf("len(WindowHour.Buckets) = %d, want 12", len(hour))
+	}
+	if want := (Bucket{GranularityFiveMinutes, time.Date(2026, 6, 15, 10, 5, 0, 0, time.Local)}); hour[0] != want {
+		t.Errorf("WindowHour.Buckets[0] = %v, want %v", hour[0], want)
+	}
+	if want := (Bucket{GranularityFiveMinutes, time.Date(2026, 6, 15, 9, 10, 0, 0, time.Local)}); hour[11] != want {
+		t.Errorf("WindowHour.Buckets[11] = %v, want %v", hour[11], want)
+	}
+
+	if got, want := WindowToday.Buckets(now), []Bucket{{GranularityDay, today}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("WindowToday.Buckets = %v, want %v", got, want)
+	}
+
+	week := WindowWeek.Buckets(now)
+	if len(week) != 7 || week[0].Start != today || week[6].Start != today.AddDate(0, 0, -6) {
+		t.Errorf("WindowWeek.Buckets = %v, want 7 day buckets from %v", week, today)
+	}
+
+	if got := len(RebuildBuckets(now)); got != 19 {
+		t.Errorf("len(RebuildBuckets) = %d, want 19", got)
+	}
+}
+
+func TestBucket_ExpireAt(t *testing.T) {
+	start := time.Date(2026, 6, 15, 10, 5, 0, 0, time.Local)
+	tests := []struct {
+		bucket Bucket
+		want   time.Time
+	}{
+		{Bucket{GranularityFiveMinutes, start}, start.Add(time.Hour)},
+		{Bucket{GranularityDay, time.Date(2026, 6, 15, 0, 0, 0, 0, time.Local)}, time.Date(2026, 6, 22, 0, 0, 0, 0, time.Local)},
+	}
+	for _, tt := range tests {
+		if got := tt.bucket.ExpireAt(); !got.Equal(tt.want) {
+			t.Errorf("%v.ExpireAt() = %v, want %v", tt.bucket, got, tt.want)
+		}
+	}
+}
+
+func TestSale_Buckets(t *testing.T) {
+	soldAt := at(10, 7)
+	fiveMinutes := Bucket{GranularityFiveMinutes, time.Date(2026, 6, 15, 10, 5, 0, 0, time.Local)}
+	day := Bucket{GranularityDay, time.Date(2026, 6, 15, 0, 0, 0, 0, time.Local)}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want []Bucket
+	}{
+		{"just sold", soldAt, []Bucket{fiveMinutes, day}},
+		{"five minute bucket expired", at(11, 5), []Bucket{day}},
+		{"all expired", soldAt.AddDate(0, 0, 7), []Bucket{}},
+	}
+	for _, tt := range tests {
+		sale := &Sale{Tickets: 2, SoldAt: soldAt}
+		if got := sale.Buckets(tt.now); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Buckets(%v) = %v, want %v", tt.name, tt.now, got, tt.want)
+		}
+	}
+}
+
+func TestConfirmedSale_UsesConfirmTime(t *testing.T) {
+	bookedAt := at(9, 0)
+	confirmedAt := at(10, 7)
+	seats := []*booking.BookedSeat{{}, {}}
+
+	tests := []struct {
+		name    string
+		booking *booking.Booking
+		want    time.Time
+	}{
+		{"confirmed", &booking.Booking{BookedSeats: seats, BookingTime: bookedAt, ConfirmedAt: &confirmedAt}, confirmedAt},
+		{"legacy without confirm time", &booking.Booking{BookedSeats: seats, BookingTime: bookedAt}, bookedAt},
+	}
+	for _, tt := range tests {
+		sale := ConfirmedSale(tt.booking, 1)
+		if !sale.SoldAt.Equal(tt.want) || sale.Tickets != 2 {
+			t.Errorf("%s: ConfirmedSale = {SoldAt: %v, Tickets: %d}, want {SoldAt: %v, Tickets: 2}", tt.name, sale.SoldAt, sale.Tickets, tt.want)
+		}
+		refund := RefundedSale(tt.booking, 1)
+		if !refund.SoldAt.Equal(tt.want) || refund.Tickets != -2 {
+			t.Errorf("%s: RefundedSale = {SoldAt: %v, Tickets: %d}, want {SoldAt: %v, Tickets: -2}", tt.name, refund.SoldAt, refund.Tickets, tt.want)
+		}
+	}
+}
+
+func TestRank(t *testing.T) {
+	tests := []struct {
+		name   string
+		totals map[uint]int
+		want   []*Entry
+	}{
+		{"empty", map[uint]int{}, []*Entry{}},
+		{"sorted by tickets", map[uint]int{1: 3, 2: 5, 3: 1}, []*Entry{{2, 5}, {1, 3}, {3, 1}}},
+		{"ties by id", map[uint]int{7: 2, 3: 2, 5: 4}, []*Entry{{5, 4}, {3, 2}, {7, 2}}},
+		{"skip refunded", map[uint]int{1: 0, 2: -1, 3: 2}, []*Entry{{3, 2}}},
+	}
+	for _, tt := range tests {
+		if got := Rank(tt.totals); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Rank(%v) = %v, want %v", tt.name, tt.totals, got, tt.want)
+		}
+	}
+}
@@ -13,6 +13,9 @@ var (
 	ErrWeakPassword    = errors.New("password does not meet strength requirements")
 	ErrInvalidPassword = errors.New("invalid password")
 
+	// 资料错误
+	ErrInvalidBirthDate = errors.New("invalid birth date")
+
 	// 数据操作错误
 	ErrDataConflict    = errors.New("data conflict")
 	ErrVersionConflict = errors.New("version conflict")
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"mrs/internal/domain/shared/vo"
 	"mrs/internal/utils"
+	"time"
 )
 
 // 用户
@@ -13,6 +14,8 @@ type User struct {
 	PasswordHash string
 	Email        string
 	Role         *Role // 聚合内部可以直接持有同一聚合内其他实体的引用
+
+	BirthDate *time.Time // 出生日期（可选，用于年龄分级核验）
 }
 
 // 接收明文密码并使用bcrypt哈希化存储
@@ -33,3 +36,33 @@ func (u *User) CheckPassword(password string, hasher utils.PasswordHasher) bool
 	}
 	return ok // 如果 err 为 nil，表示密码匹配
 }
+
+// 出生日期最早允许的年份
+const minBirthYear = 1900
+
+// 校验出生日期：不能晚于当前时间，也不能早于1900年（允许为空）
+func ValidateBirthDate(birthDate *time.Time, now time.Time) error {
+	if birthDate == nil {
+		return nil
+	}
+	if birthDate.After(now) || birthDate.Year() < minBirthYear {
+		return fmt.Errorf("%w: %s", ErrInvalidBirthDate, birthDate.Format(time.DateOnly))
+	}
+	return nil
+}
+
+// 计算用户在指定时间的周岁年龄，未登记出生日期时返回false
+// 出生日期是不带时区的日历日期，直接比较存储的年月日，不做时区换算；
+// 2月29日出生的用户在平年的3月1日满岁
+func (u *User) AgeAt(t time.Time) (int, bool) {
+	if u.BirthDate == nil {
+		return 0, false
+	}
+	birthYear, birthMonth, birthDay := u.BirthDate.Date()
+	year, month, day := t.Date()
+	age := year - birthYear
+	if month < birthMonth || (month == birthMonth && day < birthDay) {
+		age--
+	}
+	return max(age, 0), true
+}
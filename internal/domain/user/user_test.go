@@ -0,0 +1,67 @@
+package user
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int, loc *time.Location) *time.Time {
+	t := time.Date(year, month, day, 0, 0, 0, 0, loc)
+	return &t
+}
+
+func TestUser_AgeAt(t *testing.T) {
+	shanghai := time.FixedZone("UTC+8", 8*60*60)
+	newYork := time.FixedZone("UTC-5", -5*60*60)
+
+	tests := []struct {
+		name      string
+		birthDate *time.Time
+		at        time.Time
+		want      int
+		wantOK    bool
+	}{
+		{"no birth date", nil, time.Date(2026, 6, 15, 12, 0, 0, 0, time.UTC), 0, false},
+		{"day before birthday", date(2008, 6, 15, time.UTC), time.Date(2026, 6, 14, 23, 59, 0, 0, time.UTC), 17, true},
+		{"on birthday", date(2008, 6, 15, time.UTC), time.Date(2026, 6, 15, 0, 0, 0, 0, time.UTC), 18, true},
+		{"day after birthday", date(2008, 6, 15, time.UTC), time.Date(2026, 6, 16, 0, 0, 0, 0, time.UTC), 18, true},
+		{"earlier month", date(2008, 12, 1, time.UTC), time.Date(2026, 11, 30, 0, 0, 0, 0, time.UTC), 17, true},
+		{"leap day birth, non-leap Feb 28", date(2008, 2, 29, time.UTC), time.Date(2026, 2, 28, 12, 0, 0, 0, time.UTC), 17, true},
+		{"leap day birth, non-leap Mar 1", date(2008, 2, 29, time.UTC), time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), 18, true},
+		{"leap day birth, leap Feb 29", date(2008, 2, 29, time.UTC), time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC), 20, true},
+		// 出生日期按存储的年月日比较，不随查询时间的时区换算
+		{"stored in UTC, queried west of UTC", date(2008, 6, 15, time.UTC), time.Date(2026, 6, 14, 20, 0, 0, 0, newYork), 17, true},
+		{"stored in UTC+8, queried in UTC", date(2008, 6, 15, shanghai), time.Date(2026, 6, 14, 20, 0, 0, 0, time.UTC), 17, true},
+		{"stored in UTC-5, queried in UTC+8", date(2008, 6, 15, newYork), time.Date(2026, 6, 15, 9, 0, 0, 0, shanghai), 18, true},
+		{"born today", date(2026, 6, 15, time.UTC), time.Date(2026, 6, 15, 8, 0, 0, 0, time.UTC), 0, true},
+	}
+	for _, tt := range tests {
+		u := &User{BirthDate: tt.birthDate}
+		got, ok := u.AgeAt(tt.at)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("%s: AgeAt(%v) = (%d, %v), want (%d, %v)", tt.name, tt.at, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestValidateBirthDate(t *testing.T) {
+	now := time.Date(2026, 6, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		birthDate *time.Time
+		wantErr   bool
+	}{
+		{"empty", nil, false},
+		{"today", date(2026, 6, 15, time.UTC), false},
+		{"future", date(2026, 6, 16, time.UTC), true},
+		{"1900", date(1900, 1, 1, time.UTC), false},
+		{"before 1900", date(1899, 12, 31, time.UTC), true},
+	}
+	for _, tt := range tests {
+		err := ValidateBirthDate(tt.birthDate, now)
+		if (err != nil) != tt.wantErr || (err != nil && !errors.Is(err, ErrInvalidBirthDate)) {
+			t.Errorf("%s: ValidateBirthDate() = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
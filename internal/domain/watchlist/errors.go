@@ -0,0 +1,9 @@
+package watchlist
+
+import "errors"
+
+var (
+	ErrWatchlistEntryNotFound      = errors.New("watchlist entry not found")
+	ErrWatchlistEntryAlreadyExists = errors.New("movie already in watchlist")
+	ErrInvalidWatchlistPreference  = errors.New("invalid watchlist preference")
+)
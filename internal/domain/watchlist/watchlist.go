@@ -0,0 +1,84 @@
+package watchlist
+
+import (
+	"mrs/internal/domain/movie"
+	"mrs/internal/domain/notification"
+	"mrs/internal/domain/shared/vo"
+	"mrs/internal/domain/showtime"
+	"time"
+)
+
+// 关于关注列表：用户关注即将上映的电影，可选指定偏好的影院或影厅。
+// 未指定偏好时，电影排出第一个可售场次即提醒"开售"；指定偏好时，偏好影院/影厅排出场次才提醒。
+// 每个条目只提醒一次，提醒由定时任务扫描未提醒的条目完成。
+
+// 关注条目
+type WatchlistEntry struct {
+	ID      vo.WatchlistEntryID
+	UserID  vo.UserID
+	MovieID vo.MovieID
+	Movie   *movie.Movie
+
+	PreferredCinemaID vo.CinemaID     // 偏好影院，为0表示不限
+	PreferredHallID   vo.CinemaHallID // 偏好影厅，为0表示不限
+
+	NotifiedAt *time.Time // 已发送提醒的时间，为空表示尚未提醒
+	CreatedAt  time.Time
+}
+
+func NewWatchlistEntry(userID vo.UserID, movieID vo.MovieID, cinemaID vo.CinemaID, hallID vo.CinemaHallID) *WatchlistEntry {
+	return &WatchlistEntry{
+		UserID:            userID,
+		MovieID:           movieID,
+		PreferredCinemaID: cinemaID,
+		PreferredHallID:   hallID,
+	}
+}
+
+// 是否指定了影院或影厅偏好
+func (e *WatchlistEntry) HasPreference() bool {
+	return e.PreferredCinemaID != 0 || e.PreferredHallID != 0
+}
+
+// 场次是否满足条目的偏好（场次需预加载影厅才能匹配影院偏好）
+func (e *WatchlistEntry) Matches(st *showtime.Showtime) bool {
+	if st.MovieID != e.MovieID {
+		return false
+	}
+	if e.PreferredHallID != 0 && st.CinemaHallID != e.PreferredHallID {
+		return false
+	}
+	if e.PreferredCinemaID != 0 && (st.CinemaHall == nil || st.CinemaHall.CinemaID != e.PreferredCinemaID) {
+		return false
+	}
+	return true
+}
+
+// 在按开始时间排序的场次中找出第一个满足偏好的场次
+func (e *WatchlistEntry) FirstMatch(showtimes []*showtime.Showtime) *showtime.Showtime {
+	for _, st := range showtimes {
+		if e.Matches(st) {
+			return st
+		}
+	}
+	return nil
+}
+
+// 生成提醒事件：无偏好时为开售提醒，有偏好时为偏好影院/影厅的排片提醒
+func (e *WatchlistEntry) AlertEvent(st *showtime.Showtime) *notification.Event {
+	eventType, subject := notification.EventWatchlistTicketsOnSale, "tickets on sale"
+	if e.HasPreference() {
+		eventType, subject = notification.EventWatchlistShowtimeAvailable, "showtime available at preferred cinema"
+	}
+	payload := map[string]any{
+		"watchlist_entry_id": uint(e.ID),
+		"movie_id":           uint(e.MovieID),
+		"showtime_id":        uint(st.ID),
+		"cinema_hall_id":     uint(st.CinemaHallID),
+		"start_time":         st.StartTime,
+	}
+	if st.CinemaHall != nil && st.CinemaHall.CinemaID != 0 {
+		payload["cinema_id"] = uint(st.CinemaHall.CinemaID)
+	}
+	return notification.NewEvent(eventType, e.UserID, subject, payload)
+}
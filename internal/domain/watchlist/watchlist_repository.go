@@ -0,0 +1,19 @@
+package watchlist
+
+import (
+	"context"
+	"mrs/internal/domain/shared/vo"
+	"time"
+)
+
+type WatchlistRepository interface {
+	Create(ctx context.Context, entry *WatchlistEntry) (*WatchlistEntry, error)
+	// 删除用户对电影的关注
+	Delete(ctx context.Context, userID vo.UserID, movieID vo.MovieID) error
+	// 分页查询用户的关注列表（预加载电影），按关注时间倒序
+	ListByUser(ctx context.Context, userID vo.UserID, page, pageSize int) ([]*WatchlistEntry, int64, error)
+	// 按ID游标查询尚未提醒的条目
+	FindPending(ctx context.Context, afterID vo.WatchlistEntryID, limit int) ([]*WatchlistEntry, error)
+	// 标记条目已提醒
+	MarkNotified(ctx context.Context, ids []vo.WatchlistEntryID, at time.Time) error
+}
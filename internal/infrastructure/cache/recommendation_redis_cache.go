@@ -0,0 +1,94 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mrs/internal/domain/recommendation"
+	"mrs/internal/domain/shared"
+	"mrs/internal/domain/shared/vo"
+	applog "mrs/pkg/log"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// RedisRecommendationCache 推荐结果缓存实现
+type RedisRecommendationCache struct {
+	redisClient *redis.Client
+	logger      applog.Logger
+}
+
+func NewRedisRecommendationCache(redisClient *redis.Client, logger applog.Logger) recommendation.RecommendationCache {
+	return &RedisRecommendationCache{
+		redisClient: redisClient,
+		logger:      logger.With(applog.String("Component", "RedisRecommendationCache")),
+	}
+}
+
+// GetRecommendations 获取用户的推荐列表缓存
+func (c *RedisRecommendationCache) GetRecommendations(ctx context.Context, userID vo.UserID) (*recommendation.RecommendationList, error) {
+	logger := c.logger.With(applog.String("Method", "GetRecommendations"), applog.Uint("user_id", uint(userID)))
+	return c.get(ctx, logger, recommendation.GetRecommendationKey(userID))
+}
+
+// SetRecommendations 设置用户的推荐列表缓存
+func (c *RedisRecommendationCache) SetRecommendations(ctx context.Context, list *recommendation.RecommendationList, expiration time.Duration) error {
+	logger := c.logger.With(applog.String("Method", "SetRecommendations"), applog.Uint("user_id", uint(list.UserID)))
+	if expiration == 0 {
+		expiration = recommendation.DefaultRecommendationExpiration
+	}
+	return c.set(ctx, logger, recommendation.GetRecommendationKey(list.UserID), list, expiration)
+}
+
+// GetPopular 获取热门电影列表缓存
+func (c *RedisRecommendationCache) GetPopular(ctx context.Context) (*recommendation.RecommendationList, error) {
+	logger := c.logger.With(applog.String("Method", "GetPopular"))
+	return c.get(ctx, logger, recommendation.PopularKey)
+}
+
+// SetPopular 设置热门电影列表缓存
+func (c *RedisRecommendationCache) SetPopular(ctx context.Context, list *recommendation.RecommendationList, expiration time.Duration) error {
+	logger := c.logger.With(applog.String("Method", "SetPopular"))
+	if expiration == 0 {
+		expiration = recommendation.DefaultPopularExpiration
+	}
+	return c.set(ctx, logger, recommendation.PopularKey, list, expiration)
+}
+
+func (c *RedisRecommendationCache) get(ctx context.Context, logger applog.Logger, key string) (*recommendation.RecommendationList, error) {
+	valBytes, err := c.redisClient.Get(ctx, key).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			logger.Info("recommendations not found in redis", applog.String("key", key))
+			return nil, fmt.Errorf("%w: %w", shared.ErrCacheMissing, err)
+		}
+		logger.Error("failed to get recommendations from redis", applog.Error(err))
+		return nil, fmt.Errorf("failed to get recommendations from redis: %w", err)
+	}
+
+	var list recommendation.RecommendationList
+	if err := json.Unmarshal(valBytes, &list); err != nil {
+		logger.Error("failed to unmarshal recommendations", applog.Error(err))
+		return nil, fmt.Errorf("failed to unmarshal recommendations: %w", err)
+	}
+	logger.Info("get recommendations from redis successfully", applog.String("key", key))
+	return &list, nil
+}
+
+func (c *RedisRecommendationCache) set(ctx context.Context, logger applog.Logger, key string,
+	list *recommendation.RecommendationList, expiration time.Duration) error {
+	data, err := json.Marshal(list)
+	if err != nil {
+		logger.Error("failed to marshal recommendations", applog.Error(err))
+		return fmt.Errorf("failed to marshal recommendations: %w", err)
+	}
+
+	if err := c.redisClient.Set(ctx, key, data, expiration).Err(); err != nil {
+		logger.Error("failed to set recommendations to redis", applog.Error(err))
+		return fmt.Errorf("failed to set recommendations to redis: %w", err)
+	}
+
+	logger.Info("set recommendations to redis successfully", applog.String("key", key))
+	return nil
+}
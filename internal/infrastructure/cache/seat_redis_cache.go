@@ -56,8 +56,10 @@ func (c *RedisSeatCache) getHallLayoutAndMapping(ctx context.Context, showtimeID
 func (c *RedisSeatCache) InitSeatMap(
 	ctx context.Context,
 	showtimeID vo.ShowtimeID,
+	layout *cinema.HallLayout,
 	hallLayout []*cinema.Seat,
 	bookedSeatIDs []vo.SeatID,
+	blocks *cinema.SeatMapBlocks,
 	expireTime time.Duration) error {
 
 	logger := c.logger.With(applog.String("Method", "InitSeatMap"), applog.Uint("ShowtimeID", uint(showtimeID)))
@@ -76,12 +78,29 @@ func (c *RedisSeatCache) InitSeatMap(
 		logger.Error("json marshal hall layout error", applog.Error(err))
 		return fmt.Errorf("json marshal hall layout error: %w", err)
 	}
+	layoutJson, err := json.Marshal(layout)
+	if err != nil {
+		logger.Error("json marshal hall geometry error", applog.Error(err))
+		return fmt.Errorf("json marshal hall geometry error: %w", err)
+	}
+	if blocks == nil {
+		blocks = &cinema.SeatMapBlocks{}
+	}
+	blocksJson, err := json.Marshal(blocks)
+	if err != nil {
+		logger.Error("json marshal seat blocks error", applog.Error(err))
+		return fmt.Errorf("json marshal seat blocks error: %w", err)
+	}
 
 	seatBitmapKey := cinema.GetShowtimeSeatsBitmapKey(showtimeID)
 	seatInfoKey := cinema.GetShowtimeSeatsInfoKey(showtimeID)
+	seatLayoutKey := cinema.GetShowtimeSeatsLayoutKey(showtimeID)
+	seatBlockedKey := cinema.GetShowtimeSeatsBlockedKey(showtimeID)
 
 	pipe := c.client.Pipeline()
 	pipe.Set(ctx, seatInfoKey, staticJson, expireTime)
+	pipe.Set(ctx, seatLayoutKey, layoutJson, expireTime)
+	pipe.Set(ctx, seatBlockedKey, blocksJson, expireTime)
 	pipe.Del(ctx, seatBitmapKey) // 确保从干净的位图开始
 	// 预先置位图，保证bookedSeatIDs为空时，该位图仍存在
 	pipe.SetBit(ctx, seatBitmapKey, 0, 0)
@@ -90,6 +109,12 @@ func (c *RedisSeatCache) InitSeatMap(
 			pipe.SetBit(ctx, seatBitmapKey, int64(offset), 1)
 		}
 	}
+	// 被封锁的座位同样置位，使其无法被锁定
+	for id := range blocks.Reasons {
+		if offset, ok := idToOffset[id]; ok {
+			pipe.SetBit(ctx, seatBitmapKey, int64(offset), 1)
+		}
+	}
 	pipe.Expire(ctx, seatBitmapKey, expireTime)
 
 	if _, err := pipe.Exec(ctx); err != nil {
@@ -102,10 +127,12 @@ func (c *RedisSeatCache) InitSeatMap(
 }
 
 // 获取座位表
-func (c *RedisSeatCache) GetSeatMap(ctx context.Context, showtimeID vo.ShowtimeID) ([]*cinema.SeatInfo, error) {
+func (c *RedisSeatCache) GetSeatMap(ctx context.Context, showtimeID vo.ShowtimeID) (*cinema.SeatMap, error) {
 	logger := c.logger.With(applog.String("Method", "GetSeatMap"), applog.Uint("ShowtimeID", uint(showtimeID)))
 	seatInfoKey := cinema.GetShowtimeSeatsInfoKey(showtimeID)
 	seatBitmapKey := cinema.GetShowtimeSeatsBitmapKey(showtimeID)
+	seatLayoutKey := cinema.GetShowtimeSeatsLayoutKey(showtimeID)
+	seatBlockedKey := cinema.GetShowtimeSeatsBlockedKey(showtimeID)
 
 	hallLayout, _, err := c.getHallLayoutAndMapping(ctx, showtimeID)
 	if err != nil {
@@ -127,6 +154,36 @@ func (c *RedisSeatCache) GetSeatMap(ctx context.Context, showtimeID vo.ShowtimeI
 		return nil, fmt.Errorf("redis get seat bitmap error: %w", err)
 	}
 
+	layoutJson, err := c.client.Get(ctx, seatLayoutKey).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			logger.Info("seat layout not found in redis", applog.String("key", seatLayoutKey))
+			return nil, fmt.Errorf("seat layout not found in redis: %w", shared.ErrCacheMissing)
+		}
+		logger.Error("redis get seat layout error", applog.Error(err))
+		return nil, fmt.Errorf("redis get seat layout error: %w", err)
+	}
+	var layout cinema.HallLayout
+	if err := json.Unmarshal(layoutJson, &layout); err != nil {
+		logger.Error("json unmarshal seat layout error", applog.Error(err))
+		return nil, fmt.Errorf("json unmarshal seat layout error: %w", err)
+	}
+
+	blocksJson, err := c.client.Get(ctx, seatBlockedKey).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			logger.Info("seat blocks not found in redis", applog.String("key", seatBlockedKey))
+			return nil, fmt.Errorf("seat blocks not found in redis: %w", shared.ErrCacheMissing)
+		}
+		logger.Error("redis get seat blocks error", applog.Error(err))
+		return nil, fmt.Errorf("redis get seat blocks error: %w", err)
+	}
+	var blocks cinema.SeatMapBlocks
+	if err := json.Unmarshal(blocksJson, &blocks); err != nil {
+		logger.Error("json unmarshal seat blocks error", applog.Error(err))
+		return nil, fmt.Errorf("json unmarshal seat blocks error: %w", err)
+	}
+
 	seatInfos := make([]*cinema.SeatInfo, len(hallLayout))
 	for i, seat := range hallLayout {
 		status := cinema.SeatStatusAvailable
@@ -137,6 +194,10 @@ func (c *RedisSeatCache) GetSeatMap(ctx context.Context, showtimeID vo.ShowtimeI
 		if byteIndex < uint(len(bitmapBytes)) && (bitmapBytes[byteIndex]>>bitIndex)&1 == 1 {
 			status = cinema.SeatStatusLocked
 		}
+		reason, blocked := blocks.Reasons[seat.ID]
+		if blocked {
+			status = cinema.SeatStatusBlocked
+		}
 
 		seatInfos[i] = &cinema.SeatInfo{
 			ID:            seat.ID,
@@ -144,10 +205,15 @@ func (c *RedisSeatCache) GetSeatMap(ctx context.Context, showtimeID vo.ShowtimeI
 			SeatNumber:    seat.SeatNumber,
 			Type:          seat.Type,
 			Status:        status,
+			GridRow:       seat.GridRow,
+			GridCol:       seat.GridCol,
+			Block:         seat.Block,
+			BlockReason:   reason,
 		}
+		seatInfos[i].X, seatInfos[i].Y = layout.Position(seat.GridRow, seat.GridCol)
 	}
 	logger.Info("get seat map success")
-	return seatInfos, nil
+	return &cinema.SeatMap{Layout: &layout, Seats: seatInfos, Distancing: blocks.Distancing}, nil
 }
 
 // 检查座位是否已被锁定
@@ -236,8 +302,10 @@ func (c *RedisSeatCache) InvalidateSeatMap(ctx context.Context, showtimeID vo.Sh
 	logger := c.logger.With(applog.String("Method", "InvalidateSeatMap"), applog.Uint("ShowtimeID", uint(showtimeID)))
 	seatBitmapKey := cinema.GetShowtimeSeatsBitmapKey(showtimeID)
 	seatInfoKey := cinema.GetShowtimeSeatsInfoKey(showtimeID)
+	seatLayoutKey := cinema.GetShowtimeSeatsLayoutKey(showtimeID)
+	seatBlockedKey := cinema.GetShowtimeSeatsBlockedKey(showtimeID)
 
-	if err := c.client.Del(ctx, seatBitmapKey, seatInfoKey).Err(); err != nil {
+	if err := c.client.Del(ctx, seatBitmapKey, seatInfoKey, seatLayoutKey, seatBlockedKey).Err(); err != nil {
 		logger.Error("redis del error", applog.Error(err))
 		return fmt.Errorf("redis del error: %w", err)
 	}
@@ -50,10 +50,21 @@ func (c *RedisShowtimeCache) showtimeListKey(options *showtime.ShowtimeQueryOpti
 	var sb strings.Builder
 	sb.WriteString(showtimeListKeyPrefix)
 	sb.WriteString(fmt.Sprintf("%s=%v:", "movie_id", options.MovieID))            // 构建器追加字符串
+	sb.WriteString(fmt.Sprintf("%s=%v:", "cinema_id", options.CinemaID))          // 构建器追加字符串
 	sb.WriteString(fmt.Sprintf("%s=%v:", "cinema_hall_id", options.CinemaHallID)) // 构建器追加字符串
 	sb.WriteString(fmt.Sprintf("%s=%v:", "date", options.Date))                   // 构建器追加字符串
 	sb.WriteString(fmt.Sprintf("%s=%v:", "page", options.Page))                   // 构建器追加字符串
 	sb.WriteString(fmt.Sprintf("%s=%v:", "page_size", options.PageSize))          // 构建器追加字符串
+	// 场次属性过滤条件
+	sb.WriteString(fmt.Sprintf("%s=%v:", "format", options.Format))
+	sb.WriteString(fmt.Sprintf("%s=%v:", "audio_language", options.AudioLanguage))
+	sb.WriteString(fmt.Sprintf("%s=%v:", "subtitle_language", options.SubtitleLanguage))
+	sb.WriteString(fmt.Sprintf("%s=%v:", "audio_description", options.AudioDescription))
+	sb.WriteString(fmt.Sprintf("%s=%v:", "closed_captions", options.ClosedCaptions))
+	sb.WriteString(fmt.Sprintf("%s=%v:", "sensory_friendly", options.SensoryFriendly))
+	// 场次状态过滤条件（管理员可查询已取消的场次）
+	sb.WriteString(fmt.Sprintf("%s=%v:", "status", options.Status))
+	sb.WriteString(fmt.Sprintf("%s=%v:", "include_cancelled", options.IncludeCancelled))
 
 	return strings.TrimRight(sb.String(), ":") // 移除字符串右侧的:符号
 }
@@ -0,0 +1,142 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"mrs/internal/domain/trending"
+	applog "mrs/pkg/log"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// RedisTrendingCache 售票计数器实现：每个分桶、每种排行对象一个有序集合，过期时间为分桶离开所有窗口的时间
+type RedisTrendingCache struct {
+	redisClient *redis.Client
+	logger      applog.Logger
+}
+
+func NewRedisTrendingCache(redisClient *redis.Client, logger applog.Logger) trending.TrendingCache {
+	return &RedisTrendingCache{
+		redisClient: redisClient,
+		logger:      logger.With(applog.String("Component", "RedisTrendingCache")),
+	}
+}
+
+// Add 在一个事务中累加售票计入的全部分桶
+func (c *RedisTrendingCache) Add(ctx context.Context, sales ...*trending.Sale) error {
+	logger := c.logger.With(applog.String("Method", "Add"), applog.Int("sales", len(sales)))
+
+	now := time.Now()
+	commands := 0
+	_, err := c.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, sale := range sales {
+			if sale.Tickets == 0 {
+				continue
+			}
+			// 已离开所有窗口的分桶不再写入，避免重新创建过期的键
+			for _, bucket := range sale.Buckets(now) {
+				for _, subject := range trending.Subjects {
+					key := trending.GetTrendingKey(subject, bucket)
+					pipe.ZIncrBy(ctx, key, float64(sale.Tickets), strconv.FormatUint(uint64(sale.Member(subject)), 10))
+					pipe.ExpireAt(ctx, key, bucket.ExpireAt())
+					commands++
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("failed to add sales to redis", applog.Error(err))
+		return fmt.Errorf("failed to add sales to redis: %w", err)
+	}
+
+	logger.Debug("add sales to redis successfully", applog.Int("keys", commands))
+	return nil
+}
+
+// Ranking 读取全部分桶后在内存中汇总（每个窗口最多12个分桶）
+func (c *RedisTrendingCache) Ranking(ctx context.Context, subject trending.Subject, buckets []trending.Bucket) ([]*trending.Entry, error) {
+	logger := c.logger.With(applog.String("Method", "Ranking"), applog.String("subject", string(subject)),
+		applog.Int("buckets", len(buckets)))
+
+	pipe := c.redisClient.Pipeline()
+	cmds := make([]*redis.ZSliceCmd, len(buckets))
+	for i, bucket := range buckets {
+		cmds[i] = pipe.ZRangeWithScores(ctx, trending.GetTrendingKey(subject, bucket), 0, -1)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		logger.Error("failed to get trending counters from redis", applog.Error(err))
+		return nil, fmt.Errorf("failed to get trending counters from redis: %w", err)
+	}
+
+	totals := make(map[uint]int)
+	for _, cmd := range cmds {
+		for _, z := range cmd.Val() {
+			member, _ := z.Member.(string)
+			id, err := strconv.ParseUint(member, 10, 64)
+			if err != nil {
+				logger.Warn("invalid trending member", applog.String("member", member))
+				continue
+			}
+			totals[uint(id)] += int(z.Score)
+		}
+	}
+
+	entries := trending.Rank(totals)
+	logger.Debug("get trending ranking from redis successfully", applog.Int("count", len(entries)))
+	return entries, nil
+}
+
+// Replace 在一个事务中删除并重写分桶，读取方不会看到部分重建的结果
+func (c *RedisTrendingCache) Replace(ctx context.Context, buckets []trending.Bucket, counts []*trending.TicketCount) error {
+	logger := c.logger.With(applog.String("Method", "Replace"), applog.Int("buckets", len(buckets)),
+		applog.Int("counts", len(counts)))
+
+	members := make(map[string]map[string]float64)
+	for _, bucket := range buckets {
+		for _, subject := range trending.Subjects {
+			members[trending.GetTrendingKey(subject, bucket)] = make(map[string]float64)
+		}
+	}
+	for _, count := range counts {
+		sale := &trending.Sale{MovieID: count.MovieID, ShowtimeID: count.ShowtimeID}
+		for _, subject := range trending.Subjects {
+			// 不在替换范围内的分桶被忽略
+			scores, ok := members[trending.GetTrendingKey(subject, count.Bucket)]
+			if !ok {
+				continue
+			}
+			scores[strconv.FormatUint(uint64(sale.Member(subject)), 10)] += float64(count.Tickets)
+		}
+	}
+
+	now := time.Now()
+	_, err := c.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, bucket := range buckets {
+			for _, subject := range trending.Subjects {
+				key := trending.GetTrendingKey(subject, bucket)
+				pipe.Del(ctx, key)
+				scores := members[key]
+				if len(scores) == 0 || !bucket.ExpireAt().After(now) {
+					continue
+				}
+				zs := make([]*redis.Z, 0, len(scores))
+				for member, score := range scores {
+					zs = append(zs, &redis.Z{Score: score, Member: member})
+				}
+				pipe.ZAdd(ctx, key, zs...)
+				pipe.ExpireAt(ctx, key, bucket.ExpireAt())
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("failed to replace trending counters in redis", applog.Error(err))
+		return fmt.Errorf("failed to replace trending counters in redis: %w", err)
+	}
+
+	logger.Info("replace trending counters in redis successfully")
+	return nil
+}
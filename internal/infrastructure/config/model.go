@@ -12,6 +12,12 @@ type Config struct {
 	LogConfig      `mapstructure:"log"`
 	AuthConfig     `mapstructure:"auth"`
 	AdminConfig    `mapstructure:"admin"`
+	StorageConfig  `mapstructure:"storage"`
+
+	AgeRatingConfig      `mapstructure:"ageRating"`
+	LoyaltyConfig        `mapstructure:"loyalty"`
+	SchedulerConfig      `mapstructure:"scheduler"`
+	ReportDeliveryConfig `mapstructure:"reportDelivery"`
 }
 
 type ServerConfig struct {
@@ -72,3 +78,86 @@ type JWTConfig struct {
 	RefreshTokenDuration time.Duration `mapstructure:"refreshTokenDuration" yaml:"refreshTokenDuration"`
 	Issuer               string        `mapstructure:"issuer" yaml:"issuer"`
 }
+
+type StorageConfig struct {
+	Driver         string   `mapstructure:"driver"`         // 存储驱动："local"（默认）或 "s3"
+	LocalDir       string   `mapstructure:"localDir"`       // 本地存储根目录，默认 ./var/media
+	PublicBaseURL  string   `mapstructure:"publicBaseURL"`  // 媒体文件的公开访问前缀，默认 /media（由服务自身提供下载）
+	MaxImageSizeMB int64    `mapstructure:"maxImageSizeMB"` // 图片最大大小(MB)，默认 10
+	MaxVideoSizeMB int64    `mapstructure:"maxVideoSizeMB"` // 视频最大大小(MB)，默认 200
+	MaxImagePixels int      `mapstructure:"maxImagePixels"` // 图片最大像素数，默认 40000000
+	PosterWidths   []int    `mapstructure:"posterWidths"`   // 海报缩略图宽度，默认 [185, 342, 780]
+	BackdropWidths []int    `mapstructure:"backdropWidths"` // 背景图缩略图宽度，默认 [300, 780, 1280]
+	S3             S3Config `mapstructure:"s3"`
+}
+
+// S3 兼容对象存储（AWS S3、MinIO 等）
+type S3Config struct {
+	Endpoint        string `mapstructure:"endpoint"`        // 例如 https://s3.us-east-1.amazonaws.com 或 http://minio:9000
+	Region          string `mapstructure:"region"`          // 签名使用的区域，默认 us-east-1
+	Bucket          string `mapstructure:"bucket"`          // 存储桶
+	AccessKeyID     string `mapstructure:"accessKeyID"`     // 访问密钥ID
+	SecretAccessKey string `mapstructure:"secretAccessKey"` // 访问密钥
+	UsePathStyle    bool   `mapstructure:"usePathStyle"`    // 使用路径风格访问（MinIO 等需要开启）
+}
+
+type AgeRatingConfig struct {
+	Enforcement string          `mapstructure:"enforcement"` // 未达年龄时的处理："reject"（默认，拒绝下单）或 "flag"（允许下单，标记后由检票员核验）
+	Ratings     []AgeRatingRule `mapstructure:"ratings"`     // 分级对照表，未配置时使用默认的 MPA 分级
+}
+
+// 分级 -> 最低观影年龄
+type AgeRatingRule struct {
+	Rating            string `mapstructure:"rating"`            // 分级名称（不区分大小写），如 PG-13
+	MinAge            int    `mapstructure:"minAge"`            // 独立观影的最低年龄
+	Accompanied       bool   `mapstructure:"accompanied"`       // 是否允许成人陪同观影
+	AccompaniedMinAge int    `mapstructure:"accompaniedMinAge"` // 成人陪同时的最低年龄
+}
+
+// 会员积分与等级配置，数值为0时使用默认值
+type LoyaltyConfig struct {
+	EarnRate       float64           `mapstructure:"earnRate"`       // 每实付1元获得的积分，默认1
+	PointValue     float64           `mapstructure:"pointValue"`     // 每积分抵扣的金额，默认0.01
+	MaxRedeemRatio float64           `mapstructure:"maxRedeemRatio"` // 积分最多抵扣等级折扣后金额的比例，默认0.5
+	BookingWindow  time.Duration     `mapstructure:"bookingWindow"`  // 场次开始前多久开放购票，默认0不限制（等级的提前购票随之不生效）
+	Tiers          []LoyaltyTierRule `mapstructure:"tiers"`          // 会员等级，未配置时使用默认等级
+}
+
+// 等级积分门槛 -> 等级权益
+type LoyaltyTierRule struct {
+	Name         string        `mapstructure:"name"`         // 等级名称，如 silver
+	MinPoints    int           `mapstructure:"minPoints"`    // 达到该等级所需的等级积分，最低等级为0
+	Discount     float64       `mapstructure:"discount"`     // 票面金额的折扣比例，如 0.05
+	EarlyBooking time.Duration `mapstructure:"earlyBooking"` // 比普通购票窗口提前开放的时间
+}
+
+// 后台定时任务配置，间隔为0时使用默认值，小于0时禁用该任务
+type SchedulerConfig struct {
+	MovieLifecycleInterval     time.Duration `mapstructure:"movieLifecycleInterval"`     // 推进电影生命周期状态的间隔，默认1分钟
+	WatchlistAlertInterval     time.Duration `mapstructure:"watchlistAlertInterval"`     // 扫描关注列表发送开售提醒的间隔，默认1分钟
+	RecommendationInterval     time.Duration `mapstructure:"recommendationInterval"`     // 重算个性化推荐的间隔，默认1小时
+	ReportSubscriptionInterval time.Duration `mapstructure:"reportSubscriptionInterval"` // 扫描到期的报表订阅与失败重试的间隔，默认1分钟
+	TrendingRebuildInterval    time.Duration `mapstructure:"trendingRebuildInterval"`    // 从订单表重建热门排行计数器的间隔，默认10分钟
+	FunnelFlushInterval        time.Duration `mapstructure:"funnelFlushInterval"`        // 批量写入查看座位图漏斗事件的间隔，默认5秒
+}
+
+// 报表订阅的投递渠道配置，未配置 SMTP 主机时邮件渠道不可用
+type ReportDeliveryConfig struct {
+	SMTP    SMTPConfig    `mapstructure:"smtp"`
+	Webhook WebhookConfig `mapstructure:"webhook"`
+	FileDir string        `mapstructure:"fileDir"` // 本地文件渠道的输出根目录，默认 ./var/reports
+}
+
+type SMTPConfig struct {
+	Host     string        `mapstructure:"host"`     // SMTP 服务器地址
+	Port     int           `mapstructure:"port"`     // 端口，默认 587（服务器支持时使用 STARTTLS）
+	Username string        `mapstructure:"username"` // 认证用户名，为空时不认证
+	Password string        `mapstructure:"password"` // 认证密码
+	From     string        `mapstructure:"from"`     // 发件人地址，默认与用户名相同
+	Timeout  time.Duration `mapstructure:"timeout"`  // 连接与发送的超时时间，默认30秒
+}
+
+type WebhookConfig struct {
+	Secret  string        `mapstructure:"secret"`  // 签名密钥，配置后请求带 X-Signature-256 头（HMAC-SHA256）
+	Timeout time.Duration `mapstructure:"timeout"` // 请求超时时间，默认30秒
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"mrs/internal/domain/loyalty"
+	"mrs/internal/domain/movie"
+	"time"
+)
+
+// 默认的 MPA 分级：R 级未满17岁需成人陪同，NC-17 不允许陪同放宽
+var defaultAgeRatings = []*movie.AgeRequirement{
+	{Rating: "G"},
+	{Rating: "PG"},
+	{Rating: "PG-13", MinAge: 13},
+	{Rating: "R", MinAge: 17, Accompanied: true},
+	{Rating: "NC-17", MinAge: 18},
+}
+
+// NewAgeRatingPolicy 根据配置生成年龄分级策略，未配置对照表时使用默认分级
+func NewAgeRatingPolicy(cfg AgeRatingConfig) (*movie.AgeRatingPolicy, error) {
+	enforcement := movie.AgeEnforcement(cfg.Enforcement)
+	if enforcement == "" {
+		enforcement = movie.AgeEnforcementReject
+	}
+
+	requirements := defaultAgeRatings
+	if len(cfg.Ratings) > 0 {
+		requirements = make([]*movie.AgeRequirement, len(cfg.Ratings))
+		for i, rule := range cfg.Ratings {
+			requirements[i] = &movie.AgeRequirement{
+				Rating:            rule.Rating,
+				MinAge:            rule.MinAge,
+				Accompanied:       rule.Accompanied,
+				AccompaniedMinAge: rule.AccompaniedMinAge,
+			}
+		}
+	}
+	return movie.NewAgeRatingPolicy(enforcement, requirements)
+}
+
+// 默认的会员等级：白银与黄金会员享受票价折扣并提前开放购票
+var defaultLoyaltyTiers = []*loyalty.Tier{
+	{Name: "member"},
+	{Name: "silver", MinPoints: 1000, Discount: 0.05, EarlyBooking: 24 * time.Hour},
+	{Name: "gold", MinPoints: 5000, Discount: 0.1, EarlyBooking: 72 * time.Hour},
+}
+
+// NewLoyaltyProgram 根据配置生成积分规则，未配置等级时使用默认等级
+func NewLoyaltyProgram(cfg LoyaltyConfig) (*loyalty.Program, error) {
+	earnRate := cfg.EarnRate
+	if earnRate == 0 {
+		earnRate = 1
+	}
+	pointValue := cfg.PointValue
+	if pointValue == 0 {
+		pointValue = 0.01
+	}
+	maxRedeemRatio := cfg.MaxRedeemRatio
+	if maxRedeemRatio == 0 {
+		maxRedeemRatio = 0.5
+	}
+
+	tiers := defaultLoyaltyTiers
+	if len(cfg.Tiers) > 0 {
+		tiers = make([]*loyalty.Tier, len(cfg.Tiers))
+		for i, rule := range cfg.Tiers {
+			tiers[i] = &loyalty.Tier{
+				Name:         rule.Name,
+				MinPoints:    rule.MinPoints,
+				Discount:     rule.Discount,
+				EarlyBooking: rule.EarlyBooking,
+			}
+		}
+	}
+	return loyalty.NewProgram(earnRate, pointValue, maxRedeemRatio, cfg.BookingWindow, tiers)
+}
@@ -0,0 +1,34 @@
+package delivery
+
+import (
+	"mrs/internal/domain/report"
+	"mrs/internal/infrastructure/config"
+	applog "mrs/pkg/log"
+	"time"
+)
+
+const (
+	defaultFileDir        = "./var/reports"
+	defaultSMTPPort       = 587
+	defaultSMTPTimeout    = 30 * time.Second
+	defaultWebhookTimeout = 30 * time.Second
+)
+
+// NewDeliverers 根据配置创建各渠道的投递实现，未配置 SMTP 主机时不提供邮件渠道
+func NewDeliverers(cfg config.ReportDeliveryConfig, logger applog.Logger) report.Deliverers {
+	fileDir := cfg.FileDir
+	if fileDir == "" {
+		fileDir = defaultFileDir
+	}
+	deliverers := report.Deliverers{
+		report.ChannelWebhook: NewWebhookDeliverer(cfg.Webhook, logger),
+		report.ChannelFile:    NewFileDeliverer(fileDir, logger),
+	}
+
+	if cfg.SMTP.Host != "" {
+		deliverers[report.ChannelEmail] = NewSMTPDeliverer(cfg.SMTP, logger)
+	} else {
+		logger.Warn("smtp host is not configured, email delivery of report subscriptions is disabled")
+	}
+	return deliverers
+}
@@ -0,0 +1,71 @@
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"mrs/internal/domain/report"
+	applog "mrs/pkg/log"
+	"os"
+	"path/filepath"
+)
+
+// FileDeliverer 将报表写入本地目录：收件人为根目录下的相对子目录，
+// 未指定时写入 subscription-<ID> 目录。先写临时文件再重命名，读取方不会看到写了一半的文件
+type FileDeliverer struct {
+	root   string
+	logger applog.Logger
+}
+
+func NewFileDeliverer(root string, logger applog.Logger) report.Deliverer {
+	return &FileDeliverer{
+		root:   root,
+		logger: logger.With(applog.String("Component", "FileDeliverer")),
+	}
+}
+
+func (d *FileDeliverer) Deliver(ctx context.Context, delivery *report.Delivery) error {
+	dirs := delivery.Subscription.Recipients
+	if len(dirs) == 0 {
+		dirs = []string{fmt.Sprintf("subscription-%d", delivery.Subscription.ID)}
+	}
+
+	for _, dir := range dirs {
+		if !report.IsRelativeDir(dir) {
+			return fmt.Errorf("invalid report output directory: %q", dir)
+		}
+		path := filepath.Join(d.root, filepath.FromSlash(dir), delivery.File.Name)
+		if err := writeFileAtomic(path, delivery.File.Data); err != nil {
+			d.logger.Error("write report file error", applog.String("path", path), applog.Error(err))
+			return err
+		}
+		d.logger.Info("write report file successfully", applog.String("path", path),
+			applog.Int("size", len(delivery.File.Data)))
+	}
+	return nil
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create report directory error: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".report-*")
+	if err != nil {
+		return fmt.Errorf("create report temp file error: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write report file error: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close report file error: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("chmod report file error: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("rename report file error: %w", err)
+	}
+	return nil
+}
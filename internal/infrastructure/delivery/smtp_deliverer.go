@@ -0,0 +1,169 @@
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mrs/internal/domain/report"
+	"mrs/internal/infrastructure/config"
+	applog "mrs/pkg/log"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 使用隐式 TLS（SMTPS）的端口，其余端口在服务器支持时使用 STARTTLS
+const smtpsPort = 465
+
+// SMTPDeliverer 以邮件附件的形式发送报表，所有收件人在同一封邮件中
+type SMTPDeliverer struct {
+	cfg    config.SMTPConfig
+	logger applog.Logger
+}
+
+func NewSMTPDeliverer(cfg config.SMTPConfig, logger applog.Logger) report.Deliverer {
+	if cfg.Port == 0 {
+		cfg.Port = defaultSMTPPort
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultSMTPTimeout
+	}
+	if cfg.From == "" {
+		cfg.From = cfg.Username
+	}
+	return &SMTPDeliverer{
+		cfg:    cfg,
+		logger: logger.With(applog.String("Component", "SMTPDeliverer")),
+	}
+}
+
+func (d *SMTPDeliverer) Deliver(ctx context.Context, delivery *report.Delivery) error {
+	recipients := delivery.Subscription.Recipients
+	logger := d.logger.With(applog.Uint("subscription_id", uint(delivery.Subscription.ID)),
+		applog.Int("recipients", len(recipients)))
+
+	message, err := buildMessage(d.cfg.From, recipients, delivery.Subject, delivery.Body, delivery.File)
+	if err != nil {
+		logger.Error("build report email error", applog.Error(err))
+		return err
+	}
+	if err := d.send(ctx, recipients, message); err != nil {
+		logger.Error("send report email error", applog.Error(err))
+		return err
+	}
+
+	logger.Info("send report email successfully", applog.Int("size", len(message)))
+	return nil
+}
+
+func (d *SMTPDeliverer) send(ctx context.Context, recipients []string, message []byte) error {
+	addr := net.JoinHostPort(d.cfg.Host, strconv.Itoa(d.cfg.Port))
+	dialer := &net.Dialer{Timeout: d.cfg.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("connect smtp server error: %w", err)
+	}
+	if err := conn.SetDeadline(time.Now().Add(d.cfg.Timeout)); err != nil {
+		conn.Close()
+		return fmt.Errorf("set smtp deadline error: %w", err)
+	}
+	tlsConfig := &tls.Config{ServerName: d.cfg.Host}
+	if d.cfg.Port == smtpsPort {
+		conn = tls.Client(conn, tlsConfig)
+	}
+
+	client, err := smtp.NewClient(conn, d.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("create smtp client error: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok && d.cfg.Port != smtpsPort {
+		if err := client.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("smtp starttls error: %w", err)
+		}
+	}
+	if d.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", d.cfg.Username, d.cfg.Password, d.cfg.Host)); err != nil {
+			return fmt.Errorf("smtp auth error: %w", err)
+		}
+	}
+	if err := client.Mail(d.cfg.From); err != nil {
+		return fmt.Errorf("smtp mail from error: %w", err)
+	}
+	for _, recipient := range recipients {
+		if err := client.Rcpt(recipient); err != nil {
+			return fmt.Errorf("smtp rcpt %s error: %w", recipient, err)
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp data error: %w", err)
+	}
+	if _, err := w.Write(message); err != nil {
+		return fmt.Errorf("write smtp message error: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("close smtp message error: %w", err)
+	}
+	return client.Quit()
+}
+
+// 构造 multipart/mixed 邮件：纯文本正文 + 报表附件（base64）
+func buildMessage(from string, to []string, subject, body string, file *report.File) ([]byte, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	headers := []string{
+		"From: " + from,
+		"To: " + strings.Join(to, ", "),
+		"Subject: " + mime.QEncoding.Encode("utf-8", subject),
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: multipart/mixed; boundary=" + mw.Boundary(),
+	}
+	buf.WriteString(strings.Join(headers, "\r\n") + "\r\n\r\n")
+
+	text, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=utf-8"},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create email body error: %w", err)
+	}
+	writeBase64Lines(text, []byte(body))
+
+	attachment, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {file.ContentType},
+		"Content-Transfer-Encoding": {"base64"},
+		"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": file.Name})},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create email attachment error: %w", err)
+	}
+	writeBase64Lines(attachment, file.Data)
+
+	if err := mw.Close(); err != nil {
+		return nil, fmt.Errorf("close email message error: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// 按 RFC 2045 每行不超过 76 个字符写出 base64
+func writeBase64Lines(w io.Writer, data []byte) {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		w.Write([]byte(encoded[:76] + "\r\n"))
+		encoded = encoded[76:]
+	}
+	w.Write([]byte(encoded + "\r\n"))
+}
@@ -0,0 +1,89 @@
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mrs/internal/domain/report"
+	"mrs/internal/infrastructure/config"
+	applog "mrs/pkg/log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// 错误响应体最多读取的字节数
+const webhookErrorBodyMaxSize = 1024
+
+// WebhookDeliverer 以 POST 请求把报表文件发送到每个收件地址，请求体为文件内容，
+// 报表信息放在 X-Report-* 请求头中；配置了密钥时带 X-Signature-256 头（sha256=请求体的 HMAC-SHA256）。
+// 任一地址返回非 2xx 状态码即视为失败，重试时所有地址都会重新发送
+type WebhookDeliverer struct {
+	secret string
+	client *http.Client
+	logger applog.Logger
+}
+
+func NewWebhookDeliverer(cfg config.WebhookConfig, logger applog.Logger) report.Deliverer {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeout
+	}
+	return &WebhookDeliverer{
+		secret: cfg.Secret,
+		client: &http.Client{Timeout: timeout},
+		logger: logger.With(applog.String("Component", "WebhookDeliverer")),
+	}
+}
+
+func (d *WebhookDeliverer) Deliver(ctx context.Context, delivery *report.Delivery) error {
+	var errs []error
+	for _, target := range delivery.Subscription.Recipients {
+		if err := d.post(ctx, target, delivery); err != nil {
+			d.logger.Error("deliver report webhook error", applog.String("url", target), applog.Error(err))
+			errs = append(errs, fmt.Errorf("webhook %s: %w", target, err))
+			continue
+		}
+		d.logger.Info("deliver report webhook successfully", applog.String("url", target))
+	}
+	return errors.Join(errs...)
+}
+
+func (d *WebhookDeliverer) post(ctx context.Context, target string, delivery *report.Delivery) error {
+	file := delivery.File
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(file.Data))
+	if err != nil {
+		return fmt.Errorf("create webhook request error: %w", err)
+	}
+	req.Header.Set("Content-Type", file.ContentType)
+	req.Header.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": file.Name}))
+	req.Header.Set("X-Report-Subscription-Id", strconv.FormatUint(uint64(delivery.Subscription.ID), 10))
+	req.Header.Set("X-Report-Run-Id", strconv.FormatUint(uint64(delivery.Run.ID), 10))
+	req.Header.Set("X-Report-Type", string(delivery.Subscription.ReportType))
+	req.Header.Set("X-Report-Period-Start", delivery.Run.PeriodStart.Format(time.RFC3339))
+	req.Header.Set("X-Report-Period-End", delivery.Run.PeriodEnd.Format(time.RFC3339))
+	if d.secret != "" {
+		mac := hmac.New(sha256.New, []byte(d.secret))
+		mac.Write(file.Data)
+		req.Header.Set("X-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send webhook request error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, webhookErrorBodyMaxSize))
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
@@ -0,0 +1,31 @@
+package notification
+
+import (
+	"context"
+	"mrs/internal/domain/notification"
+	applog "mrs/pkg/log"
+)
+
+// LogNotifier 将通知事件写入日志，作为默认的通知实现
+type LogNotifier struct {
+	logger applog.Logger
+}
+
+func NewLogNotifier(logger applog.Logger) notification.Notifier {
+	return &LogNotifier{
+		logger: logger.With(applog.String("Component", "LogNotifier")),
+	}
+}
+
+func (n *LogNotifier) Notify(ctx context.Context, events []*notification.Event) error {
+	for _, event := range events {
+		n.logger.Info("notification event",
+			applog.String("type", string(event.Type)),
+			applog.Uint("user_id", uint(event.UserID)),
+			applog.String("subject", event.Subject),
+			applog.Any("payload", event.Payload),
+			applog.Time("occurred_at", event.OccurredAt),
+		)
+	}
+	return nil
+}
@@ -278,3 +278,90 @@ func (r *movieRepositoryWithCircuitBreaker) ReplaceGenresForMovie(ctx context.Co
 
 	return nil
 }
+
+func (r *movieRepositoryWithCircuitBreaker) ApplyReviewDelta(ctx context.Context, id vo.MovieID, countDelta int, scoreDelta int) error {
+	logger := r.logger.With(applog.String("Method", "ApplyReviewDelta"))
+
+	run := func(ctx context.Context) error {
+		return r.repo.ApplyReviewDelta(ctx, id, countDelta, scoreDelta)
+	}
+
+	fallback := func(ctx context.Context, err error) error {
+		return shared.ErrCircuitWriteOperationBusy
+	}
+
+	err := r.execute(ctx, cmdMovieWrite, run, fallback)
+	if err != nil {
+		logger.Error("apply review delta circuit breaker fallback", applog.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *movieRepositoryWithCircuitBreaker) ReplaceCreditsForMovie(ctx context.Context, id vo.MovieID, credits []*movie.Credit) error {
+	logger := r.logger.With(applog.String("Method", "ReplaceCreditsForMovie"))
+
+	run := func(ctx context.Context) error {
+		return r.repo.ReplaceCreditsForMovie(ctx, id, credits)
+	}
+
+	fallback := func(ctx context.Context, err error) error {
+		return shared.ErrCircuitWriteOperationBusy
+	}
+
+	err := r.execute(ctx, cmdMovieWrite, run, fallback)
+	if err != nil {
+		logger.Error("replace credits for movie circuit breaker fallback", applog.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *movieRepositoryWithCircuitBreaker) FindByStatuses(ctx context.Context, statuses []movie.Status) ([]*movie.Movie, error) {
+	logger := r.logger.With(applog.String("Method", "FindByStatuses"))
+
+	var movieResults []*movie.Movie
+
+	run := func(ctx context.Context) error {
+		movies, err := r.repo.FindByStatuses(ctx, statuses)
+		if err != nil {
+			return err
+		}
+		movieResults = movies
+		return nil
+	}
+
+	fallback := func(ctx context.Context, err error) error {
+		return shared.ErrCircuitReadOperationBusy
+	}
+
+	err := r.execute(ctx, cmdMovieRead, run, fallback)
+	if err != nil {
+		logger.Warn("find movies by statuses circuit breaker fallback", applog.Error(err))
+		return nil, err
+	}
+
+	return movieResults, nil
+}
+
+func (r *movieRepositoryWithCircuitBreaker) UpdateStatus(ctx context.Context, id vo.MovieID, status movie.Status) error {
+	logger := r.logger.With(applog.String("Method", "UpdateStatus"))
+
+	run := func(ctx context.Context) error {
+		return r.repo.UpdateStatus(ctx, id, status)
+	}
+
+	fallback := func(ctx context.Context, err error) error {
+		return shared.ErrCircuitWriteOperationBusy
+	}
+
+	err := r.execute(ctx, cmdMovieWrite, run, fallback)
+	if err != nil {
+		logger.Error("update movie status circuit breaker fallback", applog.Error(err))
+		return err
+	}
+
+	return nil
+}
@@ -184,6 +184,147 @@ func (r *showtimeRepositoryWithCircuitBreaker) Update(ctx context.Context, st *s
 	return nil
 }
 
+func (r *showtimeRepositoryWithCircuitBreaker) UpdateLayoutVersion(ctx context.Context, id vo.ShowtimeID, version int) error {
+	logger := r.logger.With(applog.String("Method", "UpdateLayoutVersion"))
+
+	run := func(ctx context.Context) error {
+		return r.repo.UpdateLayoutVersion(ctx, id, version)
+	}
+
+	fallback := func(ctx context.Context, err error) error {
+		return shared.ErrCircuitWriteOperationBusy
+	}
+
+	err := r.execute(ctx, cmdShowtimeWrite, run, fallback)
+	if err != nil {
+		logger.Error("update showtime layout version circuit breaker fallback", applog.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *showtimeRepositoryWithCircuitBreaker) UpdateAccessibility(ctx context.Context, id vo.ShowtimeID, accessibility showtime.Accessibility) error {
+	logger := r.logger.With(applog.String("Method", "UpdateAccessibility"))
+
+	run := func(ctx context.Context) error {
+		return r.repo.UpdateAccessibility(ctx, id, accessibility)
+	}
+
+	fallback := func(ctx context.Context, err error) error {
+		return shared.ErrCircuitWriteOperationBusy
+	}
+
+	err := r.execute(ctx, cmdShowtimeWrite, run, fallback)
+	if err != nil {
+		logger.Error("update showtime accessibility circuit breaker fallback", applog.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *showtimeRepositoryWithCircuitBreaker) UpdateSubtitleLanguage(ctx context.Context, id vo.ShowtimeID, language string) error {
+	logger := r.logger.With(applog.String("Method", "UpdateSubtitleLanguage"))
+
+	run := func(ctx context.Context) error {
+		return r.repo.UpdateSubtitleLanguage(ctx, id, language)
+	}
+
+	fallback := func(ctx context.Context, err error) error {
+		return shared.ErrCircuitWriteOperationBusy
+	}
+
+	err := r.execute(ctx, cmdShowtimeWrite, run, fallback)
+	if err != nil {
+		logger.Error("update showtime subtitle language circuit breaker fallback", applog.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *showtimeRepositoryWithCircuitBreaker) FindMovieIDsWithUpcomingShowtimes(ctx context.Context, movieIDs []vo.MovieID, now time.Time) ([]vo.MovieID, error) {
+	logger := r.logger.With(applog.String("Method", "FindMovieIDsWithUpcomingShowtimes"))
+
+	var movieIDResults []vo.MovieID
+
+	run := func(ctx context.Context) error {
+		ids, err := r.repo.FindMovieIDsWithUpcomingShowtimes(ctx, movieIDs, now)
+		if err != nil {
+			return err
+		}
+		movieIDResults = ids
+		return nil
+	}
+
+	fallback := func(ctx context.Context, err error) error {
+		return shared.ErrCircuitReadOperationBusy
+	}
+
+	err := r.execute(ctx, cmdShowtimeRead, run, fallback)
+	if err != nil {
+		logger.Warn("find movies with upcoming showtimes circuit breaker fallback", applog.Error(err))
+		return nil, err
+	}
+
+	return movieIDResults, nil
+}
+
+func (r *showtimeRepositoryWithCircuitBreaker) FindUpcomingByMovieIDs(ctx context.Context, movieIDs []vo.MovieID, now time.Time) ([]*showtime.Showtime, error) {
+	logger := r.logger.With(applog.String("Method", "FindUpcomingByMovieIDs"))
+
+	var showtimeResults []*showtime.Showtime
+
+	run := func(ctx context.Context) error {
+		showtimes, err := r.repo.FindUpcomingByMovieIDs(ctx, movieIDs, now)
+		if err != nil {
+			return err
+		}
+		showtimeResults = showtimes
+		return nil
+	}
+
+	fallback := func(ctx context.Context, err error) error {
+		return shared.ErrCircuitReadOperationBusy
+	}
+
+	err := r.execute(ctx, cmdShowtimeRead, run, fallback)
+	if err != nil {
+		logger.Warn("find upcoming showtimes by movies circuit breaker fallback", applog.Error(err))
+		return nil, err
+	}
+
+	return showtimeResults, nil
+}
+
+func (r *showtimeRepositoryWithCircuitBreaker) FindUpcomingByHallID(ctx context.Context, hallID vo.CinemaHallID, now time.Time) ([]*showtime.Showtime, error) {
+	logger := r.logger.With(applog.String("Method", "FindUpcomingByHallID"))
+
+	var showtimeResults []*showtime.Showtime
+
+	run := func(ctx context.Context) error {
+		showtimes, err := r.repo.FindUpcomingByHallID(ctx, hallID, now)
+		if err != nil {
+			return err
+		}
+		showtimeResults = showtimes
+		return nil
+	}
+
+	fallback := func(ctx context.Context, err error) error {
+		return shared.ErrCircuitReadOperationBusy
+	}
+
+	err := r.execute(ctx, cmdShowtimeRead, run, fallback)
+	if err != nil {
+		logger.Warn("find upcoming showtimes by hall circuit breaker fallback", applog.Error(err))
+		return nil, err
+	}
+
+	return showtimeResults, nil
+}
+
 func (r *showtimeRepositoryWithCircuitBreaker) Delete(ctx context.Context, id vo.ShowtimeID) error {
 	logger := r.logger.With(applog.String("Method", "Delete"))
 
@@ -2,6 +2,7 @@ package models
 
 import (
 	"mrs/internal/domain/booking"
+	"mrs/internal/domain/movie"
 	"mrs/internal/domain/shared/vo"
 	"time"
 
@@ -11,14 +12,28 @@ import (
 // 订单表
 type BookingGorm struct {
 	gorm.Model
-	UserID      uint             `gorm:"not null;index;foreignKey:UserID,references:ID"`
-	ShowtimeID  uint             `gorm:"not null;index;foreignKey:ShowtimeID,references:ID"`
-	BookingTime time.Time        `gorm:"not null"`
-	TotalAmount float64          `gorm:"not null"`
-	Status      string           `gorm:"not null"`
+	UserID      uint      `gorm:"not null;index;foreignKey:UserID,references:ID"`
+	ShowtimeID  uint      `gorm:"not null;index;foreignKey:ShowtimeID,references:ID"`
+	BookingTime time.Time `gorm:"not null"`
+	TotalAmount float64   `gorm:"not null"`
+	Status      string    `gorm:"not null"`
+	ConfirmedAt *time.Time
 	User        UserGorm         `gorm:"foreignKey:UserID"`
 	Showtime    ShowtimeGorm     `gorm:"foreignKey:ShowtimeID"`
 	BookedSeats []BookedSeatGorm `gorm:"foreignKey:BookingID"`
+
+	// 下单时的年龄要求快照（电影无年龄限制时 age_rating 为空）
+	AgeRating         string `gorm:"type:varchar(50)"`
+	MinAge            int    `gorm:"not null;default:0"`
+	Accompanied       bool   `gorm:"not null;default:false"`
+	AccompaniedMinAge int    `gorm:"not null;default:0"`
+	AgeCheck          string `gorm:"type:varchar(20)"`
+
+	// 下单时的会员折扣与积分抵扣
+	LoyaltyTier    string  `gorm:"type:varchar(30)"`
+	TierDiscount   float64 `gorm:"not null;default:0"`
+	PointsRedeemed int     `gorm:"not null;default:0"`
+	PointsDiscount float64 `gorm:"not null;default:0"`
 }
 
 // TableName 指定表名
@@ -32,25 +47,62 @@ func (b *BookingGorm) ToDomain() *booking.Booking {
 	for i, bookedSeat := range b.BookedSeats {
 		bookedSeats[i] = bookedSeat.ToDomain()
 	}
+	var ageRequirement *movie.AgeRequirement
+	if b.AgeRating != "" {
+		ageRequirement = &movie.AgeRequirement{
+			Rating:            b.AgeRating,
+			MinAge:            b.MinAge,
+			Accompanied:       b.Accompanied,
+			AccompaniedMinAge: b.AccompaniedMinAge,
+		}
+	}
+	var discount *booking.Discount
+	if b.TierDiscount > 0 || b.PointsRedeemed > 0 {
+		discount = &booking.Discount{
+			Tier:           b.LoyaltyTier,
+			TierDiscount:   b.TierDiscount,
+			PointsRedeemed: b.PointsRedeemed,
+			PointsDiscount: b.PointsDiscount,
+		}
+	}
 	return &booking.Booking{
-		ID:          vo.BookingID(b.ID),
-		UserID:      vo.UserID(b.UserID),
-		ShowtimeID:  vo.ShowtimeID(b.ShowtimeID),
-		TotalAmount: b.TotalAmount,
-		BookingTime: b.BookingTime,
-		Status:      booking.BookingStatus(b.Status),
-		BookedSeats: bookedSeats,
+		ID:             vo.BookingID(b.ID),
+		UserID:         vo.UserID(b.UserID),
+		ShowtimeID:     vo.ShowtimeID(b.ShowtimeID),
+		TotalAmount:    b.TotalAmount,
+		BookingTime:    b.BookingTime,
+		Status:         booking.BookingStatus(b.Status),
+		ConfirmedAt:    b.ConfirmedAt,
+		BookedSeats:    bookedSeats,
+		AgeRequirement: ageRequirement,
+		AgeCheck:       movie.AgeCheck(b.AgeCheck),
+		Discount:       discount,
 	}
 }
 
 // BookingGormFromDomain 将领域模型转换为GORM模型
 func BookingGormFromDomain(b *booking.Booking) *BookingGorm {
-	return &BookingGorm{
+	bookingGorm := &BookingGorm{
 		Model:       gorm.Model{ID: uint(b.ID)},
 		UserID:      uint(b.UserID),
 		ShowtimeID:  uint(b.ShowtimeID),
 		TotalAmount: b.TotalAmount,
 		BookingTime: b.BookingTime,
 		Status:      string(b.Status),
+		ConfirmedAt: b.ConfirmedAt,
+		AgeCheck:    string(b.AgeCheck),
+	}
+	if req := b.AgeRequirement; req != nil {
+		bookingGorm.AgeRating = req.Rating
+		bookingGorm.MinAge = req.MinAge
+		bookingGorm.Accompanied = req.Accompanied
+		bookingGorm.AccompaniedMinAge = req.AccompaniedMinAge
+	}
+	if d := b.Discount; d != nil {
+		bookingGorm.LoyaltyTier = d.Tier
+		bookingGorm.TierDiscount = d.TierDiscount
+		bookingGorm.PointsRedeemed = d.PointsRedeemed
+		bookingGorm.PointsDiscount = d.PointsDiscount
 	}
+	return bookingGorm
 }
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"mrs/internal/domain/cinema"
+	"mrs/internal/domain/shared/vo"
+
+	"gorm.io/gorm"
+)
+
+// 影院表
+type CinemaGorm struct {
+	gorm.Model
+	Name     string `gorm:"type:varchar(100);uniqueIndex;not null"` // 影院名称
+	Address  string `gorm:"type:varchar(255)"`                      // 详细地址
+	City     string `gorm:"type:varchar(50);index"`                 // 所在城市
+	Phone    string `gorm:"type:varchar(30)"`                       // 联系电话
+	Timezone string `gorm:"type:varchar(64);not null;default:'Asia/Shanghai'"`
+	// 营业时间，以JSON格式存储
+	OpeningHours []*cinema.OpeningHours `gorm:"type:text;serializer:json"`
+
+	// 影院下存在影厅时禁止删除
+	CinemaHalls []CinemaHallGorm `gorm:"foreignKey:CinemaID;constraint:OnDelete:RESTRICT"`
+}
+
+// TableName 指定表名
+func (CinemaGorm) TableName() string {
+	return "cinemas"
+}
+
+func (c *CinemaGorm) ToDomain() *cinema.Cinema {
+	return &cinema.Cinema{
+		ID:           vo.CinemaID(c.ID),
+		Name:         c.Name,
+		Address:      c.Address,
+		City:         c.City,
+		Phone:        c.Phone,
+		Timezone:     c.Timezone,
+		OpeningHours: c.OpeningHours,
+	}
+}
+
+func CinemaGormFromDomain(c *cinema.Cinema) *CinemaGorm {
+	return &CinemaGorm{
+		Model:        gorm.Model{ID: uint(c.ID)},
+		Name:         c.Name,
+		Address:      c.Address,
+		City:         c.City,
+		Phone:        c.Phone,
+		Timezone:     c.Timezone,
+		OpeningHours: c.OpeningHours,
+	}
+}
@@ -10,11 +10,22 @@ import (
 // 影厅表
 type CinemaHallGorm struct {
 	gorm.Model
-	Name        string `gorm:"type:varchar(50);uniqueIndex;not null;"` // 影厅名称 (例如: "1号厅", "IMAX厅")。
-	ScreenType  string `gorm:"type:varchar(50)"`                       // 屏幕类型，如 "2D", "3D", "IMAX"
-	SoundSystem string `gorm:"type:varchar(100)"`                      // 音响系统
-	RowCount    int    `gorm:"type:int;not null;"`                     // 行数
-	ColCount    int    `gorm:"type:int;not null;"`                     // 列数
+	// 所属影院ID，历史数据可为空；影厅名称在同一影院内唯一
+	CinemaID *uint `gorm:"index;uniqueIndex:idx_cinema_hall_name,priority:1"`
+	// 影厅名称 (例如: "1号厅", "IMAX厅")。
+	Name string `gorm:"type:varchar(50);uniqueIndex:idx_cinema_hall_name,priority:2;not null;"`
+
+	ScreenType  string `gorm:"type:varchar(50)"`   // 屏幕类型，如 "2D", "3D", "IMAX"
+	SoundSystem string `gorm:"type:varchar(100)"`  // 音响系统
+	RowCount    int    `gorm:"type:int;not null;"` // 行数
+	ColCount    int    `gorm:"type:int;not null;"` // 列数
+
+	// 几何布局（JSON），历史数据可为空
+	Layout *cinema.HallLayout `gorm:"type:text;serializer:json"`
+	// 社交距离策略（JSON），为空表示不启用
+	DistancingPolicy *cinema.DistancingPolicy `gorm:"type:text;serializer:json"`
+	// 当前布局版本
+	LayoutVersion int `gorm:"type:int;not null;default:1"`
 
 	Seats []SeatGorm `gorm:"foreignKey:CinemaHallID;OnDelete:CASCADE"`
 }
@@ -34,12 +45,17 @@ func (c *CinemaHallGorm) ToDomain() *cinema.CinemaHall {
 	}
 	return &cinema.CinemaHall{
 		ID:          vo.CinemaHallID(c.ID),
+		CinemaID:    vo.CinemaID(derefUint(c.CinemaID)),
 		Name:        c.Name,
 		ScreenType:  c.ScreenType,
 		SoundSystem: c.SoundSystem,
 		RowCount:    c.RowCount,
 		ColCount:    c.ColCount,
+		Layout:      c.Layout,
 		Seats:       seats,
+
+		DistancingPolicy: c.DistancingPolicy,
+		LayoutVersion:    c.LayoutVersion,
 	}
 }
 
@@ -47,10 +63,31 @@ func (c *CinemaHallGorm) ToDomain() *cinema.CinemaHall {
 func CinemaHallGormFromDomain(c *cinema.CinemaHall) *CinemaHallGorm {
 	return &CinemaHallGorm{
 		Model:       gorm.Model{ID: uint(c.ID)},
+		CinemaID:    nullableUint(uint(c.CinemaID)),
 		Name:        c.Name,
 		ScreenType:  c.ScreenType,
 		SoundSystem: c.SoundSystem,
 		RowCount:    c.RowCount,
 		ColCount:    c.ColCount,
+		Layout:      c.Layout,
+
+		DistancingPolicy: c.DistancingPolicy,
+		LayoutVersion:    c.LayoutVersion,
+	}
+}
+
+// 将可空外键转换为领域层ID（0 表示未设置）
+func derefUint(v *uint) uint {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+// 将领域层ID转换为可空外键（0 转换为 NULL）
+func nullableUint(v uint) *uint {
+	if v == 0 {
+		return nil
 	}
+	return &v
 }
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"mrs/internal/domain/booking"
+	"mrs/internal/domain/shared/vo"
+	"time"
+)
+
+// 每日销售事实表（业务日期 × 场次 × 电影 × 影厅），按场次口径的查询走 showtime_id 索引
+type DailySalesGorm struct {
+	SalesDate    time.Time `gorm:"type:date;primaryKey"`
+	ShowtimeID   uint      `gorm:"primaryKey;autoIncrement:false;index"`
+	MovieID      uint      `gorm:"primaryKey;autoIncrement:false"`
+	CinemaHallID uint      `gorm:"primaryKey;autoIncrement:false"`
+
+	Bookings         int     `gorm:"not null;default:0"`
+	Tickets          int     `gorm:"not null;default:0"`
+	Gross            float64 `gorm:"type:decimal(14,2);not null;default:0"`
+	Discounts        float64 `gorm:"type:decimal(14,2);not null;default:0"`
+	RefundedBookings int     `gorm:"not null;default:0"`
+	RefundedTickets  int     `gorm:"not null;default:0"`
+	Refunds          float64 `gorm:"type:decimal(14,2);not null;default:0"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TableName 指定表名
+func (DailySalesGorm) TableName() string {
+	return "daily_sales"
+}
+
+func (d *DailySalesGorm) ToDomain() *booking.DailySales {
+	return &booking.DailySales{
+		Date:             d.SalesDate,
+		ShowtimeID:       vo.ShowtimeID(d.ShowtimeID),
+		MovieID:          vo.MovieID(d.MovieID),
+		CinemaHallID:     vo.CinemaHallID(d.CinemaHallID),
+		Bookings:         d.Bookings,
+		Tickets:          d.Tickets,
+		Gross:            d.Gross,
+		Discounts:        d.Discounts,
+		RefundedBookings: d.RefundedBookings,
+		RefundedTickets:  d.RefundedTickets,
+		Refunds:          d.Refunds,
+	}
+}
+
+func DailySalesGormFromDomain(d *booking.DailySales) *DailySalesGorm {
+	return &DailySalesGorm{
+		SalesDate:        d.Date,
+		ShowtimeID:       uint(d.ShowtimeID),
+		MovieID:          uint(d.MovieID),
+		CinemaHallID:     uint(d.CinemaHallID),
+		Bookings:         d.Bookings,
+		Tickets:          d.Tickets,
+		Gross:            d.Gross,
+		Discounts:        d.Discounts,
+		RefundedBookings: d.RefundedBookings,
+		RefundedTickets:  d.RefundedTickets,
+		Refunds:          d.Refunds,
+	}
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"mrs/internal/domain/analytics"
+	"mrs/internal/domain/shared/vo"
+	"time"
+)
+
+// 转化漏斗事件表（只追加），会话按 user_id + showtime_id 聚合，报表按 occurred_at 范围扫描
+type FunnelEventGorm struct {
+	ID         uint      `gorm:"primaryKey"`
+	EventType  string    `gorm:"type:varchar(30);not null"`
+	UserID     uint      `gorm:"not null;index:idx_user_showtime,priority:1"`
+	ShowtimeID uint      `gorm:"not null;index:idx_user_showtime,priority:2"`
+	BookingID  uint      `gorm:"not null;default:0;index"`
+	Seats      int       `gorm:"not null;default:0"`
+	OccurredAt time.Time `gorm:"not null;index"`
+}
+
+// TableName 指定表名
+func (FunnelEventGorm) TableName() string {
+	return "funnel_events"
+}
+
+func (e *FunnelEventGorm) ToDomain() *analytics.FunnelEvent {
+	return &analytics.FunnelEvent{
+		ID:         vo.FunnelEventID(e.ID),
+		Type:       analytics.EventType(e.EventType),
+		UserID:     vo.UserID(e.UserID),
+		ShowtimeID: vo.ShowtimeID(e.ShowtimeID),
+		BookingID:  vo.BookingID(e.BookingID),
+		Seats:      e.Seats,
+		OccurredAt: e.OccurredAt,
+	}
+}
+
+func FunnelEventGormFromDomain(e *analytics.FunnelEvent) *FunnelEventGorm {
+	return &FunnelEventGorm{
+		ID:         uint(e.ID),
+		EventType:  string(e.Type),
+		UserID:     uint(e.UserID),
+		ShowtimeID: uint(e.ShowtimeID),
+		BookingID:  uint(e.BookingID),
+		Seats:      e.Seats,
+		OccurredAt: e.OccurredAt,
+	}
+}
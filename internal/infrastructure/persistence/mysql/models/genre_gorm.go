@@ -10,8 +10,11 @@ import (
 // 类型表
 type GenreGorm struct {
 	gorm.Model
-	Name   string       `gorm:"type:varchar(100);uniqueIndex;not null"` // 类型名称：科幻...
+	Name   string       `gorm:"type:varchar(100);uniqueIndex;index:idx_genres_name_fulltext,class:FULLTEXT;not null"` // 类型名称：科幻...
 	Movies []*MovieGorm `gorm:"many2many:movies_genres;joinForeignKey:genre_id;joinReferences:movie_id;constraint:OnDelete:RESTRICT;"`
+
+	// 一对多：类型名称的翻译
+	Translations []GenreTranslationGorm `gorm:"foreignKey:GenreID;constraint:OnDelete:CASCADE;"`
 }
 
 // TableName 指定表名
@@ -20,9 +23,14 @@ func (GenreGorm) TableName() string {
 }
 
 func (g *GenreGorm) ToDomain() *movie.Genre {
+	translations := make([]*movie.GenreTranslation, len(g.Translations))
+	for i, translation := range g.Translations {
+		translations[i] = translation.ToDomain()
+	}
 	return &movie.Genre{
-		ID:   vo.GenreID(g.ID),
-		Name: g.Name,
+		ID:           vo.GenreID(g.ID),
+		Name:         g.Name,
+		Translations: translations,
 	}
 }
 
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"mrs/internal/domain/cinema"
+	"mrs/internal/domain/shared/vo"
+
+	"gorm.io/gorm"
+)
+
+// 影厅布局版本表（只追加，不修改）
+type HallLayoutVersionGorm struct {
+	gorm.Model
+	CinemaHallID uint               `gorm:"not null;uniqueIndex:idx_hall_layout_version,priority:1"`
+	CinemaHall   CinemaHallGorm     `gorm:"foreignKey:CinemaHallID;constraint:OnDelete:CASCADE"`
+	Version      int                `gorm:"type:int;not null;uniqueIndex:idx_hall_layout_version,priority:2"`
+	Layout       *cinema.HallLayout `gorm:"type:text;serializer:json"`
+	SeatCount    int                `gorm:"type:int;not null"`
+}
+
+// TableName 指定表名
+func (HallLayoutVersionGorm) TableName() string {
+	return "hall_layout_versions"
+}
+
+func (v *HallLayoutVersionGorm) ToDomain() *cinema.HallLayoutVersion {
+	return &cinema.HallLayoutVersion{
+		ID:           vo.HallLayoutVersionID(v.ID),
+		CinemaHallID: vo.CinemaHallID(v.CinemaHallID),
+		Version:      v.Version,
+		Layout:       v.Layout,
+		SeatCount:    v.SeatCount,
+		CreatedAt:    v.CreatedAt,
+	}
+}
+
+func HallLayoutVersionGormFromDomain(v *cinema.HallLayoutVersion) *HallLayoutVersionGorm {
+	return &HallLayoutVersionGorm{
+		Model:        gorm.Model{ID: uint(v.ID)},
+		CinemaHallID: uint(v.CinemaHallID),
+		Version:      v.Version,
+		Layout:       v.Layout,
+		SeatCount:    v.SeatCount,
+	}
+}
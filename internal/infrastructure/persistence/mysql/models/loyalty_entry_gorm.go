@@ -0,0 +1,44 @@
+package models
+
+import (
+	"mrs/internal/domain/loyalty"
+	"mrs/internal/domain/shared/vo"
+	"time"
+)
+
+// 积分流水表（只追加），余额按 user_id 汇总，冲销按 booking_id 查询订单的流水
+type LoyaltyEntryGorm struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    uint      `gorm:"not null;index:idx_user_created,priority:1"`
+	BookingID uint      `gorm:"not null;default:0;index"`
+	EntryType string    `gorm:"type:varchar(20);not null"`
+	Points    int       `gorm:"not null"`
+	CreatedAt time.Time `gorm:"not null;index:idx_user_created,priority:2"`
+}
+
+// TableName 指定表名
+func (LoyaltyEntryGorm) TableName() string {
+	return "loyalty_entries"
+}
+
+func (e *LoyaltyEntryGorm) ToDomain() *loyalty.Entry {
+	return &loyalty.Entry{
+		ID:        vo.LoyaltyEntryID(e.ID),
+		UserID:    vo.UserID(e.UserID),
+		BookingID: vo.BookingID(e.BookingID),
+		Type:      loyalty.EntryType(e.EntryType),
+		Points:    e.Points,
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+func LoyaltyEntryGormFromDomain(e *loyalty.Entry) *LoyaltyEntryGorm {
+	return &LoyaltyEntryGorm{
+		ID:        uint(e.ID),
+		UserID:    uint(e.UserID),
+		BookingID: uint(e.BookingID),
+		EntryType: string(e.Type),
+		Points:    e.Points,
+		CreatedAt: e.CreatedAt,
+	}
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"mrs/internal/domain/movie"
+	"mrs/internal/domain/shared/vo"
+	"time"
+)
+
+// 电影媒体资源表（每部电影每种类型一条记录，重新上传时替换）
+type MediaAssetGorm struct {
+	ID          uint                  `gorm:"primaryKey"`
+	MovieID     uint                  `gorm:"not null;uniqueIndex:idx_movie_media_kind,priority:1"`
+	Kind        string                `gorm:"type:varchar(20);not null;uniqueIndex:idx_movie_media_kind,priority:2"`
+	ContentType string                `gorm:"type:varchar(100);not null"`
+	Hash        string                `gorm:"type:char(64);not null;index"` // 原始文件内容的SHA-256
+	Size        int64                 `gorm:"not null"`
+	Width       int                   `gorm:"not null;default:0"`
+	Height      int                   `gorm:"not null;default:0"`
+	Variants    []*movie.MediaVariant `gorm:"type:text;serializer:json"` // 各尺寸版本的存储键与URL
+	CreatedAt   time.Time
+}
+
+// TableName 指定表名
+func (MediaAssetGorm) TableName() string {
+	return "movie_media_assets"
+}
+
+func (a *MediaAssetGorm) ToDomain() *movie.MediaAsset {
+	return &movie.MediaAsset{
+		ID:          vo.MediaAssetID(a.ID),
+		MovieID:     vo.MovieID(a.MovieID),
+		Kind:        movie.MediaKind(a.Kind),
+		ContentType: a.ContentType,
+		Hash:        a.Hash,
+		Size:        a.Size,
+		Width:       a.Width,
+		Height:      a.Height,
+		Variants:    a.Variants,
+		CreatedAt:   a.CreatedAt,
+	}
+}
+
+func MediaAssetGormFromDomain(a *movie.MediaAsset) *MediaAssetGorm {
+	return &MediaAssetGorm{
+		ID:          uint(a.ID),
+		MovieID:     uint(a.MovieID),
+		Kind:        string(a.Kind),
+		ContentType: a.ContentType,
+		Hash:        a.Hash,
+		Size:        a.Size,
+		Width:       a.Width,
+		Height:      a.Height,
+		Variants:    a.Variants,
+	}
+}
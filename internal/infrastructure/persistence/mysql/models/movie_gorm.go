@@ -11,18 +11,34 @@ import (
 // 电影表(必选：Title，类型，上映日期)
 type MovieGorm struct {
 	gorm.Model
-	Title           string    `gorm:"type:varchar(255);not null;uniqueIndex"` //  电影标题
-	ReleaseDate     time.Time `gorm:"not null"`                               // 上映日期
-	Description     string    `gorm:"type:text"`                              // 电影剧情简介或描述（可空）
-	PosterURL       string    `gorm:"type:varchar(500)"`                      // 电影海报图片的URL地址（可空）
+	Title           string    `gorm:"type:varchar(255);not null;uniqueIndex;index:idx_movies_title_fulltext,class:FULLTEXT;index:idx_movies_fulltext,class:FULLTEXT"` //  电影标题
+	ReleaseDate     time.Time `gorm:"not null"`                                                                                                                       // 上映日期
+	Description     string    `gorm:"type:text;index:idx_movies_fulltext,class:FULLTEXT"`                                                                             // 电影剧情简介或描述（可空）
+	PosterURL       string    `gorm:"type:varchar(500)"`                                                                                                              // 电影海报图片的URL地址（可空）
 	DurationMinutes int       // 电影时长，单位为分钟
 	Rating          float32   // 评分
-	AgeRating       string    `gorm:"type:varchar(50)"` // 年龄分级 (例如 PG-13)
-	Cast            string    `gorm:"type:text"`        // 主要演员 (简单起见用文本，复杂系统可设计为关联表)
+	AgeRating       string    `gorm:"type:varchar(50)"`                                   // 年龄分级 (例如 PG-13)
+	Cast            string    `gorm:"type:text;index:idx_movies_fulltext,class:FULLTEXT"` // 主要演员 (简单起见用文本，复杂系统可设计为关联表)
+
+	// 生命周期状态：coming_soon | now_showing | archived
+	Status string `gorm:"type:varchar(20);not null;default:'coming_soon';index"`
+
+	// 用户评价聚合（仅统计已发布的评价）
+	ReviewCount    int `gorm:"not null;default:0"`
+	ReviewScoreSum int `gorm:"not null;default:0"`
 
 	// 关系
 	Genres    []*GenreGorm   `gorm:"many2many:movies_genres;joinForeignKey:movie_id;joinReferences:genre_id;constraint:OnDelete:CASCADE;"` // 多对多：GORM会自动创建名为movies_genres的连接表
 	Showtimes []ShowtimeGorm `gorm:"foreignKey:MovieID;constraint:OnDelete:CASCADE;"`                                                      // 一对多
+
+	// 一对多：演职员（查询时按署名顺序预加载）
+	Credits []MovieCreditGorm `gorm:"foreignKey:MovieID;constraint:OnDelete:CASCADE;"`
+
+	// 一对多：海报、背景图与预告片
+	MediaAssets []MediaAssetGorm `gorm:"foreignKey:MovieID;constraint:OnDelete:CASCADE;"`
+
+	// 一对多：标题与描述的翻译
+	Translations []MovieTranslationGorm `gorm:"foreignKey:MovieID;constraint:OnDelete:CASCADE;"`
 }
 
 // TableName 指定表名
@@ -35,6 +51,18 @@ func (m *MovieGorm) ToDomain() *movie.Movie {
 	for i, genre := range m.Genres {
 		genres[i] = genre.ToDomain()
 	}
+	credits := make([]*movie.Credit, len(m.Credits))
+	for i, credit := range m.Credits {
+		credits[i] = credit.ToDomain()
+	}
+	media := make([]*movie.MediaAsset, len(m.MediaAssets))
+	for i, asset := range m.MediaAssets {
+		media[i] = asset.ToDomain()
+	}
+	translations := make([]*movie.MovieTranslation, len(m.Translations))
+	for i, translation := range m.Translations {
+		translations[i] = translation.ToDomain()
+	}
 	return &movie.Movie{
 		ID:              vo.MovieID(m.ID),
 		Title:           m.Title,
@@ -42,15 +70,22 @@ func (m *MovieGorm) ToDomain() *movie.Movie {
 		PosterURL:       m.PosterURL,
 		DurationMinutes: m.DurationMinutes,
 		Genres:          genres,
+		Credits:         credits,
+		Media:           media,
+		Translations:    translations,
 		ReleaseDate:     m.ReleaseDate,
 		Rating:          m.Rating,
 		AgeRating:       m.AgeRating,
 		Cast:            m.Cast,
+		Status:          movie.Status(m.Status),
+		ReviewCount:     m.ReviewCount,
+		ReviewScoreSum:  m.ReviewScoreSum,
 	}
 }
 
 // 通常在创建时使用，不需要预加载关联数据
 // 添加电影类型需要额外调用ReplaceGenresForMovie
+// 评价聚合字段只通过ApplyReviewDelta增量更新，不在此处转换
 func MovieGormFromDomain(m *movie.Movie) *MovieGorm {
 	return &MovieGorm{
 		Model:           gorm.Model{ID: uint(m.ID)},
@@ -62,5 +97,6 @@ func MovieGormFromDomain(m *movie.Movie) *MovieGorm {
 		Rating:          m.Rating,
 		AgeRating:       m.AgeRating,
 		Cast:            m.Cast,
+		Status:          string(m.Status),
 	}
 }
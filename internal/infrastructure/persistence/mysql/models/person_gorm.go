@@ -0,0 +1,80 @@
+package models
+
+import (
+	"mrs/internal/domain/movie"
+	"mrs/internal/domain/shared/vo"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 影人表
+type PersonGorm struct {
+	gorm.Model
+	Name      string     `gorm:"type:varchar(255);uniqueIndex;not null"` // 姓名
+	Bio       string     `gorm:"type:text"`                              // 简介
+	BirthDate *time.Time // 出生日期
+	PhotoURL  string     `gorm:"type:varchar(500)"` // 照片URL
+}
+
+// TableName 指定表名
+func (PersonGorm) TableName() string {
+	return "people"
+}
+
+func (p *PersonGorm) ToDomain() *movie.Person {
+	return &movie.Person{
+		ID:        vo.PersonID(p.ID),
+		Name:      p.Name,
+		Bio:       p.Bio,
+		BirthDate: p.BirthDate,
+		PhotoURL:  p.PhotoURL,
+	}
+}
+
+func PersonGormFromDomain(p *movie.Person) *PersonGorm {
+	return &PersonGorm{
+		Model:     gorm.Model{ID: uint(p.ID)},
+		Name:      p.Name,
+		Bio:       p.Bio,
+		BirthDate: p.BirthDate,
+		PhotoURL:  p.PhotoURL,
+	}
+}
+
+// 电影演职员表（同一影人在同一电影中可以担任多个职务，但每个职务只有一条记录）
+type MovieCreditGorm struct {
+	ID           uint       `gorm:"primaryKey"`
+	MovieID      uint       `gorm:"not null;uniqueIndex:idx_movie_person_role,priority:1"`
+	Movie        MovieGorm  `gorm:"foreignKey:MovieID;constraint:OnDelete:CASCADE"`
+	PersonID     uint       `gorm:"not null;uniqueIndex:idx_movie_person_role,priority:2;index"`
+	Person       PersonGorm `gorm:"foreignKey:PersonID;constraint:OnDelete:RESTRICT"`
+	Role         string     `gorm:"type:varchar(20);not null;uniqueIndex:idx_movie_person_role,priority:3"`
+	Character    string     `gorm:"type:varchar(255)"` // 饰演角色（仅演员）
+	BillingOrder int        `gorm:"not null;default:0"`
+	CreatedAt    time.Time
+}
+
+// TableName 指定表名
+func (MovieCreditGorm) TableName() string {
+	return "movie_credits"
+}
+
+func (c *MovieCreditGorm) ToDomain() *movie.Credit {
+	return &movie.Credit{
+		Person:       c.Person.ToDomain(),
+		Role:         movie.CreditRole(c.Role),
+		Character:    c.Character,
+		BillingOrder: c.BillingOrder,
+	}
+}
+
+func MovieCreditGormFromDomain(movieID vo.MovieID, c *movie.Credit) *MovieCreditGorm {
+	return &MovieCreditGorm{
+		MovieID:      uint(movieID),
+		PersonID:     uint(c.Person.ID),
+		Role:         string(c.Role),
+		Character:    c.Character,
+		BillingOrder: c.BillingOrder,
+	}
+}
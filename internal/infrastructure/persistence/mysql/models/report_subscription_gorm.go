@@ -0,0 +1,132 @@
+package models
+
+import (
+	"mrs/internal/domain/report"
+	"mrs/internal/domain/shared/vo"
+	"time"
+)
+
+// 报表订阅表（定时任务按 enabled + next_run_at 扫描到期的订阅）
+type ReportSubscriptionGorm struct {
+	ID         uint              `gorm:"primaryKey"`
+	Name       string            `gorm:"type:varchar(100);not null"`
+	ReportType string            `gorm:"type:varchar(20);not null"`
+	Parameters report.Parameters `gorm:"type:text;serializer:json"`
+	Cron       string            `gorm:"type:varchar(100);not null"`
+	Timezone   string            `gorm:"type:varchar(64);not null;default:''"`
+	Locale     string            `gorm:"type:varchar(35);not null;default:''"`
+	Format     string            `gorm:"type:varchar(10);not null"`
+	Channel    string            `gorm:"type:varchar(20);not null"`
+	Recipients []string          `gorm:"type:text;serializer:json"`
+	Enabled    bool              `gorm:"not null;index:idx_enabled_next_run,priority:1"`
+	NextRunAt  *time.Time        `gorm:"index:idx_enabled_next_run,priority:2"`
+	CreatedBy  uint              `gorm:"not null;default:0"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// TableName 指定表名
+func (ReportSubscriptionGorm) TableName() string {
+	return "report_subscriptions"
+}
+
+func (s *ReportSubscriptionGorm) ToDomain() *report.Subscription {
+	return &report.Subscription{
+		ID:         vo.ReportSubscriptionID(s.ID),
+		Name:       s.Name,
+		ReportType: report.Type(s.ReportType),
+		Parameters: s.Parameters,
+		Cron:       s.Cron,
+		Timezone:   s.Timezone,
+		Locale:     vo.Locale(s.Locale),
+		Format:     report.Format(s.Format),
+		Channel:    report.Channel(s.Channel),
+		Recipients: s.Recipients,
+		Enabled:    s.Enabled,
+		NextRunAt:  s.NextRunAt,
+		CreatedBy:  vo.UserID(s.CreatedBy),
+		CreatedAt:  s.CreatedAt,
+		UpdatedAt:  s.UpdatedAt,
+	}
+}
+
+func ReportSubscriptionGormFromDomain(s *report.Subscription) *ReportSubscriptionGorm {
+	return &ReportSubscriptionGorm{
+		ID:         uint(s.ID),
+		Name:       s.Name,
+		ReportType: string(s.ReportType),
+		Parameters: s.Parameters,
+		Cron:       s.Cron,
+		Timezone:   s.Timezone,
+		Locale:     string(s.Locale),
+		Format:     string(s.Format),
+		Channel:    string(s.Channel),
+		Recipients: s.Recipients,
+		Enabled:    s.Enabled,
+		NextRunAt:  s.NextRunAt,
+		CreatedBy:  uint(s.CreatedBy),
+		CreatedAt:  s.CreatedAt,
+	}
+}
+
+// 报表订阅执行记录表（每次尝试一行，重试任务按 retry_at 扫描）
+type ReportRunGorm struct {
+	ID             uint                   `gorm:"primaryKey"`
+	SubscriptionID uint                   `gorm:"not null;index:idx_subscription_started,priority:1"`
+	Subscription   ReportSubscriptionGorm `gorm:"foreignKey:SubscriptionID;constraint:OnDelete:CASCADE"`
+	Trigger        string                 `gorm:"column:run_trigger;type:varchar(20);not null"`
+	ScheduledAt    time.Time              `gorm:"not null"`
+	Attempt        int                    `gorm:"not null;default:1"`
+	Status         string                 `gorm:"type:varchar(20);not null"`
+	PeriodStart    time.Time
+	PeriodEnd      time.Time
+	FileName       string     `gorm:"type:varchar(255);not null;default:''"`
+	FileSize       int64      `gorm:"not null;default:0"`
+	Error          string     `gorm:"type:text"`
+	RetryAt        *time.Time `gorm:"index"`
+	StartedAt      time.Time  `gorm:"not null;index:idx_subscription_started,priority:2"`
+	FinishedAt     *time.Time
+}
+
+// TableName 指定表名
+func (ReportRunGorm) TableName() string {
+	return "report_runs"
+}
+
+func (r *ReportRunGorm) ToDomain() *report.Run {
+	return &report.Run{
+		ID:             vo.ReportRunID(r.ID),
+		SubscriptionID: vo.ReportSubscriptionID(r.SubscriptionID),
+		Trigger:        report.RunTrigger(r.Trigger),
+		ScheduledAt:    r.ScheduledAt,
+		Attempt:        r.Attempt,
+		Status:         report.RunStatus(r.Status),
+		PeriodStart:    r.PeriodStart,
+		PeriodEnd:      r.PeriodEnd,
+		FileName:       r.FileName,
+		FileSize:       r.FileSize,
+		Error:          r.Error,
+		RetryAt:        r.RetryAt,
+		StartedAt:      r.StartedAt,
+		FinishedAt:     r.FinishedAt,
+	}
+}
+
+func ReportRunGormFromDomain(r *report.Run) *ReportRunGorm {
+	return &ReportRunGorm{
+		ID:             uint(r.ID),
+		SubscriptionID: uint(r.SubscriptionID),
+		Trigger:        string(r.Trigger),
+		ScheduledAt:    r.ScheduledAt,
+		Attempt:        r.Attempt,
+		Status:         string(r.Status),
+		PeriodStart:    r.PeriodStart,
+		PeriodEnd:      r.PeriodEnd,
+		FileName:       r.FileName,
+		FileSize:       r.FileSize,
+		Error:          r.Error,
+		RetryAt:        r.RetryAt,
+		StartedAt:      r.StartedAt,
+		FinishedAt:     r.FinishedAt,
+	}
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"mrs/internal/domain/review"
+	"mrs/internal/domain/shared/vo"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 评价表（每个用户对每部电影只有一条评价，按电影与状态分页查询）
+type ReviewGorm struct {
+	gorm.Model
+	MovieID        uint      `gorm:"not null;uniqueIndex:idx_movie_user,priority:1;index:idx_movie_status,priority:1"`
+	Movie          MovieGorm `gorm:"foreignKey:MovieID;constraint:OnDelete:CASCADE"`
+	UserID         uint      `gorm:"not null;uniqueIndex:idx_movie_user,priority:2;index"`
+	User           UserGorm  `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Score          int       `gorm:"not null"`
+	Content        string    `gorm:"type:text"`
+	Status         string    `gorm:"type:varchar(20);not null;default:'pending';index:idx_movie_status,priority:2"`
+	ModerationNote string    `gorm:"type:varchar(255)"`
+	HelpfulCount   int       `gorm:"not null;default:0"`
+}
+
+// TableName 指定表名
+func (ReviewGorm) TableName() string {
+	return "reviews"
+}
+
+func (r *ReviewGorm) ToDomain() *review.Review {
+	return &review.Review{
+		ID:             vo.ReviewID(r.ID),
+		MovieID:        vo.MovieID(r.MovieID),
+		UserID:         vo.UserID(r.UserID),
+		Score:          r.Score,
+		Content:        r.Content,
+		Status:         review.ReviewStatus(r.Status),
+		ModerationNote: r.ModerationNote,
+		HelpfulCount:   r.HelpfulCount,
+		CreatedAt:      r.CreatedAt,
+		UpdatedAt:      r.UpdatedAt,
+	}
+}
+
+// 投票数只通过AddHelpfulVote/RemoveHelpfulVote增量更新，不在此处转换
+func ReviewGormFromDomain(r *review.Review) *ReviewGorm {
+	return &ReviewGorm{
+		Model:          gorm.Model{ID: uint(r.ID)},
+		MovieID:        uint(r.MovieID),
+		UserID:         uint(r.UserID),
+		Score:          r.Score,
+		Content:        r.Content,
+		Status:         string(r.Status),
+		ModerationNote: r.ModerationNote,
+	}
+}
+
+// 评价"有用"投票表（每个用户对每条评价只能投一次）
+type ReviewVoteGorm struct {
+	ID        uint       `gorm:"primaryKey"`
+	ReviewID  uint       `gorm:"not null;uniqueIndex:idx_review_user,priority:1"`
+	Review    ReviewGorm `gorm:"foreignKey:ReviewID;constraint:OnDelete:CASCADE"`
+	UserID    uint       `gorm:"not null;uniqueIndex:idx_review_user,priority:2"`
+	User      UserGorm   `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	CreatedAt time.Time
+}
+
+// TableName 指定表名
+func (ReviewVoteGorm) TableName() string {
+	return "review_votes"
+}
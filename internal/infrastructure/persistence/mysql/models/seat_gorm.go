@@ -10,11 +10,19 @@ import (
 // 座位表(对 CinemaHallID 单独建立索引: 应对高频查询影厅全部座位的需求)
 type SeatGorm struct {
 	gorm.Model
-	CinemaHallID  uint           `gorm:"not null;index;uniqueIndex:idx_hall_row_number"` // 联合唯一索引
+	CinemaHallID  uint           `gorm:"not null;index;uniqueIndex:idx_hall_version_row_number,priority:1"` // 联合唯一索引
 	CinemaHall    CinemaHallGorm `gorm:"foreignKey:CinemaHallID"`
-	RowIdentifier string         `gorm:"type:varchar(10);not null;uniqueIndex:idx_hall_row_number"` // 联合唯一索引
-	SeatNumber    string         `gorm:"type:varchar(10);not null;uniqueIndex:idx_hall_row_number"`
+	RowIdentifier string         `gorm:"type:varchar(10);not null;uniqueIndex:idx_hall_version_row_number,priority:3"` // 联合唯一索引
+	SeatNumber    string         `gorm:"type:varchar(10);not null;uniqueIndex:idx_hall_version_row_number,priority:4"`
 	Type          string         `gorm:"type:varchar(50);default:'STANDARD'"`
+
+	// 布局网格坐标与所属分区
+	GridRow int    `gorm:"type:int;not null;default:0"`
+	GridCol int    `gorm:"type:int;not null;default:0"`
+	Block   string `gorm:"type:varchar(50)"`
+
+	// 所属布局版本，排号+座位号在同一版本内唯一；修改布局时旧版本座位软删除保留
+	LayoutVersion int `gorm:"type:int;not null;default:1;uniqueIndex:idx_hall_version_row_number,priority:2"`
 }
 
 // TableName 指定表名
@@ -29,6 +37,10 @@ func (s *SeatGorm) ToDomain() *cinema.Seat {
 		RowIdentifier: s.RowIdentifier,
 		SeatNumber:    s.SeatNumber,
 		Type:          cinema.SeatType(s.Type),
+		GridRow:       s.GridRow,
+		GridCol:       s.GridCol,
+		Block:         s.Block,
+		LayoutVersion: s.LayoutVersion,
 	}
 }
 
@@ -39,5 +51,9 @@ func SeatGormFromDomain(s *cinema.Seat) *SeatGorm {
 		RowIdentifier: s.RowIdentifier,
 		SeatNumber:    s.SeatNumber,
 		Type:          string(s.Type),
+		GridRow:       s.GridRow,
+		GridCol:       s.GridCol,
+		Block:         s.Block,
+		LayoutVersion: s.LayoutVersion,
 	}
 }
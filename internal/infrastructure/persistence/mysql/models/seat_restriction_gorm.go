@@ -0,0 +1,52 @@
+package models
+
+import (
+	"mrs/internal/domain/cinema"
+	"mrs/internal/domain/shared/vo"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 座位限制表（按影厅查询，建立 cinema_hall_id 索引）
+type SeatRestrictionGorm struct {
+	gorm.Model
+	SeatID       uint           `gorm:"not null;index"`
+	Seat         SeatGorm       `gorm:"foreignKey:SeatID;constraint:OnDelete:CASCADE"`
+	CinemaHallID uint           `gorm:"not null;index"`
+	CinemaHall   CinemaHallGorm `gorm:"foreignKey:CinemaHallID;constraint:OnDelete:CASCADE"`
+	Kind         string         `gorm:"type:varchar(20);not null"`
+	Reason       string         `gorm:"type:varchar(255)"`
+	StartsAt     *time.Time     // 为空表示立即生效
+	EndsAt       *time.Time     // 为空表示长期有效
+}
+
+// TableName 指定表名
+func (SeatRestrictionGorm) TableName() string {
+	return "seat_restrictions"
+}
+
+func (r *SeatRestrictionGorm) ToDomain() *cinema.SeatRestriction {
+	return &cinema.SeatRestriction{
+		ID:           vo.SeatRestrictionID(r.ID),
+		SeatID:       vo.SeatID(r.SeatID),
+		CinemaHallID: vo.CinemaHallID(r.CinemaHallID),
+		Kind:         cinema.SeatRestrictionKind(r.Kind),
+		Reason:       r.Reason,
+		StartsAt:     r.StartsAt,
+		EndsAt:       r.EndsAt,
+		CreatedAt:    r.CreatedAt,
+	}
+}
+
+func SeatRestrictionGormFromDomain(r *cinema.SeatRestriction) *SeatRestrictionGorm {
+	return &SeatRestrictionGorm{
+		Model:        gorm.Model{ID: uint(r.ID)},
+		SeatID:       uint(r.SeatID),
+		CinemaHallID: uint(r.CinemaHallID),
+		Kind:         string(r.Kind),
+		Reason:       r.Reason,
+		StartsAt:     r.StartsAt,
+		EndsAt:       r.EndsAt,
+	}
+}
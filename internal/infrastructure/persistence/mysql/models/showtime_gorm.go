@@ -26,6 +26,24 @@ type ShowtimeGorm struct {
 	EndTime time.Time `gorm:"not null"`
 	// 该场次的票价 (可以更复杂，比如不同座位类型不同价格)
 	Price float64 `gorm:"not null"`
+
+	// 放映格式 (2D/3D/IMAX/IMAX_3D/4DX/DOLBY_CINEMA)
+	Format string `gorm:"type:varchar(20);not null;default:'2D';index"`
+	// 音轨语言与字幕语言 (ISO 639-1)，字幕语言为空表示无字幕
+	AudioLanguage    string `gorm:"type:varchar(10);index"`
+	SubtitleLanguage string `gorm:"type:varchar(10)"`
+	// 无障碍服务标记
+	AudioDescription bool `gorm:"not null;default:false"`
+	ClosedCaptions   bool `gorm:"not null;default:false"`
+	SensoryFriendly  bool `gorm:"not null;default:false"`
+
+	// 场次状态 (scheduled/cancelled)，取消场次时只标记状态不删除记录
+	Status       string     `gorm:"type:varchar(20);not null;default:'scheduled';index"`
+	CancelledAt  *time.Time // 取消时间
+	CancelReason string     `gorm:"type:varchar(255)"` // 取消原因
+
+	// 场次固定引用的影厅布局版本
+	LayoutVersion int `gorm:"type:int;not null;default:1"`
 }
 
 // TableName 指定表名
@@ -35,24 +53,47 @@ func (ShowtimeGorm) TableName() string {
 
 func (s *ShowtimeGorm) ToDomain() *showtime.Showtime {
 	return &showtime.Showtime{
-		ID:           vo.ShowtimeID(s.ID),
-		MovieID:      vo.MovieID(s.MovieID),
-		CinemaHallID: vo.CinemaHallID(s.CinemaHallID),
-		Movie:        s.Movie.ToDomain(),
-		CinemaHall:   s.CinemaHall.ToDomain(),
-		StartTime:    s.StartTime,
-		EndTime:      s.EndTime,
-		Price:        s.Price,
+		ID:               vo.ShowtimeID(s.ID),
+		MovieID:          vo.MovieID(s.MovieID),
+		CinemaHallID:     vo.CinemaHallID(s.CinemaHallID),
+		Movie:            s.Movie.ToDomain(),
+		CinemaHall:       s.CinemaHall.ToDomain(),
+		StartTime:        s.StartTime,
+		EndTime:          s.EndTime,
+		Price:            s.Price,
+		Format:           showtime.ProjectionFormat(s.Format),
+		AudioLanguage:    s.AudioLanguage,
+		SubtitleLanguage: s.SubtitleLanguage,
+		Accessibility: showtime.Accessibility{
+			AudioDescription: s.AudioDescription,
+			ClosedCaptions:   s.ClosedCaptions,
+			SensoryFriendly:  s.SensoryFriendly,
+		},
+		Status:       showtime.ShowtimeStatus(s.Status),
+		CancelledAt:  s.CancelledAt,
+		CancelReason: s.CancelReason,
+
+		LayoutVersion: s.LayoutVersion,
 	}
 }
 
 func ShowtimeGormFromDomain(s *showtime.Showtime) *ShowtimeGorm {
 	return &ShowtimeGorm{
-		Model:        gorm.Model{ID: uint(s.ID)},
-		MovieID:      uint(s.MovieID),
-		CinemaHallID: uint(s.CinemaHallID),
-		StartTime:    s.StartTime,
-		EndTime:      s.EndTime,
-		Price:        s.Price,
+		Model:            gorm.Model{ID: uint(s.ID)},
+		MovieID:          uint(s.MovieID),
+		CinemaHallID:     uint(s.CinemaHallID),
+		StartTime:        s.StartTime,
+		EndTime:          s.EndTime,
+		Price:            s.Price,
+		Format:           string(s.Format),
+		AudioLanguage:    s.AudioLanguage,
+		SubtitleLanguage: s.SubtitleLanguage,
+		AudioDescription: s.Accessibility.AudioDescription,
+		ClosedCaptions:   s.Accessibility.ClosedCaptions,
+		SensoryFriendly:  s.Accessibility.SensoryFriendly,
+		Status:           string(s.Status),
+		CancelledAt:      s.CancelledAt,
+		CancelReason:     s.CancelReason,
+		LayoutVersion:    s.LayoutVersion,
 	}
 }
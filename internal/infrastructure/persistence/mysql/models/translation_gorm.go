@@ -0,0 +1,53 @@
+package models
+
+import (
+	"mrs/internal/domain/movie"
+	"mrs/internal/domain/shared/vo"
+	"time"
+)
+
+// 电影翻译表（每部电影每种语言一条）
+type MovieTranslationGorm struct {
+	ID          uint   `gorm:"primaryKey"`
+	MovieID     uint   `gorm:"not null;uniqueIndex:idx_movie_locale,priority:1"`
+	Locale      string `gorm:"type:varchar(35);not null;uniqueIndex:idx_movie_locale,priority:2"`
+	Title       string `gorm:"type:varchar(255);not null"`
+	Description string `gorm:"type:text"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// TableName 指定表名
+func (MovieTranslationGorm) TableName() string {
+	return "movie_translations"
+}
+
+func (t *MovieTranslationGorm) ToDomain() *movie.MovieTranslation {
+	return &movie.MovieTranslation{
+		Locale:      vo.Locale(t.Locale),
+		Title:       t.Title,
+		Description: t.Description,
+	}
+}
+
+// 类型翻译表（每个类型每种语言一条）
+type GenreTranslationGorm struct {
+	ID        uint   `gorm:"primaryKey"`
+	GenreID   uint   `gorm:"not null;uniqueIndex:idx_genre_locale,priority:1"`
+	Locale    string `gorm:"type:varchar(35);not null;uniqueIndex:idx_genre_locale,priority:2"`
+	Name      string `gorm:"type:varchar(100);not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TableName 指定表名
+func (GenreTranslationGorm) TableName() string {
+	return "genre_translations"
+}
+
+func (t *GenreTranslationGorm) ToDomain() *movie.GenreTranslation {
+	return &movie.GenreTranslation{
+		Locale: vo.Locale(t.Locale),
+		Name:   t.Name,
+	}
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"mrs/internal/domain/shared/vo"
 	"mrs/internal/domain/user"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -16,6 +17,8 @@ type UserGorm struct {
 
 	RoleID uint     `gorm:"not null"`           // 关联的角色ID
 	Role   RoleGorm `gorm:"foreignKey:RoleID "` // 通常会隐式推断，这里显式定义防止出错
+
+	BirthDate *time.Time `gorm:"type:date"` // 出生日期（可空）
 }
 
 // TableName 指定表名
@@ -30,6 +33,7 @@ func (u *UserGorm) ToDomain() *user.User {
 		Email:        u.Email,
 		PasswordHash: u.PasswordHash,
 		Role:         u.Role.ToDomain(),
+		BirthDate:    u.BirthDate,
 	}
 }
 
@@ -39,6 +43,7 @@ func UserGormFromDomain(u *user.User) *UserGorm {
 		Username:     u.Username,
 		Email:        u.Email,
 		PasswordHash: u.PasswordHash,
+		BirthDate:    u.BirthDate,
 	}
 	if u.Role != nil {
 		usr.Role = *RoleGormFromDomain(u.Role)
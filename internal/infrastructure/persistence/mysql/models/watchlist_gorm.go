@@ -0,0 +1,53 @@
+package models
+
+import (
+	"mrs/internal/domain/shared/vo"
+	"mrs/internal/domain/watchlist"
+	"time"
+)
+
+// 关注列表表（每个用户对每部电影只能关注一次，提醒任务按notified_at扫描未提醒的条目）
+type WatchlistEntryGorm struct {
+	ID                uint       `gorm:"primaryKey"`
+	UserID            uint       `gorm:"not null;uniqueIndex:idx_user_movie,priority:1"`
+	User              UserGorm   `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	MovieID           uint       `gorm:"not null;uniqueIndex:idx_user_movie,priority:2;index"`
+	Movie             MovieGorm  `gorm:"foreignKey:MovieID;constraint:OnDelete:CASCADE"`
+	PreferredCinemaID uint       `gorm:"not null;default:0"`
+	PreferredHallID   uint       `gorm:"not null;default:0"`
+	NotifiedAt        *time.Time `gorm:"index"`
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// TableName 指定表名
+func (WatchlistEntryGorm) TableName() string {
+	return "watchlist_entries"
+}
+
+func (w *WatchlistEntryGorm) ToDomain() *watchlist.WatchlistEntry {
+	entry := &watchlist.WatchlistEntry{
+		ID:                vo.WatchlistEntryID(w.ID),
+		UserID:            vo.UserID(w.UserID),
+		MovieID:           vo.MovieID(w.MovieID),
+		PreferredCinemaID: vo.CinemaID(w.PreferredCinemaID),
+		PreferredHallID:   vo.CinemaHallID(w.PreferredHallID),
+		NotifiedAt:        w.NotifiedAt,
+		CreatedAt:         w.CreatedAt,
+	}
+	if w.Movie.ID != 0 {
+		entry.Movie = w.Movie.ToDomain()
+	}
+	return entry
+}
+
+func WatchlistEntryGormFromDomain(e *watchlist.WatchlistEntry) *WatchlistEntryGorm {
+	return &WatchlistEntryGorm{
+		ID:                uint(e.ID),
+		UserID:            uint(e.UserID),
+		MovieID:           uint(e.MovieID),
+		PreferredCinemaID: uint(e.PreferredCinemaID),
+		PreferredHallID:   uint(e.PreferredHallID),
+		NotifiedAt:        e.NotifiedAt,
+	}
+}
@@ -158,3 +158,22 @@ func (r *gormBookedSeatRepository) DeleteByBookingID(ctx context.Context, bookin
 	logger.Info("delete booked seats by booking id successfully")
 	return nil
 }
+
+// DeleteByBookingIDs 批量删除多个订单的已预订座位
+func (r *gormBookedSeatRepository) DeleteByBookingIDs(ctx context.Context, bookingIDs []vo.BookingID) error {
+	logger := r.logger.With(applog.String("Method", "DeleteBookedSeatsByBookingIDs"), applog.Int("count", len(bookingIDs)))
+
+	if len(bookingIDs) == 0 {
+		return nil
+	}
+
+	result := r.db.WithContext(ctx).Delete(&models.BookedSeatGorm{}, "booking_id IN ?", bookingIDs)
+	if err := result.Error; err != nil {
+		logger.Error("database delete booked seats by booking ids error", applog.Error(err))
+		return fmt.Errorf("database delete booked seats by booking ids error: %w", err)
+	}
+
+	// 批量删除时，部分订单可能没有座位记录，因此不检查RowsAffected
+	logger.Info("delete booked seats by booking ids successfully", applog.Int64("rows_affected", result.RowsAffected))
+	return nil
+}
@@ -79,6 +79,28 @@ func (r *gormCinemaHallRepository) FindByName(ctx context.Context, name string)
 	return hallGorm.ToDomain(), nil
 }
 
+// 唯一索引 (cinema_id, name) 不约束 cinema_id 为 NULL 的记录，未归属影院的影厅需要按名称查询
+func (r *gormCinemaHallRepository) ExistsByName(ctx context.Context, cinemaID vo.CinemaID, name string, excludeID vo.CinemaHallID) (bool, error) {
+	logger := r.logger.With(applog.String("Method", "ExistsByName"),
+		applog.Uint("cinema_id", uint(cinemaID)), applog.String("name", name))
+
+	query := r.db.WithContext(ctx).Model(&models.CinemaHallGorm{}).
+		Where("name = ? AND id <> ?", name, uint(excludeID))
+	if cinemaID > 0 {
+		query = query.Where("cinema_id = ?", uint(cinemaID))
+	} else {
+		query = query.Where("cinema_id IS NULL")
+	}
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		logger.Error("database check cinema hall name exist error", applog.Error(err))
+		return false, fmt.Errorf("database check cinema hall name exist error: %w", err)
+	}
+
+	logger.Info("check cinema hall name exist successfully", applog.Int64("count", count))
+	return count > 0, nil
+}
+
 func (r *gormCinemaHallRepository) ListAll(ctx context.Context) ([]*cinema.CinemaHall, error) {
 	logger := r.logger.With(applog.String("Method", "ListAll"))
 	var hallsGorms []*models.CinemaHallGorm
@@ -120,6 +142,10 @@ func (r *gormCinemaHallRepository) Update(ctx context.Context, hall *cinema.Cine
 	}
 
 	if err := r.db.WithContext(ctx).Model(&models.CinemaHallGorm{}).Where("id = ?", cinemaHallGorm.ID).Updates(cinemaHallGorm).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			logger.Warn("cinema hall already exists", applog.Error(err))
+			return fmt.Errorf("%w: %w", cinema.ErrCinemaHallAlreadyExists, err)
+		}
 		logger.Error("database update cinema hall error", applog.Error(err))
 		return fmt.Errorf("database update cinema hall error: %w", err)
 	}
@@ -129,6 +155,31 @@ func (r *gormCinemaHallRepository) Update(ctx context.Context, hall *cinema.Cine
 	return nil
 }
 
+// 设置社交距离策略（策略为空时也需要写入，因此显式指定字段）
+func (r *gormCinemaHallRepository) UpdateDistancingPolicy(ctx context.Context, id vo.CinemaHallID, policy *cinema.DistancingPolicy) error {
+	logger := r.logger.With(applog.String("Method", "UpdateDistancingPolicy"), applog.Uint("hall_id", uint(id)))
+
+	// 策略未变化时 RowsAffected 为 0，因此先检查影厅是否存在
+	var exist int64
+	if err := r.db.WithContext(ctx).Model(&models.CinemaHallGorm{}).Where("id = ?", uint(id)).Count(&exist).Error; err != nil {
+		logger.Error("database check cinema hall exist error", applog.Error(err))
+		return fmt.Errorf("database check cinema hall exist error: %w", err)
+	}
+	if exist == 0 {
+		logger.Warn("cinema hall not found")
+		return fmt.Errorf("%w(id): %v", cinema.ErrCinemaHallNotFound, id)
+	}
+
+	if err := r.db.WithContext(ctx).Model(&models.CinemaHallGorm{}).Where("id = ?", uint(id)).
+		Select("DistancingPolicy").Updates(&models.CinemaHallGorm{DistancingPolicy: policy}).Error; err != nil {
+		logger.Error("database update distancing policy error", applog.Error(err))
+		return fmt.Errorf("database update distancing policy error: %w", err)
+	}
+
+	logger.Info("update distancing policy successfully")
+	return nil
+}
+
 // 删除影厅
 func (r *gormCinemaHallRepository) Delete(ctx context.Context, id vo.CinemaHallID) error {
 	logger := r.logger.With(applog.String("Method", "Delete"), applog.Uint("hall_id", uint(id)))
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mrs/internal/domain/cinema"
+	"mrs/internal/domain/shared/vo"
+	"mrs/internal/infrastructure/persistence/mysql/models"
+	applog "mrs/pkg/log"
+
+	"gorm.io/gorm"
+)
+
+type gormCinemaRepository struct {
+	db     *gorm.DB
+	logger applog.Logger
+}
+
+func NewGormCinemaRepository(db *gorm.DB, logger applog.Logger) cinema.CinemaRepository {
+	return &gormCinemaRepository{
+		db:     db,
+		logger: logger.With(applog.String("Repository", "gormCinemaRepository")),
+	}
+}
+
+func (r *gormCinemaRepository) Create(ctx context.Context, c *cinema.Cinema) (*cinema.Cinema, error) {
+	logger := r.logger.With(applog.String("Method", "Create"), applog.String("name", c.Name))
+
+	cinemaGorm := models.CinemaGormFromDomain(c)
+	if err := r.db.WithContext(ctx).Create(cinemaGorm).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			logger.Warn("cinema already exists", applog.Error(err))
+			return nil, fmt.Errorf("%w: %w", cinema.ErrCinemaAlreadyExists, err)
+		}
+		logger.Error("database create cinema error", applog.Error(err))
+		return nil, fmt.Errorf("database create cinema error: %w", err)
+	}
+
+	logger.Info("create cinema successfully", applog.Uint("cinema_id", cinemaGorm.ID))
+	return cinemaGorm.ToDomain(), nil
+}
+
+func (r *gormCinemaRepository) FindByID(ctx context.Context, id vo.CinemaID) (*cinema.Cinema, error) {
+	logger := r.logger.With(applog.String("Method", "FindByID"), applog.Uint("cinema_id", uint(id)))
+	var cinemaGorm models.CinemaGorm
+	if err := r.db.WithContext(ctx).First(&cinemaGorm, uint(id)).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("cinema id not found", applog.Error(err))
+			return nil, fmt.Errorf("%w(id): %w", cinema.ErrCinemaNotFound, err)
+		}
+		logger.Error("database find cinema by id error", applog.Error(err))
+		return nil, fmt.Errorf("database find cinema by id error: %w", err)
+	}
+
+	logger.Info("find cinema by id successfully")
+	return cinemaGorm.ToDomain(), nil
+}
+
+// 通过影厅查询所属影院
+func (r *gormCinemaRepository) FindByHallID(ctx context.Context, hallID vo.CinemaHallID) (*cinema.Cinema, error) {
+	logger := r.logger.With(applog.String("Method", "FindByHallID"), applog.Uint("hall_id", uint(hallID)))
+	var cinemaGorm models.CinemaGorm
+	if err := r.db.WithContext(ctx).
+		Where("id = (?)", r.db.Model(&models.CinemaHallGorm{}).Select("cinema_id").Where("id = ?", uint(hallID))).
+		First(&cinemaGorm).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("cinema of hall not found", applog.Error(err))
+			return nil, fmt.Errorf("%w(hall_id): %w", cinema.ErrCinemaNotFound, err)
+		}
+		logger.Error("database find cinema by hall id error", applog.Error(err))
+		return nil, fmt.Errorf("database find cinema by hall id error: %w", err)
+	}
+
+	logger.Info("find cinema by hall id successfully", applog.Uint("cinema_id", cinemaGorm.ID))
+	return cinemaGorm.ToDomain(), nil
+}
+
+func (r *gormCinemaRepository) ListAll(ctx context.Context) ([]*cinema.Cinema, error) {
+	logger := r.logger.With(applog.String("Method", "ListAll"))
+	var cinemaGorms []*models.CinemaGorm
+	if err := r.db.WithContext(ctx).Order("id ASC").Find(&cinemaGorms).Error; err != nil {
+		logger.Error("database list all cinemas error", applog.Error(err))
+		return nil, fmt.Errorf("database list all cinemas error: %w", err)
+	}
+
+	logger.Info("list all cinemas successfully", applog.Int("count", len(cinemaGorms)))
+	cinemas := make([]*cinema.Cinema, len(cinemaGorms))
+	for i, cinemaGorm := range cinemaGorms {
+		cinemas[i] = cinemaGorm.ToDomain()
+	}
+	return cinemas, nil
+}
+
+// 更新影院
+func (r *gormCinemaRepository) Update(ctx context.Context, c *cinema.Cinema) error {
+	logger := r.logger.With(applog.String("Method", "Update"), applog.Uint("cinema_id", uint(c.ID)))
+
+	cinemaGorm := models.CinemaGormFromDomain(c)
+
+	var exist int64
+	if err := r.db.WithContext(ctx).Model(&models.CinemaGorm{}).Where("id = ?", cinemaGorm.ID).Count(&exist).Error; err != nil {
+		logger.Error("database check cinema exist error", applog.Error(err))
+		return fmt.Errorf("database check cinema exist error: %w", err)
+	}
+
+	if exist == 0 {
+		logger.Warn("cinema not found")
+		return fmt.Errorf("%w(id): %v", cinema.ErrCinemaNotFound, cinemaGorm.ID)
+	}
+
+	if err := r.db.WithContext(ctx).Model(&models.CinemaGorm{}).Where("id = ?", cinemaGorm.ID).Updates(cinemaGorm).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			logger.Warn("cinema name already exists", applog.Error(err))
+			return fmt.Errorf("%w: %w", cinema.ErrCinemaAlreadyExists, err)
+		}
+		logger.Error("database update cinema error", applog.Error(err))
+		return fmt.Errorf("database update cinema error: %w", err)
+	}
+
+	// 无论是否真正造成更新，都返回成功
+	logger.Info("update cinema successfully")
+	return nil
+}
+
+// 删除影院
+func (r *gormCinemaRepository) Delete(ctx context.Context, id vo.CinemaID) error {
+	logger := r.logger.With(applog.String("Method", "Delete"), applog.Uint("cinema_id", uint(id)))
+
+	result := r.db.WithContext(ctx).Delete(&models.CinemaGorm{}, uint(id))
+	if err := result.Error; err != nil {
+		logger.Error("database delete cinema error", applog.Error(err))
+		return fmt.Errorf("database delete cinema error: %w", err)
+	}
+
+	if result.RowsAffected == 0 {
+		logger.Warn("cinema not found")
+		return fmt.Errorf("%w(id): %v", cinema.ErrCinemaNotFound, id)
+	}
+
+	logger.Info("delete cinema successfully")
+	return nil
+}
+
+// 统计影院下的影厅数量
+func (r *gormCinemaRepository) CountHalls(ctx context.Context, id vo.CinemaID) (int64, error) {
+	logger := r.logger.With(applog.String("Method", "CountHalls"), applog.Uint("cinema_id", uint(id)))
+
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.CinemaHallGorm{}).
+		Where("cinema_id = ?", uint(id)).
+		Count(&count).Error; err != nil {
+		logger.Error("database count cinema halls error", applog.Error(err))
+		return 0, fmt.Errorf("database count cinema halls error: %w", err)
+	}
+
+	logger.Info("count cinema halls successfully", applog.Int64("count", count))
+	return count, nil
+}
@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"mrs/internal/domain/booking"
+	"mrs/internal/infrastructure/persistence/mysql/models"
+	applog "mrs/pkg/log"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormDailySalesRepository struct {
+	db     *gorm.DB
+	logger applog.Logger
+}
+
+func NewGormDailySalesRepository(db *gorm.DB, logger applog.Logger) booking.DailySalesRepository {
+	return &gormDailySalesRepository{
+		db:     db,
+		logger: logger.With(applog.String("Repository", "DailySalesRepository")),
+	}
+}
+
+// 累加的列：冲突时在原值上加上本次增量
+var dailySalesCounters = []string{"bookings", "tickets", "gross", "discounts", "refunded_bookings", "refunded_tickets", "refunds"}
+
+// Add 累加事实增量（INSERT ... ON DUPLICATE KEY UPDATE），同一主键的增量先在内存中合并
+func (r *gormDailySalesRepository) Add(ctx context.Context, deltas ...*booking.DailySales) error {
+	logger := r.logger.With(applog.String("Method", "Add"), applog.Int("count", len(deltas)))
+	if len(deltas) == 0 {
+		return nil
+	}
+
+	merged := booking.MergeDailySales(deltas)
+	rows := make([]*models.DailySalesGorm, len(merged))
+	for i, delta := range merged {
+		rows[i] = models.DailySalesGormFromDomain(delta)
+	}
+
+	assignments := map[string]any{"updated_at": gorm.Expr("VALUES(updated_at)")}
+	for _, column := range dailySalesCounters {
+		assignments[column] = gorm.Expr(fmt.Sprintf("%[1]s + VALUES(%[1]s)", column))
+	}
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(assignments),
+	}).Create(&rows).Error; err != nil {
+		logger.Error("database add daily sales error", applog.Error(err))
+		return fmt.Errorf("database add daily sales error: %w", err)
+	}
+
+	logger.Info("add daily sales successfully", applog.Int("rows", len(rows)))
+	return nil
+}
+
+// 从订单表汇总事实：已确认与已退款的订单都计入确认数据，已退款的订单另外计入退款数据。
+// 退款时座位已被软删除，座位数与票面金额按 bookedSeatsOfBookingCond 包含已删除的座位
+var dailySalesRebuildSQL = `INSERT INTO daily_sales (sales_date, showtime_id, movie_id, cinema_hall_id,
+	bookings, tickets, gross, discounts, refunded_bookings, refunded_tickets, refunds, created_at, updated_at)
+SELECT t.sales_date, t.showtime_id, t.movie_id, t.cinema_hall_id,
+	COUNT(*), SUM(t.tickets), SUM(t.gross), SUM(t.gross - t.total_amount),
+	SUM(t.refunded), SUM(t.refunded * t.tickets), SUM(t.refunded * t.total_amount), NOW(), NOW()
+FROM (
+	SELECT DATE(bookings.booking_time) AS sales_date, showtimes.id AS showtime_id,
+		showtimes.movie_id, showtimes.cinema_hall_id, bookings.total_amount,
+		bookings.status = ? AS refunded,
+		` + salesTicketsExpr + ` AS tickets,
+		(SELECT COALESCE(SUM(booked_seats.price), 0) FROM booked_seats WHERE ` + bookedSeatsOfBookingCond + `) AS gross
+	FROM bookings
+	JOIN showtimes ON bookings.showtime_id = showtimes.id
+	WHERE bookings.deleted_at IS NULL AND bookings.status IN ? AND bookings.booking_time >= ? AND bookings.booking_time < ?
+) AS t
+GROUP BY t.sales_date, t.showtime_id, t.movie_id, t.cinema_hall_id`
+
+// Rebuild 重新汇总 [start, end] 业务日期内的事实。
+// 应在事务中调用：INSERT ... SELECT 会对读取的订单加共享锁，重建期间的订单状态变更会等待重建完成
+func (r *gormDailySalesRepository) Rebuild(ctx context.Context, start, end time.Time) (int64, error) {
+	start, end = booking.SalesDate(start), booking.SalesDate(end)
+	logger := r.logger.With(applog.String("Method", "Rebuild"),
+		applog.String("start", start.Format(time.DateOnly)), applog.String("end", end.Format(time.DateOnly)))
+
+	db := r.db.WithContext(ctx)
+	if err := db.Where("sales_date >= ? AND sales_date <= ?", start.Format(time.DateOnly), end.Format(time.DateOnly)).
+		Delete(&models.DailySalesGorm{}).Error; err != nil {
+		logger.Error("database delete daily sales error", applog.Error(err))
+		return 0, fmt.Errorf("database delete daily sales error: %w", err)
+	}
+
+	result := db.Exec(dailySalesRebuildSQL, booking.BookingStatusRefunded,
+		[]booking.BookingStatus{booking.BookingStatusConfirmed, booking.BookingStatusRefunded},
+		start, end.AddDate(0, 0, 1))
+	if result.Error != nil {
+		logger.Error("database rebuild daily sales error", applog.Error(result.Error))
+		return 0, fmt.Errorf("database rebuild daily sales error: %w", result.Error)
+	}
+
+	logger.Info("rebuild daily sales successfully", applog.Int64("rows", result.RowsAffected))
+	return result.RowsAffected, nil
+}
+
+// 事实查询的基础查询：连接场次、电影与影厅（维度表），按过滤条件与日期口径筛选
+func (r *gormDailySalesRepository) salesQuery(ctx context.Context, options *booking.SalesQueryOptions, logger applog.Logger) (*gorm.DB, applog.Logger) {
+	query := r.db.WithContext(ctx).Model(&models.DailySalesGorm{}).
+		Joins("JOIN showtimes ON daily_sales.showtime_id = showtimes.id").
+		Joins("JOIN movies ON daily_sales.movie_id = movies.id").
+		Joins("JOIN cinema_halls ON daily_sales.cinema_hall_id = cinema_halls.id")
+
+	// 按下单时间口径时按业务日期过滤，按场次口径时按场次开始时间过滤
+	if options.DateBasis == booking.SalesDateBasisShowtime {
+		if !options.StartDate.IsZero() {
+			logger = logger.With(applog.Time("start_date", options.StartDate))
+			query = query.Where("showtimes.start_time >= ?", options.StartDate)
+		}
+		if !options.EndDate.IsZero() {
+			logger = logger.With(applog.Time("end_date", options.EndDate))
+			query = query.Where("showtimes.start_time <= ?", options.EndDate)
+		}
+	} else {
+		if !options.StartDate.IsZero() {
+			logger = logger.With(applog.Time("start_date", options.StartDate))
+			query = query.Where("daily_sales.sales_date >= ?", booking.SalesDate(options.StartDate).Format(time.DateOnly))
+		}
+		if !options.EndDate.IsZero() {
+			logger = logger.With(applog.Time("end_date", options.EndDate))
+			query = query.Where("daily_sales.sales_date <= ?", booking.SalesDate(options.EndDate).Format(time.DateOnly))
+		}
+	}
+
+	if options.MovieID != 0 {
+		logger = logger.With(applog.Uint("movie_id", options.MovieID))
+		query = query.Where("daily_sales.movie_id = ?", options.MovieID)
+	}
+	if options.CinemaID != 0 {
+		logger = logger.With(applog.Uint("cinema_id", options.CinemaID))
+		query = query.Where("cinema_halls.cinema_id = ?", options.CinemaID)
+	}
+	if options.CinemaHallID != 0 {
+		logger = logger.With(applog.Uint("cinema_hall_id", options.CinemaHallID))
+		query = query.Where("daily_sales.cinema_hall_id = ?", options.CinemaHallID)
+	}
+	return query, logger
+}
+
+// 净值：确认 - 退款
+const (
+	dailySalesRevenueExpr  = "COALESCE(SUM(daily_sales.gross - daily_sales.discounts - daily_sales.refunds), 0)"
+	dailySalesBookingsExpr = "COALESCE(SUM(daily_sales.bookings - daily_sales.refunded_bookings), 0)"
+	dailySalesTicketsExpr  = "COALESCE(SUM(daily_sales.tickets - daily_sales.refunded_tickets), 0)"
+)
+
+// GetSalesStatistics 获取销售统计数据
+func (r *gormDailySalesRepository) GetSalesStatistics(ctx context.Context, options *booking.SalesQueryOptions) (*booking.SalesStatistics, error) {
+	query, logger := r.salesQuery(ctx, options, r.logger.With(applog.String("Method", "GetSalesStatistics")))
+
+	var stats booking.SalesStatistics
+	err := query.Select(dailySalesRevenueExpr+" AS total_revenue, "+
+		dailySalesBookingsExpr+" AS total_bookings, "+
+		dailySalesTicketsExpr+" AS total_tickets").
+		Row().Scan(&stats.TotalRevenue, &stats.TotalBookings, &stats.TotalTickets)
+	if err != nil {
+		logger.Error("database get daily sales statistics error", applog.Error(err))
+		return nil, fmt.Errorf("database get daily sales statistics error: %w", err)
+	}
+
+	logger.Info("get daily sales statistics successfully")
+	return &stats, nil
+}
+
+// GetSalesGroups 按时间桶和/或维度分组统计销售数据。
+// 按下单时间口径时时间桶为业务日期零点；按场次口径时按场次开始时间截断，与订单表查询相同
+func (r *gormDailySalesRepository) GetSalesGroups(ctx context.Context, options *booking.SalesQueryOptions, group *booking.SalesGroupQuery) ([]*booking.SalesBucket, error) {
+	query, logger := r.salesQuery(ctx, options, r.logger.With(applog.String("Method", "GetSalesGroups"),
+		applog.Int("bucket_minutes", group.BucketMinutes), applog.String("dimension", string(group.Dimension))))
+
+	columns := []string{"'' AS bucket"}
+	groups := make([]string, 0, 3)
+	if group.BucketMinutes > 0 {
+		bucket := "DATE_FORMAT(daily_sales.sales_date, '%Y-%m-%d 00:00')"
+		if options.DateBasis == booking.SalesDateBasisShowtime {
+			bucket = salesBucketExpr("showtimes.start_time", group.BucketMinutes)
+		}
+		columns[0] = bucket + " AS bucket"
+		groups = append(groups, "bucket")
+	}
+	dimensionColumns, dimensionGroups := salesDimensionColumns(group.Dimension)
+	columns = append(columns, dimensionColumns...)
+	groups = append(groups, dimensionGroups...)
+	columns = append(columns,
+		dailySalesRevenueExpr+" AS revenue",
+		dailySalesBookingsExpr+" AS bookings",
+		dailySalesTicketsExpr+" AS tickets")
+
+	query = query.Select(strings.Join(columns, ", "))
+	if len(groups) > 0 {
+		// 全部退款的分组不再出现，与订单表只统计已确认订单一致
+		query = query.Group(strings.Join(groups, ", ")).Having(dailySalesBookingsExpr + " > 0")
+	}
+	if group.BucketMinutes > 0 {
+		query = query.Order("bucket ASC")
+	} else {
+		query = query.Order("revenue DESC")
+	}
+
+	var rows []salesGroupRow
+	if err := query.Scan(&rows).Error; err != nil {
+		logger.Error("database get daily sales groups error", applog.Error(err))
+		return nil, fmt.Errorf("database get daily sales groups error: %w", err)
+	}
+	buckets, err := salesBucketsFromRows(rows)
+	if err != nil {
+		logger.Error("failed to parse sales buckets", applog.Error(err))
+		return nil, err
+	}
+
+	logger.Info("get daily sales groups successfully", applog.Int("count", len(buckets)))
+	return buckets, nil
+}
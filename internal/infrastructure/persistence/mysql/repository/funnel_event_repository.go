@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"mrs/internal/domain/analytics"
+	"mrs/internal/domain/shared/vo"
+	"mrs/internal/infrastructure/persistence/mysql/models"
+	applog "mrs/pkg/log"
+
+	"gorm.io/gorm"
+)
+
+type gormFunnelEventRepository struct {
+	db     *gorm.DB
+	logger applog.Logger
+}
+
+func NewGormFunnelEventRepository(db *gorm.DB, logger applog.Logger) analytics.FunnelEventRepository {
+	return &gormFunnelEventRepository{
+		db:     db,
+		logger: logger.With(applog.String("Repository", "FunnelEventRepository")),
+	}
+}
+
+func (r *gormFunnelEventRepository) Append(ctx context.Context, events ...*analytics.FunnelEvent) error {
+	logger := r.logger.With(applog.String("Method", "Append"), applog.Int("count", len(events)))
+	if len(events) == 0 {
+		return nil
+	}
+
+	rows := make([]*models.FunnelEventGorm, len(events))
+	for i, event := range events {
+		rows[i] = models.FunnelEventGormFromDomain(event)
+	}
+	if err := r.db.WithContext(ctx).Create(&rows).Error; err != nil {
+		logger.Error("database append funnel events error", applog.Error(err))
+		return fmt.Errorf("database append funnel events error: %w", err)
+	}
+	for i, row := range rows {
+		events[i].ID = vo.FunnelEventID(row.ID)
+	}
+
+	logger.Debug("append funnel events successfully")
+	return nil
+}
+
+// 按 (user_id, showtime_id) 聚合时间范围内的事件得到会话，各阶段标记为到达该阶段或之后阶段
+func (r *gormFunnelEventRepository) sessions(ctx context.Context, options *analytics.FunnelQueryOptions) *gorm.DB {
+	locked := []string{string(analytics.EventSeatsLocked), string(analytics.EventBookingCreated),
+		string(analytics.EventBookingConfirmed), string(analytics.EventBookingCancelled)}
+	booked := locked[1:]
+
+	query := r.db.WithContext(ctx).Table("funnel_events").
+		Select("user_id, showtime_id, MIN(occurred_at) AS started_at, "+
+			"MAX(event_type IN ?) AS locked, MAX(event_type IN ?) AS booked, "+
+			"MAX(event_type = ?) AS confirmed, MAX(event_type = ?) AS cancelled",
+			locked, booked, string(analytics.EventBookingConfirmed), string(analytics.EventBookingCancelled)).
+		Group("user_id, showtime_id")
+	if !options.StartDate.IsZero() {
+		query = query.Where("occurred_at >= ?", options.StartDate)
+	}
+	if !options.EndDate.IsZero() {
+		query = query.Where("occurred_at <= ?", options.EndDate)
+	}
+	return query
+}
+
+// 会话所属场次的过滤条件
+func (r *gormFunnelEventRepository) filterShowtimes(query *gorm.DB, logger applog.Logger, options *analytics.FunnelQueryOptions) (*gorm.DB, applog.Logger) {
+	if !options.StartDate.IsZero() {
+		logger = logger.With(applog.Time("start_date", options.StartDate))
+	}
+	if !options.EndDate.IsZero() {
+		logger = logger.With(applog.Time("end_date", options.EndDate))
+	}
+	if options.MovieID != 0 {
+		logger = logger.With(applog.Uint("movie_id", options.MovieID))
+		query = query.Where("showtimes.movie_id = ?", options.MovieID)
+	}
+	if options.CinemaID != 0 {
+		logger = logger.With(applog.Uint("cinema_id", options.CinemaID))
+		query = query.Where("cinema_halls.cinema_id = ?", options.CinemaID)
+	}
+	if options.CinemaHallID != 0 {
+		logger = logger.With(applog.Uint("cinema_hall_id", options.CinemaHallID))
+		query = query.Where("showtimes.cinema_hall_id = ?", options.CinemaHallID)
+	}
+	return query, logger
+}
+
+// CountFunnel 按电影与会话开始小时汇总各阶段的会话数，包含已取消或已删除的场次
+func (r *gormFunnelEventRepository) CountFunnel(ctx context.Context, options *analytics.FunnelQueryOptions) ([]*analytics.FunnelCount, error) {
+	query := r.db.WithContext(ctx).Table("(?) AS s", r.sessions(ctx, options)).
+		Joins("JOIN showtimes ON showtimes.id = s.showtime_id").
+		Joins("JOIN movies ON movies.id = showtimes.movie_id").
+		Joins("JOIN cinema_halls ON cinema_halls.id = showtimes.cinema_hall_id")
+	query, logger := r.filterShowtimes(query, r.logger.With(applog.String("Method", "CountFunnel")), options)
+
+	var rows []struct {
+		MovieID    uint
+		MovieTitle string
+		Hour       int
+		Sessions   int
+		Locked     int
+		Booked     int
+		Confirmed  int
+		Cancelled  int
+	}
+	err := query.Select("showtimes.movie_id, movies.title AS movie_title, HOUR(s.started_at) AS hour, " +
+		"COUNT(*) AS sessions, SUM(s.locked) AS locked, SUM(s.booked) AS booked, " +
+		"SUM(s.confirmed) AS confirmed, SUM(s.cancelled AND NOT s.confirmed) AS cancelled").
+		Group("showtimes.movie_id, movies.title, HOUR(s.started_at)").
+		Order("showtimes.movie_id ASC, hour ASC").
+		Scan(&rows).Error
+	if err != nil {
+		logger.Error("database count funnel error", applog.Error(err))
+		return nil, fmt.Errorf("database count funnel error: %w", err)
+	}
+
+	counts := make([]*analytics.FunnelCount, len(rows))
+	for i, row := range rows {
+		counts[i] = &analytics.FunnelCount{
+			MovieID:    vo.MovieID(row.MovieID),
+			MovieTitle: row.MovieTitle,
+			Hour:       row.Hour,
+			Sessions:   row.Sessions,
+			Locked:     row.Locked,
+			Booked:     row.Booked,
+			Confirmed:  row.Confirmed,
+			Cancelled:  row.Cancelled,
+		}
+	}
+
+	logger.Info("count funnel successfully", applog.Int("rows", len(counts)))
+	return counts, nil
+}
+
+// FindConfirmDurations 订单创建事件与确认事件按订单ID配对，两个事件都需在时间范围内
+func (r *gormFunnelEventRepository) FindConfirmDurations(ctx context.Context, options *analytics.FunnelQueryOptions) ([]*analytics.ConfirmDuration, error) {
+	query := r.db.WithContext(ctx).Table("funnel_events AS created").
+		Joins("JOIN funnel_events AS confirmed ON confirmed.booking_id = created.booking_id AND confirmed.event_type = ?",
+			string(analytics.EventBookingConfirmed)).
+		Joins("JOIN (?) AS s ON s.user_id = created.user_id AND s.showtime_id = created.showtime_id", r.sessions(ctx, options)).
+		Joins("JOIN showtimes ON showtimes.id = created.showtime_id").
+		Joins("JOIN movies ON movies.id = showtimes.movie_id").
+		Joins("JOIN cinema_halls ON cinema_halls.id = showtimes.cinema_hall_id").
+		Where("created.event_type = ? AND created.booking_id <> 0", string(analytics.EventBookingCreated))
+	if !options.StartDate.IsZero() {
+		query = query.Where("created.occurred_at >= ?", options.StartDate)
+	}
+	if !options.EndDate.IsZero() {
+		query = query.Where("confirmed.occurred_at <= ?", options.EndDate)
+	}
+	query, logger := r.filterShowtimes(query, r.logger.With(applog.String("Method", "FindConfirmDurations")), options)
+
+	var rows []struct {
+		MovieID    uint
+		MovieTitle string
+		Hour       int
+		Seconds    float64
+	}
+	err := query.Select("showtimes.movie_id, movies.title AS movie_title, HOUR(s.started_at) AS hour, " +
+		"TIMESTAMPDIFF(SECOND, created.occurred_at, confirmed.occurred_at) AS seconds").
+		Scan(&rows).Error
+	if err != nil {
+		logger.Error("database find confirm durations error", applog.Error(err))
+		return nil, fmt.Errorf("database find confirm durations error: %w", err)
+	}
+
+	durations := make([]*analytics.ConfirmDuration, len(rows))
+	for i, row := range rows {
+		durations[i] = &analytics.ConfirmDuration{
+			MovieID:    vo.MovieID(row.MovieID),
+			MovieTitle: row.MovieTitle,
+			Hour:       row.Hour,
+			Seconds:    row.Seconds,
+		}
+	}
+
+	logger.Info("find confirm durations successfully", applog.Int("count", len(durations)))
+	return durations, nil
+}
@@ -74,7 +74,7 @@ func (r *gormGenreRepository) FindByName(ctx context.Context, name string) (*mov
 func (r *gormGenreRepository) ListAll(ctx context.Context) ([]*movie.Genre, error) {
 	logger := r.logger.With(applog.String("Method", "ListAll"))
 	var genresGorms []*models.GenreGorm
-	if err := r.db.WithContext(ctx).Find(&genresGorms).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Translations").Find(&genresGorms).Error; err != nil {
 		logger.Error("database list all genres error", applog.Error(err))
 		return nil, fmt.Errorf("database list all genres error: %w", err)
 	}
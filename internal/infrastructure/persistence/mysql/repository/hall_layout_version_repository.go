@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mrs/internal/domain/cinema"
+	"mrs/internal/domain/shared/vo"
+	"mrs/internal/infrastructure/persistence/mysql/models"
+	applog "mrs/pkg/log"
+
+	"gorm.io/gorm"
+)
+
+type gormHallLayoutVersionRepository struct {
+	db     *gorm.DB
+	logger applog.Logger
+}
+
+func NewGormHallLayoutVersionRepository(db *gorm.DB, logger applog.Logger) cinema.HallLayoutVersionRepository {
+	return &gormHallLayoutVersionRepository{
+		db:     db,
+		logger: logger.With(applog.String("Repository", "gormHallLayoutVersionRepository")),
+	}
+}
+
+func (r *gormHallLayoutVersionRepository) Create(ctx context.Context, version *cinema.HallLayoutVersion) (*cinema.HallLayoutVersion, error) {
+	logger := r.logger.With(applog.String("Method", "Create"),
+		applog.Uint("hall_id", uint(version.CinemaHallID)), applog.Int("version", version.Version))
+
+	versionGorm := models.HallLayoutVersionGormFromDomain(version)
+	if err := r.db.WithContext(ctx).Create(versionGorm).Error; err != nil {
+		logger.Error("database create hall layout version error", applog.Error(err))
+		return nil, fmt.Errorf("database create hall layout version error: %w", err)
+	}
+
+	logger.Info("create hall layout version successfully")
+	return versionGorm.ToDomain(), nil
+}
+
+func (r *gormHallLayoutVersionRepository) FindByHallAndVersion(ctx context.Context, hallID vo.CinemaHallID, version int) (*cinema.HallLayoutVersion, error) {
+	logger := r.logger.With(applog.String("Method", "FindByHallAndVersion"),
+		applog.Uint("hall_id", uint(hallID)), applog.Int("version", version))
+
+	var versionGorm models.HallLayoutVersionGorm
+	if err := r.db.WithContext(ctx).Where("cinema_hall_id = ? AND version = ?", uint(hallID), version).
+		First(&versionGorm).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("hall layout version not found", applog.Error(err))
+			return nil, fmt.Errorf("%w(version): %v", cinema.ErrLayoutVersionNotFound, version)
+		}
+		logger.Error("database find hall layout version error", applog.Error(err))
+		return nil, fmt.Errorf("database find hall layout version error: %w", err)
+	}
+
+	logger.Info("find hall layout version successfully")
+	return versionGorm.ToDomain(), nil
+}
+
+func (r *gormHallLayoutVersionRepository) ListByHallID(ctx context.Context, hallID vo.CinemaHallID) ([]*cinema.HallLayoutVersion, error) {
+	logger := r.logger.With(applog.String("Method", "ListByHallID"), applog.Uint("hall_id", uint(hallID)))
+
+	var versionGorms []*models.HallLayoutVersionGorm
+	if err := r.db.WithContext(ctx).Where("cinema_hall_id = ?", uint(hallID)).Order("version").
+		Find(&versionGorms).Error; err != nil {
+		logger.Error("database list hall layout versions error", applog.Error(err))
+		return nil, fmt.Errorf("database list hall layout versions error: %w", err)
+	}
+
+	logger.Info("list hall layout versions successfully", applog.Int("count", len(versionGorms)))
+	versions := make([]*cinema.HallLayoutVersion, len(versionGorms))
+	for i, versionGorm := range versionGorms {
+		versions[i] = versionGorm.ToDomain()
+	}
+	return versions, nil
+}
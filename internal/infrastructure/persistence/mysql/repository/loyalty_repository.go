@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"mrs/internal/domain/loyalty"
+	"mrs/internal/domain/shared/vo"
+	"mrs/internal/infrastructure/persistence/mysql/models"
+	applog "mrs/pkg/log"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormLoyaltyLedgerRepository struct {
+	db     *gorm.DB
+	logger applog.Logger
+}
+
+func NewGormLoyaltyLedgerRepository(db *gorm.DB, logger applog.Logger) loyalty.LedgerRepository {
+	return &gormLoyaltyLedgerRepository{
+		db:     db,
+		logger: logger.With(applog.String("Repository", "LoyaltyLedgerRepository")),
+	}
+}
+
+func (r *gormLoyaltyLedgerRepository) Append(ctx context.Context, entries ...*loyalty.Entry) error {
+	logger := r.logger.With(applog.String("Method", "Append"), applog.Int("count", len(entries)))
+	if len(entries) == 0 {
+		return nil
+	}
+
+	rows := make([]*models.LoyaltyEntryGorm, len(entries))
+	for i, entry := range entries {
+		rows[i] = models.LoyaltyEntryGormFromDomain(entry)
+	}
+	if err := r.db.WithContext(ctx).Create(&rows).Error; err != nil {
+		logger.Error("database append loyalty entries error", applog.Error(err))
+		return fmt.Errorf("database append loyalty entries error: %w", err)
+	}
+	for i, row := range rows {
+		entries[i].ID = vo.LoyaltyEntryID(row.ID)
+	}
+
+	logger.Info("append loyalty entries successfully")
+	return nil
+}
+
+func (r *gormLoyaltyLedgerRepository) GetAccount(ctx context.Context, userID vo.UserID) (*loyalty.Account, error) {
+	logger := r.logger.With(applog.String("Method", "GetAccount"), applog.Uint("user_id", uint(userID)))
+
+	var row struct {
+		Balance          int
+		QualifyingPoints int
+	}
+	err := r.db.WithContext(ctx).Model(&models.LoyaltyEntryGorm{}).
+		Select("COALESCE(SUM(points), 0) AS balance, "+
+			"COALESCE(SUM(CASE WHEN entry_type IN ? THEN points ELSE 0 END), 0) AS qualifying_points",
+			[]string{string(loyalty.EntryEarn), string(loyalty.EntryReverse)}).
+		Where("user_id = ?", userID).
+		Scan(&row).Error
+	if err != nil {
+		logger.Error("database get loyalty account error", applog.Error(err))
+		return nil, fmt.Errorf("database get loyalty account error: %w", err)
+	}
+
+	return &loyalty.Account{
+		UserID:           userID,
+		Balance:          row.Balance,
+		QualifyingPoints: row.QualifyingPoints,
+	}, nil
+}
+
+// LockAccount 以用户行作为账户锁（SELECT ... FOR UPDATE），事务结束时释放
+func (r *gormLoyaltyLedgerRepository) LockAccount(ctx context.Context, userID vo.UserID) (*loyalty.Account, error) {
+	logger := r.logger.With(applog.String("Method", "LockAccount"), applog.Uint("user_id", uint(userID)))
+
+	var ids []uint
+	if err := r.db.WithContext(ctx).Model(&models.UserGorm{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", userID).Pluck("id", &ids).Error; err != nil {
+		logger.Error("database lock loyalty account error", applog.Error(err))
+		return nil, fmt.Errorf("database lock loyalty account error: %w", err)
+	}
+	return r.GetAccount(ctx, userID)
+}
+
+func (r *gormLoyaltyLedgerRepository) FindByBookingIDs(ctx context.Context, bookingIDs []vo.BookingID) ([]*loyalty.Entry, error) {
+	logger := r.logger.With(applog.String("Method", "FindByBookingIDs"), applog.Int("bookings", len(bookingIDs)))
+	if len(bookingIDs) == 0 {
+		return []*loyalty.Entry{}, nil
+	}
+
+	var rows []*models.LoyaltyEntryGorm
+	if err := r.db.WithContext(ctx).Where("booking_id IN ?", bookingIDs).Order("id ASC").Find(&rows).Error; err != nil {
+		logger.Error("database find loyalty entries error", applog.Error(err))
+		return nil, fmt.Errorf("database find loyalty entries error: %w", err)
+	}
+
+	entries := make([]*loyalty.Entry, len(rows))
+	for i, row := range rows {
+		entries[i] = row.ToDomain()
+	}
+	return entries, nil
+}
+
+func (r *gormLoyaltyLedgerRepository) ListByUser(ctx context.Context, userID vo.UserID, page, pageSize int) ([]*loyalty.Entry, int64, error) {
+	logger := r.logger.With(applog.String("Method", "ListByUser"), applog.Uint("user_id", uint(userID)))
+
+	query := r.db.WithContext(ctx).Model(&models.LoyaltyEntryGorm{}).Where("user_id = ?", userID)
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		logger.Error("database count loyalty entries error", applog.Error(err))
+		return nil, 0, fmt.Errorf("database count loyalty entries error: %w", err)
+	}
+	if totalCount == 0 {
+		logger.Info("no loyalty entries found")
+		return []*loyalty.Entry{}, 0, nil
+	}
+
+	var rows []*models.LoyaltyEntryGorm
+	offset := (page - 1) * pageSize
+	if err := query.Order("created_at DESC, id DESC").Offset(offset).Limit(pageSize).Find(&rows).Error; err != nil {
+		logger.Error("database list loyalty entries error", applog.Error(err))
+		return nil, 0, fmt.Errorf("database list loyalty entries error: %w", err)
+	}
+
+	logger.Info("list loyalty entries successfully", applog.Int("count", len(rows)), applog.Int64("total_count", totalCount))
+	entries := make([]*loyalty.Entry, len(rows))
+	for i, row := range rows {
+		entries[i] = row.ToDomain()
+	}
+	return entries, totalCount, nil
+}
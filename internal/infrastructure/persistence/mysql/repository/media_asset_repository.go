@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mrs/internal/domain/movie"
+	"mrs/internal/domain/shared/vo"
+	"mrs/internal/infrastructure/persistence/mysql/models"
+	applog "mrs/pkg/log"
+
+	"gorm.io/gorm"
+)
+
+type gormMediaAssetRepository struct {
+	db     *gorm.DB
+	logger applog.Logger
+}
+
+func NewGormMediaAssetRepository(db *gorm.DB, logger applog.Logger) movie.MediaAssetRepository {
+	return &gormMediaAssetRepository{
+		db:     db,
+		logger: logger.With(applog.String("Repository", "gormMediaAssetRepository")),
+	}
+}
+
+func (r *gormMediaAssetRepository) Create(ctx context.Context, asset *movie.MediaAsset) (*movie.MediaAsset, error) {
+	logger := r.logger.With(applog.String("Method", "Create"),
+		applog.Uint("movie_id", uint(asset.MovieID)), applog.String("kind", string(asset.Kind)))
+
+	assetGorm := models.MediaAssetGormFromDomain(asset)
+	if err := r.db.WithContext(ctx).Create(assetGorm).Error; err != nil {
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			logger.Warn("movie not found", applog.Error(err))
+			return nil, fmt.Errorf("%w(id): %v", movie.ErrMovieNotFound, asset.MovieID)
+		}
+		logger.Error("database create media asset error", applog.Error(err))
+		return nil, fmt.Errorf("database create media asset error: %w", err)
+	}
+
+	logger.Info("create media asset successfully", applog.Uint("media_asset_id", assetGorm.ID))
+	return assetGorm.ToDomain(), nil
+}
+
+func (r *gormMediaAssetRepository) FindByMovieID(ctx context.Context, movieID vo.MovieID) ([]*movie.MediaAsset, error) {
+	logger := r.logger.With(applog.String("Method", "FindByMovieID"), applog.Uint("movie_id", uint(movieID)))
+
+	var assetGorms []*models.MediaAssetGorm
+	if err := r.db.WithContext(ctx).Where("movie_id = ?", movieID).Order("id").Find(&assetGorms).Error; err != nil {
+		logger.Error("database find media assets by movie id error", applog.Error(err))
+		return nil, fmt.Errorf("database find media assets by movie id error: %w", err)
+	}
+
+	assets := make([]*movie.MediaAsset, len(assetGorms))
+	for i, assetGorm := range assetGorms {
+		assets[i] = assetGorm.ToDomain()
+	}
+	logger.Info("find media assets by movie id successfully", applog.Int("count", len(assets)))
+	return assets, nil
+}
+
+func (r *gormMediaAssetRepository) FindByMovieAndKind(ctx context.Context, movieID vo.MovieID, kind movie.MediaKind) (*movie.MediaAsset, error) {
+	logger := r.logger.With(applog.String("Method", "FindByMovieAndKind"),
+		applog.Uint("movie_id", uint(movieID)), applog.String("kind", string(kind)))
+
+	var assetGorm models.MediaAssetGorm
+	if err := r.db.WithContext(ctx).Where("movie_id = ? AND kind = ?", movieID, kind).First(&assetGorm).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("media asset not found", applog.Error(err))
+			return nil, fmt.Errorf("%w(movie_id, kind): %v, %s", movie.ErrMediaAssetNotFound, movieID, kind)
+		}
+		logger.Error("database find media asset error", applog.Error(err))
+		return nil, fmt.Errorf("database find media asset error: %w", err)
+	}
+
+	logger.Info("find media asset successfully", applog.Uint("media_asset_id", assetGorm.ID))
+	return assetGorm.ToDomain(), nil
+}
+
+func (r *gormMediaAssetRepository) DeleteByMovieAndKind(ctx context.Context, movieID vo.MovieID, kind movie.MediaKind) error {
+	logger := r.logger.With(applog.String("Method", "DeleteByMovieAndKind"),
+		applog.Uint("movie_id", uint(movieID)), applog.String("kind", string(kind)))
+
+	result := r.db.WithContext(ctx).Where("movie_id = ? AND kind = ?", movieID, kind).Delete(&models.MediaAssetGorm{})
+	if err := result.Error; err != nil {
+		logger.Error("database delete media asset error", applog.Error(err))
+		return fmt.Errorf("database delete media asset error: %w", err)
+	}
+
+	logger.Info("delete media asset successfully", applog.Int64("rows_affected", result.RowsAffected))
+	return nil
+}
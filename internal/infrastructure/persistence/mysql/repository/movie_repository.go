@@ -45,7 +45,7 @@ func (r *gormMovieRepository) Create(ctx context.Context, mv *movie.Movie) (*mov
 func (r *gormMovieRepository) FindByID(ctx context.Context, id vo.MovieID) (*movie.Movie, error) {
 	logger := r.logger.With(applog.String("Method", "FindByID"), applog.Uint("movie_id", uint(id)))
 	var mvGorm models.MovieGorm
-	if err := r.db.WithContext(ctx).Preload("Genres").First(&mvGorm, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Genres").Scopes(preloadDetails).First(&mvGorm, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			logger.Warn("movie id not found", applog.Error(err))
 			return nil, fmt.Errorf("%w(id): %w", movie.ErrMovieNotFound, err)
@@ -60,7 +60,7 @@ func (r *gormMovieRepository) FindByID(ctx context.Context, id vo.MovieID) (*mov
 func (r *gormMovieRepository) FindByIDs(ctx context.Context, ids []vo.MovieID) ([]*movie.Movie, error) {
 	logger := r.logger.With(applog.String("Method", "FindByIDs"), applog.Int("count", len(ids)))
 	var mvGorms []*models.MovieGorm
-	if err := r.db.WithContext(ctx).Preload("Genres").Where("id IN (?)", ids).Find(&mvGorms).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Genres").Scopes(preloadDetails).Where("id IN (?)", ids).Find(&mvGorms).Error; err != nil {
 		logger.Error("database find movies by ids error", applog.Error(err))
 		return nil, fmt.Errorf("database find movies by ids error: %w", err)
 	}
@@ -76,7 +76,7 @@ func (r *gormMovieRepository) FindByIDs(ctx context.Context, ids []vo.MovieID) (
 func (r *gormMovieRepository) FindByTitle(ctx context.Context, title string) (*movie.Movie, error) {
 	logger := r.logger.With(applog.String("Method", "FindByTitle"), applog.String("title", title))
 	var mvGorm models.MovieGorm
-	if err := r.db.WithContext(ctx).Preload("Genres").Where("title = ?", title).First(&mvGorm).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Genres").Scopes(preloadDetails).Where("title = ?", title).First(&mvGorm).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			logger.Warn("movie title not found", applog.Error(err))
 			return nil, fmt.Errorf("%w(title): %w", movie.ErrMovieNotFound, err)
@@ -139,6 +139,25 @@ func (r *gormMovieRepository) List(ctx context.Context, options *movie.MovieQuer
 			Where("genres.name = ?", options.GenreName)
 		logger = logger.With(applog.String("query_genre", options.GenreName))
 	}
+
+	// 影人过滤（子查询避免同一影人担任多个职务时产生重复行）
+	if options.PersonID != 0 {
+		creditQuery := r.db.WithContext(ctx).Model(&models.MovieCreditGorm{}).Select("movie_id").Where("person_id = ?", options.PersonID)
+		if options.CreditRole != "" {
+			creditQuery = creditQuery.Where("role = ?", options.CreditRole)
+		}
+		query = query.Where("movies.id IN (?)", creditQuery)
+		countQuery = countQuery.Where("movies.id IN (?)", creditQuery)
+		logger = logger.With(applog.Uint("query_person_id", options.PersonID), applog.String("query_credit_role", options.CreditRole))
+	}
+
+	// 生命周期状态过滤
+	if len(options.Statuses) > 0 {
+		query = query.Where("movies.status IN ?", options.Statuses)
+		countQuery = countQuery.Where("movies.status IN ?", options.Statuses)
+		logger = logger.With(applog.Any("query_statuses", options.Statuses))
+	}
+
 	// 获取总数
 	if err := countQuery.Count(&totalCount).Error; err != nil {
 		logger.Error("database count movies error", applog.Error(err))
@@ -152,7 +171,7 @@ func (r *gormMovieRepository) List(ctx context.Context, options *movie.MovieQuer
 
 	// 应用排序和分页，并预加载类型
 	offset := (options.Page - 1) * options.PageSize
-	if err := query.Order("release_date DESC, title ASC").Offset(offset).Limit(options.PageSize).Preload("Genres").Find(&moviesGorms).Error; err != nil {
+	if err := query.Order("release_date DESC, title ASC").Offset(offset).Limit(options.PageSize).Preload("Genres").Scopes(preloadDetails).Find(&moviesGorms).Error; err != nil {
 		logger.Error("database list movies error", applog.Error(err))
 		return nil, 0, fmt.Errorf("database list movies error: %w", err)
 	}
@@ -233,6 +252,104 @@ func (r *gormMovieRepository) ReplaceGenresForMovie(ctx context.Context, mv *mov
 	return nil
 }
 
+// 替换电影的演职员（先删除再批量插入）
+func (r *gormMovieRepository) ReplaceCreditsForMovie(ctx context.Context, id vo.MovieID, credits []*movie.Credit) error {
+	logger := r.logger.With(applog.String("Method", "ReplaceCreditsForMovie"),
+		applog.Uint("movie_id", uint(id)), applog.Int("credit_count", len(credits)))
+
+	if err := r.db.WithContext(ctx).Where("movie_id = ?", id).Delete(&models.MovieCreditGorm{}).Error; err != nil {
+		logger.Error("database delete movie credits error", applog.Error(err))
+		return fmt.Errorf("database delete movie credits error: %w", err)
+	}
+
+	if len(credits) > 0 {
+		creditGorms := make([]*models.MovieCreditGorm, len(credits))
+		for i, credit := range credits {
+			creditGorms[i] = models.MovieCreditGormFromDomain(id, credit)
+		}
+		if err := r.db.WithContext(ctx).Create(&creditGorms).Error; err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				logger.Warn("duplicated movie credit", applog.Error(err))
+				return fmt.Errorf("%w: duplicated person and role: %w", movie.ErrInvalidCredit, err)
+			}
+			logger.Error("database create movie credits error", applog.Error(err))
+			return fmt.Errorf("database create movie credits error: %w", err)
+		}
+	}
+
+	logger.Info("replace movie credits successfully")
+	return nil
+}
+
+// 预加载详情关联：按署名顺序的演职员及影人信息、媒体资源、电影与类型的翻译
+func preloadDetails(db *gorm.DB) *gorm.DB {
+	return db.Preload("Credits", func(db *gorm.DB) *gorm.DB {
+		return db.Order("billing_order, id")
+	}).Preload("Credits.Person").Preload("MediaAssets").
+		Preload("Translations").Preload("Genres.Translations")
+}
+
+// 增量更新用户评价聚合，使用表达式更新避免并发评价相互覆盖
+func (r *gormMovieRepository) ApplyReviewDelta(ctx context.Context, id vo.MovieID, countDelta int, scoreDelta int) error {
+	logger := r.logger.With(applog.String("Method", "ApplyReviewDelta"), applog.Uint("movie_id", uint(id)),
+		applog.Int("count_delta", countDelta), applog.Int("score_delta", scoreDelta))
+
+	result := r.db.WithContext(ctx).Model(&models.MovieGorm{}).Where("id = ?", id).Updates(map[string]any{
+		"review_count":     gorm.Expr("review_count + ?", countDelta),
+		"review_score_sum": gorm.Expr("review_score_sum + ?", scoreDelta),
+	})
+	if err := result.Error; err != nil {
+		logger.Error("database apply review delta error", applog.Error(err))
+		return fmt.Errorf("database apply review delta error: %w", err)
+	}
+
+	if result.RowsAffected == 0 {
+		logger.Warn("movie not found")
+		return fmt.Errorf("%w(id): %v", movie.ErrMovieNotFound, id)
+	}
+
+	logger.Info("apply review delta successfully")
+	return nil
+}
+
+// 查询处于指定生命周期状态的电影（不预加载关联）
+func (r *gormMovieRepository) FindByStatuses(ctx context.Context, statuses []movie.Status) ([]*movie.Movie, error) {
+	logger := r.logger.With(applog.String("Method", "FindByStatuses"), applog.Any("statuses", statuses))
+
+	var movieGorms []*models.MovieGorm
+	if err := r.db.WithContext(ctx).Where("status IN ?", statuses).Find(&movieGorms).Error; err != nil {
+		logger.Error("database find movies by statuses error", applog.Error(err))
+		return nil, fmt.Errorf("database find movies by statuses error: %w", err)
+	}
+
+	movies := make([]*movie.Movie, len(movieGorms))
+	for i, movieGorm := range movieGorms {
+		movies[i] = movieGorm.ToDomain()
+	}
+	logger.Info("find movies by statuses successfully", applog.Int("count", len(movies)))
+	return movies, nil
+}
+
+// 仅更新电影的生命周期状态
+func (r *gormMovieRepository) UpdateStatus(ctx context.Context, id vo.MovieID, status movie.Status) error {
+	logger := r.logger.With(applog.String("Method", "UpdateStatus"), applog.Uint("movie_id", uint(id)),
+		applog.String("status", string(status)))
+
+	result := r.db.WithContext(ctx).Model(&models.MovieGorm{}).Where("id = ?", id).Update("status", string(status))
+	if err := result.Error; err != nil {
+		logger.Error("database update movie status error", applog.Error(err))
+		return fmt.Errorf("database update movie status error: %w", err)
+	}
+
+	if result.RowsAffected == 0 {
+		logger.Warn("movie not found or status unchanged")
+		return fmt.Errorf("%w(id): %v", movie.ErrMovieNotFound, id)
+	}
+
+	logger.Info("update movie status successfully")
+	return nil
+}
+
 // // 为电影增加、删除和修改类型
 // func (r *gormMovieRepository) AddGenreToMovie(ctx context.Context, mv *movie.Movie, genre *movie.Genre) error {
 // 	logger := r.logger.With(
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"mrs/internal/domain/movie"
+	"mrs/internal/domain/shared/vo"
+	applog "mrs/pkg/log"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const (
+	naturalLanguageMode = "IN NATURAL LANGUAGE MODE"
+	booleanMode         = "IN BOOLEAN MODE"
+)
+
+// 相关度：标题单独加权，类型名称通过子查询汇总得分
+// 依赖 movies(title)、movies(title, description, cast) 与 genres(name) 三个 FULLTEXT 索引
+const movieSearchSQL = `
+SELECT * FROM (
+	SELECT m.id AS movie_id,
+		MATCH(m.title) AGAINST (@query %[1]s) * 3
+		+ MATCH(m.title, m.description, m.` + "`cast`" + `) AGAINST (@query %[1]s)
+		+ COALESCE(g.score, 0) * 2 AS score
+	FROM movies m
+	LEFT JOIN (
+		SELECT mg.movie_id, SUM(MATCH(genres.name) AGAINST (@query %[1]s)) AS score
+		FROM movies_genres mg
+		JOIN genres ON genres.id = mg.genre_id AND genres.deleted_at IS NULL
+		GROUP BY mg.movie_id
+	) g ON g.movie_id = m.id
+	WHERE m.deleted_at IS NULL
+) ranked
+WHERE ranked.score > 0`
+
+type movieSearchRow struct {
+	MovieID uint
+	Score   float64
+}
+
+// 基于 MySQL FULLTEXT 的电影搜索索引
+type gormMovieSearchIndex struct {
+	db     *gorm.DB
+	logger applog.Logger
+}
+
+func NewGormMovieSearchIndex(db *gorm.DB, logger applog.Logger) movie.MovieSearchIndex {
+	return &gormMovieSearchIndex{
+		db:     db,
+		logger: logger.With(applog.String("Repository", "gormMovieSearchIndex")),
+	}
+}
+
+// MySQL 在写入电影与类型时自动维护 FULLTEXT 索引，无需额外操作
+func (r *gormMovieSearchIndex) Index(ctx context.Context, mv *movie.Movie) error {
+	return nil
+}
+
+// 软删除的电影在查询时被过滤，无需额外操作
+func (r *gormMovieSearchIndex) Remove(ctx context.Context, id vo.MovieID) error {
+	return nil
+}
+
+// 先以自然语言模式检索；无结果时退化为布尔模式的前缀检索，
+// 将每个词截去可容忍的拼写错误数后加通配符，以容忍词尾的拼写错误
+func (r *gormMovieSearchIndex) Search(ctx context.Context, query *movie.MovieSearchQuery) (*movie.MovieSearchResult, error) {
+	logger := r.logger.With(applog.String("Method", "Search"), applog.String("query", query.Query))
+
+	terms := movie.QueryTerms(query.Query)
+	if len(terms) == 0 {
+		return &movie.MovieSearchResult{Hits: []*movie.MovieSearchHit{}}, nil
+	}
+
+	result, err := r.search(ctx, strings.Join(terms, " "), naturalLanguageMode, query)
+	if err != nil {
+		logger.Error("database search movies error", applog.Error(err))
+		return nil, err
+	}
+	if result.Total == 0 {
+		logger.Info("no exact matches, fallback to prefix search")
+		result, err = r.search(ctx, fuzzyBooleanQuery(terms), booleanMode, query)
+		if err != nil {
+			logger.Error("database fuzzy search movies error", applog.Error(err))
+			return nil, err
+		}
+	}
+
+	logger.Info("search movies successfully", applog.Int64("total", result.Total))
+	return result, nil
+}
+
+func (r *gormMovieSearchIndex) search(ctx context.Context, against, mode string, query *movie.MovieSearchQuery) (*movie.MovieSearchResult, error) {
+	rankedSQL := fmt.Sprintf(movieSearchSQL, mode)
+	args := map[string]any{"query": against}
+
+	var total int64
+	if err := r.db.WithContext(ctx).Raw("SELECT COUNT(*) FROM ("+rankedSQL+") counted", args).
+		Scan(&total).Error; err != nil {
+		return nil, fmt.Errorf("database count search movies error: %w", err)
+	}
+	if total == 0 {
+		return &movie.MovieSearchResult{Hits: []*movie.MovieSearchHit{}}, nil
+	}
+
+	pagedSQL := rankedSQL + " ORDER BY ranked.score DESC, ranked.movie_id ASC"
+	if query.PageSize > 0 {
+		args["limit"] = query.PageSize
+		args["offset"] = max(query.Page-1, 0) * query.PageSize
+		pagedSQL += " LIMIT @limit OFFSET @offset"
+	}
+	var rows []movieSearchRow
+	if err := r.db.WithContext(ctx).Raw(pagedSQL, args).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("database search movies error: %w", err)
+	}
+
+	hits := make([]*movie.MovieSearchHit, 0, len(rows))
+	for _, row := range rows {
+		hits = append(hits, &movie.MovieSearchHit{MovieID: vo.MovieID(row.MovieID), Score: row.Score})
+	}
+	return &movie.MovieSearchResult{Hits: hits, Total: total}, nil
+}
+
+// 构造布尔模式的前缀查询，例如 "interstelar" -> "interste*"
+func fuzzyBooleanQuery(terms []string) string {
+	parts := make([]string, 0, len(terms))
+	for _, term := range terms {
+		runes := []rune(term)
+		keep := max(len(runes)-movie.MaxTypos(term), 1)
+		parts = append(parts, string(runes[:keep])+"*")
+	}
+	return strings.Join(parts, " ")
+}
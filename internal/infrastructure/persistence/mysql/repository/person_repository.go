@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mrs/internal/domain/movie"
+	"mrs/internal/domain/shared/vo"
+	"mrs/internal/infrastructure/persistence/mysql/models"
+	applog "mrs/pkg/log"
+
+	"gorm.io/gorm"
+)
+
+type gormPersonRepository struct {
+	db     *gorm.DB
+	logger applog.Logger
+}
+
+func NewGormPersonRepository(db *gorm.DB, logger applog.Logger) movie.PersonRepository {
+	return &gormPersonRepository{
+		db:     db,
+		logger: logger.With(applog.String("Repository", "gormPersonRepository")),
+	}
+}
+
+func (r *gormPersonRepository) Create(ctx context.Context, person *movie.Person) (*movie.Person, error) {
+	logger := r.logger.With(applog.String("Method", "Create"), applog.String("name", person.Name))
+	personGorm := models.PersonGormFromDomain(person)
+	if err := r.db.WithContext(ctx).Create(personGorm).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			logger.Warn("person name already exists", applog.Error(err))
+			return nil, fmt.Errorf("%w: %w", movie.ErrPersonAlreadyExists, err)
+		}
+		logger.Error("database create person error", applog.Error(err))
+		return nil, fmt.Errorf("database create person error: %w", err)
+	}
+	logger.Info("create person successfully", applog.Uint("person_id", personGorm.ID))
+	return personGorm.ToDomain(), nil
+}
+
+func (r *gormPersonRepository) FindByID(ctx context.Context, id vo.PersonID) (*movie.Person, error) {
+	logger := r.logger.With(applog.String("Method", "FindByID"), applog.Uint("person_id", uint(id)))
+	var personGorm models.PersonGorm
+	if err := r.db.WithContext(ctx).First(&personGorm, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("person id not found", applog.Error(err))
+			return nil, fmt.Errorf("%w(id): %w", movie.ErrPersonNotFound, err)
+		}
+		logger.Error("database find person by id error", applog.Error(err))
+		return nil, fmt.Errorf("database find person by id error: %w", err)
+	}
+	logger.Info("find person successfully", applog.String("name", personGorm.Name))
+	return personGorm.ToDomain(), nil
+}
+
+func (r *gormPersonRepository) List(ctx context.Context, options *movie.PersonQueryOptions) ([]*movie.Person, int64, error) {
+	logger := r.logger.With(applog.String("Method", "List"), applog.Any("options", options))
+	var peopleGorms []*models.PersonGorm
+	var totalCount int64
+
+	query := r.db.WithContext(ctx).Model(&models.PersonGorm{})
+
+	// 姓名过滤（模糊查询）
+	if options.Name != "" {
+		query = query.Where("name LIKE ?", "%"+options.Name+"%")
+	}
+
+	if err := query.Count(&totalCount).Error; err != nil {
+		logger.Error("database count people error", applog.Error(err))
+		return nil, 0, fmt.Errorf("database count people error: %w", err)
+	}
+
+	offset := (options.Page - 1) * options.PageSize
+	if err := query.Order("name ASC").Offset(offset).Limit(options.PageSize).Find(&peopleGorms).Error; err != nil {
+		logger.Error("database list people error", applog.Error(err))
+		return nil, 0, fmt.Errorf("database list people error: %w", err)
+	}
+
+	people := make([]*movie.Person, len(peopleGorms))
+	for i, personGorm := range peopleGorms {
+		people[i] = personGorm.ToDomain()
+	}
+
+	logger.Info("list people successfully", applog.Int64("total_count", totalCount))
+	return people, totalCount, nil
+}
+
+func (r *gormPersonRepository) Update(ctx context.Context, person *movie.Person) error {
+	logger := r.logger.With(applog.String("Method", "Update"),
+		applog.Uint("person_id", uint(person.ID)), applog.String("name", person.Name))
+
+	personGorm := models.PersonGormFromDomain(person)
+
+	// 先执行一个轻量级查询
+	var exist int64
+	if err := r.db.WithContext(ctx).Model(&models.PersonGorm{}).Where("id = ?", personGorm.ID).Count(&exist).Error; err != nil {
+		logger.Error("database check person exist error", applog.Error(err))
+		return fmt.Errorf("database check person exist error: %w", err)
+	}
+
+	if exist == 0 {
+		logger.Warn("person not found")
+		return fmt.Errorf("%w(id): %v", movie.ErrPersonNotFound, personGorm.ID)
+	}
+
+	// 简介、出生日期与照片允许清空，显式指定更新列
+	result := r.db.WithContext(ctx).Model(&models.PersonGorm{}).
+		Where("id = ?", personGorm.ID).
+		Select("name", "bio", "birth_date", "photo_url").
+		Updates(personGorm)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			logger.Warn("person name already exists", applog.Error(result.Error))
+			return fmt.Errorf("%w: %w", movie.ErrPersonAlreadyExists, result.Error)
+		}
+		logger.Error("database update person error", applog.Error(result.Error))
+		return fmt.Errorf("database update person error: %w", result.Error)
+	}
+
+	logger.Info("update person successfully")
+	return nil
+}
+
+func (r *gormPersonRepository) Delete(ctx context.Context, id vo.PersonID) error {
+	logger := r.logger.With(applog.String("Method", "Delete"), applog.Uint("person_id", uint(id)))
+
+	// 软删除不会触发外键约束，引用检查由服务层通过 CheckPersonReferenced 完成
+	result := r.db.WithContext(ctx).Delete(&models.PersonGorm{}, id)
+	if err := result.Error; err != nil {
+		logger.Error("database delete person error", applog.Error(err))
+		return fmt.Errorf("database delete person error: %w", err)
+	}
+
+	if result.RowsAffected == 0 {
+		logger.Warn("person not found")
+		return fmt.Errorf("%w(id): %v", movie.ErrPersonNotFound, id)
+	}
+	logger.Info("delete person successfully")
+	return nil
+}
+
+func (r *gormPersonRepository) CheckPersonReferenced(ctx context.Context, id vo.PersonID) (bool, error) {
+	logger := r.logger.With(applog.String("Method", "CheckPersonReferenced"), applog.Uint("person_id", uint(id)))
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&models.MovieCreditGorm{}).
+		Joins("JOIN movies ON movies.id = movie_credits.movie_id AND movies.deleted_at IS NULL").
+		Where("movie_credits.person_id = ?", id).
+		Count(&count).Error; err != nil {
+		logger.Error("database check person referenced error", applog.Error(err))
+		return false, fmt.Errorf("database check person referenced error: %w", err)
+	}
+
+	logger.Info("check person referenced successfully", applog.Int64("count", count))
+	return count > 0, nil
+}
+
+// FindOrCreateByNames 查找指定姓名的影人，如果不存在则创建。
+func (r *gormPersonRepository) FindOrCreateByNames(ctx context.Context, names []string) ([]*movie.Person, error) {
+	logger := r.logger.With(applog.String("method", "FindOrCreateByNames"), applog.Any("person_names", names))
+
+	var personGorms []*models.PersonGorm
+	// 先尝试查找所有已存在的影人
+	if err := r.db.WithContext(ctx).Where("name IN ?", names).Find(&personGorms).Error; err != nil {
+		logger.Error("database find people by names error", applog.Error(err))
+		return nil, fmt.Errorf("database find people by names error: %w", err)
+	}
+
+	// 找出需要创建的影人姓名
+	existingNames := make(map[string]bool)
+	for _, p := range personGorms {
+		existingNames[p.Name] = true
+	}
+
+	var newNames []string
+	for _, name := range names {
+		if !existingNames[name] {
+			existingNames[name] = true
+			newNames = append(newNames, name)
+		}
+	}
+
+	// 批量创建不存在的影人
+	if len(newNames) > 0 {
+		newPeople := make([]*models.PersonGorm, len(newNames))
+		for i, name := range newNames {
+			newPeople[i] = &models.PersonGorm{Name: name}
+		}
+		if err := r.db.WithContext(ctx).Create(&newPeople).Error; err != nil {
+			logger.Error("database create new people error", applog.Error(err))
+			return nil, fmt.Errorf("database create new people error: %w", err)
+		}
+		personGorms = append(personGorms, newPeople...)
+	}
+
+	// 转换为领域模型
+	people := make([]*movie.Person, len(personGorms))
+	for i, p := range personGorms {
+		people[i] = p.ToDomain()
+	}
+
+	logger.Info("find or create people successfully", applog.Int("total_count", len(people)))
+	return people, nil
+}
+
+func (r *gormPersonRepository) FindFilmography(ctx context.Context, id vo.PersonID, role movie.CreditRole) ([]*movie.FilmographyEntry, error) {
+	logger := r.logger.With(applog.String("Method", "FindFilmography"),
+		applog.Uint("person_id", uint(id)), applog.String("role", string(role)))
+
+	query := r.db.WithContext(ctx).
+		Joins("JOIN movies ON movies.id = movie_credits.movie_id AND movies.deleted_at IS NULL").
+		Where("movie_credits.person_id = ?", id)
+	if role != "" {
+		query = query.Where("movie_credits.role = ?", role)
+	}
+
+	var creditGorms []*models.MovieCreditGorm
+	if err := query.Preload("Movie.Genres").
+		Order("movies.release_date DESC, movie_credits.billing_order ASC").
+		Find(&creditGorms).Error; err != nil {
+		logger.Error("database find filmography error", applog.Error(err))
+		return nil, fmt.Errorf("database find filmography error: %w", err)
+	}
+
+	entries := make([]*movie.FilmographyEntry, len(creditGorms))
+	for i, c := range creditGorms {
+		entries[i] = &movie.FilmographyEntry{
+			Movie:        c.Movie.ToDomain(),
+			Role:         movie.CreditRole(c.Role),
+			Character:    c.Character,
+			BillingOrder: c.BillingOrder,
+		}
+	}
+
+	logger.Info("find filmography successfully", applog.Int("count", len(entries)))
+	return entries, nil
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"mrs/internal/domain/booking"
+	"mrs/internal/domain/movie"
+	"mrs/internal/domain/recommendation"
+	"mrs/internal/domain/shared/vo"
+	applog "mrs/pkg/log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type gormRecommendationRepository struct {
+	db     *gorm.DB
+	logger applog.Logger
+}
+
+func NewGormRecommendationRepository(db *gorm.DB, logger applog.Logger) recommendation.RecommendationRepository {
+	return &gormRecommendationRepository{
+		db:     db,
+		logger: logger.With(applog.String("Repository", "gormRecommendationRepository")),
+	}
+}
+
+// 已确认订单与场次的关联查询（排除软删除记录）
+func (r *gormRecommendationRepository) confirmedBookings(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).Table("bookings").
+		Joins("JOIN showtimes ON showtimes.id = bookings.showtime_id AND showtimes.deleted_at IS NULL").
+		Where("bookings.deleted_at IS NULL").
+		Where("bookings.status = ?", string(booking.BookingStatusConfirmed))
+}
+
+func (r *gormRecommendationRepository) FindWatchedMovieIDs(ctx context.Context, userID vo.UserID) ([]vo.MovieID, error) {
+	logger := r.logger.With(applog.String("Method", "FindWatchedMovieIDs"), applog.Uint("user_id", uint(userID)))
+
+	var ids []uint
+	if err := r.confirmedBookings(ctx).Where("bookings.user_id = ?", userID).
+		Distinct().Pluck("showtimes.movie_id", &ids).Error; err != nil {
+		logger.Error("database find watched movies error", applog.Error(err))
+		return nil, fmt.Errorf("database find watched movies error: %w", err)
+	}
+
+	logger.Debug("find watched movies successfully", applog.Int("count", len(ids)))
+	movieIDs := make([]vo.MovieID, len(ids))
+	for i, id := range ids {
+		movieIDs[i] = vo.MovieID(id)
+	}
+	return movieIDs, nil
+}
+
+func (r *gormRecommendationRepository) FindCoAttendance(ctx context.Context, movieIDs []vo.MovieID) ([]*recommendation.CoAttendance, error) {
+	logger := r.logger.With(applog.String("Method", "FindCoAttendance"), applog.Int("movie_count", len(movieIDs)))
+	if len(movieIDs) == 0 {
+		return []*recommendation.CoAttendance{}, nil
+	}
+
+	var rows []struct {
+		MovieID      uint
+		OtherMovieID uint
+		Viewers      int
+	}
+	if err := r.confirmedBookings(ctx).
+		Joins("JOIN bookings other ON other.user_id = bookings.user_id AND other.deleted_at IS NULL AND other.status = ?",
+			string(booking.BookingStatusConfirmed)).
+		Joins("JOIN showtimes other_showtimes ON other_showtimes.id = other.showtime_id AND other_showtimes.deleted_at IS NULL").
+		Where("showtimes.movie_id IN ?", movieIDs).
+		Where("other_showtimes.movie_id <> showtimes.movie_id").
+		Select("showtimes.movie_id AS movie_id, other_showtimes.movie_id AS other_movie_id, " +
+			"COUNT(DISTINCT bookings.user_id) AS viewers").
+		Group("showtimes.movie_id, other_showtimes.movie_id").
+		Scan(&rows).Error; err != nil {
+		logger.Error("database find co-attendance error", applog.Error(err))
+		return nil, fmt.Errorf("database find co-attendance error: %w", err)
+	}
+
+	logger.Debug("find co-attendance successfully", applog.Int("count", len(rows)))
+	result := make([]*recommendation.CoAttendance, len(rows))
+	for i, row := range rows {
+		result[i] = &recommendation.CoAttendance{
+			MovieID:      vo.MovieID(row.MovieID),
+			OtherMovieID: vo.MovieID(row.OtherMovieID),
+			Viewers:      row.Viewers,
+		}
+	}
+	return result, nil
+}
+
+func (r *gormRecommendationRepository) CountAudience(ctx context.Context, movieIDs []vo.MovieID) (map[vo.MovieID]int, error) {
+	logger := r.logger.With(applog.String("Method", "CountAudience"), applog.Int("movie_count", len(movieIDs)))
+	audience := make(map[vo.MovieID]int, len(movieIDs))
+	if len(movieIDs) == 0 {
+		return audience, nil
+	}
+
+	var rows []struct {
+		MovieID uint
+		Viewers int
+	}
+	if err := r.confirmedBookings(ctx).Where("showtimes.movie_id IN ?", movieIDs).
+		Select("showtimes.movie_id AS movie_id, COUNT(DISTINCT bookings.user_id) AS viewers").
+		Group("showtimes.movie_id").
+		Scan(&rows).Error; err != nil {
+		logger.Error("database count audience error", applog.Error(err))
+		return nil, fmt.Errorf("database count audience error: %w", err)
+	}
+
+	for _, row := range rows {
+		audience[vo.MovieID(row.MovieID)] = row.Viewers
+	}
+	logger.Debug("count audience successfully", applog.Int("count", len(rows)))
+	return audience, nil
+}
+
+func (r *gormRecommendationRepository) FindPopularMovies(ctx context.Context, statuses []movie.Status, since time.Time, limit int) ([]*recommendation.PopularMovie, error) {
+	logger := r.logger.With(applog.String("Method", "FindPopularMovies"), applog.Time("since", since), applog.Int("limit", limit))
+
+	var rows []struct {
+		MovieID  uint
+		Bookings int
+	}
+	if err := r.confirmedBookings(ctx).
+		Joins("JOIN movies ON movies.id = showtimes.movie_id AND movies.deleted_at IS NULL").
+		Where("movies.status IN ?", statuses).
+		Where("bookings.booking_time >= ?", since).
+		Select("showtimes.movie_id AS movie_id, COUNT(*) AS bookings").
+		Group("showtimes.movie_id").
+		Order("bookings DESC, movie_id ASC").
+		Limit(limit).
+		Scan(&rows).Error; err != nil {
+		logger.Error("database find popular movies error", applog.Error(err))
+		return nil, fmt.Errorf("database find popular movies error: %w", err)
+	}
+
+	logger.Info("find popular movies successfully", applog.Int("count", len(rows)))
+	result := make([]*recommendation.PopularMovie, len(rows))
+	for i, row := range rows {
+		result[i] = &recommendation.PopularMovie{MovieID: vo.MovieID(row.MovieID), Bookings: row.Bookings}
+	}
+	return result, nil
+}
+
+func (r *gormRecommendationRepository) FindActiveUserIDs(ctx context.Context, since time.Time, afterID vo.UserID, limit int) ([]vo.UserID, error) {
+	logger := r.logger.With(applog.String("Method", "FindActiveUserIDs"),
+		applog.Time("since", since), applog.Uint("after_id", uint(afterID)))
+
+	var ids []uint
+	if err := r.db.WithContext(ctx).Table("bookings").
+		Where("deleted_at IS NULL").
+		Where("status = ?", string(booking.BookingStatusConfirmed)).
+		Where("booking_time >= ?", since).
+		Where("user_id > ?", afterID).
+		Distinct().Order("user_id ASC").Limit(limit).
+		Pluck("user_id", &ids).Error; err != nil {
+		logger.Error("database find active users error", applog.Error(err))
+		return nil, fmt.Errorf("database find active users error: %w", err)
+	}
+
+	logger.Debug("find active users successfully", applog.Int("count", len(ids)))
+	userIDs := make([]vo.UserID, len(ids))
+	for i, id := range ids {
+		userIDs[i] = vo.UserID(id)
+	}
+	return userIDs, nil
+}
@@ -0,0 +1,259 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mrs/internal/domain/report"
+	"mrs/internal/domain/shared/vo"
+	"mrs/internal/infrastructure/persistence/mysql/models"
+	applog "mrs/pkg/log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type gormReportSubscriptionRepository struct {
+	db     *gorm.DB
+	logger applog.Logger
+}
+
+func NewGormReportSubscriptionRepository(db *gorm.DB, logger applog.Logger) report.SubscriptionRepository {
+	return &gormReportSubscriptionRepository{
+		db:     db,
+		logger: logger.With(applog.String("Repository", "gormReportSubscriptionRepository")),
+	}
+}
+
+func (r *gormReportSubscriptionRepository) Create(ctx context.Context, subscription *report.Subscription) (*report.Subscription, error) {
+	logger := r.logger.With(applog.String("Method", "Create"), applog.String("name", subscription.Name))
+
+	subscriptionGorm := models.ReportSubscriptionGormFromDomain(subscription)
+	if err := r.db.WithContext(ctx).Create(subscriptionGorm).Error; err != nil {
+		logger.Error("database create report subscription error", applog.Error(err))
+		return nil, fmt.Errorf("database create report subscription error: %w", err)
+	}
+
+	logger.Info("create report subscription successfully", applog.Uint("subscription_id", subscriptionGorm.ID))
+	return subscriptionGorm.ToDomain(), nil
+}
+
+func (r *gormReportSubscriptionRepository) Update(ctx context.Context, subscription *report.Subscription) error {
+	logger := r.logger.With(applog.String("Method", "Update"), applog.Uint("subscription_id", uint(subscription.ID)))
+
+	subscriptionGorm := models.ReportSubscriptionGormFromDomain(subscription)
+	result := r.db.WithContext(ctx).Model(&models.ReportSubscriptionGorm{}).Where("id = ?", subscriptionGorm.ID).
+		Select("Name", "ReportType", "Parameters", "Cron", "Timezone", "Locale", "Format", "Channel", "Recipients", "Enabled", "NextRunAt").
+		Updates(subscriptionGorm)
+	if result.Error != nil {
+		logger.Error("database update report subscription error", applog.Error(result.Error))
+		return fmt.Errorf("database update report subscription error: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		logger.Warn("report subscription not found")
+		return fmt.Errorf("%w(id): %v", report.ErrSubscriptionNotFound, subscription.ID)
+	}
+
+	logger.Info("update report subscription successfully")
+	return nil
+}
+
+func (r *gormReportSubscriptionRepository) Delete(ctx context.Context, id vo.ReportSubscriptionID) error {
+	logger := r.logger.With(applog.String("Method", "Delete"), applog.Uint("subscription_id", uint(id)))
+
+	result := r.db.WithContext(ctx).Delete(&models.ReportSubscriptionGorm{}, id)
+	if result.Error != nil {
+		logger.Error("database delete report subscription error", applog.Error(result.Error))
+		return fmt.Errorf("database delete report subscription error: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		logger.Warn("report subscription not found")
+		return fmt.Errorf("%w(id): %v", report.ErrSubscriptionNotFound, id)
+	}
+
+	logger.Info("delete report subscription successfully")
+	return nil
+}
+
+func (r *gormReportSubscriptionRepository) FindByID(ctx context.Context, id vo.ReportSubscriptionID) (*report.Subscription, error) {
+	logger := r.logger.With(applog.String("Method", "FindByID"), applog.Uint("subscription_id", uint(id)))
+
+	var subscriptionGorm models.ReportSubscriptionGorm
+	if err := r.db.WithContext(ctx).First(&subscriptionGorm, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("report subscription not found")
+			return nil, fmt.Errorf("%w(id): %v", report.ErrSubscriptionNotFound, id)
+		}
+		logger.Error("database find report subscription error", applog.Error(err))
+		return nil, fmt.Errorf("database find report subscription error: %w", err)
+	}
+
+	logger.Debug("find report subscription successfully")
+	return subscriptionGorm.ToDomain(), nil
+}
+
+func (r *gormReportSubscriptionRepository) List(ctx context.Context, page, pageSize int) ([]*report.Subscription, int64, error) {
+	logger := r.logger.With(applog.String("Method", "List"), applog.Int("page", page), applog.Int("page_size", pageSize))
+
+	query := r.db.WithContext(ctx).Model(&models.ReportSubscriptionGorm{})
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		logger.Error("database count report subscriptions error", applog.Error(err))
+		return nil, 0, fmt.Errorf("database count report subscriptions error: %w", err)
+	}
+	if totalCount == 0 {
+		logger.Info("no report subscriptions found")
+		return []*report.Subscription{}, 0, nil
+	}
+
+	var subscriptionGorms []*models.ReportSubscriptionGorm
+	offset := (page - 1) * pageSize
+	if err := query.Order("id DESC").Offset(offset).Limit(pageSize).Find(&subscriptionGorms).Error; err != nil {
+		logger.Error("database list report subscriptions error", applog.Error(err))
+		return nil, 0, fmt.Errorf("database list report subscriptions error: %w", err)
+	}
+
+	logger.Info("list report subscriptions successfully", applog.Int("count", len(subscriptionGorms)), applog.Int64("total_count", totalCount))
+	subscriptions := make([]*report.Subscription, len(subscriptionGorms))
+	for i, subscriptionGorm := range subscriptionGorms {
+		subscriptions[i] = subscriptionGorm.ToDomain()
+	}
+	return subscriptions, totalCount, nil
+}
+
+func (r *gormReportSubscriptionRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*report.Subscription, error) {
+	logger := r.logger.With(applog.String("Method", "FindDue"), applog.Int("limit", limit))
+
+	var subscriptionGorms []*models.ReportSubscriptionGorm
+	if err := r.db.WithContext(ctx).Where("enabled = ? AND next_run_at <= ?", true, now).
+		Order("next_run_at ASC, id ASC").Limit(limit).Find(&subscriptionGorms).Error; err != nil {
+		logger.Error("database find due report subscriptions error", applog.Error(err))
+		return nil, fmt.Errorf("database find due report subscriptions error: %w", err)
+	}
+
+	logger.Debug("find due report subscriptions successfully", applog.Int("count", len(subscriptionGorms)))
+	subscriptions := make([]*report.Subscription, len(subscriptionGorms))
+	for i, subscriptionGorm := range subscriptionGorms {
+		subscriptions[i] = subscriptionGorm.ToDomain()
+	}
+	return subscriptions, nil
+}
+
+func (r *gormReportSubscriptionRepository) ClaimDue(ctx context.Context, id vo.ReportSubscriptionID, expected time.Time, next *time.Time) (bool, error) {
+	logger := r.logger.With(applog.String("Method", "ClaimDue"), applog.Uint("subscription_id", uint(id)), applog.Time("expected", expected))
+
+	result := r.db.WithContext(ctx).Model(&models.ReportSubscriptionGorm{}).
+		Where("id = ? AND enabled = ? AND next_run_at = ?", id, true, expected).
+		Update("next_run_at", next)
+	if result.Error != nil {
+		logger.Error("database claim report subscription error", applog.Error(result.Error))
+		return false, fmt.Errorf("database claim report subscription error: %w", result.Error)
+	}
+
+	logger.Debug("claim report subscription", applog.Bool("claimed", result.RowsAffected > 0))
+	return result.RowsAffected > 0, nil
+}
+
+type gormReportRunRepository struct {
+	db     *gorm.DB
+	logger applog.Logger
+}
+
+func NewGormReportRunRepository(db *gorm.DB, logger applog.Logger) report.RunRepository {
+	return &gormReportRunRepository{
+		db:     db,
+		logger: logger.With(applog.String("Repository", "gormReportRunRepository")),
+	}
+}
+
+func (r *gormReportRunRepository) Create(ctx context.Context, run *report.Run) (*report.Run, error) {
+	logger := r.logger.With(applog.String("Method", "Create"),
+		applog.Uint("subscription_id", uint(run.SubscriptionID)), applog.Int("attempt", run.Attempt))
+
+	runGorm := models.ReportRunGormFromDomain(run)
+	if err := r.db.WithContext(ctx).Omit("Subscription").Create(runGorm).Error; err != nil {
+		logger.Error("database create report run error", applog.Error(err))
+		return nil, fmt.Errorf("database create report run error: %w", err)
+	}
+
+	logger.Debug("create report run successfully", applog.Uint("run_id", runGorm.ID))
+	return runGorm.ToDomain(), nil
+}
+
+func (r *gormReportRunRepository) Update(ctx context.Context, run *report.Run) error {
+	logger := r.logger.With(applog.String("Method", "Update"), applog.Uint("run_id", uint(run.ID)))
+
+	runGorm := models.ReportRunGormFromDomain(run)
+	if err := r.db.WithContext(ctx).Model(&models.ReportRunGorm{}).Where("id = ?", runGorm.ID).
+		Select("Status", "FileName", "FileSize", "Error", "RetryAt", "FinishedAt").Updates(runGorm).Error; err != nil {
+		logger.Error("database update report run error", applog.Error(err))
+		return fmt.Errorf("database update report run error: %w", err)
+	}
+
+	logger.Debug("update report run successfully", applog.String("status", string(run.Status)))
+	return nil
+}
+
+func (r *gormReportRunRepository) ListBySubscription(ctx context.Context, id vo.ReportSubscriptionID, page, pageSize int) ([]*report.Run, int64, error) {
+	logger := r.logger.With(applog.String("Method", "ListBySubscription"), applog.Uint("subscription_id", uint(id)))
+
+	query := r.db.WithContext(ctx).Model(&models.ReportRunGorm{}).Where("subscription_id = ?", id)
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		logger.Error("database count report runs error", applog.Error(err))
+		return nil, 0, fmt.Errorf("database count report runs error: %w", err)
+	}
+	if totalCount == 0 {
+		logger.Info("no report runs found")
+		return []*report.Run{}, 0, nil
+	}
+
+	var runGorms []*models.ReportRunGorm
+	offset := (page - 1) * pageSize
+	if err := query.Order("started_at DESC, id DESC").Offset(offset).Limit(pageSize).Find(&runGorms).Error; err != nil {
+		logger.Error("database list report runs error", applog.Error(err))
+		return nil, 0, fmt.Errorf("database list report runs error: %w", err)
+	}
+
+	logger.Info("list report runs successfully", applog.Int("count", len(runGorms)), applog.Int64("total_count", totalCount))
+	runs := make([]*report.Run, len(runGorms))
+	for i, runGorm := range runGorms {
+		runs[i] = runGorm.ToDomain()
+	}
+	return runs, totalCount, nil
+}
+
+func (r *gormReportRunRepository) FindDueRetries(ctx context.Context, now time.Time, limit int) ([]*report.Run, error) {
+	logger := r.logger.With(applog.String("Method", "FindDueRetries"), applog.Int("limit", limit))
+
+	var runGorms []*models.ReportRunGorm
+	if err := r.db.WithContext(ctx).Where("status = ? AND retry_at <= ?", report.RunStatusFailed, now).
+		Order("retry_at ASC, id ASC").Limit(limit).Find(&runGorms).Error; err != nil {
+		logger.Error("database find due report retries error", applog.Error(err))
+		return nil, fmt.Errorf("database find due report retries error: %w", err)
+	}
+
+	logger.Debug("find due report retries successfully", applog.Int("count", len(runGorms)))
+	runs := make([]*report.Run, len(runGorms))
+	for i, runGorm := range runGorms {
+		runs[i] = runGorm.ToDomain()
+	}
+	return runs, nil
+}
+
+func (r *gormReportRunRepository) ClaimRetry(ctx context.Context, id vo.ReportRunID, expected time.Time) (bool, error) {
+	logger := r.logger.With(applog.String("Method", "ClaimRetry"), applog.Uint("run_id", uint(id)), applog.Time("expected", expected))
+
+	result := r.db.WithContext(ctx).Model(&models.ReportRunGorm{}).
+		Where("id = ? AND retry_at = ?", id, expected).
+		Update("retry_at", nil)
+	if result.Error != nil {
+		logger.Error("database claim report retry error", applog.Error(result.Error))
+		return false, fmt.Errorf("database claim report retry error: %w", result.Error)
+	}
+
+	logger.Debug("claim report retry", applog.Bool("claimed", result.RowsAffected > 0))
+	return result.RowsAffected > 0, nil
+}
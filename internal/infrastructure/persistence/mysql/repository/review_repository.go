@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mrs/internal/domain/review"
+	"mrs/internal/domain/shared/vo"
+	"mrs/internal/infrastructure/persistence/mysql/models"
+	applog "mrs/pkg/log"
+
+	"gorm.io/gorm"
+)
+
+type gormReviewRepository struct {
+	db     *gorm.DB
+	logger applog.Logger
+}
+
+func NewGormReviewRepository(db *gorm.DB, logger applog.Logger) review.ReviewRepository {
+	return &gormReviewRepository{
+		db:     db,
+		logger: logger.With(applog.String("Repository", "gormReviewRepository")),
+	}
+}
+
+func (r *gormReviewRepository) Create(ctx context.Context, rv *review.Review) (*review.Review, error) {
+	logger := r.logger.With(applog.String("Method", "Create"),
+		applog.Uint("movie_id", uint(rv.MovieID)), applog.Uint("user_id", uint(rv.UserID)))
+
+	reviewGorm := models.ReviewGormFromDomain(rv)
+	if err := r.db.WithContext(ctx).Create(reviewGorm).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			logger.Warn("review already exists", applog.Error(err))
+			return nil, fmt.Errorf("%w: %w", review.ErrReviewAlreadyExists, err)
+		}
+		logger.Error("database create review error", applog.Error(err))
+		return nil, fmt.Errorf("database create review error: %w", err)
+	}
+
+	logger.Info("create review successfully", applog.Uint("review_id", reviewGorm.ID))
+	return reviewGorm.ToDomain(), nil
+}
+
+func (r *gormReviewRepository) FindByID(ctx context.Context, id vo.ReviewID) (*review.Review, error) {
+	logger := r.logger.With(applog.String("Method", "FindByID"), applog.Uint("review_id", uint(id)))
+	var reviewGorm models.ReviewGorm
+	if err := r.db.WithContext(ctx).First(&reviewGorm, uint(id)).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("review id not found", applog.Error(err))
+			return nil, fmt.Errorf("%w(id): %w", review.ErrReviewNotFound, err)
+		}
+		logger.Error("database find review by id error", applog.Error(err))
+		return nil, fmt.Errorf("database find review by id error: %w", err)
+	}
+
+	logger.Info("find review by id successfully")
+	return reviewGorm.ToDomain(), nil
+}
+
+func (r *gormReviewRepository) List(ctx context.Context, options *review.ReviewQueryOptions) ([]*review.Review, int64, error) {
+	logger := r.logger.With(applog.String("Method", "List"), applog.Any("options", options))
+
+	query := r.db.WithContext(ctx).Model(&models.ReviewGorm{})
+	if options.MovieID != 0 {
+		query = query.Where("movie_id = ?", options.MovieID)
+	}
+	if options.UserID != 0 {
+		query = query.Where("user_id = ?", options.UserID)
+	}
+	if options.Status != "" {
+		query = query.Where("status = ?", options.Status)
+	}
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		logger.Error("database count reviews error", applog.Error(err))
+		return nil, 0, fmt.Errorf("database count reviews error: %w", err)
+	}
+	if totalCount == 0 {
+		logger.Info("no reviews found matching criteria")
+		return []*review.Review{}, 0, nil
+	}
+
+	order := "created_at DESC, id DESC"
+	if options.SortBy == review.ReviewSortHelpful {
+		order = "helpful_count DESC, " + order
+	}
+	var reviewGorms []*models.ReviewGorm
+	offset := (options.Page - 1) * options.PageSize
+	if err := query.Order(order).Offset(offset).Limit(options.PageSize).Find(&reviewGorms).Error; err != nil {
+		logger.Error("database list reviews error", applog.Error(err))
+		return nil, 0, fmt.Errorf("database list reviews error: %w", err)
+	}
+
+	logger.Info("list reviews successfully", applog.Int("count", len(reviewGorms)), applog.Int64("total_count", totalCount))
+	reviews := make([]*review.Review, len(reviewGorms))
+	for i, reviewGorm := range reviewGorms {
+		reviews[i] = reviewGorm.ToDomain()
+	}
+	return reviews, totalCount, nil
+}
+
+func (r *gormReviewRepository) Update(ctx context.Context, rv *review.Review) error {
+	logger := r.logger.With(applog.String("Method", "Update"), applog.Uint("review_id", uint(rv.ID)))
+
+	// 先执行一个轻量级查询（MySQL在值未变化时RowsAffected为0，不能据此判断是否存在）
+	var exist int64
+	if err := r.db.WithContext(ctx).Model(&models.ReviewGorm{}).Where("id = ?", rv.ID).Count(&exist).Error; err != nil {
+		logger.Error("database check review exist error", applog.Error(err))
+		return fmt.Errorf("database check review exist error: %w", err)
+	}
+	if exist == 0 {
+		logger.Warn("review not found")
+		return fmt.Errorf("%w(id): %v", review.ErrReviewNotFound, rv.ID)
+	}
+
+	// 审核备注可能被清空，显式指定更新的列
+	reviewGorm := models.ReviewGormFromDomain(rv)
+	if err := r.db.WithContext(ctx).Model(&models.ReviewGorm{}).Where("id = ?", rv.ID).
+		Select("Score", "Content", "Status", "ModerationNote").Updates(reviewGorm).Error; err != nil {
+		logger.Error("database update review error", applog.Error(err))
+		return fmt.Errorf("database update review error: %w", err)
+	}
+
+	logger.Info("update review successfully")
+	return nil
+}
+
+// 物理删除，用户删除评价后可以重新评价（唯一索引不受软删除记录影响），投票随之级联删除
+func (r *gormReviewRepository) Delete(ctx context.Context, id vo.ReviewID) error {
+	logger := r.logger.With(applog.String("Method", "Delete"), applog.Uint("review_id", uint(id)))
+
+	result := r.db.WithContext(ctx).Unscoped().Delete(&models.ReviewGorm{}, uint(id))
+	if result.Error != nil {
+		logger.Error("database delete review error", applog.Error(result.Error))
+		return fmt.Errorf("database delete review error: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		logger.Warn("review not found")
+		return fmt.Errorf("%w(id): %v", review.ErrReviewNotFound, id)
+	}
+
+	logger.Info("delete review successfully")
+	return nil
+}
+
+func (r *gormReviewRepository) AddHelpfulVote(ctx context.Context, id vo.ReviewID, userID vo.UserID) error {
+	logger := r.logger.With(applog.String("Method", "AddHelpfulVote"),
+		applog.Uint("review_id", uint(id)), applog.Uint("user_id", uint(userID)))
+
+	vote := &models.ReviewVoteGorm{ReviewID: uint(id), UserID: uint(userID)}
+	if err := r.db.WithContext(ctx).Create(vote).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			logger.Warn("review already voted", applog.Error(err))
+			return fmt.Errorf("%w: %w", review.ErrReviewAlreadyVoted, err)
+		}
+		logger.Error("database create review vote error", applog.Error(err))
+		return fmt.Errorf("database create review vote error: %w", err)
+	}
+
+	if err := r.db.WithContext(ctx).Model(&models.ReviewGorm{}).Where("id = ?", id).
+		Update("helpful_count", gorm.Expr("helpful_count + 1")).Error; err != nil {
+		logger.Error("database increase helpful count error", applog.Error(err))
+		return fmt.Errorf("database increase helpful count error: %w", err)
+	}
+
+	logger.Info("add helpful vote successfully")
+	return nil
+}
+
+func (r *gormReviewRepository) RemoveHelpfulVote(ctx context.Context, id vo.ReviewID, userID vo.UserID) error {
+	logger := r.logger.With(applog.String("Method", "RemoveHelpfulVote"),
+		applog.Uint("review_id", uint(id)), applog.Uint("user_id", uint(userID)))
+
+	result := r.db.WithContext(ctx).Where("review_id = ? AND user_id = ?", id, userID).Delete(&models.ReviewVoteGorm{})
+	if result.Error != nil {
+		logger.Error("database delete review vote error", applog.Error(result.Error))
+		return fmt.Errorf("database delete review vote error: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		logger.Warn("review vote not found")
+		return fmt.Errorf("%w(review_id): %v", review.ErrReviewVoteNotFound, id)
+	}
+
+	if err := r.db.WithContext(ctx).Model(&models.ReviewGorm{}).Where("id = ? AND helpful_count > 0", id).
+		Update("helpful_count", gorm.Expr("helpful_count - 1")).Error; err != nil {
+		logger.Error("database decrease helpful count error", applog.Error(err))
+		return fmt.Errorf("database decrease helpful count error: %w", err)
+	}
+
+	logger.Info("remove helpful vote successfully")
+	return nil
+}
@@ -82,6 +82,27 @@ func (r *gormSeatRepository) FindByHallID(ctx context.Context, hallID vo.CinemaH
 	return seats, nil
 }
 
+func (r *gormSeatRepository) FindByHallAndVersion(ctx context.Context, hallID vo.CinemaHallID, version int) ([]*cinema.Seat, error) {
+	logger := r.logger.With(applog.String("Method", "FindByHallAndVersion"),
+		applog.Uint("hall_id", uint(hallID)), applog.Int("version", version))
+	var seatsGorms []*models.SeatGorm
+
+	// 旧版本座位已软删除，需要 Unscoped 查询
+	if err := r.db.WithContext(ctx).Unscoped().
+		Where("cinema_hall_id = ? AND layout_version = ?", hallID, version).
+		Find(&seatsGorms).Error; err != nil {
+		logger.Error("database find seats by hall and version error", applog.Error(err))
+		return nil, fmt.Errorf("database find seats by hall and version error: %w", err)
+	}
+
+	logger.Info("find seats by hall and version successfully", applog.Int("count", len(seatsGorms)))
+	seats := make([]*cinema.Seat, len(seatsGorms))
+	for i, seatGorm := range seatsGorms {
+		seats[i] = seatGorm.ToDomain()
+	}
+	return seats, nil
+}
+
 func (r *gormSeatRepository) GetSeatsByIDs(ctx context.Context, seatIDs []vo.SeatID) ([]*cinema.Seat, error) {
 	logger := r.logger.With(applog.String("Method", "GetSeatsByIDs"), applog.Int("seat count", len(seatIDs)))
 	var seatsGorms []*models.SeatGorm
@@ -126,6 +147,12 @@ func (r *gormSeatRepository) Update(ctx context.Context, seat *cinema.Seat) erro
 		logger.Error("database update seat error", applog.Error(err))
 		return fmt.Errorf("database update seat error: %w", err)
 	}
+	// 网格坐标可能为0、分区可能为空，需要显式更新
+	if err := r.db.WithContext(ctx).Model(&models.SeatGorm{}).Where("id = ?", seatGorm.ID).
+		Select("GridRow", "GridCol", "Block").Updates(&seatGorm).Error; err != nil {
+		logger.Error("database update seat layout error", applog.Error(err))
+		return fmt.Errorf("database update seat layout error: %w", err)
+	}
 
 	// 无论是否真正造成更新，都返回成功
 	logger.Info("update seat successfully")
@@ -155,6 +182,7 @@ func (r *gormSeatRepository) Delete(ctx context.Context, id vo.SeatID) error {
 func (r *gormSeatRepository) DeleteByHallID(ctx context.Context, hallID vo.CinemaHallID) error {
 	logger := r.logger.With(applog.String("Method", "DeleteByHallID"), applog.Uint("hall_id", uint(hallID)))
 
+	// 软删除：被替换的座位保留其布局版本，已固定旧版本的场次与订单仍可引用
 	result := r.db.WithContext(ctx).Where("cinema_hall_id = ?", hallID).Delete(&models.SeatGorm{})
 	if err := result.Error; err != nil {
 		logger.Error("database delete seats error", applog.Error(err))
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mrs/internal/domain/cinema"
+	"mrs/internal/domain/shared/vo"
+	"mrs/internal/infrastructure/persistence/mysql/models"
+	applog "mrs/pkg/log"
+
+	"gorm.io/gorm"
+)
+
+type gormSeatRestrictionRepository struct {
+	db     *gorm.DB
+	logger applog.Logger
+}
+
+func NewGormSeatRestrictionRepository(db *gorm.DB, logger applog.Logger) cinema.SeatRestrictionRepository {
+	return &gormSeatRestrictionRepository{
+		db:     db,
+		logger: logger.With(applog.String("Repository", "gormSeatRestrictionRepository")),
+	}
+}
+
+func (r *gormSeatRestrictionRepository) CreateBatch(ctx context.Context, restrictions []*cinema.SeatRestriction) ([]*cinema.SeatRestriction, error) {
+	logger := r.logger.With(applog.String("Method", "CreateBatch"), applog.Int("restriction_count", len(restrictions)))
+
+	restrictionGorms := make([]*models.SeatRestrictionGorm, len(restrictions))
+	for i, restriction := range restrictions {
+		restrictionGorms[i] = models.SeatRestrictionGormFromDomain(restriction)
+	}
+	if err := r.db.WithContext(ctx).Create(&restrictionGorms).Error; err != nil {
+		logger.Error("database create seat restrictions error", applog.Error(err))
+		return nil, fmt.Errorf("database create seat restrictions error: %w", err)
+	}
+
+	logger.Info("create seat restrictions successfully")
+	created := make([]*cinema.SeatRestriction, len(restrictionGorms))
+	for i, restrictionGorm := range restrictionGorms {
+		created[i] = restrictionGorm.ToDomain()
+	}
+	return created, nil
+}
+
+func (r *gormSeatRestrictionRepository) FindByID(ctx context.Context, id vo.SeatRestrictionID) (*cinema.SeatRestriction, error) {
+	logger := r.logger.With(applog.String("Method", "FindByID"), applog.Uint("restriction_id", uint(id)))
+	var restrictionGorm models.SeatRestrictionGorm
+	if err := r.db.WithContext(ctx).First(&restrictionGorm, uint(id)).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("seat restriction id not found", applog.Error(err))
+			return nil, fmt.Errorf("%w(id): %w", cinema.ErrSeatRestrictionNotFound, err)
+		}
+		logger.Error("database find seat restriction by id error", applog.Error(err))
+		return nil, fmt.Errorf("database find seat restriction by id error: %w", err)
+	}
+
+	logger.Info("find seat restriction by id successfully")
+	return restrictionGorm.ToDomain(), nil
+}
+
+func (r *gormSeatRestrictionRepository) ListByHallID(ctx context.Context, hallID vo.CinemaHallID) ([]*cinema.SeatRestriction, error) {
+	logger := r.logger.With(applog.String("Method", "ListByHallID"), applog.Uint("hall_id", uint(hallID)))
+	var restrictionGorms []*models.SeatRestrictionGorm
+	if err := r.db.WithContext(ctx).Where("cinema_hall_id = ?", uint(hallID)).Order("id").Find(&restrictionGorms).Error; err != nil {
+		logger.Error("database list seat restrictions by hall id error", applog.Error(err))
+		return nil, fmt.Errorf("database list seat restrictions by hall id error: %w", err)
+	}
+
+	logger.Info("list seat restrictions by hall id successfully", applog.Int("restriction_count", len(restrictionGorms)))
+	restrictions := make([]*cinema.SeatRestriction, len(restrictionGorms))
+	for i, restrictionGorm := range restrictionGorms {
+		restrictions[i] = restrictionGorm.ToDomain()
+	}
+	return restrictions, nil
+}
+
+func (r *gormSeatRestrictionRepository) Delete(ctx context.Context, id vo.SeatRestrictionID) error {
+	logger := r.logger.With(applog.String("Method", "Delete"), applog.Uint("restriction_id", uint(id)))
+
+	result := r.db.WithContext(ctx).Delete(&models.SeatRestrictionGorm{}, uint(id))
+	if result.Error != nil {
+		logger.Error("database delete seat restriction error", applog.Error(result.Error))
+		return fmt.Errorf("database delete seat restriction error: %w", result.Error)
+	}
+
+	// 是否不存在或已删除
+	if result.RowsAffected == 0 {
+		logger.Warn("seat restriction not found")
+		return fmt.Errorf("%w(id): %v", cinema.ErrSeatRestrictionNotFound, id)
+	}
+
+	logger.Info("delete seat restriction successfully")
+	return nil
+}
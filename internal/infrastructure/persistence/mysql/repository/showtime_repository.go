@@ -103,6 +103,12 @@ func (r *gormShowtimeRepository) List(ctx context.Context, options *showtime.Sho
 		query = query.Where("movie_id = ?", options.MovieID)
 		countQuery = countQuery.Where("movie_id = ?", options.MovieID)
 	}
+	if options.CinemaID > 0 {
+		// 通过子查询筛选属于该影院的影厅，避免联表带来的列名歧义
+		hallSubQuery := r.db.Model(&models.CinemaHallGorm{}).Select("id").Where("cinema_id = ?", options.CinemaID)
+		query = query.Where("cinema_hall_id IN (?)", hallSubQuery)
+		countQuery = countQuery.Where("cinema_hall_id IN (?)", hallSubQuery)
+	}
 	if options.CinemaHallID > 0 {
 		query = query.Where("cinema_hall_id = ?", options.CinemaHallID)
 		countQuery = countQuery.Where("cinema_hall_id = ?", options.CinemaHallID)
@@ -111,6 +117,38 @@ func (r *gormShowtimeRepository) List(ctx context.Context, options *showtime.Sho
 		query = query.Where("start_time >= ? AND start_time < ?", options.Date, options.Date.AddDate(0, 0, 1))
 		countQuery = countQuery.Where("start_time >= ? AND start_time < ?", options.Date, options.Date.AddDate(0, 0, 1))
 	}
+	if options.Format != "" {
+		query = query.Where("format = ?", options.Format)
+		countQuery = countQuery.Where("format = ?", options.Format)
+	}
+	if options.AudioLanguage != "" {
+		query = query.Where("audio_language = ?", options.AudioLanguage)
+		countQuery = countQuery.Where("audio_language = ?", options.AudioLanguage)
+	}
+	if options.SubtitleLanguage != "" {
+		query = query.Where("subtitle_language = ?", options.SubtitleLanguage)
+		countQuery = countQuery.Where("subtitle_language = ?", options.SubtitleLanguage)
+	}
+	if options.AudioDescription {
+		query = query.Where("audio_description = ?", true)
+		countQuery = countQuery.Where("audio_description = ?", true)
+	}
+	if options.ClosedCaptions {
+		query = query.Where("closed_captions = ?", true)
+		countQuery = countQuery.Where("closed_captions = ?", true)
+	}
+	if options.SensoryFriendly {
+		query = query.Where("sensory_friendly = ?", true)
+		countQuery = countQuery.Where("sensory_friendly = ?", true)
+	}
+	// 默认不返回已取消的场次
+	if options.Status != "" {
+		query = query.Where("status = ?", options.Status)
+		countQuery = countQuery.Where("status = ?", options.Status)
+	} else if !options.IncludeCancelled {
+		query = query.Where("status <> ?", showtime.ShowtimeStatusCancelled)
+		countQuery = countQuery.Where("status <> ?", showtime.ShowtimeStatusCancelled)
+	}
 
 	// 获取总数
 	if err := countQuery.Count(&totalCount).Error; err != nil {
@@ -179,6 +217,153 @@ func (r *gormShowtimeRepository) Update(ctx context.Context, st *showtime.Showti
 	return nil
 }
 
+func (r *gormShowtimeRepository) UpdateLayoutVersion(ctx context.Context, id vo.ShowtimeID, version int) error {
+	logger := r.logger.With(
+		applog.String("Method", "UpdateLayoutVersion"),
+		applog.Uint("showtime_id", uint(id)),
+		applog.Int("version", version),
+	)
+
+	result := r.db.WithContext(ctx).Model(&models.ShowtimeGorm{}).Where("id = ?", uint(id)).
+		Update("layout_version", version)
+	if err := result.Error; err != nil {
+		logger.Error("database update showtime layout version error", applog.Error(err))
+		return fmt.Errorf("database update showtime layout version error: %w", err)
+	}
+	if result.RowsAffected == 0 {
+		logger.Warn("showtime not found or layout version unchanged")
+		return fmt.Errorf("%w(id): %v", showtime.ErrShowtimeNotFound, id)
+	}
+
+	logger.Info("update showtime layout version successfully")
+	return nil
+}
+
+// Updates 会忽略零值字段，无障碍标记需要显式选择才能被更新为 false
+// 值未变化时 RowsAffected 为 0，因此不据此判断场次是否存在（由调用方在同一事务中先查询场次）
+func (r *gormShowtimeRepository) UpdateAccessibility(ctx context.Context, id vo.ShowtimeID, accessibility showtime.Accessibility) error {
+	logger := r.logger.With(applog.String("Method", "UpdateAccessibility"), applog.Uint("showtime_id", uint(id)))
+
+	if err := r.db.WithContext(ctx).Model(&models.ShowtimeGorm{}).Where("id = ?", uint(id)).
+		Select("AudioDescription", "ClosedCaptions", "SensoryFriendly").
+		Updates(&models.ShowtimeGorm{
+			AudioDescription: accessibility.AudioDescription,
+			ClosedCaptions:   accessibility.ClosedCaptions,
+			SensoryFriendly:  accessibility.SensoryFriendly,
+		}).Error; err != nil {
+		logger.Error("database update showtime accessibility error", applog.Error(err))
+		return fmt.Errorf("database update showtime accessibility error: %w", err)
+	}
+
+	logger.Info("update showtime accessibility successfully")
+	return nil
+}
+
+// 字幕语言为空字符串时清除字幕（同样需要显式写入零值）
+func (r *gormShowtimeRepository) UpdateSubtitleLanguage(ctx context.Context, id vo.ShowtimeID, language string) error {
+	logger := r.logger.With(applog.String("Method", "UpdateSubtitleLanguage"),
+		applog.Uint("showtime_id", uint(id)), applog.String("subtitle_language", language))
+
+	if err := r.db.WithContext(ctx).Model(&models.ShowtimeGorm{}).Where("id = ?", uint(id)).
+		Update("subtitle_language", language).Error; err != nil {
+		logger.Error("database update showtime subtitle language error", applog.Error(err))
+		return fmt.Errorf("database update showtime subtitle language error: %w", err)
+	}
+
+	logger.Info("update showtime subtitle language successfully")
+	return nil
+}
+
+// 在给定电影中筛选出仍有未结束（且未取消）场次的电影ID
+func (r *gormShowtimeRepository) FindMovieIDsWithUpcomingShowtimes(ctx context.Context, movieIDs []vo.MovieID, now time.Time) ([]vo.MovieID, error) {
+	logger := r.logger.With(
+		applog.String("Method", "FindMovieIDsWithUpcomingShowtimes"),
+		applog.Int("movie_count", len(movieIDs)),
+		applog.Time("now", now),
+	)
+
+	if len(movieIDs) == 0 {
+		return []vo.MovieID{}, nil
+	}
+
+	var ids []uint
+	err := r.db.WithContext(ctx).Model(&models.ShowtimeGorm{}).
+		Where("movie_id IN ?", movieIDs).
+		Where("end_time > ?", now).
+		Where("status <> ?", string(showtime.ShowtimeStatusCancelled)).
+		Distinct().Pluck("movie_id", &ids).Error
+	if err != nil {
+		logger.Error("database find movies with upcoming showtimes error", applog.Error(err))
+		return nil, fmt.Errorf("database find movies with upcoming showtimes error: %w", err)
+	}
+
+	result := make([]vo.MovieID, len(ids))
+	for i, id := range ids {
+		result[i] = vo.MovieID(id)
+	}
+	logger.Info("find movies with upcoming showtimes successfully", applog.Int("count", len(result)))
+	return result, nil
+}
+
+func (r *gormShowtimeRepository) FindUpcomingByMovieIDs(ctx context.Context, movieIDs []vo.MovieID, now time.Time) ([]*showtime.Showtime, error) {
+	logger := r.logger.With(
+		applog.String("Method", "FindUpcomingByMovieIDs"),
+		applog.Int("movie_count", len(movieIDs)),
+		applog.Time("now", now),
+	)
+
+	if len(movieIDs) == 0 {
+		return []*showtime.Showtime{}, nil
+	}
+
+	var showtimesGorms []*models.ShowtimeGorm
+	err := r.db.WithContext(ctx).
+		Where("movie_id IN ?", movieIDs).
+		Where("start_time > ?", now).
+		Where("status = ?", string(showtime.ShowtimeStatusScheduled)).
+		Order("start_time ASC, id ASC").
+		Preload("CinemaHall").
+		Find(&showtimesGorms).Error
+	if err != nil {
+		logger.Error("database find upcoming showtimes by movies error", applog.Error(err))
+		return nil, fmt.Errorf("database find upcoming showtimes by movies error: %w", err)
+	}
+
+	logger.Info("find upcoming showtimes by movies successfully", applog.Int("count", len(showtimesGorms)))
+	showtimes := make([]*showtime.Showtime, len(showtimesGorms))
+	for i, showtimeGorm := range showtimesGorms {
+		showtimes[i] = showtimeGorm.ToDomain()
+	}
+	return showtimes, nil
+}
+
+func (r *gormShowtimeRepository) FindUpcomingByHallID(ctx context.Context, hallID vo.CinemaHallID, now time.Time) ([]*showtime.Showtime, error) {
+	logger := r.logger.With(
+		applog.String("Method", "FindUpcomingByHallID"),
+		applog.Uint("cinema_hall_id", uint(hallID)),
+		applog.Time("now", now),
+	)
+
+	var showtimesGorms []*models.ShowtimeGorm
+	err := r.db.WithContext(ctx).
+		Where("cinema_hall_id = ?", uint(hallID)).
+		Where("start_time > ?", now).
+		Where("status = ?", string(showtime.ShowtimeStatusScheduled)).
+		Order("start_time ASC, id ASC").
+		Find(&showtimesGorms).Error
+	if err != nil {
+		logger.Error("database find upcoming showtimes by hall error", applog.Error(err))
+		return nil, fmt.Errorf("database find upcoming showtimes by hall error: %w", err)
+	}
+
+	logger.Info("find upcoming showtimes by hall successfully", applog.Int("count", len(showtimesGorms)))
+	showtimes := make([]*showtime.Showtime, len(showtimesGorms))
+	for i, showtimeGorm := range showtimesGorms {
+		showtimes[i] = showtimeGorm.ToDomain()
+	}
+	return showtimes, nil
+}
+
 func (r *gormShowtimeRepository) Delete(ctx context.Context, id vo.ShowtimeID) error {
 	logger := r.logger.With(
 		applog.String("Method", "Delete"),
@@ -225,7 +410,9 @@ func (r *gormShowtimeRepository) CheckOverlap(ctx context.Context, hallID vo.Cin
 		// 核心重叠逻辑:
 		// 新场次的开始时间在新场次结束之前 AND 新场次的结束时间在现有场次开始之后
 		Where("start_time < ?", endTime). // Existing showtime starts before new one ends
-		Where("end_time > ?", startTime)  // Existing showtime ends after new one starts
+		Where("end_time > ?", startTime). // Existing showtime ends after new one starts
+		// 已取消的场次不再占用影厅
+		Where("status <> ?", showtime.ShowtimeStatusCancelled)
 
 	if len(uintExcludeShowtimeID) > 0 && uintExcludeShowtimeID[0] > 0 {
 		query = query.Where("id != ?", uintExcludeShowtimeID[0])
@@ -294,7 +481,7 @@ func (r *gormShowtimeRepository) FindShowtimesByHallAndDateRanges(ctx context.Co
 
 	err := r.db.WithContext(ctx).
 		Where("cinema_hall_id = ?", uint(hallID)).
-		Where("start_time BETWEEN ? AND ?").
+		Where("start_time BETWEEN ? AND ?", startDate, endDate).
 		Order("start_time ASC").
 		Preload("Movie").
 		Find(&showtimesGorms).Error
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"mrs/internal/domain/movie"
+	"mrs/internal/domain/shared/vo"
+	"mrs/internal/infrastructure/persistence/mysql/models"
+	applog "mrs/pkg/log"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormTranslationRepository struct {
+	db     *gorm.DB
+	logger applog.Logger
+}
+
+func NewGormTranslationRepository(db *gorm.DB, logger applog.Logger) movie.TranslationRepository {
+	return &gormTranslationRepository{
+		db:     db,
+		logger: logger.With(applog.String("Repository", "gormTranslationRepository")),
+	}
+}
+
+// 以 (movie_id, locale) 唯一索引实现新增或覆盖
+func (r *gormTranslationRepository) UpsertMovieTranslation(ctx context.Context, movieID vo.MovieID, translation *movie.MovieTranslation) error {
+	logger := r.logger.With(applog.String("Method", "UpsertMovieTranslation"),
+		applog.Uint("movie_id", uint(movieID)), applog.String("locale", string(translation.Locale)))
+
+	translationGorm := &models.MovieTranslationGorm{
+		MovieID:     uint(movieID),
+		Locale:      string(translation.Locale),
+		Title:       translation.Title,
+		Description: translation.Description,
+	}
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"title", "description", "updated_at"}),
+	}).Create(translationGorm).Error; err != nil {
+		logger.Error("database upsert movie translation error", applog.Error(err))
+		return fmt.Errorf("database upsert movie translation error: %w", err)
+	}
+
+	logger.Info("upsert movie translation successfully")
+	return nil
+}
+
+func (r *gormTranslationRepository) DeleteMovieTranslation(ctx context.Context, movieID vo.MovieID, locale vo.Locale) error {
+	logger := r.logger.With(applog.String("Method", "DeleteMovieTranslation"),
+		applog.Uint("movie_id", uint(movieID)), applog.String("locale", string(locale)))
+
+	result := r.db.WithContext(ctx).Where("movie_id = ? AND locale = ?", movieID, locale).Delete(&models.MovieTranslationGorm{})
+	if result.Error != nil {
+		logger.Error("database delete movie translation error", applog.Error(result.Error))
+		return fmt.Errorf("database delete movie translation error: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		logger.Warn("movie translation not found")
+		return fmt.Errorf("%w(locale): %v", movie.ErrTranslationNotFound, locale)
+	}
+
+	logger.Info("delete movie translation successfully")
+	return nil
+}
+
+// 以 (genre_id, locale) 唯一索引实现新增或覆盖
+func (r *gormTranslationRepository) UpsertGenreTranslation(ctx context.Context, genreID vo.GenreID, translation *movie.GenreTranslation) error {
+	logger := r.logger.With(applog.String("Method", "UpsertGenreTranslation"),
+		applog.Uint("genre_id", uint(genreID)), applog.String("locale", string(translation.Locale)))
+
+	translationGorm := &models.GenreTranslationGorm{
+		GenreID: uint(genreID),
+		Locale:  string(translation.Locale),
+		Name:    translation.Name,
+	}
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"name", "updated_at"}),
+	}).Create(translationGorm).Error; err != nil {
+		logger.Error("database upsert genre translation error", applog.Error(err))
+		return fmt.Errorf("database upsert genre translation error: %w", err)
+	}
+
+	logger.Info("upsert genre translation successfully")
+	return nil
+}
+
+func (r *gormTranslationRepository) DeleteGenreTranslation(ctx context.Context, genreID vo.GenreID, locale vo.Locale) error {
+	logger := r.logger.With(applog.String("Method", "DeleteGenreTranslation"),
+		applog.Uint("genre_id", uint(genreID)), applog.String("locale", string(locale)))
+
+	result := r.db.WithContext(ctx).Where("genre_id = ? AND locale = ?", genreID, locale).Delete(&models.GenreTranslationGorm{})
+	if result.Error != nil {
+		logger.Error("database delete genre translation error", applog.Error(result.Error))
+		return fmt.Errorf("database delete genre translation error: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		logger.Warn("genre translation not found")
+		return fmt.Errorf("%w(locale): %v", movie.ErrTranslationNotFound, locale)
+	}
+
+	logger.Info("delete genre translation successfully")
+	return nil
+}
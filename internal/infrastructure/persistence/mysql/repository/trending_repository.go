@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"mrs/internal/domain/booking"
+	"mrs/internal/domain/shared/vo"
+	"mrs/internal/domain/trending"
+	applog "mrs/pkg/log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type gormTrendingRepository struct {
+	db     *gorm.DB
+	logger applog.Logger
+}
+
+func NewGormTrendingRepository(db *gorm.DB, logger applog.Logger) trending.TrendingRepository {
+	return &gormTrendingRepository{
+		db:     db,
+		logger: logger.With(applog.String("Repository", "gormTrendingRepository")),
+	}
+}
+
+// 订单售出时间：确认时间，早期没有记录确认时间的订单退回到下单时间（与 booking.Booking.SoldAt 一致）
+const trendingSoldAtExpr = "COALESCE(bookings.confirmed_at, bookings.booking_time)"
+
+// 售出时间截断到分桶开始时间，格式为 salesBucketLayout
+func trendingBucketExpr(granularity trending.Granularity) string {
+	if granularity == trending.GranularityDay {
+		return "DATE_FORMAT(" + trendingSoldAtExpr + ", '%Y-%m-%d 00:00')"
+	}
+	return salesBucketExpr(trendingSoldAtExpr, trending.BucketMinutes)
+}
+
+// 票数与订单确认时计入的座位数一致（已确认订单的座位不会被删除）
+func (r *gormTrendingRepository) CountTickets(ctx context.Context, granularity trending.Granularity, since time.Time) ([]*trending.TicketCount, error) {
+	logger := r.logger.With(applog.String("Method", "CountTickets"),
+		applog.String("granularity", string(granularity)), applog.Time("since", since))
+
+	var rows []struct {
+		Bucket     string
+		ShowtimeID uint
+		MovieID    uint
+		Tickets    int
+	}
+	if err := r.db.WithContext(ctx).Table("bookings").
+		Joins("JOIN showtimes ON showtimes.id = bookings.showtime_id AND showtimes.deleted_at IS NULL").
+		Joins("JOIN booked_seats ON booked_seats.booking_id = bookings.id").
+		Where("bookings.deleted_at IS NULL").
+		Where("bookings.status = ?", string(booking.BookingStatusConfirmed)).
+		Where(trendingSoldAtExpr+" >= ?", since).
+		Select(trendingBucketExpr(granularity) + " AS bucket, bookings.showtime_id AS showtime_id, " +
+			"showtimes.movie_id AS movie_id, COUNT(*) AS tickets").
+		Group("bucket, bookings.showtime_id, showtimes.movie_id").
+		Scan(&rows).Error; err != nil {
+		logger.Error("database count trending tickets error", applog.Error(err))
+		return nil, fmt.Errorf("database count trending tickets error: %w", err)
+	}
+
+	counts := make([]*trending.TicketCount, len(rows))
+	for i, row := range rows {
+		start, err := time.ParseInLocation(salesBucketLayout, row.Bucket, time.Local)
+		if err != nil {
+			logger.Error("failed to parse trending bucket", applog.String("bucket", row.Bucket), applog.Error(err))
+			return nil, fmt.Errorf("failed to parse trending bucket(%s): %w", row.Bucket, err)
+		}
+		counts[i] = &trending.TicketCount{
+			Bucket:     trending.Bucket{Granularity: granularity, Start: start},
+			ShowtimeID: vo.ShowtimeID(row.ShowtimeID),
+			MovieID:    vo.MovieID(row.MovieID),
+			Tickets:    row.Tickets,
+		}
+	}
+
+	logger.Info("count trending tickets successfully", applog.Int("count", len(counts)))
+	return counts, nil
+}
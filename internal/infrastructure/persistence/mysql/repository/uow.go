@@ -2,12 +2,16 @@ package repository
 
 import (
 	"context"
+	"mrs/internal/domain/analytics"
 	"mrs/internal/domain/booking"
 	"mrs/internal/domain/cinema"
+	"mrs/internal/domain/loyalty"
 	"mrs/internal/domain/movie"
+	"mrs/internal/domain/review"
 	"mrs/internal/domain/shared"
 	"mrs/internal/domain/showtime"
 	"mrs/internal/domain/user"
+	"mrs/internal/domain/watchlist"
 	applog "mrs/pkg/log"
 
 	"gorm.io/gorm"
@@ -38,10 +42,26 @@ func (p *gormRepositoryProvider) GetGenreRepository() movie.GenreRepository {
 	return NewGormGenreRepository(p.tx, p.logger)
 }
 
+func (p *gormRepositoryProvider) GetPersonRepository() movie.PersonRepository {
+	return NewGormPersonRepository(p.tx, p.logger)
+}
+
+func (p *gormRepositoryProvider) GetMediaAssetRepository() movie.MediaAssetRepository {
+	return NewGormMediaAssetRepository(p.tx, p.logger)
+}
+
+func (p *gormRepositoryProvider) GetTranslationRepository() movie.TranslationRepository {
+	return NewGormTranslationRepository(p.tx, p.logger)
+}
+
 func (p *gormRepositoryProvider) GetShowtimeRepository() showtime.ShowtimeRepository {
 	return NewGormShowtimeRepository(p.tx, p.logger)
 }
 
+func (p *gormRepositoryProvider) GetCinemaRepository() cinema.CinemaRepository {
+	return NewGormCinemaRepository(p.tx, p.logger)
+}
+
 func (p *gormRepositoryProvider) GetCinemaHallRepository() cinema.CinemaHallRepository {
 	return NewGormCinemaHallRepository(p.tx, p.logger)
 }
@@ -50,6 +70,14 @@ func (p *gormRepositoryProvider) GetSeatRepository() cinema.SeatRepository {
 	return NewGormSeatRepository(p.tx, p.logger)
 }
 
+func (p *gormRepositoryProvider) GetSeatRestrictionRepository() cinema.SeatRestrictionRepository {
+	return NewGormSeatRestrictionRepository(p.tx, p.logger)
+}
+
+func (p *gormRepositoryProvider) GetHallLayoutVersionRepository() cinema.HallLayoutVersionRepository {
+	return NewGormHallLayoutVersionRepository(p.tx, p.logger)
+}
+
 func (p *gormRepositoryProvider) GetBookingRepository() booking.BookingRepository {
 	return NewGormBookingRepository(p.tx, p.logger)
 }
@@ -58,6 +86,26 @@ func (p *gormRepositoryProvider) GetBookedSeatRepository() booking.BookedSeatRep
 	return NewGormBookedSeatRepository(p.tx, p.logger)
 }
 
+func (p *gormRepositoryProvider) GetDailySalesRepository() booking.DailySalesRepository {
+	return NewGormDailySalesRepository(p.tx, p.logger)
+}
+
+func (p *gormRepositoryProvider) GetFunnelEventRepository() analytics.FunnelEventRepository {
+	return NewGormFunnelEventRepository(p.tx, p.logger)
+}
+
+func (p *gormRepositoryProvider) GetLoyaltyLedgerRepository() loyalty.LedgerRepository {
+	return NewGormLoyaltyLedgerRepository(p.tx, p.logger)
+}
+
+func (p *gormRepositoryProvider) GetReviewRepository() review.ReviewRepository {
+	return NewGormReviewRepository(p.tx, p.logger)
+}
+
+func (p *gormRepositoryProvider) GetWatchlistRepository() watchlist.WatchlistRepository {
+	return NewGormWatchlistRepository(p.tx, p.logger)
+}
+
 // gormUnitOfWork 实现了 shared.UnitOfWork 接口。
 type gormUnitOfWork struct {
 	tx     *gorm.DB // 全局的gorm.DB实例，用于开启事务
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mrs/internal/domain/shared/vo"
+	"mrs/internal/domain/watchlist"
+	"mrs/internal/infrastructure/persistence/mysql/models"
+	applog "mrs/pkg/log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type gormWatchlistRepository struct {
+	db     *gorm.DB
+	logger applog.Logger
+}
+
+func NewGormWatchlistRepository(db *gorm.DB, logger applog.Logger) watchlist.WatchlistRepository {
+	return &gormWatchlistRepository{
+		db:     db,
+		logger: logger.With(applog.String("Repository", "gormWatchlistRepository")),
+	}
+}
+
+func (r *gormWatchlistRepository) Create(ctx context.Context, entry *watchlist.WatchlistEntry) (*watchlist.WatchlistEntry, error) {
+	logger := r.logger.With(applog.String("Method", "Create"),
+		applog.Uint("user_id", uint(entry.UserID)), applog.Uint("movie_id", uint(entry.MovieID)))
+
+	entryGorm := models.WatchlistEntryGormFromDomain(entry)
+	if err := r.db.WithContext(ctx).Create(entryGorm).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			logger.Warn("watchlist entry already exists", applog.Error(err))
+			return nil, fmt.Errorf("%w: %w", watchlist.ErrWatchlistEntryAlreadyExists, err)
+		}
+		logger.Error("database create watchlist entry error", applog.Error(err))
+		return nil, fmt.Errorf("database create watchlist entry error: %w", err)
+	}
+
+	logger.Info("create watchlist entry successfully", applog.Uint("watchlist_entry_id", entryGorm.ID))
+	return entryGorm.ToDomain(), nil
+}
+
+func (r *gormWatchlistRepository) Delete(ctx context.Context, userID vo.UserID, movieID vo.MovieID) error {
+	logger := r.logger.With(applog.String("Method", "Delete"),
+		applog.Uint("user_id", uint(userID)), applog.Uint("movie_id", uint(movieID)))
+
+	result := r.db.WithContext(ctx).Where("user_id = ? AND movie_id = ?", userID, movieID).Delete(&models.WatchlistEntryGorm{})
+	if result.Error != nil {
+		logger.Error("database delete watchlist entry error", applog.Error(result.Error))
+		return fmt.Errorf("database delete watchlist entry error: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		logger.Warn("watchlist entry not found")
+		return fmt.Errorf("%w(movie_id): %v", watchlist.ErrWatchlistEntryNotFound, movieID)
+	}
+
+	logger.Info("delete watchlist entry successfully")
+	return nil
+}
+
+func (r *gormWatchlistRepository) ListByUser(ctx context.Context, userID vo.UserID, page, pageSize int) ([]*watchlist.WatchlistEntry, int64, error) {
+	logger := r.logger.With(applog.String("Method", "ListByUser"), applog.Uint("user_id", uint(userID)))
+
+	query := r.db.WithContext(ctx).Model(&models.WatchlistEntryGorm{}).Where("user_id = ?", userID)
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		logger.Error("database count watchlist entries error", applog.Error(err))
+		return nil, 0, fmt.Errorf("database count watchlist entries error: %w", err)
+	}
+	if totalCount == 0 {
+		logger.Info("no watchlist entries found")
+		return []*watchlist.WatchlistEntry{}, 0, nil
+	}
+
+	var entryGorms []*models.WatchlistEntryGorm
+	offset := (page - 1) * pageSize
+	if err := query.Preload("Movie").Preload("Movie.Genres").
+		Order("created_at DESC, id DESC").Offset(offset).Limit(pageSize).Find(&entryGorms).Error; err != nil {
+		logger.Error("database list watchlist entries error", applog.Error(err))
+		return nil, 0, fmt.Errorf("database list watchlist entries error: %w", err)
+	}
+
+	logger.Info("list watchlist entries successfully", applog.Int("count", len(entryGorms)), applog.Int64("total_count", totalCount))
+	entries := make([]*watchlist.WatchlistEntry, len(entryGorms))
+	for i, entryGorm := range entryGorms {
+		entries[i] = entryGorm.ToDomain()
+	}
+	return entries, totalCount, nil
+}
+
+func (r *gormWatchlistRepository) FindPending(ctx context.Context, afterID vo.WatchlistEntryID, limit int) ([]*watchlist.WatchlistEntry, error) {
+	logger := r.logger.With(applog.String("Method", "FindPending"),
+		applog.Uint("after_id", uint(afterID)), applog.Int("limit", limit))
+
+	var entryGorms []*models.WatchlistEntryGorm
+	if err := r.db.WithContext(ctx).Where("notified_at IS NULL AND id > ?", afterID).
+		Order("id ASC").Limit(limit).Find(&entryGorms).Error; err != nil {
+		logger.Error("database find pending watchlist entries error", applog.Error(err))
+		return nil, fmt.Errorf("database find pending watchlist entries error: %w", err)
+	}
+
+	logger.Debug("find pending watchlist entries successfully", applog.Int("count", len(entryGorms)))
+	entries := make([]*watchlist.WatchlistEntry, len(entryGorms))
+	for i, entryGorm := range entryGorms {
+		entries[i] = entryGorm.ToDomain()
+	}
+	return entries, nil
+}
+
+func (r *gormWatchlistRepository) MarkNotified(ctx context.Context, ids []vo.WatchlistEntryID, at time.Time) error {
+	logger := r.logger.With(applog.String("Method", "MarkNotified"), applog.Int("count", len(ids)))
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if err := r.db.WithContext(ctx).Model(&models.WatchlistEntryGorm{}).
+		Where("id IN ? AND notified_at IS NULL", ids).Update("notified_at", at).Error; err != nil {
+		logger.Error("database mark watchlist entries notified error", applog.Error(err))
+		return fmt.Errorf("database mark watchlist entries notified error: %w", err)
+	}
+
+	logger.Info("mark watchlist entries notified successfully")
+	return nil
+}
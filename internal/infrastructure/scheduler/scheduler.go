@@ -0,0 +1,92 @@
+package scheduler
+
+import (
+	"context"
+	applog "mrs/pkg/log"
+	"sync"
+	"time"
+)
+
+// 定时任务：按固定间隔执行，启动时立即执行一次
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler 在后台协程中运行已注册的定时任务
+// 同一任务的两次执行不会重叠：上一次执行结束后才开始计算下一次间隔
+type Scheduler struct {
+	jobs   []Job
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	logger applog.Logger
+}
+
+func NewScheduler(logger applog.Logger) *Scheduler {
+	return &Scheduler{
+		logger: logger.With(applog.String("Component", "Scheduler")),
+	}
+}
+
+// 注册任务，间隔不大于0的任务视为禁用
+func (s *Scheduler) Register(job Job) {
+	if job.Interval <= 0 {
+		s.logger.Info("job disabled", applog.String("job", job.Name))
+		return
+	}
+	s.jobs = append(s.jobs, job)
+}
+
+// 启动所有任务，ctx取消或调用Stop后任务退出
+func (s *Scheduler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, job)
+	}
+	s.logger.Info("scheduler started", applog.Int("jobs", len(s.jobs)))
+}
+
+// 停止所有任务并等待正在执行的任务结束
+func (s *Scheduler) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.wg.Wait()
+	s.logger.Info("scheduler stopped")
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	defer s.wg.Done()
+	logger := s.logger.With(applog.String("job", job.Name))
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			s.runOnce(ctx, logger, job)
+			timer.Reset(job.Interval)
+		}
+	}
+}
+
+// 执行一次任务，任务panic时记录日志而不影响其他任务
+func (s *Scheduler) runOnce(ctx context.Context, logger applog.Logger, job Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error("job panicked", applog.Any("panic", r))
+		}
+	}()
+
+	start := time.Now()
+	if err := job.Run(ctx); err != nil {
+		logger.Error("job failed", applog.Error(err), applog.Duration("elapsed", time.Since(start)))
+		return
+	}
+	logger.Debug("job finished", applog.Duration("elapsed", time.Since(start)))
+}
@@ -0,0 +1,169 @@
+package search
+
+import (
+	"context"
+	"math"
+	"mrs/internal/domain/movie"
+	"mrs/internal/domain/shared/vo"
+	applog "mrs/pkg/log"
+	"sort"
+	"sync"
+)
+
+// 各字段在相关度计算中的权重：标题命中最重要，描述最弱
+var fieldWeights = map[string]float64{
+	movie.SearchFieldTitle:       3,
+	movie.SearchFieldGenres:      2,
+	movie.SearchFieldCast:        1.5,
+	movie.SearchFieldDescription: 1,
+}
+
+// 词频饱和参数，避免长描述中重复出现的词过度拉高得分
+const termFrequencySaturation = 1.2
+
+// 某个词元在某部电影中各字段的出现次数
+type posting map[string]int
+
+// 进程内倒排索引，适用于测试与单实例部署，重启后需重新写入
+type memoryMovieIndex struct {
+	mu       sync.RWMutex
+	postings map[string]map[vo.MovieID]posting // 词元 -> 电影 -> 字段词频
+	docs     map[vo.MovieID][]string           // 电影 -> 已索引的词元，用于覆盖与删除
+	logger   applog.Logger
+}
+
+func NewMemoryMovieIndex(logger applog.Logger) movie.MovieSearchIndex {
+	return &memoryMovieIndex{
+		postings: make(map[string]map[vo.MovieID]posting),
+		docs:     make(map[vo.MovieID][]string),
+		logger:   logger.With(applog.String("SearchIndex", "memoryMovieIndex")),
+	}
+}
+
+func (i *memoryMovieIndex) Index(ctx context.Context, mv *movie.Movie) error {
+	logger := i.logger.With(applog.String("Method", "Index"), applog.Uint("movie_id", uint(mv.ID)))
+
+	fields := map[string]string{
+		movie.SearchFieldTitle:       mv.Title,
+		movie.SearchFieldDescription: mv.Description,
+		movie.SearchFieldCast:        mv.Cast,
+		movie.SearchFieldGenres:      mv.GenreText(),
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.removeLocked(mv.ID)
+	terms := make([]string, 0)
+	for field, text := range fields {
+		for _, token := range movie.Tokenize(text) {
+			docs, ok := i.postings[token]
+			if !ok {
+				docs = make(map[vo.MovieID]posting)
+				i.postings[token] = docs
+			}
+			p, ok := docs[mv.ID]
+			if !ok {
+				p = make(posting)
+				docs[mv.ID] = p
+				terms = append(terms, token)
+			}
+			p[field]++
+		}
+	}
+	i.docs[mv.ID] = terms
+
+	logger.Info("index movie successfully", applog.Int("terms", len(terms)))
+	return nil
+}
+
+func (i *memoryMovieIndex) Remove(ctx context.Context, id vo.MovieID) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.removeLocked(id)
+	i.logger.Info("remove movie from index successfully", applog.String("Method", "Remove"), applog.Uint("movie_id", uint(id)))
+	return nil
+}
+
+// 调用方需持有写锁
+func (i *memoryMovieIndex) removeLocked(id vo.MovieID) {
+	for _, term := range i.docs[id] {
+		delete(i.postings[term], id)
+		if len(i.postings[term]) == 0 {
+			delete(i.postings, term)
+		}
+	}
+	delete(i.docs, id)
+}
+
+func (i *memoryMovieIndex) Search(ctx context.Context, query *movie.MovieSearchQuery) (*movie.MovieSearchResult, error) {
+	logger := i.logger.With(applog.String("Method", "Search"), applog.String("query", query.Query))
+
+	terms := movie.QueryTerms(query.Query)
+	if len(terms) == 0 {
+		return &movie.MovieSearchResult{Hits: []*movie.MovieSearchHit{}}, nil
+	}
+
+	i.mu.RLock()
+	scores := i.scoreLocked(terms)
+	i.mu.RUnlock()
+
+	hits := make([]*movie.MovieSearchHit, 0, len(scores))
+	for id, score := range scores {
+		hits = append(hits, &movie.MovieSearchHit{MovieID: id, Score: score})
+	}
+	sort.Slice(hits, func(a, b int) bool {
+		if hits[a].Score != hits[b].Score {
+			return hits[a].Score > hits[b].Score
+		}
+		return hits[a].MovieID < hits[b].MovieID
+	})
+
+	total := int64(len(hits))
+	if query.PageSize > 0 {
+		start := min(max(query.Page-1, 0)*query.PageSize, len(hits))
+		end := min(start+query.PageSize, len(hits))
+		hits = hits[start:end]
+	}
+
+	logger.Info("search movies successfully", applog.Int64("total", total))
+	return &movie.MovieSearchResult{Hits: hits, Total: total}, nil
+}
+
+// 计算每部电影的相关度：对每个查询词取匹配度最高的索引词元（精确、前缀或拼写容错），
+// 按 IDF * 字段权重 * 饱和词频累加，最后按命中查询词的比例加权，命中越多排名越靠前
+// 调用方需持有读锁
+func (i *memoryMovieIndex) scoreLocked(terms []string) map[vo.MovieID]float64 {
+	totalDocs := float64(len(i.docs))
+	scores := make(map[vo.MovieID]float64)
+	matchedTerms := make(map[vo.MovieID]int)
+
+	for _, term := range terms {
+		termScores := make(map[vo.MovieID]float64)
+		for token, docs := range i.postings {
+			weight, ok := movie.MatchTerm(term, token)
+			if !ok {
+				continue
+			}
+			docFreq := float64(len(docs))
+			idf := math.Log(1 + (totalDocs-docFreq+0.5)/(docFreq+0.5))
+			for id, p := range docs {
+				var fieldScore float64
+				for field, tf := range p {
+					fieldScore += fieldWeights[field] * float64(tf) / (float64(tf) + termFrequencySaturation)
+				}
+				termScores[id] = max(termScores[id], weight*idf*fieldScore)
+			}
+		}
+		for id, score := range termScores {
+			scores[id] += score
+			matchedTerms[id]++
+		}
+	}
+
+	for id := range scores {
+		scores[id] *= float64(matchedTerms[id]) / float64(len(terms))
+	}
+	return scores
+}
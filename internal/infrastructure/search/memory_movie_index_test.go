@@ -0,0 +1,221 @@
+package search
+
+import (
+	"context"
+	"mrs/internal/domain/movie"
+	"mrs/internal/domain/shared/vo"
+	applog "mrs/pkg/log"
+	"strings"
+	"testing"
+)
+
+// nopLogger 是一个丢弃所有日志的 Logger 实现
+type nopLogger struct{}
+
+func (nopLogger) Debug(msg string, fields ...applog.Field) {}
+func (nopLogger) Info(msg string, fields ...applog.Field)  {}
+func (nopLogger) Warn(msg string, fields ...applog.Field)  {}
+func (nopLogger) Error(msg string, fields ...applog.Field) {}
+func (nopLogger) Panic(msg string, fields ...applog.Field) {}
+func (nopLogger) Fatal(msg string, fields ...applog.Field) {}
+func (l nopLogger) With(fields ...applog.Field) applog.Logger {
+	return l
+}
+func (nopLogger) Sync() error { return nil }
+
+func newTestIndex(t *testing.T) movie.MovieSearchIndex {
+	t.Helper()
+	index := NewMemoryMovieIndex(nopLogger{})
+	movies := []*movie.Movie{
+		{
+			ID:          1,
+			Title:       "Interstellar",
+			Description: "A team of explorers travel through a wormhole in space.",
+			Cast:        "Matthew McConaughey, Anne Hathaway",
+			Genres:      []*movie.Genre{{ID: 1, Name: "Science Fiction"}, {ID: 2, Name: "Drama"}},
+		},
+		{
+			ID:          2,
+			Title:       "The Martian",
+			Description: "An astronaut becomes stranded on Mars and must survive in space.",
+			Cast:        "Matt Damon, Jessica Chastain",
+			Genres:      []*movie.Genre{{ID: 1, Name: "Science Fiction"}},
+		},
+		{
+			ID:          3,
+			Title:       "Les Misérables",
+			Description: "An ex-prisoner seeks redemption in revolutionary France.",
+			Cast:        "Hugh Jackman, Anne Hathaway",
+			Genres:      []*movie.Genre{{ID: 2, Name: "Drama"}, {ID: 3, Name: "Musical"}},
+		},
+	}
+	for _, mv := range movies {
+		if err := index.Index(context.Background(), mv); err != nil {
+			t.Fatalf("Index(%d) failed: %v", mv.ID, err)
+		}
+	}
+	return index
+}
+
+func search(t *testing.T, index movie.MovieSearchIndex, query string) []vo.MovieID {
+	t.Helper()
+	result, err := index.Search(context.Background(), &movie.MovieSearchQuery{Query: query, Page: 1, PageSize: 10})
+	if err != nil {
+		t.Fatalf("Search(%q) failed: %v", query, err)
+	}
+	ids := make([]vo.MovieID, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		ids = append(ids, hit.MovieID)
+	}
+	if int(result.Total) != len(ids) {
+		t.Errorf("Search(%q) total = %d, want %d", query, result.Total, len(ids))
+	}
+	return ids
+}
+
+func TestMemoryMovieIndex_SearchAcrossFields(t *testing.T) {
+	index := newTestIndex(t)
+
+	tests := []struct {
+		name  string
+		query string
+		want  []vo.MovieID
+	}{
+		{name: "title", query: "martian", want: []vo.MovieID{2}},
+		{name: "description", query: "wormhole", want: []vo.MovieID{1}},
+		{name: "cast", query: "hathaway", want: []vo.MovieID{1, 3}},
+		{name: "genre", query: "musical", want: []vo.MovieID{3}},
+		{name: "case insensitive", query: "JACKMAN", want: []vo.MovieID{3}},
+		{name: "no match", query: "zombie", want: []vo.MovieID{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := search(t, index, tt.query)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestMemoryMovieIndex_Ranking(t *testing.T) {
+	index := newTestIndex(t)
+
+	// 标题命中的权重高于描述命中
+	got := search(t, index, "space martian")
+	if len(got) != 2 || got[0] != 2 {
+		t.Errorf("Expected title match first, got %v", got)
+	}
+
+	// 命中更多查询词的电影排名更靠前
+	got = search(t, index, "anne hathaway drama musical")
+	if len(got) != 2 || got[0] != 3 {
+		t.Errorf("Expected movie matching more terms first, got %v", got)
+	}
+}
+
+func TestMemoryMovieIndex_TypoTolerance(t *testing.T) {
+	index := newTestIndex(t)
+
+	tests := []struct {
+		name  string
+		query string
+		want  vo.MovieID
+	}{
+		{name: "substitution", query: "intarstellar", want: 1},
+		{name: "deletion", query: "interstelar", want: 1},
+		{name: "transposition", query: "martain", want: 2},
+		{name: "prefix", query: "inters", want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := search(t, index, tt.query)
+			if len(got) == 0 || got[0] != tt.want {
+				t.Errorf("Search(%q) = %v, want %d first", tt.query, got, tt.want)
+			}
+		})
+	}
+
+	// 短词不做拼写容错
+	if got := search(t, index, "mar"); len(got) != 1 || got[0] != 2 {
+		t.Errorf("Expected only prefix match for short term, got %v", got)
+	}
+	if got := search(t, index, "max"); len(got) != 0 {
+		t.Errorf("Expected no typo tolerance for short term, got %v", got)
+	}
+}
+
+func TestMemoryMovieIndex_UpdateAndRemove(t *testing.T) {
+	index := newTestIndex(t)
+	ctx := context.Background()
+
+	// 重新索引会覆盖旧文档
+	updated := &movie.Movie{ID: 2, Title: "The Martian", Cast: "Matt Damon", Description: "Botany on Mars."}
+	if err := index.Index(ctx, updated); err != nil {
+		t.Fatalf("Index failed: %v", err)
+	}
+	if got := search(t, index, "chastain"); len(got) != 0 {
+		t.Errorf("Expected stale terms to be removed, got %v", got)
+	}
+	if got := search(t, index, "botany"); len(got) != 1 || got[0] != 2 {
+		t.Errorf("Expected new terms to be indexed, got %v", got)
+	}
+
+	if err := index.Remove(ctx, 1); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if got := search(t, index, "hathaway"); len(got) != 1 || got[0] != 3 {
+		t.Errorf("Expected removed movie to be excluded, got %v", got)
+	}
+}
+
+func TestMemoryMovieIndex_Pagination(t *testing.T) {
+	index := newTestIndex(t)
+
+	result, err := index.Search(context.Background(), &movie.MovieSearchQuery{Query: "science drama", Page: 2, PageSize: 2})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if result.Total != 3 {
+		t.Errorf("Expected total 3, got %d", result.Total)
+	}
+	if len(result.Hits) != 1 {
+		t.Errorf("Expected 1 hit on page 2, got %d", len(result.Hits))
+	}
+}
+
+func TestBuildHighlights(t *testing.T) {
+	mv := &movie.Movie{
+		Title:       "Interstellar",
+		Description: strings.Repeat("filler ", 40) + "a wormhole appears " + strings.Repeat("filler ", 40),
+		Cast:        "Matthew McConaughey, Anne Hathaway",
+		Genres:      []*movie.Genre{{Name: "Science Fiction"}},
+	}
+
+	highlights := movie.BuildHighlights(mv, movie.QueryTerms("interstelar wormhole hathaway"))
+
+	if got := highlights[movie.SearchFieldTitle]; got != "<em>Interstellar</em>" {
+		t.Errorf("Unexpected title highlight: %q", got)
+	}
+	if got := highlights[movie.SearchFieldCast]; got != "Matthew McConaughey, Anne <em>Hathaway</em>" {
+		t.Errorf("Unexpected cast highlight: %q", got)
+	}
+	desc := highlights[movie.SearchFieldDescription]
+	if !strings.Contains(desc, "<em>wormhole</em>") {
+		t.Errorf("Expected description snippet to contain highlighted term, got %q", desc)
+	}
+	if !strings.HasPrefix(desc, "...") || !strings.HasSuffix(desc, "...") {
+		t.Errorf("Expected truncated description snippet, got %q", desc)
+	}
+	if len([]rune(desc)) > movie.DefaultSnippetLength+len("<em></em>")+2*len("...") {
+		t.Errorf("Description snippet too long: %d", len([]rune(desc)))
+	}
+	if _, ok := highlights[movie.SearchFieldGenres]; ok {
+		t.Errorf("Expected no genre highlight")
+	}
+}
@@ -0,0 +1,130 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mrs/internal/domain/shared/blob"
+	applog "mrs/pkg/log"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// LocalBlobStore 将对象保存在本地文件系统，适用于单机部署与开发环境
+type LocalBlobStore struct {
+	root    string
+	baseURL string
+	logger  applog.Logger
+}
+
+func NewLocalBlobStore(root, baseURL string, logger applog.Logger) (blob.BlobStore, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("create local storage dir error: %w", err)
+	}
+	return &LocalBlobStore{
+		root:    root,
+		baseURL: baseURL,
+		logger:  logger.With(applog.String("Component", "LocalBlobStore")),
+	}, nil
+}
+
+func (s *LocalBlobStore) filePath(key string) (string, error) {
+	cleaned, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(s.root, filepath.FromSlash(cleaned)), nil
+}
+
+// 先写入同目录下的临时文件再重命名，避免读到写了一半的文件
+func (s *LocalBlobStore) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	logger := s.logger.With(applog.String("Method", "Put"), applog.String("key", key))
+
+	filePath, err := s.filePath(key)
+	if err != nil {
+		logger.Warn("invalid blob key", applog.Error(err))
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		logger.Error("create blob dir error", applog.Error(err))
+		return fmt.Errorf("create blob dir error: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), ".upload-*")
+	if err != nil {
+		logger.Error("create temp file error", applog.Error(err))
+		return fmt.Errorf("create temp file error: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // 重命名成功后删除会失败，忽略即可
+
+	written, err := io.Copy(tmp, body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		logger.Error("write blob error", applog.Error(err))
+		return fmt.Errorf("write blob error: %w", err)
+	}
+	if size >= 0 && written != size {
+		logger.Error("blob size mismatch", applog.Int64("expected", size), applog.Int64("written", written))
+		return fmt.Errorf("write blob error: expected %d bytes, wrote %d", size, written)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		logger.Error("chmod blob error", applog.Error(err))
+		return fmt.Errorf("chmod blob error: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filePath); err != nil {
+		logger.Error("rename blob error", applog.Error(err))
+		return fmt.Errorf("rename blob error: %w", err)
+	}
+
+	logger.Debug("put blob successfully", applog.Int64("size", written))
+	return nil
+}
+
+func (s *LocalBlobStore) Get(ctx context.Context, key string) (*blob.Object, error) {
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(filePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w(key): %s", blob.ErrBlobNotFound, key)
+		}
+		return nil, fmt.Errorf("open blob error: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("stat blob error: %w", err)
+	}
+	if info.IsDir() {
+		file.Close()
+		return nil, fmt.Errorf("%w(key): %s", blob.ErrBlobNotFound, key)
+	}
+	return &blob.Object{
+		Body:        file,
+		ContentType: mime.TypeByExtension(path.Ext(key)),
+		Size:        info.Size(),
+	}, nil
+}
+
+func (s *LocalBlobStore) Delete(ctx context.Context, key string) error {
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		s.logger.Error("delete blob error", applog.String("key", key), applog.Error(err))
+		return fmt.Errorf("delete blob error: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalBlobStore) URL(key string) string {
+	return joinURL(s.baseURL, key)
+}
@@ -0,0 +1,255 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mrs/internal/domain/shared/blob"
+	"mrs/internal/infrastructure/config"
+	applog "mrs/pkg/log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	s3Service          = "s3"
+	s3SignAlgorithm    = "AWS4-HMAC-SHA256"
+	s3UnsignedPayload  = "UNSIGNED-PAYLOAD"
+	s3AmzDateFormat    = "20060102T150405Z"
+	s3ShortDateFormat  = "20060102"
+	s3RequestTimeout   = 5 * time.Minute
+	s3ErrorBodyMaxSize = 1024
+)
+
+// S3BlobStore 通过 S3 兼容的 REST 接口存取对象，使用 SigV4 签名
+// 请求体不参与签名（UNSIGNED-PAYLOAD），以便流式上传大文件
+type S3BlobStore struct {
+	cfg      config.S3Config
+	endpoint *url.URL
+	baseURL  string
+	client   *http.Client
+	logger   applog.Logger
+}
+
+func NewS3BlobStore(cfg config.S3Config, publicBaseURL string, logger applog.Logger) (blob.BlobStore, error) {
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid s3 endpoint: %q", cfg.Endpoint)
+	}
+	store := &S3BlobStore{
+		cfg:      cfg,
+		endpoint: endpoint,
+		baseURL:  publicBaseURL,
+		client:   &http.Client{Timeout: s3RequestTimeout},
+		logger:   logger.With(applog.String("Component", "S3BlobStore")),
+	}
+	if store.baseURL == "" {
+		store.baseURL = store.objectURL("").String()
+	}
+	return store, nil
+}
+
+// 对象地址：路径风格为 endpoint/bucket/key，虚拟主机风格为 bucket.endpoint/key
+func (s *S3BlobStore) objectURL(key string) *url.URL {
+	u := *s.endpoint
+	if s.cfg.UsePathStyle {
+		u.Path = "/" + s.cfg.Bucket + "/" + key
+	} else {
+		u.Host = s.cfg.Bucket + "." + u.Host
+		u.Path = "/" + key
+	}
+	u.RawPath = ""
+	u.RawQuery = ""
+	return &u
+}
+
+func (s *S3BlobStore) newRequest(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	cleaned, err := cleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, s.objectURL(cleaned).String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("create s3 request error: %w", err)
+	}
+	return req, nil
+}
+
+func (s *S3BlobStore) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	logger := s.logger.With(applog.String("Method", "Put"), applog.String("key", key))
+
+	req, err := s.newRequest(ctx, http.MethodPut, key, body)
+	if err != nil {
+		logger.Warn("failed to create request", applog.Error(err))
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := s.do(req)
+	if err != nil {
+		logger.Error("s3 put object error", applog.Error(err))
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		err := s.responseError(resp)
+		logger.Error("s3 put object error", applog.Error(err))
+		return err
+	}
+
+	logger.Debug("put blob successfully", applog.Int64("size", size))
+	return nil
+}
+
+func (s *S3BlobStore) Get(ctx context.Context, key string) (*blob.Object, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		s.logger.Error("s3 get object error", applog.String("key", key), applog.Error(err))
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return &blob.Object{
+			Body:        resp.Body,
+			ContentType: resp.Header.Get("Content-Type"),
+			Size:        resp.ContentLength,
+		}, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w(key): %s", blob.ErrBlobNotFound, key)
+	}
+	defer resp.Body.Close()
+	err = s.responseError(resp)
+	s.logger.Error("s3 get object error", applog.String("key", key), applog.Error(err))
+	return nil, err
+}
+
+func (s *S3BlobStore) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		s.logger.Error("s3 delete object error", applog.String("key", key), applog.Error(err))
+		return err
+	}
+	defer resp.Body.Close()
+	// S3 删除不存在的对象同样返回 204
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		err := s.responseError(resp)
+		s.logger.Error("s3 delete object error", applog.String("key", key), applog.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (s *S3BlobStore) URL(key string) string {
+	return joinURL(s.baseURL, key)
+}
+
+func (s *S3BlobStore) do(req *http.Request) (*http.Response, error) {
+	s.sign(req, time.Now().UTC())
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("s3 request error: %w", err)
+	}
+	return resp, nil
+}
+
+func (s *S3BlobStore) responseError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, s3ErrorBodyMaxSize))
+	return fmt.Errorf("s3 %s %s: status %d: %s", resp.Request.Method, resp.Request.URL.Path, resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+// 按 AWS Signature Version 4 为请求签名
+func (s *S3BlobStore) sign(req *http.Request, now time.Time) {
+	amzDate := now.Format(s3AmzDateFormat)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", s3UnsignedPayload)
+
+	// 参与签名的请求头
+	headers := map[string]string{
+		"host":                 req.URL.Host,
+		"x-amz-content-sha256": s3UnsignedPayload,
+		"x-amz-date":           amzDate,
+	}
+	if contentType := req.Header.Get("Content-Type"); contentType != "" {
+		headers["content-type"] = contentType
+	}
+
+	canonicalRequest, signedHeaders := s3CanonicalRequest(req.Method, req.URL.Path, req.URL.RawQuery, headers, s3UnsignedPayload)
+	scope, signature := s3Signature(s.cfg.SecretAccessKey, s.cfg.Region, now, canonicalRequest)
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3SignAlgorithm, s.cfg.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// 规范请求：headers 的键为小写请求头名，按字母排序参与签名；query 需已是规范形式
+func s3CanonicalRequest(method, path, query string, headers map[string]string, payloadHash string) (string, string) {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	return strings.Join([]string{
+		method,
+		s3EscapePath(path),
+		query,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n"), signedHeaders
+}
+
+// 计算规范请求的签名，返回凭证范围与签名
+func s3Signature(secretAccessKey, region string, now time.Time, canonicalRequest string) (string, string) {
+	shortDate := now.Format(s3ShortDateFormat)
+	scope := strings.Join([]string{shortDate, region, s3Service, "aws4_request"}, "/")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{s3SignAlgorithm, now.Format(s3AmzDateFormat), scope, hex.EncodeToString(requestHash[:])}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+secretAccessKey), shortDate)
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, s3Service)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	return scope, hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// 按 SigV4 规则编码路径：除非保留字符 A-Z a-z 0-9 - _ . ~ 与分隔符 / 外全部百分号编码
+func s3EscapePath(p string) string {
+	var sb strings.Builder
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' ||
+			c == '-' || c == '_' || c == '.' || c == '~' || c == '/' {
+			sb.WriteByte(c)
+		} else {
+			fmt.Fprintf(&sb, "%%%02X", c)
+		}
+	}
+	return sb.String()
+}
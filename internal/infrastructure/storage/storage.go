@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"fmt"
+	"mrs/internal/domain/movie"
+	"mrs/internal/domain/shared/blob"
+	"mrs/internal/infrastructure/config"
+	applog "mrs/pkg/log"
+	"path"
+	"strings"
+)
+
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+
+	defaultLocalDir       = "./var/media"
+	defaultPublicBaseURL  = "/media"
+	defaultS3Region       = "us-east-1"
+	defaultMaxImageSizeMB = 10
+	defaultMaxVideoSizeMB = 200
+	defaultMaxImagePixels = 40_000_000
+)
+
+var (
+	defaultPosterWidths   = []int{185, 342, 780}
+	defaultBackdropWidths = []int{300, 780, 1280}
+)
+
+// NewBlobStore 根据配置选择存储驱动
+func NewBlobStore(cfg config.StorageConfig, logger applog.Logger) (blob.BlobStore, error) {
+	switch cfg.Driver {
+	case "", DriverLocal:
+		dir := cfg.LocalDir
+		if dir == "" {
+			dir = defaultLocalDir
+		}
+		baseURL := cfg.PublicBaseURL
+		if baseURL == "" {
+			baseURL = defaultPublicBaseURL
+		}
+		return NewLocalBlobStore(dir, baseURL, logger)
+	case DriverS3:
+		s3cfg := cfg.S3
+		if s3cfg.Endpoint == "" || s3cfg.Bucket == "" || s3cfg.AccessKeyID == "" || s3cfg.SecretAccessKey == "" {
+			return nil, fmt.Errorf("storage driver s3 requires endpoint, bucket, accessKeyID and secretAccessKey")
+		}
+		if s3cfg.Region == "" {
+			s3cfg.Region = defaultS3Region
+		}
+		return NewS3BlobStore(s3cfg, cfg.PublicBaseURL, logger)
+	}
+	return nil, fmt.Errorf("unknown storage driver: %s", cfg.Driver)
+}
+
+// NewMediaPolicy 根据配置生成媒体上传策略，未配置的项使用默认值
+func NewMediaPolicy(cfg config.StorageConfig) *movie.MediaPolicy {
+	policy := &movie.MediaPolicy{
+		MaxImageSize:   defaultMaxImageSizeMB << 20,
+		MaxVideoSize:   defaultMaxVideoSizeMB << 20,
+		MaxImagePixels: defaultMaxImagePixels,
+		PosterWidths:   defaultPosterWidths,
+		BackdropWidths: defaultBackdropWidths,
+	}
+	if cfg.MaxImageSizeMB > 0 {
+		policy.MaxImageSize = cfg.MaxImageSizeMB << 20
+	}
+	if cfg.MaxVideoSizeMB > 0 {
+		policy.MaxVideoSize = cfg.MaxVideoSizeMB << 20
+	}
+	if cfg.MaxImagePixels > 0 {
+		policy.MaxImagePixels = cfg.MaxImagePixels
+	}
+	if len(cfg.PosterWidths) > 0 {
+		policy.PosterWidths = cfg.PosterWidths
+	}
+	if len(cfg.BackdropWidths) > 0 {
+		policy.BackdropWidths = cfg.BackdropWidths
+	}
+	return policy
+}
+
+// 校验并规范化存储键，拒绝绝对路径与跳出根目录的路径
+func cleanKey(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return "", fmt.Errorf("%w: %q", blob.ErrInvalidBlobKey, key)
+	}
+	cleaned := path.Clean(key)
+	if cleaned != key || cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("%w: %q", blob.ErrInvalidBlobKey, key)
+	}
+	return cleaned, nil
+}
+
+func joinURL(baseURL, key string) string {
+	return strings.TrimRight(baseURL, "/") + "/" + key
+}
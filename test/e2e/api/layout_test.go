@@ -0,0 +1,288 @@
+package test
+
+import (
+	"fmt"
+	"mrs/internal/api/dto/request"
+	"mrs/internal/api/dto/response"
+	"mrs/internal/domain/cinema"
+	applog "mrs/pkg/log"
+	"mrs/test/e2e/testutils"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const e2eASCIILayout = "@screen top\n" +
+	"@block left 0 0 1 1\n" +
+	"A SS|SS\n" +
+	"B VV|WS\n" +
+	"- ==|==\n" +
+	"C SS...\n"
+
+func TestHallLayoutImportFlow(t *testing.T) {
+	// 初始化测试服务器
+	ts := testutils.NewTestServer(t)
+	defer ts.Close()
+
+	logger := ts.Logger.With(applog.String("Test", "TestHallLayoutImportFlow"))
+
+	// 1. 管理员登录
+	ts.AdminToken = ts.Login(t, "admin", "admin123")
+
+	// 2. 创建影厅（不传布局与座位时生成 10x10 默认布局）
+	createHallReq := request.CreateCinemaHallRequest{
+		Name:        "布局导入厅",
+		ScreenType:  "2D",
+		SoundSystem: "Dolby 7.1",
+	}
+	resp, body := ts.DoRequest(t, http.MethodPost, "/api/v1/admin/cinema-halls", createHallReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var hallResp response.CinemaHallResponse
+	testutils.ParseResponse(t, body, &hallResp)
+	hallID := hallResp.ID
+	assert.Equal(t, 1, hallResp.LayoutVersion)
+	assert.Len(t, hallResp.Seats, cinema.DefaultLayoutRows*cinema.DefaultLayoutCols)
+
+	layoutPath := fmt.Sprintf("/api/v1/admin/cinema-halls/%d/layout", hallID)
+
+	// 3. 导入不合法的布局文件，返回全部问题及其行列
+	invalid := "A SX\n- SS\n"
+	resp, body = ts.DoRawRequest(t, http.MethodPut, layoutPath+"?format=ascii", "text/plain", []byte(invalid), ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusBadRequest, resp.StatusCode, body)
+	var issuesResp struct {
+		Issues []*cinema.LayoutIssue `json:"issues"`
+	}
+	testutils.ParseResponse(t, body, &issuesResp)
+	if assert.Len(t, issuesResp.Issues, 2) {
+		assert.Equal(t, 1, issuesResp.Issues[0].Line)
+		assert.Equal(t, 4, issuesResp.Issues[0].Column)
+		assert.Equal(t, 2, issuesResp.Issues[1].Line)
+	}
+
+	// 4. dry_run 只返回解析结果，不生成新版本
+	resp, body = ts.DoRawRequest(t, http.MethodPut, layoutPath+"?dry_run=true", "text/plain", []byte(e2eASCIILayout), ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	var dryRunResp response.CinemaHallResponse
+	testutils.ParseResponse(t, body, &dryRunResp)
+	assert.Len(t, dryRunResp.Seats, 10)
+
+	resp, body = ts.DoRequest(t, http.MethodGet, fmt.Sprintf("/api/v1/admin/cinema-halls/%d/layout-versions", hallID), nil, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	var versionsResp response.ListLayoutVersionsResponse
+	testutils.ParseResponse(t, body, &versionsResp)
+	assert.Equal(t, 1, versionsResp.CurrentVersion)
+	assert.Len(t, versionsResp.Versions, 1)
+
+	// 5. 导入布局，生成新版本并按新布局创建座位
+	resp, body = ts.DoRawRequest(t, http.MethodPut, layoutPath, "text/plain", []byte(e2eASCIILayout), ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	var importedResp response.CinemaHallResponse
+	testutils.ParseResponse(t, body, &importedResp)
+	assert.Equal(t, 2, importedResp.LayoutVersion)
+	assert.Equal(t, 4, importedResp.RowCount)
+	assert.Equal(t, 5, importedResp.ColCount)
+	assert.Len(t, importedResp.Seats, 10)
+
+	logger.Debug("import layout test", applog.Any("importedResp", importedResp))
+
+	resp, body = ts.DoRequest(t, http.MethodGet, fmt.Sprintf("/api/v1/admin/cinema-halls/%d/layout-versions", hallID), nil, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	testutils.ParseResponse(t, body, &versionsResp)
+	assert.Equal(t, 2, versionsResp.CurrentVersion)
+	assert.Len(t, versionsResp.Versions, 2)
+
+	// 6. 导出当前版本，与导入的文件一致（较短的行导出时补齐空位）
+	resp, body = ts.DoRequest(t, http.MethodGet, layoutPath, nil, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	assert.Contains(t, resp.Header.Get("Content-Disposition"), "attachment")
+	assert.Equal(t, e2eASCIILayout, strings.ReplaceAll(string(body), "\r\n", "\n"))
+
+	// 7. 导出历史版本 1（10x10 默认布局）
+	resp, body = ts.DoRequest(t, http.MethodGet, layoutPath+"?version=1", nil, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	assert.Contains(t, string(body), "A SSSSSSSSSS")
+
+	// 8. 导出不存在的版本
+	resp, body = ts.DoRequest(t, http.MethodGet, layoutPath+"?version=9", nil, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusNotFound, resp.StatusCode, body)
+}
+
+// 按排号与座位号查找座位ID
+func findSeatID(seats []*response.SeatResponse, row, number string) uint {
+	for _, s := range seats {
+		if s.RowIdentifier == row && s.SeatNumber == number {
+			return s.ID
+		}
+	}
+	return 0
+}
+
+func TestShowtimeLayoutMigrationFlow(t *testing.T) {
+	// 初始化测试服务器
+	ts := testutils.NewTestServer(t)
+	defer ts.Close()
+
+	logger := ts.Logger.With(applog.String("Test", "TestShowtimeLayoutMigrationFlow"))
+
+	// 1. 管理员登录
+	ts.AdminToken = ts.Login(t, "admin", "admin123")
+
+	// 2. 创建默认 10x10 布局的影厅（版本1）、电影和两个场次
+	createHallReq := request.CreateCinemaHallRequest{
+		Name:        "布局迁移厅",
+		ScreenType:  "2D",
+		SoundSystem: "Dolby 7.1",
+	}
+	resp, body := ts.DoRequest(t, http.MethodPost, "/api/v1/admin/cinema-halls", createHallReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var hallResp response.CinemaHallResponse
+	testutils.ParseResponse(t, body, &hallResp)
+	hallID := hallResp.ID
+
+	createMovieReq := request.CreateMovieRequest{
+		Title:           "布局迁移测试电影",
+		Description:     "用于测试场次布局迁移",
+		GenreNames:      []string{"剧情"},
+		DurationMinutes: 90,
+		ReleaseDate:     time.Now(),
+		Cast:            "演员1",
+		AgeRating:       "G",
+		Rating:          8.0,
+	}
+	resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/admin/movies", createMovieReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var movieResp response.MovieResponse
+	testutils.ParseResponse(t, body, &movieResp)
+
+	showtimeIDs := make([]uint, 2)
+	for i := range showtimeIDs {
+		startTime := time.Now().Add(time.Duration(24*(i+1)) * time.Hour)
+		createShowtimeReq := request.CreateShowtimeRequest{
+			MovieID:      movieResp.ID,
+			CinemaHallID: hallID,
+			StartTime:    startTime,
+			EndTime:      startTime.Add(time.Duration(createMovieReq.DurationMinutes) * time.Minute),
+			Price:        45.0,
+		}
+		resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/admin/showtimes", createShowtimeReq, ts.AdminToken)
+		testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+		var showtimeResp response.ShowtimeResponse
+		testutils.ParseResponse(t, body, &showtimeResp)
+		assert.Equal(t, 1, showtimeResp.LayoutVersion)
+		showtimeIDs[i] = showtimeResp.ID
+	}
+
+	// 3. 用户在第一个场次预订 A01，在第二个场次预订 J10
+	ts.UserToken = ts.Login(t, "user", "user123")
+	bookedSeats := []uint{findSeatID(hallResp.Seats, "A", "01"), findSeatID(hallResp.Seats, "J", "10")}
+	for i, showtimeID := range showtimeIDs {
+		createBookingReq := request.CreateBookingRequest{ShowtimeID: showtimeID, SeatIDs: []uint{bookedSeats[i]}}
+		resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/bookings", createBookingReq, ts.UserToken)
+		testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	}
+
+	// 4. 导入只有三排的新布局（版本2），J10 在新版本中不存在
+	layoutPath := fmt.Sprintf("/api/v1/admin/cinema-halls/%d/layout", hallID)
+	resp, body = ts.DoRawRequest(t, http.MethodPut, layoutPath, "text/plain", []byte("A SSSS\nB SSSS\nC SSSS\n"), ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	var importedResp response.CinemaHallResponse
+	testutils.ParseResponse(t, body, &importedResp)
+	assert.Equal(t, 2, importedResp.LayoutVersion)
+
+	// 5. dry_run 只报告结果，场次保持原布局版本
+	migrationPath := "/api/v1/admin/showtimes/layout-migrations"
+	migrateReq := request.MigrateShowtimeLayoutsRequest{CinemaHallID: hallID, DryRun: true}
+	resp, body = ts.DoRequest(t, http.MethodPost, migrationPath, migrateReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	var migrateResp response.MigrateShowtimeLayoutsResponse
+	testutils.ParseResponse(t, body, &migrateResp)
+	assert.True(t, migrateResp.DryRun)
+	assert.Equal(t, 2, migrateResp.TargetVersion)
+	assert.Equal(t, 1, migrateResp.WouldMigrate)
+	assert.Equal(t, 1, migrateResp.Conflicted)
+	assert.Equal(t, 0, migrateResp.Migrated)
+	assert.Equal(t, 0, migrateResp.Failed)
+	if assert.Len(t, migrateResp.Showtimes, 2) {
+		assert.Equal(t, showtimeIDs[0], migrateResp.Showtimes[0].ShowtimeID)
+		assert.True(t, migrateResp.Showtimes[0].WouldMigrate)
+		assert.False(t, migrateResp.Showtimes[0].Migrated)
+		assert.Equal(t, 1, migrateResp.Showtimes[0].ReassignedSeats)
+
+		assert.Equal(t, showtimeIDs[1], migrateResp.Showtimes[1].ShowtimeID)
+		if assert.Len(t, migrateResp.Showtimes[1].Conflicts, 1) {
+			conflict := migrateResp.Showtimes[1].Conflicts[0]
+			assert.Equal(t, bookedSeats[1], conflict.SeatID)
+			assert.Equal(t, "J", conflict.RowIdentifier)
+			assert.Equal(t, "10", conflict.SeatNumber)
+		}
+	}
+
+	resp, body = ts.DoRequest(t, http.MethodGet, fmt.Sprintf("/api/v1/showtimes/%d", showtimeIDs[0]), nil, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	var showtimeResp response.ShowtimeResponse
+	testutils.ParseResponse(t, body, &showtimeResp)
+	assert.Equal(t, 1, showtimeResp.LayoutVersion)
+
+	// 6. 执行迁移：无冲突的场次迁移到版本2，有冲突的场次保持原版本
+	migrateReq.DryRun = false
+	resp, body = ts.DoRequest(t, http.MethodPost, migrationPath, migrateReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	testutils.ParseResponse(t, body, &migrateResp)
+	assert.False(t, migrateResp.DryRun)
+	assert.Equal(t, 1, migrateResp.Migrated)
+	assert.Equal(t, 0, migrateResp.WouldMigrate)
+	assert.Equal(t, 1, migrateResp.Conflicted)
+
+	logger.Debug("migrate showtime layouts test", applog.Any("migrateResp", migrateResp))
+
+	resp, body = ts.DoRequest(t, http.MethodGet, fmt.Sprintf("/api/v1/showtimes/%d", showtimeIDs[0]), nil, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	testutils.ParseResponse(t, body, &showtimeResp)
+	assert.Equal(t, 2, showtimeResp.LayoutVersion)
+
+	resp, body = ts.DoRequest(t, http.MethodGet, fmt.Sprintf("/api/v1/showtimes/%d", showtimeIDs[1]), nil, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	testutils.ParseResponse(t, body, &showtimeResp)
+	assert.Equal(t, 1, showtimeResp.LayoutVersion)
+
+	// 7. 迁移后的座位表使用新布局，已售座位改写到新版本的 A01
+	resp, body = ts.DoRequest(t, http.MethodGet, fmt.Sprintf("/api/v1/showtimes/%d/seatmap", showtimeIDs[0]), nil, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	var seatMapResp response.SeatMapResponse
+	testutils.ParseResponse(t, body, &seatMapResp)
+	assert.Len(t, seatMapResp.Seats, 12)
+	for _, seat := range seatMapResp.Seats {
+		if seat.RowIdentifier == "A" && seat.SeatNumber == "01" {
+			assert.Equal(t, cinema.SeatStatusLocked, seat.Status)
+			assert.Equal(t, findSeatID(importedResp.Seats, "A", "01"), uint(seat.ID))
+		} else {
+			assert.Equal(t, cinema.SeatStatusAvailable, seat.Status)
+		}
+	}
+
+	// 8. 再次迁移时已是目标版本的场次被跳过
+	resp, body = ts.DoRequest(t, http.MethodPost, migrationPath, migrateReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	testutils.ParseResponse(t, body, &migrateResp)
+	assert.Equal(t, 0, migrateResp.Migrated)
+	if assert.Len(t, migrateResp.Showtimes, 1) {
+		assert.Equal(t, showtimeIDs[1], migrateResp.Showtimes[0].ShowtimeID)
+	}
+
+	// 9. 回退到版本1：冲突场次无需迁移，已迁移场次的 A01 可以对应回去
+	migrateReq.TargetVersion = 1
+	resp, body = ts.DoRequest(t, http.MethodPost, migrationPath, migrateReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	testutils.ParseResponse(t, body, &migrateResp)
+	assert.Equal(t, 1, migrateResp.TargetVersion)
+	assert.Equal(t, 1, migrateResp.Migrated)
+	assert.Equal(t, 0, migrateResp.Conflicted)
+
+	// 10. 目标版本不存在
+	migrateReq.TargetVersion = 9
+	resp, body = ts.DoRequest(t, http.MethodPost, migrationPath, migrateReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusNotFound, resp.StatusCode, body)
+}
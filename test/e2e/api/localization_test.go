@@ -0,0 +1,143 @@
+package test
+
+import (
+	"fmt"
+	"mrs/internal/api/dto/request"
+	"mrs/internal/api/dto/response"
+	applog "mrs/pkg/log"
+	"mrs/test/e2e/testutils"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 按 Accept-Language 查询电影详情
+func getLocalizedMovie(t *testing.T, ts *testutils.TestServer, movieID uint, acceptLanguage string) response.MovieResponse {
+	resp, body := ts.DoRequestWithHeaders(t, http.MethodGet, fmt.Sprintf("/api/v1/movies/%d", movieID), nil, ts.UserToken,
+		map[string]string{"Accept-Language": acceptLanguage})
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	var movieResp response.MovieResponse
+	testutils.ParseResponse(t, body, &movieResp)
+	return movieResp
+}
+
+func TestLocalizationFlow(t *testing.T) {
+	// 初始化测试服务器
+	ts := testutils.NewTestServer(t)
+	defer ts.Close()
+
+	logger := ts.Logger.With(applog.String("Test", "TestLocalizationFlow"))
+
+	// 1. 管理员创建电影，默认语言为中文
+	ts.AdminToken = ts.Login(t, "admin", "admin123")
+	ts.UserToken = ts.Login(t, "user", "user123")
+
+	createMovieReq := request.CreateMovieRequest{
+		Title:           "本地化测试电影",
+		Description:     "用于测试多语言",
+		GenreNames:      []string{"本地化测试类型"},
+		DurationMinutes: 120,
+		ReleaseDate:     time.Now().AddDate(0, 0, 7),
+		Cast:            "演员1",
+		AgeRating:       "G",
+		Rating:          7.0,
+	}
+	resp, body := ts.DoRequest(t, http.MethodPost, "/api/v1/admin/movies", createMovieReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var movieResp response.MovieResponse
+	testutils.ParseResponse(t, body, &movieResp)
+	if !assert.Len(t, movieResp.Genres, 1) {
+		return
+	}
+	movieID, genreID := movieResp.ID, movieResp.Genres[0].ID
+
+	// 2. 添加类型与电影的英文翻译，语言标签规范化后保存
+	resp, body = ts.DoRequest(t, http.MethodPut, fmt.Sprintf("/api/v1/admin/genres/%d/translations/en", genreID),
+		request.UpsertGenreTranslationRequest{Name: "Localization Genre"}, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+
+	movieTranslationPath := fmt.Sprintf("/api/v1/admin/movies/%d/translations/", movieID)
+	resp, body = ts.DoRequest(t, http.MethodPut, movieTranslationPath+"en-us",
+		request.UpsertMovieTranslationRequest{Title: "Localization Movie"}, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	var translationResp response.MovieTranslationResponse
+	testutils.ParseResponse(t, body, &translationResp)
+	assert.Equal(t, "en-US", translationResp.Locale)
+
+	// 无效的语言标签
+	resp, body = ts.DoRequest(t, http.MethodPut, movieTranslationPath+"x",
+		request.UpsertMovieTranslationRequest{Title: "Invalid"}, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusBadRequest, resp.StatusCode, body)
+	var errResp response.ErrorResponse
+	testutils.ParseResponse(t, body, &errResp)
+	assert.Equal(t, "INVALID_LOCALE", errResp.Code)
+
+	// 3. 按 Accept-Language 协商：en-GB 回退到 en-US，翻译描述为空时使用默认语言
+	localized := getLocalizedMovie(t, ts, movieID, "fr;q=0.9, en-GB")
+	assert.Equal(t, "Localization Movie", localized.Title)
+	assert.Equal(t, createMovieReq.Description, localized.Description)
+	if assert.Len(t, localized.Genres, 1) {
+		assert.Equal(t, "Localization Genre", localized.Genres[0].Name)
+	}
+	assert.Equal(t, []string{"en-US"}, localized.AvailableLocales)
+
+	// 没有请求头或没有匹配的翻译时使用默认语言
+	for _, acceptLanguage := range []string{"", "fr", "zh-CN"} {
+		localized = getLocalizedMovie(t, ts, movieID, acceptLanguage)
+		assert.Equal(t, createMovieReq.Title, localized.Title, "Accept-Language: %q", acceptLanguage)
+	}
+
+	// 类型列表同样本地化
+	resp, body = ts.DoRequestWithHeaders(t, http.MethodGet, "/api/v1/genres", nil, ts.UserToken,
+		map[string]string{"Accept-Language": "en"})
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	var genresResp response.ListAllGenresResponse
+	testutils.ParseResponse(t, body, &genresResp)
+	found := false
+	for _, genre := range genresResp.Genres {
+		if genre.ID == genreID {
+			found = true
+			assert.Equal(t, "Localization Genre", genre.Name)
+		}
+	}
+	assert.True(t, found)
+
+	// 4. 错误响应带稳定的错误码，消息按 Accept-Language 本地化（zh-TW 回退到 zh）
+	// 不存在的电影会以空值写入缓存，每种语言使用不同的电影ID
+	errorCases := []struct {
+		acceptLanguage string
+		wantMessage    string
+	}{
+		{"zh-TW", "电影不存在"},
+		{"en;q=0.8, zh;q=0.5", "Movie not found"},
+		{"fr", "Movie not found"},
+	}
+	for i, tc := range errorCases {
+		resp, body = ts.DoRequestWithHeaders(t, http.MethodGet, fmt.Sprintf("/api/v1/movies/%d", 999990+i), nil, ts.UserToken,
+			map[string]string{"Accept-Language": tc.acceptLanguage})
+		testutils.AssertResponseCode(t, http.StatusNotFound, resp.StatusCode, body)
+		testutils.ParseResponse(t, body, &errResp)
+		assert.Equal(t, "MOVIE_NOT_FOUND", errResp.Code)
+		assert.Equal(t, tc.wantMessage, errResp.Error, "Accept-Language: %q", tc.acceptLanguage)
+	}
+
+	// 5. 删除翻译后回退到默认语言，重复删除返回 404
+	resp, body = ts.DoRequest(t, http.MethodDelete, movieTranslationPath+"en-US", nil, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusNoContent, resp.StatusCode, body)
+	localized = getLocalizedMovie(t, ts, movieID, "en")
+	assert.Equal(t, createMovieReq.Title, localized.Title)
+	assert.Empty(t, localized.AvailableLocales)
+
+	resp, body = ts.DoRequest(t, http.MethodDelete, movieTranslationPath+"en-US", nil, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusNotFound, resp.StatusCode, body)
+	testutils.ParseResponse(t, body, &errResp)
+	assert.Equal(t, "TRANSLATION_NOT_FOUND", errResp.Code)
+	logger.Info("localization flow finished", applog.Uint("movie_id", movieID))
+
+	// 6. 普通用户不能管理翻译
+	resp, body = ts.DoRequest(t, http.MethodPut, movieTranslationPath+"en",
+		request.UpsertMovieTranslationRequest{Title: "Forbidden"}, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusForbidden, resp.StatusCode, body)
+}
@@ -0,0 +1,189 @@
+package test
+
+import (
+	"fmt"
+	"mrs/internal/api/dto/request"
+	"mrs/internal/api/dto/response"
+	"mrs/internal/domain/booking"
+	"mrs/internal/domain/loyalty"
+	applog "mrs/pkg/log"
+	"mrs/test/e2e/testutils"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 查询当前用户的积分账户
+func getLoyaltyAccount(t *testing.T, ts *testutils.TestServer) response.LoyaltyAccountResponse {
+	resp, body := ts.DoRequest(t, http.MethodGet, "/api/v1/users/me/loyalty", nil, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	var account response.LoyaltyAccountResponse
+	testutils.ParseResponse(t, body, &account)
+	return account
+}
+
+// 查询当前用户指定订单的积分流水（按时间倒序）
+func listBookingLedger(t *testing.T, ts *testutils.TestServer, bookingID uint) []*response.LoyaltyEntryResponse {
+	ledgerReq := request.ListLoyaltyLedgerRequest{
+		PaginationRequest: request.PaginationRequest{Page: 1, PageSize: 50},
+	}
+	resp, body := ts.DoRequest(t, http.MethodGet, "/api/v1/users/me/loyalty/ledger", ledgerReq, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	var ledgerResp response.PaginatedLoyaltyLedgerResponse
+	testutils.ParseResponse(t, body, &ledgerResp)
+
+	entries := make([]*response.LoyaltyEntryResponse, 0)
+	for _, entry := range ledgerResp.Entries {
+		if entry.BookingID == bookingID {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+func TestLoyaltyRedeemRefundFlow(t *testing.T) {
+	// 初始化测试服务器
+	ts := testutils.NewTestServer(t)
+	defer ts.Close()
+
+	logger := ts.Logger.With(applog.String("Test", "TestLoyaltyRedeemRefundFlow"))
+
+	// 1. 管理员登录，创建影厅、电影和场次
+	ts.AdminToken = ts.Login(t, "admin", "admin123")
+
+	createHallReq := request.CreateCinemaHallRequest{
+		Name:        "积分测试厅",
+		ScreenType:  "2D",
+		SoundSystem: "Dolby 5.1",
+		Seats: []*request.SeatRequest{
+			{RowIdentifier: "A", SeatNumber: "1", Type: "STANDARD"},
+			{RowIdentifier: "A", SeatNumber: "2", Type: "STANDARD"},
+		},
+	}
+	resp, body := ts.DoRequest(t, http.MethodPost, "/api/v1/admin/cinema-halls", createHallReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var hallResp response.CinemaHallResponse
+	testutils.ParseResponse(t, body, &hallResp)
+
+	createMovieReq := request.CreateMovieRequest{
+		Title:           "积分测试电影",
+		Description:     "用于测试积分抵扣与退回",
+		GenreNames:      []string{"喜剧"},
+		DurationMinutes: 110,
+		ReleaseDate:     time.Now(),
+		Cast:            "演员1",
+		AgeRating:       "G",
+		Rating:          8.0,
+	}
+	resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/admin/movies", createMovieReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var movieResp response.MovieResponse
+	testutils.ParseResponse(t, body, &movieResp)
+
+	startTime := time.Now().Add(24 * time.Hour)
+	createShowtimeReq := request.CreateShowtimeRequest{
+		MovieID:      movieResp.ID,
+		CinemaHallID: hallResp.ID,
+		StartTime:    startTime,
+		EndTime:      startTime.Add(time.Duration(createMovieReq.DurationMinutes) * time.Minute),
+		Price:        100.0,
+	}
+	resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/admin/showtimes", createShowtimeReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var showtimeResp response.ShowtimeResponse
+	testutils.ParseResponse(t, body, &showtimeResp)
+	showtimeID := showtimeResp.ID
+
+	// 2. 用户登录，记录初始积分账户
+	ts.UserToken = ts.Login(t, "user", "user123")
+	initial := getLoyaltyAccount(t, ts)
+	assert.NotNil(t, initial.Tier)
+	assert.NotEmpty(t, initial.Tiers)
+
+	// 3. 预订并确认订单，按实付金额获得积分
+	createBookingReq := request.CreateBookingRequest{ShowtimeID: showtimeID, SeatIDs: []uint{hallResp.Seats[0].ID}}
+	resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/bookings", createBookingReq, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var earnBooking response.BookingResponse
+	testutils.ParseResponse(t, body, &earnBooking)
+
+	resp, body = ts.DoRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/bookings/%d/confirm", earnBooking.ID), nil, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+
+	earned := getLoyaltyAccount(t, ts)
+	earnedPoints := earned.Balance - initial.Balance
+	assert.Greater(t, earnedPoints, 0)
+	assert.Equal(t, initial.QualifyingPoints+earnedPoints, earned.QualifyingPoints)
+	if entries := listBookingLedger(t, ts, earnBooking.ID); assert.Len(t, entries, 1) {
+		assert.Equal(t, string(loyalty.EntryEarn), entries[0].Type)
+		assert.Equal(t, earnedPoints, entries[0].Points)
+	}
+
+	// 4. 抵扣超过余额的积分被拒绝
+	createBookingReq.SeatIDs = []uint{hallResp.Seats[1].ID}
+	createBookingReq.RedeemPoints = earned.Balance + 1
+	resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/bookings", createBookingReq, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusBadRequest, resp.StatusCode, body)
+
+	// 5. 使用积分抵扣下单：余额减少，等级积分不变
+	redeemPoints := min(earned.Balance, 10)
+	createBookingReq.RedeemPoints = redeemPoints
+	resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/bookings", createBookingReq, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var redeemBooking response.BookingResponse
+	testutils.ParseResponse(t, body, &redeemBooking)
+	if assert.NotNil(t, redeemBooking.Discount) {
+		assert.Equal(t, redeemPoints, redeemBooking.Discount.PointsRedeemed)
+		assert.InDelta(t, float64(redeemPoints)*earned.PointValue, redeemBooking.Discount.PointsDiscount, 0.001)
+		assert.InDelta(t, createShowtimeReq.Price-redeemBooking.Discount.TierDiscount-redeemBooking.Discount.PointsDiscount,
+			redeemBooking.TotalAmount, 0.001)
+	}
+
+	logger.Debug("redeem booking test", applog.Any("redeemBooking", redeemBooking))
+
+	redeemed := getLoyaltyAccount(t, ts)
+	assert.Equal(t, earned.Balance-redeemPoints, redeemed.Balance)
+	assert.Equal(t, earned.QualifyingPoints, redeemed.QualifyingPoints)
+	if entries := listBookingLedger(t, ts, redeemBooking.ID); assert.Len(t, entries, 1) {
+		assert.Equal(t, string(loyalty.EntryRedeem), entries[0].Type)
+		assert.Equal(t, -redeemPoints, entries[0].Points)
+	}
+
+	// 6. 取消待支付订单，退回抵扣的积分
+	cancelPath := fmt.Sprintf("/api/v1/bookings/%d/cancel", redeemBooking.ID)
+	resp, body = ts.DoRequest(t, http.MethodPost, cancelPath, nil, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	var cancelResp response.BookingResponse
+	testutils.ParseResponse(t, body, &cancelResp)
+	assert.Equal(t, string(booking.BookingStatusCanceled), cancelResp.Status)
+
+	restored := getLoyaltyAccount(t, ts)
+	assert.Equal(t, earned.Balance, restored.Balance)
+	assert.Equal(t, earned.QualifyingPoints, restored.QualifyingPoints)
+	if entries := listBookingLedger(t, ts, redeemBooking.ID); assert.Len(t, entries, 2) {
+		assert.Equal(t, string(loyalty.EntryRestore), entries[0].Type)
+		assert.Equal(t, redeemPoints, entries[0].Points)
+	}
+
+	// 7. 重复取消失败，积分不会被重复退回
+	resp, body = ts.DoRequest(t, http.MethodPost, cancelPath, nil, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusBadRequest, resp.StatusCode, body)
+	assert.Equal(t, earned.Balance, getLoyaltyAccount(t, ts).Balance)
+
+	// 8. 取消场次退款已确认订单，冲销订单获得的积分
+	resp, body = ts.DoRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/admin/showtimes/%d/cancel", showtimeID), nil, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	var cancelShowtimeResp response.CancelShowtimeResponse
+	testutils.ParseResponse(t, body, &cancelShowtimeResp)
+	assert.Equal(t, 1, cancelShowtimeResp.RefundedBookings)
+
+	refunded := getLoyaltyAccount(t, ts)
+	assert.Equal(t, initial.Balance, refunded.Balance)
+	assert.Equal(t, initial.QualifyingPoints, refunded.QualifyingPoints)
+	if entries := listBookingLedger(t, ts, earnBooking.ID); assert.Len(t, entries, 2) {
+		assert.Equal(t, string(loyalty.EntryReverse), entries[0].Type)
+		assert.Equal(t, -earnedPoints, entries[0].Points)
+	}
+}
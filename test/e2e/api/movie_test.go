@@ -150,3 +150,206 @@ func TestMovieManagementFlow(t *testing.T) {
 	resp, _ = ts.DoRequest(t, http.MethodDelete, "/api/v1/admin/genres/1", nil, ts.AdminToken)
 	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
 }
+
+func TestMovieCatalogImportFlow(t *testing.T) {
+	// 初始化测试服务器
+	ts := testutils.NewTestServer(t)
+	defer ts.Close()
+
+	logger := ts.Logger.With(applog.String("Test", "TestMovieCatalogImportFlow"))
+
+	// 1. 管理员登录
+	ts.AdminToken = ts.Login(t, "admin", "admin123")
+
+	// 2. 试运行 csv 片单：两条新电影，一条标题重复（忽略大小写），一条缺少上映日期
+	feed := "title,release_date,genres,duration_minutes\n" +
+		"Catalog Import A,2024-03-01,科幻|冒险,120\n" +
+		"Catalog Import B,2024-05-20,剧情,95\n" +
+		"catalog import a,2024-03-02,科幻,100\n" +
+		"Catalog Import C,,剧情,90\n"
+	resp, body := ts.DoRawRequest(t, http.MethodPost, "/api/v1/admin/movies/import?format=csv&dry_run=true", "text/csv", []byte(feed), ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	var importResp response.ImportMoviesResponse
+	testutils.ParseResponse(t, body, &importResp)
+	assert.True(t, importResp.DryRun)
+	assert.Equal(t, response.ImportMoviesSummary{Total: 4, Created: 2, Failed: 2}, importResp.Summary)
+	if assert.Len(t, importResp.Rows, 4) {
+		assert.Equal(t, 2, importResp.Rows[0].Row)
+		assert.Equal(t, response.ImportActionCreate, importResp.Rows[0].Action)
+		assert.Zero(t, importResp.Rows[0].MovieID)
+		assert.NotEmpty(t, importResp.Rows[0].Changes)
+		assert.Equal(t, response.ImportActionError, importResp.Rows[2].Action)
+		assert.Contains(t, importResp.Rows[2].Error, "duplicate title, first seen at row 2")
+		assert.Equal(t, response.ImportActionError, importResp.Rows[3].Action)
+		assert.Contains(t, importResp.Rows[3].Error, "release_date is required")
+	}
+
+	// 3. 正式导入：试运行没有落库，两条新电影被创建
+	resp, body = ts.DoRawRequest(t, http.MethodPost, "/api/v1/admin/movies/import?format=csv", "text/csv", []byte(feed), ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	testutils.ParseResponse(t, body, &importResp)
+	assert.False(t, importResp.DryRun)
+	assert.Equal(t, response.ImportMoviesSummary{Total: 4, Created: 2, Failed: 2}, importResp.Summary)
+	if !assert.Len(t, importResp.Rows, 4) {
+		return
+	}
+	movieA, movieB := importResp.Rows[0].MovieID, importResp.Rows[1].MovieID
+	assert.NotZero(t, movieA)
+	assert.NotZero(t, movieB)
+
+	logger.Debug("import movies test", applog.Any("importResp", importResp))
+
+	resp, body = ts.DoRequest(t, http.MethodGet, fmt.Sprintf("/api/v1/movies/%d", movieA), nil, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	var movieResp response.MovieResponse
+	testutils.ParseResponse(t, body, &movieResp)
+	assert.Equal(t, "Catalog Import A", movieResp.Title)
+	assert.Equal(t, 120, movieResp.DurationMinutes)
+	assert.Len(t, movieResp.Genres, 2)
+
+	// 4. 以 json 再次导入：A 未变化（上映日期不会因时区产生差异），B 只有片长变化
+	jsonFeed := `[
+		{"title": "catalog import a", "release_date": "2024-03-01", "genres": ["冒险", "科幻"], "duration_minutes": 120},
+		{"title": "Catalog Import B", "release_date": "2024-05-20", "genres": ["剧情"], "duration_minutes": 100}
+	]`
+	resp, body = ts.DoRawRequest(t, http.MethodPost, "/api/v1/admin/movies/import?dry_run=true", "application/json", []byte(jsonFeed), ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	testutils.ParseResponse(t, body, &importResp)
+	assert.Equal(t, response.ImportMoviesSummary{Total: 2, Updated: 1, Unchanged: 1}, importResp.Summary)
+	if assert.Len(t, importResp.Rows, 2) {
+		assert.Equal(t, response.ImportActionUnchanged, importResp.Rows[0].Action)
+		assert.Equal(t, movieA, importResp.Rows[0].MovieID)
+		assert.Empty(t, importResp.Rows[0].Changes)
+
+		assert.Equal(t, response.ImportActionUpdate, importResp.Rows[1].Action)
+		assert.Equal(t, movieB, importResp.Rows[1].MovieID)
+		if assert.Len(t, importResp.Rows[1].Changes, 1) {
+			assert.Equal(t, "duration_minutes", importResp.Rows[1].Changes[0].Field)
+			assert.Equal(t, "95", importResp.Rows[1].Changes[0].Old)
+			assert.Equal(t, "100", importResp.Rows[1].Changes[0].New)
+		}
+	}
+
+	// 5. 片单整体无法解析
+	resp, body = ts.DoRawRequest(t, http.MethodPost, "/api/v1/admin/movies/import", "application/json", []byte(`{"title": "x"}`), ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusBadRequest, resp.StatusCode, body)
+
+	resp, body = ts.DoRawRequest(t, http.MethodPost, "/api/v1/admin/movies/import?format=csv", "text/csv", []byte("title,genres\nA,剧情\n"), ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusBadRequest, resp.StatusCode, body)
+}
+
+// 按标题与生命周期状态查询电影列表
+func listMoviesByStatus(t *testing.T, ts *testutils.TestServer, title, status string) []*response.MovieSimpleResponse {
+	listReq := request.ListMovieRequest{
+		PaginationRequest: request.PaginationRequest{Page: 1, PageSize: 10},
+		Title:             title,
+		Status:            status,
+	}
+	resp, body := ts.DoRequest(t, http.MethodGet, "/api/v1/movies", listReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	var listResp response.PaginatedMovieResponse
+	testutils.ParseResponse(t, body, &listResp)
+	return listResp.Movies
+}
+
+func TestMovieLifecycleFlow(t *testing.T) {
+	// 初始化测试服务器
+	ts := testutils.NewTestServer(t)
+	defer ts.Close()
+
+	logger := ts.Logger.With(applog.String("Test", "TestMovieLifecycleFlow"))
+
+	ts.AdminToken = ts.Login(t, "admin", "admin123")
+
+	// 1. 新电影从即将上映开始
+	createHallReq := request.CreateCinemaHallRequest{
+		Name:        "生命周期测试厅",
+		ScreenType:  "2D",
+		SoundSystem: "Dolby 5.1",
+		Seats:       []*request.SeatRequest{{RowIdentifier: "A", SeatNumber: "1", Type: "STANDARD"}},
+	}
+	resp, body := ts.DoRequest(t, http.MethodPost, "/api/v1/admin/cinema-halls", createHallReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var hallResp response.CinemaHallResponse
+	testutils.ParseResponse(t, body, &hallResp)
+
+	createMovieReq := request.CreateMovieRequest{
+		Title:           "生命周期测试电影",
+		Description:     "用于测试上映与下映",
+		GenreNames:      []string{"剧情"},
+		DurationMinutes: 100,
+		ReleaseDate:     time.Now(),
+		Cast:            "演员1",
+		AgeRating:       "G",
+		Rating:          7.0,
+	}
+	resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/admin/movies", createMovieReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var movieResp response.MovieResponse
+	testutils.ParseResponse(t, body, &movieResp)
+	assert.Equal(t, "coming_soon", movieResp.Status)
+	movieID := movieResp.ID
+
+	startTime := time.Now().Add(24 * time.Hour)
+	createShowtimeReq := request.CreateShowtimeRequest{
+		MovieID:      movieID,
+		CinemaHallID: hallResp.ID,
+		StartTime:    startTime,
+		EndTime:      startTime.Add(2 * time.Hour),
+		Price:        50.0,
+	}
+	resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/admin/showtimes", createShowtimeReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var showtimeResp response.ShowtimeResponse
+	testutils.ParseResponse(t, body, &showtimeResp)
+
+	// 2. 仍有未结束的场次时不能归档
+	archivePath := fmt.Sprintf("/api/v1/admin/movies/%d/archive", movieID)
+	restorePath := fmt.Sprintf("/api/v1/admin/movies/%d/restore", movieID)
+	resp, body = ts.DoRequest(t, http.MethodPost, archivePath, nil, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusConflict, resp.StatusCode, body)
+
+	// 3. 删除场次后可以归档，重复归档直接返回
+	resp, body = ts.DoRequest(t, http.MethodDelete, fmt.Sprintf("/api/v1/admin/showtimes/%d", showtimeResp.ID), nil, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusNoContent, resp.StatusCode, body)
+
+	for i := 0; i < 2; i++ {
+		resp, body = ts.DoRequest(t, http.MethodPost, archivePath, nil, ts.AdminToken)
+		testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+		testutils.ParseResponse(t, body, &movieResp)
+		assert.Equal(t, "archived", movieResp.Status)
+	}
+	logger.Info("movie archived", applog.Uint("movie_id", movieID))
+
+	// 4. 已归档的电影不能排片
+	resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/admin/showtimes", createShowtimeReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusConflict, resp.StatusCode, body)
+
+	// 5. 默认列表不包含已归档的电影，可按状态查询
+	assert.Empty(t, listMoviesByStatus(t, ts, createMovieReq.Title, ""))
+	if movies := listMoviesByStatus(t, ts, createMovieReq.Title, "archived"); assert.Len(t, movies, 1) {
+		assert.Equal(t, movieID, movies[0].ID)
+	}
+	assert.Len(t, listMoviesByStatus(t, ts, createMovieReq.Title, "all"), 1)
+	assert.Empty(t, listMoviesByStatus(t, ts, createMovieReq.Title, "now_showing"))
+
+	// 6. 恢复后回到即将上映，可以重新排片；未归档的电影不能恢复
+	resp, body = ts.DoRequest(t, http.MethodPost, restorePath, nil, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	testutils.ParseResponse(t, body, &movieResp)
+	assert.Equal(t, "coming_soon", movieResp.Status)
+
+	resp, body = ts.DoRequest(t, http.MethodPost, restorePath, nil, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusConflict, resp.StatusCode, body)
+
+	resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/admin/showtimes", createShowtimeReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+
+	// 7. 不存在的电影返回 404，普通用户不能归档
+	resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/admin/movies/999999/archive", nil, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusNotFound, resp.StatusCode, body)
+
+	ts.UserToken = ts.Login(t, "user", "user123")
+	resp, body = ts.DoRequest(t, http.MethodPost, archivePath, nil, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusForbidden, resp.StatusCode, body)
+}
@@ -0,0 +1,145 @@
+package test
+
+import (
+	"fmt"
+	"mrs/internal/api/dto/request"
+	"mrs/internal/api/dto/response"
+	"mrs/internal/domain/recommendation"
+	applog "mrs/pkg/log"
+	"mrs/test/e2e/testutils"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 查询当前用户的推荐列表
+func getRecommendations(t *testing.T, ts *testutils.TestServer, query string, token string) response.RecommendationListResponse {
+	resp, body := ts.DoRequest(t, http.MethodGet, "/api/v1/users/me/recommendations"+query, nil, token)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	var listResp response.RecommendationListResponse
+	testutils.ParseResponse(t, body, &listResp)
+	return listResp
+}
+
+func recommendedMovieIDs(listResp response.RecommendationListResponse) []uint {
+	ids := make([]uint, len(listResp.Recommendations))
+	for i, rec := range listResp.Recommendations {
+		ids[i] = rec.Movie.ID
+	}
+	return ids
+}
+
+func TestRecommendationFlow(t *testing.T) {
+	// 初始化测试服务器
+	ts := testutils.NewTestServer(t)
+	defer ts.Close()
+
+	logger := ts.Logger.With(applog.String("Test", "TestRecommendationFlow"))
+
+	// 1. 管理员创建影厅，以及三部正在上映的电影和已开始的场次
+	ts.AdminToken = ts.Login(t, "admin", "admin123")
+
+	createHallReq := request.CreateCinemaHallRequest{
+		Name:        "推荐测试厅",
+		ScreenType:  "2D",
+		SoundSystem: "Dolby 5.1",
+		Seats: []*request.SeatRequest{
+			{RowIdentifier: "A", SeatNumber: "1", Type: "STANDARD"},
+			{RowIdentifier: "A", SeatNumber: "2", Type: "STANDARD"},
+		},
+	}
+	resp, body := ts.DoRequest(t, http.MethodPost, "/api/v1/admin/cinema-halls", createHallReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var hallResp response.CinemaHallResponse
+	testutils.ParseResponse(t, body, &hallResp)
+
+	createMovieWithShowtime := func(title string, genre string) (uint, uint) {
+		createMovieReq := request.CreateMovieRequest{
+			Title:           title,
+			Description:     "用于测试推荐",
+			GenreNames:      []string{genre},
+			DurationMinutes: 120,
+			ReleaseDate:     time.Now().AddDate(0, 0, -7),
+			Cast:            "演员1",
+			AgeRating:       "G",
+			Rating:          7.0,
+		}
+		resp, body := ts.DoRequest(t, http.MethodPost, "/api/v1/admin/movies", createMovieReq, ts.AdminToken)
+		testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+		var movieResp response.MovieResponse
+		testutils.ParseResponse(t, body, &movieResp)
+
+		startTime := time.Now().Add(-30 * time.Minute)
+		createShowtimeReq := request.CreateShowtimeRequest{
+			MovieID:      movieResp.ID,
+			CinemaHallID: hallResp.ID,
+			StartTime:    startTime,
+			EndTime:      startTime.Add(2 * time.Hour),
+			Price:        60.0,
+		}
+		resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/admin/showtimes", createShowtimeReq, ts.AdminToken)
+		testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+		var showtimeResp response.ShowtimeResponse
+		testutils.ParseResponse(t, body, &showtimeResp)
+		return movieResp.ID, showtimeResp.ID
+	}
+	movieA, showtimeA := createMovieWithShowtime("推荐测试电影A", "剧情")
+	movieB, showtimeB := createMovieWithShowtime("推荐测试电影B", "剧情")
+	createMovieWithShowtime("推荐测试电影C", "喜剧")
+
+	bookAndConfirm := func(showtimeID uint, seatID uint, token string) {
+		createBookingReq := request.CreateBookingRequest{ShowtimeID: showtimeID, SeatIDs: []uint{seatID}}
+		resp, body := ts.DoRequest(t, http.MethodPost, "/api/v1/bookings", createBookingReq, token)
+		testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+		var bookingResp response.BookingResponse
+		testutils.ParseResponse(t, body, &bookingResp)
+		resp, body = ts.DoRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/bookings/%d/confirm", bookingResp.ID), nil, token)
+		testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	}
+
+	// 2. 管理员看过 A 和 B，两部电影成为近期热门
+	bookAndConfirm(showtimeA, hallResp.Seats[0].ID, ts.AdminToken)
+	bookAndConfirm(showtimeB, hallResp.Seats[0].ID, ts.AdminToken)
+
+	// 3. 没有订票记录的新用户得到热门推荐，没有售出的电影不推荐
+	registerReq := request.RegisterUserRequest{
+		Username: "recuser",
+		Password: "Test@123456",
+		Email:    "recuser@example.com",
+	}
+	resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/users/register", registerReq, "")
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	newUserToken := ts.Login(t, registerReq.Username, registerReq.Password)
+
+	listResp := getRecommendations(t, ts, "", newUserToken)
+	assert.Equal(t, recommendation.SourcePopular, listResp.Source)
+	assert.Equal(t, []uint{movieA, movieB}, recommendedMovieIDs(listResp))
+	for _, rec := range listResp.Recommendations {
+		assert.Equal(t, []string{recommendation.ReasonPopular}, rec.Reasons)
+	}
+
+	// limit 限制返回数量，超出上限时返回 400
+	listResp = getRecommendations(t, ts, "?limit=1", newUserToken)
+	assert.Equal(t, []uint{movieA}, recommendedMovieIDs(listResp))
+	resp, body = ts.DoRequest(t, http.MethodGet, "/api/v1/users/me/recommendations?limit=51", nil, newUserToken)
+	testutils.AssertResponseCode(t, http.StatusBadRequest, resp.StatusCode, body)
+
+	// 4. 看过 A 的用户得到个性化推荐：B 与 A 有共同观众且类型相同，排除已看过的 A
+	ts.UserToken = ts.Login(t, "user", "user123")
+	bookAndConfirm(showtimeA, hallResp.Seats[1].ID, ts.UserToken)
+
+	listResp = getRecommendations(t, ts, "", ts.UserToken)
+	assert.Equal(t, recommendation.SourcePersonalized, listResp.Source)
+	if assert.Equal(t, []uint{movieB}, recommendedMovieIDs(listResp)) {
+		assert.Equal(t, []string{recommendation.ReasonSimilarAudience, recommendation.ReasonGenre},
+			listResp.Recommendations[0].Reasons)
+		assert.Greater(t, listResp.Recommendations[0].Score, 0.0)
+	}
+	logger.Info("personalized recommendations", applog.Int("count", len(listResp.Recommendations)))
+
+	// 5. 未登录不能查询推荐
+	resp, body = ts.DoRequest(t, http.MethodGet, "/api/v1/users/me/recommendations", nil, "")
+	testutils.AssertResponseCode(t, http.StatusUnauthorized, resp.StatusCode, body)
+}
@@ -148,3 +148,560 @@ func TestGenerateSalesReport(t *testing.T) {
 	resp, _ = ts.DoRequest(t, http.MethodGet, "/api/v1/admin/reports/sales", reportReq, ts.UserToken)
 	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
 }
+
+func TestOccupancyReportSellableCapacity(t *testing.T) {
+	ts := testutils.NewTestServer(t)
+	defer ts.Close()
+
+	ts.AdminToken = ts.Login(t, "admin", "admin123")
+	ts.UserToken = ts.Login(t, "user", "user123")
+
+	// 1. 准备测试数据：4个座位的影厅与两个场次
+	hallReq := request.CreateCinemaHallRequest{
+		Name:        "Occupancy Hall",
+		ScreenType:  "2D",
+		SoundSystem: "Dolby",
+		Seats: []*request.SeatRequest{
+			{RowIdentifier: "A", SeatNumber: "1", Type: "standard"},
+			{RowIdentifier: "A", SeatNumber: "2", Type: "standard"},
+			{RowIdentifier: "A", SeatNumber: "3", Type: "standard"},
+			{RowIdentifier: "A", SeatNumber: "4", Type: "standard"},
+		},
+	}
+	resp, body := ts.DoRequest(t, http.MethodPost, "/api/v1/admin/cinema-halls", hallReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var hallResp response.CinemaHallResponse
+	testutils.ParseResponse(t, body, &hallResp)
+	seatIDs := make([]uint, len(hallReq.Seats))
+	for i, seat := range hallReq.Seats {
+		seatIDs[i] = findSeatID(hallResp.Seats, seat.RowIdentifier, seat.SeatNumber)
+		assert.NotZero(t, seatIDs[i], "seat %s%s", seat.RowIdentifier, seat.SeatNumber)
+	}
+
+	movieReq := request.CreateMovieRequest{
+		Title:           "Occupancy Movie",
+		GenreNames:      []string{"Drama"},
+		Description:     "Occupancy Description",
+		ReleaseDate:     time.Now(),
+		DurationMinutes: 100,
+		Rating:          7.5,
+		AgeRating:       "G",
+		Cast:            "Actor 1",
+	}
+	resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/admin/movies", movieReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var movieResp response.MovieResponse
+	testutils.ParseResponse(t, body, &movieResp)
+
+	firstStart := time.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	showtimeIDs := make([]uint, 2)
+	for i := range showtimeIDs {
+		start := firstStart.Add(time.Duration(i) * 24 * time.Hour)
+		showtimeReq := request.CreateShowtimeRequest{
+			MovieID:      movieResp.ID,
+			CinemaHallID: hallResp.ID,
+			StartTime:    start,
+			EndTime:      start.Add(2 * time.Hour),
+			Price:        40.0,
+		}
+		resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/admin/showtimes", showtimeReq, ts.AdminToken)
+		testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+		var showtimeResp response.ShowtimeResponse
+		testutils.ParseResponse(t, body, &showtimeResp)
+		showtimeIDs[i] = showtimeResp.ID
+	}
+
+	// 2. A4 只在第一个场次期间维修
+	restrictionPath := fmt.Sprintf("/api/v1/admin/cinema-halls/%d/seat-restrictions", hallResp.ID)
+	startsAt, endsAt := firstStart.Add(-time.Hour), firstStart.Add(3*time.Hour)
+	restrictionReq := request.CreateSeatRestrictionsRequest{
+		SeatIDs:  []uint{seatIDs[3]},
+		Kind:     "OUT_OF_SERVICE",
+		StartsAt: &startsAt,
+		EndsAt:   &endsAt,
+	}
+	resp, body = ts.DoRequest(t, http.MethodPost, restrictionPath, restrictionReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+
+	// 3. 第一个场次售出 A1、A2
+	bookingReq := request.CreateBookingRequest{ShowtimeID: showtimeIDs[0], SeatIDs: seatIDs[:2]}
+	resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/bookings", bookingReq, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var bookingResp response.BookingResponse
+	testutils.ParseResponse(t, body, &bookingResp)
+	resp, body = ts.DoRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/bookings/%d/confirm", bookingResp.ID), nil, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+
+	// 4. 售出后 A1 被长期封锁：第一个场次已售出的 A1 仍计入容量
+	restrictionReq = request.CreateSeatRestrictionsRequest{SeatIDs: []uint{seatIDs[0]}, Kind: "BLOCKED"}
+	resp, body = ts.DoRequest(t, http.MethodPost, restrictionPath, restrictionReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+
+	// 5. 容量只计可售座位
+	reportReq := request.GenerateOccupancyReportRequest{CinemaHallID: hallResp.ID}
+	resp, body = ts.DoRequest(t, http.MethodGet, "/api/v1/admin/reports/occupancy", reportReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	var reportResp response.GenerateOccupancyReportResponse
+	testutils.ParseResponse(t, body, &reportResp)
+
+	if assert.Len(t, reportResp.Showtimes, 2) {
+		first, second := reportResp.Showtimes[0], reportResp.Showtimes[1]
+		assert.Equal(t, showtimeIDs[0], first.ShowtimeID)
+		assert.Equal(t, 3, first.Capacity) // 排除维修中的 A4
+		assert.Equal(t, 2, first.SeatsSold)
+		assert.InDelta(t, 2.0/3.0, first.LoadFactor, 1e-9)
+		assert.InDelta(t, 40.0, first.EmptySeatRevenue, 1e-9)
+
+		assert.Equal(t, showtimeIDs[1], second.ShowtimeID)
+		assert.Equal(t, 3, second.Capacity) // 排除长期封锁的 A1，A4 的维修已结束
+		assert.Equal(t, 0, second.SeatsSold)
+	}
+	if assert.NotNil(t, reportResp.Summary) {
+		assert.Equal(t, 6, reportResp.Summary.Capacity)
+		assert.Equal(t, 2, reportResp.Summary.SeatsSold)
+	}
+}
+
+func TestSalesReportSeries(t *testing.T) {
+	ts := testutils.NewTestServer(t)
+	defer ts.Close()
+
+	ts.AdminToken = ts.Login(t, "admin", "admin123")
+	ts.UserToken = ts.Login(t, "user", "user123")
+
+	// 报表时区为半小时偏移的 Asia/Kolkata，SQL 按15分钟时间桶聚合后再合并
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	if !assert.NoError(t, err) {
+		return
+	}
+	nowInKolkata := time.Now().In(kolkata)
+	today := time.Date(nowInKolkata.Year(), nowInKolkata.Month(), nowInKolkata.Day(), 0, 0, 0, 0, kolkata)
+	tomorrow := today.AddDate(0, 0, 1)
+
+	// 1. 准备测试数据：两部电影在明天（报表时区）各有一个场次
+	hallReq := request.CreateCinemaHallRequest{
+		Name:        "Sales Series Hall",
+		ScreenType:  "2D",
+		SoundSystem: "Dolby",
+		Seats: []*request.SeatRequest{
+			{RowIdentifier: "A", SeatNumber: "1", Type: "standard"},
+			{RowIdentifier: "A", SeatNumber: "2", Type: "standard"},
+			{RowIdentifier: "A", SeatNumber: "3", Type: "standard"},
+		},
+	}
+	resp, body := ts.DoRequest(t, http.MethodPost, "/api/v1/admin/cinema-halls", hallReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var hallResp response.CinemaHallResponse
+	testutils.ParseResponse(t, body, &hallResp)
+
+	movieIDs := make([]uint, 2)
+	showtimeIDs := make([]uint, 2)
+	prices := []float64{50.0, 80.0}
+	for i, title := range []string{"Sales Series Movie A", "Sales Series Movie B"} {
+		movieReq := request.CreateMovieRequest{
+			Title:           title,
+			GenreNames:      []string{"Drama"},
+			Description:     "Sales Series Description",
+			ReleaseDate:     time.Now(),
+			DurationMinutes: 100,
+			Rating:          7.5,
+			AgeRating:       "G",
+			Cast:            "Actor 1",
+		}
+		resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/admin/movies", movieReq, ts.AdminToken)
+		testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+		var movieResp response.MovieResponse
+		testutils.ParseResponse(t, body, &movieResp)
+		movieIDs[i] = movieResp.ID
+
+		start := tomorrow.Add(time.Duration(10+4*i) * time.Hour)
+		showtimeReq := request.CreateShowtimeRequest{
+			MovieID:      movieResp.ID,
+			CinemaHallID: hallResp.ID,
+			StartTime:    start,
+			EndTime:      start.Add(2 * time.Hour),
+			Price:        prices[i],
+		}
+		resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/admin/showtimes", showtimeReq, ts.AdminToken)
+		testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+		var showtimeResp response.ShowtimeResponse
+		testutils.ParseResponse(t, body, &showtimeResp)
+		showtimeIDs[i] = showtimeResp.ID
+	}
+
+	// 电影A售出2张（100元），电影B售出1张（80元）
+	for i, seats := range [][]uint{{hallResp.Seats[0].ID, hallResp.Seats[1].ID}, {hallResp.Seats[2].ID}} {
+		bookingReq := request.CreateBookingRequest{ShowtimeID: showtimeIDs[i], SeatIDs: seats}
+		resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/bookings", bookingReq, ts.UserToken)
+		testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+		var bookingResp response.BookingResponse
+		testutils.ParseResponse(t, body, &bookingResp)
+		resp, body = ts.DoRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/bookings/%d/confirm", bookingResp.ID), nil, ts.UserToken)
+		testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	}
+
+	getReport := func(reportReq request.GenerateSalesReportRequest) response.GenerateSalesReportResponse {
+		resp, body := ts.DoRequest(t, http.MethodGet, "/api/v1/admin/reports/sales", reportReq, ts.AdminToken)
+		testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+		var reportResp response.GenerateSalesReportResponse
+		testutils.ParseResponse(t, body, &reportResp)
+		return reportResp
+	}
+	endOfDay := func(day time.Time) time.Time { return day.AddDate(0, 0, 1).Add(-time.Second) }
+
+	// 2. 按小时的时间序列：报表时区的24个整点周期，没有销售的周期补零
+	reportResp := getReport(request.GenerateSalesReportRequest{
+		StartDate:   today,
+		EndDate:     endOfDay(today),
+		Granularity: "hour",
+		Timezone:    "Asia/Kolkata",
+	})
+	assert.Equal(t, "Asia/Kolkata", reportResp.Timezone)
+	assert.Equal(t, today.Format("2006-01-02"), reportResp.StartDate)
+	assert.Equal(t, 180.0, reportResp.TotalRevenue)
+	assert.Equal(t, 3, reportResp.TotalTickets)
+	if assert.Len(t, reportResp.Series, 24) {
+		revenue, tickets := 0.0, 0
+		for i, point := range reportResp.Series {
+			assert.True(t, point.PeriodStart.Equal(today.Add(time.Duration(i)*time.Hour)), "period %d starts at %v", i, point.PeriodStart)
+			revenue += point.Revenue
+			tickets += point.Tickets
+		}
+		assert.Equal(t, 180.0, revenue)
+		assert.Equal(t, 3, tickets)
+	}
+
+	// 3. 按天的时间序列按电影拆分，拆分按收入降序
+	reportResp = getReport(request.GenerateSalesReportRequest{
+		StartDate:   today,
+		EndDate:     endOfDay(today),
+		Granularity: "day",
+		Timezone:    "Asia/Kolkata",
+		Breakdown:   "movie",
+	})
+	if assert.Len(t, reportResp.Series, 1) {
+		point := reportResp.Series[0]
+		assert.True(t, point.PeriodStart.Equal(today))
+		assert.Equal(t, 180.0, point.Revenue)
+		assert.Equal(t, 2, point.Bookings)
+		if assert.Len(t, point.Breakdown, 2) {
+			assert.Equal(t, movieIDs[0], point.Breakdown[0].ID)
+			assert.Equal(t, 100.0, point.Breakdown[0].Revenue)
+			assert.Equal(t, movieIDs[1], point.Breakdown[1].ID)
+			assert.Equal(t, 80.0, point.Breakdown[1].Revenue)
+		}
+	}
+	if assert.Len(t, reportResp.ByMovie, 2) {
+		assert.Equal(t, movieIDs[0], reportResp.ByMovie[0].ID)
+		assert.Equal(t, 2, reportResp.ByMovie[0].Tickets)
+	}
+
+	// 4. 按场次开始时间统计：今天没有场次，明天的场次计入
+	reportResp = getReport(request.GenerateSalesReportRequest{
+		StartDate: today,
+		EndDate:   endOfDay(today),
+		DateBasis: "showtime",
+		Timezone:  "Asia/Kolkata",
+	})
+	assert.Equal(t, "showtime", reportResp.DateBasis)
+	assert.Equal(t, 0.0, reportResp.TotalRevenue)
+	reportResp = getReport(request.GenerateSalesReportRequest{
+		StartDate:   tomorrow,
+		EndDate:     endOfDay(tomorrow),
+		DateBasis:   "showtime",
+		Granularity: "hour",
+		Timezone:    "Asia/Kolkata",
+	})
+	assert.Equal(t, 180.0, reportResp.TotalRevenue)
+	if assert.Len(t, reportResp.Series, 24) {
+		assert.Equal(t, 100.0, reportResp.Series[10].Revenue)
+		assert.Equal(t, 80.0, reportResp.Series[14].Revenue)
+	}
+
+	// 5. 与上一周期对比：上一周期没有销售时变化比例为 null
+	reportResp = getReport(request.GenerateSalesReportRequest{
+		StartDate: today,
+		EndDate:   endOfDay(today),
+		Timezone:  "Asia/Kolkata",
+		Compare:   true,
+	})
+	if assert.NotNil(t, reportResp.PreviousPeriod) {
+		assert.WithinDuration(t, today.AddDate(0, 0, -1), reportResp.PreviousPeriod.StartDate, time.Second)
+		assert.True(t, reportResp.PreviousPeriod.EndDate.Before(today))
+		assert.Equal(t, 0.0, reportResp.PreviousPeriod.TotalRevenue)
+		assert.Nil(t, reportResp.PreviousPeriod.RevenueChange)
+	}
+
+	// 6. 参数错误返回400
+	for _, reportReq := range []request.GenerateSalesReportRequest{
+		{Timezone: "Mars/Olympus"},
+		{StartDate: today, EndDate: today.Add(-time.Hour)},
+		{Compare: true},
+		{Granularity: "year"},
+		{StartDate: today.AddDate(-1, 0, 0), EndDate: today, Granularity: "hour"},
+	} {
+		resp, body = ts.DoRequest(t, http.MethodGet, "/api/v1/admin/reports/sales", reportReq, ts.AdminToken)
+		testutils.AssertResponseCode(t, http.StatusBadRequest, resp.StatusCode, body)
+	}
+}
+
+func TestSalesReportDailyFacts(t *testing.T) {
+	ts := testutils.NewTestServer(t)
+	defer ts.Close()
+
+	ts.AdminToken = ts.Login(t, "admin", "admin123")
+	ts.UserToken = ts.Login(t, "user", "user123")
+
+	// 1. 准备测试数据：明天的一个场次，一个已确认订单（2张票）与一个待支付订单
+	hallReq := request.CreateCinemaHallRequest{
+		Name:        "Daily Sales Hall",
+		ScreenType:  "2D",
+		SoundSystem: "Dolby",
+		Seats: []*request.SeatRequest{
+			{RowIdentifier: "A", SeatNumber: "1", Type: "standard"},
+			{RowIdentifier: "A", SeatNumber: "2", Type: "standard"},
+			{RowIdentifier: "A", SeatNumber: "3", Type: "standard"},
+		},
+	}
+	resp, body := ts.DoRequest(t, http.MethodPost, "/api/v1/admin/cinema-halls", hallReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var hallResp response.CinemaHallResponse
+	testutils.ParseResponse(t, body, &hallResp)
+
+	movieReq := request.CreateMovieRequest{
+		Title:           "Daily Sales Movie",
+		GenreNames:      []string{"Drama"},
+		Description:     "Daily Sales Description",
+		ReleaseDate:     time.Now(),
+		DurationMinutes: 100,
+		Rating:          7.5,
+		AgeRating:       "G",
+		Cast:            "Actor 1",
+	}
+	resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/admin/movies", movieReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var movieResp response.MovieResponse
+	testutils.ParseResponse(t, body, &movieResp)
+
+	showtimeStart := time.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	showtimeReq := request.CreateShowtimeRequest{
+		MovieID:      movieResp.ID,
+		CinemaHallID: hallResp.ID,
+		StartTime:    showtimeStart,
+		EndTime:      showtimeStart.Add(2 * time.Hour),
+		Price:        60.0,
+	}
+	resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/admin/showtimes", showtimeReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var showtimeResp response.ShowtimeResponse
+	testutils.ParseResponse(t, body, &showtimeResp)
+
+	bookingReq := request.CreateBookingRequest{ShowtimeID: showtimeResp.ID, SeatIDs: []uint{hallResp.Seats[0].ID, hallResp.Seats[1].ID}}
+	resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/bookings", bookingReq, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var bookingResp response.BookingResponse
+	testutils.ParseResponse(t, body, &bookingResp)
+	resp, body = ts.DoRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/bookings/%d/confirm", bookingResp.ID), nil, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+
+	bookingReq = request.CreateBookingRequest{ShowtimeID: showtimeResp.ID, SeatIDs: []uint{hallResp.Seats[2].ID}}
+	resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/bookings", bookingReq, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+
+	// 整天的时间范围（服务器时区）读取每日销售事实，其他时间范围实时汇总订单表，两者结果一致
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	showtimeDay := time.Date(showtimeStart.Year(), showtimeStart.Month(), showtimeStart.Day(), 0, 0, 0, 0, time.Local)
+	reportRequests := map[string]request.GenerateSalesReportRequest{
+		"daily facts":   {StartDate: today, EndDate: today.AddDate(0, 0, 1).Add(-time.Second)},
+		"daily series":  {StartDate: today, EndDate: today.AddDate(0, 0, 1).Add(-time.Second), Granularity: "day"},
+		"raw bookings":  {StartDate: now.Add(-time.Hour).Truncate(time.Minute).Add(time.Second), EndDate: now.Add(time.Hour)},
+		"showtime date": {StartDate: showtimeDay, EndDate: showtimeDay.AddDate(0, 0, 1).Add(-time.Second), DateBasis: "showtime"},
+	}
+	assertSales := func(stage string, wantRevenue float64, wantBookings, wantTickets int) {
+		for name, reportReq := range reportRequests {
+			resp, body := ts.DoRequest(t, http.MethodGet, "/api/v1/admin/reports/sales", reportReq, ts.AdminToken)
+			testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+			var reportResp response.GenerateSalesReportResponse
+			testutils.ParseResponse(t, body, &reportResp)
+			assert.Equal(t, wantRevenue, reportResp.TotalRevenue, "%s: %s revenue", stage, name)
+			assert.Equal(t, wantBookings, reportResp.TotalBookings, "%s: %s bookings", stage, name)
+			assert.Equal(t, wantTickets, reportResp.TotalTickets, "%s: %s tickets", stage, name)
+			if reportReq.Granularity != "" && assert.Len(t, reportResp.Series, 1, "%s: %s series", stage, name) {
+				assert.Equal(t, wantRevenue, reportResp.Series[0].Revenue, "%s: %s series revenue", stage, name)
+			}
+		}
+	}
+
+	// 2. 订单确认后累加事实，待支付订单不计入
+	assertSales("confirmed", 120.0, 1, 2)
+
+	// 3. 取消场次后已确认订单退款，退款计入原订单的业务日期，净值归零
+	cancelReq := request.CancelShowtimeRequest{Reason: "设备故障"}
+	resp, body = ts.DoRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/admin/showtimes/%d/cancel", showtimeResp.ID), cancelReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	var cancelResp response.CancelShowtimeResponse
+	testutils.ParseResponse(t, body, &cancelResp)
+	assert.Equal(t, 1, cancelResp.RefundedBookings)
+
+	assertSales("refunded", 0.0, 0, 0)
+}
+
+func TestFunnelReport(t *testing.T) {
+	ts := testutils.NewTestServer(t)
+	defer ts.Close()
+
+	ts.AdminToken = ts.Login(t, "admin", "admin123")
+	ts.UserToken = ts.Login(t, "user", "user123")
+
+	// 1. 准备测试数据：同一影厅的两部电影各一个场次
+	hallReq := request.CreateCinemaHallRequest{
+		Name:        "Funnel Hall",
+		ScreenType:  "2D",
+		SoundSystem: "Dolby",
+		Seats: []*request.SeatRequest{
+			{RowIdentifier: "A", SeatNumber: "1", Type: "standard"},
+			{RowIdentifier: "A", SeatNumber: "2", Type: "standard"},
+		},
+	}
+	resp, body := ts.DoRequest(t, http.MethodPost, "/api/v1/admin/cinema-halls", hallReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var hallResp response.CinemaHallResponse
+	testutils.ParseResponse(t, body, &hallResp)
+
+	showtimeStart := time.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	movieIDs := make([]uint, 2)
+	showtimeIDs := make([]uint, 2)
+	for i, title := range []string{"Funnel Movie A", "Funnel Movie B"} {
+		movieReq := request.CreateMovieRequest{
+			Title:           title,
+			GenreNames:      []string{"Drama"},
+			Description:     "Funnel Description",
+			ReleaseDate:     time.Now(),
+			DurationMinutes: 100,
+			Rating:          7.5,
+			AgeRating:       "G",
+			Cast:            "Actor 1",
+		}
+		resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/admin/movies", movieReq, ts.AdminToken)
+		testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+		var movieResp response.MovieResponse
+		testutils.ParseResponse(t, body, &movieResp)
+		movieIDs[i] = movieResp.ID
+
+		startTime := showtimeStart.Add(time.Duration(i) * 3 * time.Hour)
+		showtimeReq := request.CreateShowtimeRequest{
+			MovieID:      movieResp.ID,
+			CinemaHallID: hallResp.ID,
+			StartTime:    startTime,
+			EndTime:      startTime.Add(2 * time.Hour),
+			Price:        60.0,
+		}
+		resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/admin/showtimes", showtimeReq, ts.AdminToken)
+		testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+		var showtimeResp response.ShowtimeResponse
+		testutils.ParseResponse(t, body, &showtimeResp)
+		showtimeIDs[i] = showtimeResp.ID
+	}
+
+	book := func(showtimeID, seatID uint, token string) uint {
+		bookingReq := request.CreateBookingRequest{ShowtimeID: showtimeID, SeatIDs: []uint{seatID}}
+		resp, body := ts.DoRequest(t, http.MethodPost, "/api/v1/bookings", bookingReq, token)
+		testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+		var bookingResp response.BookingResponse
+		testutils.ParseResponse(t, body, &bookingResp)
+		return bookingResp.ID
+	}
+	confirm := func(bookingID uint, token string) {
+		resp, body := ts.DoRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/bookings/%d/confirm", bookingID), nil, token)
+		testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	}
+
+	// 2. 场次 A：用户下单并确认，管理员下单后自行取消
+	confirm(book(showtimeIDs[0], hallResp.Seats[0].ID, ts.UserToken), ts.UserToken)
+	bookingID := book(showtimeIDs[0], hallResp.Seats[1].ID, ts.AdminToken)
+	resp, body = ts.DoRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/bookings/%d/cancel", bookingID), nil, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+
+	// 场次 B：用户的订单待支付，管理员下单并确认，随后取消场次，两个订单都记录取消事件
+	book(showtimeIDs[1], hallResp.Seats[0].ID, ts.UserToken)
+	confirm(book(showtimeIDs[1], hallResp.Seats[1].ID, ts.AdminToken), ts.AdminToken)
+	cancelReq := request.CancelShowtimeRequest{Reason: "设备故障"}
+	resp, body = ts.DoRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/admin/showtimes/%d/cancel", showtimeIDs[1]), cancelReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+
+	// 3. 按影厅查询漏斗：4 个会话，2 个确认；取消的待支付订单计入取消，已确认后退款的会话仍计入确认
+	now := time.Now()
+	reportReq := request.GenerateFunnelReportRequest{
+		CinemaHallID: hallResp.ID,
+		StartDate:    now.Add(-time.Hour),
+		EndDate:      now.Add(time.Hour),
+	}
+	resp, body = ts.DoRequest(t, http.MethodGet, "/api/v1/admin/reports/funnel", reportReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	var reportResp response.GenerateFunnelReportResponse
+	testutils.ParseResponse(t, body, &reportResp)
+
+	summary := reportResp.Summary
+	if !assert.NotNil(t, summary) {
+		return
+	}
+	assert.Equal(t, 4, summary.Sessions)
+	assert.Equal(t, 4, summary.SeatsLocked)
+	assert.Equal(t, 4, summary.BookingsCreated)
+	assert.Equal(t, 2, summary.BookingsConfirmed)
+	assert.Equal(t, 2, summary.BookingsCancelled)
+	assert.Equal(t, 2, summary.Abandoned)
+	assert.Equal(t, 0.5, summary.ConversionRate)
+	assert.Equal(t, 0.5, summary.AbandonmentRate)
+	if assert.NotNil(t, summary.MedianTimeToConfirmSeconds) {
+		assert.GreaterOrEqual(t, *summary.MedianTimeToConfirmSeconds, 0.0)
+	}
+	assert.Equal(t, []string{"booking_expired"}, reportResp.UntrackedStages)
+
+	if assert.Len(t, reportResp.ByMovie, 2) {
+		for i, group := range reportResp.ByMovie {
+			assert.Equal(t, fmt.Sprint(movieIDs[i]), group.Key)
+			assert.Equal(t, 2, group.Sessions)
+			assert.Equal(t, 1, group.BookingsConfirmed)
+			assert.Equal(t, 1, group.BookingsCancelled)
+		}
+		assert.Equal(t, "Funnel Movie A", reportResp.ByMovie[0].Label)
+	}
+	sessions := 0
+	for _, group := range reportResp.ByHour {
+		sessions += group.Sessions
+	}
+	assert.Equal(t, 4, sessions)
+
+	// 按电影过滤
+	reportReq.MovieID = movieIDs[0]
+	resp, body = ts.DoRequest(t, http.MethodGet, "/api/v1/admin/reports/funnel", reportReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	testutils.ParseResponse(t, body, &reportResp)
+	assert.Equal(t, 2, reportResp.Summary.Sessions)
+	assert.Equal(t, 1, reportResp.Summary.BookingsConfirmed)
+
+	// 时间范围之外没有会话
+	reportReq = request.GenerateFunnelReportRequest{
+		CinemaHallID: hallResp.ID,
+		StartDate:    now.AddDate(0, 0, -2),
+		EndDate:      now.AddDate(0, 0, -1),
+	}
+	resp, body = ts.DoRequest(t, http.MethodGet, "/api/v1/admin/reports/funnel", reportReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	testutils.ParseResponse(t, body, &reportResp)
+	assert.Equal(t, 0, reportResp.Summary.Sessions)
+	assert.Nil(t, reportResp.Summary.MedianTimeToConfirmSeconds)
+	assert.Empty(t, reportResp.ByMovie)
+
+	// 4. 导出为 csv 附件
+	reportReq.Format = "csv"
+	resp, body = ts.DoRequest(t, http.MethodGet, "/api/v1/admin/reports/funnel", reportReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	assert.Contains(t, resp.Header.Get("Content-Disposition"), "attachment")
+
+	// 普通用户无权查看
+	resp, body = ts.DoRequest(t, http.MethodGet, "/api/v1/admin/reports/funnel", nil, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusForbidden, resp.StatusCode, body)
+}
@@ -0,0 +1,185 @@
+package test
+
+import (
+	"fmt"
+	"mrs/internal/api/dto/request"
+	"mrs/internal/api/dto/response"
+	"mrs/internal/domain/review"
+	applog "mrs/pkg/log"
+	"mrs/test/e2e/testutils"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 查询电影的评分聚合
+func getMovieReviewStats(t *testing.T, ts *testutils.TestServer, movieID uint) (float64, int) {
+	resp, body := ts.DoRequest(t, http.MethodGet, fmt.Sprintf("/api/v1/movies/%d", movieID), nil, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	var movieResp response.MovieResponse
+	testutils.ParseResponse(t, body, &movieResp)
+	return movieResp.ReviewAverage, movieResp.ReviewCount
+}
+
+func TestMovieReviewModerationFlow(t *testing.T) {
+	// 初始化测试服务器
+	ts := testutils.NewTestServer(t)
+	defer ts.Close()
+
+	logger := ts.Logger.With(applog.String("Test", "TestMovieReviewModerationFlow"))
+
+	// 1. 管理员创建影厅、电影和一个已开始的场次
+	ts.AdminToken = ts.Login(t, "admin", "admin123")
+
+	createHallReq := request.CreateCinemaHallRequest{
+		Name:        "评价测试厅",
+		ScreenType:  "2D",
+		SoundSystem: "Dolby 5.1",
+		Seats: []*request.SeatRequest{
+			{RowIdentifier: "A", SeatNumber: "1", Type: "STANDARD"},
+		},
+	}
+	resp, body := ts.DoRequest(t, http.MethodPost, "/api/v1/admin/cinema-halls", createHallReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var hallResp response.CinemaHallResponse
+	testutils.ParseResponse(t, body, &hallResp)
+
+	createMovieReq := request.CreateMovieRequest{
+		Title:           "评价测试电影",
+		Description:     "用于测试评价审核",
+		GenreNames:      []string{"剧情"},
+		DurationMinutes: 120,
+		ReleaseDate:     time.Now().AddDate(0, 0, -7),
+		Cast:            "演员1",
+		AgeRating:       "G",
+		Rating:          7.0,
+	}
+	resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/admin/movies", createMovieReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var movieResp response.MovieResponse
+	testutils.ParseResponse(t, body, &movieResp)
+	reviewsPath := fmt.Sprintf("/api/v1/movies/%d/reviews", movieResp.ID)
+
+	startTime := time.Now().Add(-30 * time.Minute)
+	createShowtimeReq := request.CreateShowtimeRequest{
+		MovieID:      movieResp.ID,
+		CinemaHallID: hallResp.ID,
+		StartTime:    startTime,
+		EndTime:      startTime.Add(2 * time.Hour),
+		Price:        60.0,
+	}
+	resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/admin/showtimes", createShowtimeReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var showtimeResp response.ShowtimeResponse
+	testutils.ParseResponse(t, body, &showtimeResp)
+
+	// 2. 没有看过电影的用户不能评价
+	ts.UserToken = ts.Login(t, "user", "user123")
+	createReviewReq := request.CreateReviewRequest{Score: 8, Content: "值得一看"}
+	resp, body = ts.DoRequest(t, http.MethodPost, reviewsPath, createReviewReq, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusForbidden, resp.StatusCode, body)
+
+	// 3. 预订并确认已开始的场次后可以评价，新评价待审核
+	createBookingReq := request.CreateBookingRequest{ShowtimeID: showtimeResp.ID, SeatIDs: []uint{hallResp.Seats[0].ID}}
+	resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/bookings", createBookingReq, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var bookingResp response.BookingResponse
+	testutils.ParseResponse(t, body, &bookingResp)
+	resp, body = ts.DoRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/bookings/%d/confirm", bookingResp.ID), nil, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+
+	resp, body = ts.DoRequest(t, http.MethodPost, reviewsPath, createReviewReq, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var reviewResp response.ReviewResponse
+	testutils.ParseResponse(t, body, &reviewResp)
+	assert.Equal(t, string(review.ReviewStatusPending), reviewResp.Status)
+	reviewID := reviewResp.ID
+	logger.Info("review created", applog.Uint("review_id", reviewID))
+
+	// 每人每部电影只能评价一次
+	resp, body = ts.DoRequest(t, http.MethodPost, reviewsPath, createReviewReq, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusConflict, resp.StatusCode, body)
+
+	// 待审核的评价不公开，也不计入评分
+	listReq := request.ListMovieReviewsRequest{PaginationRequest: request.PaginationRequest{Page: 1, PageSize: 10}}
+	resp, body = ts.DoRequest(t, http.MethodGet, reviewsPath, listReq, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	var listResp response.PaginatedReviewResponse
+	testutils.ParseResponse(t, body, &listResp)
+	assert.Empty(t, listResp.Reviews)
+	_, count := getMovieReviewStats(t, ts, movieResp.ID)
+	assert.Equal(t, 0, count)
+
+	// 4. 管理员在待审核队列中看到评价，隐藏未发布的评价失败，审核通过后计入评分
+	queueReq := request.ListReviewsRequest{
+		PaginationRequest: request.PaginationRequest{Page: 1, PageSize: 10},
+		Status:            string(review.ReviewStatusPending),
+	}
+	resp, body = ts.DoRequest(t, http.MethodGet, "/api/v1/admin/reviews", queueReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	testutils.ParseResponse(t, body, &listResp)
+	if assert.Len(t, listResp.Reviews, 1) {
+		assert.Equal(t, reviewID, listResp.Reviews[0].ID)
+	}
+
+	moderate := func(action string, note string, wantCode int) response.ReviewResponse {
+		resp, body := ts.DoRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/admin/reviews/%d/%s", reviewID, action),
+			request.ModerateReviewRequest{Note: note}, ts.AdminToken)
+		testutils.AssertResponseCode(t, wantCode, resp.StatusCode, body)
+		var moderated response.ReviewResponse
+		if wantCode == http.StatusOK {
+			testutils.ParseResponse(t, body, &moderated)
+		}
+		return moderated
+	}
+
+	moderate(request.ReviewActionHide, "剧透", http.StatusConflict)
+	moderated := moderate(request.ReviewActionApprove, "", http.StatusOK)
+	assert.Equal(t, string(review.ReviewStatusPublished), moderated.Status)
+	average, count := getMovieReviewStats(t, ts, movieResp.ID)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, 8.0, average)
+
+	// 5. 不能给自己的评价投票，其他用户投票后计数增加，重复投票失败
+	helpfulPath := fmt.Sprintf("/api/v1/reviews/%d/helpful", reviewID)
+	resp, body = ts.DoRequest(t, http.MethodPost, helpfulPath, nil, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusForbidden, resp.StatusCode, body)
+	resp, body = ts.DoRequest(t, http.MethodPost, helpfulPath, nil, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	testutils.ParseResponse(t, body, &reviewResp)
+	assert.Equal(t, 1, reviewResp.HelpfulCount)
+	resp, body = ts.DoRequest(t, http.MethodPost, helpfulPath, nil, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusConflict, resp.StatusCode, body)
+
+	// 6. 隐藏后不再计入评分，恢复后重新计入
+	moderated = moderate(request.ReviewActionHide, "剧透", http.StatusOK)
+	assert.Equal(t, string(review.ReviewStatusHidden), moderated.Status)
+	assert.Equal(t, "剧透", moderated.ModerationNote)
+	_, count = getMovieReviewStats(t, ts, movieResp.ID)
+	assert.Equal(t, 0, count)
+
+	moderated = moderate(request.ReviewActionRestore, "", http.StatusOK)
+	assert.Equal(t, string(review.ReviewStatusPublished), moderated.Status)
+	assert.Empty(t, moderated.ModerationNote)
+	_, count = getMovieReviewStats(t, ts, movieResp.ID)
+	assert.Equal(t, 1, count)
+
+	// 7. 用户修改评价后重新进入待审核，驳回后不计入评分
+	updateReviewReq := request.UpdateReviewRequest{Score: 4, Content: "二刷感觉一般"}
+	resp, body = ts.DoRequest(t, http.MethodPut, fmt.Sprintf("/api/v1/reviews/%d", reviewID), updateReviewReq, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	testutils.ParseResponse(t, body, &reviewResp)
+	assert.Equal(t, string(review.ReviewStatusPending), reviewResp.Status)
+	_, count = getMovieReviewStats(t, ts, movieResp.ID)
+	assert.Equal(t, 0, count)
+
+	moderated = moderate(request.ReviewActionReject, "内容不符合规范", http.StatusOK)
+	assert.Equal(t, string(review.ReviewStatusRejected), moderated.Status)
+	moderate(request.ReviewActionApprove, "", http.StatusConflict)
+
+	// 8. 普通用户不能审核
+	resp, body = ts.DoRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/admin/reviews/%d/restore", reviewID), nil, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusForbidden, resp.StatusCode, body)
+}
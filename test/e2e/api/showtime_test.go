@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"mrs/internal/api/dto/request"
 	"mrs/internal/api/dto/response"
+	"mrs/internal/domain/booking"
 	"mrs/internal/domain/cinema"
+	"mrs/internal/domain/showtime"
 	applog "mrs/pkg/log"
 	"mrs/test/e2e/testutils"
 	"net/http"
@@ -160,3 +162,162 @@ func TestShowtimeManagementFlow(t *testing.T) {
 	resp, _ = ts.DoRequest(t, http.MethodGet, fmt.Sprintf("/api/v1/showtimes/%d", showtimeID), nil, ts.UserToken)
 	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
 }
+
+func TestShowtimeCancellationFlow(t *testing.T) {
+	// 初始化测试服务器
+	ts := testutils.NewTestServer(t)
+	defer ts.Close()
+
+	logger := ts.Logger.With(applog.String("Test", "TestShowtimeCancellationFlow"))
+
+	// 1. 管理员登录
+	ts.AdminToken = ts.Login(t, "admin", "admin123")
+
+	// 2. 创建影厅、电影和场次
+	createHallReq := request.CreateCinemaHallRequest{
+		Name:        "取消场次测试厅",
+		ScreenType:  "2D",
+		SoundSystem: "Dolby 5.1",
+		Seats: []*request.SeatRequest{
+			{RowIdentifier: "A", SeatNumber: "1", Type: "STANDARD"},
+			{RowIdentifier: "A", SeatNumber: "2", Type: "STANDARD"},
+			{RowIdentifier: "A", SeatNumber: "3", Type: "STANDARD"},
+		},
+	}
+	resp, body := ts.DoRequest(t, http.MethodPost, "/api/v1/admin/cinema-halls", createHallReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var hallResp response.CinemaHallResponse
+	testutils.ParseResponse(t, body, &hallResp)
+	hallID := hallResp.ID
+
+	createMovieReq := request.CreateMovieRequest{
+		Title:           "取消场次测试电影",
+		Description:     "用于测试场次取消",
+		GenreNames:      []string{"剧情"},
+		DurationMinutes: 100,
+		ReleaseDate:     time.Now(),
+		Cast:            "演员1",
+		AgeRating:       "G",
+		Rating:          7.5,
+	}
+	resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/admin/movies", createMovieReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var movieResp response.MovieResponse
+	testutils.ParseResponse(t, body, &movieResp)
+	movieID := movieResp.ID
+
+	startTime := time.Now().Add(48 * time.Hour)
+	createShowtimeReq := request.CreateShowtimeRequest{
+		MovieID:      movieID,
+		CinemaHallID: hallID,
+		StartTime:    startTime,
+		EndTime:      startTime.Add(time.Duration(createMovieReq.DurationMinutes) * time.Minute),
+		Price:        60.0,
+	}
+	resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/admin/showtimes", createShowtimeReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var showtimeResp response.ShowtimeResponse
+	testutils.ParseResponse(t, body, &showtimeResp)
+	showtimeID := showtimeResp.ID
+
+	// 3. 用户预订两单：一单确认支付，一单保持待支付
+	ts.UserToken = ts.Login(t, "user", "user123")
+
+	createBookingReq := request.CreateBookingRequest{
+		ShowtimeID: showtimeID,
+		SeatIDs:    []uint{hallResp.Seats[0].ID, hallResp.Seats[1].ID},
+	}
+	resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/bookings", createBookingReq, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var confirmedBooking response.BookingResponse
+	testutils.ParseResponse(t, body, &confirmedBooking)
+
+	resp, body = ts.DoRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/bookings/%d/confirm", confirmedBooking.ID), nil, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+
+	createBookingReq.SeatIDs = []uint{hallResp.Seats[2].ID}
+	resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/bookings", createBookingReq, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var pendingBooking response.BookingResponse
+	testutils.ParseResponse(t, body, &pendingBooking)
+
+	// 4. 普通用户无权取消场次
+	cancelReq := request.CancelShowtimeRequest{Reason: "设备故障", BatchSize: 1}
+	cancelPath := fmt.Sprintf("/api/v1/admin/showtimes/%d/cancel", showtimeID)
+	resp, body = ts.DoRequest(t, http.MethodPost, cancelPath, cancelReq, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusForbidden, resp.StatusCode, body)
+
+	// 5. 管理员取消场次：已确认订单退款，待支付订单取消，每批处理一单
+	resp, body = ts.DoRequest(t, http.MethodPost, cancelPath, cancelReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	var cancelResp response.CancelShowtimeResponse
+	testutils.ParseResponse(t, body, &cancelResp)
+	assert.Equal(t, showtimeID, cancelResp.ShowtimeID)
+	assert.Equal(t, string(showtime.ShowtimeStatusCancelled), cancelResp.Status)
+	assert.Equal(t, cancelReq.Reason, cancelResp.Reason)
+	assert.False(t, cancelResp.CancelledAt.IsZero())
+	assert.Equal(t, 2, cancelResp.TotalBookings)
+	assert.Equal(t, 1, cancelResp.RefundedBookings)
+	assert.Equal(t, 1, cancelResp.CancelledBookings)
+	assert.Equal(t, confirmedBooking.TotalAmount, cancelResp.RefundAmount)
+	assert.Equal(t, 3, cancelResp.ReleasedSeats)
+	assert.Equal(t, 1, cancelResp.NotifiedUsers)
+	assert.Equal(t, 2, cancelResp.Batches)
+
+	logger.Debug("cancel showtime test", applog.Any("cancelResp", cancelResp))
+
+	// 6. 订单状态已更新
+	resp, body = ts.DoRequest(t, http.MethodGet, fmt.Sprintf("/api/v1/bookings/%d", confirmedBooking.ID), nil, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	var bookingResp response.BookingResponse
+	testutils.ParseResponse(t, body, &bookingResp)
+	assert.Equal(t, string(booking.BookingStatusRefunded), bookingResp.Status)
+
+	resp, body = ts.DoRequest(t, http.MethodGet, fmt.Sprintf("/api/v1/bookings/%d", pendingBooking.ID), nil, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	testutils.ParseResponse(t, body, &bookingResp)
+	assert.Equal(t, string(booking.BookingStatusCanceled), bookingResp.Status)
+
+	// 7. 已取消的场次不能再预订
+	resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/bookings", createBookingReq, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusBadRequest, resp.StatusCode, body)
+
+	// 8. 公开列表不再返回已取消的场次
+	listReq := request.ListShowtimesRequest{
+		MovieID:           movieID,
+		PaginationRequest: request.PaginationRequest{Page: 1, PageSize: 10},
+	}
+	resp, body = ts.DoRequest(t, http.MethodGet, "/api/v1/showtimes", listReq, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	var listResp response.PaginatedShowtimeResponse
+	testutils.ParseResponse(t, body, &listResp)
+	assert.Empty(t, listResp.Showtimes)
+
+	// 9. 管理员列表可按状态过滤
+	adminListReq := request.ListAdminShowtimesRequest{ListShowtimesRequest: listReq, Status: "cancelled"}
+	resp, body = ts.DoRequest(t, http.MethodGet, "/api/v1/admin/showtimes", adminListReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	testutils.ParseResponse(t, body, &listResp)
+	if assert.Len(t, listResp.Showtimes, 1) {
+		assert.Equal(t, showtimeID, listResp.Showtimes[0].ID)
+		assert.Equal(t, string(showtime.ShowtimeStatusCancelled), listResp.Showtimes[0].Status)
+	}
+
+	adminListReq.Status = "scheduled"
+	resp, body = ts.DoRequest(t, http.MethodGet, "/api/v1/admin/showtimes", adminListReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	testutils.ParseResponse(t, body, &listResp)
+	assert.Empty(t, listResp.Showtimes)
+
+	// 10. 重复取消是幂等的，没有剩余订单需要处理
+	resp, body = ts.DoRequest(t, http.MethodPost, cancelPath, nil, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	testutils.ParseResponse(t, body, &cancelResp)
+	assert.Equal(t, string(showtime.ShowtimeStatusCancelled), cancelResp.Status)
+	assert.Equal(t, cancelReq.Reason, cancelResp.Reason)
+	assert.Equal(t, 0, cancelResp.TotalBookings)
+
+	// 11. 取消不存在的场次
+	resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/admin/showtimes/999999/cancel", nil, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusNotFound, resp.StatusCode, body)
+}
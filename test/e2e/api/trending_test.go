@@ -0,0 +1,138 @@
+package test
+
+import (
+	"fmt"
+	"mrs/internal/api/dto/request"
+	"mrs/internal/api/dto/response"
+	applog "mrs/pkg/log"
+	"mrs/test/e2e/testutils"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrendingLeaderboardFlow(t *testing.T) {
+	// 初始化测试服务器
+	ts := testutils.NewTestServer(t)
+	defer ts.Close()
+
+	logger := ts.Logger.With(applog.String("Test", "TestTrendingLeaderboardFlow"))
+
+	// 1. 管理员创建影厅、带英文翻译的电影和一个场次
+	ts.AdminToken = ts.Login(t, "admin", "admin123")
+	ts.UserToken = ts.Login(t, "user", "user123")
+
+	createHallReq := request.CreateCinemaHallRequest{
+		Name:        "热门测试厅",
+		ScreenType:  "2D",
+		SoundSystem: "Dolby 5.1",
+		Seats: []*request.SeatRequest{
+			{RowIdentifier: "A", SeatNumber: "1", Type: "STANDARD"},
+			{RowIdentifier: "A", SeatNumber: "2", Type: "STANDARD"},
+		},
+	}
+	resp, body := ts.DoRequest(t, http.MethodPost, "/api/v1/admin/cinema-halls", createHallReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var hallResp response.CinemaHallResponse
+	testutils.ParseResponse(t, body, &hallResp)
+
+	createMovieReq := request.CreateMovieRequest{
+		Title:           "热门测试电影",
+		Description:     "用于测试热门排行",
+		GenreNames:      []string{"剧情"},
+		DurationMinutes: 120,
+		ReleaseDate:     time.Now().AddDate(0, 0, -7),
+		Cast:            "演员1",
+		AgeRating:       "G",
+		Rating:          7.0,
+	}
+	resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/admin/movies", createMovieReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var movieResp response.MovieResponse
+	testutils.ParseResponse(t, body, &movieResp)
+
+	resp, body = ts.DoRequest(t, http.MethodPut, fmt.Sprintf("/api/v1/admin/movies/%d/translations/en", movieResp.ID),
+		request.UpsertMovieTranslationRequest{Title: "Trending Movie"}, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+
+	startTime := time.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	createShowtimeReq := request.CreateShowtimeRequest{
+		MovieID:      movieResp.ID,
+		CinemaHallID: hallResp.ID,
+		StartTime:    startTime,
+		EndTime:      startTime.Add(2 * time.Hour),
+		Price:        60.0,
+	}
+	resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/admin/showtimes", createShowtimeReq, ts.AdminToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var showtimeResp response.ShowtimeResponse
+	testutils.ParseResponse(t, body, &showtimeResp)
+
+	// 2. 用户预订两个座位并确认，确认时累加热门计数
+	bookingReq := request.CreateBookingRequest{ShowtimeID: showtimeResp.ID, SeatIDs: []uint{hallResp.Seats[0].ID, hallResp.Seats[1].ID}}
+	resp, body = ts.DoRequest(t, http.MethodPost, "/api/v1/bookings", bookingReq, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, body)
+	var bookingResp response.BookingResponse
+	testutils.ParseResponse(t, body, &bookingResp)
+	resp, body = ts.DoRequest(t, http.MethodPost, fmt.Sprintf("/api/v1/bookings/%d/confirm", bookingResp.ID), nil, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+
+	// 3. 热门电影按 Accept-Language 本地化标题
+	findMovie := func(movies []*response.TrendingMovieResponse) *response.TrendingMovieResponse {
+		for _, item := range movies {
+			if item.Movie != nil && item.Movie.ID == movieResp.ID {
+				return item
+			}
+		}
+		return nil
+	}
+	trendingCases := []struct {
+		acceptLanguage string
+		wantTitle      string
+	}{
+		{"en-GB, zh;q=0.5", "Trending Movie"},
+		{"", createMovieReq.Title},
+	}
+	for _, tc := range trendingCases {
+		resp, body = ts.DoRequestWithHeaders(t, http.MethodGet, "/api/v1/movies/trending?window=1h&limit=50", nil, ts.UserToken,
+			map[string]string{"Accept-Language": tc.acceptLanguage})
+		testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+		var trendingResp response.TrendingMoviesResponse
+		testutils.ParseResponse(t, body, &trendingResp)
+		assert.Equal(t, "1h", trendingResp.Window)
+		if item := findMovie(trendingResp.Movies); assert.NotNil(t, item, "Accept-Language: %q", tc.acceptLanguage) {
+			assert.Equal(t, tc.wantTitle, item.Movie.Title, "Accept-Language: %q", tc.acceptLanguage)
+			assert.Equal(t, 2, item.Tickets)
+		}
+	}
+
+	// 4. 排行榜中电影与场次的电影标题都本地化
+	resp, body = ts.DoRequestWithHeaders(t, http.MethodGet, "/api/v1/admin/reports/leaderboard?window=1h&limit=100", nil, ts.AdminToken,
+		map[string]string{"Accept-Language": "en"})
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, body)
+	var leaderboardResp response.LeaderboardResponse
+	testutils.ParseResponse(t, body, &leaderboardResp)
+	if item := findMovie(leaderboardResp.Movies); assert.NotNil(t, item) {
+		assert.Equal(t, "Trending Movie", item.Movie.Title)
+		assert.Equal(t, 2, item.Tickets)
+	}
+	found := false
+	for _, item := range leaderboardResp.Showtimes {
+		if item.Showtime != nil && item.Showtime.ID == showtimeResp.ID {
+			found = true
+			assert.Equal(t, "Trending Movie", item.MovieTitle)
+			assert.Equal(t, createHallReq.Name, item.CinemaHallName)
+			assert.Equal(t, 2, item.Tickets)
+		}
+	}
+	assert.True(t, found)
+	logger.Info("trending leaderboard flow finished", applog.Uint("movie_id", movieResp.ID))
+
+	// 5. 参数校验与权限
+	resp, body = ts.DoRequest(t, http.MethodGet, "/api/v1/movies/trending?window=30d", nil, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusBadRequest, resp.StatusCode, body)
+	resp, body = ts.DoRequest(t, http.MethodGet, "/api/v1/admin/reports/leaderboard", nil, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusForbidden, resp.StatusCode, body)
+}
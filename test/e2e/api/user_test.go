@@ -8,6 +8,7 @@ import (
 	"mrs/test/e2e/testutils"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -138,3 +139,40 @@ func TestUserManagementFlow(t *testing.T) {
 	resp, _ = ts.DoRequest(t, http.MethodDelete, fmt.Sprintf("/api/v1/admin/roles/%d", roleID), nil, ts.AdminToken)
 	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
 }
+
+func TestUserBirthDateFlow(t *testing.T) {
+	ts := testutils.NewTestServer(t)
+	defer ts.Close()
+
+	// 1. 出生日期为 YYYY-MM-DD 时按日历日期保存
+	body := []byte(`{"username":"birthday","password":"Test@123456","email":"birthday@example.com","birth_date":"2008-02-29"}`)
+	resp, respBody := ts.DoRawRequest(t, http.MethodPost, "/api/v1/users/register", "application/json", body, "")
+	testutils.AssertResponseCode(t, http.StatusCreated, resp.StatusCode, respBody)
+
+	ts.UserToken = ts.Login(t, "birthday", "Test@123456")
+	resp, respBody = ts.DoRequest(t, http.MethodGet, "/api/v1/users/me", nil, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, respBody)
+	var profileResp response.UserProfileResponse
+	testutils.ParseResponse(t, respBody, &profileResp)
+	if assert.NotNil(t, profileResp.BirthDate) {
+		assert.Equal(t, "2008-02-29", profileResp.BirthDate.Format(time.DateOnly))
+	}
+
+	// 2. RFC3339 只取其中的日期，不做时区换算
+	body = []byte(`{"birth_date":"2008-03-01T23:30:00-10:00"}`)
+	resp, respBody = ts.DoRawRequest(t, http.MethodPut, "/api/v1/users/me", "application/json", body, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, respBody)
+	resp, respBody = ts.DoRequest(t, http.MethodGet, "/api/v1/users/me", nil, ts.UserToken)
+	testutils.AssertResponseCode(t, http.StatusOK, resp.StatusCode, respBody)
+	testutils.ParseResponse(t, respBody, &profileResp)
+	if assert.NotNil(t, profileResp.BirthDate) {
+		assert.Equal(t, "2008-03-01", profileResp.BirthDate.Format(time.DateOnly))
+	}
+
+	// 3. 格式错误或晚于今天的出生日期返回 400
+	for _, birthDate := range []string{`"2008/02/29"`, `"2008-02-30"`, `"` + time.Now().AddDate(0, 0, 1).Format(time.DateOnly) + `"`} {
+		body = []byte(`{"username":"badbirthday","password":"Test@123456","email":"badbirthday@example.com","birth_date":` + birthDate + `}`)
+		resp, respBody = ts.DoRawRequest(t, http.MethodPost, "/api/v1/users/register", "application/json", body, "")
+		testutils.AssertResponseCode(t, http.StatusBadRequest, resp.StatusCode, respBody)
+	}
+}
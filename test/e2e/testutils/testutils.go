@@ -89,6 +89,11 @@ func (ts *TestServer) Close() {
 
 // DoRequest 发送HTTP请求并返回响应
 func (ts *TestServer) DoRequest(t *testing.T, method, path string, body interface{}, token string) (*http.Response, []byte) {
+	return ts.DoRequestWithHeaders(t, method, path, body, token, nil)
+}
+
+// DoRequestWithHeaders 发送HTTP请求并附加额外的请求头（如 Accept-Language）
+func (ts *TestServer) DoRequestWithHeaders(t *testing.T, method, path string, body interface{}, token string, headers map[string]string) (*http.Response, []byte) {
 	var reqBody io.Reader
 	targetURL := ts.Server.URL + path
 
@@ -117,6 +122,9 @@ func (ts *TestServer) DoRequest(t *testing.T, method, path string, body interfac
 	if body != nil && method != http.MethodGet {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
 
 	ts.Logger.Debug("req", applog.Any("req", req))
 
@@ -130,6 +138,28 @@ func (ts *TestServer) DoRequest(t *testing.T, method, path string, body interfac
 	return resp, respBody
 }
 
+// DoRawRequest 以原始请求体发送HTTP请求（如导入布局文件），path 可包含查询参数
+func (ts *TestServer) DoRawRequest(t *testing.T, method, path, contentType string, body []byte, token string) (*http.Response, []byte) {
+	req, err := http.NewRequest(method, ts.Server.URL+path, bytes.NewReader(body))
+	assert.NoError(t, err)
+
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+
+	respBody, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	return resp, respBody
+}
+
 // Login 执行登录操作并返回token
 func (ts *TestServer) Login(t *testing.T, username, password string) string {
 	loginReq := request.LoginRequest{
@@ -197,11 +227,27 @@ func applyMigrations(db *gorm.DB, logger applog.Logger) {
 		&models.RoleGorm{},
 		&models.MovieGorm{},
 		&models.GenreGorm{},
+		&models.CinemaGorm{},
 		&models.CinemaHallGorm{},
 		&models.SeatGorm{},
+		&models.SeatRestrictionGorm{},
+		&models.HallLayoutVersionGorm{},
 		&models.ShowtimeGorm{},
 		&models.BookingGorm{},
 		&models.BookedSeatGorm{},
+		&models.ReviewGorm{},
+		&models.ReviewVoteGorm{},
+		&models.PersonGorm{},
+		&models.MovieCreditGorm{},
+		&models.MediaAssetGorm{},
+		&models.WatchlistEntryGorm{},
+		&models.MovieTranslationGorm{},
+		&models.GenreTranslationGorm{},
+		&models.DailySalesGorm{},
+		&models.ReportSubscriptionGorm{},
+		&models.ReportRunGorm{},
+		&models.FunnelEventGorm{},
+		&models.LoyaltyEntryGorm{},
 	)
 	if err != nil {
 		logger.Fatal("Database migration failed", applog.Error(err))